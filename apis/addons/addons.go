@@ -4,11 +4,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/apis/addons/v1beta1"
 )
 
 // AddToSchemes may be used to add all resources defined in the project to a Scheme
 var AddToSchemes runtime.SchemeBuilder = runtime.SchemeBuilder{
 	v1alpha1.SchemeBuilder.AddToScheme,
+	v1beta1.SchemeBuilder.AddToScheme,
 }
 
 // AddToScheme adds all addon Resources to the Scheme
@@ -26,8 +26,50 @@ type AddonInstanceStatus struct {
 	// Timestamp of the last reported status check
 	// +optional
 	LastHeartbeatTime metav1.Time `json:"lastHeartbeatTime"`
+	// Bounded history of recent heartbeat gaps and condition transitions, oldest first.
+	// Helps diagnosing intermittent health problems after the fact.
+	// Mirrors the "<name>-history" ConfigMap next to the AddonInstance,
+	// so status updates of clients unaware of this field do not lose it.
+	// +optional
+	History []AddonInstanceHistoryEvent `json:"history,omitempty"`
 }
 
+// AddonInstanceHistoryEvent records a heartbeat gap or condition transition of an AddonInstance.
+type AddonInstanceHistoryEvent struct {
+	// Type of the event.
+	// +kubebuilder:validation:Enum={"HeartbeatGap","ConditionTransition"}
+	Type AddonInstanceHistoryEventType `json:"type"`
+	// Time the event started.
+	Time metav1.MicroTime `json:"time"`
+	// Type of the condition that transitioned.
+	// Only set for ConditionTransition events.
+	// +optional
+	ConditionType string `json:"conditionType,omitempty"`
+	// Status the condition transitioned to.
+	// Only set for ConditionTransition events.
+	// +optional
+	Status metav1.ConditionStatus `json:"status,omitempty"`
+	// Reason of the condition transition.
+	// Only set for ConditionTransition events.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Duration between the last heartbeat before and the first heartbeat after the gap.
+	// Only set for HeartbeatGap events, after heartbeats were received again.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+type AddonInstanceHistoryEventType string
+
+const (
+	// AddonInstanceHistoryEventHeartbeatGap records that no heartbeat
+	// was received before the configured threshold.
+	AddonInstanceHistoryEventHeartbeatGap AddonInstanceHistoryEventType = "HeartbeatGap"
+	// AddonInstanceHistoryEventConditionTransition records that the
+	// status of a condition changed.
+	AddonInstanceHistoryEventConditionTransition AddonInstanceHistoryEventType = "ConditionTransition"
+)
+
 // AddonInstance is a managed service facing interface to get configuration and report status back.
 //
 // **Example**
@@ -74,6 +116,7 @@ type AddonInstanceList struct {
 const (
 	DefaultAddonInstanceName                  = "addon-instance"
 	DefaultAddonInstanceHeartbeatUpdatePeriod = 10 * time.Second
+	DefaultAddonInstanceHistoryLimit          = 20
 )
 
 // AddonInstanceCondition is a condition Type used by AddonInstance
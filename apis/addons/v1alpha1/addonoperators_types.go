@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,6 +19,9 @@ const (
 
 	// Addon operator has resumed reconciliation
 	AddonOperatorReasonUnpaused = "AddonOperatorUnpaused"
+
+	// Addon operator defers Addon installs and upgrades during cluster maintenance
+	AddonOperatorReasonMaintenanceMode = "MaintenanceMode"
 )
 
 // AddonOperatorSpec defines the desired state of Addon operator.
@@ -37,6 +41,151 @@ type AddonOperatorSpec struct {
 	// e.g. push status reporting, etc.
 	// +optional
 	OCM *AddonOperatorOCM `json:"ocm,omitempty"`
+	// Disables all interactions with OCM, e.g. on disconnected or non-OSD clusters.
+	// .spec.ocm is ignored, upgrade policy, status and installation reporting
+	// and parameter syncs are skipped and Manual upgrades need no approval.
+	// +optional
+	OCMDisabled bool `json:"ocmDisabled,omitempty"`
+	// Remote write the addon-operator's own metrics to RHOBS,
+	// labeled with the cluster id.
+	// Requires the MonitoringStack feature toggle.
+	// +optional
+	MetricsRemoteWrite *AddonOperatorMetricsRemoteWrite `json:"metricsRemoteWrite,omitempty"`
+	// Extension hook called before installing or upgrading
+	// and after deleting an Addon, which may veto or annotate the operation.
+	// +optional
+	ExtensionHook *AddonOperatorExtensionHook `json:"extensionHook,omitempty"`
+	// Signals an ongoing cluster maintenance, set by fleet tooling.
+	// Addon installs and upgrades are deferred until the maintenance ends,
+	// while the health of installed Addons continues to be reported.
+	// +optional
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
+	// Requeue intervals of Addons per class of failure.
+	// Classes left unset keep their default behavior.
+	// +optional
+	BackoffPolicy *AddonOperatorBackoffPolicy `json:"backoffPolicy,omitempty"`
+	// Labels and annotations stamped onto every object created for Addons,
+	// e.g. to tag them with a cost center or team fleet-wide.
+	// +optional
+	ObjectMetadata *AddonOperatorObjectMetadata `json:"objectMetadata,omitempty"`
+	// Default PodSecurity admission levels labeled on addon namespaces.
+	// Addons may override them per mode.
+	// +optional
+	PodSecurity *PodSecurityConfig `json:"podSecurity,omitempty"`
+	// Thanos Ruler evaluating the monitoring rules of Addons
+	// with .spec.monitoring.monitoringStack.ruleEvaluation = ThanosRuler.
+	// +optional
+	ThanosRuler *AddonOperatorThanosRuler `json:"thanosRuler,omitempty"`
+	// Dead Man's Snitch account provisioning the snitches of Addons
+	// with .spec.monitoring.monitoringStack.deadMansSnitch.
+	// +optional
+	DeadMansSnitch *AddonOperatorDeadMansSnitch `json:"deadMansSnitch,omitempty"`
+	// PagerDuty account provisioning the services of Addons
+	// with .spec.monitoring.pagerDuty.
+	// +optional
+	PagerDuty *AddonOperatorPagerDuty `json:"pagerDuty,omitempty"`
+	// Maximum number of Addons upgrading at the same time.
+	// Only upgrades scheduled by an OCM upgrade policy are limited,
+	// while the maintenance windows of their Addons are open.
+	// Further upgrades are queued in the order they were requested,
+	// so they do not overlap within the same upgrade window.
+	// 0 does not limit concurrent upgrades.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrentAddonUpgrades int `json:"maxConcurrentAddonUpgrades,omitempty"`
+}
+
+// PagerDuty account provisioning the services of Addons.
+type AddonOperatorPagerDuty struct {
+	// Secret holding the PagerDuty REST API key in its "api-key" key.
+	APIKeySecret ClusterSecretReference `json:"apiKeySecret"`
+	// Escalation policy of services, unless overridden by the Addon.
+	// +optional
+	EscalationPolicyID string `json:"escalationPolicyID,omitempty"`
+}
+
+// Dead Man's Snitch account provisioning the snitches of Addons.
+type AddonOperatorDeadMansSnitch struct {
+	// Secret holding the Dead Man's Snitch API token in its "api-token" key.
+	APITokenSecret ClusterSecretReference `json:"apiTokenSecret"`
+}
+
+// Thanos Ruler instance centralizing the rule evaluation of Addons.
+type AddonOperatorThanosRuler struct {
+	// Namespace watched by the Thanos Ruler for PrometheusRules.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Labels matching the ruleSelector of the Thanos Ruler,
+	// added to the PrometheusRules of Addons.
+	// +optional
+	RuleSelector map[string]string `json:"ruleSelector,omitempty"`
+}
+
+// Labels and annotations stamped onto every object created for Addons.
+// The labels and annotations of the objects themselves take precedence,
+// as do the common labels and annotations configured on an Addon.
+type AddonOperatorObjectMetadata struct {
+	// Labels to be applied to all resources.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations to be applied to all resources.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Requeue intervals of Addons per class of failure.
+type AddonOperatorBackoffPolicy struct {
+	// Interval to retry at, when syncing the Addon status with OCM failed.
+	// Defaults to the exponential backoff of the controller.
+	// +optional
+	OCMError *metav1.Duration `json:"ocmError,omitempty"`
+	// Interval to recheck at, while OLM objects of the Addon are not ready yet.
+	// Defaults to the retry interval of the addon-operator.
+	// +optional
+	OLMNotReady *metav1.Duration `json:"olmNotReady,omitempty"`
+	// Interval to retry at, when the Addon configuration failed validation.
+	// Defaults to not retrying until the Addon changes.
+	// +optional
+	ValidationFailure *metav1.Duration `json:"validationFailure,omitempty"`
+}
+
+// Remote write configuration for the addon-operator's own metrics.
+type AddonOperatorMetricsRemoteWrite struct {
+	// RHOBS endpoint to send the metrics to.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// OAuth2 config for the remote write URL.
+	// +optional
+	OAuth2 *monv1.OAuth2 `json:"oauth2,omitempty"`
+}
+
+// Policy applied when calling the extension hook fails.
+type ExtensionHookFailurePolicy string
+
+const (
+	// Treat a failed extension hook call as a veto.
+	ExtensionHookFailurePolicyFail ExtensionHookFailurePolicy = "Fail"
+	// Proceed with the operation, when the extension hook call failed.
+	ExtensionHookFailurePolicyIgnore ExtensionHookFailurePolicy = "Ignore"
+)
+
+// Extension hook configuration.
+type AddonOperatorExtensionHook struct {
+	// HTTPS endpoint hook requests are posted to.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// PEM encoded CA bundle used to verify the serving certificate of the hook.
+	// Defaults to the system trust roots.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// Defines how failed hook calls are handled.
+	// +kubebuilder:validation:Enum=Fail;Ignore
+	// +kubebuilder:default=Fail
+	// +optional
+	FailurePolicy ExtensionHookFailurePolicy `json:"failurePolicy,omitempty"`
 }
 
 type AddonOperatorFeatureToggles struct {
@@ -51,9 +200,70 @@ type AddonOperatorOCM struct {
 	Endpoint string `json:"endpoint"`
 
 	// Secret to authenticate to the OCM API Endpoint.
-	// Only supports secrets of type "kubernetes.io/dockerconfigjson"
+	// The AccessToken mechanism only supports secrets of type "kubernetes.io/dockerconfigjson"
 	// https://kubernetes.io/docs/concepts/configuration/secret/#secret-types
+	// Other mechanisms read their credentials from the keys documented on .auth.type.
 	Secret ClusterSecretReference `json:"secret"`
+
+	// Mechanism authenticating to the OCM API Endpoint.
+	// Defaults to the access token of the cluster pull secret.
+	// +optional
+	Auth *AddonOperatorOCMAuth `json:"auth,omitempty"`
+
+	// Timeouts of requests to the OCM API per endpoint.
+	// +optional
+	Timeouts *AddonOperatorOCMTimeouts `json:"timeouts,omitempty"`
+}
+
+type AddonOperatorOCMAuth struct {
+	// Type of the authentication mechanism.
+	// AccessToken: static access token of the "kubernetes.io/dockerconfigjson" secret.
+	// OfflineToken: exchanges the "offlineToken" key of the secret for access tokens,
+	// issued to the optional "clientID" key.
+	// ClientCredentials: requests access tokens with the "clientID" and "clientSecret" keys of the secret.
+	// ServiceAccount: requests access tokens for the "clientID" key of the secret,
+	// asserted by the service account JWT at .serviceAccountTokenPath.
+	// +kubebuilder:validation:Enum=AccessToken;OfflineToken;ClientCredentials;ServiceAccount
+	// +kubebuilder:default=AccessToken
+	Type AddonOperatorOCMAuthType `json:"type"`
+
+	// OAuth token endpoint, issuing access tokens.
+	// Defaults to the Red Hat SSO.
+	// +optional
+	TokenURL string `json:"tokenURL,omitempty"`
+
+	// Path of the service account JWT, e.g. a projected ServiceAccount token.
+	// Required for the ServiceAccount mechanism.
+	// +optional
+	ServiceAccountTokenPath string `json:"serviceAccountTokenPath,omitempty"`
+}
+
+type AddonOperatorOCMAuthType string
+
+const (
+	OCMAuthAccessToken       AddonOperatorOCMAuthType = "AccessToken"
+	OCMAuthOfflineToken      AddonOperatorOCMAuthType = "OfflineToken"
+	OCMAuthClientCredentials AddonOperatorOCMAuthType = "ClientCredentials"
+	OCMAuthServiceAccount    AddonOperatorOCMAuthType = "ServiceAccount"
+)
+
+// Timeouts of requests to the OCM API per endpoint, defaulting to 30s.
+type AddonOperatorOCMTimeouts struct {
+	// Looking up the cluster.
+	// +optional
+	Clusters *metav1.Duration `json:"clusters,omitempty"`
+	// Reading and updating the state of addon upgrade policies.
+	// +optional
+	UpgradePolicies *metav1.Duration `json:"upgradePolicies,omitempty"`
+	// Reporting the status of addons to the addon service.
+	// +optional
+	AddOnStatus *metav1.Duration `json:"addonStatus,omitempty"`
+	// Reporting the state of addon installations.
+	// +optional
+	AddOnInstallations *metav1.Duration `json:"addonInstallations,omitempty"`
+	// Posting cluster service logs.
+	// +optional
+	ServiceLogs *metav1.Duration `json:"serviceLogs,omitempty"`
 }
 
 // AddonOperatorStatus defines the observed state of Addon
@@ -77,6 +287,10 @@ const (
 
 	// Paused condition indicates that the AddonOperator is paused entirely.
 	AddonOperatorPaused = "Paused"
+
+	// MaintenanceMode condition indicates that the AddonOperator
+	// defers Addon installs and upgrades during cluster maintenance.
+	AddonOperatorMaintenanceMode = "MaintenanceMode"
 )
 
 // AddonOperator is the Schema for the AddonOperator API
@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,10 +26,32 @@ type AddonSpec struct {
 	// Defines a list of Kubernetes Namespaces that belong to this Addon.
 	// Namespaces listed here will be created prior to installation of the Addon and
 	// will be removed from the cluster when the Addon is deleted.
-	// Collisions with existing Namespaces will result in the existing Namespaces
-	// being adopted.
+	// Collisions with existing Namespaces are handled according to
+	// the NamespaceCollisionPolicy.
 	Namespaces []AddonNamespace `json:"namespaces,omitempty"`
 
+	// Defines how Namespaces listed in .spec.namespaces are handled,
+	// which already exist, but are not owned by this Addon.
+	// Defaults to AdoptAlways, adopting all existing Namespaces.
+	// +kubebuilder:validation:Enum=Fail;AdoptIfUnowned;AdoptAlways
+	// +kubebuilder:default=AdoptAlways
+	// +optional
+	NamespaceCollisionPolicy NamespaceCollisionPolicy `json:"namespaceCollisionPolicy,omitempty"`
+
+	// Priority of the Addon install, Addons with a higher priority are installed first.
+	// A new Addon is only installed once all Addons with a higher priority are Available,
+	// so critical Addons don't compete for OLM and API bandwidth with lower priority ones.
+	// Addons failing to become Available within their install timeout
+	// no longer hold back Addons with a lower priority.
+	// Defaults to 0.
+	// +optional
+	InstallPriority int32 `json:"installPriority,omitempty"`
+
+	// Names of Addons this Addon depends on.
+	// The Addon is only installed once all Addons it depends on are Available.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
 	// Labels to be applied to all resources.
 	CommonLabels map[string]string `json:"commonLabels,omitempty"`
 
@@ -48,6 +71,17 @@ type AddonSpec struct {
 	// +optional
 	DeleteAckRequired bool `json:"deleteAckRequired"`
 
+	// Protects the addon from being deleted accidentally.
+	// DELETE requests are rejected and the finalizer of the addon
+	// is kept until deletion protection is turned off again.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// Defines which objects are removed when the addon is deleted.
+	// +kubebuilder:default=Cascade
+	// +optional
+	UninstallStrategy AddonUninstallStrategy `json:"uninstallStrategy,omitempty"`
+
 	// UpgradePolicy enables status reporting via upgrade policies.
 	UpgradePolicy *AddonUpgradePolicy `json:"upgradePolicy,omitempty"`
 
@@ -58,6 +92,310 @@ type AddonSpec struct {
 	SecretPropagation *AddonSecretPropagation `json:"secretPropagation,omitempty"`
 	// defines the PackageOperator image as part of the addon Spec
 	AddonPackageOperator *AddonPackageOperator `json:"packageOperator,omitempty"`
+
+	// Probes that need to succeed, in addition to the ClusterServiceVersion,
+	// before the Addon is reported as Available.
+	// Probes run periodically in the background, reporting their outcome
+	// in the ReadinessProbesReady condition of the Addon.
+	// Useful for Addons that take time to become usable after their operator is running.
+	// +optional
+	ReadinessProbes []AddonReadinessProbe `json:"readinessProbes,omitempty"`
+
+	// Network isolation of the Addon namespaces.
+	// +optional
+	Network *AddonNetwork `json:"network,omitempty"`
+
+	// Resource constraints enforced in every Addon namespace.
+	// +optional
+	ResourceConstraints *AddonResourceConstraints `json:"resourceConstraints,omitempty"`
+
+	// Parameters passed to the addon via the addon-<name>-parameters
+	// ConfigMap and Secret in the install namespace.
+	// The addon operator is restarted, whenever the parameters change.
+	// +optional
+	Parameters []AddonParameter `json:"parameters,omitempty"`
+
+	// Syncs the parameter values of the addon installation from OCM
+	// into the addon-<name>-parameters Secret,
+	// overriding .spec.parameters of the same name.
+	// +optional
+	OCMParameterSync *AddonOCMParameterSync `json:"ocmParameterSync,omitempty"`
+
+	// Jobs run in the install namespace before the addon is installed
+	// and before it is deleted.
+	// +optional
+	LifecycleHooks *AddonLifecycleHooks `json:"lifecycleHooks,omitempty"`
+
+	// Scheduling constraints for the addon workloads,
+	// passed to the Subscription and the CatalogSource pods.
+	// +optional
+	Placement *AddonPlacement `json:"placement,omitempty"`
+
+	// Name of an existing PriorityClass for the addon workloads.
+	// Takes precedence over .spec.priorityClass.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PriorityClass created for the addon workloads.
+	// +optional
+	PriorityClass *AddonPriorityClass `json:"priorityClass,omitempty"`
+
+	// Proxy configuration of the addon operator.
+	// Addons without proxy configuration inherit the cluster-wide Proxy.
+	// +optional
+	Proxy *AddonProxy `json:"proxy,omitempty"`
+
+	// Rolls the addon back to the last known good CSV and catalog image,
+	// as recorded in .status.lastKnownGood.
+	// The addon stays rolled back, until rollback is turned off again.
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+
+	// PodSecurity admission levels labeled on the namespaces of the addon,
+	// overriding the default levels of the AddonOperator per mode.
+	// +optional
+	PodSecurity *PodSecurityConfig `json:"podSecurity,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Cascade;OrphanWorkloads;OrphanNamespace
+type AddonUninstallStrategy string
+
+const (
+	// All objects of the addon are deleted, including its namespaces.
+	UninstallStrategyCascade AddonUninstallStrategy = "Cascade"
+	// The namespaces of the addon and the workloads of its operator are kept.
+	// Only the Subscription and CatalogSources of the addon are deleted.
+	UninstallStrategyOrphanWorkloads AddonUninstallStrategy = "OrphanWorkloads"
+	// The namespaces of the addon are kept with the data in them,
+	// while the operator of the addon is uninstalled.
+	UninstallStrategyOrphanNamespace AddonUninstallStrategy = "OrphanNamespace"
+)
+
+type AddonLifecycleHooks struct {
+	// Runs once before the addon is installed.
+	// The installation waits until the hook has completed.
+	// +optional
+	PreInstall *AddonLifecycleHook `json:"preInstall,omitempty"`
+
+	// Runs before the addon is deleted.
+	// The addon is not reported ready to be deleted and its finalizer
+	// is not removed until the hook has completed.
+	// +optional
+	PreDelete *AddonLifecycleHook `json:"preDelete,omitempty"`
+}
+
+type AddonLifecycleHook struct {
+	// Container image running the hook.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// Entrypoint of the hook container.
+	// The image entrypoint is used if not set.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Arguments passed to the entrypoint.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Number of retries before the hook is considered failed.
+	// Retries are delayed with an exponential backoff.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// Time in seconds the hook may run, including retries,
+	// before it is considered failed.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+type AddonParameter struct {
+	// Name of the parameter, used as key in the ConfigMap or Secret.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Name string `json:"name"`
+
+	// Value of the parameter.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// Stores the parameter in the Secret instead of the ConfigMap.
+	// +optional
+	Secret bool `json:"secret,omitempty"`
+}
+
+type AddonOCMParameterSync struct {
+	// Interval in which parameter values are polled from OCM.
+	// Defaults to 5m.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// Placement of the addon workloads on dedicated nodes.
+// Settings in the OLM install config take precedence.
+type AddonPlacement struct {
+	// Node selector of the addon workloads.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the addon workloads.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity of the addon operator pods.
+	// Not applied to CatalogSource pods.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// PriorityClass named addon-<addon name>, created by the addon-operator.
+// The value is bounded, so addon pods never outrank platform components.
+type AddonPriorityClass struct {
+	// Priority of the addon pods.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	// +optional
+	Value int32 `json:"value,omitempty"`
+
+	// Whether addon pods may preempt pods with a lower priority.
+	// +kubebuilder:validation:Enum=Never;PreemptLowerPriority
+	// +kubebuilder:default=Never
+	// +optional
+	PreemptionPolicy corev1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+}
+
+// Proxy settings passed to the addon operator via environment variables.
+type AddonProxy struct {
+	// Whether settings left empty are inherited from the cluster-wide Proxy.
+	// +kubebuilder:validation:Enum=Explicit;InheritCluster
+	// +kubebuilder:default=Explicit
+	// +optional
+	Mode AddonProxyMode `json:"mode,omitempty"`
+
+	// URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// Comma-separated list of hostnames and CIDRs excluded from proxying.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// ConfigMap in the install namespace with the CA bundle of the proxy
+	// in its ca-bundle.crt key, mounted into the addon operator pods.
+	// +optional
+	TrustedCA *corev1.LocalObjectReference `json:"trustedCA,omitempty"`
+}
+
+type AddonProxyMode string
+
+const (
+	// Only the settings of the Addon are used.
+	AddonProxyModeExplicit AddonProxyMode = "Explicit"
+	// Empty settings are inherited from the cluster-wide Proxy.
+	AddonProxyModeInheritCluster AddonProxyMode = "InheritCluster"
+)
+
+type AddonResourceConstraints struct {
+	// Spec of the ResourceQuota created in every Addon namespace.
+	// +optional
+	Quota *corev1.ResourceQuotaSpec `json:"quota,omitempty"`
+
+	// Spec of the LimitRange created in every Addon namespace.
+	// +optional
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+}
+
+type AddonNetwork struct {
+	// NetworkPolicies allowing traffic to and from pods in every Addon namespace.
+	// When set, all other ingress traffic is denied by a default-deny NetworkPolicy.
+	// Egress traffic, e.g. to DNS and the API server, is not denied by default,
+	// it is only restricted for pods selected by policies declaring egress rules.
+	// +optional
+	Policies []AddonNetworkPolicy `json:"policies,omitempty"`
+}
+
+type AddonNetworkPolicy struct {
+	// Name of the NetworkPolicy, prefixed with the Addon name.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Selects the pods the policy applies to.
+	// An empty selector selects all pods in the namespace.
+	// +optional
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Allowed ingress traffic.
+	// +optional
+	Ingress []networkingv1.NetworkPolicyIngressRule `json:"ingress,omitempty"`
+
+	// Allowed egress traffic.
+	// +optional
+	Egress []networkingv1.NetworkPolicyEgressRule `json:"egress,omitempty"`
+}
+
+type AddonReadinessProbe struct {
+	// Name of the probe, used in status messages.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace to probe in, must be one of the Addon's namespaces.
+	// Defaults to the Addon install namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Probes an HTTP endpoint of a Service.
+	// +optional
+	HTTPGet *AddonReadinessProbeHTTPGet `json:"httpGet,omitempty"`
+
+	// Executes a command in a Pod.
+	// The Addon Operator is allowed to exec into Pods only in namespaces
+	// with exec probes, via RoleBindings it creates for the Addon.
+	// +optional
+	Exec *AddonReadinessProbeExec `json:"exec,omitempty"`
+}
+
+type AddonReadinessProbeHTTPGet struct {
+	// Name of the Service to probe.
+	// +kubebuilder:validation:MinLength=1
+	Service string `json:"service"`
+
+	// Port of the Service to probe.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Path to request, defaults to "/".
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Scheme to connect with, defaults to HTTP.
+	// Certificates are not verified for HTTPS, same as for kubelet probes.
+	// +kubebuilder:validation:Enum={"HTTP","HTTPS"}
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+}
+
+type AddonReadinessProbeExec struct {
+	// Selects the Pod to execute the command in.
+	// The first running Pod matching the selector is used.
+	PodSelector metav1.LabelSelector `json:"podSelector"`
+
+	// Container to execute the command in.
+	// Defaults to the first container of the Pod.
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// Command to execute, an exit code of 0 reports the probe as successful.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
 }
 
 type AddonPackageOperator struct {
@@ -78,11 +416,66 @@ type AddonSecretPropagationReference struct {
 type AddonUpgradePolicy struct {
 	// Upgrade policy id.
 	ID string `json:"id"`
+	// Maintenance windows in which CatalogSource image updates are rolled out.
+	// Updates outside of all windows are deferred until the next window opens.
+	// Updates are not restricted, if no window is configured.
+	// +optional
+	MaintenanceWindows []AddonMaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Strategy of rolling out new CatalogSource images.
+	// +kubebuilder:default=Direct
+	// +optional
+	Strategy AddonUpgradeStrategy `json:"strategy,omitempty"`
+	// Time the staging CatalogSource of a Canary upgrade has to stay healthy,
+	// before the new catalog image is rolled out to the Subscription.
+	// Defaults to 15m.
+	// +optional
+	CanaryHealthCheckWindow *metav1.Duration `json:"canaryHealthCheckWindow,omitempty"`
+	// Mode of approving upgrades.
+	// Manual upgrades are reported as scheduled to the Upgrade Policy endpoint
+	// and new CatalogSource images are only rolled out,
+	// after OCM approved or acknowledged the upgrade policy.
+	// Manual upgrades require .spec.version to be set.
+	// +kubebuilder:default=Automatic
+	// +optional
+	Mode AddonUpgradeMode `json:"mode,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Automatic;Manual
+type AddonUpgradeMode string
+
+const (
+	// Upgrades are rolled out without approval.
+	UpgradeModeAutomatic AddonUpgradeMode = "Automatic"
+	// Upgrades are rolled out after OCM approved the upgrade policy.
+	UpgradeModeManual AddonUpgradeMode = "Manual"
+)
+
+// +kubebuilder:validation:Enum=Direct;Canary
+type AddonUpgradeStrategy string
+
+const (
+	// New catalog images are rolled out to the CatalogSource of the Subscription directly.
+	UpgradeStrategyDirect AddonUpgradeStrategy = "Direct"
+	// New catalog images are first served by a staging CatalogSource.
+	// The Subscription only switches to the new image, after the staging catalog
+	// offers the expected CSV and stayed healthy for the health check window.
+	UpgradeStrategyCanary AddonUpgradeStrategy = "Canary"
+)
+
+type AddonMaintenanceWindow struct {
+	// Cron schedule in UTC at which the maintenance window opens,
+	// e.g. "0 2 * * 1-5" for 2am on weekdays.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration for which the maintenance window stays open.
+	Duration metav1.Duration `json:"duration"`
 }
 
 type AddonUpgradePolicyValue string
 
 const (
+	AddonUpgradePolicyValueScheduled AddonUpgradePolicyValue = "scheduled"
+	AddonUpgradePolicyValueApproved  AddonUpgradePolicyValue = "approved"
 	AddonUpgradePolicyValueStarted   AddonUpgradePolicyValue = "started"
 	AddonUpgradePolicyValueCompleted AddonUpgradePolicyValue = "completed"
 )
@@ -111,14 +504,246 @@ type MonitoringSpec struct {
 	// Settings For Monitoring Stack
 	// +optional
 	MonitoringStack *MonitoringStackSpec `json:"monitoringStack,omitempty"`
+
+	// Backend the metrics of the addon are collected by.
+	// MonitoringStack creates a dedicated MonitoringStack as configured in `.monitoringStack`.
+	// UserWorkload routes the metrics into the user-workload monitoring of the cluster instead,
+	// labeling the addon namespaces for it and registering `.monitoringStack.rhobsRemoteWriteConfig`
+	// as remote write of the user-workload Prometheus, limited to the addon namespaces.
+	// Secrets and ConfigMaps referenced by the remote write config are looked up in
+	// the openshift-user-workload-monitoring namespace then.
+	// +kubebuilder:validation:Enum=MonitoringStack;UserWorkload
+	// +kubebuilder:default=MonitoringStack
+	// +optional
+	Mode AddonMonitoringMode `json:"mode,omitempty"`
+
+	// Alerting and recording rules of the addon, rendered into a PrometheusRule
+	// in the monitoring namespace and evaluated against the federated metrics.
+	// Requires `.monitoring.federation` to be set.
+	// +optional
+	Rules []monv1.RuleGroup `json:"rules,omitempty"`
+
+	// Silences the alerts of the addon namespaces in the cluster-monitoring
+	// Alertmanager while the addon is upgraded.
+	// +optional
+	SilenceDuringUpgrade *SilenceDuringUpgradeSpec `json:"silenceDuringUpgrade,omitempty"`
+
+	// ServiceMonitors created in the addon namespaces,
+	// for addons that can't ship their own monitoring manifests.
+	// The namespace selector is pinned to the namespace of the ServiceMonitor
+	// and honorLabels is always disabled.
+	// +optional
+	ServiceMonitors []AddonServiceMonitor `json:"serviceMonitors,omitempty"`
+
+	// PodMonitors created in the addon namespaces,
+	// for addons that can't ship their own monitoring manifests.
+	// The namespace selector is pinned to the namespace of the PodMonitor
+	// and honorLabels is always disabled.
+	// +optional
+	PodMonitors []AddonPodMonitor `json:"podMonitors,omitempty"`
+
+	// HTTP(S) endpoints probed by the platform blackbox exporter.
+	// Rendered into Probes in the monitoring namespace,
+	// the addon becomes unavailable while a probe keeps failing.
+	// Requires `.monitoring.federation` to be set.
+	// +optional
+	Probes []AddonProbe `json:"probes,omitempty"`
+
+	// Provisions a PagerDuty service of its own for the addon,
+	// storing its Events API v2 routing key in a Secret in the addon namespace.
+	// Requires PagerDuty credentials configured on the AddonOperator.
+	// +optional
+	PagerDuty *PagerDutySpec `json:"pagerDuty,omitempty"`
+
+	// Service level objectives of the addon.
+	// The rates of their events are recorded over 5m in the monitoring namespace,
+	// from which the remaining error budget is evaluated and published in `.status.slo`.
+	// Requires `.monitoring.federation` to be set.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SLOs []AddonSLO `json:"slos,omitempty"`
+}
+
+type AddonSLO struct {
+	// Name of the SLO, unique within the addon.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Percentage of events that must succeed within the window, e.g. "99.9".
+	// +kubebuilder:validation:Pattern=`^[0-9]{1,2}(\.[0-9]+)?$`
+	Target string `json:"target"`
+	// Indicator measuring the failed events.
+	Indicator AddonSLOIndicator `json:"indicator"`
+	// Window the error budget is computed over.
+	// +kubebuilder:default="720h"
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+	// Rate the error budget may be used up at over the last hour,
+	// above which the SLO is reported as breached.
+	// At a burn rate of 1 the error budget is used up exactly at the end of the window.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:default="14.4"
+	// +optional
+	MaxBurnRate string `json:"maxBurnRate,omitempty"`
+}
+
+type AddonSLOIndicator struct {
+	// PromQL selector of a counter of failed events,
+	// e.g. `http_requests_total{job="addon",code=~"5.."}`.
+	// +kubebuilder:validation:MinLength=1
+	ErrorQuery string `json:"errorQuery"`
+	// PromQL selector of a counter of all events,
+	// e.g. `http_requests_total{job="addon"}`.
+	// +kubebuilder:validation:MinLength=1
+	TotalQuery string `json:"totalQuery"`
+}
+
+type PagerDutySpec struct {
+	// Escalation policy of the PagerDuty service.
+	// Defaults to the escalation policy configured on the AddonOperator.
+	// +optional
+	EscalationPolicyID string `json:"escalationPolicyID,omitempty"`
+	// Name of the Secret in the addon namespace holding the routing key in its "routing-key" key.
+	// Defaults to "<addon name>-pagerduty".
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+type AddonProbe struct {
+	// Name of the probe, unique within the addon.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// HTTP(S) URL of the probed endpoint.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Blackbox exporter module used for the probe.
+	// +kubebuilder:default=http_2xx
+	// +optional
+	Module string `json:"module,omitempty"`
+	// Interval the endpoint is probed at.
+	// +kubebuilder:default="30s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Number of consecutive failed probes,
+	// after which the addon is reported as unavailable.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+type AddonServiceMonitor struct {
+	// Name of the ServiceMonitor.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the ServiceMonitor, must be one of .spec.namespaces.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Specification of the ServiceMonitor.
+	Spec monv1.ServiceMonitorSpec `json:"spec"`
+}
+
+type AddonPodMonitor struct {
+	// Name of the PodMonitor.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of the PodMonitor, must be one of .spec.namespaces.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Specification of the PodMonitor.
+	Spec monv1.PodMonitorSpec `json:"spec"`
+}
+
+type SilenceDuringUpgradeSpec struct {
+	// Time the silence is created for, ending it even if the upgrade does not complete.
+	// Defaults to 1h.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 type MonitoringStackSpec struct {
 	// Settings for RHOBS Remote Write
 	// +optional
 	RHOBSRemoteWriteConfig *RHOBSRemoteWriteConfigSpec `json:"rhobsRemoteWriteConfig,omitempty"`
+
+	// Forward firing alerts with severity "critical" from the
+	// MonitoringStack's Alertmanager to OCM as cluster service logs.
+	// Useful for addons that are not integrated with PagerDuty.
+	// +optional
+	ForwardAlertsToServiceLogs bool `json:"forwardAlertsToServiceLogs,omitempty"`
+
+	// Number of Prometheus replicas of the MonitoringStack.
+	// Defaults to the default of the MonitoringStack.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resource requests and limits of the MonitoringStack pods.
+	// Defaults to the defaults of the MonitoringStack.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Time to retain the data of the MonitoringStack for.
+	// Defaults to 30d.
+	// +optional
+	Retention monv1.Duration `json:"retention,omitempty"`
+
+	// Where the rules of .spec.monitoring.rules are evaluated.
+	// ThanosRuler registers them with the Thanos Ruler configured on the AddonOperator
+	// instead of evaluating them locally, .spec.monitoring.federation is not required then.
+	// +kubebuilder:validation:Enum=Local;ThanosRuler
+	// +kubebuilder:default=Local
+	// +optional
+	RuleEvaluation AddonRuleEvaluation `json:"ruleEvaluation,omitempty"`
+
+	// Provisions a Dead Man's Snitch for the addon, checked in by a constantly
+	// firing alert routed through the MonitoringStack's Alertmanager.
+	// The snitch alerts, when the MonitoringStack stops evaluating rules or sending alerts.
+	// Requires a Dead Man's Snitch API token configured on the AddonOperator.
+	// +optional
+	DeadMansSnitch *DeadMansSnitchSpec `json:"deadMansSnitch,omitempty"`
 }
 
+type DeadMansSnitchSpec struct {
+	// Interval the snitch expects check-ins in.
+	// +kubebuilder:validation:Enum="15_minute";"30_minute";"hourly"
+	// +kubebuilder:default="15_minute"
+	// +optional
+	Interval DeadMansSnitchInterval `json:"interval,omitempty"`
+	// Tags added to the snitch, e.g. to route its alerts within Dead Man's Snitch.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+type DeadMansSnitchInterval string
+
+const (
+	DeadMansSnitchInterval15Minutes DeadMansSnitchInterval = "15_minute"
+	DeadMansSnitchInterval30Minutes DeadMansSnitchInterval = "30_minute"
+	DeadMansSnitchIntervalHourly    DeadMansSnitchInterval = "hourly"
+)
+
+type AddonMonitoringMode string
+
+const (
+	// Metrics are collected by a dedicated MonitoringStack.
+	AddonMonitoringModeMonitoringStack AddonMonitoringMode = "MonitoringStack"
+	// Metrics are collected by the user-workload monitoring of the cluster.
+	AddonMonitoringModeUserWorkload AddonMonitoringMode = "UserWorkload"
+)
+
+type AddonRuleEvaluation string
+
+const (
+	// Rules are evaluated by cluster-monitoring on the federated metrics.
+	AddonRuleEvaluationLocal AddonRuleEvaluation = "Local"
+	// Rules are evaluated by the Thanos Ruler configured on the AddonOperator.
+	AddonRuleEvaluationThanosRuler AddonRuleEvaluation = "ThanosRuler"
+)
+
 type RHOBSRemoteWriteConfigSpec struct {
 	// RHOBS endpoints where your data is sent to
 	// It varies by environment:
@@ -130,13 +755,78 @@ type RHOBSRemoteWriteConfigSpec struct {
 	// +optional
 	OAuth2 *monv1.OAuth2 `json:"oauth2,omitempty"`
 
+	// OAuth2 client credentials for the remote write URL, read from a Secret.
+	// Mutually exclusive with OAuth2.
+	// +optional
+	OAuth2ClientCredentials *RHOBSOAuth2ClientCredentials `json:"oauth2ClientCredentials,omitempty"`
+
+	// CA bundle to verify the remote write URL with,
+	// instead of the system trust store.
+	// +optional
+	CA *monv1.SecretOrConfigMap `json:"ca,omitempty"`
+
+	// Proxy the remote write requests are sent through.
+	// Without proxy configuration, the HTTPS proxy
+	// of the cluster-wide Proxy is inherited.
+	// +optional
+	Proxy *RHOBSRemoteWriteProxy `json:"proxy,omitempty"`
+
 	// List of metrics to push to RHOBS.
 	// Any metric not listed here is dropped.
+	// Entries are either exact metric names or regular expressions
+	// anchored with ^ and $ in RE2 syntax, e.g. ^kube_pod_.+$.
 	Allowlist []string `json:"allowlist,omitempty"`
+
+	// Limits the number of series remote-written to RHOBS,
+	// protecting it from a cardinality explosion of this Addon's metrics.
+	// +optional
+	CardinalityGuard *CardinalityGuardSpec `json:"cardinalityGuard,omitempty"`
+}
+
+type RHOBSOAuth2ClientCredentials struct {
+	// Name of the Secret in the install namespace of the Addon,
+	// holding the client ID in its client-id key
+	// and the client secret in its client-secret key.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+
+	// URL to fetch the token from.
+	// +kubebuilder:validation:MinLength=1
+	TokenURL string `json:"tokenUrl"`
+
+	// Scopes requested for the token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type RHOBSRemoteWriteProxy struct {
+	// Whether the proxy is inherited from the HTTPS proxy
+	// of the cluster-wide Proxy, when URL is left empty.
+	// +kubebuilder:validation:Enum=Explicit;InheritCluster
+	// +kubebuilder:default=Explicit
+	// +optional
+	Mode AddonProxyMode `json:"mode,omitempty"`
+
+	// URL of the proxy.
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
+type CardinalityGuardSpec struct {
+	// Maximum number of series this Addon may remote-write to RHOBS.
+	// The Addon reports a RemoteWriteDegraded condition while the limit is exceeded.
+	// +kubebuilder:validation:Minimum=1
+	SeriesLimit int64 `json:"seriesLimit"`
+
+	// List of metrics to drop from remote write while the SeriesLimit is exceeded.
+	// +optional
+	DropOnLimitExceeded []string `json:"dropOnLimitExceeded,omitempty"`
 }
 
 type MonitoringFederationSpec struct {
 	// Namespace where the prometheus server is running.
+	// If missing, it is created with a monitoring ResourceQuota and owned by the Addon,
+	// when enabled on the addon-operator.
 	// +kubebuilder:validation:MinLength=1
 	Namespace string `json:"namespace"`
 
@@ -149,19 +839,82 @@ type MonitoringFederationSpec struct {
 	MatchNames []string `json:"matchNames"`
 
 	// List of labels used to discover the prometheus server(s) to be federated.
+	// Should not select prometheus servers of other Addons,
+	// which is reported via the MonitoringFederationTenancyViolated condition.
 	// +kubebuilder:validation:MinProperties=1
 	MatchLabels map[string]string `json:"matchLabels"`
+
+	// Credentials to authenticate against the /federate endpoint with.
+	// Defaults to the ServiceAccount token of the cluster-monitoring prometheus.
+	// +optional
+	Auth *MonitoringFederationAuth `json:"auth,omitempty"`
+
+	// Relabelings applied to the federated series before ingestion,
+	// e.g. to drop high-cardinality series or to rename labels.
+	// Every federated series is labeled with addon=<addon name> afterwards.
+	// +optional
+	MetricRelabelings []*monv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+}
+
+// Credentials to authenticate against the /federate endpoint with.
+type MonitoringFederationAuth struct {
+	// Name of a ServiceAccount in the federation namespace,
+	// whose token is sent as bearer token.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// Client certificate presented to the /federate endpoint.
+	// +optional
+	MTLS *MonitoringFederationMTLS `json:"mtls,omitempty"`
+}
+
+// Client certificate for mutual TLS.
+type MonitoringFederationMTLS struct {
+	// Name of a kubernetes.io/tls Secret in the federation namespace,
+	// holding the client certificate and key.
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
 }
 
 // AddonInstallSpec defines the desired Addon installation type.
 type AddonInstallSpec struct {
 	// Type of installation.
-	// +kubebuilder:validation:Enum={"OLMOwnNamespace","OLMAllNamespaces"}
+	// +kubebuilder:validation:Enum={"OLMOwnNamespace","OLMAllNamespaces","PackageOperator"}
 	Type AddonInstallType `json:"type"`
 	// OLMAllNamespaces config parameters. Present only if Type = OLMAllNamespaces.
 	OLMAllNamespaces *AddonInstallOLMAllNamespaces `json:"olmAllNamespaces,omitempty"`
 	// OLMOwnNamespace config parameters. Present only if Type = OLMOwnNamespace.
 	OLMOwnNamespace *AddonInstallOLMOwnNamespace `json:"olmOwnNamespace,omitempty"`
+	// PackageOperator config parameters. Present only if Type = PackageOperator.
+	PackageOperator *AddonInstallPackageOperator `json:"packageOperator,omitempty"`
+	// Defines how an OLM install recovers from a failed CSV.
+	// +kubebuilder:default=None
+	// +optional
+	Recovery AddonInstallRecoveryPolicy `json:"recovery,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=None;ReinstallOnFailure
+type AddonInstallRecoveryPolicy string
+
+const (
+	// A failed CSV is reported, but left for manual intervention.
+	InstallRecoveryNone AddonInstallRecoveryPolicy = "None"
+	// The Subscription and CSV of the addon are deleted and recreated,
+	// when the CSV failed. Reinstalls are retried a limited number of times
+	// with exponential backoff.
+	InstallRecoveryReinstallOnFailure AddonInstallRecoveryPolicy = "ReinstallOnFailure"
+)
+
+// PackageOperator specific Addon installation parameters.
+type AddonInstallPackageOperator struct {
+	// Namespace the Addon is installed into,
+	// passed to the package as its target namespace.
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Image of the package to deploy.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
 }
 
 // Common Addon installation parameters.
@@ -178,29 +931,142 @@ type AddonInstallOLMCommon struct {
 	// +kubebuilder:validation:MinLength=1
 	Channel string `json:"channel"`
 
-	// Name of the package to install via OLM.
-	// OLM will resove this package name to install the matching bundle.
-	// +kubebuilder:validation:MinLength=1
-	PackageName string `json:"packageName"`
+	// Name of the package to install via OLM.
+	// OLM will resove this package name to install the matching bundle.
+	// +kubebuilder:validation:MinLength=1
+	PackageName string `json:"packageName"`
+
+	// Reference to a secret of type kubernetes.io/dockercfg or kubernetes.io/dockerconfigjson
+	// in the addon operators installation namespace.
+	// The secret referenced here, will be made available to the addon in the addon installation namespace,
+	// as addon-pullsecret prior to installing the addon itself.
+	// Deprecated: use PullSecrets instead.
+	PullSecretName string `json:"pullSecretName,omitempty"`
+
+	// Names of secrets of type kubernetes.io/dockercfg or kubernetes.io/dockerconfigjson,
+	// propagated into all Addon namespaces via .spec.secretPropagation.
+	// The secrets are used to pull the catalog images and attached
+	// to the default ServiceAccount of every Addon namespace.
+	// +optional
+	PullSecrets []string `json:"pullSecrets,omitempty"`
+
+	// Configs to be passed to subscription OLM object
+	// +optional
+	Config *SubscriptionConfig `json:"config,omitempty"`
+
+	// Additional catalog source objects to be created in the cluster
+	// +optional
+	AdditionalCatalogSources []AdditionalCatalogSource `json:"additionalCatalogSources,omitempty"`
+
+	// Labels and annotations to be added to the generated Subscription.
+	// +optional
+	SubscriptionMetadata *AdditionalMetadata `json:"subscriptionMetadata,omitempty"`
+
+	// Labels and annotations to be added to the generated CatalogSources,
+	// including additional CatalogSources.
+	// +optional
+	CatalogSourceMetadata *AdditionalMetadata `json:"catalogSourceMetadata,omitempty"`
+
+	// Configuration of the generated CatalogSources,
+	// including additional CatalogSources.
+	// +optional
+	CatalogSource *CatalogSourceConfig `json:"catalogSource,omitempty"`
+
+	// Approval mode of InstallPlans created for the Subscription.
+	// With Manual approval, the addon-operator only approves InstallPlans
+	// installing the CSV of the version set in .spec.version.
+	// When unset, the approval mode of an existing Subscription is kept.
+	// +kubebuilder:validation:Enum=Manual;Automatic
+	// +optional
+	InstallPlanApproval AddonInstallPlanApproval `json:"installPlanApproval,omitempty"`
+}
+
+type AddonInstallPlanApproval string
+
+const (
+	// InstallPlans are only approved, if they install the version pinned in the Addon.
+	InstallPlanApprovalManual AddonInstallPlanApproval = "Manual"
+	// InstallPlans are approved automatically by OLM.
+	InstallPlanApprovalAutomatic AddonInstallPlanApproval = "Automatic"
+)
+
+// Labels and annotations passed through to generated objects.
+// Labels managed by the addon-operator take precedence.
+type AdditionalMetadata struct {
+	// Labels to be added to the object.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to be added to the object.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type SubscriptionConfig struct {
+	// Array of env variables to be passed to the subscription object.
+	// +optional
+	EnvironmentVariables []EnvObject `json:"env,omitempty"`
+
+	// Compute resources of the operator container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Tolerations of the operator pod.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Node selector of the operator pod.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+type CatalogSourceConfig struct {
+	// Defines how OLM checks the catalog image for updates.
+	// +optional
+	UpdateStrategy *CatalogSourceUpdateStrategy `json:"updateStrategy,omitempty"`
+
+	// Overrides for the grpc registry pod serving the catalog.
+	// +optional
+	GrpcPodConfig *CatalogSourceGrpcPodConfig `json:"grpcPodConfig,omitempty"`
 
-	// Reference to a secret of type kubernetes.io/dockercfg or kubernetes.io/dockerconfigjson
-	// in the addon operators installation namespace.
-	// The secret referenced here, will be made available to the addon in the addon installation namespace,
-	// as addon-pullsecret prior to installing the addon itself.
-	PullSecretName string `json:"pullSecretName,omitempty"`
+	// Resolves the tag of the .catalogSourceImage to its digest
+	// and pins the CatalogSource to that digest.
+	// The tag is only resolved again when the .catalogSourceImage changes,
+	// so the catalog is not upgraded by pushing to an existing tag.
+	// +optional
+	PinImageDigest bool `json:"pinImageDigest,omitempty"`
 
-	// Configs to be passed to subscription OLM object
+	// Checks that the manifest of a new .catalogSourceImage can be pulled
+	// with the pull secrets of the addon, before the CatalogSource is updated.
+	// Unreachable images are reported as CatalogImageUnreachable and not rolled out,
+	// instead of leaving the registry pod in ImagePullBackOff.
 	// +optional
-	Config *SubscriptionConfig `json:"config,omitempty"`
+	VerifyImage bool `json:"verifyImage,omitempty"`
+}
 
-	// Additional catalog source objects to be created in the cluster
+type CatalogSourceUpdateStrategy struct {
+	// Periodically polls the catalog image for updates.
 	// +optional
-	AdditionalCatalogSources []AdditionalCatalogSource `json:"additionalCatalogSources,omitempty"`
+	RegistryPoll *CatalogSourceRegistryPoll `json:"registryPoll,omitempty"`
 }
 
-type SubscriptionConfig struct {
-	// Array of env variables to be passed to the subscription object.
-	EnvironmentVariables []EnvObject `json:"env"`
+type CatalogSourceRegistryPoll struct {
+	// Interval between checks for a new version of the catalog image, e.g. 45m.
+	Interval metav1.Duration `json:"interval"`
+}
+
+type CatalogSourceGrpcPodConfig struct {
+	// Priority class of the registry pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Node selector of the registry pod.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the registry pod.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 type AdditionalCatalogSource struct {
@@ -210,6 +1076,51 @@ type AdditionalCatalogSource struct {
 	// Image url of the additional catalog source
 	// +kubebuilder:validation:MinLength=1
 	Image string `json:"image"`
+	// Priority of the catalog source for the OLM dependency resolver.
+	// CatalogSources with a higher priority are preferred and created first.
+	// +optional
+	Priority int `json:"priority,omitempty"`
+	// Whether the catalog source has to be READY,
+	// before the Subscription of the Addon is created.
+	// +kubebuilder:default=true
+	// +optional
+	Required *bool `json:"required,omitempty"`
+	// Subscribes to an operator package served by the catalog source,
+	// in addition to the package of the Addon.
+	// Allows Addons composed of multiple operators.
+	// +optional
+	Subscription *AdditionalCatalogSourceSubscription `json:"subscription,omitempty"`
+}
+
+// Subscription created against an additional catalog source.
+type AdditionalCatalogSourceSubscription struct {
+	// Name of the package to subscribe to.
+	// Must differ from the package of the Addon
+	// and of other additional catalog sources.
+	// +kubebuilder:validation:MinLength=1
+	PackageName string `json:"packageName"`
+	// Channel of the package to subscribe to.
+	// +kubebuilder:validation:MinLength=1
+	Channel string `json:"channel"`
+}
+
+// Returns the names of all pull secrets,
+// including the deprecated PullSecretName.
+func (c AddonInstallOLMCommon) GetPullSecrets() []string {
+	if len(c.PullSecretName) == 0 {
+		return c.PullSecrets
+	}
+	for _, name := range c.PullSecrets {
+		if name == c.PullSecretName {
+			return c.PullSecrets
+		}
+	}
+	return append([]string{c.PullSecretName}, c.PullSecrets...)
+}
+
+// Returns true if the Subscription has to wait for the catalog source.
+func (s AdditionalCatalogSource) IsRequired() bool {
+	return s.Required == nil || *s.Required
 }
 
 type EnvObject struct {
@@ -231,6 +1142,20 @@ type AddonInstallOLMOwnNamespace struct {
 	AddonInstallOLMCommon `json:",inline"`
 }
 
+type NamespaceCollisionPolicy string
+
+const (
+	// Existing Namespaces not owned by this Addon are not adopted
+	// and block the reconciliation of the Addon.
+	NamespaceCollisionPolicyFail NamespaceCollisionPolicy = "Fail"
+	// Existing Namespaces are adopted, unless they are controlled by another object.
+	NamespaceCollisionPolicyAdoptIfUnowned NamespaceCollisionPolicy = "AdoptIfUnowned"
+	// Existing Namespaces are always adopted.
+	// Namespaces controlled by another object keep their controller
+	// and are only referenced as owned by this Addon.
+	NamespaceCollisionPolicyAdoptAlways NamespaceCollisionPolicy = "AdoptAlways"
+)
+
 type AddonInstallType string
 
 const (
@@ -243,6 +1168,10 @@ const (
 	// The Operator will only watch and be made available for use in this single namespace.
 	// Maps directly to the OLM install mode "specific namespace"
 	OLMOwnNamespace AddonInstallType = "OLMOwnNamespace"
+	// Deploys the Addon as a package-operator ClusterPackage,
+	// skipping the OLM CatalogSource and Subscription machinery.
+	// Requires the ADDONS_PLUG_AND_PLAY feature toggle.
+	PackageOperator AddonInstallType = "PackageOperator"
 )
 
 // Annotation keys for delete signal from OCM.
@@ -251,6 +1180,36 @@ const (
 	DeleteTimeoutDuration = "addons.managed.openshift.io/deletetimeout"
 )
 
+// Annotation overriding the time an Addon may take to install,
+// before it is reported as timed out, e.g. "45m".
+const InstallTimeoutAnnotation = "addons.managed.openshift.io/install-timeout"
+
+// Annotation overriding the time the namespaces of a deleted Addon may take to terminate,
+// before they are reported as stuck, e.g. "30m".
+const NamespaceDeletionTimeoutAnnotation = "addons.managed.openshift.io/namespace-deletion-timeout"
+
+// Annotation letting an Addon take over its CatalogSources, Subscription and OperatorGroup when set to "true",
+// if they already exist without being owned by the Addon, e.g. to bring a manually installed operator
+// under management. A single OperatorGroup in the install namespace is taken over under its own name.
+// Without it, such objects are reported as collisions and left untouched.
+const AdoptOLMObjectsAnnotation = "addons.managed.openshift.io/adopt-olm-objects"
+
+// Annotation pausing the reconciliation of a single Addon when set to "true",
+// e.g. to freeze an Addon during incident response without changing its spec.
+const PausedAnnotation = "addons.managed.openshift.io/paused"
+
+// Annotation keys set on managed resources while their Addon is paused,
+// explaining why drift on these resources is not being corrected.
+const (
+	PausedReasonAnnotation = "addons.managed.openshift.io/paused-reason"
+	PausedSinceAnnotation  = "addons.managed.openshift.io/paused-since"
+)
+
+// Environment variable set on the addon operator via its Subscription,
+// holding a hash of the Addon parameters. OLM rolls out the operator
+// whenever the hash changes, so it picks up changed parameters.
+const ParametersHashEnvVar = "ADDON_PARAMETERS_HASH"
+
 // Addon condition reasons
 
 const (
@@ -266,12 +1225,30 @@ const (
 	// Addon has paused reconciliation
 	AddonReasonPaused = "AddonPaused"
 
+	// Addon reconciliation is paused via the paused annotation
+	AddonReasonPausedByAnnotation = "AddonPausedByAnnotation"
+
 	// Addon has an unready Catalog source
 	AddonReasonUnreadyCatalogSource = "UnreadyCatalogSource"
 
+	// Addon's new catalog image can not be pulled from its registry
+	AddonReasonCatalogImageUnreachable = "CatalogImageUnreachable"
+
 	// Addon has an unready additional Catalog source
 	AddonReasonUnreadyAdditionalCatalogSource = "UnreadyAdditionalCatalogSource"
 
+	// Addon has an additional Subscription without an installed CSV
+	AddonReasonUnreadyAdditionalSubscription = "UnreadyAdditionalSubscription"
+
+	// All additional Catalog sources of the Addon are ready
+	AddonReasonAllCatalogSourcesReady = "AllCatalogSourcesReady"
+
+	// An additional Catalog source of the Addon is still connecting
+	AddonReasonCatalogSourceConnecting = "CatalogSourceConnecting"
+
+	// An additional Catalog source of the Addon failed to connect
+	AddonReasonCatalogSourceUnhealthy = "CatalogSourceUnhealthy"
+
 	// Addon has unready namespaces
 	AddonReasonUnreadyNamespaces = "UnreadyNamespaces"
 
@@ -281,6 +1258,147 @@ const (
 	// Addon has unready monitoring stack
 	AddonReasonUnreadyMonitoringStack = "UnreadyMonitoringStack"
 
+	// Addon routes its metrics into user-workload monitoring, which is not enabled
+	AddonReasonUnreadyUserWorkloadMonitoring = "UnreadyUserWorkloadMonitoring"
+
+	// Addon requests a Dead Man's Snitch, which cannot be provisioned
+	AddonReasonUnreadyDeadMansSnitch = "UnreadyDeadMansSnitch"
+
+	// Addon requests a PagerDuty service, which cannot be provisioned
+	AddonReasonUnreadyPagerDuty = "UnreadyPagerDuty"
+
+	// Addon declares monitoring rules, that cannot be rendered
+	AddonReasonInvalidMonitoringRules = "InvalidMonitoringRules"
+
+	// Addon declares ServiceMonitors or PodMonitors, that cannot be created
+	AddonReasonInvalidMonitors = "InvalidMonitors"
+
+	// Addon declares Probes, that cannot be created
+	AddonReasonInvalidMonitoringProbes = "InvalidMonitoringProbes"
+
+	// Addon has Probes failing for longer than their failure threshold
+	AddonReasonFailingMonitoringProbes = "FailingMonitoringProbes"
+
+	// Addon declares SLOs, whose recording rules cannot be created
+	AddonReasonInvalidMonitoringSLOs = "InvalidMonitoringSLOs"
+
+	// Addon has failing ReadinessProbes
+	AddonReasonUnreadyReadinessProbes = "UnreadyReadinessProbes"
+
+	// Addon ReadinessProbes are succeeding
+	AddonReasonReadyReadinessProbes = "ReadyReadinessProbes"
+
+	// Addon ReadinessProbes did not complete since they changed
+	AddonReasonPendingReadinessProbes = "PendingReadinessProbes"
+
+	// Addon's new catalog has no upgrade edge for the installed version
+	AddonReasonMissingUpgradeEdge = "MissingUpgradeEdge"
+
+	// Addon's new catalog skips versions, no bundle of its channel upgrades from the installed CSV
+	AddonReasonVersionsSkipped = "VersionsSkipped"
+
+	// Addon installation was vetoed by the extension hook
+	AddonReasonInstallVetoed = "InstallVetoed"
+
+	// Addon upgrade was vetoed by the extension hook
+	AddonReasonUpgradeVetoed = "UpgradeVetoed"
+
+	// Addon install or upgrade is deferred until the cluster maintenance ends
+	AddonReasonClusterMaintenance = "ClusterMaintenance"
+
+	// Addon upgrade is deferred until the next maintenance window opens
+	AddonReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
+
+	// Addon upgrade is deferred until OCM approved the upgrade policy
+	AddonReasonAwaitingUpgradeApproval = "AwaitingUpgradeApproval"
+
+	// Addon upgrade is staged, while the new catalog is health checked
+	AddonReasonCanaryHealthCheck = "CanaryHealthCheck"
+
+	// Addon upgrade is held, because the staging catalog is not healthy
+	AddonReasonCanaryUnhealthy = "CanaryUnhealthy"
+
+	// Addon is being rolled back to its last known good version
+	AddonReasonRollingBack = "RollingBack"
+
+	// Addon has been rolled back to its last known good version
+	AddonReasonRollbackSucceeded = "RollbackSucceeded"
+
+	// Addon has no known good version to roll back to
+	AddonReasonNoKnownGoodVersion = "NoKnownGoodVersion"
+
+	// Addon has a pending InstallPlan not matching the pinned version
+	AddonReasonInstallPlanVersionMismatch = "InstallPlanVersionMismatch"
+
+	// NetworkPolicies of the Addon were changed outside of the Addon and have been reset
+	AddonReasonNetworkPolicyDriftCorrected = "NetworkPolicyDriftCorrected"
+
+	// NetworkPolicies of the Addon match their desired state
+	AddonReasonNetworkPoliciesInSync = "NetworkPoliciesInSync"
+
+	// Addon workloads use all of a resource granted by the ResourceQuota of an Addon namespace
+	AddonReasonResourceQuotaExceeded = "ResourceQuotaExceeded"
+
+	// Addon workloads stay within the ResourceQuotas of the Addon namespaces
+	AddonReasonResourceQuotaWithinLimits = "ResourceQuotaWithinLimits"
+
+	// Addon is being deleted, while deletion protection is enabled.
+	AddonReasonDeletionProtected = "DeletionProtected"
+
+	// Addon is deleted with all its objects, including its namespaces.
+	AddonReasonCascadeUninstall = "CascadeUninstall"
+
+	// Addon is deleted, keeping its namespaces and the workloads of its operator.
+	AddonReasonOrphanWorkloadsUninstall = "OrphanWorkloadsUninstall"
+
+	// Addon is deleted, keeping its namespaces.
+	AddonReasonOrphanNamespaceUninstall = "OrphanNamespaceUninstall"
+
+	// Addon is waiting for a lifecycle hook to complete.
+	AddonReasonLifecycleHookRunning = "LifecycleHookRunning"
+
+	// Addon CSV has not succeeded within the install timeout.
+	AddonReasonCSVNotSucceeded = "CSVNotSucceeded"
+
+	// The pre-install lifecycle hook of the Addon has failed.
+	AddonReasonPreInstallHookFailed = "PreInstallHookFailed"
+
+	// The pre-delete lifecycle hook of the Addon has failed.
+	AddonReasonPreDeleteHookFailed = "PreDeleteHookFailed"
+
+	// Addon Namespaces collide with existing Namespaces not owned by the Addon
+	AddonReasonCollidedNamespaces = "CollidedNamespaces"
+
+	// Addon OLM objects collide with existing objects not owned by the Addon
+	AddonReasonCollidedOLMObjects = "CollidedOLMObjects"
+
+	// Addon install namespace contains OperatorGroups not belonging to the Addon
+	AddonReasonConflictingOperatorGroups = "ConflictingOperatorGroups"
+
+	// Addon Subscription reports no conditions that need attention
+	AddonReasonSubscriptionHealthy = "SubscriptionHealthy"
+
+	// Addon Subscription failed to resolve the operator or its dependencies
+	AddonReasonResolutionFailed = "ResolutionFailed"
+
+	// Addon Subscription references CatalogSources that are unhealthy
+	AddonReasonSubscriptionCatalogSourcesUnhealthy = "SubscriptionCatalogSourcesUnhealthy"
+
+	// Addon Subscription waits for an InstallPlan to be approved or completed
+	AddonReasonInstallPlanPending = "InstallPlanPending"
+
+	// Addon install is held back until Addons with a higher install priority are Available
+	AddonReasonWaitingForHigherPriority = "WaitingForHigherPriorityAddons"
+
+	// Addon upgrade is held back until fewer Addons are upgrading than the AddonOperator allows
+	AddonReasonUpgradeQueued = "UpgradeQueued"
+
+	// Addon install is held back until the Addons it depends on are Available
+	AddonReasonDependenciesNotReady = "DependenciesNotReady"
+
+	// Addon has unready ClusterPackage
+	AddonReasonUnreadyClusterPackage = "UnreadyClusterPackage"
+
 	// Addon has unready ClusterPackageTemplate
 	AddonReasonUnreadyClusterPackageTemplate = "UnreadyClusterPackageTemplate"
 
@@ -313,8 +1431,72 @@ const (
 
 	// Addon has timed out waiting for acknowledgement from the underlying addon.
 	AddonReasonDeletionTimedOut = "AddonReasonDeletionTimedOut"
+
+	// Namespaces of the deleted Addon are terminating for longer than allowed
+	AddonReasonNamespacesStuckTerminating = "NamespacesStuckTerminating"
+
+	// Monitoring objects of the deleted Addon are not removed yet
+	AddonReasonMonitoringObjectsRemaining = "MonitoringObjectsRemaining"
+
+	// Addon is remote-writing more series than allowed by its CardinalityGuard.
+	AddonReasonSeriesLimitExceeded = "SeriesLimitExceeded"
+
+	// Addon is remote-writing fewer series than allowed by its CardinalityGuard.
+	AddonReasonSeriesWithinLimit = "SeriesWithinLimit"
+
+	// Cluster-monitoring fails to federate metrics from some targets of the Addon.
+	AddonReasonUnhealthyFederationTargets = "UnhealthyTargets"
+
+	// Cluster-monitoring federates metrics from all targets of the Addon.
+	AddonReasonHealthyFederationTargets = "HealthyTargets"
+
+	// Monitoring federation of the Addon may select the series of other tenants.
+	AddonReasonFederationTenancyViolated = "TenancyViolated"
+
+	// Addon uses up the error budget of an SLO faster than its maximum burn rate.
+	AddonReasonSLOBurnRateExceeded = "BurnRateExceeded"
+
+	// Addon uses up the error budgets of all SLOs within their maximum burn rate.
+	AddonReasonSLOBurnRateWithinLimit = "BurnRateWithinLimit"
+
+	// Reporting the Addon state to OCM fails.
+	AddonReasonOCMUnreachable = "OCMUnreachable"
+
+	// Parameters of the Addon are synced from OCM.
+	AddonReasonOCMParametersSynced = "ParametersSynced"
+
+	// Syncing parameters of the Addon from OCM fails,
+	// the parameters last synced are kept.
+	AddonReasonOCMParametersSyncFailed = "ParametersSyncFailed"
+)
+
+// PodSecurity admission level of a namespace,
+// see https://kubernetes.io/docs/concepts/security/pod-security-standards/.
+// +kubebuilder:validation:Enum=privileged;baseline;restricted
+type PodSecurityLevel string
+
+const (
+	PodSecurityLevelPrivileged PodSecurityLevel = "privileged"
+	PodSecurityLevelBaseline   PodSecurityLevel = "baseline"
+	PodSecurityLevelRestricted PodSecurityLevel = "restricted"
 )
 
+// PodSecurity admission levels per mode.
+// Modes left unset are not labeled.
+type PodSecurityConfig struct {
+	// Level enforced, pods violating it are rejected.
+	// +optional
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+
+	// Level audited, violations are recorded in the audit log.
+	// +optional
+	Audit PodSecurityLevel `json:"audit,omitempty"`
+
+	// Level warned about, violations are returned as warnings to the client.
+	// +optional
+	Warn PodSecurityLevel `json:"warn,omitempty"`
+}
+
 type AddonNamespace struct {
 	// Name of the KubernetesNamespace.
 	// +kubebuilder:validation:MinLength=1
@@ -327,6 +1509,17 @@ type AddonNamespace struct {
 	// Annotations to be added to the namespace
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Names of secrets in the Addon Operator install namespace
+	// to be copied into this namespace under the same name and kept in sync.
+	// +optional
+	PropagateSecrets []string `json:"propagateSecrets,omitempty"`
+
+	// Manage an existing namespace without becoming its owner.
+	// Adopted namespaces are not created by the addon-operator and
+	// are kept, when the Addon or the namespace entry is removed.
+	// +optional
+	Adopt bool `json:"adopt,omitempty"`
 }
 
 const (
@@ -352,6 +1545,186 @@ const (
 	// DeleteTimeout condition indicates whether an addon has timed out waiting for an delete acknowledgement
 	// from underlying addon.
 	DeleteTimeout = "DeleteTimeout"
+
+	// Degraded condition indicates that the addon is operational,
+	// but some of its features have been restricted.
+	Degraded = "Degraded"
+
+	// MonitoringFederationDegraded condition indicates that cluster-monitoring
+	// fails to federate metrics from some targets of the addon.
+	MonitoringFederationDegraded = "MonitoringFederationDegraded"
+
+	// MonitoringFederationTenancyViolated condition indicates that the monitoring
+	// federation of the addon may select the series of other tenants.
+	// Such addons are still federated, but will be rejected in a future release.
+	MonitoringFederationTenancyViolated = "MonitoringFederationTenancyViolated"
+
+	// RemoteWriteDegraded condition indicates that metrics of the addon are dropped
+	// from remote write, as it exceeds the series limit of its CardinalityGuard.
+	RemoteWriteDegraded = "RemoteWriteDegraded"
+
+	// SLOBreached condition indicates that the error budget of an SLO of the addon
+	// is used up faster than its maximum burn rate.
+	SLOBreached = "SLOBreached"
+
+	// OCMReportingDegraded condition indicates that the state of the addon
+	// could not be reported to the OCM AddOn installations API and is being retried.
+	OCMReportingDegraded = "OCMReportingDegraded"
+
+	// OCMParametersSynced condition indicates whether the parameters of the addon
+	// were last synced from OCM successfully. While false, the addon keeps the
+	// parameters last synced, which may be stale.
+	OCMParametersSynced = "OCMParametersSynced"
+
+	// Frozen condition indicates that installing or upgrading the addon
+	// is deferred until the cluster maintenance ends.
+	Frozen = "Frozen"
+
+	// UpgradeDeferred condition indicates that rolling out a new CatalogSource image
+	// is deferred until the next maintenance window of the addon opens.
+	UpgradeDeferred = "UpgradeDeferred"
+
+	// UpgradeQueued condition indicates that the upgrade of the addon
+	// waits for other addons to finish upgrading within an OCM upgrade policy window.
+	UpgradeQueued = "UpgradeQueued"
+
+	// CanaryUpgrade condition indicates that a new CatalogSource image is staged
+	// and health checked, before it is rolled out to the Subscription of the addon.
+	CanaryUpgrade = "CanaryUpgrade"
+
+	// OperatorGroupConflict condition indicates that the install namespace of the addon
+	// contains other OperatorGroups, which would keep OLM from installing the CSV.
+	// The message names the conflicting OperatorGroups.
+	OperatorGroupConflict = "OperatorGroupConflict"
+
+	// UpgradePathInvalid condition indicates that the channel graph of a new CatalogSource image
+	// has no upgrade path from the installed CSV, so the image is not rolled out.
+	UpgradePathInvalid = "UpgradePathInvalid"
+
+	// CatalogSourcesReady condition indicates whether the additional CatalogSources
+	// of the addon are ready, listing the state of each one that is not.
+	CatalogSourcesReady = "CatalogSourcesReady"
+
+	// RolledBack condition indicates whether a rollback requested via .spec.rollback
+	// to the last known good version of the addon has completed.
+	RolledBack = "RolledBack"
+
+	// SubscriptionHealthy condition indicates whether the Subscription of the addon
+	// reports conditions that need attention, like failed dependency resolution.
+	// The message contains the message of the Subscription condition verbatim.
+	SubscriptionHealthy = "SubscriptionHealthy"
+
+	// NetworkPolicyDrift condition indicates that NetworkPolicies of the addon
+	// were changed outside of the addon and have been reset to their desired state.
+	NetworkPolicyDrift = "NetworkPolicyDrift"
+
+	// QuotaExceeded condition indicates that the workloads of the addon
+	// have used up a resource of the ResourceQuota in one of the addon namespaces.
+	QuotaExceeded = "QuotaExceeded"
+
+	// HookFailed condition indicates that a lifecycle hook Job of the addon
+	// has failed. The hook is retried when the Job is deleted or the hook is changed.
+	HookFailed = "HookFailed"
+
+	// Uninstalling condition indicates that the addon is being deleted,
+	// the reason reflects the uninstall strategy of the addon.
+	Uninstalling = "Uninstalling"
+
+	// InstallTimedOut condition indicates that the addon has not been installed
+	// within its install timeout. The message details why the installation is stuck.
+	InstallTimedOut = "InstallTimedOut"
+
+	// NamespaceDeletionStuck condition indicates that namespaces of the deleted addon
+	// are terminating for longer than allowed. The resources blocking them are
+	// listed in .status.stuckNamespaces.
+	NamespaceDeletionStuck = "NamespaceDeletionStuck"
+
+	// MonitoringTeardownPending condition indicates that the deleted addon waits
+	// for the removal of its ServiceMonitors, PodMonitors, PrometheusRules and MonitoringStacks.
+	// The message lists the remaining objects and their finalizers.
+	MonitoringTeardownPending = "MonitoringTeardownPending"
+)
+
+// Conditions reported by the individual reconcile phases of an Addon.
+// They attribute a not yet available Addon to the phase holding it back.
+const (
+	// NamespacesReady condition indicates that the namespaces of the addon exist and are active.
+	NamespacesReady = "NamespacesReady"
+
+	// NetworkPoliciesReady condition indicates that the NetworkPolicies of the addon are in place.
+	NetworkPoliciesReady = "NetworkPoliciesReady"
+
+	// SecretsReady condition indicates that the secrets of the addon have been propagated.
+	SecretsReady = "SecretsReady"
+
+	// LifecycleHooksReady condition indicates that the pre-install hook of the addon has completed.
+	LifecycleHooksReady = "LifecycleHooksReady"
+
+	// AddonInstanceReady condition indicates that the AddonInstance of the addon is in place.
+	AddonInstanceReady = "AddonInstanceReady"
+
+	// InstallPlansReady condition indicates that no InstallPlan of the addon awaits approval.
+	InstallPlansReady = "InstallPlansReady"
+
+	// OLMReady condition indicates that the CatalogSource, OperatorGroup and Subscription
+	// of the addon are in place and its CSV has succeeded.
+	OLMReady = "OLMReady"
+
+	// PackageInstallReady condition indicates that the ClusterPackage of the addon is available.
+	PackageInstallReady = "PackageInstallReady"
+
+	// MonitoringFederationReady condition indicates that the monitoring federation of the addon is in place.
+	MonitoringFederationReady = "MonitoringFederationReady"
+
+	// MonitoringRulesReady condition indicates that the monitoring rules of the addon are in place.
+	MonitoringRulesReady = "MonitoringRulesReady"
+
+	// MonitorsReady condition indicates that the ServiceMonitors and PodMonitors of the addon are in place.
+	MonitorsReady = "MonitorsReady"
+
+	// MonitoringProbesReady condition indicates that the Probes of the addon are in place and succeed.
+	MonitoringProbesReady = "MonitoringProbesReady"
+
+	// MonitoringSLOsReady condition indicates that the recording rules of the SLOs of the addon are in place.
+	MonitoringSLOsReady = "MonitoringSLOsReady"
+
+	// MonitoringStackReady condition indicates that the monitoring stack of the addon is available.
+	MonitoringStackReady = "MonitoringStackReady"
+
+	// UserWorkloadMonitoringReady condition indicates that the metrics of the addon
+	// are routed into the user-workload monitoring of the cluster.
+	UserWorkloadMonitoringReady = "UserWorkloadMonitoringReady"
+
+	// DeadMansSnitchReady condition indicates that the Dead Man's Snitch of the addon
+	// is provisioned and wired into the Alertmanager of its monitoring stack.
+	DeadMansSnitchReady = "DeadMansSnitchReady"
+
+	// PagerDutyReady condition indicates that the PagerDuty service of the addon
+	// is provisioned and its routing key is stored in the addon namespace.
+	PagerDutyReady = "PagerDutyReady"
+
+	// PackageOperatorReady condition indicates that the ClusterObjectTemplate of the addon is available.
+	PackageOperatorReady = "PackageOperatorReady"
+
+	// ReadinessProbesReady condition indicates that the readiness probes of the addon succeed.
+	// Failing or pending probes keep the Addon unavailable,
+	// once its ClusterServiceVersion succeeded.
+	ReadinessProbesReady = "ReadinessProbesReady"
+)
+
+// Reasons of the conditions reported by the reconcile phases of an Addon.
+const (
+	// The phase has reconciled all its objects.
+	PhaseReasonReconciled = "Reconciled"
+
+	// The phase waits for its objects to become ready.
+	PhaseReasonProgressing = "Progressing"
+
+	// The phase failed to reconcile its objects.
+	PhaseReasonReconcileError = "ReconcileError"
+
+	// The phase did not run, because a preceding phase is not ready.
+	PhaseReasonBlocked = "BlockedByPrecedingPhase"
 )
 
 // AddonStatus defines the observed state of Addon
@@ -364,18 +1737,237 @@ type AddonStatus struct {
 	// it will go away as soon as kubectl can print conditions!
 	// Human readable status - please use .Conditions from code
 	Phase AddonPhase `json:"phase,omitempty"`
+	// Time the Addon last entered each of the phases it went through.
+	// +listType=map
+	// +listMapKey=phase
+	// +optional
+	PhaseTransitions []AddonPhaseTransition `json:"phaseTransitions,omitempty"`
+	// Summary of the Addon state computed from its phase, conditions and versions.
+	// +optional
+	ShortStatus AddonShortStatus `json:"shortStatus,omitempty"`
 	// Tracks last reported upgrade policy status.
 	// +optional
 	UpgradePolicy *AddonUpgradePolicyStatus `json:"upgradePolicy,omitempty"`
 	// Tracks the last addon status reported to OCM.
 	// +optional
 	OCMReportedStatusHash *OCMAddOnStatusHash `json:"ocmReportedStatusHash,omitempty"`
+	// Tracks the last addon state reported to the OCM AddOn installations API.
+	// +optional
+	OCMReportedInstallation *OCMAddOnInstallationStatus `json:"ocmReportedInstallation,omitempty"`
+	// Tracks the parameters last synced from OCM into the parameters Secret.
+	// +optional
+	OCMParameters *AddonOCMParametersStatus `json:"ocmParameters,omitempty"`
 	// Observed version of the Addon on the cluster, only present when .spec.version is populated.
 	// +optional
 	ObservedVersion string `json:"observedVersion,omitempty"`
 	// Namespaced name of the csv(available) that was last observed.
 	// +optional
 	LastObservedAvailableCSV string `json:"lastObservedAvailableCSV,omitempty"`
+	// Version of the csv(available) that was last observed,
+	// sourced from the installed csv itself.
+	// Allows to diff the desired .spec.version against the actually installed version.
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	// State of the CSV currently installed via OLM.
+	// +optional
+	OLM *AddonOLMStatus `json:"olm,omitempty"`
+	// Reinstalls attempted to recover from a failed CSV.
+	// +optional
+	Recovery *AddonRecoveryStatus `json:"recovery,omitempty"`
+	// Namespaces of the deleted addon stuck in Terminating.
+	// +optional
+	StuckNamespaces []AddonStuckNamespace `json:"stuckNamespaces,omitempty"`
+	// CSV and catalog image the addon is successfully installed with.
+	// +optional
+	KnownGood *AddonKnownGoodStatus `json:"knownGood,omitempty"`
+	// Known good installation preceding the current one,
+	// restored when .spec.rollback is set.
+	// +optional
+	LastKnownGood *AddonKnownGoodStatus `json:"lastKnownGood,omitempty"`
+	// Progress of a Canary upgrade to a new catalog image.
+	// +optional
+	Canary *AddonCanaryStatus `json:"canary,omitempty"`
+	// Digest the catalog image is pinned to,
+	// only present when .catalogSource.pinImageDigest is set.
+	// +optional
+	CatalogSourceImage *AddonCatalogSourceImageStatus `json:"catalogSourceImage,omitempty"`
+	// Health of the Subscription installing the addon via OLM.
+	// +optional
+	Subscription *AddonSubscriptionStatus `json:"subscription,omitempty"`
+	// Alertmanager silence created for the running upgrade,
+	// only present when .spec.monitoring.silenceDuringUpgrade is set.
+	// +optional
+	UpgradeSilence *AddonUpgradeSilenceStatus `json:"upgradeSilence,omitempty"`
+	// Position of the upgrade in the queue of Addon upgrades scheduled by an OCM upgrade policy,
+	// only present while the upgrade waits for other Addons to finish upgrading.
+	// +optional
+	UpgradeQueue *AddonUpgradeQueueStatus `json:"upgradeQueue,omitempty"`
+	// Latest operation allowed by the extension hook configured on the AddonOperator.
+	// The hook is not called again for the same stage and version.
+	// +optional
+	ExtensionHook *AddonExtensionHookStatus `json:"extensionHook,omitempty"`
+	// Error budget of the SLOs in .spec.monitoring.slos, as last evaluated.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SLO []AddonSLOStatus `json:"slo,omitempty"`
+	// Diagnostics of the latest reconcile of the Addon.
+	// +optional
+	LastReconcile *AddonLastReconcileStatus `json:"lastReconcile,omitempty"`
+}
+
+type AddonExtensionHookStatus struct {
+	// Lifecycle stage the extension hook allowed, e.g. "PreInstall" or "PreUpgrade".
+	Stage string `json:"stage"`
+	// Version of the addon the stage was allowed for.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Time the extension hook allowed the stage.
+	AllowedTime metav1.Time `json:"allowedTime"`
+	// Annotations the extension hook returned for the operation.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type AddonSLOStatus struct {
+	// Name of the SLO.
+	Name string `json:"name"`
+	// Percentage of the error budget left within the window, negative once used up.
+	ErrorBudgetRemaining string `json:"errorBudgetRemaining"`
+	// Rate the error budget was used up at over the last hour.
+	BurnRate string `json:"burnRate"`
+	// Time the SLO was last evaluated at.
+	LastEvaluationTime metav1.Time `json:"lastEvaluationTime"`
+}
+
+type AddonUpgradeQueueStatus struct {
+	// Position in the queue, starting at 1 for the next upgrade to start.
+	Position int `json:"position"`
+	// Time the upgrade was queued at. Upgrades start in this order.
+	QueuedSince metav1.Time `json:"queuedSince"`
+}
+
+type AddonLastReconcileStatus struct {
+	// Time the reconcile started at.
+	Time metav1.Time `json:"time"`
+	// Time the reconcile took.
+	Duration metav1.Duration `json:"duration"`
+	// Outcome of the reconcile.
+	Outcome AddonReconcileOutcome `json:"outcome"`
+	// Phase that stopped the reconcile before all phases ran.
+	// Phases of sub-reconcilers are qualified by the sub-reconciler,
+	// e.g. olmReconciler/subscription.
+	// +optional
+	StoppedAt string `json:"stoppedAt,omitempty"`
+	// Error the reconcile failed with.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Completed;Stopped;Failed
+type AddonReconcileOutcome string
+
+const (
+	// All phases ran without errors.
+	ReconcileOutcomeCompleted AddonReconcileOutcome = "Completed"
+	// A phase stopped the reconcile, e.g. to wait for an object to become ready.
+	ReconcileOutcomeStopped AddonReconcileOutcome = "Stopped"
+	// The reconcile failed with an error.
+	ReconcileOutcomeFailed AddonReconcileOutcome = "Failed"
+)
+
+type AddonUpgradeSilenceStatus struct {
+	// ID of the silence in the Alertmanager.
+	ID string `json:"id"`
+	// Time the silence ends at.
+	EndsAt metav1.Time `json:"endsAt"`
+}
+
+type AddonSubscriptionStatus struct {
+	// State of the Subscription as reported by OLM.
+	// +optional
+	State string `json:"state,omitempty"`
+	// Conditions of the Subscription that need attention, copied verbatim.
+	// +optional
+	Conditions []AddonSubscriptionCondition `json:"conditions,omitempty"`
+}
+
+type AddonSubscriptionCondition struct {
+	// Type of the Subscription condition, e.g. ResolutionFailed.
+	Type string `json:"type"`
+	// Reason of the Subscription condition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message of the Subscription condition.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Last time the Subscription condition transitioned.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+type AddonKnownGoodStatus struct {
+	// Namespaced name of the CSV that succeeded.
+	CSV string `json:"csv"`
+	// Version of the CSV.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Image of the CatalogSource the CSV was installed from.
+	CatalogSourceImage string `json:"catalogSourceImage"`
+}
+
+type AddonCanaryStatus struct {
+	// Catalog image served by the staging CatalogSource.
+	Image string `json:"image"`
+	// CSV the staging catalog would install.
+	// +optional
+	TargetCSV string `json:"targetCSV,omitempty"`
+	// Time since which the staging catalog has been healthy.
+	// +optional
+	HealthySince *metav1.Time `json:"healthySince,omitempty"`
+}
+
+type AddonCatalogSourceImageStatus struct {
+	// Catalog image as referenced in the Addon.
+	Image string `json:"image"`
+	// Digest the catalog image was resolved to.
+	Digest string `json:"digest"`
+}
+
+type AddonStuckNamespace struct {
+	// Name of the namespace.
+	Name string `json:"name"`
+	// Time the deletion of the namespace was requested.
+	TerminatingSince metav1.Time `json:"terminatingSince"`
+	// Resources and finalizers blocking the deletion,
+	// as reported in the namespace conditions.
+	// +optional
+	BlockingResources []string `json:"blockingResources,omitempty"`
+}
+
+type AddonRecoveryStatus struct {
+	// Number of reinstalls attempted since the CSV last succeeded.
+	Attempts int32 `json:"attempts"`
+	// Time of the last reinstall attempt.
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+	// Name of the failed CSV that caused the last reinstall attempt.
+	// +optional
+	FailedCSV string `json:"failedCSV,omitempty"`
+}
+
+type AddonOLMStatus struct {
+	// Name of the installed CSV.
+	InstalledCSV string `json:"installedCSV"`
+	// Version of the installed CSV.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Phase of the installed CSV.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// Message of the most recent condition of the installed CSV.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type AddOnStatusCondition struct {
@@ -391,6 +1983,30 @@ type OCMAddOnStatusHash struct {
 	ObservedGeneration int64 `json:"observedGeneration"`
 }
 
+type OCMAddOnInstallationStatus struct {
+	// Reported installation state, e.g. "installing" or "ready".
+	State string `json:"state"`
+	// Reported human readable description of the state.
+	// +optional
+	StateDescription string `json:"stateDescription,omitempty"`
+	// Reported installed version.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// The most recent generation the reported state was based on.
+	ObservedGeneration int64 `json:"observedGeneration"`
+}
+
+type AddonOCMParametersStatus struct {
+	// Names of the parameters synced from OCM.
+	// Values are only stored in the parameters Secret.
+	// +optional
+	Names []string `json:"names,omitempty"`
+	// Hash over the synced parameter values, changing it rolls out the addon.
+	Hash string `json:"hash"`
+	// Time the parameters were last synced from OCM.
+	LastSyncTime metav1.Time `json:"lastSyncTime"`
+}
+
 // Struct used to hash the reported addon status (along with correlationID).
 type OCMAddOnStatus struct {
 	// ID of the addon.
@@ -408,10 +2024,36 @@ type AddonPhase string
 // Well-known Addon Phases for printing a Status in kubectl,
 // see deprecation notice in AddonStatus for details.
 const (
-	PhasePending     AddonPhase = "Pending"
-	PhaseReady       AddonPhase = "Ready"
+	// Waiting for other Addons or the extension hook before installing.
+	PhasePending AddonPhase = "Pending"
+	// Installed for the first time, not yet available.
+	PhaseInstalling AddonPhase = "Installing"
+	PhaseReady      AddonPhase = "Ready"
+	// Upgrading to a new version, not yet available.
+	PhaseUpgrading AddonPhase = "Upgrading"
+	// Installed, but no longer available.
+	PhaseDegraded    AddonPhase = "Degraded"
 	PhaseTerminating AddonPhase = "Terminating"
-	PhaseError       AddonPhase = "Error"
+	// Misconfigured or failed to resolve its dependencies.
+	PhaseError AddonPhase = "Error"
+)
+
+// Records when the Addon last entered a phase.
+type AddonPhaseTransition struct {
+	Phase AddonPhase `json:"phase"`
+	// Time the Addon last transitioned into the phase.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
+// +kubebuilder:validation:Enum=Installing;Ready;Degraded;Upgrading;Deleting
+type AddonShortStatus string
+
+const (
+	ShortStatusInstalling AddonShortStatus = "Installing"
+	ShortStatusReady      AddonShortStatus = "Ready"
+	ShortStatusDegraded   AddonShortStatus = "Degraded"
+	ShortStatusUpgrading  AddonShortStatus = "Upgrading"
+	ShortStatusDeleting   AddonShortStatus = "Deleting"
 )
 
 // Addon is the Schema for the Addons API
@@ -441,8 +2083,13 @@ const (
 //
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.shortStatus"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Installed",type="string",JSONPath=".status.installedVersion",priority=1
+// +kubebuilder:printcolumn:name="Last Transition",type="date",JSONPath=`.status.conditions[?(@.type=="Available")].lastTransitionTime`
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Addon struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -472,6 +2119,24 @@ func (a *Addon) UpgradeCompleteForCurrentVersion() bool {
 		a.Status.UpgradePolicy.Value == AddonUpgradePolicyValueCompleted
 }
 
+// Returns whether the upgrade to the current version may be rolled out.
+// Manual upgrades must be approved in OCM first.
+func (a *Addon) UpgradeApprovedForCurrentVersion() bool {
+	if a.Spec.UpgradePolicy == nil || a.Spec.UpgradePolicy.Mode != UpgradeModeManual {
+		return true
+	}
+	if a.Status.UpgradePolicy == nil || a.Status.UpgradePolicy.Version != a.Spec.Version {
+		return false
+	}
+	switch a.Status.UpgradePolicy.Value {
+	case AddonUpgradePolicyValueApproved,
+		AddonUpgradePolicyValueStarted,
+		AddonUpgradePolicyValueCompleted:
+		return true
+	}
+	return false
+}
+
 // AddonList contains a list of Addon
 // +kubebuilder:object:root=true
 type AddonList struct {
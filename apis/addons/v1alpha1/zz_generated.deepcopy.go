@@ -6,6 +6,8 @@ package v1alpha1
 
 import (
 	monitoringv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -28,6 +30,16 @@ func (in *AddOnStatusCondition) DeepCopy() *AddOnStatusCondition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AdditionalCatalogSource) DeepCopyInto(out *AdditionalCatalogSource) {
 	*out = *in
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Subscription != nil {
+		in, out := &in.Subscription, &out.Subscription
+		*out = new(AdditionalCatalogSourceSubscription)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalCatalogSource.
@@ -40,6 +52,50 @@ func (in *AdditionalCatalogSource) DeepCopy() *AdditionalCatalogSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalCatalogSourceSubscription) DeepCopyInto(out *AdditionalCatalogSourceSubscription) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalCatalogSourceSubscription.
+func (in *AdditionalCatalogSourceSubscription) DeepCopy() *AdditionalCatalogSourceSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalCatalogSourceSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalMetadata) DeepCopyInto(out *AdditionalMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalMetadata.
+func (in *AdditionalMetadata) DeepCopy() *AdditionalMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Addon) DeepCopyInto(out *Addon) {
 	*out = *in
@@ -67,6 +123,63 @@ func (in *Addon) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonCanaryStatus) DeepCopyInto(out *AddonCanaryStatus) {
+	*out = *in
+	if in.HealthySince != nil {
+		in, out := &in.HealthySince, &out.HealthySince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonCanaryStatus.
+func (in *AddonCanaryStatus) DeepCopy() *AddonCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonCatalogSourceImageStatus) DeepCopyInto(out *AddonCatalogSourceImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonCatalogSourceImageStatus.
+func (in *AddonCatalogSourceImageStatus) DeepCopy() *AddonCatalogSourceImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonCatalogSourceImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonExtensionHookStatus) DeepCopyInto(out *AddonExtensionHookStatus) {
+	*out = *in
+	in.AllowedTime.DeepCopyInto(&out.AllowedTime)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonExtensionHookStatus.
+func (in *AddonExtensionHookStatus) DeepCopy() *AddonExtensionHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonExtensionHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonInstallOLMAllNamespaces) DeepCopyInto(out *AddonInstallOLMAllNamespaces) {
 	*out = *in
@@ -86,6 +199,11 @@ func (in *AddonInstallOLMAllNamespaces) DeepCopy() *AddonInstallOLMAllNamespaces
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonInstallOLMCommon) DeepCopyInto(out *AddonInstallOLMCommon) {
 	*out = *in
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(SubscriptionConfig)
@@ -94,7 +212,24 @@ func (in *AddonInstallOLMCommon) DeepCopyInto(out *AddonInstallOLMCommon) {
 	if in.AdditionalCatalogSources != nil {
 		in, out := &in.AdditionalCatalogSources, &out.AdditionalCatalogSources
 		*out = make([]AdditionalCatalogSource, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SubscriptionMetadata != nil {
+		in, out := &in.SubscriptionMetadata, &out.SubscriptionMetadata
+		*out = new(AdditionalMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CatalogSourceMetadata != nil {
+		in, out := &in.CatalogSourceMetadata, &out.CatalogSourceMetadata
+		*out = new(AdditionalMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CatalogSource != nil {
+		in, out := &in.CatalogSource, &out.CatalogSource
+		*out = new(CatalogSourceConfig)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -124,6 +259,21 @@ func (in *AddonInstallOLMOwnNamespace) DeepCopy() *AddonInstallOLMOwnNamespace {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonInstallPackageOperator) DeepCopyInto(out *AddonInstallPackageOperator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonInstallPackageOperator.
+func (in *AddonInstallPackageOperator) DeepCopy() *AddonInstallPackageOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonInstallPackageOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonInstallSpec) DeepCopyInto(out *AddonInstallSpec) {
 	*out = *in
@@ -137,6 +287,11 @@ func (in *AddonInstallSpec) DeepCopyInto(out *AddonInstallSpec) {
 		*out = new(AddonInstallOLMOwnNamespace)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PackageOperator != nil {
+		in, out := &in.PackageOperator, &out.PackageOperator
+		*out = new(AddonInstallPackageOperator)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonInstallSpec.
@@ -176,6 +331,27 @@ func (in *AddonInstance) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonInstanceHistoryEvent) DeepCopyInto(out *AddonInstanceHistoryEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonInstanceHistoryEvent.
+func (in *AddonInstanceHistoryEvent) DeepCopy() *AddonInstanceHistoryEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonInstanceHistoryEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonInstanceList) DeepCopyInto(out *AddonInstanceList) {
 	*out = *in
@@ -235,6 +411,13 @@ func (in *AddonInstanceStatus) DeepCopyInto(out *AddonInstanceStatus) {
 		}
 	}
 	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AddonInstanceHistoryEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonInstanceStatus.
@@ -248,134 +431,123 @@ func (in *AddonInstanceStatus) DeepCopy() *AddonInstanceStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonList) DeepCopyInto(out *AddonList) {
+func (in *AddonKnownGoodStatus) DeepCopyInto(out *AddonKnownGoodStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Addon, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonList.
-func (in *AddonList) DeepCopy() *AddonList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonKnownGoodStatus.
+func (in *AddonKnownGoodStatus) DeepCopy() *AddonKnownGoodStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonList)
+	out := new(AddonKnownGoodStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AddonList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonNamespace) DeepCopyInto(out *AddonNamespace) {
+func (in *AddonLastReconcileStatus) DeepCopyInto(out *AddonLastReconcileStatus) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonNamespace.
-func (in *AddonNamespace) DeepCopy() *AddonNamespace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonLastReconcileStatus.
+func (in *AddonLastReconcileStatus) DeepCopy() *AddonLastReconcileStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonNamespace)
+	out := new(AddonLastReconcileStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonOperator) DeepCopyInto(out *AddonOperator) {
+func (in *AddonLifecycleHook) DeepCopyInto(out *AddonLifecycleHook) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperator.
-func (in *AddonOperator) DeepCopy() *AddonOperator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonLifecycleHook.
+func (in *AddonLifecycleHook) DeepCopy() *AddonLifecycleHook {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonOperator)
+	out := new(AddonLifecycleHook)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AddonOperator) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonOperatorFeatureToggles) DeepCopyInto(out *AddonOperatorFeatureToggles) {
+func (in *AddonLifecycleHooks) DeepCopyInto(out *AddonLifecycleHooks) {
 	*out = *in
+	if in.PreInstall != nil {
+		in, out := &in.PreInstall, &out.PreInstall
+		*out = new(AddonLifecycleHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = new(AddonLifecycleHook)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorFeatureToggles.
-func (in *AddonOperatorFeatureToggles) DeepCopy() *AddonOperatorFeatureToggles {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonLifecycleHooks.
+func (in *AddonLifecycleHooks) DeepCopy() *AddonLifecycleHooks {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonOperatorFeatureToggles)
+	out := new(AddonLifecycleHooks)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonOperatorList) DeepCopyInto(out *AddonOperatorList) {
+func (in *AddonList) DeepCopyInto(out *AddonList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]AddonOperator, len(*in))
+		*out = make([]Addon, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorList.
-func (in *AddonOperatorList) DeepCopy() *AddonOperatorList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonList.
+func (in *AddonList) DeepCopy() *AddonList {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonOperatorList)
+	out := new(AddonList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *AddonOperatorList) DeepCopyObject() runtime.Object {
+func (in *AddonList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -383,232 +555,1388 @@ func (in *AddonOperatorList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonOperatorOCM) DeepCopyInto(out *AddonOperatorOCM) {
+func (in *AddonMaintenanceWindow) DeepCopyInto(out *AddonMaintenanceWindow) {
 	*out = *in
-	out.Secret = in.Secret
+	out.Duration = in.Duration
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorOCM.
-func (in *AddonOperatorOCM) DeepCopy() *AddonOperatorOCM {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMaintenanceWindow.
+func (in *AddonMaintenanceWindow) DeepCopy() *AddonMaintenanceWindow {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonOperatorOCM)
+	out := new(AddonMaintenanceWindow)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonOperatorSpec) DeepCopyInto(out *AddonOperatorSpec) {
+func (in *AddonNamespace) DeepCopyInto(out *AddonNamespace) {
 	*out = *in
-	out.FeatureToggles = in.FeatureToggles
-	if in.OCM != nil {
-		in, out := &in.OCM, &out.OCM
-		*out = new(AddonOperatorOCM)
-		**out = **in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PropagateSecrets != nil {
+		in, out := &in.PropagateSecrets, &out.PropagateSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorSpec.
-func (in *AddonOperatorSpec) DeepCopy() *AddonOperatorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonNamespace.
+func (in *AddonNamespace) DeepCopy() *AddonNamespace {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonOperatorSpec)
+	out := new(AddonNamespace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonOperatorStatus) DeepCopyInto(out *AddonOperatorStatus) {
+func (in *AddonNetwork) DeepCopyInto(out *AddonNetwork) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]AddonNetworkPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorStatus.
-func (in *AddonOperatorStatus) DeepCopy() *AddonOperatorStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonNetwork.
+func (in *AddonNetwork) DeepCopy() *AddonNetwork {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonOperatorStatus)
+	out := new(AddonNetwork)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonPackageOperator) DeepCopyInto(out *AddonPackageOperator) {
+func (in *AddonNetworkPolicy) DeepCopyInto(out *AddonNetworkPolicy) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonPackageOperator.
-func (in *AddonPackageOperator) DeepCopy() *AddonPackageOperator {
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonNetworkPolicy.
+func (in *AddonNetworkPolicy) DeepCopy() *AddonNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOCMParameterSync) DeepCopyInto(out *AddonOCMParameterSync) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOCMParameterSync.
+func (in *AddonOCMParameterSync) DeepCopy() *AddonOCMParameterSync {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOCMParameterSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOCMParametersStatus) DeepCopyInto(out *AddonOCMParametersStatus) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastSyncTime.DeepCopyInto(&out.LastSyncTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOCMParametersStatus.
+func (in *AddonOCMParametersStatus) DeepCopy() *AddonOCMParametersStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOCMParametersStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOLMStatus) DeepCopyInto(out *AddonOLMStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOLMStatus.
+func (in *AddonOLMStatus) DeepCopy() *AddonOLMStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOLMStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperator) DeepCopyInto(out *AddonOperator) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperator.
+func (in *AddonOperator) DeepCopy() *AddonOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AddonOperator) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorBackoffPolicy) DeepCopyInto(out *AddonOperatorBackoffPolicy) {
+	*out = *in
+	if in.OCMError != nil {
+		in, out := &in.OCMError, &out.OCMError
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.OLMNotReady != nil {
+		in, out := &in.OLMNotReady, &out.OLMNotReady
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ValidationFailure != nil {
+		in, out := &in.ValidationFailure, &out.ValidationFailure
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorBackoffPolicy.
+func (in *AddonOperatorBackoffPolicy) DeepCopy() *AddonOperatorBackoffPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorBackoffPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorDeadMansSnitch) DeepCopyInto(out *AddonOperatorDeadMansSnitch) {
+	*out = *in
+	out.APITokenSecret = in.APITokenSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorDeadMansSnitch.
+func (in *AddonOperatorDeadMansSnitch) DeepCopy() *AddonOperatorDeadMansSnitch {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorDeadMansSnitch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorExtensionHook) DeepCopyInto(out *AddonOperatorExtensionHook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorExtensionHook.
+func (in *AddonOperatorExtensionHook) DeepCopy() *AddonOperatorExtensionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorExtensionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorFeatureToggles) DeepCopyInto(out *AddonOperatorFeatureToggles) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorFeatureToggles.
+func (in *AddonOperatorFeatureToggles) DeepCopy() *AddonOperatorFeatureToggles {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorFeatureToggles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorList) DeepCopyInto(out *AddonOperatorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AddonOperator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorList.
+func (in *AddonOperatorList) DeepCopy() *AddonOperatorList {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AddonOperatorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorMetricsRemoteWrite) DeepCopyInto(out *AddonOperatorMetricsRemoteWrite) {
+	*out = *in
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(monitoringv1.OAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorMetricsRemoteWrite.
+func (in *AddonOperatorMetricsRemoteWrite) DeepCopy() *AddonOperatorMetricsRemoteWrite {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorMetricsRemoteWrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorOCM) DeepCopyInto(out *AddonOperatorOCM) {
+	*out = *in
+	out.Secret = in.Secret
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(AddonOperatorOCMAuth)
+		**out = **in
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(AddonOperatorOCMTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorOCM.
+func (in *AddonOperatorOCM) DeepCopy() *AddonOperatorOCM {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorOCM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorOCMAuth) DeepCopyInto(out *AddonOperatorOCMAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorOCMAuth.
+func (in *AddonOperatorOCMAuth) DeepCopy() *AddonOperatorOCMAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorOCMAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorOCMTimeouts) DeepCopyInto(out *AddonOperatorOCMTimeouts) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UpgradePolicies != nil {
+		in, out := &in.UpgradePolicies, &out.UpgradePolicies
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AddOnStatus != nil {
+		in, out := &in.AddOnStatus, &out.AddOnStatus
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.AddOnInstallations != nil {
+		in, out := &in.AddOnInstallations, &out.AddOnInstallations
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ServiceLogs != nil {
+		in, out := &in.ServiceLogs, &out.ServiceLogs
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorOCMTimeouts.
+func (in *AddonOperatorOCMTimeouts) DeepCopy() *AddonOperatorOCMTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorOCMTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorObjectMetadata) DeepCopyInto(out *AddonOperatorObjectMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorObjectMetadata.
+func (in *AddonOperatorObjectMetadata) DeepCopy() *AddonOperatorObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorPagerDuty) DeepCopyInto(out *AddonOperatorPagerDuty) {
+	*out = *in
+	out.APIKeySecret = in.APIKeySecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorPagerDuty.
+func (in *AddonOperatorPagerDuty) DeepCopy() *AddonOperatorPagerDuty {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorPagerDuty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorSpec) DeepCopyInto(out *AddonOperatorSpec) {
+	*out = *in
+	out.FeatureToggles = in.FeatureToggles
+	if in.OCM != nil {
+		in, out := &in.OCM, &out.OCM
+		*out = new(AddonOperatorOCM)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsRemoteWrite != nil {
+		in, out := &in.MetricsRemoteWrite, &out.MetricsRemoteWrite
+		*out = new(AddonOperatorMetricsRemoteWrite)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtensionHook != nil {
+		in, out := &in.ExtensionHook, &out.ExtensionHook
+		*out = new(AddonOperatorExtensionHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackoffPolicy != nil {
+		in, out := &in.BackoffPolicy, &out.BackoffPolicy
+		*out = new(AddonOperatorBackoffPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectMetadata != nil {
+		in, out := &in.ObjectMetadata, &out.ObjectMetadata
+		*out = new(AddonOperatorObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurityConfig)
+		**out = **in
+	}
+	if in.ThanosRuler != nil {
+		in, out := &in.ThanosRuler, &out.ThanosRuler
+		*out = new(AddonOperatorThanosRuler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadMansSnitch != nil {
+		in, out := &in.DeadMansSnitch, &out.DeadMansSnitch
+		*out = new(AddonOperatorDeadMansSnitch)
+		**out = **in
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(AddonOperatorPagerDuty)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorSpec.
+func (in *AddonOperatorSpec) DeepCopy() *AddonOperatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorStatus) DeepCopyInto(out *AddonOperatorStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastHeartbeatTime.DeepCopyInto(&out.LastHeartbeatTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorStatus.
+func (in *AddonOperatorStatus) DeepCopy() *AddonOperatorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonOperatorThanosRuler) DeepCopyInto(out *AddonOperatorThanosRuler) {
+	*out = *in
+	if in.RuleSelector != nil {
+		in, out := &in.RuleSelector, &out.RuleSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonOperatorThanosRuler.
+func (in *AddonOperatorThanosRuler) DeepCopy() *AddonOperatorThanosRuler {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonOperatorThanosRuler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonPackageOperator) DeepCopyInto(out *AddonPackageOperator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonPackageOperator.
+func (in *AddonPackageOperator) DeepCopy() *AddonPackageOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonPackageOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonParameter) DeepCopyInto(out *AddonParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonParameter.
+func (in *AddonParameter) DeepCopy() *AddonParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonPhaseTransition) DeepCopyInto(out *AddonPhaseTransition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonPhaseTransition.
+func (in *AddonPhaseTransition) DeepCopy() *AddonPhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonPhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonPlacement) DeepCopyInto(out *AddonPlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonPlacement.
+func (in *AddonPlacement) DeepCopy() *AddonPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonPodMonitor) DeepCopyInto(out *AddonPodMonitor) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonPodMonitor.
+func (in *AddonPodMonitor) DeepCopy() *AddonPodMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonPodMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonPriorityClass) DeepCopyInto(out *AddonPriorityClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonPriorityClass.
+func (in *AddonPriorityClass) DeepCopy() *AddonPriorityClass {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonPriorityClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonProbe) DeepCopyInto(out *AddonProbe) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonProbe.
+func (in *AddonProbe) DeepCopy() *AddonProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonProxy) DeepCopyInto(out *AddonProxy) {
+	*out = *in
+	if in.TrustedCA != nil {
+		in, out := &in.TrustedCA, &out.TrustedCA
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonProxy.
+func (in *AddonProxy) DeepCopy() *AddonProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonReadinessProbe) DeepCopyInto(out *AddonReadinessProbe) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(AddonReadinessProbeHTTPGet)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(AddonReadinessProbeExec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonReadinessProbe.
+func (in *AddonReadinessProbe) DeepCopy() *AddonReadinessProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonReadinessProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonReadinessProbeExec) DeepCopyInto(out *AddonReadinessProbeExec) {
+	*out = *in
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonReadinessProbeExec.
+func (in *AddonReadinessProbeExec) DeepCopy() *AddonReadinessProbeExec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonReadinessProbeExec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonReadinessProbeHTTPGet) DeepCopyInto(out *AddonReadinessProbeHTTPGet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonReadinessProbeHTTPGet.
+func (in *AddonReadinessProbeHTTPGet) DeepCopy() *AddonReadinessProbeHTTPGet {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonReadinessProbeHTTPGet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonRecoveryStatus) DeepCopyInto(out *AddonRecoveryStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonRecoveryStatus.
+func (in *AddonRecoveryStatus) DeepCopy() *AddonRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonResourceConstraints) DeepCopyInto(out *AddonResourceConstraints) {
+	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(corev1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(corev1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonResourceConstraints.
+func (in *AddonResourceConstraints) DeepCopy() *AddonResourceConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonResourceConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSLO) DeepCopyInto(out *AddonSLO) {
+	*out = *in
+	out.Indicator = in.Indicator
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSLO.
+func (in *AddonSLO) DeepCopy() *AddonSLO {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSLO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSLOIndicator) DeepCopyInto(out *AddonSLOIndicator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSLOIndicator.
+func (in *AddonSLOIndicator) DeepCopy() *AddonSLOIndicator {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSLOIndicator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSLOStatus) DeepCopyInto(out *AddonSLOStatus) {
+	*out = *in
+	in.LastEvaluationTime.DeepCopyInto(&out.LastEvaluationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSLOStatus.
+func (in *AddonSLOStatus) DeepCopy() *AddonSLOStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSLOStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSecretPropagation) DeepCopyInto(out *AddonSecretPropagation) {
+	*out = *in
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]AddonSecretPropagationReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSecretPropagation.
+func (in *AddonSecretPropagation) DeepCopy() *AddonSecretPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSecretPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSecretPropagationReference) DeepCopyInto(out *AddonSecretPropagationReference) {
+	*out = *in
+	out.SourceSecret = in.SourceSecret
+	out.DestinationSecret = in.DestinationSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSecretPropagationReference.
+func (in *AddonSecretPropagationReference) DeepCopy() *AddonSecretPropagationReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSecretPropagationReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonServiceMonitor) DeepCopyInto(out *AddonServiceMonitor) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonServiceMonitor.
+func (in *AddonServiceMonitor) DeepCopy() *AddonServiceMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonServiceMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]AddonNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Install.DeepCopyInto(&out.Install)
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(AddonUpgradePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretPropagation != nil {
+		in, out := &in.SecretPropagation, &out.SecretPropagation
+		*out = new(AddonSecretPropagation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AddonPackageOperator != nil {
+		in, out := &in.AddonPackageOperator, &out.AddonPackageOperator
+		*out = new(AddonPackageOperator)
+		**out = **in
+	}
+	if in.ReadinessProbes != nil {
+		in, out := &in.ReadinessProbes, &out.ReadinessProbes
+		*out = make([]AddonReadinessProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(AddonNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceConstraints != nil {
+		in, out := &in.ResourceConstraints, &out.ResourceConstraints
+		*out = new(AddonResourceConstraints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]AddonParameter, len(*in))
+		copy(*out, *in)
+	}
+	if in.OCMParameterSync != nil {
+		in, out := &in.OCMParameterSync, &out.OCMParameterSync
+		*out = new(AddonOCMParameterSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = new(AddonLifecycleHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(AddonPlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClass != nil {
+		in, out := &in.PriorityClass, &out.PriorityClass
+		*out = new(AddonPriorityClass)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(AddonProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecurityConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSpec.
+func (in *AddonSpec) DeepCopy() *AddonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PhaseTransitions != nil {
+		in, out := &in.PhaseTransitions, &out.PhaseTransitions
+		*out = make([]AddonPhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpgradePolicy != nil {
+		in, out := &in.UpgradePolicy, &out.UpgradePolicy
+		*out = new(AddonUpgradePolicyStatus)
+		**out = **in
+	}
+	if in.OCMReportedStatusHash != nil {
+		in, out := &in.OCMReportedStatusHash, &out.OCMReportedStatusHash
+		*out = new(OCMAddOnStatusHash)
+		**out = **in
+	}
+	if in.OCMReportedInstallation != nil {
+		in, out := &in.OCMReportedInstallation, &out.OCMReportedInstallation
+		*out = new(OCMAddOnInstallationStatus)
+		**out = **in
+	}
+	if in.OCMParameters != nil {
+		in, out := &in.OCMParameters, &out.OCMParameters
+		*out = new(AddonOCMParametersStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OLM != nil {
+		in, out := &in.OLM, &out.OLM
+		*out = new(AddonOLMStatus)
+		**out = **in
+	}
+	if in.Recovery != nil {
+		in, out := &in.Recovery, &out.Recovery
+		*out = new(AddonRecoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StuckNamespaces != nil {
+		in, out := &in.StuckNamespaces, &out.StuckNamespaces
+		*out = make([]AddonStuckNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KnownGood != nil {
+		in, out := &in.KnownGood, &out.KnownGood
+		*out = new(AddonKnownGoodStatus)
+		**out = **in
+	}
+	if in.LastKnownGood != nil {
+		in, out := &in.LastKnownGood, &out.LastKnownGood
+		*out = new(AddonKnownGoodStatus)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(AddonCanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CatalogSourceImage != nil {
+		in, out := &in.CatalogSourceImage, &out.CatalogSourceImage
+		*out = new(AddonCatalogSourceImageStatus)
+		**out = **in
+	}
+	if in.Subscription != nil {
+		in, out := &in.Subscription, &out.Subscription
+		*out = new(AddonSubscriptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeSilence != nil {
+		in, out := &in.UpgradeSilence, &out.UpgradeSilence
+		*out = new(AddonUpgradeSilenceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeQueue != nil {
+		in, out := &in.UpgradeQueue, &out.UpgradeQueue
+		*out = new(AddonUpgradeQueueStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtensionHook != nil {
+		in, out := &in.ExtensionHook, &out.ExtensionHook
+		*out = new(AddonExtensionHookStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SLO != nil {
+		in, out := &in.SLO, &out.SLO
+		*out = make([]AddonSLOStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastReconcile != nil {
+		in, out := &in.LastReconcile, &out.LastReconcile
+		*out = new(AddonLastReconcileStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
+func (in *AddonStatus) DeepCopy() *AddonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonStuckNamespace) DeepCopyInto(out *AddonStuckNamespace) {
+	*out = *in
+	in.TerminatingSince.DeepCopyInto(&out.TerminatingSince)
+	if in.BlockingResources != nil {
+		in, out := &in.BlockingResources, &out.BlockingResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStuckNamespace.
+func (in *AddonStuckNamespace) DeepCopy() *AddonStuckNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonStuckNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSubscriptionCondition) DeepCopyInto(out *AddonSubscriptionCondition) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSubscriptionCondition.
+func (in *AddonSubscriptionCondition) DeepCopy() *AddonSubscriptionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSubscriptionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSubscriptionStatus) DeepCopyInto(out *AddonSubscriptionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]AddonSubscriptionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSubscriptionStatus.
+func (in *AddonSubscriptionStatus) DeepCopy() *AddonSubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonUpgradePolicy) DeepCopyInto(out *AddonUpgradePolicy) {
+	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]AddonMaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.CanaryHealthCheckWindow != nil {
+		in, out := &in.CanaryHealthCheckWindow, &out.CanaryHealthCheckWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradePolicy.
+func (in *AddonUpgradePolicy) DeepCopy() *AddonUpgradePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonUpgradePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonUpgradePolicyStatus) DeepCopyInto(out *AddonUpgradePolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradePolicyStatus.
+func (in *AddonUpgradePolicyStatus) DeepCopy() *AddonUpgradePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonUpgradePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonUpgradeQueueStatus) DeepCopyInto(out *AddonUpgradeQueueStatus) {
+	*out = *in
+	in.QueuedSince.DeepCopyInto(&out.QueuedSince)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradeQueueStatus.
+func (in *AddonUpgradeQueueStatus) DeepCopy() *AddonUpgradeQueueStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonPackageOperator)
+	out := new(AddonUpgradeQueueStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonSecretPropagation) DeepCopyInto(out *AddonSecretPropagation) {
+func (in *AddonUpgradeSilenceStatus) DeepCopyInto(out *AddonUpgradeSilenceStatus) {
 	*out = *in
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = make([]AddonSecretPropagationReference, len(*in))
-		copy(*out, *in)
-	}
+	in.EndsAt.DeepCopyInto(&out.EndsAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSecretPropagation.
-func (in *AddonSecretPropagation) DeepCopy() *AddonSecretPropagation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradeSilenceStatus.
+func (in *AddonUpgradeSilenceStatus) DeepCopy() *AddonUpgradeSilenceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonSecretPropagation)
+	out := new(AddonUpgradeSilenceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonSecretPropagationReference) DeepCopyInto(out *AddonSecretPropagationReference) {
+func (in *CardinalityGuardSpec) DeepCopyInto(out *CardinalityGuardSpec) {
 	*out = *in
-	out.SourceSecret = in.SourceSecret
-	out.DestinationSecret = in.DestinationSecret
+	if in.DropOnLimitExceeded != nil {
+		in, out := &in.DropOnLimitExceeded, &out.DropOnLimitExceeded
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSecretPropagationReference.
-func (in *AddonSecretPropagationReference) DeepCopy() *AddonSecretPropagationReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CardinalityGuardSpec.
+func (in *CardinalityGuardSpec) DeepCopy() *CardinalityGuardSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonSecretPropagationReference)
+	out := new(CardinalityGuardSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
+func (in *CatalogSourceConfig) DeepCopyInto(out *CatalogSourceConfig) {
 	*out = *in
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = make([]AddonNamespace, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CommonLabels != nil {
-		in, out := &in.CommonLabels, &out.CommonLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.CommonAnnotations != nil {
-		in, out := &in.CommonAnnotations, &out.CommonAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Install.DeepCopyInto(&out.Install)
-	if in.UpgradePolicy != nil {
-		in, out := &in.UpgradePolicy, &out.UpgradePolicy
-		*out = new(AddonUpgradePolicy)
-		**out = **in
-	}
-	if in.Monitoring != nil {
-		in, out := &in.Monitoring, &out.Monitoring
-		*out = new(MonitoringSpec)
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(CatalogSourceUpdateStrategy)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.SecretPropagation != nil {
-		in, out := &in.SecretPropagation, &out.SecretPropagation
-		*out = new(AddonSecretPropagation)
+	if in.GrpcPodConfig != nil {
+		in, out := &in.GrpcPodConfig, &out.GrpcPodConfig
+		*out = new(CatalogSourceGrpcPodConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.AddonPackageOperator != nil {
-		in, out := &in.AddonPackageOperator, &out.AddonPackageOperator
-		*out = new(AddonPackageOperator)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSpec.
-func (in *AddonSpec) DeepCopy() *AddonSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSourceConfig.
+func (in *CatalogSourceConfig) DeepCopy() *CatalogSourceConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonSpec)
+	out := new(CatalogSourceConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
+func (in *CatalogSourceGrpcPodConfig) DeepCopyInto(out *CatalogSourceGrpcPodConfig) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.UpgradePolicy != nil {
-		in, out := &in.UpgradePolicy, &out.UpgradePolicy
-		*out = new(AddonUpgradePolicyStatus)
-		**out = **in
-	}
-	if in.OCMReportedStatusHash != nil {
-		in, out := &in.OCMReportedStatusHash, &out.OCMReportedStatusHash
-		*out = new(OCMAddOnStatusHash)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
-func (in *AddonStatus) DeepCopy() *AddonStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSourceGrpcPodConfig.
+func (in *CatalogSourceGrpcPodConfig) DeepCopy() *CatalogSourceGrpcPodConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonStatus)
+	out := new(CatalogSourceGrpcPodConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonUpgradePolicy) DeepCopyInto(out *AddonUpgradePolicy) {
+func (in *CatalogSourceRegistryPoll) DeepCopyInto(out *CatalogSourceRegistryPoll) {
 	*out = *in
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradePolicy.
-func (in *AddonUpgradePolicy) DeepCopy() *AddonUpgradePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSourceRegistryPoll.
+func (in *CatalogSourceRegistryPoll) DeepCopy() *CatalogSourceRegistryPoll {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonUpgradePolicy)
+	out := new(CatalogSourceRegistryPoll)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonUpgradePolicyStatus) DeepCopyInto(out *AddonUpgradePolicyStatus) {
+func (in *CatalogSourceUpdateStrategy) DeepCopyInto(out *CatalogSourceUpdateStrategy) {
 	*out = *in
+	if in.RegistryPoll != nil {
+		in, out := &in.RegistryPoll, &out.RegistryPoll
+		*out = new(CatalogSourceRegistryPoll)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonUpgradePolicyStatus.
-func (in *AddonUpgradePolicyStatus) DeepCopy() *AddonUpgradePolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CatalogSourceUpdateStrategy.
+func (in *CatalogSourceUpdateStrategy) DeepCopy() *CatalogSourceUpdateStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonUpgradePolicyStatus)
+	out := new(CatalogSourceUpdateStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -628,6 +1956,26 @@ func (in *ClusterSecretReference) DeepCopy() *ClusterSecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeadMansSnitchSpec) DeepCopyInto(out *DeadMansSnitchSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeadMansSnitchSpec.
+func (in *DeadMansSnitchSpec) DeepCopy() *DeadMansSnitchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeadMansSnitchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvObject) DeepCopyInto(out *EnvObject) {
 	*out = *in
@@ -643,6 +1991,41 @@ func (in *EnvObject) DeepCopy() *EnvObject {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringFederationAuth) DeepCopyInto(out *MonitoringFederationAuth) {
+	*out = *in
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MonitoringFederationMTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringFederationAuth.
+func (in *MonitoringFederationAuth) DeepCopy() *MonitoringFederationAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringFederationAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringFederationMTLS) DeepCopyInto(out *MonitoringFederationMTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringFederationMTLS.
+func (in *MonitoringFederationMTLS) DeepCopy() *MonitoringFederationMTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringFederationMTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitoringFederationSpec) DeepCopyInto(out *MonitoringFederationSpec) {
 	*out = *in
@@ -658,6 +2041,22 @@ func (in *MonitoringFederationSpec) DeepCopyInto(out *MonitoringFederationSpec)
 			(*out)[key] = val
 		}
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(MonitoringFederationAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringFederationSpec.
@@ -683,6 +2082,51 @@ func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
 		*out = new(MonitoringStackSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]monitoringv1.RuleGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SilenceDuringUpgrade != nil {
+		in, out := &in.SilenceDuringUpgrade, &out.SilenceDuringUpgrade
+		*out = new(SilenceDuringUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMonitors != nil {
+		in, out := &in.ServiceMonitors, &out.ServiceMonitors
+		*out = make([]AddonServiceMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodMonitors != nil {
+		in, out := &in.PodMonitors, &out.PodMonitors
+		*out = make([]AddonPodMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]AddonProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PagerDuty != nil {
+		in, out := &in.PagerDuty, &out.PagerDuty
+		*out = new(PagerDutySpec)
+		**out = **in
+	}
+	if in.SLOs != nil {
+		in, out := &in.SLOs, &out.SLOs
+		*out = make([]AddonSLO, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
@@ -703,6 +2147,21 @@ func (in *MonitoringStackSpec) DeepCopyInto(out *MonitoringStackSpec) {
 		*out = new(RHOBSRemoteWriteConfigSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeadMansSnitch != nil {
+		in, out := &in.DeadMansSnitch, &out.DeadMansSnitch
+		*out = new(DeadMansSnitchSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringStackSpec.
@@ -715,6 +2174,21 @@ func (in *MonitoringStackSpec) DeepCopy() *MonitoringStackSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCMAddOnInstallationStatus) DeepCopyInto(out *OCMAddOnInstallationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCMAddOnInstallationStatus.
+func (in *OCMAddOnInstallationStatus) DeepCopy() *OCMAddOnInstallationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OCMAddOnInstallationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OCMAddOnStatus) DeepCopyInto(out *OCMAddOnStatus) {
 	*out = *in
@@ -750,6 +2224,56 @@ func (in *OCMAddOnStatusHash) DeepCopy() *OCMAddOnStatusHash {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutySpec) DeepCopyInto(out *PagerDutySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutySpec.
+func (in *PagerDutySpec) DeepCopy() *PagerDutySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityConfig) DeepCopyInto(out *PodSecurityConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityConfig.
+func (in *PodSecurityConfig) DeepCopy() *PodSecurityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RHOBSOAuth2ClientCredentials) DeepCopyInto(out *RHOBSOAuth2ClientCredentials) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RHOBSOAuth2ClientCredentials.
+func (in *RHOBSOAuth2ClientCredentials) DeepCopy() *RHOBSOAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(RHOBSOAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RHOBSRemoteWriteConfigSpec) DeepCopyInto(out *RHOBSRemoteWriteConfigSpec) {
 	*out = *in
@@ -758,11 +2282,31 @@ func (in *RHOBSRemoteWriteConfigSpec) DeepCopyInto(out *RHOBSRemoteWriteConfigSp
 		*out = new(monitoringv1.OAuth2)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OAuth2ClientCredentials != nil {
+		in, out := &in.OAuth2ClientCredentials, &out.OAuth2ClientCredentials
+		*out = new(RHOBSOAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(monitoringv1.SecretOrConfigMap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(RHOBSRemoteWriteProxy)
+		**out = **in
+	}
 	if in.Allowlist != nil {
 		in, out := &in.Allowlist, &out.Allowlist
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CardinalityGuard != nil {
+		in, out := &in.CardinalityGuard, &out.CardinalityGuard
+		*out = new(CardinalityGuardSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RHOBSRemoteWriteConfigSpec.
@@ -775,6 +2319,41 @@ func (in *RHOBSRemoteWriteConfigSpec) DeepCopy() *RHOBSRemoteWriteConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RHOBSRemoteWriteProxy) DeepCopyInto(out *RHOBSRemoteWriteProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RHOBSRemoteWriteProxy.
+func (in *RHOBSRemoteWriteProxy) DeepCopy() *RHOBSRemoteWriteProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(RHOBSRemoteWriteProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SilenceDuringUpgradeSpec) DeepCopyInto(out *SilenceDuringUpgradeSpec) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SilenceDuringUpgradeSpec.
+func (in *SilenceDuringUpgradeSpec) DeepCopy() *SilenceDuringUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SilenceDuringUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubscriptionConfig) DeepCopyInto(out *SubscriptionConfig) {
 	*out = *in
@@ -783,6 +2362,25 @@ func (in *SubscriptionConfig) DeepCopyInto(out *SubscriptionConfig) {
 		*out = make([]EnvObject, len(*in))
 		copy(*out, *in)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubscriptionConfig.
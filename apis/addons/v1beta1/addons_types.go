@@ -0,0 +1,206 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// AddonSpec defines the desired state of Addon.
+// Apart from .install, the spec is shared with v1alpha1.
+type AddonSpec struct {
+	// Human readable name for this addon.
+	// +kubebuilder:validation:MinLength=1
+	DisplayName string `json:"displayName"`
+
+	// Version of the Addon to deploy.
+	// Used for reporting via status and metrics.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Pause reconciliation of Addon when set to True
+	// +optional
+	Paused bool `json:"pause"`
+
+	// Defines a list of Kubernetes Namespaces that belong to this Addon.
+	// Namespaces listed here will be created prior to installation of the Addon and
+	// will be removed from the cluster when the Addon is deleted.
+	// Collisions with existing Namespaces are handled according to
+	// the NamespaceCollisionPolicy.
+	Namespaces []v1alpha1.AddonNamespace `json:"namespaces,omitempty"`
+
+	// Defines how Namespaces listed in .spec.namespaces are handled,
+	// which already exist, but are not owned by this Addon.
+	// Defaults to AdoptAlways, adopting all existing Namespaces.
+	// +kubebuilder:validation:Enum=Fail;AdoptIfUnowned;AdoptAlways
+	// +kubebuilder:default=AdoptAlways
+	// +optional
+	NamespaceCollisionPolicy v1alpha1.NamespaceCollisionPolicy `json:"namespaceCollisionPolicy,omitempty"`
+
+	// Priority of the Addon install, Addons with a higher priority are installed first.
+	// A new Addon is only installed once all Addons with a higher priority are Available,
+	// so critical Addons don't compete for OLM and API bandwidth with lower priority ones.
+	// Defaults to 0.
+	// +optional
+	InstallPriority int32 `json:"installPriority,omitempty"`
+
+	// Names of Addons this Addon depends on.
+	// The Addon is only installed once all Addons it depends on are Available.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Labels to be applied to all resources.
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+
+	// Annotations to be applied to all resources.
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+
+	// Correlation ID for co-relating current AddonCR revision and reported status.
+	// +optional
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Defines how an Addon is installed.
+	// This field is immutable.
+	Install AddonInstallSpec `json:"install"`
+
+	// Defines whether the addon needs acknowledgment from the underlying
+	// addon's operator before deletion.
+	// +optional
+	DeleteAckRequired bool `json:"deleteAckRequired"`
+
+	// Protects the addon from being deleted accidentally.
+	// DELETE requests are rejected and the finalizer of the addon
+	// is kept until deletion protection is turned off again.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// Defines which objects are removed when the addon is deleted.
+	// +kubebuilder:default=Cascade
+	// +optional
+	UninstallStrategy v1alpha1.AddonUninstallStrategy `json:"uninstallStrategy,omitempty"`
+
+	// UpgradePolicy enables status reporting via upgrade policies.
+	UpgradePolicy *v1alpha1.AddonUpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// Defines how an addon is monitored.
+	Monitoring *v1alpha1.MonitoringSpec `json:"monitoring,omitempty"`
+
+	// Settings for propagating secrets from the Addon Operator install namespace into Addon namespaces.
+	SecretPropagation *v1alpha1.AddonSecretPropagation `json:"secretPropagation,omitempty"`
+	// defines the PackageOperator image as part of the addon Spec
+	AddonPackageOperator *v1alpha1.AddonPackageOperator `json:"packageOperator,omitempty"`
+
+	// Probes that need to succeed, in addition to the ClusterServiceVersion,
+	// before the Addon is reported as Available.
+	// Useful for Addons that take time to become usable after their operator is running.
+	// +optional
+	ReadinessProbes []v1alpha1.AddonReadinessProbe `json:"readinessProbes,omitempty"`
+
+	// Network isolation of the Addon namespaces.
+	// +optional
+	Network *v1alpha1.AddonNetwork `json:"network,omitempty"`
+
+	// Resource constraints enforced in every Addon namespace.
+	// +optional
+	ResourceConstraints *v1alpha1.AddonResourceConstraints `json:"resourceConstraints,omitempty"`
+
+	// Parameters passed to the addon via the addon-<name>-parameters
+	// ConfigMap and Secret in the install namespace.
+	// +optional
+	Parameters []v1alpha1.AddonParameter `json:"parameters,omitempty"`
+
+	// Syncs the parameter values of the addon installation from OCM
+	// into the addon-<name>-parameters Secret,
+	// overriding .spec.parameters of the same name.
+	// +optional
+	OCMParameterSync *v1alpha1.AddonOCMParameterSync `json:"ocmParameterSync,omitempty"`
+
+	// Jobs run in the install namespace before the addon is installed
+	// and before it is deleted.
+	// +optional
+	LifecycleHooks *v1alpha1.AddonLifecycleHooks `json:"lifecycleHooks,omitempty"`
+
+	// Scheduling constraints for the addon workloads,
+	// passed to the Subscription and the CatalogSource pods.
+	// +optional
+	Placement *v1alpha1.AddonPlacement `json:"placement,omitempty"`
+
+	// Name of an existing PriorityClass for the addon workloads.
+	// Takes precedence over .spec.priorityClass.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// PriorityClass created for the addon workloads.
+	// +optional
+	PriorityClass *v1alpha1.AddonPriorityClass `json:"priorityClass,omitempty"`
+
+	// Proxy configuration of the addon operator.
+	// +optional
+	Proxy *v1alpha1.AddonProxy `json:"proxy,omitempty"`
+
+	// Rolls the addon back to the last known good CSV and catalog image,
+	// as recorded in .status.lastKnownGood.
+	// The addon stays rolled back, until rollback is turned off again.
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+
+	// PodSecurity admission levels labeled on the namespaces of the addon,
+	// overriding the default levels of the AddonOperator per mode.
+	// +optional
+	PodSecurity *v1alpha1.PodSecurityConfig `json:"podSecurity,omitempty"`
+}
+
+// Defines how an Addon is installed.
+// Unlike v1alpha1, both OLM install types share the .olm configuration.
+type AddonInstallSpec struct {
+	// Type of installation.
+	// +kubebuilder:validation:Enum={"OLMOwnNamespace","OLMAllNamespaces","PackageOperator"}
+	Type v1alpha1.AddonInstallType `json:"type"`
+	// OLM config parameters. Present only if Type = OLMOwnNamespace or OLMAllNamespaces.
+	// +optional
+	OLM *v1alpha1.AddonInstallOLMCommon `json:"olm,omitempty"`
+	// PackageOperator config parameters. Present only if Type = PackageOperator.
+	// +optional
+	PackageOperator *v1alpha1.AddonInstallPackageOperator `json:"packageOperator,omitempty"`
+	// Defines how an OLM install recovers from a failed CSV.
+	// +kubebuilder:default=None
+	// +optional
+	Recovery v1alpha1.AddonInstallRecoveryPolicy `json:"recovery,omitempty"`
+}
+
+// Addon is the Schema for the Addons API
+//
+// v1beta1 is served next to v1alpha1, objects are stored as v1alpha1
+// and converted by the addon-operator webhook.
+// The webhook is wired into the CRD by OLM via the CSV
+// and by the dev deployment when it deploys the webhook.
+//
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.shortStatus"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Installed",type="string",JSONPath=".status.installedVersion",priority=1
+// +kubebuilder:printcolumn:name="Last Transition",type="date",JSONPath=`.status.conditions[?(@.type=="Available")].lastTransitionTime`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Addon struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AddonSpec `json:"spec,omitempty"`
+	// +kubebuilder:default={phase:Pending}
+	Status v1alpha1.AddonStatus `json:"status,omitempty"`
+}
+
+// AddonList contains a list of Addon
+// +kubebuilder:object:root=true
+type AddonList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Addon `json:"items"`
+}
+
+func init() {
+	register(&Addon{}, &AddonList{})
+}
@@ -0,0 +1,131 @@
+package v1beta1
+
+import (
+	"github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// ConvertTo converts this Addon to the v1alpha1 storage version.
+func (src *Addon) ConvertTo(dst *v1alpha1.Addon) error {
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = v1alpha1.AddonSpec{
+		DisplayName:              in.Spec.DisplayName,
+		Version:                  in.Spec.Version,
+		Paused:                   in.Spec.Paused,
+		Namespaces:               in.Spec.Namespaces,
+		NamespaceCollisionPolicy: in.Spec.NamespaceCollisionPolicy,
+		InstallPriority:          in.Spec.InstallPriority,
+		DependsOn:                in.Spec.DependsOn,
+		CommonLabels:             in.Spec.CommonLabels,
+		CommonAnnotations:        in.Spec.CommonAnnotations,
+		CorrelationID:            in.Spec.CorrelationID,
+		Install:                  convertInstallToV1alpha1(in.Spec.Install),
+		DeleteAckRequired:        in.Spec.DeleteAckRequired,
+		DeletionProtection:       in.Spec.DeletionProtection,
+		UninstallStrategy:        in.Spec.UninstallStrategy,
+		UpgradePolicy:            in.Spec.UpgradePolicy,
+		Monitoring:               in.Spec.Monitoring,
+		SecretPropagation:        in.Spec.SecretPropagation,
+		AddonPackageOperator:     in.Spec.AddonPackageOperator,
+		ReadinessProbes:          in.Spec.ReadinessProbes,
+		Network:                  in.Spec.Network,
+		ResourceConstraints:      in.Spec.ResourceConstraints,
+		Parameters:               in.Spec.Parameters,
+		OCMParameterSync:         in.Spec.OCMParameterSync,
+		LifecycleHooks:           in.Spec.LifecycleHooks,
+		Placement:                in.Spec.Placement,
+		PriorityClassName:        in.Spec.PriorityClassName,
+		PriorityClass:            in.Spec.PriorityClass,
+		Proxy:                    in.Spec.Proxy,
+		Rollback:                 in.Spec.Rollback,
+		PodSecurity:              in.Spec.PodSecurity,
+	}
+	dst.Status = in.Status
+	return nil
+}
+
+// ConvertFrom converts from the v1alpha1 storage version to this version.
+func (dst *Addon) ConvertFrom(src *v1alpha1.Addon) error {
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	dst.Spec = AddonSpec{
+		DisplayName:              in.Spec.DisplayName,
+		Version:                  in.Spec.Version,
+		Paused:                   in.Spec.Paused,
+		Namespaces:               in.Spec.Namespaces,
+		NamespaceCollisionPolicy: in.Spec.NamespaceCollisionPolicy,
+		InstallPriority:          in.Spec.InstallPriority,
+		DependsOn:                in.Spec.DependsOn,
+		CommonLabels:             in.Spec.CommonLabels,
+		CommonAnnotations:        in.Spec.CommonAnnotations,
+		CorrelationID:            in.Spec.CorrelationID,
+		Install:                  convertInstallFromV1alpha1(in.Spec.Install),
+		DeleteAckRequired:        in.Spec.DeleteAckRequired,
+		DeletionProtection:       in.Spec.DeletionProtection,
+		UninstallStrategy:        in.Spec.UninstallStrategy,
+		UpgradePolicy:            in.Spec.UpgradePolicy,
+		Monitoring:               in.Spec.Monitoring,
+		SecretPropagation:        in.Spec.SecretPropagation,
+		AddonPackageOperator:     in.Spec.AddonPackageOperator,
+		ReadinessProbes:          in.Spec.ReadinessProbes,
+		Network:                  in.Spec.Network,
+		ResourceConstraints:      in.Spec.ResourceConstraints,
+		Parameters:               in.Spec.Parameters,
+		OCMParameterSync:         in.Spec.OCMParameterSync,
+		LifecycleHooks:           in.Spec.LifecycleHooks,
+		Placement:                in.Spec.Placement,
+		PriorityClassName:        in.Spec.PriorityClassName,
+		PriorityClass:            in.Spec.PriorityClass,
+		Proxy:                    in.Spec.Proxy,
+		Rollback:                 in.Spec.Rollback,
+		PodSecurity:              in.Spec.PodSecurity,
+	}
+	dst.Status = in.Status
+	return nil
+}
+
+func convertInstallToV1alpha1(in AddonInstallSpec) v1alpha1.AddonInstallSpec {
+	out := v1alpha1.AddonInstallSpec{
+		Type:            in.Type,
+		PackageOperator: in.PackageOperator,
+		Recovery:        in.Recovery,
+	}
+	if in.OLM == nil {
+		return out
+	}
+
+	// The OLM config is kept for other install types as well,
+	// so it survives a round trip through v1alpha1.
+	if in.Type == v1alpha1.OLMAllNamespaces {
+		out.OLMAllNamespaces = &v1alpha1.AddonInstallOLMAllNamespaces{
+			AddonInstallOLMCommon: *in.OLM,
+		}
+	} else {
+		out.OLMOwnNamespace = &v1alpha1.AddonInstallOLMOwnNamespace{
+			AddonInstallOLMCommon: *in.OLM,
+		}
+	}
+	return out
+}
+
+func convertInstallFromV1alpha1(in v1alpha1.AddonInstallSpec) AddonInstallSpec {
+	out := AddonInstallSpec{
+		Type:            in.Type,
+		PackageOperator: in.PackageOperator,
+		Recovery:        in.Recovery,
+	}
+
+	// Both OLM configs being set is rejected by the validating webhook,
+	// the config matching the install type wins.
+	switch {
+	case in.Type == v1alpha1.OLMAllNamespaces && in.OLMAllNamespaces != nil:
+		out.OLM = &in.OLMAllNamespaces.AddonInstallOLMCommon
+	case in.OLMOwnNamespace != nil:
+		out.OLM = &in.OLMOwnNamespace.AddonInstallOLMCommon
+	case in.OLMAllNamespaces != nil:
+		out.OLM = &in.OLMAllNamespaces.AddonInstallOLMCommon
+	}
+	return out
+}
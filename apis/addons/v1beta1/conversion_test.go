@@ -0,0 +1,121 @@
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+
+	"github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+const fuzzIterations = 1000
+
+func newFuzzer() *fuzz.Fuzzer {
+	return fuzz.New().NilChance(0.2).NumElements(0, 3).Funcs(
+		// apiVersion and kind are set by the caller of the conversion.
+		func(in *metav1.TypeMeta, c fuzz.Continue) {},
+		// Only specs passing the validating webhook have to survive a round trip,
+		// which have the OLM config matching their install type.
+		func(in *v1alpha1.AddonInstallSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(in)
+			switch c.Intn(3) {
+			case 0:
+				in.Type = v1alpha1.OLMOwnNamespace
+				in.OLMAllNamespaces = nil
+			case 1:
+				in.Type = v1alpha1.OLMAllNamespaces
+				in.OLMOwnNamespace = nil
+			default:
+				in.Type = v1alpha1.PackageOperator
+				in.OLMOwnNamespace = nil
+				in.OLMAllNamespaces = nil
+			}
+		},
+	)
+}
+
+func TestAddonRoundTrip_v1alpha1(t *testing.T) {
+	f := newFuzzer()
+	for i := 0; i < fuzzIterations; i++ {
+		original := &v1alpha1.Addon{}
+		f.Fuzz(original)
+
+		beta := &Addon{}
+		if err := beta.ConvertFrom(original); err != nil {
+			t.Fatalf("converting from v1alpha1: %v", err)
+		}
+		roundTripped := &v1alpha1.Addon{}
+		if err := beta.ConvertTo(roundTripped); err != nil {
+			t.Fatalf("converting to v1alpha1: %v", err)
+		}
+
+		if !reflect.DeepEqual(original, roundTripped) {
+			t.Fatalf("v1alpha1 round trip changed the Addon:\n%s",
+				diff.ObjectReflectDiff(original, roundTripped))
+		}
+	}
+}
+
+func TestAddonRoundTrip_v1beta1(t *testing.T) {
+	f := newFuzzer()
+	for i := 0; i < fuzzIterations; i++ {
+		original := &Addon{}
+		f.Fuzz(original)
+
+		alpha := &v1alpha1.Addon{}
+		if err := original.ConvertTo(alpha); err != nil {
+			t.Fatalf("converting to v1alpha1: %v", err)
+		}
+		roundTripped := &Addon{}
+		if err := roundTripped.ConvertFrom(alpha); err != nil {
+			t.Fatalf("converting from v1alpha1: %v", err)
+		}
+
+		if !reflect.DeepEqual(original, roundTripped) {
+			t.Fatalf("v1beta1 round trip changed the Addon:\n%s",
+				diff.ObjectReflectDiff(original, roundTripped))
+		}
+	}
+}
+
+func TestConvertInstall(t *testing.T) {
+	olm := &v1alpha1.AddonInstallOLMCommon{
+		Namespace:          "reference-addon",
+		CatalogSourceImage: "quay.io/osd-addons/reference-addon-index",
+		Channel:            "alpha",
+		PackageName:        "reference-addon",
+	}
+
+	for name, tc := range map[string]struct {
+		beta  AddonInstallSpec
+		alpha v1alpha1.AddonInstallSpec
+	}{
+		"OLMOwnNamespace": {
+			beta: AddonInstallSpec{Type: v1alpha1.OLMOwnNamespace, OLM: olm},
+			alpha: v1alpha1.AddonInstallSpec{
+				Type:            v1alpha1.OLMOwnNamespace,
+				OLMOwnNamespace: &v1alpha1.AddonInstallOLMOwnNamespace{AddonInstallOLMCommon: *olm},
+			},
+		},
+		"OLMAllNamespaces": {
+			beta: AddonInstallSpec{Type: v1alpha1.OLMAllNamespaces, OLM: olm},
+			alpha: v1alpha1.AddonInstallSpec{
+				Type:             v1alpha1.OLMAllNamespaces,
+				OLMAllNamespaces: &v1alpha1.AddonInstallOLMAllNamespaces{AddonInstallOLMCommon: *olm},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if alpha := convertInstallToV1alpha1(tc.beta); !reflect.DeepEqual(tc.alpha, alpha) {
+				t.Errorf("unexpected v1alpha1 install spec:\n%s", diff.ObjectReflectDiff(tc.alpha, alpha))
+			}
+			if beta := convertInstallFromV1alpha1(tc.alpha); !reflect.DeepEqual(tc.beta, beta) {
+				t.Errorf("unexpected v1beta1 install spec:\n%s", diff.ObjectReflectDiff(tc.beta, beta))
+			}
+		})
+	}
+}
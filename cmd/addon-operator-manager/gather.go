@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/addon-operator/internal/gather"
+)
+
+// Subcommand collecting the troubleshooting archive of an Addon.
+const gatherCommand = "gather"
+
+// Writes the troubleshooting archive of an Addon,
+// e.g. addon-operator-manager gather --addon my-addon.
+func runGather(args []string) error {
+	var (
+		addonName    string
+		output       string
+		namespace    = os.Getenv("ADDON_OPERATOR_NAMESPACE")
+		logTailLines int64
+	)
+	flags := flag.NewFlagSet(gatherCommand, flag.ExitOnError)
+	flags.StringVar(&addonName, "addon", "", "Name of the Addon to collect.")
+	flags.StringVar(&output, "output", "",
+		`File the archive is written to, "-" for stdout. Defaults to addon-<name>.tar.gz.`)
+	flags.StringVar(&namespace, "namespace", namespace,
+		"Namespace of the addon-operator, to capture its log lines mentioning the Addon from.")
+	flags.Int64Var(&logTailLines, "log-tail-lines", gather.DefaultLogTailLines,
+		"Number of lines captured from the end of each container log.")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(addonName) == 0 {
+		return errors.New("--addon must be set")
+	}
+	if len(output) == 0 {
+		output = fmt.Sprintf("addon-%s.tar.gz", addonName)
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return fmt.Errorf("getting kubeconfig: %w", err)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("setting up client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("setting up clientset: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("creating archive: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	g := gather.NewGatherer(c, clientset,
+		gather.WithOperatorNamespace(namespace),
+		gather.WithLogTailLines(logTailLines),
+	)
+	if err := g.Gather(context.Background(), addonName, w); err != nil {
+		return fmt.Errorf("gathering Addon %q: %w", addonName, err)
+	}
+	if output != "-" {
+		fmt.Fprintf(os.Stderr, "wrote %s\n", output)
+	}
+	return nil
+}
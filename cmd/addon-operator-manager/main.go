@@ -1,7 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
+	"expvar"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
@@ -20,10 +23,13 @@ import (
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	uberzap "go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,10 +39,15 @@ import (
 
 	aoapis "github.com/openshift/addon-operator/apis"
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/alertreceiver"
+	"github.com/openshift/addon-operator/internal/clusterid"
 	addoncontroller "github.com/openshift/addon-operator/internal/controllers/addon"
 	aictrl "github.com/openshift/addon-operator/internal/controllers/addoninstance"
 	aocontroller "github.com/openshift/addon-operator/internal/controllers/addonoperator"
+	"github.com/openshift/addon-operator/internal/eventsink"
 	"github.com/openshift/addon-operator/internal/featuretoggle"
+	"github.com/openshift/addon-operator/internal/ocm"
+	"github.com/openshift/addon-operator/internal/runtimeconfig"
 )
 
 var (
@@ -44,6 +55,9 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// ServiceAccount the Addon Operator runs as in its namespace.
+const serviceAccountName = "addon-operator"
+
 func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 	_ = aoapis.AddToScheme(scheme)
@@ -53,11 +67,26 @@ func init() {
 	_ = monitoringv1.AddToScheme(scheme)
 }
 
+// Alert receiver forwarding alerts of Addon MonitoringStacks
+// to OCM service logs, disabled without an address.
+type alertReceiverOptions struct {
+	Addr         string
+	TokenKeyFile string
+}
+
 func initReconcilers(mgr ctrl.Manager,
 	namespace string,
 	enableRecorder bool,
 	addonOperatorInCluster addonsv1alpha1.AddonOperator,
 	enableStatusReporting bool,
+	alertReceiverOpts alertReceiverOptions,
+	orphanCollectorOpts addoncontroller.OrphanCollectorOptions,
+	clusterIDOpts clusterid.Options,
+	ocmAuditLog *ocm.AuditLog,
+	eventSink eventsink.Sink,
+	runtimeConfig runtimeconfig.Config,
+	restart func(),
+	logLevel uberzap.AtomicLevel,
 	opts ...addoncontroller.AddonReconcilerOptions) error {
 	ctx := context.Background()
 
@@ -68,13 +97,52 @@ func initReconcilers(mgr ctrl.Manager,
 		return fmt.Errorf("unable to set up uncached client: %w", err)
 	}
 
-	// Lookup ClusterID prior to starting
-	cv := &configv1.ClusterVersion{}
-	if err := uncachedClient.Get(ctx, client.ObjectKey{Name: "version"}, cv); err != nil {
-		return fmt.Errorf("getting clusterversion: %w", err)
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return fmt.Errorf("unable to set up clientset: %w", err)
+	}
+
+	// Run Addon ReadinessProbes in the background after all other sub reconcilers succeeded.
+	// RoleBindings and Pods are looked up uncached to not watch them cluster-wide.
+	opts = append(opts, addoncontroller.WithReadinessProbeReconciler{
+		Client:     uncachedClient,
+		Scheme:     mgr.GetScheme(),
+		Clientset:  clientset,
+		RestConfig: mgr.GetConfig(),
+		ServiceAccount: client.ObjectKey{
+			Namespace: namespace,
+			Name:      serviceAccountName,
+		},
+	})
+
+	// Stamp fleet-wide labels and annotations onto Addon objects from the first reconcile on,
+	// the AddonOperator controller keeps them up to date afterwards.
+	controllers.SetCommonObjectMetadata(addonOperatorInCluster.Spec.ObjectMetadata)
+
+	// Discover the cluster IDs prior to starting
+	clusterIDs, err := clusterid.Discover(ctx, uncachedClient, clusterIDOpts)
+	if err != nil {
+		return fmt.Errorf("discovering cluster IDs: %w", err)
 	}
 	// calling this external ID to differentiate it from the cluster ID we use to contact OCM
-	clusterExternalID := string(cv.Spec.ClusterID)
+	clusterExternalID := clusterIDs.External
+	setupLog.Info("discovered cluster IDs",
+		"externalID", clusterIDs.External, "internalID", clusterIDs.Internal, "name", clusterIDs.Name)
+
+	// Reports Addon lifecycle milestones and reverted manual changes to Addon objects as Events,
+	// forwarded to the event sink, if configured.
+	var eventRecorder record.EventRecorder = mgr.GetEventRecorderFor("addon-operator")
+	if eventSink != nil {
+		forwarder := eventsink.NewForwarder(eventSink,
+			eventsink.WithClusterID(clusterExternalID),
+			eventsink.WithLog(ctrl.Log.WithName("eventsink")),
+		)
+		if err := mgr.Add(forwarder); err != nil {
+			return fmt.Errorf("unable to add event sink forwarder: %w", err)
+		}
+		eventRecorder = eventsink.NewEventRecorder(eventRecorder, forwarder)
+	}
+	opts = append(opts, addoncontroller.WithEventRecorder{Recorder: eventRecorder})
 
 	// Create metrics recorder
 	var recorder *metrics.Recorder
@@ -93,11 +161,23 @@ func initReconcilers(mgr ctrl.Manager,
 		enableStatusReporting,
 		opts...,
 	)
+	// Label Addon namespaces from the first reconcile on,
+	// the AddonOperator controller keeps the defaults up to date afterwards.
+	addonReconciler.SetDefaultPodSecurity(addonOperatorInCluster.Spec.PodSecurity)
+	addonReconciler.SetThanosRuler(addonOperatorInCluster.Spec.ThanosRuler)
+	addonReconciler.SetMaxConcurrentAddonUpgrades(addonOperatorInCluster.Spec.MaxConcurrentAddonUpgrades)
 	if err := addonReconciler.SetupWithManager(mgr, opts...); err != nil {
 		return fmt.Errorf("unable to create Addon controller: %w", err)
 	}
 
-	if err := (&aocontroller.AddonOperatorReconciler{
+	// Forwards alerts of Addon MonitoringStacks to OCM service logs.
+	if len(alertReceiverOpts.Addr) > 0 {
+		if err := initAlertReceiver(mgr, alertReceiverOpts.Addr, alertReceiverOpts.TokenKeyFile, addonReconciler); err != nil {
+			return err
+		}
+	}
+
+	addonOperatorReconciler := &aocontroller.AddonOperatorReconciler{
 		Client:              mgr.GetClient(),
 		UncachedClient:      uncachedClient,
 		Log:                 ctrl.Log.WithName("controllers").WithName("AddonOperator"),
@@ -106,11 +186,58 @@ func initReconcilers(mgr ctrl.Manager,
 		OCMClientManager:    addonReconciler,
 		Recorder:            recorder,
 		ClusterExternalID:   clusterExternalID,
+		ClusterID:           clusterIDs.Internal,
+		ClusterName:         clusterIDs.Name,
+		OCMAuditLog:         ocmAuditLog,
 		FeatureTogglesState: strings.Split(addonOperatorInCluster.Spec.FeatureFlags, ","),
-	}).SetupWithManager(mgr); err != nil {
+
+		AddonOperatorNamespace: namespace,
+		MonitoringStackEnabled: featuretoggle.IsEnabled(
+			&featuretoggle.MonitoringStackFeatureToggle{},
+			withRuntimeFeatureGates(addonOperatorInCluster, runtimeConfig)),
+		ExtensionHookManager:      addonReconciler,
+		MaintenanceModeManager:    addonReconciler,
+		BackoffPolicyManager:      addonReconciler,
+		PodSecurityManager:        addonReconciler,
+		ThanosRulerManager:        addonReconciler,
+		DeadMansSnitchManager:     addonReconciler,
+		PagerDutyManager:          addonReconciler,
+		UpgradeConcurrencyManager: addonReconciler,
+	}
+	addonOperatorReconciler.SetRequeueInterval(runtimeConfig.AddonOperatorRequeueInterval)
+	if err := addonOperatorReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create AddonOperator controller: %w", err)
 	}
 
+	// Applies changes to the runtime config ConfigMap without a restart.
+	if err := mgr.Add(runtimeconfig.NewWatcher(clientset, namespace, runtimeConfig,
+		func(cfg runtimeconfig.Config) {
+			logLevel.SetLevel(cfg.LogLevel)
+			addoncontroller.SetRetryAfterTime(cfg.AddonRetryInterval)
+			addoncontroller.SetDriftDetectionInterval(cfg.AddonDriftDetectionInterval)
+			addonOperatorReconciler.SetRequeueInterval(cfg.AddonOperatorRequeueInterval)
+		},
+		runtimeconfig.WithLog{Log: ctrl.Log.WithName("runtimeconfig")},
+		runtimeconfig.WithRestart{Restart: restart},
+	)); err != nil {
+		return fmt.Errorf("unable to add runtime config watcher: %w", err)
+	}
+
+	// Deletes objects left behind by Addons, whose teardown did not complete.
+	if orphanCollectorOpts.Interval > 0 {
+		var orphanRecorder addoncontroller.OrphanRecorder
+		if recorder != nil {
+			orphanRecorder = recorder
+		}
+		if err := mgr.Add(addoncontroller.NewOrphanCollector(
+			mgr.GetClient(), uncachedClient, orphanRecorder,
+			ctrl.Log.WithName("controllers").WithName("OrphanCollector"),
+			orphanCollectorOpts,
+		)); err != nil {
+			return fmt.Errorf("unable to add orphan collector: %w", err)
+		}
+	}
+
 	var (
 		addonInstanceCtrlLog  = ctrl.Log.WithName("controllers").WithName("AddonInstance")
 		addonInstancePhaseLog = addonInstanceCtrlLog.V(1).WithName("phase")
@@ -133,21 +260,112 @@ func initReconcilers(mgr ctrl.Manager,
 	return nil
 }
 
-func initPprof(mgr ctrl.Manager, addr string) {
+// Returns a copy of the AddonOperator object with the feature gates
+// of the runtime config enabled in addition to its own feature toggles.
+func withRuntimeFeatureGates(
+	addonOperator addonsv1alpha1.AddonOperator, cfg runtimeconfig.Config) addonsv1alpha1.AddonOperator {
+	addonOperator = *addonOperator.DeepCopy()
+	addonOperator.Spec.FeatureFlags = cfg.MergeFeatureGates(addonOperator.Spec.FeatureFlags)
+	return addonOperator
+}
+
+func initPprof(mgr ctrl.Manager, addr string, ocmAuditLog *ocm.AuditLog) {
+	if err := addHTTPServer(mgr, addr, newDebugMux(ocmAuditLog)); err != nil {
+		setupLog.Error(err, "unable to create pprof server")
+		os.Exit(1)
+	}
+}
+
+// Serves the debug endpoints on the metrics server,
+// only to requests bearing the token read from the given file.
+func initMetricsDebugEndpoints(mgr ctrl.Manager, tokenFile string, ocmAuditLog *ocm.AuditLog) error {
+	token, err := readTokenFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("reading debug token: %w", err)
+	}
+
+	handler := requireBearerToken(token, newDebugMux(ocmAuditLog))
+	for _, path := range []string{"/debug/pprof/", "/debug/vars", "/debug/ocm/requests"} {
+		if err := mgr.AddMetricsExtraHandler(path, handler); err != nil {
+			return fmt.Errorf("adding %s to metrics server: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Reads a token from the given file, which must not be empty.
+func readTokenFile(path string) ([]byte, error) {
+	token, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	token = bytes.TrimSpace(token)
+	if len(token) == 0 {
+		return nil, fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// Serves pprof profiles, expvar runtime metrics and recent OCM requests.
+func newDebugMux(ocmAuditLog *ocm.AuditLog) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	if ocmAuditLog != nil {
+		mux.Handle("/debug/ocm/requests", ocmAuditLog)
+	}
+	return mux
+}
 
+// Rejects requests not bearing the given token in their Authorization header.
+func requireBearerToken(token []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "Bearer "
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, prefix) ||
+			subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), token) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func initAlertReceiver(
+	mgr ctrl.Manager, addr, tokenKeyFile string, addonReconciler *addoncontroller.AddonReconciler,
+) error {
+	key, err := readTokenFile(tokenKeyFile)
+	if err != nil {
+		return fmt.Errorf("reading alert receiver token key: %w", err)
+	}
+	// Alertmanagers of Addons authenticate with tokens derived from the key.
+	addonReconciler.SetAlertReceiverTokenKey(key)
+
+	receiver := alertreceiver.NewReceiver(key, mgr.GetClient(), addonReconciler,
+		alertreceiver.WithLog{Log: ctrl.Log.WithName("alertreceiver")},
+	)
+
+	if err := addHTTPServer(mgr, addr, receiver); err != nil {
+		return fmt.Errorf("unable to create alert receiver server: %w", err)
+	}
+	return nil
+}
+
+// addHTTPServer runs a http server serving the given handler
+// for as long as the manager is running.
+func addHTTPServer(mgr ctrl.Manager, addr string, handler http.Handler) error {
 	s := &http.Server{
-		Addr: addr, Handler: mux,
+		Addr: addr, Handler: handler,
 		// Mitigate: G112: Potential Slowloris Attack because
 		// ReadHeaderTimeout is not configured in the http.Server (gosec)
 		ReadHeaderTimeout: 2 * time.Second,
 	}
-	err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		errCh := make(chan error)
 		defer func() {
 			for range errCh {
@@ -166,13 +384,46 @@ func initPprof(mgr ctrl.Manager, addr string) {
 			return nil
 		}
 	}))
-	if err != nil {
-		setupLog.Error(err, "unable to create pprof server")
-		os.Exit(1)
-	}
 }
 
 func setup() error {
+	opts := options{
+		MetricsAddr:           ":8080",
+		ProbeAddr:             ":8081",
+		EnableMetricsRecorder: true,
+		// Enable pprof by default to listen on localhost only.
+		// This way we don't expose pprof open to the whole cluster we are running on,
+		// while keeping it easy to access.
+		// Example Command:
+		// $ kubectl exec -it <addon-operator-pod> --container manager bash -- \
+		// curl -sK -v http://localhost:8070/debug/pprof/heap > heap.out
+		PprofAddr: "127.0.0.1:8070",
+		// Same overall rate limit as the controller-runtime default.
+		RequeueRateLimitShards: 1,
+		RequeueQPS:             10,
+		RequeueBurst:           100,
+		// Orphaned objects are only logged and counted,
+		// unless deleting them is enabled explicitly.
+		OrphanGCInterval: time.Hour,
+		// Queried via kube-rbac-proxy, authorizing the addon-operator ServiceAccount.
+		FederationHealthURL:    "https://prometheus-k8s.openshift-monitoring.svc:9091",
+		UpgradeAlertmanagerURL: "https://alertmanager-main.openshift-monitoring.svc:9094",
+		BlackboxExporterAddr:   "blackbox-exporter.openshift-monitoring.svc:9115",
+		ProbeResultsURL:        "https://prometheus-k8s.openshift-monitoring.svc:9091",
+		SLOPrometheusURL:       "https://prometheus-k8s.openshift-monitoring.svc:9091",
+		ClusterIDConfigMap:     clusterid.DefaultConfigMapName,
+		OCMAuditLogSize:        ocm.DefaultAuditLogSize,
+		EventSinkType:          string(eventsink.TypeWebhook),
+	}
+
+	if err := opts.Process(); err != nil {
+		return fmt.Errorf("processing options: %w", err)
+	}
+
+	// The log level can be changed at runtime via the runtime config.
+	logLevel := uberzap.NewAtomicLevel()
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true), zap.Level(logLevel)))
+
 	// Create a client that does not cache resources cluster-wide.
 	uncachedClient, err := client.New(
 		ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
@@ -180,8 +431,23 @@ func setup() error {
 		return fmt.Errorf("unable to set up uncached client: %w", err)
 	}
 
+	clientset, err := kubernetes.NewForConfig(ctrl.GetConfigOrDie())
+	if err != nil {
+		return fmt.Errorf("unable to set up clientset: %w", err)
+	}
+
 	ctx := context.Background()
 
+	runtimeConfig, err := runtimeconfig.Load(ctx, clientset, opts.Namespace)
+	if err != nil {
+		// Don't prevent the operator from starting because of an invalid config.
+		setupLog.Error(err, "loading runtime config, falling back to defaults")
+		runtimeConfig = runtimeconfig.Default()
+	}
+	logLevel.SetLevel(runtimeConfig.LogLevel)
+	addoncontroller.SetRetryAfterTime(runtimeConfig.AddonRetryInterval)
+	addoncontroller.SetDriftDetectionInterval(runtimeConfig.AddonDriftDetectionInterval)
+
 	addonOperatorObjectInCluster := addonsv1alpha1.AddonOperator{}
 	if err := uncachedClient.Get(ctx, types.NamespacedName{Name: addonsv1alpha1.DefaultAddonOperatorName}, &addonOperatorObjectInCluster); err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -192,6 +458,9 @@ func setup() error {
 
 	addonReconcilerOptions := []addoncontroller.AddonReconcilerOptions{}
 
+	// Feature toggles enabled via the AddonOperator object or the runtime config.
+	featureToggles := withRuntimeFeatureGates(addonOperatorObjectInCluster, runtimeConfig)
+
 	// feature toggle handlers ADO intends to support
 	featureToggleHandlers := featuretoggle.GetAvailableFeatureToggles(
 		featuretoggle.WithSchemeToUpdate{Scheme: scheme},
@@ -199,7 +468,7 @@ func setup() error {
 	)
 
 	for _, featureToggleHandler := range featureToggleHandlers {
-		if !featuretoggle.IsEnabled(featureToggleHandler, addonOperatorObjectInCluster) {
+		if !featuretoggle.IsEnabled(featureToggleHandler, featureToggles) {
 			continue
 		}
 		if err := featureToggleHandler.PreManagerSetupHandle(ctx); err != nil {
@@ -207,25 +476,6 @@ func setup() error {
 		}
 	}
 
-	opts := options{
-		MetricsAddr:           ":8080",
-		ProbeAddr:             ":8081",
-		EnableMetricsRecorder: true,
-		// Enable pprof by default to listen on localhost only.
-		// This way we don't expose pprof open to the whole cluster we are running on,
-		// while keeping it easy to access.
-		// Example Command:
-		// $ kubectl exec -it <addon-operator-pod> --container manager bash -- \
-		// curl -sK -v http://localhost:8070/debug/pprof/heap > heap.out
-		PprofAddr: "127.0.0.1:8070",
-	}
-
-	if err := opts.Process(); err != nil {
-		return fmt.Errorf("processing options: %w", err)
-	}
-
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
-
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                     scheme,
 		MetricsBindAddress:         opts.MetricsAddr,
@@ -250,7 +500,7 @@ func setup() error {
 	}
 
 	for _, featureToggleHandler := range featureToggleHandlers {
-		if !featuretoggle.IsEnabled(featureToggleHandler, addonOperatorObjectInCluster) {
+		if !featuretoggle.IsEnabled(featureToggleHandler, featureToggles) {
 			continue
 		}
 		if err := featureToggleHandler.PostManagerSetupHandle(ctx, mgr); err != nil {
@@ -258,9 +508,33 @@ func setup() error {
 		}
 	}
 
+	// Recent OCM requests, served next to pprof.
+	var ocmAuditLog *ocm.AuditLog
+	if opts.OCMAuditLogSize > 0 {
+		ocmAuditLog = ocm.NewAuditLog(ocm.WithAuditLogSize(opts.OCMAuditLogSize))
+	}
+
+	// Forwards Addon lifecycle Events to external tooling.
+	var eventSink eventsink.Sink
+	if len(opts.EventSinkURL) > 0 {
+		eventSink, err = eventsink.NewSink(
+			eventsink.WithType(eventsink.Type(opts.EventSinkType)),
+			eventsink.WithURL(opts.EventSinkURL),
+			eventsink.WithKafkaTopic(opts.EventSinkKafkaTopic),
+		)
+		if err != nil {
+			return fmt.Errorf("initializing event sink: %w", err)
+		}
+	}
+
 	// PPROF
 	if len(opts.PprofAddr) > 0 {
-		initPprof(mgr, opts.PprofAddr)
+		initPprof(mgr, opts.PprofAddr, ocmAuditLog)
+	}
+	if len(opts.DebugTokenFile) > 0 {
+		if err := initMetricsDebugEndpoints(mgr, opts.DebugTokenFile, ocmAuditLog); err != nil {
+			return fmt.Errorf("initializing debug endpoints: %w", err)
+		}
 	}
 
 	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
@@ -270,19 +544,109 @@ func setup() error {
 		return fmt.Errorf("unable to set up ready check: %w", err)
 	}
 
+	// Reports Addons whose federation targets cluster-monitoring fails to scrape.
+	// The checks need the in-cluster ServiceAccount and are skipped without it.
+	if len(opts.FederationHealthURL) > 0 {
+		checker, err := addoncontroller.NewPrometheusTargetHealthChecker(opts.FederationHealthURL)
+		if err != nil {
+			setupLog.Error(err, "disabling monitoring federation health checks")
+		} else {
+			addonReconcilerOptions = append(addonReconcilerOptions,
+				addoncontroller.WithMonitoringFederationHealthCheck{Checker: checker})
+		}
+	}
+	if opts.FederationNamespaces {
+		addonReconcilerOptions = append(addonReconcilerOptions,
+			addoncontroller.WithMonitoringFederationNamespaceCreation{})
+	}
+
+	// Silences alerts of Addons opted in via .spec.monitoring.silenceDuringUpgrade
+	// while they are upgraded.
+	if len(opts.UpgradeAlertmanagerURL) > 0 {
+		silencer, err := addoncontroller.NewAlertmanagerSilencer(opts.UpgradeAlertmanagerURL)
+		if err != nil {
+			setupLog.Error(err, "disabling upgrade silences")
+		} else {
+			addonReconcilerOptions = append(addonReconcilerOptions,
+				addoncontroller.WithUpgradeAlertSilencing{Silencer: silencer})
+		}
+	}
+
+	// Probes endpoints of Addons via the platform blackbox exporter
+	// and reports Addons unavailable while their probes keep failing.
+	probesOpt := addoncontroller.WithMonitoringProbes{ProberURL: opts.BlackboxExporterAddr}
+	if len(opts.ProbeResultsURL) > 0 {
+		results, err := addoncontroller.NewPrometheusProbeResultChecker(opts.ProbeResultsURL)
+		if err != nil {
+			setupLog.Error(err, "disabling probe result checks")
+		} else {
+			probesOpt.Results = results
+		}
+	}
+	addonReconcilerOptions = append(addonReconcilerOptions, probesOpt)
+
+	// Publishes the error budgets of the SLOs declared by Addons
+	// and reports Addons burning their error budget too fast.
+	if len(opts.SLOPrometheusURL) > 0 {
+		evaluator, err := addoncontroller.NewPrometheusSLOEvaluator(opts.SLOPrometheusURL)
+		if err != nil {
+			setupLog.Error(err, "disabling SLO evaluation")
+		} else {
+			addonReconcilerOptions = append(addonReconcilerOptions,
+				addoncontroller.WithMonitoringSLOEvaluation{Evaluator: evaluator})
+		}
+	}
+
+	// Shard the requeue rate limit by Addon name, so Addons requeued at a high rate
+	// do not exhaust the rate limit of all other Addons.
+	addonReconcilerOptions = append(addonReconcilerOptions, addoncontroller.WithReconcileRateLimits{
+		MaxConcurrentReconciles: runtimeConfig.MaxConcurrentReconciles,
+		RateLimitShards:         opts.RequeueRateLimitShards,
+		QPS:                     opts.RequeueQPS,
+		Burst:                   opts.RequeueBurst,
+	})
+
+	// Stopped by the runtime config watcher when a change requires a restart.
+	// The manager is stopped gracefully and the process exits once it returns,
+	// so that k8s restarts ADO (pods), bootstrapping it with the new configuration.
+	mgrCtx, stopManager := context.WithCancel(ctrl.SetupSignalHandler())
+	defer stopManager()
+
 	if err := initReconcilers(mgr, opts.Namespace,
-		opts.EnableMetricsRecorder, addonOperatorObjectInCluster, opts.StatusReportingEnabled, addonReconcilerOptions...); err != nil {
+		opts.EnableMetricsRecorder, addonOperatorObjectInCluster, opts.StatusReportingEnabled,
+		alertReceiverOptions{
+			Addr:         opts.AlertReceiverAddr,
+			TokenKeyFile: opts.AlertReceiverTokenKeyFile,
+		}, addoncontroller.OrphanCollectorOptions{
+			Interval: opts.OrphanGCInterval,
+			DryRun:   !opts.OrphanGCDelete,
+		}, clusterid.Options{
+			Overrides: clusterid.IDs{
+				External: opts.ClusterExternalID,
+				Internal: opts.ClusterID,
+				Name:     opts.ClusterName,
+			},
+			ConfigMap: client.ObjectKey{Name: opts.ClusterIDConfigMap, Namespace: opts.Namespace},
+		}, ocmAuditLog, eventSink, runtimeConfig, stopManager, logLevel, addonReconcilerOptions...); err != nil {
 		return fmt.Errorf("init reconcilers: %w", err)
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(mgrCtx); err != nil {
 		return fmt.Errorf("problem running manager: %w", err)
 	}
 	return nil
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == gatherCommand {
+		if err := runGather(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := setup(); err != nil {
 		setupLog.Error(err, "setting up manager")
 		os.Exit(1)
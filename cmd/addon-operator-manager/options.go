@@ -5,17 +5,42 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"time"
+
+	"github.com/openshift/addon-operator/internal/controllers"
 )
 
 type options struct {
-	EnableLeaderElection    bool
-	EnableMetricsRecorder   bool
-	LeaderElectionNamespace string
-	MetricsAddr             string
-	Namespace               string
-	PprofAddr               string
-	ProbeAddr               string
-	StatusReportingEnabled  bool
+	AlertReceiverAddr         string
+	AlertReceiverTokenKeyFile string
+	BlackboxExporterAddr      string
+	ClusterExternalID         string
+	ClusterID                 string
+	ClusterIDConfigMap        string
+	ClusterName               string
+	DebugTokenFile            string
+	EnableLeaderElection      bool
+	EnableMetricsRecorder     bool
+	EventSinkKafkaTopic       string
+	EventSinkType             string
+	EventSinkURL              string
+	FederationHealthURL       string
+	FederationNamespaces      bool
+	UpgradeAlertmanagerURL    string
+	LeaderElectionNamespace   string
+	MetricsAddr               string
+	Namespace                 string
+	OCMAuditLogSize           int
+	OrphanGCDelete            bool
+	OrphanGCInterval          time.Duration
+	PprofAddr                 string
+	ProbeAddr                 string
+	ProbeResultsURL           string
+	RequeueRateLimitShards    int
+	RequeueQPS                float64
+	RequeueBurst              int
+	SLOPrometheusURL          string
+	StatusReportingEnabled    bool
 }
 
 // Process retrieves values from flags, environment values,
@@ -32,6 +57,63 @@ func (o *options) Process() error {
 }
 
 func (o *options) parseFlags() {
+	flag.StringVar(
+		&o.AlertReceiverAddr,
+		"alert-receiver-addr",
+		o.AlertReceiverAddr,
+		"The address the alert receiver forwarding Addon alerts to OCM service logs binds to, "+
+			fmt.Sprintf("e.g. :%d to serve the alert receiver Service. ", controllers.AlertReceiverServicePort)+
+			"The receiver is disabled by default.",
+	)
+
+	flag.StringVar(
+		&o.AlertReceiverTokenKeyFile,
+		"alert-receiver-token-key-file",
+		o.AlertReceiverTokenKeyFile,
+		"File holding the key the bearer tokens of Addon Alertmanagers posting to the alert receiver "+
+			"are derived from. Required when the alert receiver is enabled.",
+	)
+
+	flag.StringVar(
+		&o.ClusterExternalID,
+		"cluster-external-id",
+		o.ClusterExternalID,
+		"External ID of the cluster, overriding the one discovered from the ClusterVersion or cluster ID ConfigMap.",
+	)
+
+	flag.StringVar(
+		&o.ClusterID,
+		"cluster-id",
+		o.ClusterID,
+		"Internal OCM ID of the cluster, overriding the one discovered from the cluster ID ConfigMap. "+
+			"Looked up in OCM by the external ID when not set.",
+	)
+
+	flag.StringVar(
+		&o.ClusterName,
+		"cluster-name",
+		o.ClusterName,
+		"OCM name of the cluster, overriding the one discovered from the cluster ID ConfigMap. "+
+			"Looked up in OCM by the external ID when not set.",
+	)
+
+	flag.StringVar(
+		&o.ClusterIDConfigMap,
+		"cluster-id-configmap",
+		o.ClusterIDConfigMap,
+		"Name of the ConfigMap in the operator namespace to discover cluster IDs from, "+
+			"if they are not set via flags or the ClusterVersion. "+
+			"Set to an empty string to disable the lookup.",
+	)
+
+	flag.StringVar(
+		&o.DebugTokenFile,
+		"debug-token-file",
+		o.DebugTokenFile,
+		"File holding the bearer token, which opts into serving pprof profiles, expvar runtime metrics "+
+			"and recent OCM requests below /debug/ on the metrics server to requests bearing it.",
+	)
+
 	flag.BoolVar(
 		&o.EnableLeaderElection,
 		"enable-leader-election",
@@ -47,6 +129,75 @@ func (o *options) parseFlags() {
 		"Enable recording Addon Metrics",
 	)
 
+	flag.StringVar(
+		&o.EventSinkURL,
+		"event-sink-url",
+		o.EventSinkURL,
+		"URL of the webhook or Kafka REST Proxy Addon lifecycle Events are forwarded to as JSON. "+
+			"Set to an empty string to disable forwarding.",
+	)
+
+	flag.StringVar(
+		&o.EventSinkType,
+		"event-sink-type",
+		o.EventSinkType,
+		"Type of the event sink, 'webhook' or 'kafka'.",
+	)
+
+	flag.StringVar(
+		&o.EventSinkKafkaTopic,
+		"event-sink-kafka-topic",
+		o.EventSinkKafkaTopic,
+		"Kafka topic Addon lifecycle Events are produced to, required for the 'kafka' event sink.",
+	)
+
+	flag.StringVar(
+		&o.FederationHealthURL,
+		"federation-health-prometheus-url",
+		o.FederationHealthURL,
+		"URL of the cluster-monitoring Prometheus to check the health of Addon monitoring federation targets in. "+
+			"Set to an empty string to disable the checks.",
+	)
+
+	flag.BoolVar(
+		&o.FederationNamespaces,
+		"create-federation-namespaces",
+		o.FederationNamespaces,
+		"Create missing .spec.monitoring.federation.namespace namespaces of Addons with a monitoring ResourceQuota and LimitRange, "+
+			"instead of waiting for them to be created.",
+	)
+
+	flag.StringVar(
+		&o.UpgradeAlertmanagerURL,
+		"upgrade-silence-alertmanager-url",
+		o.UpgradeAlertmanagerURL,
+		"URL of the cluster-monitoring Alertmanager to silence alerts of upgrading Addons in. "+
+			"Set to an empty string to disable upgrade silences.",
+	)
+
+	flag.StringVar(
+		&o.BlackboxExporterAddr,
+		"blackbox-exporter-addr",
+		o.BlackboxExporterAddr,
+		"Address of the blackbox exporter probing the endpoints declared in .spec.monitoring.probes of Addons.",
+	)
+
+	flag.StringVar(
+		&o.ProbeResultsURL,
+		"probe-results-prometheus-url",
+		o.ProbeResultsURL,
+		"URL of the cluster-monitoring Prometheus to look up the results of Addon probes in. "+
+			"Set to an empty string to not report failing probes on Addons.",
+	)
+
+	flag.StringVar(
+		&o.SLOPrometheusURL,
+		"slo-prometheus-url",
+		o.SLOPrometheusURL,
+		"URL of the cluster-monitoring Prometheus to evaluate the error budgets of Addon SLOs in. "+
+			"Set to an empty string to not report the error budgets on Addons.",
+	)
+
 	flag.StringVar(
 		&o.LeaderElectionNamespace,
 		"leader-election-namspace",
@@ -68,6 +219,13 @@ func (o *options) parseFlags() {
 		"The namespace in which the operator is running.",
 	)
 
+	flag.IntVar(
+		&o.OCMAuditLogSize,
+		"ocm-audit-log-size",
+		o.OCMAuditLogSize,
+		"Number of recent OCM requests served at /debug/ocm/requests on the pprof address. 0 disables the audit log.",
+	)
+
 	flag.StringVar(
 		&o.PprofAddr,
 		"pprof-addr", o.PprofAddr,
@@ -81,6 +239,42 @@ func (o *options) parseFlags() {
 		"The address the probe endpoint binds to.",
 	)
 
+	flag.IntVar(
+		&o.RequeueRateLimitShards,
+		"addon-requeue-rate-limit-shards",
+		o.RequeueRateLimitShards,
+		"Number of separate requeue rate limits Addons are distributed across by name. "+
+			"All Addons still share one workqueue and its reconcile workers.",
+	)
+
+	flag.Float64Var(
+		&o.RequeueQPS,
+		"addon-requeue-qps",
+		o.RequeueQPS,
+		"Sustained rate of Addon requeues per rate limit shard.",
+	)
+
+	flag.IntVar(
+		&o.RequeueBurst,
+		"addon-requeue-burst",
+		o.RequeueBurst,
+		"Burst of Addon requeues per rate limit shard.",
+	)
+
+	flag.DurationVar(
+		&o.OrphanGCInterval,
+		"orphan-gc-interval",
+		o.OrphanGCInterval,
+		"Interval to delete objects of no longer existing Addons in. 0 disables the collection.",
+	)
+
+	flag.BoolVar(
+		&o.OrphanGCDelete,
+		"orphan-gc-delete",
+		o.OrphanGCDelete,
+		"Delete objects of no longer existing Addons. By default they are only logged and counted.",
+	)
+
 	flag.Parse()
 }
 
@@ -134,6 +328,21 @@ func (o *options) validate() error {
 	if o.Namespace == "" {
 		return fmt.Errorf("'Namespace' must not be empty: %w", errInvalidOption)
 	}
+	if o.RequeueRateLimitShards < 1 {
+		return fmt.Errorf("'RequeueRateLimitShards' must be at least 1: %w", errInvalidOption)
+	}
+	if o.RequeueQPS <= 0 || o.RequeueBurst < 1 {
+		return fmt.Errorf("'RequeueQPS' and 'RequeueBurst' must be positive: %w", errInvalidOption)
+	}
+	if o.OCMAuditLogSize < 0 {
+		return fmt.Errorf("'OCMAuditLogSize' must not be negative: %w", errInvalidOption)
+	}
+	if len(o.AlertReceiverAddr) > 0 && len(o.AlertReceiverTokenKeyFile) == 0 {
+		return fmt.Errorf("'AlertReceiverTokenKeyFile' must be set to enable the alert receiver: %w", errInvalidOption)
+	}
+	if o.OrphanGCInterval < 0 {
+		return fmt.Errorf("'OrphanGCInterval' must not be negative: %w", errInvalidOption)
+	}
 
 	return nil
 }
@@ -71,6 +71,9 @@ func main() {
 			Client: mgr.GetClient(),
 		},
 	})
+	wbh.Register("/convert", &webhooks.AddonConversionHandler{
+		Log: log.Log.WithName("conversion webhooks").WithName("Addon"),
+	})
 
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
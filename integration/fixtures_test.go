@@ -302,6 +302,10 @@ func addon_TestResourceAdoption() *addonsv1alpha1.Addon {
 	return &addonsv1alpha1.Addon{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: referenceAddonName,
+			// The OLM objects are created beforehand, without belonging to the Addon.
+			Annotations: map[string]string{
+				addonsv1alpha1.AdoptOLMObjectsAnnotation: "true",
+			},
 		},
 		Spec: addonsv1alpha1.AddonSpec{
 			Version:     "v0.3.0",
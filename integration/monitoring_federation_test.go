@@ -224,6 +224,13 @@ func validateMonitoringFederationServiceMonitor(t *testing.T, ctx context.Contex
 					},
 				},
 				Interval: "30s",
+				MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+					{
+						TargetLabel: "addon",
+						Replacement: addon.Name,
+						Action:      "replace",
+					},
+				},
 				TLSConfig: &monitoringv1.TLSConfig{
 					CAFile: "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt",
 					SafeTLSConfig: monitoringv1.SafeTLSConfig{
@@ -0,0 +1,101 @@
+// Package addonphase implements the state machine of the Addon phase,
+// validating transitions between phases and recording when they happened.
+package addonphase
+
+import (
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// ErrInvalidTransition is returned for transitions not allowed by the state machine.
+var ErrInvalidTransition = errors.New("invalid Addon phase transition")
+
+// Phases reachable from each phase.
+// Addons without a phase yet may enter any phase.
+// Terminating Addons can not leave the Terminating phase,
+// as their deletion can not be cancelled.
+var transitions = map[addonsv1alpha1.AddonPhase][]addonsv1alpha1.AddonPhase{
+	addonsv1alpha1.PhasePending: {
+		addonsv1alpha1.PhaseInstalling,
+		addonsv1alpha1.PhaseReady,
+		addonsv1alpha1.PhaseError,
+		addonsv1alpha1.PhaseTerminating,
+	},
+	addonsv1alpha1.PhaseInstalling: {
+		addonsv1alpha1.PhasePending,
+		addonsv1alpha1.PhaseReady,
+		addonsv1alpha1.PhaseError,
+		addonsv1alpha1.PhaseTerminating,
+	},
+	addonsv1alpha1.PhaseReady: {
+		addonsv1alpha1.PhaseUpgrading,
+		addonsv1alpha1.PhaseDegraded,
+		addonsv1alpha1.PhaseError,
+		addonsv1alpha1.PhaseTerminating,
+	},
+	addonsv1alpha1.PhaseUpgrading: {
+		addonsv1alpha1.PhaseReady,
+		addonsv1alpha1.PhaseDegraded,
+		addonsv1alpha1.PhaseError,
+		addonsv1alpha1.PhaseTerminating,
+	},
+	addonsv1alpha1.PhaseDegraded: {
+		addonsv1alpha1.PhaseReady,
+		addonsv1alpha1.PhaseUpgrading,
+		addonsv1alpha1.PhaseError,
+		addonsv1alpha1.PhaseTerminating,
+	},
+	addonsv1alpha1.PhaseError: {
+		addonsv1alpha1.PhasePending,
+		addonsv1alpha1.PhaseInstalling,
+		addonsv1alpha1.PhaseReady,
+		addonsv1alpha1.PhaseUpgrading,
+		addonsv1alpha1.PhaseDegraded,
+		addonsv1alpha1.PhaseTerminating,
+	},
+	addonsv1alpha1.PhaseTerminating: {},
+}
+
+// CanTransition returns true if an Addon may move from one phase to the other.
+// Staying in the same phase is always allowed.
+func CanTransition(from, to addonsv1alpha1.AddonPhase) bool {
+	if from == to || len(from) == 0 {
+		return true
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the Addon into the given phase and records the time of the transition.
+// Returns true if the phase changed and ErrInvalidTransition,
+// leaving the status untouched, if the transition is not allowed.
+func Transition(status *addonsv1alpha1.AddonStatus, to addonsv1alpha1.AddonPhase, now metav1.Time) (bool, error) {
+	from := status.Phase
+	if !CanTransition(from, to) {
+		return false, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+	if from == to {
+		return false, nil
+	}
+
+	status.Phase = to
+	for i := range status.PhaseTransitions {
+		if status.PhaseTransitions[i].Phase == to {
+			status.PhaseTransitions[i].LastTransitionTime = now
+			return true, nil
+		}
+	}
+	status.PhaseTransitions = append(status.PhaseTransitions, addonsv1alpha1.AddonPhaseTransition{
+		Phase:              to,
+		LastTransitionTime: now,
+	})
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package addonphase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+func TestCanTransition(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		From, To addonsv1alpha1.AddonPhase
+		Expected bool
+	}{
+		"initial":             {From: "", To: addonsv1alpha1.PhaseReady, Expected: true},
+		"same phase":          {From: addonsv1alpha1.PhaseReady, To: addonsv1alpha1.PhaseReady, Expected: true},
+		"installed":           {From: addonsv1alpha1.PhaseInstalling, To: addonsv1alpha1.PhaseReady, Expected: true},
+		"upgrade":             {From: addonsv1alpha1.PhaseReady, To: addonsv1alpha1.PhaseUpgrading, Expected: true},
+		"recovered":           {From: addonsv1alpha1.PhaseDegraded, To: addonsv1alpha1.PhaseReady, Expected: true},
+		"back to pending":     {From: addonsv1alpha1.PhaseReady, To: addonsv1alpha1.PhasePending},
+		"upgrade not started": {From: addonsv1alpha1.PhaseInstalling, To: addonsv1alpha1.PhaseUpgrading},
+		"leave terminating":   {From: addonsv1alpha1.PhaseTerminating, To: addonsv1alpha1.PhaseReady},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.Expected, CanTransition(tc.From, tc.To))
+		})
+	}
+}
+
+func TestTransition(t *testing.T) {
+	t.Parallel()
+
+	var (
+		first  = metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		second = metav1.NewTime(first.Add(time.Hour))
+		third  = metav1.NewTime(second.Add(time.Hour))
+	)
+	status := &addonsv1alpha1.AddonStatus{}
+
+	changed, err := Transition(status, addonsv1alpha1.PhaseReady, first)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = Transition(status, addonsv1alpha1.PhaseDegraded, second)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = Transition(status, addonsv1alpha1.PhaseReady, third)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = Transition(status, addonsv1alpha1.PhaseReady, third)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.Equal(t, addonsv1alpha1.PhaseReady, status.Phase)
+	assert.Equal(t, []addonsv1alpha1.AddonPhaseTransition{
+		{Phase: addonsv1alpha1.PhaseReady, LastTransitionTime: third},
+		{Phase: addonsv1alpha1.PhaseDegraded, LastTransitionTime: second},
+	}, status.PhaseTransitions)
+
+	_, err = Transition(status, addonsv1alpha1.PhasePending, third)
+	require.ErrorIs(t, err, ErrInvalidTransition)
+	assert.Equal(t, addonsv1alpha1.PhaseReady, status.Phase)
+}
@@ -0,0 +1,25 @@
+package alertreceiver
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+type WithLog struct{ Log logr.Logger }
+
+func (w WithLog) ConfigureReceiver(c *ReceiverConfig) {
+	c.Log = w.Log
+}
+
+type WithForwardedAlertsTTL struct{ TTL time.Duration }
+
+func (w WithForwardedAlertsTTL) ConfigureReceiver(c *ReceiverConfig) {
+	c.ForwardedAlertsTTL = w.TTL
+}
+
+type WithClock struct{ Clock func() time.Time }
+
+func (w WithClock) ConfigureReceiver(c *ReceiverConfig) {
+	c.Clock = w.Clock
+}
@@ -0,0 +1,279 @@
+package alertreceiver
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gorilla/mux"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+const (
+	// Path the Alertmanager webhook receivers of Addon MonitoringStacks post to.
+	// The last path segment identifies the Addon the alerts originate from.
+	AlertsPathPrefix = "/alerts/"
+
+	// Alerts carrying this severity label value are forwarded to OCM.
+	forwardedSeverity = "critical"
+
+	alertStatusFiring = "firing"
+
+	// Time alerts are remembered as forwarded, so Alertmanager retrying
+	// or repeating a notification does not post them again.
+	defaultForwardedAlertsTTL = 24 * time.Hour
+)
+
+// Returns the bearer token the Alertmanager of the given Addon authenticates with,
+// derived from the key, so it can not be used to post alerts of other Addons.
+func AddonToken(key []byte, addonName string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(addonName))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServiceLogPoster posts service log entries to OCM.
+type ServiceLogPoster interface {
+	PostServiceLog(ctx context.Context, req ocm.ServiceLogPostRequest) error
+}
+
+// Receiver accepts Alertmanager webhook notifications sent on behalf of
+// an Addon and forwards firing critical alerts to OCM as cluster service logs.
+// Only Addons forwarding their alerts are accepted, authenticated by AddonToken.
+type Receiver struct {
+	cfg       ReceiverConfig
+	addons    client.Reader
+	poster    ServiceLogPoster
+	router    *mux.Router
+	forwarded *forwardedAlerts
+}
+
+func NewReceiver(
+	tokenKey []byte, addons client.Reader,
+	poster ServiceLogPoster, opts ...ReceiverOption,
+) *Receiver {
+	var cfg ReceiverConfig
+
+	cfg.Option(opts...)
+	cfg.Default()
+	cfg.TokenKey = tokenKey
+
+	r := &Receiver{
+		cfg:    cfg,
+		addons: addons,
+		poster: poster,
+		router: mux.NewRouter(),
+		forwarded: &forwardedAlerts{
+			ttl:   cfg.ForwardedAlertsTTL,
+			clock: cfg.Clock,
+			keys:  map[string]time.Time{},
+		},
+	}
+	r.router.HandleFunc(AlertsPathPrefix+"{addon}", r.handleAlerts).
+		Methods(http.MethodPost)
+
+	return r
+}
+
+type ReceiverConfig struct {
+	Log logr.Logger
+	// Key the bearer tokens of Addons are derived from.
+	TokenKey []byte
+	// Time alerts are remembered as forwarded.
+	ForwardedAlertsTTL time.Duration
+	Clock              func() time.Time
+}
+
+func (c *ReceiverConfig) Option(opts ...ReceiverOption) {
+	for _, opt := range opts {
+		opt.ConfigureReceiver(c)
+	}
+}
+
+func (c *ReceiverConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+	if c.ForwardedAlertsTTL == 0 {
+		c.ForwardedAlertsTTL = defaultForwardedAlertsTTL
+	}
+	if c.Clock == nil {
+		c.Clock = time.Now
+	}
+}
+
+type ReceiverOption interface {
+	ConfigureReceiver(c *ReceiverConfig)
+}
+
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.router.ServeHTTP(w, req)
+}
+
+func (r *Receiver) handleAlerts(w http.ResponseWriter, req *http.Request) {
+	addonName := mux.Vars(req)["addon"]
+	log := r.cfg.Log.WithValues("addon", addonName)
+
+	if !r.authenticated(req, addonName) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	addon := &addonsv1alpha1.Addon{}
+	if err := r.addons.Get(req.Context(), client.ObjectKey{Name: addonName}, addon); k8sApiErrors.IsNotFound(err) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Error(err, "getting Addon")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if !forwardsAlerts(addon) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	var msg webhookMessage
+	if err := json.NewDecoder(req.Body).Decode(&msg); err != nil {
+		log.Error(err, "decoding alertmanager webhook message")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	for _, alert := range msg.Alerts {
+		if !alert.shouldForward() {
+			continue
+		}
+		key := addonName + "/" + alert.key()
+		if r.forwarded.contains(key) {
+			continue
+		}
+
+		if err := r.poster.PostServiceLog(req.Context(), alert.toServiceLog(addonName)); err != nil {
+			log.Error(err, "forwarding alert to OCM service logs", "alertname", alert.Labels["alertname"])
+			// Let Alertmanager retry the whole notification.
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		// Alerts already forwarded are skipped when Alertmanager retries.
+		r.forwarded.add(key)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *Receiver) authenticated(req *http.Request, addonName string) bool {
+	if len(r.cfg.TokenKey) == 0 {
+		return false
+	}
+	token := req.Header.Get("Authorization")
+	if !strings.HasPrefix(token, "Bearer ") {
+		return false
+	}
+	token = strings.TrimPrefix(token, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(AddonToken(r.cfg.TokenKey, addonName))) == 1
+}
+
+func forwardsAlerts(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil &&
+		addon.Spec.Monitoring.MonitoringStack != nil &&
+		addon.Spec.Monitoring.MonitoringStack.ForwardAlertsToServiceLogs
+}
+
+// Remembers the alerts forwarded within the TTL. Concurrency safe.
+type forwardedAlerts struct {
+	mux   sync.Mutex
+	ttl   time.Duration
+	clock func() time.Time
+	keys  map[string]time.Time
+}
+
+func (f *forwardedAlerts) contains(key string) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	forwardedAt, ok := f.keys[key]
+	return ok && f.clock().Sub(forwardedAt) < f.ttl
+}
+
+func (f *forwardedAlerts) add(key string) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	now := f.clock()
+	for k, forwardedAt := range f.keys {
+		if now.Sub(forwardedAt) >= f.ttl {
+			delete(f.keys, k)
+		}
+	}
+	f.keys[key] = now
+}
+
+// Subset of the Alertmanager webhook payload.
+// https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+type webhookMessage struct {
+	Status string         `json:"status"`
+	Alerts []webhookAlert `json:"alerts"`
+}
+
+type webhookAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	Fingerprint string            `json:"fingerprint"`
+}
+
+// Identifies the firing of an alert.
+func (a webhookAlert) key() string {
+	fingerprint := a.Fingerprint
+	if len(fingerprint) == 0 {
+		// Older Alertmanagers don't send fingerprints.
+		names := make([]string, 0, len(a.Labels))
+		for name := range a.Labels {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fingerprint += name + "=" + a.Labels[name] + ","
+		}
+	}
+	return fingerprint + "@" + a.StartsAt.UTC().Format(time.RFC3339)
+}
+
+func (a webhookAlert) shouldForward() bool {
+	return a.Status == alertStatusFiring &&
+		strings.EqualFold(a.Labels["severity"], forwardedSeverity)
+}
+
+func (a webhookAlert) toServiceLog(addonName string) ocm.ServiceLogPostRequest {
+	summary := a.Annotations["summary"]
+	if len(summary) == 0 {
+		summary = fmt.Sprintf("Alert %s is firing", a.Labels["alertname"])
+	}
+
+	description := a.Annotations["description"]
+	if len(description) == 0 {
+		description = a.Annotations["message"]
+	}
+
+	return ocm.ServiceLogPostRequest{
+		Severity:    ocm.ServiceLogSeverityCritical,
+		ServiceName: addonName,
+		Summary:     summary,
+		Description: description,
+	}
+}
@@ -0,0 +1,240 @@
+package alertreceiver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+var testTokenKey = []byte("key")
+
+func newTestAddons(t *testing.T) client.Reader {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, addonsv1alpha1.AddToScheme(scheme))
+	forwarding := func(name string, forward bool) *addonsv1alpha1.Addon {
+		return &addonsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: addonsv1alpha1.AddonSpec{
+				Monitoring: &addonsv1alpha1.MonitoringSpec{
+					MonitoringStack: &addonsv1alpha1.MonitoringStackSpec{
+						ForwardAlertsToServiceLogs: forward,
+					},
+				},
+			},
+		}
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(forwarding("reference-addon", true), forwarding("quiet-addon", false)).
+		Build()
+}
+
+func newTestRequest(method, path, token, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+type serviceLogPosterMock struct {
+	mock.Mock
+}
+
+func (m *serviceLogPosterMock) PostServiceLog(ctx context.Context, req ocm.ServiceLogPostRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func TestReceiver(t *testing.T) {
+	t.Parallel()
+
+	const payload = `{
+		"status": "firing",
+		"alerts": [
+			{
+				"status": "firing",
+				"labels": {"alertname": "AddonDown", "severity": "critical"},
+				"fingerprint": "a1b2c3",
+				"startsAt": "2022-01-01T12:00:00Z",
+				"annotations": {"summary": "addon is down", "description": "no replicas available"}
+			},
+			{
+				"status": "firing",
+				"labels": {"alertname": "AddonSlow", "severity": "warning"}
+			},
+			{
+				"status": "resolved",
+				"labels": {"alertname": "AddonCrashing", "severity": "critical"}
+			}
+		]
+	}`
+
+	for name, tc := range map[string]struct {
+		Method         string
+		Path           string
+		Token          string
+		Body           string
+		PostErr        error
+		ExpectPost     bool
+		ExpectedStatus int
+	}{
+		"forwards firing critical alerts": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/reference-addon",
+			Token:          AddonToken(testTokenKey, "reference-addon"),
+			Body:           payload,
+			ExpectPost:     true,
+			ExpectedStatus: http.StatusOK,
+		},
+		"ocm unavailable": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/reference-addon",
+			Token:          AddonToken(testTokenKey, "reference-addon"),
+			Body:           payload,
+			PostErr:        errors.New("ocm client not initialized"),
+			ExpectPost:     true,
+			ExpectedStatus: http.StatusServiceUnavailable,
+		},
+		"malformed payload": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/reference-addon",
+			Token:          AddonToken(testTokenKey, "reference-addon"),
+			Body:           "{",
+			ExpectedStatus: http.StatusBadRequest,
+		},
+		"missing token": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/reference-addon",
+			Body:           payload,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		"token of another addon": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/reference-addon",
+			Token:          AddonToken(testTokenKey, "quiet-addon"),
+			Body:           payload,
+			ExpectedStatus: http.StatusUnauthorized,
+		},
+		"unknown addon": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/unknown-addon",
+			Token:          AddonToken(testTokenKey, "unknown-addon"),
+			Body:           payload,
+			ExpectedStatus: http.StatusNotFound,
+		},
+		"addon not forwarding alerts": {
+			Method:         http.MethodPost,
+			Path:           "/alerts/quiet-addon",
+			Token:          AddonToken(testTokenKey, "quiet-addon"),
+			Body:           payload,
+			ExpectedStatus: http.StatusForbidden,
+		},
+		"unsupported method": {
+			Method:         http.MethodGet,
+			Path:           "/alerts/reference-addon",
+			ExpectedStatus: http.StatusMethodNotAllowed,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			poster := &serviceLogPosterMock{}
+			poster.
+				On("PostServiceLog", mock.Anything, ocm.ServiceLogPostRequest{
+					Severity:    ocm.ServiceLogSeverityCritical,
+					ServiceName: "reference-addon",
+					Summary:     "addon is down",
+					Description: "no replicas available",
+				}).
+				Return(tc.PostErr)
+
+			rec := httptest.NewRecorder()
+			req := newTestRequest(tc.Method, tc.Path, tc.Token, tc.Body)
+
+			NewReceiver(testTokenKey, newTestAddons(t), poster).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.ExpectedStatus, rec.Code)
+			if tc.ExpectPost {
+				poster.AssertNumberOfCalls(t, "PostServiceLog", 1)
+			} else {
+				poster.AssertNotCalled(t, "PostServiceLog", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestReceiver_Deduplication(t *testing.T) {
+	t.Parallel()
+
+	const payload = `{
+		"alerts": [
+			{
+				"status": "firing",
+				"labels": {"alertname": "AddonDown", "severity": "critical"},
+				"fingerprint": "a1b2c3",
+				"startsAt": "2022-01-01T12:00:00Z"
+			},
+			{
+				"status": "firing",
+				"labels": {"alertname": "AddonSlow", "severity": "critical"},
+				"fingerprint": "d4e5f6",
+				"startsAt": "2022-01-01T12:00:00Z"
+			}
+		]
+	}`
+	downLog := mock.MatchedBy(func(req ocm.ServiceLogPostRequest) bool {
+		return req.Summary == "Alert AddonDown is firing"
+	})
+	slowLog := mock.MatchedBy(func(req ocm.ServiceLogPostRequest) bool {
+		return req.Summary == "Alert AddonSlow is firing"
+	})
+
+	poster := &serviceLogPosterMock{}
+	poster.On("PostServiceLog", mock.Anything, downLog).Return(nil)
+	poster.On("PostServiceLog", mock.Anything, slowLog).Return(errors.New("unavailable")).Once()
+	poster.On("PostServiceLog", mock.Anything, slowLog).Return(nil)
+
+	now := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := NewReceiver(testTokenKey, newTestAddons(t), poster,
+		WithClock{Clock: func() time.Time { return now }})
+	token := AddonToken(testTokenKey, "reference-addon")
+	post := func() int {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, newTestRequest(http.MethodPost, "/alerts/reference-addon", token, payload))
+		return rec.Code
+	}
+
+	// The second alert fails, so Alertmanager retries the notification.
+	assert.Equal(t, http.StatusServiceUnavailable, post())
+	assert.Equal(t, http.StatusOK, post())
+	poster.AssertNumberOfCalls(t, "PostServiceLog", 3)
+
+	// Repeated notifications are not forwarded again.
+	assert.Equal(t, http.StatusOK, post())
+	poster.AssertNumberOfCalls(t, "PostServiceLog", 3)
+
+	// Until the alerts are forgotten.
+	now = now.Add(defaultForwardedAlertsTTL)
+	assert.Equal(t, http.StatusOK, post())
+	poster.AssertNumberOfCalls(t, "PostServiceLog", 5)
+}
@@ -0,0 +1,209 @@
+package catalogregistry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/http2"
+
+	"github.com/openshift/addon-operator/internal/version"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// gRPC service served by OLM catalog pods.
+	registryService = "api.Registry"
+)
+
+// gRPC status codes the registry answers with, when the requested object is not in the catalog.
+// Besides NotFound, the registry returns lookup failures of its store as Unknown.
+const (
+	statusOK       = 0
+	statusUnknown  = 2
+	statusNotFound = 5
+)
+
+// ErrNotFound is returned for packages, channels and bundles
+// that are not part of the catalog.
+var ErrNotFound = errors.New("not found")
+
+// Client reads packages and bundles from the gRPC registry API
+// served by the pods of OLM CatalogSources.
+type Client struct {
+	httpClient *http.Client
+}
+
+// Creates a new Client with the given options.
+func NewClient(opts ...Option) *Client {
+	o := ClientOptions{
+		Timeout: defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	httpClient := o.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: o.Timeout,
+			// Catalog pods serve gRPC via HTTP/2 without TLS.
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, network, addr)
+				},
+			},
+		}
+	}
+	return &Client{httpClient: httpClient}
+}
+
+type ClientOptions struct {
+	Timeout    time.Duration
+	HTTPClient *http.Client
+}
+
+type Option func(o *ClientOptions)
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// Overrides the http.Client used to talk to catalog pods.
+// It has to speak HTTP/2 with prior knowledge.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *ClientOptions) {
+		o.HTTPClient = c
+	}
+}
+
+// Returns the package with the given name and the heads of its channels
+// from the registry at address, e.g. my-catalog.my-namespace.svc:50051.
+func (c *Client) GetPackage(ctx context.Context, address, name string) (Package, error) {
+	res, err := c.call(ctx, address, "GetPackage", encodeGetPackageRequest(name))
+	if err != nil {
+		return Package{}, fmt.Errorf("getting package %s: %w", name, err)
+	}
+	pkg, err := decodePackage(res)
+	if err != nil {
+		return Package{}, fmt.Errorf("decoding package %s: %w", name, err)
+	}
+	return pkg, nil
+}
+
+// Returns the bundle of the given CSV in a channel of a package
+// from the registry at address.
+func (c *Client) GetBundle(
+	ctx context.Context, address, packageName, channelName, csvName string,
+) (Bundle, error) {
+	res, err := c.call(ctx, address, "GetBundle",
+		encodeGetBundleRequest(packageName, channelName, csvName))
+	if err != nil {
+		return Bundle{}, fmt.Errorf("getting bundle %s: %w", csvName, err)
+	}
+	bundle, err := decodeBundle(res)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("decoding bundle %s: %w", csvName, err)
+	}
+	return bundle, nil
+}
+
+// Executes an unary gRPC call and returns the response message.
+func (c *Client) call(ctx context.Context, address, method string, msg []byte) ([]byte, error) {
+	callURL := url.URL{
+		Scheme: "http",
+		Host:   address,
+		Path:   fmt.Sprintf("/%s/%s", registryService, method),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callURL.String(),
+		bytes.NewReader(frame(msg)))
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/grpc+proto")
+	req.Header.Add("TE", "trailers")
+	req.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing http request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d calling %s", res.StatusCode, method)
+	}
+	if err := grpcStatusError(res); err != nil {
+		return nil, err
+	}
+	return unframe(body)
+}
+
+// Returns the error reported by the gRPC status of a response or nil.
+// Responses without messages carry their status in the headers instead of the trailers.
+func grpcStatusError(res *http.Response) error {
+	rawStatus := res.Trailer.Get("Grpc-Status")
+	message := res.Trailer.Get("Grpc-Message")
+	if len(rawStatus) == 0 {
+		rawStatus = res.Header.Get("Grpc-Status")
+		message = res.Header.Get("Grpc-Message")
+	}
+	if len(rawStatus) == 0 {
+		return errors.New("response without gRPC status")
+	}
+	status, err := strconv.Atoi(rawStatus)
+	if err != nil {
+		return fmt.Errorf("parsing gRPC status %q: %w", rawStatus, err)
+	}
+	if decoded, err := url.PathUnescape(message); err == nil {
+		message = decoded
+	}
+
+	switch status {
+	case statusOK:
+		return nil
+	case statusUnknown, statusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, message)
+	default:
+		return fmt.Errorf("gRPC status %d: %s", status, message)
+	}
+}
+
+// Length-prefixes an uncompressed gRPC message.
+func frame(msg []byte) []byte {
+	framed := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(framed[1:], uint32(len(msg)))
+	return append(framed, msg...)
+}
+
+// Returns the single message of an unary gRPC response body.
+func unframe(body []byte) ([]byte, error) {
+	if len(body) < 5 {
+		return nil, errors.New("response without message")
+	}
+	if body[0] != 0 {
+		return nil, errors.New("compressed response messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(body[1:5])
+	if uint64(len(body)-5) < uint64(length) {
+		return nil, errors.New("truncated response message")
+	}
+	return body[5 : 5+length], nil
+}
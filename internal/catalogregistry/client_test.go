@@ -0,0 +1,118 @@
+package catalogregistry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+
+	s := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/grpc+proto" {
+			rw.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req, err := unframe(body)
+		require.NoError(t, err)
+
+		rw.Header().Set("Content-Type", "application/grpc+proto")
+		switch {
+		case r.URL.Path == "/api.Registry/GetPackage" &&
+			string(req) == string(encodeGetPackageRequest("reference-addon")):
+			var channel []byte
+			channel = appendString(channel, channelName, "alpha")
+			channel = appendString(channel, channelCSVName, "reference-addon.v0.3.0")
+			var pkg []byte
+			pkg = appendString(pkg, packageName, "reference-addon")
+			pkg = protowire.AppendTag(pkg, packageChannels, protowire.BytesType)
+			pkg = protowire.AppendBytes(pkg, channel)
+			pkg = appendString(pkg, packageDefaultChannelName, "alpha")
+			_, _ = rw.Write(frame(pkg))
+			rw.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+
+		case r.URL.Path == "/api.Registry/GetBundle" &&
+			string(req) == string(encodeGetBundleRequest("reference-addon", "alpha", "reference-addon.v0.3.0")):
+			var bundle []byte
+			bundle = appendString(bundle, bundleCSVName, "reference-addon.v0.3.0")
+			bundle = appendString(bundle, bundlePackageName, "reference-addon")
+			bundle = appendString(bundle, bundleChannelName, "alpha")
+			// csvJson, skipped
+			bundle = appendString(bundle, 4, "{}")
+			bundle = appendString(bundle, bundleVersion, "0.3.0")
+			bundle = appendString(bundle, bundleSkipRange, ">=0.1.0 <0.3.0")
+			bundle = appendString(bundle, bundleReplaces, "reference-addon.v0.2.0")
+			bundle = appendString(bundle, bundleSkips, "reference-addon.v0.2.1")
+			bundle = appendString(bundle, bundleSkips, "reference-addon.v0.2.2")
+			_, _ = rw.Write(frame(bundle))
+			rw.Header().Set(http.TrailerPrefix+"Grpc-Status", "0")
+
+		default:
+			// Trailers-only response of the registry for unknown objects.
+			rw.Header().Set("Grpc-Status", "2")
+			rw.Header().Set("Grpc-Message", "no%20entry%20found")
+		}
+	}), &http2.Server{}))
+	t.Cleanup(s.Close)
+
+	address := strings.TrimPrefix(s.URL, "http://")
+	c := NewClient()
+
+	t.Run("gets package", func(t *testing.T) {
+		t.Parallel()
+
+		pkg, err := c.GetPackage(context.Background(), address, "reference-addon")
+		require.NoError(t, err)
+		assert.Equal(t, Package{
+			Name: "reference-addon",
+			Channels: []Channel{
+				{Name: "alpha", CSVName: "reference-addon.v0.3.0"},
+			},
+			DefaultChannelName: "alpha",
+		}, pkg)
+
+		channel, ok := pkg.Channel("alpha")
+		assert.True(t, ok)
+		assert.Equal(t, "reference-addon.v0.3.0", channel.CSVName)
+		_, ok = pkg.Channel("beta")
+		assert.False(t, ok)
+	})
+
+	t.Run("gets bundle", func(t *testing.T) {
+		t.Parallel()
+
+		bundle, err := c.GetBundle(context.Background(), address,
+			"reference-addon", "alpha", "reference-addon.v0.3.0")
+		require.NoError(t, err)
+		assert.Equal(t, Bundle{
+			CSVName:     "reference-addon.v0.3.0",
+			PackageName: "reference-addon",
+			ChannelName: "alpha",
+			Version:     "0.3.0",
+			SkipRange:   ">=0.1.0 <0.3.0",
+			Replaces:    "reference-addon.v0.2.0",
+			Skips:       []string{"reference-addon.v0.2.1", "reference-addon.v0.2.2"},
+		}, bundle)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := c.GetBundle(context.Background(), address,
+			"reference-addon", "alpha", "reference-addon.v0.0.1")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorContains(t, err, "no entry found")
+	})
+}
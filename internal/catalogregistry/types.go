@@ -0,0 +1,172 @@
+package catalogregistry
+
+import "google.golang.org/protobuf/encoding/protowire"
+
+// Package as returned by the registry, subset of api.Package.
+type Package struct {
+	Name               string
+	Channels           []Channel
+	DefaultChannelName string
+}
+
+// Returns the channel with the given name and whether it exists.
+func (p Package) Channel(name string) (Channel, bool) {
+	for _, channel := range p.Channels {
+		if channel.Name == name {
+			return channel, true
+		}
+	}
+	return Channel{}, false
+}
+
+// Channel of a package, subset of api.Channel.
+type Channel struct {
+	Name string
+	// Name of the CSV at the head of the channel.
+	CSVName string
+}
+
+// Bundle as returned by the registry, subset of api.Bundle
+// carrying the upgrade edges of the bundle.
+type Bundle struct {
+	CSVName     string
+	PackageName string
+	ChannelName string
+	Version     string
+	// Range of versions this bundle directly upgrades from,
+	// as declared by the olm.skipRange annotation of its CSV.
+	SkipRange string
+	// CSV this bundle upgrades from.
+	Replaces string
+	// CSVs this bundle upgrades from in addition to Replaces.
+	Skips []string
+}
+
+// Field numbers of the registry protobuf messages.
+const (
+	getPackageRequestName = 1
+
+	getBundleRequestPkgName     = 1
+	getBundleRequestChannelName = 2
+	getBundleRequestCSVName     = 3
+
+	packageName               = 1
+	packageChannels           = 2
+	packageDefaultChannelName = 3
+
+	channelName    = 1
+	channelCSVName = 2
+
+	bundleCSVName     = 1
+	bundlePackageName = 2
+	bundleChannelName = 3
+	bundleVersion     = 9
+	bundleSkipRange   = 10
+	bundleReplaces    = 13
+	bundleSkips       = 14
+)
+
+func encodeGetPackageRequest(name string) []byte {
+	return appendString(nil, getPackageRequestName, name)
+}
+
+func encodeGetBundleRequest(pkg, channel, csv string) []byte {
+	var b []byte
+	b = appendString(b, getBundleRequestPkgName, pkg)
+	b = appendString(b, getBundleRequestChannelName, channel)
+	return appendString(b, getBundleRequestCSVName, csv)
+}
+
+func appendString(b []byte, num protowire.Number, v string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, v)
+}
+
+func decodePackage(b []byte) (Package, error) {
+	pkg := Package{}
+	err := decodeFields(b, func(num protowire.Number, v []byte) error {
+		switch num {
+		case packageName:
+			pkg.Name = string(v)
+		case packageChannels:
+			channel, err := decodeChannel(v)
+			if err != nil {
+				return err
+			}
+			pkg.Channels = append(pkg.Channels, channel)
+		case packageDefaultChannelName:
+			pkg.DefaultChannelName = string(v)
+		}
+		return nil
+	})
+	return pkg, err
+}
+
+func decodeChannel(b []byte) (Channel, error) {
+	channel := Channel{}
+	err := decodeFields(b, func(num protowire.Number, v []byte) error {
+		switch num {
+		case channelName:
+			channel.Name = string(v)
+		case channelCSVName:
+			channel.CSVName = string(v)
+		}
+		return nil
+	})
+	return channel, err
+}
+
+func decodeBundle(b []byte) (Bundle, error) {
+	bundle := Bundle{}
+	err := decodeFields(b, func(num protowire.Number, v []byte) error {
+		switch num {
+		case bundleCSVName:
+			bundle.CSVName = string(v)
+		case bundlePackageName:
+			bundle.PackageName = string(v)
+		case bundleChannelName:
+			bundle.ChannelName = string(v)
+		case bundleVersion:
+			bundle.Version = string(v)
+		case bundleSkipRange:
+			bundle.SkipRange = string(v)
+		case bundleReplaces:
+			bundle.Replaces = string(v)
+		case bundleSkips:
+			bundle.Skips = append(bundle.Skips, string(v))
+		}
+		return nil
+	})
+	return bundle, err
+}
+
+// Calls fn for every length-delimited field of a protobuf message,
+// other wire types are skipped, as all fields read are strings or messages.
+func decodeFields(b []byte, fn func(num protowire.Number, v []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+		if err := fn(num, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
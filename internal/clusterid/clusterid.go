@@ -0,0 +1,122 @@
+// Package clusterid discovers the IDs identifying the cluster in OCM.
+package clusterid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Name of the optional ConfigMap in the addon-operator namespace
+// holding the cluster IDs, e.g. on clusters without a ClusterVersion.
+const DefaultConfigMapName = "cluster-id"
+
+// Keys supported in the cluster ID ConfigMap.
+const (
+	// External cluster ID, the .spec.clusterID of the ClusterVersion.
+	ExternalIDKey = "externalID"
+	// Internal OCM cluster ID.
+	InternalIDKey = "internalID"
+	// Name of the cluster in OCM.
+	NameKey = "name"
+)
+
+// Name of the ClusterVersion object holding the external cluster ID.
+const clusterVersionName = "version"
+
+// IDs identifying the cluster in OCM.
+type IDs struct {
+	// External cluster ID, used to look up the cluster in OCM.
+	External string
+	// Internal OCM cluster ID, looked up in OCM by the external ID when empty.
+	Internal string
+	// Name of the cluster in OCM, looked up in OCM by the external ID when empty.
+	Name string
+}
+
+type Options struct {
+	// Overrides discovered IDs, when set.
+	Overrides IDs
+	// ConfigMap holding IDs not found in the ClusterVersion.
+	// No ConfigMap is read when the name is empty.
+	ConfigMap client.ObjectKey
+}
+
+var ErrExternalIDNotFound = errors.New("cluster external ID not found")
+
+// Discovers the cluster IDs. The external ID is taken from the override,
+// the ClusterVersion or the ConfigMap, in that order.
+// The internal ID and name are taken from the overrides or the ConfigMap
+// and left empty otherwise.
+func Discover(ctx context.Context, c client.Reader, opts Options) (IDs, error) {
+	ids := opts.Overrides
+
+	if len(ids.External) == 0 {
+		external, err := externalIDFromClusterVersion(ctx, c)
+		if err != nil {
+			return IDs{}, err
+		}
+		ids.External = external
+	}
+
+	if len(ids.External) > 0 && len(ids.Internal) > 0 && len(ids.Name) > 0 {
+		return ids, nil
+	}
+
+	data, err := configMapData(ctx, c, opts.ConfigMap)
+	if err != nil {
+		return IDs{}, err
+	}
+	for key, id := range map[string]*string{
+		ExternalIDKey: &ids.External,
+		InternalIDKey: &ids.Internal,
+		NameKey:       &ids.Name,
+	} {
+		if len(*id) == 0 {
+			*id = data[key]
+		}
+	}
+
+	if len(ids.External) == 0 {
+		return IDs{}, ErrExternalIDNotFound
+	}
+	return ids, nil
+}
+
+// Returns the .spec.clusterID of the ClusterVersion,
+// or an empty string if the ClusterVersion or its API does not exist.
+func externalIDFromClusterVersion(ctx context.Context, c client.Reader) (string, error) {
+	cv := &configv1.ClusterVersion{}
+	err := c.Get(ctx, client.ObjectKey{Name: clusterVersionName}, cv)
+	if k8sApiErrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("getting ClusterVersion: %w", err)
+	}
+	return string(cv.Spec.ClusterID), nil
+}
+
+// Returns the data of the given ConfigMap,
+// or nil if it is not configured or does not exist.
+func configMapData(ctx context.Context, c client.Reader, key client.ObjectKey) (map[string]string, error) {
+	if len(key.Name) == 0 {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := c.Get(ctx, key, cm)
+	if k8sApiErrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster ID ConfigMap: %w", err)
+	}
+	return cm.Data, nil
+}
@@ -0,0 +1,139 @@
+package clusterid
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+var testConfigMapKey = client.ObjectKey{Name: DefaultConfigMapName, Namespace: "addon-operator"}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	notFound := k8sApiErrors.NewNotFound(schema.GroupResource{}, "")
+	noMatch := &meta.NoKindMatchError{}
+
+	for name, tc := range map[string]struct {
+		Overrides             IDs
+		ConfigMapKey          client.ObjectKey
+		ClusterVersionID      string
+		ClusterVersionErr     error
+		ConfigMapData         map[string]string
+		ConfigMapErr          error
+		ExpectConfigMapLookup bool
+		Expected              IDs
+		ExpectedErr           error
+	}{
+		"ClusterVersion": {
+			ClusterVersionID: "external",
+			Expected:         IDs{External: "external"},
+		},
+		"ClusterVersion and ConfigMap": {
+			ConfigMapKey:     testConfigMapKey,
+			ClusterVersionID: "external",
+			ConfigMapData: map[string]string{
+				ExternalIDKey: "ignored",
+				InternalIDKey: "internal",
+				NameKey:       "name",
+			},
+			ExpectConfigMapLookup: true,
+			Expected:              IDs{External: "external", Internal: "internal", Name: "name"},
+		},
+		"ClusterVersion API missing": {
+			ConfigMapKey:          testConfigMapKey,
+			ClusterVersionErr:     noMatch,
+			ConfigMapData:         map[string]string{ExternalIDKey: "external"},
+			ExpectConfigMapLookup: true,
+			Expected:              IDs{External: "external"},
+		},
+		"ClusterVersion not found": {
+			ConfigMapKey:          testConfigMapKey,
+			ClusterVersionErr:     notFound,
+			ConfigMapData:         map[string]string{ExternalIDKey: "external"},
+			ExpectConfigMapLookup: true,
+			Expected:              IDs{External: "external"},
+		},
+		"overrides": {
+			Overrides: IDs{External: "external", Internal: "internal", Name: "name"},
+			Expected:  IDs{External: "external", Internal: "internal", Name: "name"},
+		},
+		"ConfigMap not found": {
+			ConfigMapKey:          testConfigMapKey,
+			ClusterVersionID:      "external",
+			ConfigMapErr:          notFound,
+			ExpectConfigMapLookup: true,
+			Expected:              IDs{External: "external"},
+		},
+		"not found": {
+			ConfigMapKey:          testConfigMapKey,
+			ClusterVersionErr:     notFound,
+			ConfigMapErr:          notFound,
+			ExpectConfigMapLookup: true,
+			ExpectedErr:           ErrExternalIDNotFound,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("Get", testutil.IsContext, client.ObjectKey{Name: clusterVersionName},
+				mock.IsType(&configv1.ClusterVersion{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					cv := args.Get(2).(*configv1.ClusterVersion)
+					cv.Spec.ClusterID = configv1.ClusterID(tc.ClusterVersionID)
+				}).
+				Return(tc.ClusterVersionErr).
+				Maybe()
+			if tc.ExpectConfigMapLookup {
+				c.On("Get", testutil.IsContext, tc.ConfigMapKey,
+					mock.IsType(&corev1.ConfigMap{}), mock.Anything).
+					Run(func(args mock.Arguments) {
+						cm := args.Get(2).(*corev1.ConfigMap)
+						cm.Data = tc.ConfigMapData
+					}).
+					Return(tc.ConfigMapErr)
+			}
+
+			ids, err := Discover(context.Background(), c, Options{
+				Overrides: tc.Overrides,
+				ConfigMap: tc.ConfigMapKey,
+			})
+			if tc.ExpectedErr != nil {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, ids)
+			c.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiscover_ClusterVersionError(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&configv1.ClusterVersion{}), mock.Anything).
+		Return(errors.New("boom"))
+
+	_, err := Discover(context.Background(), c, Options{ConfigMap: testConfigMapKey})
+	require.Error(t, err)
+	c.AssertNotCalled(t, "Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&corev1.ConfigMap{}), mock.Anything)
+}
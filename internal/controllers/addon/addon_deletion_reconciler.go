@@ -33,6 +33,7 @@ func (c defaultClock) Now() time.Time {
 
 type addonDeletionReconciler struct {
 	clock    clock
+	hooks    *lifecycleHookRunner
 	handlers []addonDeletionHandler
 }
 
@@ -42,8 +43,14 @@ func (r *addonDeletionReconciler) Reconcile(ctx context.Context, addon *addonsv1
 		return ctrl.Result{}, nil
 	}
 
+	// The pre-delete hook has to complete before the addon is ready to be deleted.
+	hookCompleted, err := r.preDeleteHookCompleted(ctx, addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// if spec.DeleteAckRequired is false, we directly report ReadyToBeDeleted=true Status condition.
-	if !addon.Spec.DeleteAckRequired {
+	if !addon.Spec.DeleteAckRequired && hookCompleted {
 		removeDeleteTimeoutCondition(addon)
 		reportAddonReadyToBeDeletedStatus(addon, metav1.ConditionTrue)
 		return ctrl.Result{}, nil
@@ -52,6 +59,11 @@ func (r *addonDeletionReconciler) Reconcile(ctx context.Context, addon *addonsv1
 	// We set ReadyToBeDeleted=false status condition in response to the delete signal received from OCM.
 	reportAddonReadyToBeDeletedStatus(addon, metav1.ConditionFalse)
 
+	// Addons are only notified about their deletion after the pre-delete hook has completed.
+	if !hookCompleted {
+		return r.awaitDeletion(addon), nil
+	}
+
 	for _, handler := range r.handlers {
 		if err := handler.NotifyAddon(ctx, addon); err != nil {
 			return ctrl.Result{}, err
@@ -68,13 +80,25 @@ func (r *addonDeletionReconciler) Reconcile(ctx context.Context, addon *addonsv1
 		}
 	}
 
+	return r.awaitDeletion(addon), nil
+}
+
+func (r *addonDeletionReconciler) awaitDeletion(addon *addonsv1alpha1.Addon) ctrl.Result {
 	// If deletion has timed out.
 	if r.deletionTimedOut(addon) {
 		reportAddonDeletionTimedOut(addon)
-		return ctrl.Result{}, nil
+		return ctrl.Result{}
 	}
 	// If no ack is received from the addon, we arrange for a requeue after the deletetimeout duration.
-	return ctrl.Result{RequeueAfter: deleteTimeoutInterval(addon)}, nil
+	return ctrl.Result{RequeueAfter: deleteTimeoutInterval(addon)}
+}
+
+func (r *addonDeletionReconciler) preDeleteHookCompleted(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	if r.hooks == nil {
+		return true, nil
+	}
+	return r.hooks.preDeleteCompleted(ctx, addon)
 }
 
 // Deletion is timed out when (ReadyToBeDeleted=false) condition's last transition time + deleteTimeoutInterval
@@ -116,3 +140,7 @@ func awaitingRemoteDeletion(addon *addonsv1alpha1.Addon) bool {
 func (r *addonDeletionReconciler) Name() string {
 	return DELETION_RECONCILER_NAME
 }
+
+func (r *addonDeletionReconciler) Order() subReconcilerOrder {
+	return addonDeletionReconcilerOrder
+}
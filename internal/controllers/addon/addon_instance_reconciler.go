@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -37,6 +36,18 @@ func (r *addonInstanceReconciler) Name() string {
 	return ADDON_INSTANCE_RECONCILER_NAME
 }
 
+func (r *addonInstanceReconciler) Order() subReconcilerOrder {
+	return addonInstanceReconcilerOrder
+}
+
+func (r *addonInstanceReconciler) ConditionType() string {
+	return addonsv1alpha1.AddonInstanceReady
+}
+
+func (r *addonInstanceReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return true
+}
+
 // Ensures the presence of an AddonInstance well-compliant with the provided Addon object
 func (r *addonInstanceReconciler) ensureAddonInstance(
 	ctx context.Context, addon *addonsv1alpha1.Addon) (err error) {
@@ -67,25 +78,19 @@ func (r *addonInstanceReconciler) ensureAddonInstance(
 	return r.reconcileAddonInstance(ctx, desiredAddonInstance)
 }
 
-// Reconciles the reality to have the desired AddonInstance resource by creating it if it does not exist,
-// or updating if it exists with a different spec.
+// Reconciles the reality to have the desired AddonInstance resource by applying it server-side.
 func (r *addonInstanceReconciler) reconcileAddonInstance(
 	ctx context.Context, desiredAddonInstance *addonsv1alpha1.AddonInstance) error {
 	currentAddonInstance := &addonsv1alpha1.AddonInstance{}
 	err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredAddonInstance), currentAddonInstance)
-	if errors.IsNotFound(err) {
-		return r.client.Create(ctx, desiredAddonInstance)
-	}
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("getting AddonInstance: %w", err)
 	}
 	// We don't want to overwrite the marked for deletion field of the existing
 	// addoninstance. The addon deletion sub-reconciler handles that part.
 	desiredAddonInstance.Spec.MarkedForDeletion = currentAddonInstance.Spec.MarkedForDeletion
-	if !equality.Semantic.DeepEqual(currentAddonInstance.Spec, desiredAddonInstance.Spec) {
-		currentAddonInstance.Spec = desiredAddonInstance.Spec
-		currentAddonInstance.OwnerReferences = desiredAddonInstance.OwnerReferences
-		return r.client.Update(ctx, currentAddonInstance)
+	if err := controllers.Apply(ctx, r.client, desiredAddonInstance); err != nil {
+		return fmt.Errorf("applying AddonInstance: %w", err)
 	}
 	return nil
 }
@@ -119,34 +119,19 @@ func TestEnsureAddonInstance(t *testing.T) {
 				}
 
 				var reconciledInstance *addonsv1alpha1.AddonInstance
-				// update path
-				if test.existingAddonInstance != nil {
-					c.
-						On(
-							"Update",
-							mock.Anything,
-							mock.IsType(&addonsv1alpha1.AddonInstance{}),
-							mock.Anything,
-						).
-						Run(func(args mock.Arguments) {
-							reconciledInstance = args.Get(1).(*addonsv1alpha1.AddonInstance)
-						}).
-						Return(nil)
+				c.
+					On(
+						"Patch",
+						mock.Anything,
+						mock.IsType(&addonsv1alpha1.AddonInstance{}),
+						client.Apply,
+						mock.Anything,
+					).
+					Run(func(args mock.Arguments) {
+						reconciledInstance = args.Get(1).(*addonsv1alpha1.AddonInstance)
+					}).
+					Return(nil)
 
-				} else {
-					// Create path
-					c.
-						On(
-							"Create",
-							mock.Anything,
-							mock.IsType(&addonsv1alpha1.AddonInstance{}),
-							mock.Anything,
-						).
-						Run(func(args mock.Arguments) {
-							reconciledInstance = args.Get(1).(*addonsv1alpha1.AddonInstance)
-						}).
-						Return(nil)
-				}
 				// Test
 				ctx := context.Background()
 				controllers.ContextWithLogger(ctx, log)
@@ -297,18 +282,25 @@ func TestReconcileAddonInstance(t *testing.T) {
 				mock.IsType(&addonsv1alpha1.AddonInstance{}),
 				mock.Anything,
 			).
-			Run(func(args mock.Arguments) {
-				fetchedAddonInstance := args.Get(2).(*addonsv1alpha1.AddonInstance)
-				addonInstance.DeepCopyInto(fetchedAddonInstance)
-			}).
+			Return(testutil.NewTestErrNotFound())
+
+		c.
+			On(
+				"Patch",
+				mock.Anything,
+				mock.IsType(&addonsv1alpha1.AddonInstance{}),
+				client.Apply,
+				mock.Anything,
+			).
 			Return(nil)
 
 		ctx := context.Background()
 		err := r.reconcileAddonInstance(ctx, addonInstance.DeepCopy())
 		require.NoError(t, err)
+		c.AssertExpectations(t)
 	})
 
-	t.Run("update", func(t *testing.T) {
+	t.Run("keeps marked for deletion", func(t *testing.T) {
 		c := testutil.NewClient()
 		r := addonInstanceReconciler{
 			client: c,
@@ -323,26 +315,33 @@ func TestReconcileAddonInstance(t *testing.T) {
 				mock.IsType(&addonsv1alpha1.AddonInstance{}),
 				mock.Anything,
 			).
+			Run(func(args mock.Arguments) {
+				fetchedAddonInstance := args.Get(2).(*addonsv1alpha1.AddonInstance)
+				addonInstance.DeepCopyInto(fetchedAddonInstance)
+				fetchedAddonInstance.Spec.MarkedForDeletion = true
+			}).
 			Return(nil)
 
+		var appliedAddonInstance *addonsv1alpha1.AddonInstance
 		c.
 			On(
-				"Update",
+				"Patch",
 				mock.Anything,
 				mock.IsType(&addonsv1alpha1.AddonInstance{}),
+				client.Apply,
 				mock.Anything,
 			).
+			Run(func(args mock.Arguments) {
+				appliedAddonInstance = args.Get(1).(*addonsv1alpha1.AddonInstance)
+			}).
 			Return(nil)
 
 		ctx := context.Background()
 		err := r.reconcileAddonInstance(ctx, addonInstance.DeepCopy())
 		require.NoError(t, err)
+		c.AssertExpectations(t)
 
-		c.AssertCalled(t,
-			"Update",
-			mock.Anything,
-			mock.IsType(&addonsv1alpha1.AddonInstance{}),
-			mock.Anything,
-		)
+		require.NotNil(t, appliedAddonInstance)
+		assert.True(t, appliedAddonInstance.Spec.MarkedForDeletion)
 	})
 }
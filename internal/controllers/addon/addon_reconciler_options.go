@@ -1,11 +1,17 @@
 package addon
 
 import (
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	pkov1alpha1 "package-operator.run/apis/core/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 )
 
 type AddonReconcilerOptions interface {
@@ -20,14 +26,38 @@ type WithMonitoringStackReconciler struct {
 
 func (w WithMonitoringStackReconciler) ApplyToAddonReconciler(config *AddonReconciler) {
 	msReconciler := &monitoringStackReconciler{
-		client: w.Client,
-		scheme: w.Scheme,
+		client:                 w.Client,
+		scheme:                 w.Scheme,
+		addonOperatorNamespace: config.AddonOperatorNamespace,
+		seriesCounter:          newPrometheusSeriesCounter(),
+		seriesCounts:           newSeriesCountCache(seriesCountInterval),
+		alertReceiver:          config.alertReceiver,
+	}
+	config.registerSubReconciler(msReconciler)
+
+	// Snitches are checked in by the Alertmanager of the MonitoringStack.
+	config.registerSubReconciler(&deadMansSnitchReconciler{
+		client:    w.Client,
+		scheme:    w.Scheme,
+		clusterID: config.ClusterExternalID,
+		snitches:  config.deadMansSnitch,
+	})
+	config.registerFinalizer(&deadMansSnitchFinalizer{
+		client:   w.Client,
+		snitches: config.deadMansSnitch,
+	})
+
+	for _, finalizer := range config.finalizers {
+		if monitoringFinalizer, ok := finalizer.(*monitoringFinalizer); ok {
+			monitoringFinalizer.monitoringStacks = true
+		}
 	}
-	config.subReconcilers = append(config.subReconcilers, msReconciler)
 }
 
 func (w WithMonitoringStackReconciler) ApplyToControllerBuilder(b *builder.Builder) {
-	b.Owns(&obov1alpha1.MonitoringStack{})
+	b.Owns(&obov1alpha1.MonitoringStack{}).
+		Owns(&monv1alpha1.AlertmanagerConfig{}).
+		Owns(&monv1.PrometheusRule{})
 }
 
 type WithPackageOperatorReconciler struct {
@@ -42,9 +72,161 @@ func (w WithPackageOperatorReconciler) ApplyToAddonReconciler(config *AddonRecon
 		ClusterID:      config.ClusterExternalID,
 		OcmClusterInfo: config.GetOCMClusterInfo,
 	}
-	config.subReconcilers = append(config.subReconcilers, poReconciler)
+	config.registerSubReconciler(poReconciler)
+
+	// Installs Addons of the PackageOperator install type.
+	config.registerSubReconciler(&packageInstallReconciler{
+		client:         w.Client,
+		scheme:         w.Scheme,
+		clusterID:      config.ClusterExternalID,
+		ocmClusterInfo: config.GetOCMClusterInfo,
+	})
 }
 
 func (w WithPackageOperatorReconciler) ApplyToControllerBuilder(b *builder.Builder) {
-	b.Owns(&pkov1alpha1.ClusterObjectTemplate{})
+	b.Owns(&pkov1alpha1.ClusterObjectTemplate{}).
+		Owns(&pkov1alpha1.ClusterPackage{})
+}
+
+type WithReadinessProbeReconciler struct {
+	// Client used for RoleBindings and to look up Pods for exec probes.
+	Client     client.Client
+	Scheme     *runtime.Scheme
+	Clientset  kubernetes.Interface
+	RestConfig *rest.Config
+	// ServiceAccount of the Addon Operator, allowed to exec into Pods of exec probes.
+	ServiceAccount client.ObjectKey
+}
+
+func (w WithReadinessProbeReconciler) ApplyToAddonReconciler(config *AddonReconciler) {
+	rpReconciler := &readinessProbeReconciler{
+		client:         w.Client,
+		scheme:         w.Scheme,
+		serviceAccount: w.ServiceAccount,
+		prober:         newDefaultReadinessProber(w.Client, w.Clientset, w.RestConfig),
+		results:        newReadinessProbeResults(),
+	}
+	config.registerSubReconciler(rpReconciler)
+}
+
+func (w WithReadinessProbeReconciler) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Emits Events on Addons, e.g. when manual changes to their objects are reverted.
+type WithEventRecorder struct {
+	Recorder record.EventRecorder
+}
+
+func (w WithEventRecorder) ApplyToAddonReconciler(config *AddonReconciler) {
+	config.events = w.Recorder
+	config.drift.events = w.Recorder
+}
+
+func (w WithEventRecorder) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Sets the number of reconcile workers and rate limits requeues
+// of Addons per shard of Addon names.
+// All Addons share one workqueue and its workers, only the rate limit is sharded.
+type WithReconcileRateLimits struct {
+	// Maximum number of Addons reconciled concurrently.
+	MaxConcurrentReconciles int
+	// Number of separate requeue rate limits.
+	RateLimitShards int
+	// Sustained rate and burst of requeues per shard.
+	QPS   float64
+	Burst int
+}
+
+func (w WithReconcileRateLimits) ApplyToAddonReconciler(config *AddonReconciler) {}
+
+func (w WithReconcileRateLimits) ApplyToControllerBuilder(b *builder.Builder) {
+	b.WithOptions(controller.Options{
+		MaxConcurrentReconciles: w.MaxConcurrentReconciles,
+		RateLimiter:             newShardedRateLimiter(w.RateLimitShards, w.QPS, w.Burst),
+	})
+}
+
+// Replaces the resolver used to pin catalog images to their digest.
+type WithImageDigestResolver struct {
+	Resolver ImageDigestResolver
+}
+
+func (w WithImageDigestResolver) ApplyToAddonReconciler(config *AddonReconciler) {
+	config.catalogImages.resolver = w.Resolver
+}
+
+func (w WithImageDigestResolver) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Checks the health of the monitoring federation targets in cluster-monitoring.
+type WithMonitoringFederationHealthCheck struct {
+	Checker TargetHealthChecker
 }
+
+func (w WithMonitoringFederationHealthCheck) ApplyToAddonReconciler(config *AddonReconciler) {
+	for _, reconciler := range config.subReconcilers {
+		if federationReconciler, ok := reconciler.(*monitoringFederationReconciler); ok {
+			federationReconciler.targetHealth = w.Checker
+		}
+	}
+}
+
+func (w WithMonitoringFederationHealthCheck) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Creates missing .spec.monitoring.federation.namespace namespaces
+// with a monitoring ResourceQuota and LimitRange, owned by their Addon.
+type WithMonitoringFederationNamespaceCreation struct{}
+
+func (w WithMonitoringFederationNamespaceCreation) ApplyToAddonReconciler(config *AddonReconciler) {
+	for _, reconciler := range config.subReconcilers {
+		if federationReconciler, ok := reconciler.(*monitoringFederationReconciler); ok {
+			federationReconciler.createFederationNamespaces = true
+		}
+	}
+}
+
+func (w WithMonitoringFederationNamespaceCreation) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Silences the alerts of upgrading Addons opted in via .spec.monitoring.silenceDuringUpgrade.
+type WithUpgradeAlertSilencing struct {
+	Silencer AlertSilencer
+}
+
+func (w WithUpgradeAlertSilencing) ApplyToAddonReconciler(config *AddonReconciler) {
+	config.alertSilencer = w.Silencer
+}
+
+func (w WithUpgradeAlertSilencing) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Renders Addon probes into Probes against the given blackbox exporter
+// and folds their results into the Available condition of Addons.
+type WithMonitoringProbes struct {
+	// Address of the blackbox exporter, e.g. "blackbox-exporter.openshift-monitoring.svc:9115".
+	ProberURL string
+	// Looks up probe results, optional.
+	Results ProbeResultChecker
+}
+
+func (w WithMonitoringProbes) ApplyToAddonReconciler(config *AddonReconciler) {
+	for _, reconciler := range config.subReconcilers {
+		if probesReconciler, ok := reconciler.(*monitoringProbesReconciler); ok {
+			probesReconciler.proberURL = w.ProberURL
+			probesReconciler.results = w.Results
+		}
+	}
+}
+
+func (w WithMonitoringProbes) ApplyToControllerBuilder(b *builder.Builder) {}
+
+// Periodically evaluates the error budgets of the SLOs declared in .spec.monitoring.slos.
+type WithMonitoringSLOEvaluation struct {
+	Evaluator SLOEvaluator
+}
+
+func (w WithMonitoringSLOEvaluation) ApplyToAddonReconciler(config *AddonReconciler) {
+	for _, reconciler := range config.subReconcilers {
+		if slosReconciler, ok := reconciler.(*monitoringSLOsReconciler); ok {
+			slosReconciler.evaluator = w.Evaluator
+		}
+	}
+}
+
+func (w WithMonitoringSLOEvaluation) ApplyToControllerBuilder(b *builder.Builder) {}
@@ -0,0 +1,88 @@
+package addon
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Class of failures sharing a requeue interval.
+type failureClass int
+
+const (
+	// Syncing the Addon status with OCM failed.
+	failureClassOCMError failureClass = iota
+	// OLM objects of the Addon are not ready yet.
+	failureClassOLMNotReady
+	// The Addon configuration failed validation.
+	failureClassValidationFailure
+)
+
+// Requeue intervals per class of failure, configured via the AddonOperator.
+// Classes without an interval keep their default behavior.
+type backoffPolicy struct {
+	mux       sync.RWMutex
+	intervals map[failureClass]time.Duration
+}
+
+// Replaces the configured intervals with the given policy.
+// A nil policy resets all classes to their default behavior.
+func (p *backoffPolicy) set(policy *addonsv1alpha1.AddonOperatorBackoffPolicy) {
+	intervals := map[failureClass]time.Duration{}
+	if policy != nil {
+		for class, d := range map[failureClass]*metav1.Duration{
+			failureClassOCMError:          policy.OCMError,
+			failureClassOLMNotReady:       policy.OLMNotReady,
+			failureClassValidationFailure: policy.ValidationFailure,
+		} {
+			if d != nil && d.Duration > 0 {
+				intervals[class] = d.Duration
+			}
+		}
+	}
+
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	p.intervals = intervals
+}
+
+// Returns the interval configured for the given class of failures,
+// or zero if the class keeps its default behavior.
+func (p *backoffPolicy) interval(class failureClass) time.Duration {
+	if p == nil {
+		return 0
+	}
+
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	return p.intervals[class]
+}
+
+// Like handleExit, but rechecks OLM objects that are not ready yet
+// at the interval configured for them.
+func (p *backoffPolicy) handleOLMExit(result requeueResult) ctrl.Result {
+	if d := p.interval(failureClassOLMNotReady); result == resultRetry && d > 0 {
+		return ctrl.Result{RequeueAfter: d}
+	}
+	return handleExit(result)
+}
+
+// Retries misconfigured Addons at the interval configured for validation failures.
+// Without an interval, misconfigured Addons are only reconciled again once they change.
+func (p *backoffPolicy) requeueForValidationFailure(addon *addonsv1alpha1.Addon) ctrl.Result {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	if cond == nil || cond.Reason != addonsv1alpha1.AddonReasonConfigError {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: p.interval(failureClassValidationFailure)}
+}
+
+// Sets the requeue intervals of Addons per class of failure. Concurrency safe.
+func (r *AddonReconciler) SetBackoffPolicy(policy *addonsv1alpha1.AddonOperatorBackoffPolicy) {
+	r.backoff.set(policy)
+}
@@ -0,0 +1,49 @@
+package addon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestBackoffPolicy_HandleOLMExit(t *testing.T) {
+	t.Parallel()
+
+	p := &backoffPolicy{}
+	assert.Equal(t, handleExit(resultRetry), p.handleOLMExit(resultRetry))
+
+	p.set(&addonsv1alpha1.AddonOperatorBackoffPolicy{
+		OLMNotReady: &metav1.Duration{Duration: 5 * time.Second},
+	})
+	assert.Equal(t, ctrl.Result{RequeueAfter: 5 * time.Second}, p.handleOLMExit(resultRetry))
+	assert.Equal(t, ctrl.Result{}, p.handleOLMExit(resultStop))
+	assert.Zero(t, p.interval(failureClassOCMError))
+
+	// Removing the policy restores the defaults.
+	p.set(nil)
+	assert.Equal(t, handleExit(resultRetry), p.handleOLMExit(resultRetry))
+}
+
+func TestBackoffPolicy_RequeueForValidationFailure(t *testing.T) {
+	t.Parallel()
+
+	p := &backoffPolicy{}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	reportConfigurationError(addon, "broken")
+	assert.Equal(t, ctrl.Result{}, p.requeueForValidationFailure(addon))
+
+	p.set(&addonsv1alpha1.AddonOperatorBackoffPolicy{
+		ValidationFailure: &metav1.Duration{Duration: time.Minute},
+	})
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute}, p.requeueForValidationFailure(addon))
+
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.Available)
+	assert.Equal(t, ctrl.Result{}, p.requeueForValidationFailure(addon))
+}
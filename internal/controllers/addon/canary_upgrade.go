@@ -0,0 +1,191 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Time the staging catalog has to stay healthy, if not configured in the Addon.
+const defaultCanaryHealthCheckWindow = 15 * time.Minute
+
+// Stages new catalog images of Addons with the Canary upgrade strategy.
+// The new image is served by a staging CatalogSource first,
+// while the CatalogSource of the Subscription keeps the current image.
+// Only when the staging catalog offers the expected CSV and stayed healthy
+// for the health check window, the new image is rolled out to the Subscription.
+func (r *olmReconciler) stageCanaryUpgrade(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	desiredCatalogSource *operatorsv1alpha1.CatalogSource,
+	packageName, channel string,
+) error {
+	stagingCatalogSource := desiredCatalogSource.DeepCopy()
+	stagingCatalogSource.Name = stagingCatalogSourceName(desiredCatalogSource.Name)
+
+	if !canaryUpgrade(addon) {
+		if addon.Status.Canary == nil {
+			return nil
+		}
+		// The strategy changed while an upgrade was staged.
+		return r.concludeCanaryUpgrade(ctx, addon, stagingCatalogSource)
+	}
+
+	currentCatalogSource := &operatorsv1alpha1.CatalogSource{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredCatalogSource), currentCatalogSource); err != nil {
+		if k8sApiErrors.IsNotFound(err) {
+			// Initial installs are not staged.
+			return r.concludeCanaryUpgrade(ctx, addon, stagingCatalogSource)
+		}
+		return fmt.Errorf("getting CatalogSource: %w", err)
+	}
+	if currentCatalogSource.Spec.Image == desiredCatalogSource.Spec.Image {
+		return r.concludeCanaryUpgrade(ctx, addon, stagingCatalogSource)
+	}
+
+	newImage := desiredCatalogSource.Spec.Image
+	status := addon.Status.Canary
+	if status == nil || status.Image != newImage {
+		status = &addonsv1alpha1.AddonCanaryStatus{Image: newImage}
+		addon.Status.Canary = status
+	}
+
+	healthy, err := r.checkStagingCatalog(ctx, addon, stagingCatalogSource, packageName, channel)
+	if err != nil {
+		return err
+	}
+	now := r.clock.Now()
+	if !healthy {
+		status.HealthySince = nil
+		desiredCatalogSource.Spec.Image = currentCatalogSource.Spec.Image
+		return nil
+	}
+	if status.HealthySince == nil {
+		status.HealthySince = &metav1.Time{Time: now}
+	}
+
+	promoteAt := status.HealthySince.Add(canaryHealthCheckWindow(addon))
+	if now.Before(promoteAt) {
+		reportCanaryUpgrade(addon, addonsv1alpha1.AddonReasonCanaryHealthCheck, fmt.Sprintf(
+			"Catalog %s offering %s is health checked until %s.",
+			newImage, status.TargetCSV, promoteAt.UTC().Format(time.RFC3339)))
+		desiredCatalogSource.Spec.Image = currentCatalogSource.Spec.Image
+		return nil
+	}
+
+	// The staging catalog passed, roll the new image out to the Subscription.
+	// The staging CatalogSource is removed, once the CatalogSource serves the new image.
+	controllers.LoggerFromContext(ctx).Info("promoting canary catalog",
+		"image", newImage, "targetCSV", status.TargetCSV)
+	return nil
+}
+
+// Reconciles the staging CatalogSource and checks that it is ready
+// and offers a CSV matching the version of the Addon in its channel.
+func (r *olmReconciler) checkStagingCatalog(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	stagingCatalogSource *operatorsv1alpha1.CatalogSource,
+	packageName, channel string,
+) (healthy bool, err error) {
+	observed, _, err := reconcileCatalogSource(ctx, r.client, stagingCatalogSource)
+	if err != nil {
+		return false, fmt.Errorf("reconciling staging CatalogSource: %w", err)
+	}
+	if state := observed.Status.GRPCConnectionState; state == nil || state.LastObservedState != "READY" {
+		reportCanaryUpgrade(addon, addonsv1alpha1.AddonReasonCanaryUnhealthy,
+			fmt.Sprintf("Staging catalog %s is not ready.", stagingCatalogSource.Spec.Image))
+		return false, nil
+	}
+
+	packageChannel, err := r.getPackageChannel(ctx, observed, packageName, channel)
+	if err != nil {
+		return false, err
+	}
+	if packageChannel == nil {
+		reportCanaryUpgrade(addon, addonsv1alpha1.AddonReasonCanaryUnhealthy, fmt.Sprintf(
+			"Channel %q of package %q not found in staging catalog %s.",
+			channel, packageName, stagingCatalogSource.Spec.Image))
+		return false, nil
+	}
+
+	targetCSV := packageChannel.CurrentCSV
+	addon.Status.Canary.TargetCSV = targetCSV
+	if len(addon.Spec.Version) > 0 && !csvNameHasVersion(targetCSV, addon.Spec.Version) {
+		reportCanaryUpgrade(addon, addonsv1alpha1.AddonReasonCanaryUnhealthy, fmt.Sprintf(
+			"Staging catalog %s offers %s, which does not match version %q.",
+			stagingCatalogSource.Spec.Image, targetCSV, addon.Spec.Version))
+		return false, nil
+	}
+	return true, nil
+}
+
+// Removes the staging CatalogSource and the canary status of the Addon.
+func (r *olmReconciler) concludeCanaryUpgrade(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	stagingCatalogSource *operatorsv1alpha1.CatalogSource,
+) error {
+	addon.Status.Canary = nil
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.CanaryUpgrade)
+
+	// check the cache first to not issue a delete request on every reconcile
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(stagingCatalogSource),
+		&operatorsv1alpha1.CatalogSource{}); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := r.client.Delete(ctx, stagingCatalogSource); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting staging CatalogSource: %w", err)
+	}
+	return nil
+}
+
+func canaryUpgrade(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.UpgradePolicy != nil &&
+		addon.Spec.UpgradePolicy.Strategy == addonsv1alpha1.UpgradeStrategyCanary
+}
+
+func canaryHealthCheckWindow(addon *addonsv1alpha1.Addon) time.Duration {
+	if window := addon.Spec.UpgradePolicy.CanaryHealthCheckWindow; window != nil {
+		return window.Duration
+	}
+	return defaultCanaryHealthCheckWindow
+}
+
+// Requeues Addons with a staged canary upgrade when their health check window ends,
+// or to check their unhealthy staging catalog again.
+func requeueForCanaryUpgrade(addon *addonsv1alpha1.Addon, now time.Time) ctrl.Result {
+	status := addon.Status.Canary
+	if !canaryUpgrade(addon) || status == nil ||
+		!meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.CanaryUpgrade) {
+		return ctrl.Result{}
+	}
+	if status.HealthySince == nil {
+		return handleExit(resultRetry)
+	}
+	if promoteAt := status.HealthySince.Add(canaryHealthCheckWindow(addon)); now.Before(promoteAt) {
+		return ctrl.Result{RequeueAfter: promoteAt.Sub(now)}
+	}
+	return ctrl.Result{}
+}
+
+func reportCanaryUpgrade(addon *addonsv1alpha1.Addon, reason, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.CanaryUpgrade,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func stagingCatalogSourceName(catalogSourceName string) string {
+	return fmt.Sprintf("%s-staging", catalogSourceName)
+}
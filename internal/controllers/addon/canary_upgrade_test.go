@@ -0,0 +1,207 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestStageCanaryUpgrade(t *testing.T) {
+	t.Parallel()
+
+	const (
+		oldImage = "quay.io/osd-addons/reference-addon-index:old"
+		newImage = "quay.io/osd-addons/reference-addon-index:new"
+	)
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		Version         string
+		StagingState    string
+		HealthySince    *metav1.Time
+		ExpectedImage   string
+		ExpectedReason  string
+		ExpectedHealthy bool
+	}{
+		"starts health check": {
+			Version:         "0.3.0",
+			StagingState:    "READY",
+			ExpectedImage:   oldImage,
+			ExpectedReason:  addonsv1alpha1.AddonReasonCanaryHealthCheck,
+			ExpectedHealthy: true,
+		},
+		"staging catalog not ready": {
+			StagingState:   "CONNECTING",
+			HealthySince:   &metav1.Time{Time: now.Add(-time.Minute)},
+			ExpectedImage:  oldImage,
+			ExpectedReason: addonsv1alpha1.AddonReasonCanaryUnhealthy,
+		},
+		"target CSV does not match version": {
+			Version:        "0.4.0",
+			StagingState:   "READY",
+			ExpectedImage:  oldImage,
+			ExpectedReason: addonsv1alpha1.AddonReasonCanaryUnhealthy,
+		},
+		"promotes after health check window": {
+			Version:         "0.3.0",
+			StagingState:    "READY",
+			HealthySince:    &metav1.Time{Time: now.Add(-time.Hour)},
+			ExpectedImage:   newImage,
+			ExpectedHealthy: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			uncachedClient := testutil.NewClient()
+			clock := &testClock{}
+			clock.On("Now").Return(now)
+			r := &olmReconciler{client: c, uncachedClient: uncachedClient, clock: clock}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Version = tc.Version
+			addon.Spec.UpgradePolicy = &addonsv1alpha1.AddonUpgradePolicy{
+				ID:       "123",
+				Strategy: addonsv1alpha1.UpgradeStrategyCanary,
+			}
+			addon.Status.Canary = &addonsv1alpha1.AddonCanaryStatus{
+				Image:        newImage,
+				HealthySince: tc.HealthySince,
+			}
+
+			desired := testutil.NewTestCatalogSource()
+			desired.Spec.Image = newImage
+
+			c.On("Get", testutil.IsContext, client.ObjectKeyFromObject(desired),
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					current := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+					current.Spec.Image = oldImage
+				}).
+				Return(nil)
+			c.On("Get", testutil.IsContext, client.ObjectKey{
+				Name:      stagingCatalogSourceName(desired.Name),
+				Namespace: desired.Namespace,
+			}, testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+				Return(nil)
+			c.On("Patch", testutil.IsContext,
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr, client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					staging := args.Get(1).(*operatorsv1alpha1.CatalogSource)
+					staging.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
+						LastObservedState: tc.StagingState,
+					}
+				}).
+				Return(nil)
+			uncachedClient.On("List", testutil.IsContext,
+				mock.IsType(&unstructured.UnstructuredList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*unstructured.UnstructuredList)
+					list.Items = []unstructured.Unstructured{{
+						Object: map[string]interface{}{
+							"metadata": map[string]interface{}{"name": "reference-addon"},
+							"status": map[string]interface{}{
+								"channels": []interface{}{
+									map[string]interface{}{
+										"name":       "alpha",
+										"currentCSV": "reference-addon.v0.3.0",
+									},
+								},
+							},
+						},
+					}}
+				}).
+				Return(nil)
+
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			require.NoError(t, r.stageCanaryUpgrade(ctx, addon, desired, "reference-addon", "alpha"))
+
+			assert.Equal(t, tc.ExpectedImage, desired.Spec.Image)
+			require.NotNil(t, addon.Status.Canary)
+			assert.Equal(t, tc.ExpectedHealthy, addon.Status.Canary.HealthySince != nil)
+			if len(tc.ExpectedReason) > 0 {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.CanaryUpgrade)
+				require.NotNil(t, cond)
+				assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			}
+		})
+	}
+}
+
+func TestStageCanaryUpgrade_Concluded(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &olmReconciler{client: c}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.UpgradePolicy = &addonsv1alpha1.AddonUpgradePolicy{
+		ID:       "123",
+		Strategy: addonsv1alpha1.UpgradeStrategyCanary,
+	}
+	addon.Status.Canary = &addonsv1alpha1.AddonCanaryStatus{Image: "quay.io/osd-addons/test:new"}
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.CanaryUpgrade,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonCanaryHealthCheck,
+	})
+
+	desired := testutil.NewTestCatalogSource()
+	c.On("Get", testutil.IsContext, mock.Anything,
+		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			current := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+			current.Spec.Image = desired.Spec.Image
+		}).
+		Return(nil)
+	c.On("Delete", testutil.IsContext,
+		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+		Return(nil)
+
+	require.NoError(t, r.stageCanaryUpgrade(context.Background(), addon, desired, "reference-addon", "alpha"))
+	c.AssertCalled(t, "Delete", testutil.IsContext,
+		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything)
+	assert.Nil(t, addon.Status.Canary)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.CanaryUpgrade))
+}
+
+func TestRequeueForCanaryUpgrade(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.UpgradePolicy = &addonsv1alpha1.AddonUpgradePolicy{
+		ID:                      "123",
+		Strategy:                addonsv1alpha1.UpgradeStrategyCanary,
+		CanaryHealthCheckWindow: &metav1.Duration{Duration: 30 * time.Minute},
+	}
+	addon.Status.Canary = &addonsv1alpha1.AddonCanaryStatus{
+		Image:        "quay.io/osd-addons/test:new",
+		HealthySince: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+	}
+	assert.Equal(t, ctrl.Result{}, requeueForCanaryUpgrade(addon, now))
+
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.CanaryUpgrade,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonCanaryHealthCheck,
+	})
+	assert.Equal(t, 20*time.Minute, requeueForCanaryUpgrade(addon, now).RequeueAfter)
+}
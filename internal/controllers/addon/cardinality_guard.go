@@ -0,0 +1,221 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Port of the Prometheus service created by the observability-operator for a MonitoringStack.
+const monitoringStackPrometheusPort = 9090
+
+const (
+	// Series are counted at most once per interval and Addon,
+	// as counting all series of a Prometheus instance is expensive.
+	seriesCountInterval = 5 * time.Minute
+	// Timeout of a single series count, so an unresponsive Prometheus
+	// does not hold back the reconcile of the Addon.
+	seriesCountTimeout = 10 * time.Second
+)
+
+// seriesCounter counts the series matching a selector in a Prometheus instance.
+type seriesCounter interface {
+	CountSeries(ctx context.Context, prometheusURL, selector string) (int64, error)
+}
+
+// prometheusSeriesCounter counts series using the Prometheus HTTP API.
+type prometheusSeriesCounter struct {
+	// Shared by the Prometheus instances of all Addons.
+	httpClient *http.Client
+}
+
+func newPrometheusSeriesCounter() prometheusSeriesCounter {
+	return prometheusSeriesCounter{
+		httpClient: &http.Client{Timeout: seriesCountTimeout},
+	}
+}
+
+func (c prometheusSeriesCounter) CountSeries(
+	ctx context.Context, prometheusURL, selector string,
+) (int64, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: prometheusURL, Client: c.httpClient})
+	if err != nil {
+		return 0, fmt.Errorf("creating prometheus client: %w", err)
+	}
+
+	res, _, err := promv1.NewAPI(client).Query(ctx, fmt.Sprintf("count(%s)", selector), time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("querying prometheus: %w", err)
+	}
+
+	vector, ok := res.(model.Vector)
+	if !ok {
+		return 0, fmt.Errorf("unexpected prometheus result type: %s", res.Type())
+	}
+	// count() over no matching series returns an empty vector.
+	if len(vector) == 0 {
+		return 0, nil
+	}
+	return int64(vector[0].Value), nil
+}
+
+// Last series counts of Addons, keyed by Addon name.
+type seriesCountCache struct {
+	mux      sync.Mutex
+	interval time.Duration
+	counts   map[string]seriesCount
+}
+
+type seriesCount struct {
+	series    int64
+	err       error
+	countedAt time.Time
+}
+
+func newSeriesCountCache(interval time.Duration) *seriesCountCache {
+	return &seriesCountCache{
+		interval: interval,
+		counts:   map[string]seriesCount{},
+	}
+}
+
+// Returns the last count of the Addon, unless it is older than the interval.
+func (c *seriesCountCache) get(addonName string, now time.Time) (seriesCount, bool) {
+	if c == nil {
+		return seriesCount{}, false
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	count, ok := c.counts[addonName]
+	if !ok || now.Sub(count.countedAt) >= c.interval {
+		return seriesCount{}, false
+	}
+	return count, true
+}
+
+func (c *seriesCountCache) set(addonName string, count seriesCount) {
+	if c == nil {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.counts[addonName] = count
+}
+
+func (c *seriesCountCache) forget(addonName string) {
+	if c == nil {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.counts, addonName)
+}
+
+// Checks the number of series remote-written by the Addon against its CardinalityGuard
+// and reports the result as RemoteWriteDegraded condition.
+// Returns true when the series limit is exceeded.
+//
+// Series are counted in the MonitoringStack's local TSDB which is not affected by
+// write relabeling, so dropping metrics from remote write does not cause flapping.
+// Counts are reused for the seriesCountInterval, including failed ones.
+func (r *monitoringStackReconciler) checkSeriesLimit(ctx context.Context,
+	addon *addonsv1alpha1.Addon, namespace string) (exceeded bool) {
+	log := controllers.LoggerFromContext(ctx)
+
+	rhobsRemoteWriteConfig := addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig
+	if rhobsRemoteWriteConfig == nil || rhobsRemoteWriteConfig.CardinalityGuard == nil {
+		r.seriesCounts.forget(addon.Name)
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.RemoteWriteDegraded)
+		return false
+	}
+	guard := rhobsRemoteWriteConfig.CardinalityGuard
+
+	now := time.Now()
+	count, ok := r.seriesCounts.get(addon.Name, now)
+	if !ok {
+		count.series, count.err = r.seriesCounter.CountSeries(ctx,
+			getMonitoringStackPrometheusURL(addon.Name, namespace),
+			getRemoteWriteSeriesSelector(rhobsRemoteWriteConfig.Allowlist))
+		count.countedAt = now
+		r.seriesCounts.set(addon.Name, count)
+		if count.err != nil {
+			log.Error(count.err, "counting remote write series")
+		}
+	}
+	if count.err != nil {
+		// The MonitoringStack may not be up yet,
+		// keep the last known state instead of blocking the Addon.
+		return meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.RemoteWriteDegraded)
+	}
+	series := count.series
+
+	if series > guard.SeriesLimit {
+		reportSeriesLimitExceeded(addon, series, guard.SeriesLimit)
+		return true
+	}
+	reportSeriesWithinLimit(addon, series, guard.SeriesLimit)
+	return false
+}
+
+func reportSeriesLimitExceeded(addon *addonsv1alpha1.Addon, series, limit int64) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.RemoteWriteDegraded,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonSeriesLimitExceeded,
+		Message: fmt.Sprintf(
+			"Remote writing %d series exceeds the limit of %d series", series, limit),
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func reportSeriesWithinLimit(addon *addonsv1alpha1.Addon, series, limit int64) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.RemoteWriteDegraded,
+		Status: metav1.ConditionFalse,
+		Reason: addonsv1alpha1.AddonReasonSeriesWithinLimit,
+		Message: fmt.Sprintf(
+			"Remote writing %d of %d allowed series", series, limit),
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func getMonitoringStackPrometheusURL(addonName, namespace string) string {
+	return fmt.Sprintf("http://%s-prometheus.%s.svc:%d",
+		getMonitoringStackName(addonName), namespace, monitoringStackPrometheusPort)
+}
+
+// Returns a selector for all series passing the remote write allowlist.
+func getRemoteWriteSeriesSelector(allowlist []string) string {
+	if len(allowlist) == 0 {
+		return `{__name__=~".+"}`
+	}
+	// Raw string, so backslashes of the regex are not taken as escape sequences.
+	return fmt.Sprintf("{__name__=~`%s`}", getAllowlistRegex(allowlist))
+}
+
+func getWriteRelabelConfigFromDropList(droplist []string) []monv1.RelabelConfig {
+	if len(droplist) == 0 {
+		return nil
+	}
+
+	return []monv1.RelabelConfig{
+		{
+			Action:       "drop",
+			SourceLabels: []monv1.LabelName{"__name__"},
+			Regex:        fmt.Sprintf("(%s)", strings.Join(droplist, "|")),
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type seriesCounterMock struct {
+	series int64
+	err    error
+}
+
+func (m seriesCounterMock) CountSeries(context.Context, string, string) (int64, error) {
+	return m.series, m.err
+}
+
+func TestCheckSeriesLimit(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Counter           seriesCounter
+		Guarded           bool
+		AlreadyDegraded   bool
+		ExpectedExceeded  bool
+		ExpectedCondition bool
+		ExpectedReason    string
+	}{
+		"no guard": {
+			Guarded:         false,
+			AlreadyDegraded: true,
+		},
+		"within limit": {
+			Counter:           seriesCounterMock{series: 100},
+			Guarded:           true,
+			ExpectedCondition: true,
+			ExpectedReason:    addonsv1alpha1.AddonReasonSeriesWithinLimit,
+		},
+		"limit exceeded": {
+			Counter:           seriesCounterMock{series: 1001},
+			Guarded:           true,
+			ExpectedExceeded:  true,
+			ExpectedCondition: true,
+			ExpectedReason:    addonsv1alpha1.AddonReasonSeriesLimitExceeded,
+		},
+		"count error keeps degraded state": {
+			Counter:           seriesCounterMock{err: fmt.Errorf("connection refused")},
+			Guarded:           true,
+			AlreadyDegraded:   true,
+			ExpectedExceeded:  true,
+			ExpectedCondition: true,
+			ExpectedReason:    addonsv1alpha1.AddonReasonSeriesLimitExceeded,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &monitoringStackReconciler{
+				seriesCounter: tc.Counter,
+			}
+
+			addon := testutil.NewTestAddonWithMonitoringStack()
+			if tc.Guarded {
+				addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig.CardinalityGuard = &addonsv1alpha1.CardinalityGuardSpec{
+					SeriesLimit: 1000,
+				}
+			}
+			if tc.AlreadyDegraded {
+				reportSeriesLimitExceeded(addon, 1001, 1000)
+			}
+
+			exceeded := r.checkSeriesLimit(context.Background(), addon, "addon-1")
+			assert.Equal(t, tc.ExpectedExceeded, exceeded)
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.RemoteWriteDegraded)
+			if !tc.ExpectedCondition {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Degraded))
+		})
+	}
+}
+
+type countingSeriesCounter struct {
+	calls int
+}
+
+func (c *countingSeriesCounter) CountSeries(context.Context, string, string) (int64, error) {
+	c.calls++
+	return 1001, nil
+}
+
+func TestCheckSeriesLimit_Interval(t *testing.T) {
+	t.Parallel()
+
+	counter := &countingSeriesCounter{}
+	r := &monitoringStackReconciler{
+		seriesCounter: counter,
+		seriesCounts:  newSeriesCountCache(time.Hour),
+	}
+
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig.CardinalityGuard = &addonsv1alpha1.CardinalityGuardSpec{
+		SeriesLimit: 1000,
+	}
+
+	assert.True(t, r.checkSeriesLimit(context.Background(), addon, "addon-1"))
+	assert.True(t, r.checkSeriesLimit(context.Background(), addon, "addon-1"))
+	assert.Equal(t, 1, counter.calls)
+
+	// The cached count is compared against the current limit.
+	addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig.CardinalityGuard.SeriesLimit = 2000
+	assert.False(t, r.checkSeriesLimit(context.Background(), addon, "addon-1"))
+	assert.Equal(t, 1, counter.calls)
+}
+
+func TestGetDesiredMonitoringStack_SeriesLimitExceeded(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&configv1.Proxy{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+
+	r := &monitoringStackReconciler{
+		client:        c,
+		scheme:        testutil.NewTestSchemeWithAddonsv1alpha1AndMsov1alpha1(),
+		seriesCounter: seriesCounterMock{series: 1001},
+	}
+
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig.Allowlist = []string{"foo", "bar"}
+	addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig.CardinalityGuard = &addonsv1alpha1.CardinalityGuardSpec{
+		SeriesLimit:         1000,
+		DropOnLimitExceeded: []string{"bar"},
+	}
+
+	ms, err := r.getDesiredMonitoringStack(context.Background(), addon)
+	require.NoError(t, err)
+
+	relabelConfigs := ms.Spec.PrometheusConfig.RemoteWrite[0].WriteRelabelConfigs
+	require.Len(t, relabelConfigs, 2)
+	assert.Equal(t, monv1.RelabelConfig{
+		Action:       "drop",
+		SourceLabels: []monv1.LabelName{"__name__"},
+		Regex:        "(bar)",
+	}, relabelConfigs[1])
+}
+
+func TestPrometheusSeriesCounter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "count({__name__=~`(foo|bar)`})", r.Form.Get("query"))
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1683000000,"42"]}]}}`)
+	}))
+	defer server.Close()
+
+	series, err := newPrometheusSeriesCounter().CountSeries(context.Background(),
+		server.URL, getRemoteWriteSeriesSelector([]string{"foo", "bar"}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), series)
+}
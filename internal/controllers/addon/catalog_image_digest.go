@@ -0,0 +1,110 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/registry"
+)
+
+// ImageDigestResolver resolves image references to the digest they currently point to.
+type ImageDigestResolver interface {
+	ResolveDigest(ctx context.Context, image string, keychain registry.Keychain) (string, error)
+}
+
+// Pins catalog images of Addons to the digest of their tag.
+type catalogImagePinner struct {
+	// Reads the pull secrets of the Addon, which are not cached.
+	client   client.Client
+	resolver ImageDigestResolver
+	// Catalog image last verified to be pullable per Addon name.
+	verified sync.Map
+}
+
+// Pins the image of the given CatalogSource to its digest,
+// if requested by the Addon. The digest recorded in the Addon status
+// is reused until the catalog image of the Addon changes.
+func (p *catalogImagePinner) pin(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	catalogSource *operatorsv1alpha1.CatalogSource,
+	commonConfig addonsv1alpha1.AddonInstallOLMCommon,
+) error {
+	if p == nil || commonConfig.CatalogSource == nil ||
+		!commonConfig.CatalogSource.PinImageDigest {
+		addon.Status.CatalogSourceImage = nil
+		return nil
+	}
+
+	image := commonConfig.CatalogSourceImage
+	ref, err := registry.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	if len(ref.Digest) > 0 {
+		// Already pinned in the Addon.
+		addon.Status.CatalogSourceImage = nil
+		return nil
+	}
+
+	if status := addon.Status.CatalogSourceImage; status != nil &&
+		status.Image == image && len(status.Digest) > 0 {
+		catalogSource.Spec.Image = ref.WithDigest(status.Digest)
+		return nil
+	}
+
+	keychain, err := p.keychain(ctx, commonConfig)
+	if err != nil {
+		return err
+	}
+	digest, err := p.resolver.ResolveDigest(ctx, image, keychain)
+	if err != nil {
+		return fmt.Errorf("resolving digest of %s: %w", image, err)
+	}
+
+	addon.Status.CatalogSourceImage = &addonsv1alpha1.AddonCatalogSourceImageStatus{
+		Image:  image,
+		Digest: digest,
+	}
+	catalogSource.Spec.Image = ref.WithDigest(digest)
+	return nil
+}
+
+// Collects registry credentials from the pull secrets of the Addon,
+// as propagated into the Addon namespace.
+func (p *catalogImagePinner) keychain(
+	ctx context.Context, commonConfig addonsv1alpha1.AddonInstallOLMCommon,
+) (registry.Keychain, error) {
+	keychain := registry.Keychain{}
+	for _, name := range commonConfig.GetPullSecrets() {
+		secret := &corev1.Secret{}
+		if err := p.client.Get(ctx, client.ObjectKey{
+			Name:      name,
+			Namespace: commonConfig.Namespace,
+		}, secret); err != nil {
+			if k8sApiErrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("getting pull secret: %w", err)
+		}
+
+		for _, key := range []string{corev1.DockerConfigJsonKey, corev1.DockerConfigKey} {
+			data, ok := secret.Data[key]
+			if !ok {
+				continue
+			}
+			secretKeychain, err := registry.KeychainFromDockerConfig(data)
+			if err != nil {
+				return nil, fmt.Errorf("parsing pull secret %s: %w", name, err)
+			}
+			keychain.Merge(secretKeychain)
+		}
+	}
+	return keychain, nil
+}
@@ -0,0 +1,119 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/registry"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type imageDigestResolverMock struct {
+	mock.Mock
+}
+
+func (m *imageDigestResolverMock) ResolveDigest(
+	ctx context.Context, image string, keychain registry.Keychain,
+) (string, error) {
+	args := m.Called(ctx, image, keychain)
+	return args.String(0), args.Error(1)
+}
+
+func TestCatalogImagePinner(t *testing.T) {
+	t.Parallel()
+
+	const (
+		image  = "quay.io/osd-addons/test:v1.0.0"
+		digest = "sha256:04864220677b2ed6244f2e0d421166df908986700647595ffdb6fd9ca4e5098a"
+		pinned = "quay.io/osd-addons/test@" + digest
+	)
+	newAddon := func(pin bool) *addonsv1alpha1.Addon {
+		addon := testutil.NewTestAddonWithCatalogSourceImage()
+		addon.Spec.Install.OLMOwnNamespace.CatalogSourceImage = image
+		addon.Spec.Install.OLMOwnNamespace.CatalogSource = &addonsv1alpha1.CatalogSourceConfig{
+			PinImageDigest: pin,
+		}
+		return addon
+	}
+
+	t.Run("resolves and pins digest", func(t *testing.T) {
+		t.Parallel()
+
+		c := testutil.NewClient()
+		resolver := &imageDigestResolverMock{}
+		p := &catalogImagePinner{client: c, resolver: resolver}
+
+		c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+			mock.IsType(&corev1.Secret{}), mock.Anything).
+			Run(func(args mock.Arguments) {
+				secret := args.Get(2).(*corev1.Secret)
+				secret.Data = map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"auth":"cm9ib3Q6c2VjcmV0"}}}`),
+				}
+			}).
+			Return(nil)
+		resolver.On("ResolveDigest", testutil.IsContext, image, registry.Keychain{
+			"quay.io": {Username: "robot", Password: "secret"},
+		}).Return(digest, nil)
+
+		addon := newAddon(true)
+		catalogSource := &operatorsv1alpha1.CatalogSource{
+			Spec: operatorsv1alpha1.CatalogSourceSpec{Image: image},
+		}
+		require.NoError(t, p.pin(context.Background(), addon, catalogSource,
+			GetCommonInstallOptions(addon)))
+		resolver.AssertExpectations(t)
+
+		assert.Equal(t, pinned, catalogSource.Spec.Image)
+		assert.Equal(t, &addonsv1alpha1.AddonCatalogSourceImageStatus{
+			Image:  image,
+			Digest: digest,
+		}, addon.Status.CatalogSourceImage)
+	})
+
+	t.Run("reuses digest of unchanged image", func(t *testing.T) {
+		t.Parallel()
+
+		resolver := &imageDigestResolverMock{}
+		p := &catalogImagePinner{client: testutil.NewClient(), resolver: resolver}
+
+		addon := newAddon(true)
+		addon.Status.CatalogSourceImage = &addonsv1alpha1.AddonCatalogSourceImageStatus{
+			Image:  image,
+			Digest: digest,
+		}
+		catalogSource := &operatorsv1alpha1.CatalogSource{
+			Spec: operatorsv1alpha1.CatalogSourceSpec{Image: image},
+		}
+		require.NoError(t, p.pin(context.Background(), addon, catalogSource,
+			GetCommonInstallOptions(addon)))
+		resolver.AssertNotCalled(t, "ResolveDigest", mock.Anything, mock.Anything, mock.Anything)
+		assert.Equal(t, pinned, catalogSource.Spec.Image)
+	})
+
+	t.Run("not pinning", func(t *testing.T) {
+		t.Parallel()
+
+		p := &catalogImagePinner{client: testutil.NewClient(), resolver: &imageDigestResolverMock{}}
+
+		addon := newAddon(false)
+		addon.Status.CatalogSourceImage = &addonsv1alpha1.AddonCatalogSourceImageStatus{
+			Image:  image,
+			Digest: digest,
+		}
+		catalogSource := &operatorsv1alpha1.CatalogSource{
+			Spec: operatorsv1alpha1.CatalogSourceSpec{Image: image},
+		}
+		require.NoError(t, p.pin(context.Background(), addon, catalogSource,
+			GetCommonInstallOptions(addon)))
+		assert.Equal(t, image, catalogSource.Spec.Image)
+		assert.Nil(t, addon.Status.CatalogSourceImage)
+	})
+}
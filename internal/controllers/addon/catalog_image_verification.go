@@ -0,0 +1,58 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Checks that a new catalog image can be pulled with the pull secrets of the Addon,
+// before the CatalogSource, or a candidate CatalogSource validating it, is switched to the image.
+// Otherwise the registry pod would be left in ImagePullBackOff.
+func (r *olmReconciler) verifyCatalogImage(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	catalogSource *operatorsv1alpha1.CatalogSource,
+	commonConfig addonsv1alpha1.AddonInstallOLMCommon,
+) (requeueResult, error) {
+	if r.catalogImages == nil || commonConfig.CatalogSource == nil ||
+		!commonConfig.CatalogSource.VerifyImage {
+		return resultNil, nil
+	}
+
+	image := catalogSource.Spec.Image
+	if verified, ok := r.catalogImages.verified.Load(addon.Name); ok && verified == image {
+		return resultNil, nil
+	}
+
+	currentCatalogSource := &operatorsv1alpha1.CatalogSource{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(catalogSource),
+		currentCatalogSource); client.IgnoreNotFound(err) != nil {
+		return resultNil, fmt.Errorf("getting CatalogSource: %w", err)
+	}
+	if currentCatalogSource.Spec.Image == image {
+		// Already rolled out.
+		return resultNil, nil
+	}
+
+	keychain, err := r.catalogImages.keychain(ctx, commonConfig)
+	if err != nil {
+		return resultNil, err
+	}
+	if _, err := r.catalogImages.resolver.ResolveDigest(ctx, image, keychain); err != nil {
+		controllers.LoggerFromContext(ctx).Info("catalog image unreachable",
+			"image", image, "error", err.Error())
+		reportPendingStatus(addon, addonsv1alpha1.AddonReasonCatalogImageUnreachable,
+			fmt.Sprintf("Catalog image %s can not be pulled: %v", image, err))
+		return resultRetry, nil
+	}
+
+	// Remember the image, so the registry is not asked again,
+	// while the rollout of the image is held back.
+	r.catalogImages.verified.Store(addon.Name, image)
+	return resultNil, nil
+}
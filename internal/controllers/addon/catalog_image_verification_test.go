@@ -0,0 +1,118 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestVerifyCatalogImage(t *testing.T) {
+	t.Parallel()
+
+	const (
+		currentImage = "quay.io/osd-addons/test:v1.0.0"
+		newImage     = "quay.io/osd-addons/test:v1.1.0"
+	)
+
+	for name, tc := range map[string]struct {
+		Verify         bool
+		Image          string
+		ResolveErr     error
+		ExpectedResult requeueResult
+		ExpectResolve  bool
+	}{
+		"disabled": {
+			Image:          newImage,
+			ExpectedResult: resultNil,
+		},
+		"image already rolled out": {
+			Verify:         true,
+			Image:          currentImage,
+			ExpectedResult: resultNil,
+		},
+		"new image reachable": {
+			Verify:         true,
+			Image:          newImage,
+			ExpectedResult: resultNil,
+			ExpectResolve:  true,
+		},
+		"new image unreachable": {
+			Verify:         true,
+			Image:          newImage,
+			ResolveErr:     errors.New("HTTP 404 resolving " + newImage),
+			ExpectedResult: resultRetry,
+			ExpectResolve:  true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			uncachedClient := testutil.NewClient()
+			resolver := &imageDigestResolverMock{}
+			r := &olmReconciler{
+				client:        c,
+				catalogImages: &catalogImagePinner{client: uncachedClient, resolver: resolver},
+			}
+
+			c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					current := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+					current.Spec.Image = currentImage
+				}).
+				Return(nil)
+			uncachedClient.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				mock.IsType(&corev1.Secret{}), mock.Anything).
+				Return(testutil.NewTestErrNotFound())
+			resolver.On("ResolveDigest", testutil.IsContext, tc.Image, mock.Anything).
+				Return("sha256:04864220677b2ed6244f2e0d421166df908986700647595ffdb6fd9ca4e5098a", tc.ResolveErr)
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Install.OLMOwnNamespace.CatalogSource = &addonsv1alpha1.CatalogSourceConfig{
+				VerifyImage: tc.Verify,
+			}
+			catalogSource := testutil.NewTestCatalogSource()
+			catalogSource.Spec.Image = tc.Image
+
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			result, err := r.verifyCatalogImage(ctx, addon, catalogSource, GetCommonInstallOptions(addon))
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+
+			if tc.ExpectResolve {
+				resolver.AssertExpectations(t)
+			} else {
+				resolver.AssertNotCalled(t, "ResolveDigest", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			if tc.ResolveErr != nil {
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonCatalogImageUnreachable, cond.Reason)
+				return
+			}
+			assert.Nil(t, cond)
+
+			// Verified images are not resolved again.
+			if tc.ExpectResolve {
+				_, err := r.verifyCatalogImage(ctx, addon, catalogSource, GetCommonInstallOptions(addon))
+				require.NoError(t, err)
+				resolver.AssertNumberOfCalls(t, "ResolveDigest", 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package addon
+
+import (
+	"fmt"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+const (
+	// Connection state of CatalogSources serving their catalog.
+	catalogSourceStateReady = "READY"
+	// Connection state of CatalogSources failing to reach their registry pod.
+	catalogSourceStateTransientFailure = "TRANSIENT_FAILURE"
+)
+
+// Readiness of an additional CatalogSource, as observed during a reconcile.
+type catalogSourceReadiness struct {
+	name     string
+	required bool
+	// State last observed by OLM, empty if not yet reported.
+	state string
+}
+
+func observeCatalogSourceReadiness(
+	name string, required bool, catalogSource *operatorsv1alpha1.CatalogSource,
+) catalogSourceReadiness {
+	readiness := catalogSourceReadiness{name: name, required: required}
+	if state := catalogSource.Status.GRPCConnectionState; state != nil {
+		readiness.state = state.LastObservedState
+	}
+	return readiness
+}
+
+func (r catalogSourceReadiness) ready() bool {
+	return r.state == catalogSourceStateReady
+}
+
+func (r catalogSourceReadiness) reason() string {
+	switch r.state {
+	case catalogSourceStateReady:
+		return addonsv1alpha1.AddonReasonAllCatalogSourcesReady
+	case catalogSourceStateTransientFailure:
+		return addonsv1alpha1.AddonReasonCatalogSourceUnhealthy
+	default:
+		return addonsv1alpha1.AddonReasonCatalogSourceConnecting
+	}
+}
+
+func (r catalogSourceReadiness) String() string {
+	name := r.name
+	if !r.required {
+		name += " (optional)"
+	}
+	if len(r.state) == 0 {
+		return fmt.Sprintf("%s: %s, .Status.GRPCConnectionState is nil", name, r.reason())
+	}
+	return fmt.Sprintf("%s: %s, .Status.GRPCConnectionState.LastObservedState == %s",
+		name, r.reason(), r.state)
+}
+
+// Reports the CatalogSourcesReady condition from the readiness of each additional CatalogSource.
+// Unready optional CatalogSources are listed, but keep the condition True.
+// Returns the required CatalogSources that are not ready.
+func reportCatalogSourcesReady(
+	addon *addonsv1alpha1.Addon, readiness []catalogSourceReadiness,
+) (unreadyRequired []catalogSourceReadiness) {
+	var (
+		unready []string
+		reason  = addonsv1alpha1.AddonReasonAllCatalogSourcesReady
+	)
+	for _, r := range readiness {
+		if r.ready() {
+			continue
+		}
+		unready = append(unready, r.String())
+		if r.required {
+			unreadyRequired = append(unreadyRequired, r)
+		}
+		// Unhealthy CatalogSources take precedence over connecting ones.
+		if reason != addonsv1alpha1.AddonReasonCatalogSourceUnhealthy {
+			reason = r.reason()
+		}
+	}
+
+	status := metav1.ConditionTrue
+	if len(unreadyRequired) > 0 {
+		status = metav1.ConditionFalse
+	}
+	message := "All additional CatalogSources are ready."
+	if len(unready) > 0 {
+		message = fmt.Sprintf("Not ready: %s.", strings.Join(unready, "; "))
+	}
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.CatalogSourcesReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+	return unreadyRequired
+}
+
+// Filters CatalogSource updates down to changes the Addon has to react to:
+// changes to their spec and metadata, which are reverted as drift,
+// and transitions of their connection state, which gate the Subscription.
+// OLM updates other status fields, like the last connect time, far more often.
+func catalogSourceChangedPredicate() predicate.Predicate {
+	return predicate.Or(
+		predicate.GenerationChangedPredicate{},
+		predicate.LabelChangedPredicate{},
+		predicate.AnnotationChangedPredicate{},
+		predicate.Funcs{UpdateFunc: catalogSourceStateChanged},
+	)
+}
+
+func catalogSourceStateChanged(e event.UpdateEvent) bool {
+	oldCatalogSource, ok := e.ObjectOld.(*operatorsv1alpha1.CatalogSource)
+	if !ok {
+		return true
+	}
+	newCatalogSource, ok := e.ObjectNew.(*operatorsv1alpha1.CatalogSource)
+	if !ok {
+		return true
+	}
+	return catalogSourceState(oldCatalogSource) != catalogSourceState(newCatalogSource) ||
+		!equality.Semantic.DeepEqual(oldCatalogSource.OwnerReferences, newCatalogSource.OwnerReferences)
+}
+
+func catalogSourceState(catalogSource *operatorsv1alpha1.CatalogSource) string {
+	if state := catalogSource.Status.GRPCConnectionState; state != nil {
+		return state.LastObservedState
+	}
+	return ""
+}
@@ -0,0 +1,108 @@
+package addon
+
+import (
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestReportCatalogSourcesReady(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithAdditionalCatalogSources()
+	unready := reportCatalogSourcesReady(addon, []catalogSourceReadiness{
+		{name: "test-1", required: true, state: catalogSourceStateReady},
+		{name: "test-2", required: true, state: "CONNECTING"},
+		{name: "test-3", required: false, state: catalogSourceStateTransientFailure},
+	})
+	require.Len(t, unready, 1)
+	assert.Equal(t, "test-2", unready[0].name)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.CatalogSourcesReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonCatalogSourceUnhealthy, cond.Reason)
+	assert.Equal(t, "Not ready: "+
+		"test-2: CatalogSourceConnecting, .Status.GRPCConnectionState.LastObservedState == CONNECTING; "+
+		"test-3 (optional): CatalogSourceUnhealthy, .Status.GRPCConnectionState.LastObservedState == TRANSIENT_FAILURE.",
+		cond.Message)
+
+	unready = reportCatalogSourcesReady(addon, []catalogSourceReadiness{
+		{name: "test-1", required: true, state: catalogSourceStateReady},
+	})
+	assert.Empty(t, unready)
+	cond = meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.CatalogSourcesReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonAllCatalogSourcesReady, cond.Reason)
+}
+
+func TestCatalogSourceChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	newCatalogSource := func(state string) *operatorsv1alpha1.CatalogSource {
+		catalogSource := testutil.NewTestCatalogSource()
+		catalogSource.Generation = 1
+		if len(state) > 0 {
+			catalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
+				LastObservedState: state,
+			}
+		}
+		return catalogSource
+	}
+
+	for name, tc := range map[string]struct {
+		Old, New *operatorsv1alpha1.CatalogSource
+		Expected bool
+	}{
+		"becomes ready": {
+			Old: newCatalogSource("CONNECTING"), New: newCatalogSource(catalogSourceStateReady),
+			Expected: true,
+		},
+		"first state reported": {
+			Old: newCatalogSource(""), New: newCatalogSource("CONNECTING"),
+			Expected: true,
+		},
+		"becomes unhealthy": {
+			Old: newCatalogSource(catalogSourceStateReady), New: newCatalogSource(catalogSourceStateTransientFailure),
+			Expected: true,
+		},
+		"last connect time updated": {
+			Old: newCatalogSource(catalogSourceStateReady),
+			New: func() *operatorsv1alpha1.CatalogSource {
+				catalogSource := newCatalogSource(catalogSourceStateReady)
+				catalogSource.Status.GRPCConnectionState.LastConnectTime = metav1.Now()
+				return catalogSource
+			}(),
+			Expected: false,
+		},
+		"spec changed": {
+			Old: newCatalogSource(catalogSourceStateReady),
+			New: func() *operatorsv1alpha1.CatalogSource {
+				catalogSource := newCatalogSource(catalogSourceStateReady)
+				catalogSource.Generation = 2
+				return catalogSource
+			}(),
+			Expected: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, catalogSourceChangedPredicate().Update(event.UpdateEvent{
+				ObjectOld: tc.Old,
+				ObjectNew: tc.New,
+			}))
+		})
+	}
+}
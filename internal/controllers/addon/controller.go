@@ -4,24 +4,33 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/openshift/addon-operator/internal/catalogregistry"
 	"github.com/openshift/addon-operator/internal/controllers"
 	"github.com/openshift/addon-operator/internal/metrics"
 
 	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -29,6 +38,7 @@ import (
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
 	internalhandler "github.com/openshift/addon-operator/internal/controllers/addon/handler"
 	"github.com/openshift/addon-operator/internal/ocm"
+	"github.com/openshift/addon-operator/internal/registry"
 )
 
 const (
@@ -37,6 +47,24 @@ const (
 	cacheFinalizer        = "addons.managed.openshift.io/cache"
 )
 
+var (
+	errOCMClientNotInitialized = fmt.Errorf("ocm client not initialized")
+	errOCMDisabled             = fmt.Errorf("ocm disabled")
+)
+
+// Timeout used when we do a manual RequeueAfter,
+// can be tuned at runtime via SetRetryAfterTime.
+var retryAfterTime = int64(defaultRetryAfterTime)
+
+// Sets the timeout used when we do a manual RequeueAfter. Concurrency safe.
+func SetRetryAfterTime(d time.Duration) {
+	atomic.StoreInt64(&retryAfterTime, int64(d))
+}
+
+func getRetryAfterTime() time.Duration {
+	return time.Duration(atomic.LoadInt64(&retryAfterTime))
+}
+
 type AddonReconciler struct {
 	client.Client
 	Log               logr.Logger
@@ -55,18 +83,52 @@ type AddonReconciler struct {
 
 	ocmClient    ocmClient
 	ocmClientMux sync.RWMutex
-
-	// List of Addon sub-reconcilers.
-	// Reconcilers will run  serially
-	// in the order in which they appear in this slice.
+	// Disables all interactions with OCM, guarded by ocmClientMux.
+	ocmDisabled bool
+
+	extensionHookClient    extensionHookClient
+	extensionHookClientMux sync.RWMutex
+
+	maintenanceMode    bool
+	maintenanceModeMux sync.RWMutex
+
+	// Records Events for lifecycle milestones of Addons, optional.
+	events record.EventRecorder
+	// Reports manual changes to Addon objects reverted by sub-reconcilers.
+	drift *driftReporter
+	// Requeue intervals per class of failure.
+	backoff *backoffPolicy
+	// Backs off retries of failed reports to the OCM AddOn installations API.
+	ocmInstallationReportRetries *ocmInstallationReportRetries
+	// Pins catalog images to their digest.
+	catalogImages *catalogImagePinner
+	// Silences alerts of upgrading Addons, optional.
+	alertSilencer AlertSilencer
+	// Default PodSecurity admission levels of Addon namespaces.
+	podSecurity *podSecurityDefaults
+	// Thanos Ruler evaluating Addon monitoring rules, optional.
+	thanosRuler *thanosRulerTarget
+	// Authenticates Addon Alertmanagers to the alert receiver, optional.
+	alertReceiver *alertReceiverKey
+	// Dead Man's Snitch account provisioning Addon snitches, optional.
+	deadMansSnitch *deadMansSnitchAccount
+	// PagerDuty account provisioning Addon services, optional.
+	pagerDuty *pagerDutyAccount
+	// Maximum number of Addons upgrading at the same time.
+	upgradeConcurrency *upgradeConcurrency
+
+	// Addons whose skippable sub-reconcilers completed for their current state.
+	reconciled *reconciledAddons
+
+	// Finalizers of subsystems cleaning up after deleted Addons, sorted by their order.
+	// Use registerFinalizer to add new finalizers.
+	finalizers []addonFinalizer
+
+	// List of Addon sub-reconcilers, sorted by their order.
+	// Use registerSubReconciler to add new sub-reconcilers.
 	subReconcilers []addonReconciler
 }
 
-type addonReconciler interface {
-	Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error)
-	Name() string
-}
-
 func NewAddonReconciler(
 	client client.Client,
 	uncachedClient client.Client,
@@ -79,55 +141,134 @@ func NewAddonReconciler(
 	opts ...AddonReconcilerOptions,
 ) *AddonReconciler {
 	operatorResourceHandler := internalhandler.NewOperatorResourceHandler()
+	lifecycleHooks := &lifecycleHookRunner{client: client, scheme: scheme}
+	drift := &driftReporter{recorder: recorder}
+	backoff := &backoffPolicy{}
+	podSecurity := &podSecurityDefaults{}
+	thanosRuler := &thanosRulerTarget{}
+	pagerDuty := &pagerDutyAccount{}
+	catalogImages := &catalogImagePinner{
+		client:   uncachedClient,
+		resolver: registry.NewDigestResolver(),
+	}
 	adoReconciler := &AddonReconciler{
-		Client:                  client,
-		UncachedClient:          uncachedClient,
-		Log:                     log,
-		Scheme:                  scheme,
-		Recorder:                recorder,
-		ClusterExternalID:       clusterExternalID,
-		AddonOperatorNamespace:  addonOperatorNamespace,
-		operatorResourceHandler: operatorResourceHandler,
-		statusReportingEnabled:  enableStatusReporting,
-		subReconcilers: []addonReconciler{
-			// Step 1: Check if addon is being deleted.
-			&addonDeletionReconciler{
-				clock: defaultClock{},
-				handlers: []addonDeletionHandler{
-					&legacyDeletionHandler{client: client, uncachedClient: uncachedClient},
-					&addonInstanceDeletionHandler{client: client},
-				},
-			},
-			// Step 2: Reconcile Namespace
-			&namespaceReconciler{
-				client: client,
-				scheme: scheme,
-			},
-			// Step 3: Reconcile Addon pull secrets
-			&addonSecretPropagationReconciler{
-				cachedClient:           client,
-				uncachedClient:         uncachedClient,
-				scheme:                 scheme,
-				addonOperatorNamespace: addonOperatorNamespace,
-			},
-			// Step 4: Reconcile AddonInstance object
-			&addonInstanceReconciler{
-				client: client,
-				scheme: scheme,
-			},
-			// Step 5: Reconcile OLM objects
-			&olmReconciler{
-				client:                  client,
-				uncachedClient:          uncachedClient,
-				scheme:                  scheme,
-				operatorResourceHandler: operatorResourceHandler,
-			},
-			// Step 6: Reconcile Monitoring Federation
-			&monitoringFederationReconciler{
-				client: client,
-				scheme: scheme,
+		Client:                       client,
+		UncachedClient:               uncachedClient,
+		Log:                          log,
+		Scheme:                       scheme,
+		Recorder:                     recorder,
+		ClusterExternalID:            clusterExternalID,
+		AddonOperatorNamespace:       addonOperatorNamespace,
+		operatorResourceHandler:      operatorResourceHandler,
+		statusReportingEnabled:       enableStatusReporting,
+		drift:                        drift,
+		backoff:                      backoff,
+		ocmInstallationReportRetries: newOCMInstallationReportRetries(),
+		reconciled:                   newReconciledAddons(),
+		catalogImages:                catalogImages,
+		podSecurity:                  podSecurity,
+		thanosRuler:                  thanosRuler,
+		alertReceiver:                &alertReceiverKey{},
+		deadMansSnitch:               &deadMansSnitchAccount{},
+		pagerDuty:                    pagerDuty,
+		upgradeConcurrency:           &upgradeConcurrency{},
+	}
+
+	for _, reconciler := range []addonReconciler{
+		&addonDeletionReconciler{
+			clock: defaultClock{},
+			hooks: lifecycleHooks,
+			handlers: []addonDeletionHandler{
+				&legacyDeletionHandler{client: client, uncachedClient: uncachedClient},
+				&addonInstanceDeletionHandler{client: client},
 			},
 		},
+		&namespaceReconciler{
+			client:      client,
+			scheme:      scheme,
+			drift:       drift,
+			podSecurity: podSecurity,
+		},
+		&networkPolicyReconciler{
+			client: client,
+			scheme: scheme,
+			drift:  drift,
+		},
+		&addonSecretPropagationReconciler{
+			cachedClient:           client,
+			uncachedClient:         uncachedClient,
+			scheme:                 scheme,
+			addonOperatorNamespace: addonOperatorNamespace,
+		},
+		&lifecycleHookReconciler{
+			hooks: lifecycleHooks,
+		},
+		&addonInstanceReconciler{
+			client: client,
+			scheme: scheme,
+		},
+		&installPlanReconciler{
+			client: client,
+		},
+		&olmReconciler{
+			client:                  client,
+			uncachedClient:          uncachedClient,
+			scheme:                  scheme,
+			operatorResourceHandler: operatorResourceHandler,
+			clock:                   defaultClock{},
+			drift:                   drift,
+			backoff:                 backoff,
+			catalogImages:           catalogImages,
+			catalogRegistry:         catalogregistry.NewClient(),
+			ocmParameters:           adoReconciler,
+			ocmMode:                 adoReconciler,
+			recorder:                recorder,
+		},
+		&monitoringFederationReconciler{
+			client:         client,
+			uncachedClient: uncachedClient,
+			scheme:         scheme,
+		},
+		&monitoringRulesReconciler{
+			client:      client,
+			scheme:      scheme,
+			thanosRuler: thanosRuler,
+		},
+		&monitorsReconciler{
+			client: client,
+			scheme: scheme,
+		},
+		&monitoringSLOsReconciler{
+			client: client,
+			scheme: scheme,
+		},
+		&monitoringProbesReconciler{
+			client:  client,
+			scheme:  scheme,
+			checked: newMonitoringProbeResults(),
+		},
+		&userWorkloadMonitoringReconciler{
+			client: client,
+		},
+		&pagerDutyReconciler{
+			client:    client,
+			scheme:    scheme,
+			clusterID: clusterExternalID,
+			services:  pagerDuty,
+		},
+	} {
+		adoReconciler.registerSubReconciler(reconciler)
+	}
+
+	for _, finalizer := range []addonFinalizer{
+		&preDeleteHookFinalizer{hooks: lifecycleHooks},
+		&cacheFinalizerHandler{reconciler: adoReconciler},
+		&monitoringFinalizer{client: client},
+		&pagerDutyFinalizer{client: client, services: pagerDuty},
+		&namespaceFinalizer{client: client},
+		&postDeleteHookFinalizer{reconciler: adoReconciler},
+	} {
+		adoReconciler.registerFinalizer(finalizer)
 	}
 
 	for _, opt := range opts {
@@ -163,6 +304,18 @@ type ocmClient interface {
 		ctx context.Context,
 		addonID string,
 	) (res ocm.AddOnStatusResponse, err error)
+	PatchAddOnInstallation(
+		ctx context.Context,
+		req ocm.AddOnInstallationPatchRequest,
+	) (res ocm.AddOnInstallationPatchResponse, err error)
+	GetAddOnInstallation(
+		ctx context.Context,
+		req ocm.AddOnInstallationGetRequest,
+	) (res ocm.AddOnInstallationGetResponse, err error)
+	PostServiceLog(
+		ctx context.Context,
+		req ocm.ServiceLogPostRequest,
+	) (res ocm.ServiceLogPostResponse, err error)
 }
 
 func (r *AddonReconciler) InjectOCMClient(ctx context.Context, c *ocm.Client) error {
@@ -182,6 +335,35 @@ func (r *AddonReconciler) InjectOCMClient(ctx context.Context, c *ocm.Client) er
 	return nil
 }
 
+// Disables or re-enables all interactions with OCM. Concurrency safe.
+// Disabling OCM drops the current OCM client.
+func (r *AddonReconciler) SetOCMDisabled(ctx context.Context, disabled bool) error {
+	r.ocmClientMux.Lock()
+	defer r.ocmClientMux.Unlock()
+
+	if r.ocmDisabled == disabled {
+		return nil
+	}
+	r.Log.Info("ocm interactions toggled", "disabled", disabled)
+	r.ocmDisabled = disabled
+	if disabled {
+		r.ocmClient = nil
+	}
+
+	// Requeue all addons to clear or report their OCM state.
+	if err := r.requeueAllAddons(ctx); err != nil {
+		return fmt.Errorf("requeue all Addons: %w", err)
+	}
+	return nil
+}
+
+// Whether all interactions with OCM are disabled. Concurrency safe.
+func (r *AddonReconciler) IsOCMDisabled() bool {
+	r.ocmClientMux.RLock()
+	defer r.ocmClientMux.RUnlock()
+	return r.ocmDisabled
+}
+
 func (r *AddonReconciler) GetOCMClusterInfo() OcmClusterInfo {
 	r.ocmClientMux.RLock()
 	defer r.ocmClientMux.RUnlock()
@@ -196,6 +378,53 @@ func (r *AddonReconciler) GetOCMClusterInfo() OcmClusterInfo {
 	}
 }
 
+// Posts a service log entry for this cluster to OCM. Concurrency safe.
+func (r *AddonReconciler) PostServiceLog(ctx context.Context, req ocm.ServiceLogPostRequest) error {
+	r.ocmClientMux.RLock()
+	defer r.ocmClientMux.RUnlock()
+
+	if r.ocmDisabled {
+		// Service logs are dropped while OCM is disabled.
+		return nil
+	}
+	if r.ocmClient == nil {
+		return errOCMClientNotInitialized
+	}
+
+	_, err := r.ocmClient.PostServiceLog(ctx, req)
+	return err
+}
+
+// Returns the parameter values of the addon installation on this cluster from OCM. Concurrency safe.
+func (r *AddonReconciler) getOCMAddOnParameters(ctx context.Context, addonID string) (map[string]string, error) {
+	r.ocmClientMux.RLock()
+	defer r.ocmClientMux.RUnlock()
+
+	if r.ocmDisabled {
+		return nil, errOCMDisabled
+	}
+	if r.ocmClient == nil {
+		return nil, errOCMClientNotInitialized
+	}
+
+	var (
+		res ocm.AddOnInstallationGetResponse
+		err error
+	)
+	r.recordOCMRequestDuration(func() {
+		res, err = r.ocmClient.GetAddOnInstallation(ctx, ocm.AddOnInstallationGetRequest{ID: addonID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, param := range res.Parameters.Items {
+		values[param.ID] = param.Value
+	}
+	return values, nil
+}
+
 // Pauses reconcilation of all Addon objects. Concurrency safe.
 func (r *AddonReconciler) EnableGlobalPause(ctx context.Context) error {
 	return r.setGlobalPause(ctx, true)
@@ -240,24 +469,57 @@ func (r *AddonReconciler) SetupWithManager(mgr ctrl.Manager, opts ...AddonReconc
 		return fmt.Errorf("operatorResourceHandler cannot be nil")
 	}
 
+	if err := setupDependsOnIndex(mgr); err != nil {
+		return fmt.Errorf("setting up dependsOn index: %w", err)
+	}
+	if err := setupInstallStateIndex(mgr); err != nil {
+		return fmt.Errorf("setting up install state index: %w", err)
+	}
+	if err := setupUpgradeStateIndex(mgr); err != nil {
+		return fmt.Errorf("setting up upgrade state index: %w", err)
+	}
+
 	r.addonRequeueCh = make(chan event.GenericEvent)
 	adoControllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&addonsv1alpha1.Addon{}).
-		Owns(&corev1.Namespace{}).
-		Owns(&operatorsv1.OperatorGroup{}).
-		Owns(&operatorsv1alpha1.CatalogSource{}).
-		Owns(&operatorsv1alpha1.Subscription{}).
-		Owns(&addonsv1alpha1.AddonInstance{}).
-		Owns(&monitoringv1.ServiceMonitor{}).
-		Watches(&source.Kind{
-			Type: &corev1.Secret{},
-		}, &handler.EnqueueRequestForOwner{
-			OwnerType:    &addonsv1alpha1.Addon{},
-			IsController: false, // We don't "control" the source secret, so we are only adding ourselves as owner/watcher
-		}).
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &operatorsv1.OperatorGroup{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &operatorsv1alpha1.CatalogSource{}}, r.handleOwnedObjects(true),
+			builder.WithPredicates(catalogSourceChangedPredicate())).
+		Watches(&source.Kind{Type: &operatorsv1alpha1.Subscription{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &addonsv1alpha1.AddonInstance{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &monitoringv1.ServiceMonitor{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &monitoringv1.PrometheusRule{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &monitoringv1.PodMonitor{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &monitoringv1.Probe{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &networkingv1.NetworkPolicy{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &corev1.ResourceQuota{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &corev1.LimitRange{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &batchv1.Job{}}, r.handleOwnedObjects(true)).
+		Watches(&source.Kind{Type: &schedulingv1.PriorityClass{}}, r.handleOwnedObjects(true)).
+		// We don't "control" the source secret, so we are only adding ourselves as owner/watcher
+		Watches(&source.Kind{Type: &corev1.Secret{}}, r.handleOwnedObjects(false)).
 		Watches(&source.Kind{
 			Type: &operatorsv1.Operator{},
 		}, r.operatorResourceHandler, builder.OnlyMetadata).
+		Watches(&source.Kind{ // Requeue Addons approving InstallPlans of their Subscription.
+			Type: &operatorsv1alpha1.InstallPlan{},
+		}, handler.EnqueueRequestsFromMapFunc(r.enqueueInstallPlanAddon)).
+		Watches(&source.Kind{ // Requeue Addons waiting for a higher priority Addon to become Available.
+			Type: &addonsv1alpha1.Addon{},
+		}, handler.EnqueueRequestsFromMapFunc(r.enqueueLowerPriorityAddons)).
+		Watches(&source.Kind{ // Requeue Addons with a queued upgrade, when another Addon finishes upgrading.
+			Type: &addonsv1alpha1.Addon{},
+		}, handler.EnqueueRequestsFromMapFunc(r.enqueueQueuedUpgrades)).
+		Watches(&source.Kind{ // Requeue Addons depending on an Addon when it changes.
+			Type: &addonsv1alpha1.Addon{},
+		}, handler.EnqueueRequestsFromMapFunc(r.enqueueDependentAddons)).
+		Watches(&source.Kind{ // Requeue Addons inheriting the cluster-wide Proxy when it changes.
+			Type: &configv1.Proxy{},
+		}, &ownedObjectHandler{
+			owner:      handler.EnqueueRequestsFromMapFunc(r.enqueueClusterProxyAddons),
+			reconciled: r.reconciled,
+		}).
 		Watches(&source.Channel{ // Requeue everything when entering/leaving global pause.
 			Source: r.addonRequeueCh,
 		}, &handler.EnqueueRequestForObject{})
@@ -272,29 +534,67 @@ func (r *AddonReconciler) SetupWithManager(mgr ctrl.Manager, opts ...AddonReconc
 // AddonReconciler/Controller entrypoint
 func (r *AddonReconciler) Reconcile(
 	ctx context.Context, req ctrl.Request,
-) (ctrl.Result, error) {
+) (res ctrl.Result, err error) {
 	logger := r.Log.WithValues("addon", req.NamespacedName.String())
 	ctx = controllers.ContextWithLogger(ctx, logger)
 
 	addon := &addonsv1alpha1.Addon{}
 	if err := r.Get(ctx, req.NamespacedName, addon); err != nil {
+		if k8sApiErrors.IsNotFound(err) && r.Recorder != nil {
+			r.Recorder.ForgetAddon(req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	start := time.Now()
+	defer func() { r.recordReconcile(addon.Name, start, res, err) }()
+
+	previousPhase := addon.Status.Phase
+	previousConditions := append([]metav1.Condition(nil), addon.Status.Conditions...)
+	previousLastReconcile := addon.Status.LastReconcile
+	resetReconcileStopped(addon)
 	reconcileResult, reconcileErr := r.reconcile(ctx, addon, logger)
+	r.recordLifecycleEvents(addon, previousConditions)
+	r.reconcileUpgradeSilence(ctx, addon)
 
-	// Update metrics only if a Recorder is initialized
-	if r.Recorder != nil {
-		r.Recorder.RecordAddonMetrics(addon)
-	}
 	errors := r.syncWithExternalAPIs(ctx, logger, addon)
+	if d := r.backoff.interval(failureClassOCMError); d > 0 && errors.ErrorOrNil() != nil {
+		// Retry at the configured interval instead of backing off exponentially.
+		logger.Error(errors, "syncing with external APIs", "retryAfter", d)
+		reconcileResult = mergeResults(reconcileResult, ctrl.Result{RequeueAfter: d})
+		errors = nil
+	} else if d, ok := ocmCircuitOpen(errors); ok {
+		// Retry once OCM is let through again instead of retrying hot while it is unavailable.
+		logger.Info("delaying sync with external APIs while OCM is unavailable", "retryAfter", d)
+		reconcileResult = mergeResults(reconcileResult, ctrl.Result{RequeueAfter: d})
+		errors = nil
+	}
 
 	// append reconcilerErr
 	errors = multierror.Append(errors, reconcileErr)
 
 	// We report the observed version regardless of whether the addon
-	// is available or not.
-	reportObservedVersion(addon)
+	// is available or not, unless the upgrade was vetoed or deferred.
+	if !upgradeVetoed(addon) && !addonFrozen(addon) && !upgradeDeferred(addon) && !upgradeQueued(addon) {
+		reportObservedVersion(addon)
+	}
+	reportShortStatus(addon)
+	reconcileResult = mergeResults(reconcileResult, r.handleOCMInstallationReporting(
+		ctx, logger.WithName("AddonInstallationReporter"), addon,
+	))
+
+	reportLastReconcile(addon, previousLastReconcile, start, time.Now(), errors.ErrorOrNil())
+
+	// Update metrics only if a Recorder is initialized,
+	// after the status is final to report what is written.
+	if r.Recorder != nil {
+		r.Recorder.RecordAddonMetrics(addon)
+		r.recordConditionTransitions(addon, previousConditions)
+		if previousPhase != addon.Status.Phase {
+			r.Recorder.RecordAddonPhaseTransition(
+				string(previousPhase), string(addon.Status.Phase))
+		}
+	}
 
 	if statusErr := r.Status().Update(ctx, addon); statusErr != nil {
 		errors = multierror.Append(errors, statusErr)
@@ -320,6 +620,26 @@ func (r *AddonReconciler) syncWithExternalAPIs(ctx context.Context, logger logr.
 	return multiErr
 }
 
+// Returns the longest time until OCM is let through again,
+// if all errors were caused by the OCM circuit breaker being open.
+func ocmCircuitOpen(errs *multierror.Error) (time.Duration, bool) {
+	if errs.ErrorOrNil() == nil {
+		return 0, false
+	}
+
+	var longest time.Duration
+	for _, err := range errs.WrappedErrors() {
+		retryAfter, ok := ocm.RetryAfter(err)
+		if !ok {
+			return 0, false
+		}
+		if retryAfter > longest {
+			longest = retryAfter
+		}
+	}
+	return longest, true
+}
+
 func (r *AddonReconciler) reconcile(ctx context.Context, addon *addonsv1alpha1.Addon,
 	log logr.Logger,
 ) (ctrl.Result, error) {
@@ -327,7 +647,11 @@ func (r *AddonReconciler) reconcile(ctx context.Context, addon *addonsv1alpha1.A
 	// Handle addon deletion before checking for pause condition.
 	// This allows even paused addons to be deleted.
 	if !addon.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, r.handleAddonCRDeletion(ctx, addon)
+		reportReconcileStopped(addon, reconcilePhaseDeletion)
+		if err := r.handleAddonCRDeletion(ctx, addon); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.handleStuckNamespaces(ctx, addon, time.Now())
 	}
 
 	// check for global pause
@@ -335,23 +659,78 @@ func (r *AddonReconciler) reconcile(ctx context.Context, addon *addonsv1alpha1.A
 	defer r.globalPauseMux.RUnlock()
 	if r.globalPause {
 		reportAddonPauseStatus(addon, addonsv1alpha1.AddonOperatorReasonPaused)
+		reportReconcileStopped(addon, reconcilePhaseGlobalPause)
 		// TODO: figure out how we can continue to report status
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.propagatePauseState(ctx, addon)
 	}
 
 	// check for Addon pause
 	if addon.Spec.Paused {
 		reportAddonPauseStatus(addon, addonsv1alpha1.AddonReasonPaused)
-		return ctrl.Result{}, nil
+		reportReconcileStopped(addon, reconcilePhasePause)
+		return ctrl.Result{}, r.propagatePauseState(ctx, addon)
+	}
+	if pausedByAnnotation(addon) {
+		reportAddonPauseStatus(addon, addonsv1alpha1.AddonReasonPausedByAnnotation)
+		reportReconcileStopped(addon, reconcilePhasePause)
+		return ctrl.Result{}, r.propagatePauseState(ctx, addon)
 	}
 
-	// Make sure Pause condition is removed
+	// Make sure Pause condition and annotations are removed
+	if meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Paused) != nil {
+		if err := r.removePauseState(ctx, addon); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	r.removeAddonPauseCondition(addon)
 
+	// Defer installs and upgrades during cluster maintenance.
+	if r.freezeForMaintenance(addon) {
+		reportReconcileStopped(addon, reconcilePhaseMaintenance)
+		return ctrl.Result{}, nil
+	}
+
+	// Install Addons with a higher install priority first.
+	if waiting, requeueAfter, err := r.waitForHigherPriorityAddons(ctx, addon, time.Now()); err != nil {
+		reportReconcileStopped(addon, reconcilePhaseInstallPriority)
+		return ctrl.Result{}, fmt.Errorf("checking install priority: %w", err)
+	} else if waiting {
+		reportReconcileStopped(addon, reconcilePhaseInstallPriority)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Install the Addons this Addon depends on first.
+	if waiting, err := r.waitForDependencies(ctx, addon); err != nil {
+		reportReconcileStopped(addon, reconcilePhaseDependencies)
+		return ctrl.Result{}, fmt.Errorf("checking dependencies: %w", err)
+	} else if waiting {
+		reportReconcileStopped(addon, reconcilePhaseDependencies)
+		return ctrl.Result{}, nil
+	}
+
+	// Consult the extension hook before installing or upgrading the Addon.
+	if vetoed, err := r.handlePreOperationHook(ctx, addon); err != nil {
+		reportReconcileStopped(addon, reconcilePhasePreOperationHook)
+		return ctrl.Result{}, fmt.Errorf("calling extension hook: %w", err)
+	} else if vetoed {
+		reportReconcileStopped(addon, reconcilePhasePreOperationHook)
+		return handleExit(resultRetry), nil
+	}
+
+	// Upgrade only as many Addons at the same time as the AddonOperator allows.
+	if waiting, err := r.waitForUpgradeSlot(ctx, addon, time.Now()); err != nil {
+		reportReconcileStopped(addon, reconcilePhaseUpgradeQueue)
+		return ctrl.Result{}, fmt.Errorf("checking upgrade queue: %w", err)
+	} else if waiting {
+		reportReconcileStopped(addon, reconcilePhaseUpgradeQueue)
+		return ctrl.Result{RequeueAfter: upgradeQueuePollInterval}, nil
+	}
+
 	// Check if the addon is being upgraded
 	// by comparing spec.version and status.ObservedVersion.
 	if addonIsBeingUpgraded(addon) {
 		reportAddonUpgradeStarted(addon)
+		reportReconcileStopped(addon, reconcilePhaseUpgradeStarted)
 		return ctrl.Result{}, nil
 	}
 
@@ -360,22 +739,28 @@ func (r *AddonReconciler) reconcile(ctx context.Context, addon *addonsv1alpha1.A
 		reportInstalledConditionFalse(addon)
 	}
 
-	// Ensure cache finalizer
-	if !controllerutil.ContainsFinalizer(addon, cacheFinalizer) {
-		controllerutil.AddFinalizer(addon, cacheFinalizer)
-		if err := r.Update(ctx, addon); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
-		}
+	if err := r.ensureFinalizers(ctx, addon); err != nil {
+		reportReconcileStopped(addon, reconcilePhaseFinalizers)
+		return ctrl.Result{}, err
 	}
 
-	// Run each sub reconciler serially
-	for _, reconciler := range r.subReconcilers {
-		if result, err := reconciler.Reconcile(ctx, addon); err != nil {
-			return ctrl.Result{}, fmt.Errorf("%s : failed to reconcile : %w", reconciler.Name(), err)
-		} else if !result.IsZero() {
-			return result, nil
-		}
+	result, err := r.runSubReconcilers(ctx, addon)
+	if err != nil {
+		return result, err
+	}
+	result = mergeResults(result, requeueForDeferredUpgrade(addon, time.Now()))
+	result = mergeResults(result, requeueForCanaryUpgrade(addon, time.Now()))
+	if !r.IsOCMDisabled() {
+		result = mergeResults(result, requeueForOCMParameterSync(addon, time.Now()))
 	}
 
-	return ctrl.Result{}, nil
+	timeoutResult, err := r.handleInstallTimeout(ctx, addon, time.Now())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("checking install timeout: %w", err)
+	}
+	result = mergeResults(result, timeoutResult)
+	result = mergeResults(result, r.backoff.requeueForValidationFailure(addon))
+	// Re-assert the desired state periodically to revert manual changes,
+	// that did not trigger a reconcile of the Addon.
+	return mergeResults(result, ctrl.Result{RequeueAfter: getDriftDetectionInterval()}), nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
 
@@ -11,6 +12,8 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -35,6 +38,10 @@ func (m *mockSubReconciler) Name() string {
 	return "mock-sub-reconciler"
 }
 
+func (m *mockSubReconciler) Order() subReconcilerOrder {
+	return 0
+}
+
 func (m *mockSubReconciler) Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
 	if m.returnErr {
 		return ctrl.Result{}, errors.New("failed to reconcile")
@@ -112,12 +119,18 @@ func TestReconcileErrorHandling(t *testing.T) {
 			ocmClient.On("PostAddOnStatus", mock.Anything, mock.Anything, mock.Anything).Return(ocm.AddOnStatusResponse{}, nil)
 		}
 
+		ocmClient.On("PatchAddOnInstallation", mock.Anything, mock.Anything).
+			Return(ocm.AddOnInstallationPatchResponse{}, nil)
+
 		if testCase.statusUpdateErrPresent {
 			client.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("kube api server busy"))
 		} else {
 			client.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		}
 
+		// No other Addons with a higher install priority.
+		client.On("List", mock.Anything, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).Return(nil)
+
 		// Return the prepared addon.
 		client.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 			passedAddon := (args.Get(2)).(*addonsv1alpha1.Addon)
@@ -138,6 +151,70 @@ func TestReconcileErrorHandling(t *testing.T) {
 	}
 }
 
+func TestReconcile_OCMErrorBackoff(t *testing.T) {
+	client := testutil.NewClient()
+	ocmClient := ocmtest.NewClient()
+	r := AddonReconciler{
+		Client:         client,
+		ocmClient:      ocmClient,
+		Log:            logr.Discard(),
+		subReconcilers: []addonReconciler{&mockSubReconciler{}},
+		backoff:        &backoffPolicy{},
+	}
+	r.statusReportingEnabled = true
+	r.SetBackoffPolicy(&addonsv1alpha1.AddonOperatorBackoffPolicy{
+		OCMError: &metav1.Duration{Duration: 30 * time.Second},
+	})
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Finalizers = append(addon.Finalizers, cacheFinalizer)
+
+	ocmClient.On("PostAddOnStatus", mock.Anything, mock.Anything, mock.Anything).
+		Return(ocm.AddOnStatusResponse{}, errors.New("gateway timeout"))
+	ocmClient.On("PatchAddOnInstallation", mock.Anything, mock.Anything).
+		Return(ocm.AddOnInstallationPatchResponse{}, nil)
+	client.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	client.On("List", mock.Anything, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).Return(nil)
+	client.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		passedAddon := (args.Get(2)).(*addonsv1alpha1.Addon)
+		*passedAddon = *addon
+	}).Return(nil)
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: 30 * time.Second}, res)
+}
+
+func TestReconcile_OCMCircuitOpen(t *testing.T) {
+	client := testutil.NewClient()
+	ocmClient := ocmtest.NewClient()
+	r := AddonReconciler{
+		Client:         client,
+		ocmClient:      ocmClient,
+		Log:            logr.Discard(),
+		subReconcilers: []addonReconciler{&mockSubReconciler{}},
+	}
+	r.statusReportingEnabled = true
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Finalizers = append(addon.Finalizers, cacheFinalizer)
+
+	ocmClient.On("PostAddOnStatus", mock.Anything, mock.Anything, mock.Anything).
+		Return(ocm.AddOnStatusResponse{}, ocm.CircuitOpenError{RetryAfter: 42 * time.Second})
+	ocmClient.On("PatchAddOnInstallation", mock.Anything, mock.Anything).
+		Return(ocm.AddOnInstallationPatchResponse{}, ocm.CircuitOpenError{RetryAfter: 42 * time.Second})
+	client.StatusMock.On("Update", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	client.On("List", mock.Anything, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).Return(nil)
+	client.On("Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		passedAddon := (args.Get(2)).(*addonsv1alpha1.Addon)
+		*passedAddon = *addon
+	}).Return(nil)
+
+	res, err := r.Reconcile(context.Background(), reconcile.Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: 42 * time.Second}, res)
+}
+
 func expectedNumErrors(testCase reconcileErrorTestCase) int {
 	res := 0
 	if testCase.externalAPISyncErrPresent {
@@ -151,3 +228,34 @@ func expectedNumErrors(testCase reconcileErrorTestCase) int {
 	}
 	return res
 }
+
+func TestReconcile_PausedByAnnotation(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("List", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	r := AddonReconciler{
+		Client:         c,
+		Log:            logr.Discard(),
+		subReconcilers: []addonReconciler{&mockSubReconciler{returnErr: true}},
+	}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Annotations = map[string]string{addonsv1alpha1.PausedAnnotation: "true"}
+
+	// Sub-reconcilers are not run while paused.
+	_, err := r.reconcile(context.Background(), addon, logr.Discard())
+	assert.NoError(t, err)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Paused)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, addonsv1alpha1.AddonReasonPausedByAnnotation, cond.Reason)
+	}
+
+	// Reconciliation resumes once the annotation is removed.
+	delete(addon.Annotations, addonsv1alpha1.PausedAnnotation)
+	addon.Finalizers = append(addon.Finalizers, cacheFinalizer)
+
+	_, err = r.reconcile(context.Background(), addon, logr.Discard())
+	assert.Error(t, err)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Paused))
+}
@@ -0,0 +1,461 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/deadmanssnitch"
+)
+
+const DEAD_MANS_SNITCH_RECONCILER_NAME = "deadMansSnitchReconciler"
+
+const (
+	// Key of the check-in URL in the snitch Secret.
+	deadMansSnitchURLKey = "url"
+	// Annotation on the snitch Secret recording the token of the snitch.
+	deadMansSnitchTokenAnnotation = "addons.managed.openshift.io/dead-mans-snitch-token"
+	// Alert constantly firing in the MonitoringStack, checking in the snitch.
+	deadMansSnitchAlertName = "AddonDeadMansSnitch"
+)
+
+type deadMansSnitchClient interface {
+	CreateSnitch(ctx context.Context, req deadmanssnitch.CreateSnitchRequest) (deadmanssnitch.Snitch, error)
+	GetSnitch(ctx context.Context, token string) (deadmanssnitch.Snitch, error)
+	FindSnitch(ctx context.Context, name string, tags []string) (deadmanssnitch.Snitch, error)
+	DeleteSnitch(ctx context.Context, token string) error
+}
+
+// Dead Man's Snitch account provisioning the snitches of Addons, configured via the AddonOperator.
+type deadMansSnitchAccount struct {
+	mux    sync.RWMutex
+	client deadMansSnitchClient
+}
+
+// Replaces the client of the account. A nil client removes it.
+// Returns true, if the account became available or unavailable.
+func (a *deadMansSnitchAccount) set(c deadMansSnitchClient) (changed bool) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	changed = (a.client == nil) != (c == nil)
+	a.client = c
+	return changed
+}
+
+// Returns the client of the account or nil.
+func (a *deadMansSnitchAccount) get() deadMansSnitchClient {
+	if a == nil {
+		return nil
+	}
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+	return a.client
+}
+
+// Injects the client provisioning the snitches of Addons,
+// a nil client disables provisioning. Concurrency safe.
+func (r *AddonReconciler) InjectDeadMansSnitchClient(c *deadmanssnitch.Client) {
+	var snitches deadMansSnitchClient
+	if c != nil {
+		snitches = c
+	}
+	if r.deadMansSnitch.set(snitches) {
+		// Addons waiting for the account have to be picked up again.
+		r.reconciled.forgetAll()
+	}
+}
+
+// Provisions a Dead Man's Snitch per Addon and checks it in through the
+// Alertmanager of the Addon's MonitoringStack, using a constantly firing alert.
+// The check-in URL is kept in a Secret in the install namespace,
+// snitches deleted in Dead Man's Snitch are replaced with new ones.
+// Snitches are named after the Addon and the cluster, an existing snitch
+// of the same name and tags is adopted, e.g. after its Secret was lost.
+// Errors of the Dead Man's Snitch API are reported in the DeadMansSnitchReady
+// condition instead of failing the reconcile of the Addon.
+type deadMansSnitchReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// External ID of the cluster, used to name snitches.
+	clusterID string
+	snitches  *deadMansSnitchAccount
+}
+
+func (r *deadMansSnitchReconciler) Name() string {
+	return DEAD_MANS_SNITCH_RECONCILER_NAME
+}
+
+func (r *deadMansSnitchReconciler) Order() subReconcilerOrder {
+	return deadMansSnitchReconcilerOrder
+}
+
+// Only acts on the Addon and the snitch itself,
+// which is re-checked every drift detection interval.
+func (r *deadMansSnitchReconciler) Skippable() bool {
+	return true
+}
+
+// No sub-reconciler depends on the snitch.
+func (r *deadMansSnitchReconciler) Independent() bool {
+	return true
+}
+
+func (r *deadMansSnitchReconciler) ConditionType() string {
+	return addonsv1alpha1.DeadMansSnitchReady
+}
+
+func (r *deadMansSnitchReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasDeadMansSnitch(addon)
+}
+
+func (r *deadMansSnitchReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if !HasDeadMansSnitch(addon) {
+		if err := r.ensureDeletionOfDeadMansSnitch(ctx, addon); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// The skip cache is reset when the account changes,
+	// so no need to requeue while waiting for it.
+	snitches := r.snitches.get()
+	if snitches == nil {
+		reportUnreadyDeadMansSnitch(ctx, "no Dead Man's Snitch API token is configured on the AddonOperator")
+		return ctrl.Result{}, nil
+	}
+	if len(r.clusterID) == 0 {
+		// Snitches of all clusters would share the same name otherwise.
+		reportUnreadyDeadMansSnitch(ctx, "the external ID of the cluster is unknown")
+		return ctrl.Result{}, nil
+	}
+
+	secret, err := r.ensureSnitchSecret(ctx, addon, snitches)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if secret == nil {
+		return ctrl.Result{}, nil
+	}
+
+	prometheusRule, err := r.desiredPrometheusRule(addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, prometheusRule); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying Dead Man's Snitch PrometheusRule: %w", err)
+	}
+
+	alertmanagerConfig, err := r.desiredAlertmanagerConfig(addon, secret)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, alertmanagerConfig); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying Dead Man's Snitch AlertmanagerConfig: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// Ensures the Secret with the check-in URL of the snitch exists,
+// creating a new snitch, if the Secret is missing or its snitch is gone.
+// Returns nil without error, if Dead Man's Snitch could not be reached.
+func (r *deadMansSnitchReconciler) ensureSnitchSecret(ctx context.Context,
+	addon *addonsv1alpha1.Addon, snitches deadMansSnitchClient) (*corev1.Secret, error) {
+	log := controllers.LoggerFromContext(ctx)
+	current := &corev1.Secret{}
+	err := r.client.Get(ctx, client.ObjectKey{
+		Name:      getDeadMansSnitchName(addon),
+		Namespace: GetCommonInstallOptions(addon).Namespace,
+	}, current)
+	switch {
+	case err == nil:
+		token := current.Annotations[deadMansSnitchTokenAnnotation]
+		_, err := snitches.GetSnitch(ctx, token)
+		if err == nil {
+			return current, nil
+		}
+		if !errors.Is(err, deadmanssnitch.ErrSnitchNotFound) {
+			// Keep checking in with the current snitch.
+			log.Error(err, "getting snitch", "token", token)
+			reportUnreadyDeadMansSnitch(ctx, fmt.Sprintf("getting snitch: %v", err))
+			return current, nil
+		}
+		log.Info("replacing deleted Dead Man's Snitch", "token", token)
+	case !k8sApiErrors.IsNotFound(err):
+		return nil, fmt.Errorf("getting Dead Man's Snitch Secret: %w", err)
+	}
+
+	spec := addon.Spec.Monitoring.MonitoringStack.DeadMansSnitch
+	name := fmt.Sprintf("%s (%s)", addon.Name, r.clusterID)
+	snitch, err := snitches.FindSnitch(ctx, name, spec.Tags)
+	switch {
+	case err == nil:
+		log.Info("adopting existing Dead Man's Snitch", "token", snitch.Token)
+		return r.storeCheckInURL(ctx, addon, snitch)
+	case !errors.Is(err, deadmanssnitch.ErrSnitchNotFound):
+		log.Error(err, "looking up snitch")
+		reportUnreadyDeadMansSnitch(ctx, fmt.Sprintf("looking up snitch: %v", err))
+		return nil, nil
+	}
+
+	snitch, err = snitches.CreateSnitch(ctx, deadmanssnitch.CreateSnitchRequest{
+		Name:      name,
+		Interval:  string(getDeadMansSnitchInterval(spec)),
+		Tags:      spec.Tags,
+		Notes:     fmt.Sprintf("Checked in by the MonitoringStack of Addon %s on cluster %s.", addon.Name, r.clusterID),
+		AlertType: deadmanssnitch.AlertTypeBasic,
+	})
+	if err != nil {
+		log.Error(err, "creating snitch")
+		reportUnreadyDeadMansSnitch(ctx, fmt.Sprintf("creating snitch: %v", err))
+		return nil, nil
+	}
+
+	secret, err := r.storeCheckInURL(ctx, addon, snitch)
+	if err != nil {
+		// Don't leak the snitch, a new one is created with the next reconcile.
+		if delErr := snitches.DeleteSnitch(ctx, snitch.Token); delErr != nil {
+			log.Error(delErr, "deleting unreferenced snitch", "token", snitch.Token)
+		}
+		return nil, err
+	}
+	return secret, nil
+}
+
+// Applies the Secret holding the check-in URL of the given snitch.
+func (r *deadMansSnitchReconciler) storeCheckInURL(ctx context.Context,
+	addon *addonsv1alpha1.Addon, snitch deadmanssnitch.Snitch) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getDeadMansSnitchName(addon),
+			Namespace: GetCommonInstallOptions(addon).Namespace,
+			Annotations: map[string]string{
+				deadMansSnitchTokenAnnotation: snitch.Token,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			deadMansSnitchURLKey: []byte(snitch.CheckInURL),
+		},
+	}
+	controllers.AddCommonLabels(secret, addon)
+	if err := controllerutil.SetControllerReference(addon, secret, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on Dead Man's Snitch Secret: %w", err)
+	}
+	if err := controllers.Apply(ctx, r.client, secret); err != nil {
+		return nil, fmt.Errorf("applying Dead Man's Snitch Secret: %w", err)
+	}
+	return secret, nil
+}
+
+// PrometheusRule evaluated by the MonitoringStack, firing the snitch alert at all times.
+func (r *deadMansSnitchReconciler) desiredPrometheusRule(
+	addon *addonsv1alpha1.Addon) (*monv1.PrometheusRule, error) {
+	prometheusRule := &monv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getDeadMansSnitchName(addon),
+			Namespace: GetCommonInstallOptions(addon).Namespace,
+			Labels: map[string]string{
+				controllers.MSOLabel: addon.Name,
+			},
+		},
+		Spec: monv1.PrometheusRuleSpec{
+			Groups: []monv1.RuleGroup{{
+				Name: "dead-mans-snitch",
+				Rules: []monv1.Rule{{
+					Alert: deadMansSnitchAlertName,
+					Expr:  intstr.FromString("vector(1)"),
+					Labels: map[string]string{
+						"severity": "none",
+						// Matched by the namespace matcher added to the AlertmanagerConfig route.
+						"namespace": GetCommonInstallOptions(addon).Namespace,
+					},
+					Annotations: map[string]string{
+						"description": "Always firing, checks in the Dead Man's Snitch of the addon.",
+					},
+				}},
+			}},
+		},
+	}
+
+	controllers.AddCommonLabels(prometheusRule, addon)
+	if err := controllerutil.SetControllerReference(addon, prometheusRule, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on Dead Man's Snitch PrometheusRule: %w", err)
+	}
+	return prometheusRule, nil
+}
+
+// AlertmanagerConfig routing the snitch alert to the check-in URL of the snitch.
+func (r *deadMansSnitchReconciler) desiredAlertmanagerConfig(addon *addonsv1alpha1.Addon,
+	secret *corev1.Secret) (*monv1alpha1.AlertmanagerConfig, error) {
+	const receiverName = "dead-mans-snitch"
+	sendResolved := false
+
+	alertmanagerConfig := &monv1alpha1.AlertmanagerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getDeadMansSnitchName(addon),
+			Namespace: GetCommonInstallOptions(addon).Namespace,
+			Labels: map[string]string{
+				controllers.MSOLabel: addon.Name,
+			},
+		},
+		Spec: monv1alpha1.AlertmanagerConfigSpec{
+			Route: &monv1alpha1.Route{
+				Receiver: receiverName,
+				Matchers: []monv1alpha1.Matcher{
+					{
+						Name:      "alertname",
+						Value:     deadMansSnitchAlertName,
+						MatchType: monv1alpha1.MatchEqual,
+					},
+				},
+				// Check in well within the shortest snitch interval.
+				GroupWait:      "0s",
+				RepeatInterval: "5m",
+			},
+			Receivers: []monv1alpha1.Receiver{
+				{
+					Name: receiverName,
+					WebhookConfigs: []monv1alpha1.WebhookConfig{
+						{
+							URLSecret: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+								Key:                  deadMansSnitchURLKey,
+							},
+							SendResolved: &sendResolved,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	controllers.AddCommonLabels(alertmanagerConfig, addon)
+	if err := controllerutil.SetControllerReference(addon, alertmanagerConfig, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on Dead Man's Snitch AlertmanagerConfig: %w", err)
+	}
+	return alertmanagerConfig, nil
+}
+
+// Removes the snitch and its objects, when the Addon no longer requests one.
+func (r *deadMansSnitchReconciler) ensureDeletionOfDeadMansSnitch(ctx context.Context,
+	addon *addonsv1alpha1.Addon) error {
+	namespace := GetCommonInstallOptions(addon).Namespace
+	if len(namespace) == 0 {
+		return nil
+	}
+
+	// Retried with the next reconcile, as long as the Secret is left.
+	if _, err := deleteDeadMansSnitch(ctx, r.client, r.snitches.get(), addon); err != nil {
+		controllers.LoggerFromContext(ctx).Error(err, "deleting Dead Man's Snitch")
+	}
+	for _, obj := range []client.Object{
+		&monv1.PrometheusRule{},
+		&monv1alpha1.AlertmanagerConfig{},
+	} {
+		obj.SetName(getDeadMansSnitchName(addon))
+		obj.SetNamespace(namespace)
+		if err := r.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting Dead Man's Snitch %T: %w", obj, err)
+		}
+	}
+	return nil
+}
+
+// Deletes the snitch of the Addon in Dead Man's Snitch, followed by the Secret recording it.
+// Without a configured account the Secret is kept, so the snitch
+// can still be deleted once an account is configured. Returns false in that case.
+func deleteDeadMansSnitch(ctx context.Context, c client.Client,
+	snitches deadMansSnitchClient, addon *addonsv1alpha1.Addon) (deleted bool, err error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name:      getDeadMansSnitchName(addon),
+		Namespace: GetCommonInstallOptions(addon).Namespace,
+	}, secret); k8sApiErrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting Dead Man's Snitch Secret: %w", err)
+	}
+
+	token := secret.Annotations[deadMansSnitchTokenAnnotation]
+	switch {
+	case len(token) == 0:
+	case snitches == nil:
+		controllers.LoggerFromContext(ctx).Info(
+			"no Dead Man's Snitch API token configured, keeping snitch", "token", token)
+		return false, nil
+	default:
+		if err := snitches.DeleteSnitch(ctx, token); err != nil &&
+			!errors.Is(err, deadmanssnitch.ErrSnitchNotFound) {
+			return false, fmt.Errorf("deleting snitch: %w", err)
+		}
+	}
+
+	if err := c.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("deleting Dead Man's Snitch Secret: %w", err)
+	}
+	return true, nil
+}
+
+// Deletes the snitch of a deleted Addon, which would alert forever otherwise.
+type deadMansSnitchFinalizer struct {
+	client   client.Client
+	snitches *deadMansSnitchAccount
+}
+
+func (f *deadMansSnitchFinalizer) Finalizer() string {
+	return "addons.managed.openshift.io/dead-mans-snitch"
+}
+
+func (f *deadMansSnitchFinalizer) Order() finalizerOrder {
+	return deadMansSnitchFinalizerOrder
+}
+
+func (f *deadMansSnitchFinalizer) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasDeadMansSnitch(addon)
+}
+
+// Holds back the removal of the Addon while no account is configured
+// to delete its snitch, retrying with backoff.
+func (f *deadMansSnitchFinalizer) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	if len(GetCommonInstallOptions(addon).Namespace) == 0 {
+		return true, nil
+	}
+	deleted, err := deleteDeadMansSnitch(ctx, f.client, f.snitches.get(), addon)
+	if err != nil {
+		return false, err
+	}
+	if !deleted {
+		return false, errDeadMansSnitchAccountMissing
+	}
+	return true, nil
+}
+
+var errDeadMansSnitchAccountMissing = errors.New(
+	"no Dead Man's Snitch API token is configured on the AddonOperator to delete the snitch")
+
+func getDeadMansSnitchName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("%s-dead-mans-snitch", addon.Name)
+}
+
+func getDeadMansSnitchInterval(spec *addonsv1alpha1.DeadMansSnitchSpec) addonsv1alpha1.DeadMansSnitchInterval {
+	if len(spec.Interval) == 0 {
+		return addonsv1alpha1.DeadMansSnitchInterval15Minutes
+	}
+	return spec.Interval
+}
@@ -0,0 +1,324 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/deadmanssnitch"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type deadMansSnitchClientMock struct {
+	mock.Mock
+}
+
+func (m *deadMansSnitchClientMock) CreateSnitch(
+	ctx context.Context, req deadmanssnitch.CreateSnitchRequest,
+) (deadmanssnitch.Snitch, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(deadmanssnitch.Snitch), args.Error(1)
+}
+
+func (m *deadMansSnitchClientMock) GetSnitch(
+	ctx context.Context, token string,
+) (deadmanssnitch.Snitch, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(deadmanssnitch.Snitch), args.Error(1)
+}
+
+func (m *deadMansSnitchClientMock) FindSnitch(
+	ctx context.Context, name string, tags []string,
+) (deadmanssnitch.Snitch, error) {
+	args := m.Called(ctx, name, tags)
+	return args.Get(0).(deadmanssnitch.Snitch), args.Error(1)
+}
+
+func (m *deadMansSnitchClientMock) DeleteSnitch(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func newTestAddonWithDeadMansSnitch() *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.Monitoring.MonitoringStack.DeadMansSnitch = &addonsv1alpha1.DeadMansSnitchSpec{
+		Tags: []string{"addon-foo"},
+	}
+	return addon
+}
+
+func TestDeadMansSnitchReconciler(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		// Token recorded in the existing Secret, no Secret exists if empty.
+		ExistingToken string
+		// Whether the existing snitch was deleted in Dead Man's Snitch.
+		SnitchDeleted bool
+		// Snitch of the same name and tags found in Dead Man's Snitch, none if empty.
+		FoundToken   string
+		ExpectCreate bool
+	}{
+		"creates snitch": {
+			ExpectCreate: true,
+		},
+		"keeps existing snitch": {
+			ExistingToken: "old",
+		},
+		"replaces deleted snitch": {
+			ExistingToken: "old",
+			SnitchDeleted: true,
+			ExpectCreate:  true,
+		},
+		"adopts snitch of the same name": {
+			FoundToken: "found",
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newTestAddonWithDeadMansSnitch()
+
+			var getErr error
+			if len(tc.ExistingToken) == 0 {
+				getErr = k8sApiErrors.NewNotFound(schema.GroupResource{}, "")
+			}
+			c := testutil.NewClient()
+			c.On("Get", testutil.IsContext, client.ObjectKey{
+				Name:      "addon-foo-dead-mans-snitch",
+				Namespace: "addon-1",
+			}, mock.IsType(&corev1.Secret{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					if getErr != nil {
+						return
+					}
+					secret := args.Get(2).(*corev1.Secret)
+					secret.Name = "addon-foo-dead-mans-snitch"
+					secret.Annotations = map[string]string{
+						deadMansSnitchTokenAnnotation: tc.ExistingToken,
+					}
+				}).
+				Return(getErr)
+			var (
+				secret             *corev1.Secret
+				alertmanagerConfig *monv1alpha1.AlertmanagerConfig
+			)
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					secret = args.Get(1).(*corev1.Secret).DeepCopy()
+				}).
+				Return(nil).Maybe()
+			c.On("Patch", testutil.IsContext, mock.IsType(&monv1.PrometheusRule{}), client.Apply, mock.Anything).
+				Return(nil)
+			c.On("Patch", testutil.IsContext, mock.IsType(&monv1alpha1.AlertmanagerConfig{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					alertmanagerConfig = args.Get(1).(*monv1alpha1.AlertmanagerConfig).DeepCopy()
+				}).
+				Return(nil)
+
+			snitches := &deadMansSnitchClientMock{}
+			if tc.SnitchDeleted {
+				snitches.On("GetSnitch", testutil.IsContext, tc.ExistingToken).
+					Return(deadmanssnitch.Snitch{}, deadmanssnitch.ErrSnitchNotFound)
+			} else {
+				snitches.On("GetSnitch", testutil.IsContext, tc.ExistingToken).
+					Return(deadmanssnitch.Snitch{Token: tc.ExistingToken}, nil).Maybe()
+			}
+			if len(tc.FoundToken) > 0 {
+				snitches.On("FindSnitch", testutil.IsContext, "addon-foo (cluster-1)", []string{"addon-foo"}).
+					Return(deadmanssnitch.Snitch{
+						Token:      tc.FoundToken,
+						CheckInURL: "https://nosnch.in/" + tc.FoundToken,
+					}, nil)
+			} else {
+				snitches.On("FindSnitch", testutil.IsContext, "addon-foo (cluster-1)", []string{"addon-foo"}).
+					Return(deadmanssnitch.Snitch{}, deadmanssnitch.ErrSnitchNotFound).Maybe()
+			}
+			snitches.On("CreateSnitch", testutil.IsContext, mock.Anything).
+				Return(deadmanssnitch.Snitch{
+					Token:      "new",
+					CheckInURL: "https://nosnch.in/new",
+				}, nil).Maybe()
+
+			account := &deadMansSnitchAccount{}
+			account.set(snitches)
+			r := &deadMansSnitchReconciler{
+				client:    c,
+				scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+				clusterID: "cluster-1",
+				snitches:  account,
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			assert.True(t, result.IsZero())
+
+			require.NotNil(t, alertmanagerConfig)
+			receiver := alertmanagerConfig.Spec.Receivers[0]
+			require.Len(t, receiver.WebhookConfigs, 1)
+			assert.Equal(t, "addon-foo-dead-mans-snitch", receiver.WebhookConfigs[0].URLSecret.Name)
+			assert.Equal(t, deadMansSnitchURLKey, receiver.WebhookConfigs[0].URLSecret.Key)
+			assert.Equal(t, "addon-foo", alertmanagerConfig.Labels["addons.managed.openshift.io/mso"])
+
+			if len(tc.FoundToken) > 0 {
+				snitches.AssertNotCalled(t, "CreateSnitch", mock.Anything, mock.Anything)
+				require.NotNil(t, secret)
+				assert.Equal(t, tc.FoundToken, secret.Annotations[deadMansSnitchTokenAnnotation])
+				assert.Equal(t, []byte("https://nosnch.in/found"), secret.Data[deadMansSnitchURLKey])
+				return
+			}
+			if !tc.ExpectCreate {
+				snitches.AssertNotCalled(t, "CreateSnitch", mock.Anything, mock.Anything)
+				assert.Nil(t, secret)
+				return
+			}
+			snitches.AssertCalled(t, "CreateSnitch", testutil.IsContext, deadmanssnitch.CreateSnitchRequest{
+				Name:      "addon-foo (cluster-1)",
+				Interval:  "15_minute",
+				Tags:      []string{"addon-foo"},
+				Notes:     "Checked in by the MonitoringStack of Addon addon-foo on cluster cluster-1.",
+				AlertType: deadmanssnitch.AlertTypeBasic,
+			})
+			require.NotNil(t, secret)
+			assert.Equal(t, "new", secret.Annotations[deadMansSnitchTokenAnnotation])
+			assert.Equal(t, []byte("https://nosnch.in/new"), secret.Data[deadMansSnitchURLKey])
+			assert.True(t, metav1.IsControlledBy(secret, addon))
+		})
+	}
+}
+
+func TestDeadMansSnitchReconciler_NoAccount(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithDeadMansSnitch()
+	c := testutil.NewClient()
+	r := &deadMansSnitchReconciler{
+		client:    c,
+		scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+		clusterID: "cluster-1",
+		snitches:  &deadMansSnitchAccount{},
+	}
+
+	ctx, report := contextWithPhaseReport(context.Background())
+	result, err := r.Reconcile(ctx, addon)
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyDeadMansSnitch, report.notReadyReason)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+}
+
+func TestDeadMansSnitchReconciler_APIError(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithDeadMansSnitch()
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, mock.Anything, mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+
+	snitches := &deadMansSnitchClientMock{}
+	snitches.On("FindSnitch", testutil.IsContext, mock.Anything, mock.Anything).
+		Return(deadmanssnitch.Snitch{}, deadmanssnitch.ErrSnitchNotFound)
+	snitches.On("CreateSnitch", testutil.IsContext, mock.Anything).
+		Return(deadmanssnitch.Snitch{}, errors.New("HTTP 500"))
+
+	account := &deadMansSnitchAccount{}
+	account.set(snitches)
+	r := &deadMansSnitchReconciler{
+		client:    c,
+		scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+		clusterID: "cluster-1",
+		snitches:  account,
+	}
+
+	ctx, report := contextWithPhaseReport(context.Background())
+	result, err := r.Reconcile(ctx, addon)
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyDeadMansSnitch, report.notReadyReason)
+	assert.Contains(t, report.notReadyMessage, "HTTP 500")
+}
+
+func TestDeadMansSnitchFinalizer(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithDeadMansSnitch()
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, client.ObjectKey{
+		Name:      "addon-foo-dead-mans-snitch",
+		Namespace: "addon-1",
+	}, mock.IsType(&corev1.Secret{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*corev1.Secret).Annotations = map[string]string{
+				deadMansSnitchTokenAnnotation: "c2354d53d2",
+			}
+		}).
+		Return(nil)
+	c.On("Delete", testutil.IsContext, mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(nil)
+
+	snitches := &deadMansSnitchClientMock{}
+	snitches.On("DeleteSnitch", testutil.IsContext, "c2354d53d2").
+		Return(deadmanssnitch.ErrSnitchNotFound)
+
+	account := &deadMansSnitchAccount{}
+	account.set(snitches)
+	f := &deadMansSnitchFinalizer{client: c, snitches: account}
+
+	done, err := f.Finalize(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, done)
+	snitches.AssertExpectations(t)
+	c.AssertExpectations(t)
+}
+
+func TestDeadMansSnitchFinalizer_NoAccount(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithDeadMansSnitch()
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, mock.Anything, mock.IsType(&corev1.Secret{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*corev1.Secret).Annotations = map[string]string{
+				deadMansSnitchTokenAnnotation: "c2354d53d2",
+			}
+		}).
+		Return(nil)
+
+	f := &deadMansSnitchFinalizer{client: c, snitches: &deadMansSnitchAccount{}}
+
+	done, err := f.Finalize(context.Background(), addon)
+	require.ErrorIs(t, err, errDeadMansSnitchAccountMissing)
+	assert.False(t, done)
+	c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeadMansSnitchFinalizer_AppliesTo(t *testing.T) {
+	t.Parallel()
+
+	f := &deadMansSnitchFinalizer{}
+	assert.True(t, f.AppliesTo(newTestAddonWithDeadMansSnitch()))
+	assert.False(t, f.AppliesTo(testutil.NewTestAddonWithMonitoringStack()))
+}
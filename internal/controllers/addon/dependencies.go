@@ -0,0 +1,81 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Field index of Addons by the names of the Addons they depend on.
+const dependsOnIndexKey = ".spec.dependsOn"
+
+func indexAddonDependsOn(obj client.Object) []string {
+	addon, ok := obj.(*addonsv1alpha1.Addon)
+	if !ok {
+		return nil
+	}
+	return addon.Spec.DependsOn
+}
+
+func setupDependsOnIndex(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(
+		context.Background(), &addonsv1alpha1.Addon{}, dependsOnIndexKey, indexAddonDependsOn)
+}
+
+// Holds back the install of the Addon until all Addons it depends on are Available.
+// Returns true, if the Addon has to wait.
+func (r *AddonReconciler) waitForDependencies(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (waiting bool, err error) {
+	if len(addon.Spec.DependsOn) == 0 ||
+		meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed) {
+		return false, nil
+	}
+
+	var notReady []string
+	for _, name := range addon.Spec.DependsOn {
+		dependency := &addonsv1alpha1.Addon{}
+		err := r.Get(ctx, client.ObjectKey{Name: name}, dependency)
+		if client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("getting Addon dependency %q: %w", name, err)
+		}
+		if err == nil && meta.IsStatusConditionTrue(dependency.Status.Conditions, addonsv1alpha1.Available) {
+			continue
+		}
+		notReady = append(notReady, name)
+	}
+	if len(notReady) == 0 {
+		return false, nil
+	}
+
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonDependenciesNotReady,
+		fmt.Sprintf("Waiting for Addon dependencies to become Available: %s", strings.Join(notReady, ", ")))
+	return true, nil
+}
+
+// Enqueues all Addons depending on the given Addon,
+// so they are reconciled as soon as it becomes Available.
+func (r *AddonReconciler) enqueueDependentAddons(obj client.Object) []reconcile.Request {
+	addonList := &addonsv1alpha1.AddonList{}
+	if err := r.List(context.Background(), addonList, client.MatchingFields{
+		dependsOnIndexKey: obj.GetName(),
+	}); err != nil {
+		r.Log.Error(err, "listing dependent Addons")
+		return nil
+	}
+
+	reqs := make([]reconcile.Request, 0, len(addonList.Items))
+	for _, dependent := range addonList.Items {
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: dependent.Name},
+		})
+	}
+	return reqs
+}
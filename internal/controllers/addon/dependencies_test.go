@@ -0,0 +1,101 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestWaitForDependencies(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		DependencyAvailable bool
+		DependencyMissing   bool
+		ExpectedWaiting     bool
+	}{
+		"dependency available": {
+			DependencyAvailable: true,
+		},
+		"dependency not available": {
+			ExpectedWaiting: true,
+		},
+		"dependency missing": {
+			DependencyMissing: true,
+			ExpectedWaiting:   true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			if tc.DependencyMissing {
+				c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsAddonsv1alpha1AddonPtr, mock.Anything).
+					Return(errors.NewNotFound(schema.GroupResource{}, "logging"))
+			} else {
+				c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsAddonsv1alpha1AddonPtr, mock.Anything).
+					Run(func(args mock.Arguments) {
+						if !tc.DependencyAvailable {
+							return
+						}
+						dependency := args.Get(2).(*addonsv1alpha1.Addon)
+						dependency.Status.Conditions = []metav1.Condition{{
+							Type:   addonsv1alpha1.Available,
+							Status: metav1.ConditionTrue,
+						}}
+					}).
+					Return(nil)
+			}
+
+			r := &AddonReconciler{Client: c}
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.DependsOn = []string{"logging"}
+
+			waiting, err := r.waitForDependencies(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedWaiting, waiting)
+
+			if tc.ExpectedWaiting {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonDependenciesNotReady, cond.Reason)
+				assert.Equal(t, addonsv1alpha1.PhasePending, addon.Status.Phase)
+			}
+		})
+	}
+}
+
+func TestEnqueueDependentAddons(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.On("List", mock.Anything, mock.IsType(&addonsv1alpha1.AddonList{}),
+		[]client.ListOption{client.MatchingFields{dependsOnIndexKey: "logging"}}).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*addonsv1alpha1.AddonList)
+			list.Items = []addonsv1alpha1.Addon{
+				{ObjectMeta: metav1.ObjectMeta{Name: "backup"}},
+			}
+		}).
+		Return(nil)
+
+	r := &AddonReconciler{Client: c}
+	logging := &addonsv1alpha1.Addon{ObjectMeta: metav1.ObjectMeta{Name: "logging"}}
+
+	reqs := r.enqueueDependentAddons(logging)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "backup", reqs[0].Name)
+}
@@ -0,0 +1,72 @@
+package addon
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/metrics"
+)
+
+const (
+	// Default interval to re-assert the desired state of Addons at,
+	// when nothing else triggers their reconciliation.
+	defaultDriftDetectionInterval = 10 * time.Minute
+	// Reason of Events emitted for reverted manual changes.
+	driftRemediatedEventReason = "DriftRemediated"
+)
+
+// Interval to re-assert the desired state of Addons at,
+// can be tuned at runtime via SetDriftDetectionInterval.
+var driftDetectionInterval = int64(defaultDriftDetectionInterval)
+
+// Sets the interval to re-assert the desired state of Addons at. Concurrency safe.
+func SetDriftDetectionInterval(d time.Duration) {
+	atomic.StoreInt64(&driftDetectionInterval, int64(d))
+}
+
+func getDriftDetectionInterval() time.Duration {
+	return time.Duration(atomic.LoadInt64(&driftDetectionInterval))
+}
+
+// Reports objects of an Addon whose manual changes were reverted
+// via metrics and Events on the Addon.
+// Both the metrics recorder and the event recorder are optional.
+type driftReporter struct {
+	recorder *metrics.Recorder
+	events   record.EventRecorder
+}
+
+// Reports the given object as remediated, if it was changed
+// while the spec of the Addon stayed the same.
+// Changes caused by a new Addon spec are expected and not reported.
+func (d *driftReporter) reportRemediated(
+	ctx context.Context, addon *addonsv1alpha1.Addon, obj client.Object, kind string) {
+	if addon.Status.ObservedGeneration != addon.Generation {
+		return
+	}
+
+	controllers.LoggerFromContext(ctx).Info("reverted manual changes",
+		"kind", kind, "object", client.ObjectKeyFromObject(obj))
+
+	if d == nil {
+		return
+	}
+	if d.recorder != nil {
+		d.recorder.RecordDriftRemediation(addon.Name, kind)
+	}
+	if d.events != nil {
+		name := obj.GetName()
+		if ns := obj.GetNamespace(); len(ns) > 0 {
+			name = ns + "/" + name
+		}
+		d.events.Eventf(addon, corev1.EventTypeWarning, driftRemediatedEventReason,
+			"Reverted manual changes to %s %s.", kind, name)
+	}
+}
@@ -0,0 +1,55 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/metrics"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestDriftReporter_ReportRemediated(t *testing.T) {
+	t.Parallel()
+
+	events := record.NewFakeRecorder(10)
+	d := &driftReporter{
+		recorder: metrics.NewRecorder(false, "cluster-1"),
+		events:   events,
+	}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Generation = 2
+	addon.Status.ObservedGeneration = 2
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "addon-1"}}
+
+	ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	d.reportRemediated(ctx, addon, ns, "Namespace")
+
+	require.Len(t, events.Events, 1)
+	assert.Equal(t, "Warning DriftRemediated Reverted manual changes to Namespace addon-1.", <-events.Events)
+}
+
+func TestDriftReporter_IgnoresSpecChanges(t *testing.T) {
+	t.Parallel()
+
+	events := record.NewFakeRecorder(10)
+	d := &driftReporter{events: events}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Generation = 3
+	addon.Status.ObservedGeneration = 2
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "addon-1"}}
+
+	d.reportRemediated(context.Background(), addon, ns, "Namespace")
+	assert.Empty(t, events.Events)
+
+	// Reporters are optional.
+	var noop *driftReporter
+	addon.Status.ObservedGeneration = 3
+	noop.reportRemediated(context.Background(), addon, ns, "Namespace")
+}
@@ -0,0 +1,113 @@
+package addon
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Reasons of Events emitted for Addon lifecycle milestones.
+const (
+	installStartedEventReason             = "InstallStarted"
+	csvSucceededEventReason               = "CSVSucceeded"
+	installSucceededEventReason           = "InstallSucceeded"
+	upgradeStartedEventReason             = "UpgradeStarted"
+	upgradeSucceededEventReason           = "UpgradeSucceeded"
+	monitoringFederationFailedEventReason = "MonitoringFederationFailed"
+	deletionStartedEventReason            = "DeletionStarted"
+	rolledBackEventReason                 = "RolledBack"
+	namespaceDeletionStuckEventReason     = "NamespaceDeletionStuck"
+	degradedEventReason                   = "Degraded"
+	federationTenancyEventReason          = "MonitoringFederationTenancyViolated"
+)
+
+// Emits Events for the lifecycle milestones the Addon reached,
+// by comparing its conditions to the conditions before reconciling it,
+// so `kubectl describe addon` tells the story of the Addon.
+func (r *AddonReconciler) recordLifecycleEvents(
+	addon *addonsv1alpha1.Addon, previous []metav1.Condition) {
+	if r.events == nil {
+		return
+	}
+
+	current := addon.Status.Conditions
+	becameTrue := func(condType string) bool {
+		return meta.IsStatusConditionTrue(current, condType) &&
+			!meta.IsStatusConditionTrue(previous, condType)
+	}
+
+	if meta.FindStatusCondition(previous, addonsv1alpha1.Installed) == nil &&
+		meta.IsStatusConditionFalse(current, addonsv1alpha1.Installed) {
+		r.events.Eventf(addon, corev1.EventTypeNormal, installStartedEventReason,
+			"Started installing version %q.", addon.Spec.Version)
+	}
+
+	if becameTrue(addonsv1alpha1.Installed) {
+		if csv := addon.Status.LastObservedAvailableCSV; len(csv) > 0 {
+			r.events.Eventf(addon, corev1.EventTypeNormal, csvSucceededEventReason,
+				"ClusterServiceVersion %s succeeded.", csv)
+		} else {
+			r.events.Event(addon, corev1.EventTypeNormal, installSucceededEventReason,
+				"Addon has been successfully installed.")
+		}
+	}
+
+	if becameTrue(addonsv1alpha1.UpgradeStarted) {
+		r.events.Eventf(addon, corev1.EventTypeNormal, upgradeStartedEventReason,
+			"Started upgrade from version %q to %q.",
+			addon.Status.ObservedVersion, addon.Spec.Version)
+	}
+
+	if becameTrue(addonsv1alpha1.UpgradeSucceeded) {
+		r.events.Eventf(addon, corev1.EventTypeNormal, upgradeSucceededEventReason,
+			"Upgraded to version %q.", addon.Spec.Version)
+	}
+
+	if cond := meta.FindStatusCondition(current, addonsv1alpha1.MonitoringFederationReady); cond != nil &&
+		cond.Reason == addonsv1alpha1.PhaseReasonReconcileError {
+		prev := meta.FindStatusCondition(previous, addonsv1alpha1.MonitoringFederationReady)
+		if prev == nil || prev.Reason != cond.Reason || prev.Message != cond.Message {
+			r.events.Event(addon, corev1.EventTypeWarning, monitoringFederationFailedEventReason,
+				cond.Message)
+		}
+	}
+
+	if becameTrue(addonsv1alpha1.RolledBack) {
+		r.events.Event(addon, corev1.EventTypeNormal, rolledBackEventReason,
+			meta.FindStatusCondition(current, addonsv1alpha1.RolledBack).Message)
+	}
+
+	if meta.IsStatusConditionTrue(previous, addonsv1alpha1.Available) &&
+		meta.IsStatusConditionFalse(current, addonsv1alpha1.Available) &&
+		!isAvailableReason(current, addonsv1alpha1.AddonReasonTerminating) {
+		cond := meta.FindStatusCondition(current, addonsv1alpha1.Available)
+		r.events.Eventf(addon, corev1.EventTypeWarning, degradedEventReason,
+			"Addon is no longer available: %s: %s", cond.Reason, cond.Message)
+	}
+
+	if isAvailableReason(current, addonsv1alpha1.AddonReasonTerminating) &&
+		!isAvailableReason(previous, addonsv1alpha1.AddonReasonTerminating) {
+		r.events.Eventf(addon, corev1.EventTypeNormal, deletionStartedEventReason,
+			"Deleting Addon with uninstall strategy %s.", uninstallStrategy(addon))
+	}
+
+	if becameTrue(addonsv1alpha1.MonitoringFederationTenancyViolated) {
+		r.events.Event(addon, corev1.EventTypeWarning, federationTenancyEventReason,
+			meta.FindStatusCondition(current, addonsv1alpha1.MonitoringFederationTenancyViolated).Message)
+	}
+
+	if cond := meta.FindStatusCondition(current, addonsv1alpha1.NamespaceDeletionStuck); cond != nil {
+		prev := meta.FindStatusCondition(previous, addonsv1alpha1.NamespaceDeletionStuck)
+		if prev == nil || prev.Message != cond.Message {
+			r.events.Event(addon, corev1.EventTypeWarning, namespaceDeletionStuckEventReason,
+				cond.Message)
+		}
+	}
+}
+
+func isAvailableReason(conds []metav1.Condition, reason string) bool {
+	cond := meta.FindStatusCondition(conds, addonsv1alpha1.Available)
+	return cond != nil && cond.Reason == reason
+}
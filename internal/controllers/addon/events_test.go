@@ -0,0 +1,112 @@
+package addon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestRecordLifecycleEvents(t *testing.T) {
+	t.Parallel()
+
+	cond := func(condType string, status metav1.ConditionStatus, reason, msg string) metav1.Condition {
+		return metav1.Condition{Type: condType, Status: status, Reason: reason, Message: msg}
+	}
+	federationFailed := cond(addonsv1alpha1.MonitoringFederationReady, metav1.ConditionFalse,
+		addonsv1alpha1.PhaseReasonReconcileError, "servicemonitor conflict")
+	namespaceStuck := cond(addonsv1alpha1.NamespaceDeletionStuck, metav1.ConditionTrue,
+		addonsv1alpha1.AddonReasonNamespacesStuckTerminating, "Namespaces terminating for longer than 15m0s: addon-1.")
+
+	for name, tc := range map[string]struct {
+		Previous, Current []metav1.Condition
+		CSV               string
+		Expected          []string
+	}{
+		"install started": {
+			Current: []metav1.Condition{
+				cond(addonsv1alpha1.Installed, metav1.ConditionFalse, addonsv1alpha1.AddonReasonNotInstalled, ""),
+			},
+			Expected: []string{`Normal InstallStarted Started installing version "1.0.0".`},
+		},
+		"csv succeeded": {
+			Previous: []metav1.Condition{
+				cond(addonsv1alpha1.Installed, metav1.ConditionFalse, addonsv1alpha1.AddonReasonNotInstalled, ""),
+			},
+			Current: []metav1.Condition{
+				cond(addonsv1alpha1.Installed, metav1.ConditionTrue, addonsv1alpha1.AddonReasonInstalled, ""),
+			},
+			CSV:      "addon-1/operator.v1.0.0",
+			Expected: []string{"Normal CSVSucceeded ClusterServiceVersion addon-1/operator.v1.0.0 succeeded."},
+		},
+		"upgrade": {
+			Current: []metav1.Condition{
+				cond(addonsv1alpha1.UpgradeSucceeded, metav1.ConditionTrue, addonsv1alpha1.AddonReasonUpgradeSucceeded, ""),
+			},
+			Expected: []string{`Normal UpgradeSucceeded Upgraded to version "1.0.0".`},
+		},
+		"monitoring federation failed": {
+			Current:  []metav1.Condition{federationFailed},
+			Expected: []string{"Warning MonitoringFederationFailed servicemonitor conflict"},
+		},
+		"monitoring federation still failing": {
+			Previous: []metav1.Condition{federationFailed},
+			Current:  []metav1.Condition{federationFailed},
+		},
+		"monitoring federation tenancy violated": {
+			Current: []metav1.Condition{
+				cond(addonsv1alpha1.MonitoringFederationTenancyViolated, metav1.ConditionTrue,
+					addonsv1alpha1.AddonReasonFederationTenancyViolated, "namespace shared"),
+			},
+			Expected: []string{"Warning MonitoringFederationTenancyViolated namespace shared"},
+		},
+		"deletion": {
+			Current: []metav1.Condition{
+				cond(addonsv1alpha1.Available, metav1.ConditionFalse, addonsv1alpha1.AddonReasonTerminating, ""),
+			},
+			Expected: []string{"Normal DeletionStarted Deleting Addon with uninstall strategy Cascade."},
+		},
+		"degraded": {
+			Previous: []metav1.Condition{
+				cond(addonsv1alpha1.Available, metav1.ConditionTrue, addonsv1alpha1.AddonReasonFullyReconciled, ""),
+			},
+			Current: []metav1.Condition{
+				cond(addonsv1alpha1.Available, metav1.ConditionFalse, addonsv1alpha1.AddonReasonUnreadyCSV, "CSV is failing"),
+			},
+			Expected: []string{"Warning Degraded Addon is no longer available: UnreadyCSV: CSV is failing"},
+		},
+		"namespace deletion stuck": {
+			Current:  []metav1.Condition{namespaceStuck},
+			Expected: []string{"Warning NamespaceDeletionStuck Namespaces terminating for longer than 15m0s: addon-1."},
+		},
+		"namespace deletion still stuck": {
+			Previous: []metav1.Condition{namespaceStuck},
+			Current:  []metav1.Condition{namespaceStuck},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			events := record.NewFakeRecorder(10)
+			r := &AddonReconciler{events: events}
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Version = "1.0.0"
+			addon.Status.Conditions = tc.Current
+			addon.Status.LastObservedAvailableCSV = tc.CSV
+
+			r.recordLifecycleEvents(addon, tc.Previous)
+			close(events.Events)
+
+			var recorded []string
+			for e := range events.Events {
+				recorded = append(recorded, e)
+			}
+			assert.Equal(t, tc.Expected, recorded)
+		})
+	}
+}
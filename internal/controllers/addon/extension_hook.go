@@ -0,0 +1,160 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/extensionhook"
+)
+
+type extensionHookClient interface {
+	Call(ctx context.Context, req extensionhook.Request) (extensionhook.Response, error)
+	IgnoreFailures() bool
+}
+
+// Injects the client used to call the extension hook,
+// a nil client disables the extension hook. Concurrency safe.
+func (r *AddonReconciler) InjectExtensionHookClient(c *extensionhook.Client) {
+	r.extensionHookClientMux.Lock()
+	defer r.extensionHookClientMux.Unlock()
+
+	if c == nil {
+		r.extensionHookClient = nil
+		return
+	}
+	r.extensionHookClient = c
+}
+
+// Calls the extension hook before the Addon is installed or upgraded.
+// Returns true, if the hook vetoed the operation.
+// Operations allowed are recorded in .status.extensionHook,
+// so the hook is called only once per stage and version.
+func (r *AddonReconciler) handlePreOperationHook(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (vetoed bool, err error) {
+	r.extensionHookClientMux.RLock()
+	defer r.extensionHookClientMux.RUnlock()
+
+	req := extensionhook.Request{
+		ClusterID: r.ClusterExternalID,
+		Addon:     addon,
+	}
+	vetoReason := addonsv1alpha1.AddonReasonInstallVetoed
+	switch {
+	case addonIsBeingUpgraded(addon):
+		req.Stage = extensionhook.StagePreUpgrade
+		req.PreviousVersion = addon.Status.ObservedVersion
+		vetoReason = addonsv1alpha1.AddonReasonUpgradeVetoed
+	case !meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed):
+		req.Stage = extensionhook.StagePreInstall
+	default:
+		removeExtensionHookVeto(addon)
+		return false, nil
+	}
+
+	if r.extensionHookClient == nil || extensionHookAllowed(addon, req.Stage) {
+		removeExtensionHookVeto(addon)
+		return false, nil
+	}
+
+	res, err := r.extensionHookClient.Call(ctx, req)
+	if err != nil {
+		if r.extensionHookClient.IgnoreFailures() {
+			controllers.LoggerFromContext(ctx).Error(err, "ignoring failed extension hook call", "stage", req.Stage)
+			removeExtensionHookVeto(addon)
+			return false, nil
+		}
+		reportPendingStatus(addon, vetoReason, fmt.Sprintf("Extension hook call failed: %s", err))
+		return true, nil
+	}
+
+	if !res.Allowed {
+		reportPendingStatus(addon, vetoReason, fmt.Sprintf("Vetoed by extension hook: %s", res.Message))
+		return true, nil
+	}
+	removeExtensionHookVeto(addon)
+	addon.Status.ExtensionHook = &addonsv1alpha1.AddonExtensionHookStatus{
+		Stage:       string(req.Stage),
+		Version:     addon.Spec.Version,
+		AllowedTime: metav1.Now(),
+		Annotations: res.Annotations,
+	}
+	return false, nil
+}
+
+// Whether the extension hook already allowed the given stage for the current version.
+func extensionHookAllowed(addon *addonsv1alpha1.Addon, stage extensionhook.Stage) bool {
+	allowed := addon.Status.ExtensionHook
+	return allowed != nil &&
+		allowed.Stage == string(stage) &&
+		allowed.Version == addon.Spec.Version
+}
+
+// Notifies the extension hook about the deletion of the Addon.
+// Deletion can't be vetoed, so failures are only logged.
+func (r *AddonReconciler) handlePostDeleteHook(ctx context.Context, addon *addonsv1alpha1.Addon) {
+	r.extensionHookClientMux.RLock()
+	defer r.extensionHookClientMux.RUnlock()
+
+	if r.extensionHookClient == nil {
+		return
+	}
+
+	if _, err := r.extensionHookClient.Call(ctx, extensionhook.Request{
+		Stage:     extensionhook.StagePostDelete,
+		ClusterID: r.ClusterExternalID,
+		Addon:     addon,
+	}); err != nil {
+		controllers.LoggerFromContext(ctx).Error(err, "calling extension hook", "stage", extensionhook.StagePostDelete)
+	}
+}
+
+// Notifies the extension hook about removed Addons,
+// e.g. to deregister them from fleet tooling.
+// Failed hook calls are logged and do not block the removal.
+type postDeleteHookFinalizer struct {
+	reconciler *AddonReconciler
+}
+
+func (f *postDeleteHookFinalizer) Finalizer() string {
+	return "addons.managed.openshift.io/post-delete-hook"
+}
+
+func (f *postDeleteHookFinalizer) Order() finalizerOrder {
+	return postDeleteHookFinalizerOrder
+}
+
+func (f *postDeleteHookFinalizer) AppliesTo(*addonsv1alpha1.Addon) bool {
+	f.reconciler.extensionHookClientMux.RLock()
+	defer f.reconciler.extensionHookClientMux.RUnlock()
+	return f.reconciler.extensionHookClient != nil
+}
+
+func (f *postDeleteHookFinalizer) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	f.reconciler.handlePostDeleteHook(ctx, addon)
+	return true, nil
+}
+
+// Whether the last upgrade of the Addon was vetoed by the extension hook.
+func upgradeVetoed(addon *addonsv1alpha1.Addon) bool {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	return cond != nil && cond.Reason == addonsv1alpha1.AddonReasonUpgradeVetoed
+}
+
+// Removes the Available condition if it was set by a veto,
+// it's reported again by the sub-reconcilers.
+func removeExtensionHookVeto(addon *addonsv1alpha1.Addon) {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	if cond == nil {
+		return
+	}
+	if cond.Reason == addonsv1alpha1.AddonReasonInstallVetoed ||
+		cond.Reason == addonsv1alpha1.AddonReasonUpgradeVetoed {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.Available)
+	}
+}
@@ -0,0 +1,171 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/extensionhook"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type extensionHookClientMock struct {
+	mock.Mock
+}
+
+func (m *extensionHookClientMock) Call(
+	ctx context.Context, req extensionhook.Request) (extensionhook.Response, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(extensionhook.Response), args.Error(1)
+}
+
+func (m *extensionHookClientMock) IgnoreFailures() bool {
+	return m.Called().Bool(0)
+}
+
+func TestHandlePreOperationHook(t *testing.T) {
+	t.Parallel()
+
+	installed := metav1.Condition{
+		Type:   addonsv1alpha1.Installed,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonInstalled,
+	}
+
+	for name, tc := range map[string]struct {
+		Conditions      []metav1.Condition
+		SpecVersion     string
+		ObservedVersion string
+		Response        extensionhook.Response
+		CallErr         error
+		IgnoreFailures  bool
+		ExpectedStage   extensionhook.Stage
+		ExpectedVetoed  bool
+		ExpectedReason  string
+	}{
+		"install allowed": {
+			Response:      extensionhook.Response{Allowed: true},
+			ExpectedStage: extensionhook.StagePreInstall,
+		},
+		"install vetoed": {
+			Response:       extensionhook.Response{Allowed: false, Message: "change freeze"},
+			ExpectedStage:  extensionhook.StagePreInstall,
+			ExpectedVetoed: true,
+			ExpectedReason: addonsv1alpha1.AddonReasonInstallVetoed,
+		},
+		"upgrade vetoed": {
+			Conditions:      []metav1.Condition{installed},
+			SpecVersion:     "2.0.0",
+			ObservedVersion: "1.0.0",
+			Response:        extensionhook.Response{Allowed: false},
+			ExpectedStage:   extensionhook.StagePreUpgrade,
+			ExpectedVetoed:  true,
+			ExpectedReason:  addonsv1alpha1.AddonReasonUpgradeVetoed,
+		},
+		"failed call vetoes": {
+			CallErr:        errors.New("connection refused"),
+			ExpectedStage:  extensionhook.StagePreInstall,
+			ExpectedVetoed: true,
+			ExpectedReason: addonsv1alpha1.AddonReasonInstallVetoed,
+		},
+		"failed call ignored": {
+			CallErr:        errors.New("connection refused"),
+			IgnoreFailures: true,
+			ExpectedStage:  extensionhook.StagePreInstall,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hook := &extensionHookClientMock{}
+			hook.On("Call", mock.Anything, mock.Anything).Return(tc.Response, tc.CallErr)
+			hook.On("IgnoreFailures").Return(tc.IgnoreFailures).Maybe()
+
+			r := &AddonReconciler{extensionHookClient: hook}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Status.Conditions = tc.Conditions
+			addon.Spec.Version = tc.SpecVersion
+			addon.Status.ObservedVersion = tc.ObservedVersion
+
+			vetoed, err := r.handlePreOperationHook(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedVetoed, vetoed)
+
+			req := hook.Calls[0].Arguments.Get(1).(extensionhook.Request)
+			assert.Equal(t, tc.ExpectedStage, req.Stage)
+
+			if tc.ExpectedVetoed {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, cond)
+				assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			}
+			assert.Equal(t, tc.ExpectedReason == addonsv1alpha1.AddonReasonUpgradeVetoed, upgradeVetoed(addon))
+		})
+	}
+}
+
+func TestHandlePreOperationHook_Installed(t *testing.T) {
+	t.Parallel()
+
+	hook := &extensionHookClientMock{}
+	r := &AddonReconciler{extensionHookClient: hook}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Status.Conditions = []metav1.Condition{
+		{
+			Type:   addonsv1alpha1.Installed,
+			Status: metav1.ConditionTrue,
+		},
+		{
+			Type:   addonsv1alpha1.Available,
+			Status: metav1.ConditionFalse,
+			Reason: addonsv1alpha1.AddonReasonInstallVetoed,
+		},
+	}
+
+	vetoed, err := r.handlePreOperationHook(context.Background(), addon)
+	require.NoError(t, err)
+	assert.False(t, vetoed)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+	hook.AssertNotCalled(t, "Call", mock.Anything, mock.Anything)
+}
+
+func TestHandlePreOperationHook_RecordsAllowed(t *testing.T) {
+	t.Parallel()
+
+	hook := &extensionHookClientMock{}
+	hook.On("Call", mock.Anything, mock.Anything).Return(extensionhook.Response{
+		Allowed:     true,
+		Annotations: map[string]string{"example.com/ticket": "CHG-1"},
+	}, nil).Once()
+
+	r := &AddonReconciler{extensionHookClient: hook}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.Version = "1.0.0"
+	vetoed, err := r.handlePreOperationHook(context.Background(), addon)
+	require.NoError(t, err)
+	assert.False(t, vetoed)
+
+	// Annotations are recorded in the status, without updating the Addon.
+	require.NotNil(t, addon.Status.ExtensionHook)
+	assert.Equal(t, string(extensionhook.StagePreInstall), addon.Status.ExtensionHook.Stage)
+	assert.Equal(t, "1.0.0", addon.Status.ExtensionHook.Version)
+	assert.Equal(t, "CHG-1", addon.Status.ExtensionHook.Annotations["example.com/ticket"])
+
+	// The hook is not called again for the same stage and version.
+	vetoed, err = r.handlePreOperationHook(context.Background(), addon)
+	require.NoError(t, err)
+	assert.False(t, vetoed)
+	hook.AssertNumberOfCalls(t, "Call", 1)
+}
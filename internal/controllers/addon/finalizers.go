@@ -0,0 +1,107 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Position of a finalizer within the cleanup of a deleted Addon.
+// Cleanups run serially in ascending order. Orders are spaced
+// apart, so new subsystems can be slotted in between existing ones.
+type finalizerOrder int
+
+const (
+	preDeleteHookFinalizerOrder  finalizerOrder = 100
+	cacheFinalizerOrder          finalizerOrder = 200
+	monitoringFinalizerOrder     finalizerOrder = 250
+	deadMansSnitchFinalizerOrder finalizerOrder = 260
+	pagerDutyFinalizerOrder      finalizerOrder = 270
+	namespaceFinalizerOrder      finalizerOrder = 290
+	postDeleteHookFinalizerOrder finalizerOrder = 300
+)
+
+// Cleanup of a subsystem, guarded by a finalizer of its own
+// on every Addon the subsystem applies to.
+type addonFinalizer interface {
+	// Finalizer added to Addons.
+	Finalizer() string
+	Order() finalizerOrder
+	// Whether the subsystem has anything to clean up after the given Addon.
+	// The finalizer is removed from Addons it no longer applies to.
+	AppliesTo(addon *addonsv1alpha1.Addon) bool
+	// Cleans up after a deleted Addon, before its finalizer is removed.
+	// Returns false to keep the finalizer until a later reconcile,
+	// e.g. while waiting for a Job to complete.
+	Finalize(ctx context.Context, addon *addonsv1alpha1.Addon) (done bool, err error)
+}
+
+// Registers a finalizer and sorts all finalizers by their order.
+func (r *AddonReconciler) registerFinalizer(finalizer addonFinalizer) {
+	r.finalizers = append(r.finalizers, finalizer)
+	sort.SliceStable(r.finalizers, func(i, j int) bool {
+		return r.finalizers[i].Order() < r.finalizers[j].Order()
+	})
+}
+
+// Adds the finalizers of all registered subsystems applying to the Addon
+// and removes the finalizers of subsystems no longer applying to it.
+func (r *AddonReconciler) ensureFinalizers(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	var changed bool
+	for _, f := range r.finalizers {
+		if f.AppliesTo(addon) {
+			changed = controllerutil.AddFinalizer(addon, f.Finalizer()) || changed
+			continue
+		}
+		changed = controllerutil.RemoveFinalizer(addon, f.Finalizer()) || changed
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := r.Update(ctx, addon); err != nil {
+		return fmt.Errorf("failed to update finalizers: %w", err)
+	}
+	return nil
+}
+
+// Whether the Addon still carries any of the registered finalizers.
+func (r *AddonReconciler) hasFinalizers(addon *addonsv1alpha1.Addon) bool {
+	for _, f := range r.finalizers {
+		if controllerutil.ContainsFinalizer(addon, f.Finalizer()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Runs the cleanup of each subsystem in order,
+// removing its finalizer as soon as the cleanup is done.
+// Stops at the first cleanup that is not done yet.
+func (r *AddonReconciler) runFinalizers(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	for _, f := range r.finalizers {
+		if !controllerutil.ContainsFinalizer(addon, f.Finalizer()) {
+			continue
+		}
+
+		done, err := f.Finalize(ctx, addon)
+		if err != nil {
+			return fmt.Errorf("finalizing %s: %w", f.Finalizer(), err)
+		}
+		if !done {
+			return nil
+		}
+
+		controllerutil.RemoveFinalizer(addon, f.Finalizer())
+		if err := r.Update(ctx, addon); err != nil {
+			return fmt.Errorf("failed to remove finalizer %s: %w", f.Finalizer(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type testFinalizer struct {
+	name       string
+	order      finalizerOrder
+	notApplies bool
+	done       bool
+	err        error
+	calls      *[]string
+}
+
+func (f *testFinalizer) Finalizer() string                    { return f.name }
+func (f *testFinalizer) Order() finalizerOrder                { return f.order }
+func (f *testFinalizer) AppliesTo(*addonsv1alpha1.Addon) bool { return !f.notApplies }
+
+func (f *testFinalizer) Finalize(context.Context, *addonsv1alpha1.Addon) (bool, error) {
+	*f.calls = append(*f.calls, f.name)
+	return f.done, f.err
+}
+
+func TestEnsureFinalizers(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	c := testutil.NewClient()
+	r := &AddonReconciler{Client: c}
+	r.registerFinalizer(&testFinalizer{name: "test/b", order: 200, calls: &calls})
+	r.registerFinalizer(&testFinalizer{name: "test/a", order: 100, calls: &calls})
+	r.registerFinalizer(&testFinalizer{name: "test/c", order: 300, notApplies: true, calls: &calls})
+
+	c.On("Update", testutil.IsContext, mock.IsType(&addonsv1alpha1.Addon{}), mock.Anything).
+		Return(nil).
+		Once()
+
+	// Finalizers no longer applying to the Addon are removed.
+	addon := &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"test/c", "other/finalizer"}},
+	}
+	require.NoError(t, r.ensureFinalizers(context.Background(), addon))
+	assert.Equal(t, []string{"other/finalizer", "test/a", "test/b"}, addon.Finalizers)
+
+	// Nothing to update, when all finalizers are present.
+	require.NoError(t, r.ensureFinalizers(context.Background(), addon))
+	c.AssertExpectations(t)
+}
+
+func TestRunFinalizers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs in order", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		c := testutil.NewClient()
+		r := &AddonReconciler{Client: c}
+		r.registerFinalizer(&testFinalizer{name: "test/c", order: 300, done: true, calls: &calls})
+		r.registerFinalizer(&testFinalizer{name: "test/a", order: 100, done: true, calls: &calls})
+		r.registerFinalizer(&testFinalizer{name: "test/b", order: 200, done: true, calls: &calls})
+
+		c.On("Update", testutil.IsContext, mock.IsType(&addonsv1alpha1.Addon{}), mock.Anything).
+			Return(nil).
+			Times(2)
+
+		addon := &addonsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"test/c", "test/a"}},
+		}
+		require.NoError(t, r.runFinalizers(context.Background(), addon))
+		c.AssertExpectations(t)
+
+		// Cleanups of finalizers no longer present are skipped.
+		assert.Equal(t, []string{"test/a", "test/c"}, calls)
+		assert.Empty(t, addon.Finalizers)
+	})
+
+	t.Run("stops at unfinished cleanup", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		c := testutil.NewClient()
+		r := &AddonReconciler{Client: c}
+		r.registerFinalizer(&testFinalizer{name: "test/a", order: 100, calls: &calls})
+		r.registerFinalizer(&testFinalizer{name: "test/b", order: 200, done: true, calls: &calls})
+
+		addon := &addonsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"test/a", "test/b"}},
+		}
+		require.NoError(t, r.runFinalizers(context.Background(), addon))
+		c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+
+		assert.Equal(t, []string{"test/a"}, calls)
+		assert.True(t, r.hasFinalizers(addon))
+	})
+
+	t.Run("stops at failed cleanup", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		c := testutil.NewClient()
+		r := &AddonReconciler{Client: c}
+		r.registerFinalizer(&testFinalizer{
+			name: "test/a", order: 100, err: errors.New("explosion"), calls: &calls,
+		})
+		r.registerFinalizer(&testFinalizer{name: "test/b", order: 200, done: true, calls: &calls})
+
+		addon := &addonsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"test/a", "test/b"}},
+		}
+		require.Error(t, r.runFinalizers(context.Background(), addon))
+		assert.Equal(t, []string{"test/a"}, calls)
+		assert.Equal(t, []string{"test/a", "test/b"}, addon.Finalizers)
+	})
+}
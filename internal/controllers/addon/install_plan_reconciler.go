@@ -0,0 +1,172 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const INSTALL_PLAN_RECONCILER_NAME = "installPlanReconciler"
+
+// Approves InstallPlans of Addons with Manual InstallPlan approval,
+// if they install the CSV of the version pinned in .spec.version,
+// or the known good CSV an Addon is rolled back to.
+// This keeps OLM from jumping to a newer bundle in the catalog mid-rollout.
+type installPlanReconciler struct {
+	client client.Client
+}
+
+func (r *installPlanReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	log := controllers.LoggerFromContext(ctx)
+
+	if addon.Spec.Install.Type == addonsv1alpha1.PackageOperator {
+		return ctrl.Result{}, nil
+	}
+	commonInstallOptions := GetCommonInstallOptions(addon)
+	if commonInstallOptions.InstallPlanApproval != addonsv1alpha1.InstallPlanApprovalManual {
+		return ctrl.Result{}, nil
+	}
+
+	installPlans := &operatorsv1alpha1.InstallPlanList{}
+	if err := r.client.List(ctx, installPlans,
+		client.InNamespace(commonInstallOptions.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing InstallPlans: %w", err)
+	}
+
+	var mismatchedCSVs []string
+	for i := range installPlans.Items {
+		installPlan := &installPlans.Items[i]
+		if installPlan.Spec.Approved ||
+			!ownedBySubscription(installPlan, SubscriptionName(addon)) {
+			continue
+		}
+
+		if !installsVersion(installPlan, commonInstallOptions.PackageName, addon.Spec.Version) &&
+			!installsCSV(installPlan, rollbackTargetCSVName(addon)) {
+			mismatchedCSVs = append(mismatchedCSVs, installPlan.Spec.ClusterServiceVersionNames...)
+			continue
+		}
+
+		installPlan.Spec.Approved = true
+		if err := r.client.Update(ctx, installPlan); err != nil {
+			return ctrl.Result{}, fmt.Errorf("approving InstallPlan: %w", err)
+		}
+		log.Info("approved InstallPlan", "installPlan", installPlan.Name, "version", addon.Spec.Version)
+	}
+
+	if len(mismatchedCSVs) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("not approving InstallPlans of unpinned versions",
+		"csvs", mismatchedCSVs, "version", addon.Spec.Version)
+	if meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed) {
+		// Installed Addons keep running their current CSV.
+		return ctrl.Result{}, nil
+	}
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonInstallPlanVersionMismatch,
+		fmt.Sprintf("InstallPlan for %s does not match version %q.",
+			strings.Join(mismatchedCSVs, ", "), addon.Spec.Version))
+	return handleExit(resultRetry), nil
+}
+
+func (r *installPlanReconciler) Name() string {
+	return INSTALL_PLAN_RECONCILER_NAME
+}
+
+func (r *installPlanReconciler) Order() subReconcilerOrder {
+	return installPlanReconcilerOrder
+}
+
+func (r *installPlanReconciler) ConditionType() string {
+	return addonsv1alpha1.InstallPlansReady
+}
+
+func (r *installPlanReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Install.Type != addonsv1alpha1.PackageOperator &&
+		GetCommonInstallOptions(addon).InstallPlanApproval == addonsv1alpha1.InstallPlanApprovalManual
+}
+
+// OLM sets owner references to the Subscriptions an InstallPlan was created for.
+func ownedBySubscription(installPlan *operatorsv1alpha1.InstallPlan, subscriptionName string) bool {
+	for _, ref := range installPlan.OwnerReferences {
+		if ref.Kind == operatorsv1alpha1.SubscriptionKind && ref.Name == subscriptionName {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true, if the InstallPlan installs the CSV of the given package in the given version.
+// CSVs of other packages, e.g. dependencies resolved by OLM, may be of any version.
+// CSV names are expected to follow the "<package>.v<version>" convention.
+func installsVersion(installPlan *operatorsv1alpha1.InstallPlan, packageName, version string) bool {
+	if len(version) == 0 {
+		return false
+	}
+
+	for _, csvName := range installPlan.Spec.ClusterServiceVersionNames {
+		if strings.HasPrefix(csvName, packageName+".") && csvNameHasVersion(csvName, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true, if the InstallPlan installs the named CSV.
+func installsCSV(installPlan *operatorsv1alpha1.InstallPlan, csvName string) bool {
+	if len(csvName) == 0 {
+		return false
+	}
+
+	for _, name := range installPlan.Spec.ClusterServiceVersionNames {
+		if name == csvName {
+			return true
+		}
+	}
+	return false
+}
+
+// Maps InstallPlans to the Addon controlling the Subscription they were created for,
+// so InstallPlans are approved as soon as OLM creates them.
+func (r *AddonReconciler) enqueueInstallPlanAddon(obj client.Object) []reconcile.Request {
+	var reqs []reconcile.Request
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind != operatorsv1alpha1.SubscriptionKind {
+			continue
+		}
+
+		subscription := &operatorsv1alpha1.Subscription{}
+		if err := r.Get(context.Background(), client.ObjectKey{
+			Name:      ref.Name,
+			Namespace: obj.GetNamespace(),
+		}, subscription); err != nil {
+			continue
+		}
+		if owner := metav1.GetControllerOf(subscription); owner != nil && owner.Kind == "Addon" {
+			reqs = append(reqs, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: owner.Name},
+			})
+		}
+	}
+	return reqs
+}
+
+// Returns true, if the CSV name ends in the given version.
+func csvNameHasVersion(csvName, version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	return strings.HasSuffix(csvName, ".v"+version) ||
+		strings.HasSuffix(csvName, "."+version)
+}
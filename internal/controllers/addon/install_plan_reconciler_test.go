@@ -0,0 +1,207 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestInstallPlanReconciler_AutomaticApproval(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &installPlanReconciler{client: c}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	res, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.AssertExpectations(t)
+}
+
+func TestInstallPlanReconciler_ManualApproval(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		CSVName          string
+		Installed        bool
+		RollbackCSV      string
+		ExpectedApproved bool
+		ExpectedRequeue  bool
+	}{
+		"pinned version": {
+			CSVName:          "reference-addon.v0.2.0",
+			ExpectedApproved: true,
+		},
+		"other version during install": {
+			CSVName:         "reference-addon.v0.3.0",
+			ExpectedRequeue: true,
+		},
+		"other version after install": {
+			CSVName:   "reference-addon.v0.3.0",
+			Installed: true,
+		},
+		"rollback target": {
+			CSVName:          "reference-addon.v0.1.0",
+			Installed:        true,
+			RollbackCSV:      "addon-1/reference-addon.v0.1.0",
+			ExpectedApproved: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &installPlanReconciler{client: c}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Version = "0.2.0"
+			addon.Spec.Install.OLMOwnNamespace.PackageName = "reference-addon"
+			addon.Spec.Install.OLMOwnNamespace.InstallPlanApproval = addonsv1alpha1.InstallPlanApprovalManual
+			if tc.Installed {
+				reportInstalledCondition(addon)
+			}
+			if len(tc.RollbackCSV) > 0 {
+				addon.Spec.Rollback = true
+				addon.Status.LastKnownGood = &addonsv1alpha1.AddonKnownGoodStatus{CSV: tc.RollbackCSV}
+			}
+
+			c.On("List", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.InstallPlanList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*operatorsv1alpha1.InstallPlanList)
+					list.Items = []operatorsv1alpha1.InstallPlan{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "install-1",
+								Namespace: "addon-1",
+								OwnerReferences: []metav1.OwnerReference{
+									{Kind: operatorsv1alpha1.SubscriptionKind, Name: SubscriptionName(addon)},
+								},
+							},
+							Spec: operatorsv1alpha1.InstallPlanSpec{
+								ClusterServiceVersionNames: []string{tc.CSVName},
+								Approval:                   operatorsv1alpha1.ApprovalManual,
+							},
+						},
+						{
+							// InstallPlan of another Subscription in the same namespace.
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "install-2",
+								Namespace: "addon-1",
+								OwnerReferences: []metav1.OwnerReference{
+									{Kind: operatorsv1alpha1.SubscriptionKind, Name: "other"},
+								},
+							},
+							Spec: operatorsv1alpha1.InstallPlanSpec{
+								ClusterServiceVersionNames: []string{"other.v0.2.0"},
+								Approval:                   operatorsv1alpha1.ApprovalManual,
+							},
+						},
+					}
+				}).
+				Return(nil)
+			var approved *operatorsv1alpha1.InstallPlan
+			c.On("Update", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.InstallPlan{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					approved = args.Get(1).(*operatorsv1alpha1.InstallPlan)
+				}).
+				Return(nil)
+
+			res, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.ExpectedApproved {
+				require.NotNil(t, approved)
+				assert.Equal(t, "install-1", approved.Name)
+				assert.True(t, approved.Spec.Approved)
+			} else {
+				c.AssertNotCalled(t, "Update", testutil.IsContext, mock.Anything, mock.Anything)
+			}
+
+			assert.Equal(t, tc.ExpectedRequeue, !res.IsZero())
+			if tc.ExpectedRequeue {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonInstallPlanVersionMismatch, cond.Reason)
+			}
+		})
+	}
+}
+
+func TestInstallsVersion(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		CSVNames []string
+		Version  string
+		Expected bool
+	}{
+		"matching":          {CSVNames: []string{"reference-addon.v0.2.0"}, Version: "0.2.0", Expected: true},
+		"prefixed version":  {CSVNames: []string{"reference-addon.v0.2.0"}, Version: "v0.2.0", Expected: true},
+		"unprefixed csv":    {CSVNames: []string{"reference-addon.0.2.0"}, Version: "0.2.0", Expected: true},
+		"other version":     {CSVNames: []string{"reference-addon.v0.12.0"}, Version: "2.0", Expected: false},
+		"no version pinned": {CSVNames: []string{"reference-addon.v0.2.0"}, Version: "", Expected: false},
+		"with dependency": {
+			CSVNames: []string{"reference-addon.v0.2.0", "dep.v1.0.0"}, Version: "0.2.0", Expected: true,
+		},
+		"only other package": {CSVNames: []string{"dep.v0.2.0"}, Version: "0.2.0", Expected: false},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			installPlan := &operatorsv1alpha1.InstallPlan{
+				Spec: operatorsv1alpha1.InstallPlanSpec{ClusterServiceVersionNames: tc.CSVNames},
+			}
+			assert.Equal(t, tc.Expected, installsVersion(installPlan, "reference-addon", tc.Version))
+		})
+	}
+}
+
+func TestEnqueueInstallPlanAddon(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &AddonReconciler{Client: c}
+
+	c.On("Get", testutil.IsContext, client.ObjectKey{Name: "addon-1", Namespace: "addon-1"},
+		mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			sub := args.Get(2).(*operatorsv1alpha1.Subscription)
+			sub.OwnerReferences = []metav1.OwnerReference{
+				{Kind: "Addon", Name: "addon-1", Controller: pointer.Bool(true)},
+			}
+		}).
+		Return(nil)
+
+	installPlan := &operatorsv1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "install-1",
+			Namespace: "addon-1",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: operatorsv1alpha1.SubscriptionKind, Name: "addon-1"},
+			},
+		},
+	}
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "addon-1"}},
+	}, r.enqueueInstallPlanAddon(installPlan))
+}
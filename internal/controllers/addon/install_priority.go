@@ -0,0 +1,143 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Field index of Addons by whether they are installed and Available.
+const installStateIndexKey = ".status.installState"
+
+const (
+	installStateNotInstalled = "NotInstalled"
+	installStateNotAvailable = "NotAvailable"
+)
+
+// Paused and deleted Addons are not indexed,
+// they would hold back installs indefinitely.
+func indexAddonInstallState(obj client.Object) []string {
+	addon, ok := obj.(*addonsv1alpha1.Addon)
+	if !ok || addon.Spec.Paused || pausedByAnnotation(addon) || !addon.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	var states []string
+	if !meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed) {
+		states = append(states, installStateNotInstalled)
+	}
+	if !meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Available) {
+		states = append(states, installStateNotAvailable)
+	}
+	return states
+}
+
+func setupInstallStateIndex(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(
+		context.Background(), &addonsv1alpha1.Addon{}, installStateIndexKey, indexAddonInstallState)
+}
+
+// Holds back the install of the Addon while Addons with a higher
+// install priority are not yet Available. Addons failing to install
+// within their install timeout no longer hold back other installs.
+// Returns true and when to check again, if the Addon has to wait.
+func (r *AddonReconciler) waitForHigherPriorityAddons(
+	ctx context.Context, addon *addonsv1alpha1.Addon, now time.Time,
+) (waiting bool, requeueAfter time.Duration, err error) {
+	if meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed) {
+		return false, 0, nil
+	}
+
+	addonList := &addonsv1alpha1.AddonList{}
+	if err := r.List(ctx, addonList, client.MatchingFields{
+		installStateIndexKey: installStateNotAvailable,
+	}); err != nil {
+		return false, 0, fmt.Errorf("listing Addons: %w", err)
+	}
+
+	var pending []string
+	for i := range addonList.Items {
+		other := &addonList.Items[i]
+		if other.Spec.InstallPriority <= addon.Spec.InstallPriority {
+			continue
+		}
+		deadline, blocking := installPriorityDeadline(other)
+		if !blocking || !now.Before(deadline) {
+			continue
+		}
+		if until := deadline.Sub(now); requeueAfter == 0 || until < requeueAfter {
+			requeueAfter = until
+		}
+		pending = append(pending, other.Name)
+	}
+	if len(pending) == 0 {
+		return false, 0, nil
+	}
+
+	sort.Strings(pending)
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonWaitingForHigherPriority,
+		fmt.Sprintf("Waiting for Addons with a higher install priority: %s", strings.Join(pending, ", ")))
+	return true, requeueAfter, nil
+}
+
+// Returns until when the given not yet Available Addon holds back
+// the install of Addons with a lower install priority.
+// Addons, which timed out installing, do not hold back other installs.
+func installPriorityDeadline(addon *addonsv1alpha1.Addon) (deadline time.Time, blocking bool) {
+	if meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.InstallTimedOut) {
+		return time.Time{}, false
+	}
+	return addon.CreationTimestamp.Add(installTimeout(addon)), true
+}
+
+// Whether the Addon still holds back the install of Addons with a lower install priority.
+// Addons exceeding their install deadline are not considered,
+// as Addons waiting for them requeue themselves at the deadline.
+func holdsBackLowerPriorityAddons(addon *addonsv1alpha1.Addon) bool {
+	for _, state := range indexAddonInstallState(addon) {
+		if state == installStateNotAvailable {
+			_, blocking := installPriorityDeadline(addon)
+			return blocking
+		}
+	}
+	return false
+}
+
+// Enqueues all Addons not yet installed with a lower install priority
+// than the given Addon, so they are reconciled as soon as it
+// no longer holds back their install.
+func (r *AddonReconciler) enqueueLowerPriorityAddons(obj client.Object) []reconcile.Request {
+	addon, ok := obj.(*addonsv1alpha1.Addon)
+	if !ok || holdsBackLowerPriorityAddons(addon) {
+		return nil
+	}
+
+	addonList := &addonsv1alpha1.AddonList{}
+	if err := r.List(context.Background(), addonList, client.MatchingFields{
+		installStateIndexKey: installStateNotInstalled,
+	}); err != nil {
+		r.Log.Error(err, "listing Addons to requeue by install priority")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, other := range addonList.Items {
+		if other.Spec.InstallPriority >= addon.Spec.InstallPriority {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: other.Name},
+		})
+	}
+	return reqs
+}
@@ -0,0 +1,182 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestWaitForHigherPriorityAddons(t *testing.T) {
+	t.Parallel()
+
+	available := metav1.Condition{
+		Type:   addonsv1alpha1.Available,
+		Status: metav1.ConditionTrue,
+	}
+
+	now := time.Now()
+	installTimedOut := metav1.Condition{
+		Type:   addonsv1alpha1.InstallTimedOut,
+		Status: metav1.ConditionTrue,
+	}
+
+	for name, tc := range map[string]struct {
+		Others               []addonsv1alpha1.Addon
+		ExpectedWaiting      bool
+		ExpectedRequeueAfter time.Duration
+	}{
+		"no other Addons": {},
+		"higher priority Addon available": {
+			Others: []addonsv1alpha1.Addon{
+				newPriorityTestAddon("logging", 10, available),
+			},
+		},
+		"higher priority Addon not available": {
+			Others: []addonsv1alpha1.Addon{
+				newPriorityTestAddon("logging", 10),
+			},
+			ExpectedWaiting:      true,
+			ExpectedRequeueAfter: defaultInstallTimeout - time.Minute,
+		},
+		"higher priority Addon timed out installing": {
+			Others: []addonsv1alpha1.Addon{
+				newPriorityTestAddon("logging", 10, installTimedOut),
+			},
+		},
+		"higher priority Addon exceeded install timeout": {
+			Others: func() []addonsv1alpha1.Addon {
+				a := newPriorityTestAddon("logging", 10)
+				a.CreationTimestamp = metav1.NewTime(now.Add(-defaultInstallTimeout))
+				return []addonsv1alpha1.Addon{a}
+			}(),
+		},
+		"lower priority Addon not available": {
+			Others: []addonsv1alpha1.Addon{
+				newPriorityTestAddon("logging", -10),
+			},
+		},
+		"higher priority Addon paused": {
+			Others: func() []addonsv1alpha1.Addon {
+				a := newPriorityTestAddon("logging", 10)
+				a.Spec.Paused = true
+				return []addonsv1alpha1.Addon{a}
+			}(),
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			others := make([]addonsv1alpha1.Addon, len(tc.Others))
+			for i, other := range tc.Others {
+				if other.CreationTimestamp.IsZero() {
+					other.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+				}
+				others[i] = other
+			}
+			c := newInstallStateIndexTestClient(others)
+
+			r := &AddonReconciler{Client: c}
+			addon := newPriorityTestAddon("backup", 0)
+
+			waiting, requeueAfter, err := r.waitForHigherPriorityAddons(context.Background(), &addon, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedWaiting, waiting)
+			assert.Equal(t, tc.ExpectedRequeueAfter, requeueAfter)
+
+			if tc.ExpectedWaiting {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonWaitingForHigherPriority, cond.Reason)
+			}
+		})
+	}
+}
+
+func TestWaitForHigherPriorityAddons_Installed(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &AddonReconciler{Client: c}
+
+	addon := newPriorityTestAddon("backup", 0, metav1.Condition{
+		Type:   addonsv1alpha1.Installed,
+		Status: metav1.ConditionTrue,
+	})
+
+	waiting, _, err := r.waitForHigherPriorityAddons(context.Background(), &addon, time.Now())
+	require.NoError(t, err)
+	assert.False(t, waiting)
+	c.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnqueueLowerPriorityAddons(t *testing.T) {
+	t.Parallel()
+
+	c := newInstallStateIndexTestClient([]addonsv1alpha1.Addon{
+		newPriorityTestAddon("logging", 10),
+		newPriorityTestAddon("backup", 0),
+		newPriorityTestAddon("installed", 0, metav1.Condition{
+			Type:   addonsv1alpha1.Installed,
+			Status: metav1.ConditionTrue,
+		}),
+	})
+
+	r := &AddonReconciler{Client: c}
+
+	// Addons not yet Available still hold back lower priority Addons.
+	logging := newPriorityTestAddon("logging", 10)
+	assert.Empty(t, r.enqueueLowerPriorityAddons(&logging))
+
+	logging = newPriorityTestAddon("logging", 10, metav1.Condition{
+		Type:   addonsv1alpha1.Available,
+		Status: metav1.ConditionTrue,
+	})
+	reqs := r.enqueueLowerPriorityAddons(&logging)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "backup", reqs[0].Name)
+}
+
+// Returns a client listing the given Addons matching the install state index.
+func newInstallStateIndexTestClient(addons []addonsv1alpha1.Addon) *testutil.Client {
+	c := testutil.NewClient()
+	c.On("List", mock.Anything, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*addonsv1alpha1.AddonList)
+			fields := args.Get(2).([]client.ListOption)[0].(client.MatchingFields)
+			list.Items = nil
+			for i := range addons {
+				for _, state := range indexAddonInstallState(&addons[i]) {
+					if state == fields[installStateIndexKey] {
+						list.Items = append(list.Items, addons[i])
+					}
+				}
+			}
+		}).
+		Return(nil).
+		Maybe()
+	return c
+}
+
+func newPriorityTestAddon(name string, priority int32, conds ...metav1.Condition) addonsv1alpha1.Addon {
+	return addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: addonsv1alpha1.AddonSpec{
+			InstallPriority: priority,
+		},
+		Status: addonsv1alpha1.AddonStatus{
+			Conditions: conds,
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const (
+	// Reinstalls attempted for a failed CSV, before it is left for manual intervention.
+	maxReinstallAttempts = 5
+	// Time to wait after the first reinstall, doubled after each further attempt.
+	reinstallBaseBackoff = time.Minute
+)
+
+// Deletes the Subscription and the failed CSV of Addons
+// with the ReinstallOnFailure recovery policy, so OLM installs the operator again.
+// Attempts are bounded and backed off exponentially.
+func (r *olmReconciler) recoverFailedCSV(
+	ctx context.Context, addon *addonsv1alpha1.Addon, csvKey client.ObjectKey,
+) error {
+	if addon.Spec.Install.Recovery != addonsv1alpha1.InstallRecoveryReinstallOnFailure {
+		return nil
+	}
+
+	log := controllers.LoggerFromContext(ctx)
+	now := r.clock.Now()
+	status := addon.Status.Recovery
+	if status == nil {
+		status = &addonsv1alpha1.AddonRecoveryStatus{}
+	}
+	if status.Attempts >= maxReinstallAttempts {
+		log.Info("not reinstalling failed CSV, attempts exhausted",
+			"csv", csvKey.String(), "attempts", status.Attempts)
+		return nil
+	}
+	if status.LastAttemptTime != nil &&
+		now.Before(status.LastAttemptTime.Add(reinstallBackoff(status.Attempts))) {
+		return nil
+	}
+
+	if err := r.deleteSubscriptionAndCSV(ctx, addon, csvKey); err != nil {
+		return err
+	}
+
+	status.Attempts++
+	status.LastAttemptTime = &metav1.Time{Time: now}
+	status.FailedCSV = csvKey.Name
+	addon.Status.Recovery = status
+	log.Info("reinstalling failed CSV", "csv", csvKey.String(), "attempt", status.Attempts)
+	return nil
+}
+
+// Deletes the Subscription of the Addon and the given CSV,
+// so OLM installs the operator again, once the Subscription is recreated.
+func (r *olmReconciler) deleteSubscriptionAndCSV(
+	ctx context.Context, addon *addonsv1alpha1.Addon, csvKey client.ObjectKey,
+) error {
+	subscription := &operatorsv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SubscriptionName(addon),
+			Namespace: csvKey.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, subscription); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting Subscription: %w", err)
+	}
+	csv := &operatorsv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      csvKey.Name,
+			Namespace: csvKey.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, csv); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting CSV: %w", err)
+	}
+	return nil
+}
+
+// Time to wait after the given number of reinstall attempts before the next one.
+func reinstallBackoff(attempts int32) time.Duration {
+	if attempts <= 1 {
+		return reinstallBaseBackoff
+	}
+	return reinstallBaseBackoff << (attempts - 1)
+}
+
+func failedCSVMessage(addon *addonsv1alpha1.Addon) string {
+	status := addon.Status.Recovery
+	if addon.Spec.Install.Recovery != addonsv1alpha1.InstallRecoveryReinstallOnFailure ||
+		status == nil {
+		return "failed"
+	}
+	if status.Attempts >= maxReinstallAttempts {
+		return fmt.Sprintf("failed, giving up after %d reinstall attempts", status.Attempts)
+	}
+	return fmt.Sprintf("failed, reinstall attempt %d of %d", status.Attempts, maxReinstallAttempts)
+}
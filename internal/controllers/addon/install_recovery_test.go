@@ -0,0 +1,113 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestRecoverFailedCSV(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	csvKey := client.ObjectKey{Name: "operator.v1.0.0", Namespace: "addon-1"}
+
+	for name, tc := range map[string]struct {
+		Policy            addonsv1alpha1.AddonInstallRecoveryPolicy
+		Status            *addonsv1alpha1.AddonRecoveryStatus
+		ExpectedReinstall bool
+		ExpectedMessage   string
+	}{
+		"no recovery": {
+			Policy:          addonsv1alpha1.InstallRecoveryNone,
+			ExpectedMessage: "failed",
+		},
+		"first attempt": {
+			Policy:            addonsv1alpha1.InstallRecoveryReinstallOnFailure,
+			ExpectedReinstall: true,
+			ExpectedMessage:   "failed, reinstall attempt 1 of 5",
+		},
+		"backing off": {
+			Policy: addonsv1alpha1.InstallRecoveryReinstallOnFailure,
+			Status: &addonsv1alpha1.AddonRecoveryStatus{
+				Attempts:        2,
+				LastAttemptTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			ExpectedMessage: "failed, reinstall attempt 2 of 5",
+		},
+		"backoff expired": {
+			Policy: addonsv1alpha1.InstallRecoveryReinstallOnFailure,
+			Status: &addonsv1alpha1.AddonRecoveryStatus{
+				Attempts:        2,
+				LastAttemptTime: &metav1.Time{Time: now.Add(-2 * time.Minute)},
+			},
+			ExpectedReinstall: true,
+			ExpectedMessage:   "failed, reinstall attempt 3 of 5",
+		},
+		"attempts exhausted": {
+			Policy: addonsv1alpha1.InstallRecoveryReinstallOnFailure,
+			Status: &addonsv1alpha1.AddonRecoveryStatus{
+				Attempts:        maxReinstallAttempts,
+				LastAttemptTime: &metav1.Time{Time: now.Add(-24 * time.Hour)},
+			},
+			ExpectedMessage: "failed, giving up after 5 reinstall attempts",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			clock := &testClock{}
+			clock.On("Now").Return(now)
+			r := &olmReconciler{client: c, clock: clock}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Install.Recovery = tc.Policy
+			addon.Status.Recovery = tc.Status
+
+			var deleted []string
+			c.On("Delete", testutil.IsContext, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					obj := args.Get(1).(client.Object)
+					deleted = append(deleted, obj.GetName())
+				}).
+				Return(nil)
+
+			require.NoError(t, r.recoverFailedCSV(context.Background(), addon, csvKey))
+			assert.Equal(t, tc.ExpectedMessage, failedCSVMessage(addon))
+
+			if !tc.ExpectedReinstall {
+				c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, []string{SubscriptionName(addon), csvKey.Name}, deleted)
+			c.AssertCalled(t, "Delete", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything)
+			c.AssertCalled(t, "Delete", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything)
+			require.NotNil(t, addon.Status.Recovery)
+			assert.Equal(t, now, addon.Status.Recovery.LastAttemptTime.Time)
+			assert.Equal(t, csvKey.Name, addon.Status.Recovery.FailedCSV)
+		})
+	}
+}
+
+func TestReinstallBackoff(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Minute, reinstallBackoff(1))
+	assert.Equal(t, 2*time.Minute, reinstallBackoff(2))
+	assert.Equal(t, 8*time.Minute, reinstallBackoff(4))
+}
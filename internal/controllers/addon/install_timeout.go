@@ -0,0 +1,130 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Default time an Addon may take to install, before it is reported as timed out.
+const defaultInstallTimeout = 30 * time.Minute
+
+// Reports the InstallTimedOut condition on OLM Addons, whose CSV
+// has not succeeded within the install timeout since their installation started.
+// Requeues Addons still installing when their timeout expires.
+func (r *AddonReconciler) handleInstallTimeout(
+	ctx context.Context, addon *addonsv1alpha1.Addon, now time.Time) (ctrl.Result, error) {
+	installed := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Installed)
+	if addon.Spec.Install.Type == addonsv1alpha1.PackageOperator ||
+		installed == nil || installed.Status == metav1.ConditionTrue {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.InstallTimedOut)
+		return ctrl.Result{}, nil
+	}
+
+	timeout := installTimeout(addon)
+	if deadline := installed.LastTransitionTime.Add(timeout); now.Before(deadline) {
+		return ctrl.Result{RequeueAfter: deadline.Sub(now)}, nil
+	}
+
+	failingPods, err := r.listFailingPods(ctx, addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	reportInstallTimedOut(addon, timeout, failingPods)
+	return ctrl.Result{}, nil
+}
+
+func installTimeout(addon *addonsv1alpha1.Addon) time.Duration {
+	v, ok := addon.Annotations[addonsv1alpha1.InstallTimeoutAnnotation]
+	if !ok {
+		return defaultInstallTimeout
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return defaultInstallTimeout
+	}
+	return d
+}
+
+// Lists pods in the Addon namespace that failed or can not start,
+// formatted as "name (reason)".
+func (r *AddonReconciler) listFailingPods(
+	ctx context.Context, addon *addonsv1alpha1.Addon) ([]string, error) {
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		return nil, nil
+	}
+
+	// Pods are not cached, so read them from the API server directly.
+	pods := &corev1.PodList{}
+	if err := r.UncachedClient.List(ctx, pods, client.InNamespace(commonConfig.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing pods: %w", err)
+	}
+
+	var failing []string
+	for i := range pods.Items {
+		if reason, ok := podFailureReason(&pods.Items[i]); ok {
+			failing = append(failing, fmt.Sprintf("%s (%s)", pods.Items[i].Name, reason))
+		}
+	}
+	return failing, nil
+}
+
+func podFailureReason(pod *corev1.Pod) (string, bool) {
+	if pod.Status.Phase == corev1.PodFailed {
+		return string(corev1.PodFailed), true
+	}
+
+	var statuses []corev1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		waiting := status.State.Waiting
+		if waiting == nil {
+			continue
+		}
+		switch waiting.Reason {
+		case "", "ContainerCreating", "PodInitializing":
+			// Still starting up.
+		default:
+			return waiting.Reason, true
+		}
+	}
+	return "", false
+}
+
+func reportInstallTimedOut(addon *addonsv1alpha1.Addon, timeout time.Duration, failingPods []string) {
+	msg := []string{fmt.Sprintf("Addon has not been installed within %s.", timeout)}
+	if olm := addon.Status.OLM; olm != nil {
+		csv := fmt.Sprintf("CSV %s is in phase %q", olm.InstalledCSV, olm.Phase)
+		if len(olm.Message) > 0 {
+			csv += ": " + olm.Message
+		}
+		msg = append(msg, csv+".")
+	} else {
+		msg = append(msg, "No CSV has been installed for the Subscription.")
+	}
+	if len(failingPods) > 0 {
+		msg = append(msg, fmt.Sprintf("Failing pods: %s.", strings.Join(failingPods, ", ")))
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.InstallTimedOut,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonCSVNotSucceeded,
+		Message:            strings.Join(msg, " "),
+		ObservedGeneration: addon.Generation,
+	})
+	addon.Status.ObservedGeneration = addon.Generation
+	reportPhase(addon, addonsv1alpha1.PhaseError)
+}
@@ -0,0 +1,142 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestHandleInstallTimeout(t *testing.T) {
+	t.Parallel()
+
+	started := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newInstallingAddon := func() *addonsv1alpha1.Addon {
+		addon := testutil.NewTestAddonWithCatalogSourceImage()
+		addon.Status.Phase = addonsv1alpha1.PhaseInstalling
+		addon.Status.Conditions = []metav1.Condition{{
+			Type:               addonsv1alpha1.Installed,
+			Status:             metav1.ConditionFalse,
+			Reason:             addonsv1alpha1.AddonReasonNotInstalled,
+			LastTransitionTime: metav1.NewTime(started),
+		}}
+		return addon
+	}
+
+	t.Run("requeues until the timeout expires", func(t *testing.T) {
+		t.Parallel()
+
+		c := testutil.NewClient()
+		r := &AddonReconciler{Client: c}
+		addon := newInstallingAddon()
+
+		res, err := r.handleInstallTimeout(context.Background(), addon, started.Add(10*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Minute, res.RequeueAfter)
+		assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.InstallTimedOut))
+		c.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("timeout from annotation", func(t *testing.T) {
+		t.Parallel()
+
+		r := &AddonReconciler{Client: testutil.NewClient()}
+		addon := newInstallingAddon()
+		addon.Annotations = map[string]string{
+			addonsv1alpha1.InstallTimeoutAnnotation: "1h",
+		}
+
+		res, err := r.handleInstallTimeout(context.Background(), addon, started.Add(40*time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Minute, res.RequeueAfter)
+	})
+
+	t.Run("reports timed out installation", func(t *testing.T) {
+		t.Parallel()
+
+		c := testutil.NewClient()
+		r := &AddonReconciler{Client: c, UncachedClient: c}
+		addon := newInstallingAddon()
+		addon.Status.OLM = &addonsv1alpha1.AddonOLMStatus{
+			InstalledCSV: "operator.v1.0.0",
+			Phase:        "Installing",
+			Message:      "waiting for install components to report healthy",
+		}
+
+		c.On("List", testutil.IsContext,
+			mock.IsType(&corev1.PodList{}), mock.Anything).
+			Run(func(args mock.Arguments) {
+				list := args.Get(1).(*corev1.PodList)
+				list.Items = []corev1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "operator-crashing"},
+						Status: corev1.PodStatus{
+							ContainerStatuses: []corev1.ContainerStatus{{
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+								},
+							}},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "operator-starting"},
+						Status: corev1.PodStatus{
+							ContainerStatuses: []corev1.ContainerStatus{{
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+								},
+							}},
+						},
+					},
+				}
+			}).
+			Return(nil)
+
+		res, err := r.handleInstallTimeout(context.Background(), addon, started.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, res.IsZero())
+		c.AssertExpectations(t)
+
+		cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.InstallTimedOut)
+		require.NotNil(t, cond)
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, addonsv1alpha1.AddonReasonCSVNotSucceeded, cond.Reason)
+		assert.Equal(t, "Addon has not been installed within 30m0s. "+
+			`CSV operator.v1.0.0 is in phase "Installing": waiting for install components to report healthy. `+
+			"Failing pods: operator-crashing (CrashLoopBackOff).", cond.Message)
+		assert.Equal(t, addonsv1alpha1.PhaseError, addon.Status.Phase)
+	})
+
+	t.Run("clears condition once installed", func(t *testing.T) {
+		t.Parallel()
+
+		r := &AddonReconciler{Client: testutil.NewClient()}
+		addon := newInstallingAddon()
+		addon.Status.Conditions = []metav1.Condition{
+			{
+				Type:   addonsv1alpha1.Installed,
+				Status: metav1.ConditionTrue,
+				Reason: addonsv1alpha1.AddonReasonInstalled,
+			},
+			{
+				Type:   addonsv1alpha1.InstallTimedOut,
+				Status: metav1.ConditionTrue,
+				Reason: addonsv1alpha1.AddonReasonCSVNotSucceeded,
+			},
+		}
+
+		res, err := r.handleInstallTimeout(context.Background(), addon, started.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, res.IsZero())
+		assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.InstallTimedOut))
+	})
+}
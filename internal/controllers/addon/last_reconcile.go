@@ -0,0 +1,79 @@
+package addon
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Phases stopping the reconcile before the sub-reconcilers run.
+const (
+	reconcilePhaseDeletion         = "deletion"
+	reconcilePhaseGlobalPause      = "globalPause"
+	reconcilePhasePause            = "pause"
+	reconcilePhaseMaintenance      = "maintenance"
+	reconcilePhaseInstallPriority  = "installPriority"
+	reconcilePhaseDependencies     = "dependencies"
+	reconcilePhasePreOperationHook = "preOperationHook"
+	reconcilePhaseUpgradeQueue     = "upgradeQueue"
+	reconcilePhaseUpgradeStarted   = "upgradeStarted"
+	reconcilePhaseFinalizers       = "finalizers"
+)
+
+// Minimum time between updates of .status.lastReconcile with an unchanged
+// outcome. Every status update triggers another reconcile of the Addon,
+// so refreshing it on each reconcile would never let the Addon settle.
+const lastReconcileRefreshInterval = time.Minute
+
+// Reports the phase stopping the current reconcile of the Addon.
+// A phase reported before by a sub-reconciler is qualified
+// by the phase of the sub-reconciler itself.
+func reportReconcileStopped(addon *addonsv1alpha1.Addon, phase string) {
+	if addon.Status.LastReconcile == nil {
+		addon.Status.LastReconcile = &addonsv1alpha1.AddonLastReconcileStatus{}
+	}
+	if stoppedAt := addon.Status.LastReconcile.StoppedAt; len(stoppedAt) > 0 {
+		phase += "/" + stoppedAt
+	}
+	addon.Status.LastReconcile.StoppedAt = phase
+}
+
+// Clears the phase reported to stop the current reconcile,
+// e.g. when the sub-reconciler reporting it did not stop the chain after all.
+func resetReconcileStopped(addon *addonsv1alpha1.Addon) {
+	addon.Status.LastReconcile = nil
+}
+
+// Reports the outcome of the reconcile started at the given time,
+// keeping the previous report if the outcome did not change recently.
+func reportLastReconcile(addon *addonsv1alpha1.Addon,
+	previous *addonsv1alpha1.AddonLastReconcileStatus,
+	start, now time.Time, err error) {
+	current := addon.Status.LastReconcile
+	if current == nil {
+		current = &addonsv1alpha1.AddonLastReconcileStatus{}
+	}
+	current.Time = metav1.NewTime(start)
+	current.Duration = metav1.Duration{Duration: now.Sub(start).Round(time.Millisecond)}
+	switch {
+	case err != nil:
+		current.Outcome = addonsv1alpha1.ReconcileOutcomeFailed
+		current.Message = err.Error()
+	case len(current.StoppedAt) > 0:
+		current.Outcome = addonsv1alpha1.ReconcileOutcomeStopped
+	default:
+		current.Outcome = addonsv1alpha1.ReconcileOutcomeCompleted
+	}
+
+	if previous != nil &&
+		previous.Outcome == current.Outcome &&
+		previous.StoppedAt == current.StoppedAt &&
+		previous.Message == current.Message &&
+		start.Sub(previous.Time.Time) < lastReconcileRefreshInterval {
+		addon.Status.LastReconcile = previous
+		return
+	}
+	addon.Status.LastReconcile = current
+}
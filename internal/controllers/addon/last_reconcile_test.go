@@ -0,0 +1,109 @@
+package addon
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+func TestReportReconcileStopped(t *testing.T) {
+	t.Parallel()
+
+	addon := &addonsv1alpha1.Addon{}
+	reportReconcileStopped(addon, olmPhaseSubscription)
+	reportReconcileStopped(addon, OLM_RECONCILER_NAME)
+
+	require.NotNil(t, addon.Status.LastReconcile)
+	assert.Equal(t, "olmReconciler/subscription", addon.Status.LastReconcile.StoppedAt)
+
+	resetReconcileStopped(addon)
+	assert.Nil(t, addon.Status.LastReconcile)
+}
+
+func TestReportLastReconcile(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC)
+	previous := &addonsv1alpha1.AddonLastReconcileStatus{
+		Time:      metav1.NewTime(start.Add(-10 * time.Second)),
+		Duration:  metav1.Duration{Duration: time.Second},
+		Outcome:   addonsv1alpha1.ReconcileOutcomeStopped,
+		StoppedAt: reconcilePhaseDependencies,
+	}
+
+	for name, tc := range map[string]struct {
+		Previous         *addonsv1alpha1.AddonLastReconcileStatus
+		StoppedAt        string
+		Start            time.Time
+		Err              error
+		ExpectedOutcome  addonsv1alpha1.AddonReconcileOutcome
+		ExpectedMessage  string
+		ExpectedPrevious bool
+	}{
+		"completed": {
+			Start:           start,
+			ExpectedOutcome: addonsv1alpha1.ReconcileOutcomeCompleted,
+		},
+		"stopped": {
+			StoppedAt:       reconcilePhaseMaintenance,
+			Start:           start,
+			ExpectedOutcome: addonsv1alpha1.ReconcileOutcomeStopped,
+		},
+		"failed": {
+			StoppedAt:       reconcilePhaseFinalizers,
+			Start:           start,
+			Err:             errors.New("boom"),
+			ExpectedOutcome: addonsv1alpha1.ReconcileOutcomeFailed,
+			ExpectedMessage: "boom",
+		},
+		"unchanged is kept": {
+			Previous:         previous,
+			StoppedAt:        reconcilePhaseDependencies,
+			Start:            start,
+			ExpectedOutcome:  addonsv1alpha1.ReconcileOutcomeStopped,
+			ExpectedPrevious: true,
+		},
+		"unchanged is refreshed after interval": {
+			Previous:        previous,
+			StoppedAt:       reconcilePhaseDependencies,
+			Start:           start.Add(lastReconcileRefreshInterval),
+			ExpectedOutcome: addonsv1alpha1.ReconcileOutcomeStopped,
+		},
+		"changed phase": {
+			Previous:        previous,
+			StoppedAt:       reconcilePhaseInstallPriority,
+			Start:           start,
+			ExpectedOutcome: addonsv1alpha1.ReconcileOutcomeStopped,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := &addonsv1alpha1.Addon{}
+			if tc.StoppedAt != "" {
+				reportReconcileStopped(addon, tc.StoppedAt)
+			}
+			reportLastReconcile(addon, tc.Previous, tc.Start, tc.Start.Add(1500*time.Millisecond), tc.Err)
+
+			lastReconcile := addon.Status.LastReconcile
+			require.NotNil(t, lastReconcile)
+			if tc.ExpectedPrevious {
+				assert.Same(t, tc.Previous, lastReconcile)
+				return
+			}
+			assert.Equal(t, tc.Start, lastReconcile.Time.Time)
+			assert.Equal(t, 1500*time.Millisecond, lastReconcile.Duration.Duration)
+			assert.Equal(t, tc.ExpectedOutcome, lastReconcile.Outcome)
+			assert.Equal(t, tc.StoppedAt, lastReconcile.StoppedAt)
+			assert.Equal(t, tc.ExpectedMessage, lastReconcile.Message)
+		})
+	}
+}
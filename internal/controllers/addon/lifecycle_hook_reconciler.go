@@ -0,0 +1,343 @@
+package addon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const LIFECYCLE_HOOK_RECONCILER_NAME = "lifecycleHookReconciler"
+
+// Marks Jobs running lifecycle hooks with the type of the hook.
+const lifecycleHookLabel = "addons.managed.openshift.io/lifecycle-hook"
+
+const defaultLifecycleHookBackoffLimit int32 = 3
+
+type lifecycleHookType string
+
+const (
+	preInstallHook lifecycleHookType = "pre-install"
+	preDeleteHook  lifecycleHookType = "pre-delete"
+)
+
+type lifecycleHookStatus int
+
+const (
+	lifecycleHookRunning lifecycleHookStatus = iota
+	lifecycleHookSucceeded
+	lifecycleHookFailed
+)
+
+// Runs the pre-install hook of Addons before they are installed.
+// The pre-delete hook is run by the deletion handling of the Addon.
+type lifecycleHookReconciler struct {
+	hooks *lifecycleHookRunner
+}
+
+func (r *lifecycleHookReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	hooks := addon.Spec.LifecycleHooks
+	if hooks == nil || hooks.PreInstall == nil ||
+		meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed) {
+		// The pre-install hook only runs once before the first install.
+		return ctrl.Result{}, nil
+	}
+
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		return ctrl.Result{}, nil
+	}
+
+	status, msg, err := r.hooks.run(ctx, addon, commonConfig.Namespace, preInstallHook, hooks.PreInstall)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("running pre-install hook: %w", err)
+	}
+
+	// Hook Jobs are watched, the retry only stops the following
+	// sub-reconcilers from installing the Addon.
+	switch status {
+	case lifecycleHookFailed:
+		reportHookFailed(addon, addonsv1alpha1.AddonReasonPreInstallHookFailed, msg)
+		reportPendingStatus(addon, addonsv1alpha1.AddonReasonPreInstallHookFailed, msg)
+		return handleExit(resultRetry), nil
+	case lifecycleHookRunning:
+		reportPendingStatus(addon, addonsv1alpha1.AddonReasonLifecycleHookRunning,
+			"waiting for pre-install hook to complete")
+		return handleExit(resultRetry), nil
+	}
+
+	removeHookFailedCondition(addon)
+	return ctrl.Result{}, nil
+}
+
+func (r *lifecycleHookReconciler) Name() string {
+	return LIFECYCLE_HOOK_RECONCILER_NAME
+}
+
+func (r *lifecycleHookReconciler) Order() subReconcilerOrder {
+	return lifecycleHookReconcilerOrder
+}
+
+func (r *lifecycleHookReconciler) ConditionType() string {
+	return addonsv1alpha1.LifecycleHooksReady
+}
+
+func (r *lifecycleHookReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.LifecycleHooks != nil && addon.Spec.LifecycleHooks.PreInstall != nil
+}
+
+// Holds back the removal of the Addon until its pre-delete hook completed.
+type preDeleteHookFinalizer struct {
+	hooks *lifecycleHookRunner
+}
+
+func (f *preDeleteHookFinalizer) Finalizer() string {
+	return "addons.managed.openshift.io/pre-delete-hook"
+}
+
+func (f *preDeleteHookFinalizer) Order() finalizerOrder {
+	return preDeleteHookFinalizerOrder
+}
+
+func (f *preDeleteHookFinalizer) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.LifecycleHooks != nil && addon.Spec.LifecycleHooks.PreDelete != nil
+}
+
+func (f *preDeleteHookFinalizer) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	if awaitingRemoteDeletion(addon) {
+		// The hook already completed before ReadyToBeDeleted was reported.
+		return true, nil
+	}
+	// Hook Jobs are watched, no need to requeue.
+	return f.hooks.preDeleteCompleted(ctx, addon)
+}
+
+// Runs lifecycle hooks as Jobs owned by the Addon.
+type lifecycleHookRunner struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// Runs the pre-delete hook of the Addon, if configured.
+// Returns true, when the deletion of the Addon may proceed.
+func (r *lifecycleHookRunner) preDeleteCompleted(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	hooks := addon.Spec.LifecycleHooks
+	if hooks == nil || hooks.PreDelete == nil {
+		return true, nil
+	}
+
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		// Misconfigured Addons must not be stuck in deletion.
+		return true, nil
+	}
+
+	// There is nothing left to clean up, when the namespace is already gone.
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: commonConfig.Namespace}, ns); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("getting namespace: %w", err)
+	}
+	if ns.Status.Phase == corev1.NamespaceTerminating {
+		return true, nil
+	}
+
+	status, msg, err := r.run(ctx, addon, commonConfig.Namespace, preDeleteHook, hooks.PreDelete)
+	if err != nil {
+		return false, fmt.Errorf("running pre-delete hook: %w", err)
+	}
+
+	switch status {
+	case lifecycleHookFailed:
+		reportHookFailed(addon, addonsv1alpha1.AddonReasonPreDeleteHookFailed, msg)
+		return false, nil
+	case lifecycleHookRunning:
+		return false, nil
+	}
+
+	removeHookFailedCondition(addon)
+	return true, nil
+}
+
+// Ensures the Job running the given hook exists and returns its status.
+// Jobs of previous versions of the hook are deleted.
+func (r *lifecycleHookRunner) run(
+	ctx context.Context, addon *addonsv1alpha1.Addon, namespace string,
+	hookType lifecycleHookType, hook *addonsv1alpha1.AddonLifecycleHook,
+) (status lifecycleHookStatus, msg string, err error) {
+	desired, err := r.desiredJob(addon, namespace, hookType, hook)
+	if err != nil {
+		return lifecycleHookRunning, "", fmt.Errorf("building desired Job: %w", err)
+	}
+
+	if err := r.deleteOutdatedJobs(ctx, addon, hookType, desired); err != nil {
+		return lifecycleHookRunning, "", fmt.Errorf("deleting outdated Jobs: %w", err)
+	}
+
+	current := &batchv1.Job{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), current); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return lifecycleHookRunning, "", fmt.Errorf("getting Job: %w", err)
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			return lifecycleHookRunning, "", fmt.Errorf("creating Job: %w", err)
+		}
+		return lifecycleHookRunning, "", nil
+	}
+
+	status, msg = lifecycleHookJobStatus(current)
+	return status, msg, nil
+}
+
+func (r *lifecycleHookRunner) desiredJob(
+	addon *addonsv1alpha1.Addon, namespace string,
+	hookType lifecycleHookType, hook *addonsv1alpha1.AddonLifecycleHook,
+) (*batchv1.Job, error) {
+	name, err := lifecycleHookJobName(addon, hookType, hook)
+	if err != nil {
+		return nil, err
+	}
+
+	backoffLimit := defaultLifecycleHookBackoffLimit
+	if hook.BackoffLimit != nil {
+		backoffLimit = *hook.BackoffLimit
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				lifecycleHookLabel: string(hookType),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: hook.ActiveDeadlineSeconds,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:     corev1.RestartPolicyNever,
+					PriorityClassName: effectivePriorityClassName(addon),
+					Containers: []corev1.Container{
+						{
+							Name:    "hook",
+							Image:   hook.Image,
+							Command: hook.Command,
+							Args:    hook.Args,
+						},
+					},
+				},
+			},
+		},
+	}
+	controllers.AddCommonLabels(job, addon)
+	controllers.AddCommonAnnotations(job, addon)
+	if err := controllerutil.SetControllerReference(addon, job, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference: %w", err)
+	}
+	return job, nil
+}
+
+func (r *lifecycleHookRunner) deleteOutdatedJobs(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	hookType lifecycleHookType, desired *batchv1.Job,
+) error {
+	selector := controllers.CommonLabelsAsLabelSelector(addon)
+	requirement, err := labels.NewRequirement(lifecycleHookLabel, "==", []string{string(hookType)})
+	if err != nil {
+		return err
+	}
+
+	currentList := &batchv1.JobList{}
+	if err := r.client.List(ctx, currentList, client.MatchingLabelsSelector{
+		Selector: selector.Add(*requirement),
+	}); err != nil {
+		return fmt.Errorf("listing Jobs: %w", err)
+	}
+
+	for i := range currentList.Items {
+		job := &currentList.Items[i]
+		if client.ObjectKeyFromObject(job) == client.ObjectKeyFromObject(desired) {
+			continue
+		}
+		// Pods of the Job are not deleted with the default orphan propagation.
+		if err := r.client.Delete(ctx, job,
+			client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting Job: %w", err)
+		}
+	}
+	return nil
+}
+
+// Jobs are named after the hook spec, so changing the hook runs it again.
+// The name is truncated, as it is used as label value on the Job pods.
+func lifecycleHookJobName(
+	addon *addonsv1alpha1.Addon, hookType lifecycleHookType,
+	hook *addonsv1alpha1.AddonLifecycleHook,
+) (string, error) {
+	b, err := json.Marshal(hook)
+	if err != nil {
+		return "", fmt.Errorf("marshalling hook: %w", err)
+	}
+
+	h := sha256.New()
+	// The Addon name is part of the hash, so truncated names stay unique.
+	fmt.Fprintf(h, "%s\x00%s\x00", addon.Name, hookType)
+	h.Write(b)
+	hash := hex.EncodeToString(h.Sum(nil))[:8]
+
+	prefix := fmt.Sprintf("addon-%s-%s", addon.Name, hookType)
+	if maxPrefixLength := 63 - len(hash) - 1; len(prefix) > maxPrefixLength {
+		prefix = prefix[:maxPrefixLength]
+	}
+	return fmt.Sprintf("%s-%s", prefix, hash), nil
+}
+
+func lifecycleHookJobStatus(job *batchv1.Job) (lifecycleHookStatus, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return lifecycleHookSucceeded, ""
+		case batchv1.JobFailed:
+			return lifecycleHookFailed, fmt.Sprintf("Job %s failed: %s", job.Name, cond.Message)
+		}
+	}
+	return lifecycleHookRunning, ""
+}
+
+func reportHookFailed(addon *addonsv1alpha1.Addon, reason, msg string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.HookFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func removeHookFailedCondition(addon *addonsv1alpha1.Addon) {
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.HookFailed)
+}
@@ -0,0 +1,301 @@
+package addon
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func newTestAddonWithLifecycleHooks() *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.LifecycleHooks = &addonsv1alpha1.AddonLifecycleHooks{
+		PreInstall: &addonsv1alpha1.AddonLifecycleHook{
+			Image: "quay.io/osd-addons/hook:pre-install",
+			Args:  []string{"--migrate"},
+		},
+		PreDelete: &addonsv1alpha1.AddonLifecycleHook{
+			Image:        "quay.io/osd-addons/hook:pre-delete",
+			BackoffLimit: pointer.Int32(1),
+		},
+	}
+	return addon
+}
+
+func TestLifecycleHookReconciler_CreatesPreInstallJob(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &lifecycleHookReconciler{
+		hooks: &lifecycleHookRunner{
+			client: c,
+			scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		},
+	}
+	addon := newTestAddonWithLifecycleHooks()
+
+	c.On("List", testutil.IsContext,
+		mock.IsType(&batchv1.JobList{}), mock.Anything).
+		Return(nil)
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&batchv1.Job{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+	var created *batchv1.Job
+	c.On("Create", testutil.IsContext,
+		mock.IsType(&batchv1.Job{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*batchv1.Job)
+		}).
+		Return(nil)
+
+	res, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.False(t, res.IsZero())
+	c.AssertExpectations(t)
+
+	require.NotNil(t, created)
+	assert.Equal(t, "addon-1", created.Namespace)
+	assert.True(t, strings.HasPrefix(created.Name, "addon-addon-1-pre-install-"))
+	assert.Equal(t, string(preInstallHook), created.Labels[lifecycleHookLabel])
+	assert.Equal(t, defaultLifecycleHookBackoffLimit, *created.Spec.BackoffLimit)
+	assert.Equal(t, corev1.RestartPolicyNever, created.Spec.Template.Spec.RestartPolicy)
+	require.Len(t, created.Spec.Template.Spec.Containers, 1)
+	assert.Equal(t, "quay.io/osd-addons/hook:pre-install", created.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"--migrate"}, created.Spec.Template.Spec.Containers[0].Args)
+	require.Len(t, created.OwnerReferences, 1)
+
+	available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	require.NotNil(t, available)
+	assert.Equal(t, addonsv1alpha1.AddonReasonLifecycleHookRunning, available.Reason)
+}
+
+func TestLifecycleHookReconciler_JobStatus(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Conditions       []batchv1.JobCondition
+		ExpectedStop     bool
+		ExpectHookFailed bool
+	}{
+		"running": {
+			ExpectedStop: true,
+		},
+		"failed": {
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"},
+			},
+			ExpectedStop:     true,
+			ExpectHookFailed: true,
+		},
+		"succeeded": {
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &lifecycleHookReconciler{
+				hooks: &lifecycleHookRunner{
+					client: c,
+					scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+				},
+			}
+			addon := newTestAddonWithLifecycleHooks()
+			// Left over from a previous failure.
+			reportHookFailed(addon, addonsv1alpha1.AddonReasonPreInstallHookFailed, "failed")
+
+			c.On("List", testutil.IsContext,
+				mock.IsType(&batchv1.JobList{}), mock.Anything).
+				Return(nil)
+			c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				mock.IsType(&batchv1.Job{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					job := args.Get(2).(*batchv1.Job)
+					job.Status.Conditions = tc.Conditions
+				}).
+				Return(nil)
+
+			res, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedStop, !res.IsZero())
+			c.AssertExpectations(t)
+
+			hookFailed := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.HookFailed)
+			if tc.ExpectHookFailed {
+				require.NotNil(t, hookFailed)
+				assert.Equal(t, metav1.ConditionTrue, hookFailed.Status)
+				assert.Contains(t, hookFailed.Message, "BackoffLimitExceeded")
+			} else if !tc.ExpectedStop {
+				assert.Nil(t, hookFailed)
+			}
+		})
+	}
+}
+
+func TestLifecycleHookReconciler_SkipsInstalledAddons(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &lifecycleHookReconciler{
+		hooks: &lifecycleHookRunner{
+			client: c,
+			scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		},
+	}
+	addon := newTestAddonWithLifecycleHooks()
+	reportInstalledCondition(addon)
+
+	res, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.AssertExpectations(t)
+}
+
+func TestLifecycleHookRunner_DeletesOutdatedJobs(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &lifecycleHookRunner{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonWithLifecycleHooks()
+
+	c.On("List", testutil.IsContext,
+		mock.IsType(&batchv1.JobList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*batchv1.JobList)
+			list.Items = []batchv1.Job{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "addon-addon-1-pre-install-outdated",
+					Namespace: "addon-1",
+				},
+			}}
+		}).
+		Return(nil)
+	var deleted *batchv1.Job
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&batchv1.Job{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deleted = args.Get(1).(*batchv1.Job)
+		}).
+		Return(nil)
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&batchv1.Job{}), mock.Anything).
+		Return(nil)
+
+	status, _, err := r.run(context.Background(), addon, "addon-1",
+		preInstallHook, addon.Spec.LifecycleHooks.PreInstall)
+	require.NoError(t, err)
+	assert.Equal(t, lifecycleHookRunning, status)
+	c.AssertExpectations(t)
+
+	require.NotNil(t, deleted)
+	assert.Equal(t, "addon-addon-1-pre-install-outdated", deleted.Name)
+}
+
+func TestLifecycleHookRunner_PreDeleteWithoutNamespace(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &lifecycleHookRunner{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonWithLifecycleHooks()
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&corev1.Namespace{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+
+	completed, err := r.preDeleteCompleted(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, completed)
+	c.AssertExpectations(t)
+}
+
+func TestAddonDeletionReconciler_WaitsForPreDeleteHook(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	handler := &mockdeletionStrategy{}
+	r := &addonDeletionReconciler{
+		clock: defaultClock{},
+		hooks: &lifecycleHookRunner{
+			client: c,
+			scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		},
+		handlers: []addonDeletionHandler{handler},
+	}
+	addon := newTestAddonWithLifecycleHooks()
+	addon.Annotations = map[string]string{
+		addonsv1alpha1.DeleteAnnotationFlag: "",
+	}
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&corev1.Namespace{}), mock.Anything).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&batchv1.JobList{}), mock.Anything).
+		Return(nil)
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&batchv1.Job{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+	var created *batchv1.Job
+	c.On("Create", testutil.IsContext,
+		mock.IsType(&batchv1.Job{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*batchv1.Job)
+		}).
+		Return(nil)
+
+	_, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+	// Addons are not notified before the hook has completed.
+	handler.AssertNotCalled(t, "NotifyAddon", mock.Anything, mock.Anything)
+
+	require.NotNil(t, created)
+	assert.True(t, strings.HasPrefix(created.Name, "addon-addon-1-pre-delete-"))
+	assert.Equal(t, int32(1), *created.Spec.BackoffLimit)
+
+	ready := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.ReadyToBeDeleted)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+}
+
+func TestLifecycleHookJobName(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithLifecycleHooks()
+	name, err := lifecycleHookJobName(addon, preInstallHook, addon.Spec.LifecycleHooks.PreInstall)
+	require.NoError(t, err)
+
+	changed := addon.Spec.LifecycleHooks.PreInstall.DeepCopy()
+	changed.Image = "quay.io/osd-addons/hook:v2"
+	changedName, err := lifecycleHookJobName(addon, preInstallHook, changed)
+	require.NoError(t, err)
+	assert.NotEqual(t, name, changedName)
+
+	addon.Name = strings.Repeat("a", 100)
+	longName, err := lifecycleHookJobName(addon, preInstallHook, changed)
+	require.NoError(t, err)
+	assert.Len(t, longName, 63)
+}
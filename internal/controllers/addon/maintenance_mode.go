@@ -0,0 +1,74 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Enables or disables the cluster maintenance mode,
+// requeueing all Addons when it changed. Concurrency safe.
+func (r *AddonReconciler) SetMaintenanceMode(ctx context.Context, enabled bool) error {
+	r.maintenanceModeMux.Lock()
+	defer r.maintenanceModeMux.Unlock()
+
+	if r.maintenanceMode == enabled {
+		return nil
+	}
+	r.maintenanceMode = enabled
+
+	if err := r.requeueAllAddons(ctx); err != nil {
+		return fmt.Errorf("requeue all Addons: %w", err)
+	}
+	return nil
+}
+
+func (r *AddonReconciler) maintenanceModeEnabled() bool {
+	r.maintenanceModeMux.RLock()
+	defer r.maintenanceModeMux.RUnlock()
+
+	return r.maintenanceMode
+}
+
+// Installs and upgrades are deferred during cluster maintenance.
+// Installed Addons are reconciled as usual to keep reporting their health.
+func (r *AddonReconciler) freezeForMaintenance(addon *addonsv1alpha1.Addon) bool {
+	if !r.maintenanceModeEnabled() {
+		removeFrozenCondition(addon)
+		return false
+	}
+
+	switch {
+	case addonIsBeingUpgraded(addon):
+		reportAddonFrozen(addon, "Addon upgrade is deferred until the cluster maintenance ends.")
+	case !meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed):
+		reportAddonFrozen(addon, "Addon install is deferred until the cluster maintenance ends.")
+	default:
+		removeFrozenCondition(addon)
+		return false
+	}
+	return true
+}
+
+func reportAddonFrozen(addon *addonsv1alpha1.Addon, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.Frozen,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonClusterMaintenance,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+	addon.Status.ObservedGeneration = addon.Generation
+}
+
+func removeFrozenCondition(addon *addonsv1alpha1.Addon) {
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.Frozen)
+}
+
+func addonFrozen(addon *addonsv1alpha1.Addon) bool {
+	return meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Frozen)
+}
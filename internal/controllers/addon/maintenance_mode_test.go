@@ -0,0 +1,71 @@
+package addon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestFreezeForMaintenance(t *testing.T) {
+	t.Parallel()
+
+	installed := metav1.Condition{
+		Type:   addonsv1alpha1.Installed,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonInstalled,
+	}
+
+	for name, tc := range map[string]struct {
+		MaintenanceMode bool
+		Conditions      []metav1.Condition
+		SpecVersion     string
+		ObservedVersion string
+		ExpectedFrozen  bool
+	}{
+		"no maintenance": {
+			MaintenanceMode: false,
+		},
+		"install deferred": {
+			MaintenanceMode: true,
+			ExpectedFrozen:  true,
+		},
+		"upgrade deferred": {
+			MaintenanceMode: true,
+			Conditions:      []metav1.Condition{installed},
+			SpecVersion:     "2.0.0",
+			ObservedVersion: "1.0.0",
+			ExpectedFrozen:  true,
+		},
+		"installed addon keeps reconciling": {
+			MaintenanceMode: true,
+			Conditions:      []metav1.Condition{installed},
+			SpecVersion:     "1.0.0",
+			ObservedVersion: "1.0.0",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &AddonReconciler{maintenanceMode: tc.MaintenanceMode}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Status.Conditions = tc.Conditions
+			addon.Spec.Version = tc.SpecVersion
+			addon.Status.ObservedVersion = tc.ObservedVersion
+
+			assert.Equal(t, tc.ExpectedFrozen, r.freezeForMaintenance(addon))
+			assert.Equal(t, tc.ExpectedFrozen, addonFrozen(addon))
+			if tc.ExpectedFrozen {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Frozen)
+				assert.Equal(t, addonsv1alpha1.AddonReasonClusterMaintenance, cond.Reason)
+			}
+		})
+	}
+}
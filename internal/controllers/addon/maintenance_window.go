@@ -0,0 +1,132 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/cron"
+)
+
+// Keeps the current image of the CatalogSource,
+// if the desired image would be rolled out outside of the Addons maintenance windows.
+// The initial install is never deferred.
+func (r *olmReconciler) deferCatalogUpgrade(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	desiredCatalogSource *operatorsv1alpha1.CatalogSource,
+) error {
+	windows := maintenanceWindows(addon)
+	if len(windows) == 0 {
+		removeUpgradeDeferredCondition(addon)
+		return nil
+	}
+
+	currentCatalogSource := &operatorsv1alpha1.CatalogSource{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredCatalogSource), currentCatalogSource); err != nil {
+		if k8sApiErrors.IsNotFound(err) {
+			removeUpgradeDeferredCondition(addon)
+			return nil
+		}
+		return fmt.Errorf("getting CatalogSource: %w", err)
+	}
+
+	if currentCatalogSource.Spec.Image == desiredCatalogSource.Spec.Image {
+		removeUpgradeDeferredCondition(addon)
+		return nil
+	}
+
+	open, next, err := evaluateMaintenanceWindows(windows, r.clock.Now())
+	if err != nil {
+		return err
+	}
+	if open {
+		removeUpgradeDeferredCondition(addon)
+		return nil
+	}
+
+	desiredCatalogSource.Spec.Image = currentCatalogSource.Spec.Image
+	reportUpgradeDeferred(addon, next)
+	return nil
+}
+
+// Requeues Addons with a deferred upgrade when their next maintenance window opens
+// or to poll OCM for the approval of Manual upgrades.
+func requeueForDeferredUpgrade(addon *addonsv1alpha1.Addon, now time.Time) ctrl.Result {
+	if !upgradeDeferred(addon) {
+		return ctrl.Result{}
+	}
+	if awaitingUpgradeApproval(addon) {
+		return ctrl.Result{RequeueAfter: upgradeApprovalPollInterval}
+	}
+
+	_, next, err := evaluateMaintenanceWindows(maintenanceWindows(addon), now)
+	if err != nil || next.IsZero() {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}
+}
+
+func maintenanceWindows(addon *addonsv1alpha1.Addon) []addonsv1alpha1.AddonMaintenanceWindow {
+	if addon.Spec.UpgradePolicy == nil {
+		return nil
+	}
+	return addon.Spec.UpgradePolicy.MaintenanceWindows
+}
+
+// Returns whether one of the given maintenance windows is open at the given time.
+// Otherwise returns the time the next window opens,
+// which is zero, if none of the windows will ever open again.
+func evaluateMaintenanceWindows(
+	windows []addonsv1alpha1.AddonMaintenanceWindow, now time.Time,
+) (open bool, next time.Time, err error) {
+	for _, window := range windows {
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("parsing maintenance window schedule: %w", err)
+		}
+
+		// The window is open, if it was last opened within its duration.
+		if start := schedule.Next(now.Add(-window.Duration.Duration)); !start.IsZero() && !start.After(now) {
+			return true, time.Time{}, nil
+		}
+
+		start := schedule.Next(now)
+		if !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
+
+func reportUpgradeDeferred(addon *addonsv1alpha1.Addon, next time.Time) {
+	message := "CatalogSource image update is deferred, no upcoming maintenance window."
+	if !next.IsZero() {
+		message = fmt.Sprintf(
+			"CatalogSource image update is deferred until the next maintenance window at %s.",
+			next.UTC().Format(time.RFC3339))
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.UpgradeDeferred,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonOutsideMaintenanceWindow,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func removeUpgradeDeferredCondition(addon *addonsv1alpha1.Addon) {
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.UpgradeDeferred)
+}
+
+func upgradeDeferred(addon *addonsv1alpha1.Addon) bool {
+	return meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.UpgradeDeferred)
+}
@@ -0,0 +1,161 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEvaluateMaintenanceWindows(t *testing.T) {
+	t.Parallel()
+
+	// Monday
+	now := time.Date(2023, time.April, 3, 3, 30, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		windows      []addonsv1alpha1.AddonMaintenanceWindow
+		expectedOpen bool
+		expectedNext time.Time
+	}{
+		"open": {
+			windows: []addonsv1alpha1.AddonMaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			expectedOpen: true,
+		},
+		"closed": {
+			windows: []addonsv1alpha1.AddonMaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expectedNext: time.Date(2023, time.April, 4, 2, 0, 0, 0, time.UTC),
+		},
+		"earliest of multiple windows": {
+			windows: []addonsv1alpha1.AddonMaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				{Schedule: "0 22 * * 1", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expectedNext: time.Date(2023, time.April, 3, 22, 0, 0, 0, time.UTC),
+		},
+		"one of multiple windows open": {
+			windows: []addonsv1alpha1.AddonMaintenanceWindow{
+				{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				{Schedule: "0 3 * * 1", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+			expectedOpen: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			open, next, err := evaluateMaintenanceWindows(tc.windows, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOpen, open)
+			assert.Equal(t, tc.expectedNext, next)
+		})
+	}
+}
+
+func TestDeferCatalogUpgrade(t *testing.T) {
+	t.Parallel()
+
+	// Monday
+	now := time.Date(2023, time.April, 3, 3, 30, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		schedule      string
+		expectedImage string
+		deferred      bool
+	}{
+		"within window": {
+			schedule:      "0 3 * * *",
+			expectedImage: "quay.io/osd-addons/test:new",
+		},
+		"outside window": {
+			schedule:      "0 2 * * *",
+			expectedImage: "quay.io/osd-addons/test:old",
+			deferred:      true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			clock := &testClock{}
+			clock.On("Now").Return(now)
+			r := &olmReconciler{client: c, clock: clock}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.UpgradePolicy = &addonsv1alpha1.AddonUpgradePolicy{
+				ID: "123",
+				MaintenanceWindows: []addonsv1alpha1.AddonMaintenanceWindow{
+					{Schedule: tc.schedule, Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			}
+
+			desired := testutil.NewTestCatalogSource()
+			desired.Spec.Image = "quay.io/osd-addons/test:new"
+
+			c.On("Get", testutil.IsContext, client.ObjectKeyFromObject(desired),
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					current := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+					current.Spec.Image = "quay.io/osd-addons/test:old"
+				}).
+				Return(nil)
+
+			err := r.deferCatalogUpgrade(context.Background(), addon, desired)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedImage, desired.Spec.Image)
+			assert.Equal(t, tc.deferred, upgradeDeferred(addon))
+			c.AssertExpectations(t)
+
+			result := requeueForDeferredUpgrade(addon, now)
+			if tc.deferred {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradeDeferred)
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonOutsideMaintenanceWindow, cond.Reason)
+				assert.Contains(t, cond.Message, "2023-04-04T02:00:00Z")
+				assert.Equal(t, 22*time.Hour+30*time.Minute, result.RequeueAfter)
+			} else {
+				assert.True(t, result.IsZero())
+			}
+		})
+	}
+}
+
+func TestDeferCatalogUpgrade_NoWindows(t *testing.T) {
+	t.Parallel()
+
+	r := &olmReconciler{client: testutil.NewClient()}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.UpgradeDeferred,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonOutsideMaintenanceWindow,
+	})
+
+	desired := testutil.NewTestCatalogSource()
+	desired.Spec.Image = "quay.io/osd-addons/test:new"
+
+	err := r.deferCatalogUpgrade(context.Background(), addon, desired)
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/osd-addons/test:new", desired.Spec.Image)
+	assert.False(t, upgradeDeferred(addon))
+}
@@ -0,0 +1,209 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Key of the bearer token in the federation auth Secret.
+// The client certificate and key use the kubernetes.io/tls keys.
+const federationAuthTokenKey = "token"
+
+// Helper function to compute the name of the Secret holding the federation credentials,
+// next to the federation ServiceMonitor.
+func GetMonitoringFederationAuthSecretName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("federated-sm-%s-auth", addon.Name)
+}
+
+// Name of the ServiceAccount token Secret requested in the federation namespace.
+func getMonitoringFederationTokenSecretName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-federation-token", addon.Name)
+}
+
+// Renders the credentials of .spec.monitoring.federation.auth into a Secret
+// in the monitoring namespace, as ServiceMonitors can only reference Secrets
+// in their own namespace. Credentials no longer configured are removed.
+func (r *monitoringFederationReconciler) ensureFederationAuth(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	auth := addon.Spec.Monitoring.Federation.Auth
+	if auth == nil {
+		auth = &addonsv1alpha1.MonitoringFederationAuth{}
+	}
+	federationNamespace := addon.Spec.Monitoring.Federation.Namespace
+	data := map[string][]byte{}
+
+	tokenSecretKey := client.ObjectKey{
+		Name:      getMonitoringFederationTokenSecretName(addon),
+		Namespace: federationNamespace,
+	}
+	if len(auth.ServiceAccount) > 0 {
+		token, err := r.ensureServiceAccountToken(ctx, addon, tokenSecretKey, auth.ServiceAccount)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("ensuring ServiceAccount token: %w", err)
+		}
+		if len(token) == 0 {
+			reportUnreadyMonitoringFederation(addon,
+				fmt.Sprintf("token of ServiceAccount %q not yet issued", auth.ServiceAccount))
+			return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
+		}
+		data[federationAuthTokenKey] = token
+	} else if err := r.deleteSecretIfExists(ctx, tokenSecretKey); err != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting ServiceAccount token: %w", err)
+	}
+
+	if auth.MTLS != nil {
+		certSecret, err := r.getClientCertificate(ctx, client.ObjectKey{
+			Name:      auth.MTLS.SecretName,
+			Namespace: federationNamespace,
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting client certificate: %w", err)
+		}
+		if certSecret == nil {
+			reportUnreadyMonitoringFederation(addon, fmt.Sprintf(
+				"client certificate Secret %q with %s and %s not found in namespace %q",
+				auth.MTLS.SecretName, corev1.TLSCertKey, corev1.TLSPrivateKeyKey, federationNamespace))
+			return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
+		}
+		data[corev1.TLSCertKey] = certSecret.Data[corev1.TLSCertKey]
+		data[corev1.TLSPrivateKeyKey] = certSecret.Data[corev1.TLSPrivateKeyKey]
+	}
+
+	authSecretKey := client.ObjectKey{
+		Name:      GetMonitoringFederationAuthSecretName(addon),
+		Namespace: GetMonitoringNamespaceName(addon),
+	}
+	if len(data) == 0 {
+		if err := r.deleteSecretIfExists(ctx, authSecretKey); err != nil {
+			return ctrl.Result{}, fmt.Errorf("deleting federation auth Secret: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	authSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      authSecretKey.Name,
+			Namespace: authSecretKey.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	controllers.AddCommonLabels(authSecret, addon)
+	if err := controllerutil.SetControllerReference(addon, authSecret, r.scheme); err != nil {
+		return ctrl.Result{}, fmt.Errorf("setting controller reference on federation auth Secret: %w", err)
+	}
+	if err := controllers.Apply(ctx, r.client, authSecret); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying federation auth Secret: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// Requests a token for the ServiceAccount and returns it,
+// or nil, if the token controller did not issue it yet.
+func (r *monitoringFederationReconciler) ensureServiceAccountToken(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	key client.ObjectKey, serviceAccount string,
+) ([]byte, error) {
+	current := &corev1.Secret{}
+	if err := r.client.Get(ctx, key, current); err == nil {
+		if current.Annotations[corev1.ServiceAccountNameKey] != serviceAccount {
+			// The token controller does not reissue tokens for another ServiceAccount.
+			if err := r.client.Delete(ctx, current); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("deleting token of previous ServiceAccount: %w", err)
+			}
+		}
+	} else if !k8sApiErrors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting token Secret: %w", err)
+	}
+
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: serviceAccount,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	controllers.AddCommonLabels(tokenSecret, addon)
+	if err := controllerutil.SetControllerReference(addon, tokenSecret, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on token Secret: %w", err)
+	}
+	if err := controllers.Apply(ctx, r.client, tokenSecret); err != nil {
+		return nil, fmt.Errorf("applying token Secret: %w", err)
+	}
+	return tokenSecret.Data[corev1.ServiceAccountTokenKey], nil
+}
+
+// Returns the client certificate Secret, or nil, if it does not exist or misses the certificate or key.
+func (r *monitoringFederationReconciler) getClientCertificate(
+	ctx context.Context, key client.ObjectKey) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := r.client.Get(ctx, key, secret)
+	if k8sApiErrors.IsNotFound(err) {
+		// Secrets without the cache label are only visible uncached.
+		err = r.uncachedClient.Get(ctx, key, secret)
+	}
+	if k8sApiErrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, nil
+	}
+	return secret, nil
+}
+
+func (r *monitoringFederationReconciler) deleteSecretIfExists(ctx context.Context, key client.ObjectKey) error {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, key, secret); k8sApiErrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return client.IgnoreNotFound(r.client.Delete(ctx, secret))
+}
+
+// Authenticates the federation endpoint with the credentials
+// rendered into the federation auth Secret.
+func applyMonitoringFederationAuth(addon *addonsv1alpha1.Addon, endpoint *monitoringv1.Endpoint) {
+	auth := addon.Spec.Monitoring.Federation.Auth
+	if auth == nil {
+		return
+	}
+	secretRef := corev1.LocalObjectReference{Name: GetMonitoringFederationAuthSecretName(addon)}
+
+	if len(auth.ServiceAccount) > 0 {
+		endpoint.BearerTokenFile = ""
+		endpoint.BearerTokenSecret = corev1.SecretKeySelector{
+			LocalObjectReference: secretRef,
+			Key:                  federationAuthTokenKey,
+		}
+	}
+	if auth.MTLS != nil {
+		endpoint.TLSConfig.Cert = monitoringv1.SecretOrConfigMap{
+			Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: secretRef,
+				Key:                  corev1.TLSCertKey,
+			},
+		}
+		endpoint.TLSConfig.KeySecret = &corev1.SecretKeySelector{
+			LocalObjectReference: secretRef,
+			Key:                  corev1.TLSPrivateKeyKey,
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsureFederationAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Token        []byte
+		ClientCert   map[string][]byte
+		ExpectedData map[string][]byte
+	}{
+		"renders token and client certificate": {
+			Token: []byte("token"),
+			ClientCert: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+			ExpectedData: map[string][]byte{
+				federationAuthTokenKey:  []byte("token"),
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		},
+		"token not yet issued": {
+			ClientCert: map[string][]byte{
+				corev1.TLSCertKey:       []byte("cert"),
+				corev1.TLSPrivateKeyKey: []byte("key"),
+			},
+		},
+		"client certificate without key": {
+			Token: []byte("token"),
+			ClientCert: map[string][]byte{
+				corev1.TLSCertKey: []byte("cert"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+			addon.Spec.Monitoring.Federation.Auth = &addonsv1alpha1.MonitoringFederationAuth{
+				ServiceAccount: "federate",
+				MTLS:           &addonsv1alpha1.MonitoringFederationMTLS{SecretName: "client-cert"},
+			}
+			federationNamespace := addon.Spec.Monitoring.Federation.Namespace
+			certKey := client.ObjectKey{Name: "client-cert", Namespace: federationNamespace}
+
+			c := testutil.NewClient()
+			c.On("Get", testutil.IsContext, mock.Anything,
+				mock.IsType(&corev1.Secret{}), mock.Anything).
+				Return(testutil.NewTestErrNotFound())
+			var authSecret *corev1.Secret
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Secret{}), mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					secret := args.Get(1).(*corev1.Secret)
+					switch secret.Name {
+					case getMonitoringFederationTokenSecretName(addon):
+						assert.Equal(t, federationNamespace, secret.Namespace)
+						assert.Equal(t, corev1.SecretTypeServiceAccountToken, secret.Type)
+						assert.Equal(t, "federate", secret.Annotations[corev1.ServiceAccountNameKey])
+						if len(tc.Token) > 0 {
+							secret.Data = map[string][]byte{corev1.ServiceAccountTokenKey: tc.Token}
+						}
+					case GetMonitoringFederationAuthSecretName(addon):
+						authSecret = secret.DeepCopy()
+					}
+				}).
+				Return(nil)
+
+			uncachedClient := testutil.NewClient()
+			uncachedClient.On("Get", testutil.IsContext, certKey,
+				mock.IsType(&corev1.Secret{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(2).(*corev1.Secret).Data = tc.ClientCert
+				}).
+				Return(nil).Maybe()
+
+			r := &monitoringFederationReconciler{
+				client:         c,
+				uncachedClient: uncachedClient,
+				scheme:         testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+
+			result, err := r.ensureFederationAuth(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.ExpectedData == nil {
+				assert.False(t, result.IsZero())
+				assert.Nil(t, authSecret)
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyMonitoringFederation, cond.Reason)
+				return
+			}
+
+			assert.True(t, result.IsZero())
+			require.NotNil(t, authSecret)
+			assert.Equal(t, GetMonitoringNamespaceName(addon), authSecret.Namespace)
+			assert.Equal(t, tc.ExpectedData, authSecret.Data)
+		})
+	}
+}
+
+func TestGetMonitoringFederationServiceMonitorEndpoints_Auth(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	addon.Spec.Monitoring.Federation.Auth = &addonsv1alpha1.MonitoringFederationAuth{
+		ServiceAccount: "federate",
+		MTLS:           &addonsv1alpha1.MonitoringFederationMTLS{SecretName: "client-cert"},
+	}
+	secretRef := corev1.LocalObjectReference{Name: GetMonitoringFederationAuthSecretName(addon)}
+
+	endpoints := GetMonitoringFederationServiceMonitorEndpoints(addon)
+	require.Len(t, endpoints, 1)
+	endpoint := endpoints[0]
+
+	assert.Empty(t, endpoint.BearerTokenFile)
+	assert.Equal(t, corev1.SecretKeySelector{
+		LocalObjectReference: secretRef, Key: federationAuthTokenKey,
+	}, endpoint.BearerTokenSecret)
+	assert.Equal(t, monitoringv1.SecretOrConfigMap{
+		Secret: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: corev1.TLSCertKey},
+	}, endpoint.TLSConfig.Cert)
+	assert.Equal(t, &corev1.SecretKeySelector{
+		LocalObjectReference: secretRef, Key: corev1.TLSPrivateKeyKey,
+	}, endpoint.TLSConfig.KeySecret)
+	// The service CA still verifies the federated prometheus.
+	assert.NotEmpty(t, endpoint.TLSConfig.CAFile)
+}
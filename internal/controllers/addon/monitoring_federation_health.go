@@ -0,0 +1,225 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	promconfig "github.com/prometheus/common/config"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Credentials of the addon-operator ServiceAccount, used to query cluster-monitoring.
+const (
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceCAFile           = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+)
+
+// Maximum number of unhealthy targets listed in the condition message.
+const maxReportedUnhealthyTargets = 5
+
+const (
+	// Interval the targets of cluster-monitoring are listed in,
+	// so Addons reconciled in between share the same listing.
+	targetHealthInterval = time.Minute
+	// Timeout of listing the targets, so an unresponsive Prometheus
+	// does not hold back the reconcile of the Addon.
+	targetHealthTimeout = 10 * time.Second
+)
+
+// UnhealthyTarget is a scrape target, which failed its latest scrape.
+type UnhealthyTarget struct {
+	URL       string
+	LastError string
+}
+
+// TargetHealthChecker looks up the health of scrape targets in a Prometheus instance.
+type TargetHealthChecker interface {
+	// Returns the number of active targets of the scrape pool and the unhealthy ones among them.
+	CheckTargets(ctx context.Context, scrapePool string) (active int, unhealthy []UnhealthyTarget, err error)
+}
+
+// prometheusTargetHealthChecker checks target health using the Prometheus HTTP API.
+// The targets of all scrape pools are listed at most once per interval.
+type prometheusTargetHealthChecker struct {
+	api      promv1.API
+	interval time.Duration
+
+	mux      sync.Mutex
+	targets  []promv1.ActiveTarget
+	err      error
+	listedAt time.Time
+}
+
+// Creates a TargetHealthChecker querying the Prometheus at the given URL.
+func NewPrometheusTargetHealthChecker(prometheusURL string) (TargetHealthChecker, error) {
+	api, err := newPrometheusAPI(prometheusURL)
+	if err != nil {
+		return nil, err
+	}
+	return newPrometheusTargetHealthChecker(api), nil
+}
+
+func newPrometheusTargetHealthChecker(api promv1.API) *prometheusTargetHealthChecker {
+	return &prometheusTargetHealthChecker{api: api, interval: targetHealthInterval}
+}
+
+// Creates a client of the Prometheus HTTP API at the given URL,
+// authenticated as the ServiceAccount of the addon-operator.
+func newPrometheusAPI(prometheusURL string) (promv1.API, error) {
+	rt, err := promconfig.NewRoundTripperFromConfig(promconfig.HTTPClientConfig{
+		BearerTokenFile: serviceAccountTokenFile,
+		TLSConfig: promconfig.TLSConfig{
+			CAFile: serviceCAFile,
+		},
+	}, "addon-operator")
+	if err != nil {
+		return nil, fmt.Errorf("creating prometheus round tripper: %w", err)
+	}
+
+	c, err := promapi.NewClient(promapi.Config{
+		Address:      prometheusURL,
+		RoundTripper: rt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating prometheus client: %w", err)
+	}
+	return promv1.NewAPI(c), nil
+}
+
+func (c *prometheusTargetHealthChecker) CheckTargets(
+	ctx context.Context, scrapePool string,
+) (int, []UnhealthyTarget, error) {
+	targets, err := c.listTargets(ctx, time.Now())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var (
+		active    int
+		unhealthy []UnhealthyTarget
+	)
+	for _, target := range targets {
+		if target.ScrapePool != scrapePool {
+			continue
+		}
+		active++
+		// Targets not yet scraped report an unknown health.
+		if target.Health == promv1.HealthBad {
+			unhealthy = append(unhealthy, UnhealthyTarget{
+				URL:       target.ScrapeURL,
+				LastError: target.LastError,
+			})
+		}
+	}
+	return active, unhealthy, nil
+}
+
+// Returns the active targets of the last listing, unless it is older than the interval.
+// Failed listings are reused as well, to not retry them on every reconcile.
+func (c *prometheusTargetHealthChecker) listTargets(
+	ctx context.Context, now time.Time,
+) ([]promv1.ActiveTarget, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if !c.listedAt.IsZero() && now.Sub(c.listedAt) < c.interval {
+		return c.targets, c.err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, targetHealthTimeout)
+	defer cancel()
+
+	c.targets, c.err = nil, nil
+	targets, err := c.api.Targets(ctx)
+	if err != nil {
+		c.err = fmt.Errorf("listing prometheus targets: %w", err)
+	} else {
+		c.targets = targets.Active
+	}
+	c.listedAt = now
+	return c.targets, c.err
+}
+
+// Name of the scrape pool prometheus-operator generates
+// for the only endpoint of the federation ServiceMonitor.
+func getMonitoringFederationScrapePool(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("serviceMonitor/%s/%s/0",
+		GetMonitoringNamespaceName(addon), GetMonitoringFederationServiceMonitorName(addon))
+}
+
+// Checks the health of the federation targets in cluster-monitoring
+// and reports the result as MonitoringFederationDegraded condition.
+// Failing checks are logged and keep the last known state,
+// as they do not affect the federation itself.
+func (r *monitoringFederationReconciler) checkFederationHealth(
+	ctx context.Context, addon *addonsv1alpha1.Addon) {
+	if r.targetHealth == nil || !HasMonitoringFederation(addon) {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.MonitoringFederationDegraded)
+		return
+	}
+
+	active, unhealthy, err := r.targetHealth.CheckTargets(ctx, getMonitoringFederationScrapePool(addon))
+	if err != nil {
+		controllers.LoggerFromContext(ctx).Error(err, "checking federation target health")
+		return
+	}
+
+	switch {
+	case active == 0:
+		reportUnhealthyFederationTargets(addon,
+			"No federation targets discovered, check .spec.monitoring.federation.matchLabels and .portName")
+	case len(unhealthy) > 0:
+		reportUnhealthyFederationTargets(addon, fmt.Sprintf("%d of %d federation targets are down: %s",
+			len(unhealthy), active, formatUnhealthyTargets(unhealthy)))
+	default:
+		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+			Type:               addonsv1alpha1.MonitoringFederationDegraded,
+			Status:             metav1.ConditionFalse,
+			Reason:             addonsv1alpha1.AddonReasonHealthyFederationTargets,
+			Message:            fmt.Sprintf("All %d federation targets are up", active),
+			ObservedGeneration: addon.Generation,
+		})
+	}
+}
+
+func reportUnhealthyFederationTargets(addon *addonsv1alpha1.Addon, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.MonitoringFederationDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonUnhealthyFederationTargets,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+// Lists the unhealthy targets sorted by URL, to keep the condition message stable.
+func formatUnhealthyTargets(unhealthy []UnhealthyTarget) string {
+	sort.Slice(unhealthy, func(i, j int) bool {
+		return unhealthy[i].URL < unhealthy[j].URL
+	})
+
+	reported := unhealthy
+	if len(reported) > maxReportedUnhealthyTargets {
+		reported = reported[:maxReportedUnhealthyTargets]
+	}
+	targets := make([]string, len(reported))
+	for i, target := range reported {
+		targets[i] = fmt.Sprintf("%s (%s)", target.URL, target.LastError)
+	}
+
+	msg := strings.Join(targets, ", ")
+	if omitted := len(unhealthy) - len(reported); omitted > 0 {
+		msg += fmt.Sprintf(" and %d more", omitted)
+	}
+	return msg
+}
@@ -0,0 +1,161 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type targetHealthCheckerMock struct {
+	mock.Mock
+}
+
+func (m *targetHealthCheckerMock) CheckTargets(
+	ctx context.Context, scrapePool string,
+) (int, []UnhealthyTarget, error) {
+	args := m.Called(ctx, scrapePool)
+	unhealthy, _ := args.Get(1).([]UnhealthyTarget)
+	return args.Int(0), unhealthy, args.Error(2)
+}
+
+func TestCheckFederationHealth(t *testing.T) {
+	t.Parallel()
+
+	previous := metav1.Condition{
+		Type:   addonsv1alpha1.MonitoringFederationDegraded,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonUnhealthyFederationTargets,
+	}
+
+	tests := map[string]struct {
+		Active          int
+		Unhealthy       []UnhealthyTarget
+		Err             error
+		ExpectedStatus  metav1.ConditionStatus
+		ExpectedMessage string
+	}{
+		"all targets up": {
+			Active:          2,
+			ExpectedStatus:  metav1.ConditionFalse,
+			ExpectedMessage: "All 2 federation targets are up",
+		},
+		"targets down": {
+			Active: 3,
+			Unhealthy: []UnhealthyTarget{
+				{URL: "https://10.0.0.2:9091/federate", LastError: "connection refused"},
+				{URL: "https://10.0.0.1:9091/federate", LastError: "server returned HTTP status 403 Forbidden"},
+			},
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedMessage: "2 of 3 federation targets are down: " +
+				"https://10.0.0.1:9091/federate (server returned HTTP status 403 Forbidden), " +
+				"https://10.0.0.2:9091/federate (connection refused)",
+		},
+		"no targets": {
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedMessage: "No federation targets discovered, " +
+				"check .spec.monitoring.federation.matchLabels and .portName",
+		},
+		"check failing keeps the last state": {
+			Err:            errors.New("connection refused"),
+			ExpectedStatus: metav1.ConditionTrue,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+			meta.SetStatusCondition(&addon.Status.Conditions, previous)
+
+			checker := &targetHealthCheckerMock{}
+			checker.On("CheckTargets", testutil.IsContext,
+				"serviceMonitor/redhat-monitoring-addon-foo/federated-sm-addon-foo/0").
+				Return(tc.Active, tc.Unhealthy, tc.Err)
+
+			r := &monitoringFederationReconciler{targetHealth: checker}
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			r.checkFederationHealth(ctx, addon)
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.MonitoringFederationDegraded)
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.ExpectedStatus, cond.Status)
+			assert.Equal(t, tc.ExpectedMessage, cond.Message)
+		})
+	}
+}
+
+func TestCheckFederationHealth_Disabled(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.MonitoringFederationDegraded,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonUnhealthyFederationTargets,
+	})
+
+	r := &monitoringFederationReconciler{}
+	r.checkFederationHealth(context.Background(), addon)
+
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.MonitoringFederationDegraded))
+}
+
+func TestPrometheusTargetHealthChecker(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/targets", r.URL.Path)
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status":"success","data":{"activeTargets":[`+
+			`{"scrapePool":"serviceMonitor/ns/sm/0","scrapeUrl":"https://a/federate","health":"up"},`+
+			`{"scrapePool":"serviceMonitor/ns/sm/0","scrapeUrl":"https://b/federate","health":"down","lastError":"timeout"},`+
+			`{"scrapePool":"serviceMonitor/ns/sm/0","scrapeUrl":"https://c/federate","health":"unknown"},`+
+			`{"scrapePool":"serviceMonitor/ns/other/0","scrapeUrl":"https://d/federate","health":"down"}`+
+			`],"droppedTargets":[]}}`)
+	}))
+	defer server.Close()
+
+	c, err := promapi.NewClient(promapi.Config{Address: server.URL})
+	require.NoError(t, err)
+	checker := newPrometheusTargetHealthChecker(promv1.NewAPI(c))
+
+	active, unhealthy, err := checker.CheckTargets(context.Background(), "serviceMonitor/ns/sm/0")
+	require.NoError(t, err)
+	assert.Equal(t, 3, active)
+	assert.Equal(t, []UnhealthyTarget{{URL: "https://b/federate", LastError: "timeout"}}, unhealthy)
+
+	// Other scrape pools are checked against the same listing.
+	active, unhealthy, err = checker.CheckTargets(context.Background(), "serviceMonitor/ns/other/0")
+	require.NoError(t, err)
+	assert.Equal(t, 1, active)
+	assert.Len(t, unhealthy, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// Targets are listed again after the interval.
+	checker.listedAt = checker.listedAt.Add(-targetHealthInterval)
+	_, _, err = checker.CheckTargets(context.Background(), "serviceMonitor/ns/sm/0")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
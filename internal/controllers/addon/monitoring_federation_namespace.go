@@ -0,0 +1,185 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Marks federation namespaces and their ResourceQuota and LimitRange created by the addon-operator,
+// which are managed by the monitoringFederationReconciler instead of the namespaceReconciler.
+const monitoringFederationNamespaceLabel = "addons.managed.openshift.io/monitoring-federation-namespace"
+
+// Hard limits of the ResourceQuota in created federation namespaces,
+// sized for a prometheus server with an alertmanager and their volumes.
+var defaultMonitoringFederationNamespaceQuota = corev1.ResourceList{
+	corev1.ResourcePods:                   resource.MustParse("20"),
+	corev1.ResourceRequestsCPU:            resource.MustParse("2"),
+	corev1.ResourceRequestsMemory:         resource.MustParse("8Gi"),
+	corev1.ResourceLimitsMemory:           resource.MustParse("16Gi"),
+	corev1.ResourcePersistentVolumeClaims: resource.MustParse("4"),
+	corev1.ResourceRequestsStorage:        resource.MustParse("100Gi"),
+}
+
+// Defaults of the LimitRange in created federation namespaces.
+// Pods are rejected by the ResourceQuota, unless every container requests CPU and memory
+// and limits memory, so containers not setting them themselves get these defaults.
+var defaultMonitoringFederationNamespaceLimits = corev1.LimitRangeItem{
+	Type: corev1.LimitTypeContainer,
+	DefaultRequest: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("256Mi"),
+	},
+	Default: corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	},
+}
+
+// Helper function to compute the name of the ResourceQuota in created federation namespaces.
+func GetMonitoringFederationQuotaName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-monitoring-quota", addon.Name)
+}
+
+// Helper function to compute the name of the LimitRange in created federation namespaces.
+func GetMonitoringFederationLimitRangeName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-monitoring-limits", addon.Name)
+}
+
+// Ensures the namespace of the federated prometheus server exists.
+// Missing namespaces are created, if enabled, and are otherwise reported on the Addon.
+// Existing namespaces not created by the addon-operator are left untouched.
+func (r *monitoringFederationReconciler) ensureFederationNamespace(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	name := addon.Spec.Monitoring.Federation.Namespace
+
+	// Namespaces not created by us are not cached.
+	actual := &corev1.Namespace{}
+	err := r.uncachedClient.Get(ctx, client.ObjectKey{Name: name}, actual)
+	switch {
+	case err == nil && !isMonitoringFederationNamespace(actual, addon):
+		return ctrl.Result{}, nil
+
+	case err == nil && !r.createFederationNamespaces:
+		// Creation has been disabled since, keep the namespace as is.
+		return ctrl.Result{}, nil
+
+	case k8sApiErrors.IsNotFound(err) && !r.createFederationNamespaces:
+		reportUnreadyMonitoringFederation(addon, fmt.Sprintf("namespace %q not found", name))
+		return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
+
+	case err != nil && !k8sApiErrors.IsNotFound(err):
+		return ctrl.Result{}, fmt.Errorf("getting federation namespace: %w", err)
+	}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				monitoringFederationNamespaceLabel: "true",
+			},
+		},
+	}
+	if err := r.addOwnership(addon, namespace); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, namespace); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying federation namespace: %w", err)
+	}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMonitoringFederationQuotaName(addon),
+			Namespace: name,
+			Labels: map[string]string{
+				monitoringFederationNamespaceLabel: "true",
+			},
+		},
+		Spec: corev1.ResourceQuotaSpec{
+			Hard: defaultMonitoringFederationNamespaceQuota,
+		},
+	}
+	if err := r.addOwnership(addon, quota); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, quota); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying federation namespace ResourceQuota: %w", err)
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMonitoringFederationLimitRangeName(addon),
+			Namespace: name,
+			Labels: map[string]string{
+				monitoringFederationNamespaceLabel: "true",
+			},
+		},
+		Spec: corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{defaultMonitoringFederationNamespaceLimits},
+		},
+	}
+	if err := r.addOwnership(addon, limitRange); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, limitRange); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying federation namespace LimitRange: %w", err)
+	}
+
+	if namespace.Status.Phase == corev1.NamespaceActive {
+		return ctrl.Result{}, nil
+	}
+	reportUnreadyMonitoringFederation(addon, fmt.Sprintf("namespace %q is not active", name))
+	return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
+}
+
+func (r *monitoringFederationReconciler) addOwnership(addon *addonsv1alpha1.Addon, obj client.Object) error {
+	controllers.AddCommonLabels(obj, addon)
+	controllers.AddCommonAnnotations(obj, addon)
+	if err := controllerutil.SetControllerReference(addon, obj, r.scheme); err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+	return nil
+}
+
+// Deletes federation namespaces created for the Addon, which are no longer federated.
+// Namespaces are garbage collected with the Addon itself.
+func (r *monitoringFederationReconciler) ensureDeletionOfUnwantedFederationNamespaces(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	if !r.createFederationNamespaces {
+		return nil
+	}
+
+	namespaces, err := getOwnedNamespacesViaCommonLabels(ctx, r.client, addon)
+	if err != nil {
+		return err
+	}
+
+	var wanted string
+	if HasMonitoringFederation(addon) {
+		wanted = addon.Spec.Monitoring.Federation.Namespace
+	}
+	for i := range namespaces {
+		namespace := &namespaces[i]
+		if namespace.Name == wanted || !isMonitoringFederationNamespace(namespace, addon) {
+			continue
+		}
+		if err := ensureNamespaceDeletion(ctx, r.client, namespace.Name); err != nil {
+			return fmt.Errorf("deleting federation namespace: %w", err)
+		}
+	}
+	return nil
+}
+
+func isMonitoringFederationNamespace(namespace *corev1.Namespace, addon *addonsv1alpha1.Addon) bool {
+	_, ok := namespace.Labels[monitoringFederationNamespaceLabel]
+	return ok && metav1.IsControlledBy(namespace, addon)
+}
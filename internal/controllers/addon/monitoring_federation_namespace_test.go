@@ -0,0 +1,174 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsureFederationNamespace(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Exists        bool
+		Create        bool
+		ExpectCreated bool
+		ExpectUnready bool
+	}{
+		"existing namespace": {
+			Exists: true,
+			Create: true,
+		},
+		"missing namespace": {
+			ExpectUnready: true,
+		},
+		"creates missing namespace": {
+			Create:        true,
+			ExpectCreated: true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+
+			var getErr error
+			if !tc.Exists {
+				getErr = testutil.NewTestErrNotFound()
+			}
+			uncachedClient := testutil.NewClient()
+			uncachedClient.On("Get", testutil.IsContext, types.NamespacedName{Name: "addon-foo-monitoring"},
+				mock.IsType(&corev1.Namespace{}), mock.Anything).
+				Return(getErr)
+
+			var (
+				namespace  *corev1.Namespace
+				quota      *corev1.ResourceQuota
+				limitRange *corev1.LimitRange
+			)
+			c := testutil.NewClient()
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Namespace{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					ns := args.Get(1).(*corev1.Namespace)
+					ns.Status.Phase = corev1.NamespaceActive
+					namespace = ns.DeepCopy()
+				}).
+				Return(nil).Maybe()
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ResourceQuota{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					quota = args.Get(1).(*corev1.ResourceQuota).DeepCopy()
+				}).
+				Return(nil).Maybe()
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.LimitRange{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					limitRange = args.Get(1).(*corev1.LimitRange).DeepCopy()
+				}).
+				Return(nil).Maybe()
+
+			r := &monitoringFederationReconciler{
+				client:                     c,
+				uncachedClient:             uncachedClient,
+				scheme:                     testutil.NewTestSchemeWithAddonsv1alpha1(),
+				createFederationNamespaces: tc.Create,
+			}
+
+			result, err := r.ensureFederationNamespace(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectUnready, !result.IsZero())
+
+			available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			if tc.ExpectUnready {
+				require.NotNil(t, available)
+				assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyMonitoringFederation, available.Reason)
+			} else {
+				assert.Nil(t, available)
+			}
+
+			if !tc.ExpectCreated {
+				c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NotNil(t, namespace)
+			assert.Equal(t, "addon-foo-monitoring", namespace.Name)
+			assert.True(t, isMonitoringFederationNamespace(namespace, addon))
+
+			require.NotNil(t, quota)
+			assert.Equal(t, "addon-foo-monitoring", quota.Namespace)
+			assert.Equal(t, "addon-addon-foo-monitoring-quota", quota.Name)
+			assert.Equal(t, defaultMonitoringFederationNamespaceQuota, quota.Spec.Hard)
+			assert.Contains(t, quota.Labels, monitoringFederationNamespaceLabel)
+			assert.True(t, metav1.IsControlledBy(quota, addon))
+
+			require.NotNil(t, limitRange)
+			assert.Equal(t, "addon-foo-monitoring", limitRange.Namespace)
+			assert.Equal(t, "addon-addon-foo-monitoring-limits", limitRange.Name)
+			assert.Equal(t, []corev1.LimitRangeItem{defaultMonitoringFederationNamespaceLimits}, limitRange.Spec.Limits)
+			assert.True(t, metav1.IsControlledBy(limitRange, addon))
+		})
+	}
+}
+
+func TestEnsureDeletionOfUnwantedFederationNamespaces(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	namespaces := []corev1.Namespace{
+		*testFederationNamespace(addon, "addon-foo-monitoring"),
+		*testFederationNamespace(addon, "addon-foo-monitoring-old"),
+		// Namespace of .spec.namespaces.
+		{ObjectMeta: metav1.ObjectMeta{Name: "namespace-1"}},
+	}
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&corev1.NamespaceList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1.NamespaceList).Items = namespaces
+		}).
+		Return(nil)
+	var deleted []string
+	c.On("Delete", testutil.IsContext, mock.IsType(&corev1.Namespace{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deleted = append(deleted, args.Get(1).(*corev1.Namespace).Name)
+		}).
+		Return(nil)
+
+	r := &monitoringFederationReconciler{
+		client:                     c,
+		scheme:                     testutil.NewTestSchemeWithAddonsv1alpha1(),
+		createFederationNamespaces: true,
+	}
+
+	err := r.ensureDeletionOfUnwantedFederationNamespaces(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"addon-foo-monitoring-old"}, deleted)
+}
+
+func testFederationNamespace(addon *addonsv1alpha1.Addon, name string) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				monitoringFederationNamespaceLabel: "true",
+			},
+		},
+	}
+	_ = (&monitoringFederationReconciler{
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}).addOwnership(addon, ns)
+	return ns
+}
@@ -7,10 +7,9 @@ import (
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,7 +24,13 @@ const MONITORING_FEDERATION_RECONCILER_NAME = "monitoringFederationReconciler"
 
 type monitoringFederationReconciler struct {
 	client client.Client
-	scheme *runtime.Scheme
+	// Reads client certificate Secrets and federation namespaces not labeled for the cache.
+	uncachedClient client.Client
+	scheme         *runtime.Scheme
+	// Checks the federation targets in cluster-monitoring, if set.
+	targetHealth TargetHealthChecker
+	// Creates missing federation namespaces instead of waiting for them.
+	createFederationNamespaces bool
 }
 
 func (r *monitoringFederationReconciler) Reconcile(ctx context.Context,
@@ -38,6 +43,8 @@ func (r *monitoringFederationReconciler) Reconcile(ctx context.Context,
 	// thus we want to create the service monitor as late as possible to ensure that
 	// cluster-monitoring prom does not try to scrape a non-existent addon prometheus.
 
+	checkMonitoringFederationTenancy(addon)
+
 	result, err := r.ensureMonitoringFederation(ctx, addon)
 	if errors.Is(err, controllers.ErrNotOwnedByUs) {
 		log.Info("stopping", "reason", "monitoring federation namespace or serviceMonitor owned by something else")
@@ -53,13 +60,53 @@ func (r *monitoringFederationReconciler) Reconcile(ctx context.Context,
 	if err := r.ensureDeletionOfUnwantedMonitoringFederation(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure deletion of unwanted ServiceMonitors: %w", err)
 	}
+
+	r.checkFederationHealth(ctx, addon)
 	return reconcile.Result{}, nil
 }
 
+// Reports federations, which may select the series of other tenants.
+// They are still federated, as rejecting Addons admitted before
+// would leave them without metrics and without a way to migrate.
+func checkMonitoringFederationTenancy(addon *addonsv1alpha1.Addon) {
+	if !HasMonitoringFederation(addon) {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.MonitoringFederationTenancyViolated)
+		return
+	}
+
+	if err := controllers.ValidateMonitoringFederationTenancy(addon); err != nil {
+		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+			Type:               addonsv1alpha1.MonitoringFederationTenancyViolated,
+			Status:             metav1.ConditionTrue,
+			Reason:             addonsv1alpha1.AddonReasonFederationTenancyViolated,
+			Message:            err.Error(),
+			ObservedGeneration: addon.Generation,
+		})
+		return
+	}
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.MonitoringFederationTenancyViolated)
+}
+
 func (r *monitoringFederationReconciler) Name() string {
 	return MONITORING_FEDERATION_RECONCILER_NAME
 }
 
+func (r *monitoringFederationReconciler) Order() subReconcilerOrder {
+	return monitoringFederationReconcilerOrder
+}
+
+func (r *monitoringFederationReconciler) Skippable() bool {
+	return true
+}
+
+func (r *monitoringFederationReconciler) ConditionType() string {
+	return addonsv1alpha1.MonitoringFederationReady
+}
+
+func (r *monitoringFederationReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringFederation(addon)
+}
+
 // ensureMonitoringFederation inspects an addon's MonitoringFederation specification
 // and if it exists ensures that a ServiceMonitor is present in the desired monitoring
 // namespace.
@@ -75,6 +122,20 @@ func (r *monitoringFederationReconciler) ensureMonitoringFederation(ctx context.
 		return result, nil
 	}
 
+	result, err = r.ensureFederationNamespace(ctx, addon)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring federation Namespace: %w", err)
+	} else if !result.IsZero() {
+		return result, nil
+	}
+
+	result, err = r.ensureFederationAuth(ctx, addon)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring federation auth: %w", err)
+	} else if !result.IsZero() {
+		return result, nil
+	}
+
 	if err := r.ensureServiceMonitor(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("ensuring ServiceMonitor: %w", err)
 	}
@@ -90,35 +151,28 @@ func (r *monitoringFederationReconciler) ensureMonitoringNamespace(
 	}
 
 	actual, err := r.actualMonitoringNamespace(ctx, addon)
-	if k8sApiErrors.IsNotFound(err) {
-		return ctrl.Result{}, r.client.Create(ctx, desired)
-	} else if err != nil {
+	if err == nil {
+		if err := yieldToForeignController(ctx, r.client, actual, desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("adopting monitoring namespace: %w", err)
+		}
+	} else if !k8sApiErrors.IsNotFound(err) {
 		return ctrl.Result{}, fmt.Errorf("getting monitoring namespace: %w", err)
 	}
 
-	ownedByAddon := controllers.HasSameController(actual, desired)
-	labelsChanged := !equality.Semantic.DeepEqual(actual.Labels, desired.Labels)
-
-	if ownedByAddon && !labelsChanged {
-		return ctrl.Result{}, nil
+	if err := controllers.Apply(ctx, r.client, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying monitoring namespace: %w", err)
 	}
 
-	actual.OwnerReferences, actual.Labels = desired.OwnerReferences, desired.Labels
-
-	if err := r.client.Update(ctx, actual); err != nil {
-		return ctrl.Result{}, fmt.Errorf("updating monitoring namespace: %w", err)
-	}
-
-	if actual.Status.Phase == corev1.NamespaceActive {
+	if desired.Status.Phase == corev1.NamespaceActive {
 		return ctrl.Result{}, nil
 	}
 
-	reportUnreadyMonitoringFederation(addon, fmt.Sprintf("namespace %q is not active", actual.Name))
+	reportUnreadyMonitoringFederation(addon, fmt.Sprintf("namespace %q is not active", desired.Name))
 
 	// Previously this would trigger exit and move on to the next phase.
 	// However, given that the reconciliation is not complete an error should
 	// be returned to requeue the work.
-	return ctrl.Result{RequeueAfter: defaultRetryAfterTime}, nil
+	return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
 }
 
 func (r *monitoringFederationReconciler) desiredMonitoringNamespace(addon *addonsv1alpha1.Addon) (*corev1.Namespace, error) {
@@ -161,27 +215,15 @@ func (r *monitoringFederationReconciler) ensureServiceMonitor(ctx context.Contex
 	}
 
 	actual, err := r.actualServiceMonitor(ctx, addon)
-	if k8sApiErrors.IsNotFound(err) {
-		return r.client.Create(ctx, desired)
-	} else if err != nil {
+	if err == nil {
+		if err := yieldToForeignController(ctx, r.client, actual, desired); err != nil {
+			return fmt.Errorf("adopting ServiceMonitor: %w", err)
+		}
+	} else if !k8sApiErrors.IsNotFound(err) {
 		return fmt.Errorf("getting ServiceMonitor: %w", err)
 	}
 
-	currentLabels := labels.Set(actual.Labels)
-	newLabels := labels.Merge(currentLabels, labels.Set(desired.Labels))
-	ownedByAddon := controllers.HasSameController(actual, desired)
-	specChanged := !equality.Semantic.DeepEqual(actual.Spec, desired.Spec)
-	labelsChanged := !labels.Equals(currentLabels, newLabels)
-
-	if ownedByAddon && !specChanged && !labelsChanged {
-		return nil
-	}
-
-	actual.Spec = desired.Spec
-	actual.Labels = newLabels
-	actual.OwnerReferences = desired.OwnerReferences
-
-	return r.client.Update(ctx, actual)
+	return controllers.Apply(ctx, r.client, desired)
 }
 
 func (r *monitoringFederationReconciler) desiredServiceMonitor(addon *addonsv1alpha1.Addon) (*monitoringv1.ServiceMonitor, error) {
@@ -259,7 +301,7 @@ func (r *monitoringFederationReconciler) ensureDeletionOfUnwantedMonitoringFeder
 		}
 	}
 
-	return nil
+	return r.ensureDeletionOfUnwantedFederationNamespaces(ctx, addon)
 }
 
 // Get all ServiceMonitors that have common labels matching the given Addon resource
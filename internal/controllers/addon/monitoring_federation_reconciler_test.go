@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,8 +41,9 @@ func TestEnsureMonitoringFederation_MonitoringPresentInSpec_NotPresentInCluster(
 	c := testutil.NewClient()
 
 	r := &monitoringFederationReconciler{
-		client: c,
-		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		client:         c,
+		uncachedClient: newTestExistingFederationNamespaceClient(),
+		scheme:         testutil.NewTestSchemeWithAddonsv1alpha1(),
 	}
 
 	addon := testutil.NewTestAddonWithMonitoringFederation()
@@ -49,7 +51,7 @@ func TestEnsureMonitoringFederation_MonitoringPresentInSpec_NotPresentInCluster(
 
 	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&corev1.Namespace{}), mock.Anything).
 		Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, mock.IsType(&corev1.Namespace{}), mock.Anything).
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Namespace{}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			// mocked Namespace is immediately active
 			namespace := args.Get(1).(*corev1.Namespace)
@@ -57,9 +59,11 @@ func TestEnsureMonitoringFederation_MonitoringPresentInSpec_NotPresentInCluster(
 			assert.Equal(t, GetMonitoringNamespaceName(addon), namespace.Name)
 		}).
 		Return(nil)
+	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
 	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&monitoringv1.ServiceMonitor{}), mock.Anything).
 		Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, mock.IsType(&monitoringv1.ServiceMonitor{}), mock.Anything).
+	c.On("Patch", testutil.IsContext, mock.IsType(&monitoringv1.ServiceMonitor{}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			serviceMonitor := args.Get(1).(*monitoringv1.ServiceMonitor)
 			assert.Equal(t, "https", serviceMonitor.Spec.Endpoints[0].Port)
@@ -73,16 +77,37 @@ func TestEnsureMonitoringFederation_MonitoringPresentInSpec_NotPresentInCluster(
 	_, err := r.ensureMonitoringFederation(ctx, addon)
 	require.NoError(t, err)
 	c.AssertExpectations(t)
-	c.AssertNumberOfCalls(t, "Get", 2)
-	c.AssertNumberOfCalls(t, "Create", 2)
+	// Namespace, ServiceMonitor and the federation auth Secrets to clean up.
+	c.AssertNumberOfCalls(t, "Get", 4)
+	c.AssertNumberOfCalls(t, "Patch", 2)
+}
+
+func TestCheckMonitoringFederationTenancy(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	addon.Spec.Monitoring.Federation.Namespace = "openshift-monitoring"
+
+	checkMonitoringFederationTenancy(addon)
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.MonitoringFederationTenancyViolated)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, controllers.ErrMonitoringFederationNamespaceShared.Error(), cond.Message)
+	// The federation is reported, but not torn down.
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+
+	addon.Spec.Monitoring.Federation.Namespace = "addon-foo-prometheus"
+	checkMonitoringFederationTenancy(addon)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.MonitoringFederationTenancyViolated))
 }
 
 func TestEnsureMonitoringFederation_MonitoringPresentInSpec_PresentInCluster(t *testing.T) {
 	c := testutil.NewClient()
 
 	r := &monitoringFederationReconciler{
-		client: c,
-		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		client:         c,
+		uncachedClient: newTestExistingFederationNamespaceClient(),
+		scheme:         testutil.NewTestSchemeWithAddonsv1alpha1(),
 	}
 
 	addon := testutil.NewTestAddonWithMonitoringFederation()
@@ -104,6 +129,8 @@ func TestEnsureMonitoringFederation_MonitoringPresentInSpec_PresentInCluster(t *
 		}).
 		Return(nil)
 
+	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
 	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&monitoringv1.ServiceMonitor{}), mock.Anything).
 		Run(func(args mock.Arguments) {
 			namespacedName := args.Get(1).(types.NamespacedName)
@@ -150,10 +177,19 @@ func TestEnsureMonitoringFederation_MonitoringPresentInSpec_PresentInCluster(t *
 			}
 		}).
 		Return(nil)
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Namespace{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			namespace := args.Get(1).(*corev1.Namespace)
+			namespace.Status.Phase = corev1.NamespaceActive
+		}).
+		Return(nil)
+	c.On("Patch", testutil.IsContext, mock.IsType(&monitoringv1.ServiceMonitor{}), mock.Anything, mock.Anything).
+		Return(nil)
 
 	ctx := context.Background()
 	_, err := r.ensureMonitoringFederation(ctx, addon)
 	require.NoError(t, err)
+	c.AssertExpectations(t)
 }
 
 func TestEnsureMonitoringFederation_Adoption(t *testing.T) {
@@ -196,12 +232,22 @@ func TestEnsureMonitoringFederation_Adoption(t *testing.T) {
 				}).
 				Return(nil)
 
-			c.On("Update",
+			c.On("Patch",
 				testutil.IsContext,
 				testutil.IsCoreV1NamespacePtr,
+				mock.Anything,
 				mock.Anything).
-				Return(nil).
-				Maybe()
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1.Namespace).Status.Phase = corev1.NamespaceActive
+				}).
+				Return(nil)
+
+			c.On("Get",
+				testutil.IsContext,
+				mock.IsType(types.NamespacedName{}),
+				mock.IsType(&corev1.Secret{}),
+				mock.Anything).
+				Return(testutil.NewTestErrNotFound())
 
 			c.On("Get",
 				testutil.IsContext,
@@ -213,16 +259,17 @@ func TestEnsureMonitoringFederation_Adoption(t *testing.T) {
 				}).
 				Return(nil)
 
-			c.On("Update",
+			c.On("Patch",
 				testutil.IsContext,
 				testutil.IsMonitoringV1ServiceMonitorPtr,
+				mock.Anything,
 				mock.Anything).
-				Return(nil).
-				Maybe()
+				Return(nil)
 
 			rec := &monitoringFederationReconciler{
-				client: c,
-				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+				client:         c,
+				uncachedClient: newTestExistingFederationNamespaceClient(),
+				scheme:         testutil.NewTestSchemeWithAddonsv1alpha1(),
 			}
 
 			addonCopy := addon.DeepCopy()
@@ -235,6 +282,14 @@ func TestEnsureMonitoringFederation_Adoption(t *testing.T) {
 	}
 }
 
+// Returns a client finding the federation namespace, which is not created by the addon-operator.
+func newTestExistingFederationNamespaceClient() *testutil.Client {
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&corev1.Namespace{}), mock.Anything).
+		Return(nil)
+	return c
+}
+
 func addonOwnedTestMonitoringNamespace(addon *addonsv1alpha1.Addon) *corev1.Namespace {
 	ns := testMonitoringNamespace(addon)
 	_ = controllerutil.SetControllerReference(addon, ns, testutil.NewTestSchemeWithAddonsv1alpha1())
@@ -0,0 +1,330 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const MONITORING_PROBES_RECONCILER_NAME = "monitoringProbesReconciler"
+
+// Defaults of AddonProbes, also defaulted by the API server.
+const (
+	defaultMonitoringProbeModule           = "http_2xx"
+	defaultMonitoringProbeInterval         = 30 * time.Second
+	defaultMonitoringProbeFailureThreshold = 3
+)
+
+// ProbeResultChecker looks up the results of blackbox probes in a Prometheus instance.
+type ProbeResultChecker interface {
+	// Returns true, if all probes of the job failed within the given window.
+	// Jobs without results within the window are not failing.
+	ProbeFailing(ctx context.Context, job string, window time.Duration) (bool, error)
+}
+
+// Renders the Probes of .spec.monitoring into the monitoring namespace,
+// where cluster-monitoring scrapes them via the platform blackbox exporter,
+// and reports the Addon as unavailable while a probe keeps failing.
+type monitoringProbesReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// Address of the blackbox exporter, e.g. "blackbox-exporter.openshift-monitoring.svc:9115".
+	proberURL string
+	// Looks up probe results in cluster-monitoring, if set.
+	results ProbeResultChecker
+	// Probe results looked up last per Addon.
+	checked *monitoringProbeResults
+}
+
+func (r *monitoringProbesReconciler) Name() string {
+	return MONITORING_PROBES_RECONCILER_NAME
+}
+
+func (r *monitoringProbesReconciler) Order() subReconcilerOrder {
+	return monitoringProbesReconcilerOrder
+}
+
+// Failing probes must not hold back reconciling the addon itself.
+func (r *monitoringProbesReconciler) Independent() bool {
+	return true
+}
+
+func (r *monitoringProbesReconciler) ConditionType() string {
+	return addonsv1alpha1.MonitoringProbesReady
+}
+
+func (r *monitoringProbesReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringProbes(addon)
+}
+
+func (r *monitoringProbesReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if !HasMonitoringFederation(addon) {
+		// The monitoring namespace is removed together with the federation,
+		// taking all Probes with it.
+		if HasMonitoringProbes(addon) {
+			reportInvalidMonitoringProbes(addon, "probes are scraped in the monitoring namespace and require .spec.monitoring.federation")
+			return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+	if HasMonitoringProbes(addon) && len(r.proberURL) == 0 {
+		reportInvalidMonitoringProbes(addon, "no blackbox exporter is configured")
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+	}
+
+	desired, err := r.desiredProbes(addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	for _, probe := range desired {
+		if err := controllers.Apply(ctx, r.client, probe); err != nil {
+			return ctrl.Result{}, fmt.Errorf("applying Probe: %w", err)
+		}
+	}
+	if err := r.deleteUnwantedProbes(ctx, addon, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting unwanted Probes: %w", err)
+	}
+
+	return r.checkProbeResults(ctx, addon)
+}
+
+func (r *monitoringProbesReconciler) desiredProbes(
+	addon *addonsv1alpha1.Addon) ([]*monitoringv1.Probe, error) {
+	if !HasMonitoringProbes(addon) {
+		return nil, nil
+	}
+
+	var desired []*monitoringv1.Probe
+	for _, p := range addon.Spec.Monitoring.Probes {
+		name := GetMonitoringProbeName(addon, p)
+		probe := &monitoringv1.Probe{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: GetMonitoringNamespaceName(addon),
+			},
+			Spec: monitoringv1.ProbeSpec{
+				JobName: name,
+				ProberSpec: monitoringv1.ProberSpec{
+					URL: r.proberURL,
+				},
+				Module:   getMonitoringProbeModule(p),
+				Interval: monitoringv1.Duration(model.Duration(getMonitoringProbeInterval(p)).String()),
+				Targets: monitoringv1.ProbeTargets{
+					StaticConfig: &monitoringv1.ProbeTargetStaticConfig{
+						Targets: []string{p.URL},
+					},
+				},
+			},
+		}
+
+		controllers.AddCommonLabels(probe, addon)
+		if err := controllerutil.SetControllerReference(addon, probe, r.scheme); err != nil {
+			return nil, fmt.Errorf("setting controller reference on Probe: %w", err)
+		}
+		desired = append(desired, probe)
+	}
+	return desired, nil
+}
+
+func (r *monitoringProbesReconciler) deleteUnwantedProbes(
+	ctx context.Context, addon *addonsv1alpha1.Addon, desired []*monitoringv1.Probe) error {
+	wanted := map[client.ObjectKey]struct{}{}
+	for _, probe := range desired {
+		wanted[client.ObjectKeyFromObject(probe)] = struct{}{}
+	}
+
+	current := &monitoringv1.ProbeList{}
+	if err := r.client.List(ctx, current, client.MatchingLabelsSelector{
+		Selector: controllers.CommonLabelsAsLabelSelector(addon),
+	}); err != nil {
+		return fmt.Errorf("listing Probes: %w", err)
+	}
+	for _, probe := range current.Items {
+		if _, ok := wanted[client.ObjectKeyFromObject(probe)]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, probe); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting Probe: %w", err)
+		}
+	}
+	return nil
+}
+
+// Reports the addon as unavailable, while probes fail for longer than their failure threshold.
+// Failing lookups are logged and don't affect the addon, as probe results are best effort.
+// Results are looked up once per probe interval only, as no new ones come in between.
+func (r *monitoringProbesReconciler) checkProbeResults(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if r.results == nil || !HasMonitoringProbes(addon) {
+		r.checked.forget(addon.Name)
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	checked, ok := r.checked.get(addon.Name, addon.Generation, now)
+	if !ok {
+		checked = r.lookupProbeResults(ctx, addon, now)
+		r.checked.set(addon.Name, checked)
+	}
+
+	if len(checked.failing) == 0 {
+		return ctrl.Result{}, nil
+	}
+	reportFailingMonitoringProbes(addon, strings.Join(checked.failing, ", "))
+	// Check again, as soon as the next probe result may be in.
+	return ctrl.Result{RequeueAfter: checked.nextCheckAt.Sub(now)}, nil
+}
+
+func (r *monitoringProbesReconciler) lookupProbeResults(ctx context.Context,
+	addon *addonsv1alpha1.Addon, now time.Time) monitoringProbeResult {
+	var (
+		failing     []string
+		minInterval time.Duration
+	)
+	for _, p := range addon.Spec.Monitoring.Probes {
+		interval := getMonitoringProbeInterval(p)
+		if minInterval == 0 || interval < minInterval {
+			minInterval = interval
+		}
+		window := interval * time.Duration(getMonitoringProbeFailureThreshold(p))
+		failed, err := r.results.ProbeFailing(ctx, GetMonitoringProbeName(addon, p), window)
+		if err != nil {
+			controllers.LoggerFromContext(ctx).Error(err, "checking probe results", "probe", p.Name)
+			continue
+		}
+		if failed {
+			failing = append(failing, fmt.Sprintf("%s (%s)", p.Name, p.URL))
+		}
+	}
+	sort.Strings(failing)
+
+	return monitoringProbeResult{
+		generation:  addon.Generation,
+		failing:     failing,
+		nextCheckAt: now.Add(minInterval),
+	}
+}
+
+// Latest probe results per Addon.
+type monitoringProbeResults struct {
+	mux     sync.Mutex
+	results map[string]monitoringProbeResult
+}
+
+type monitoringProbeResult struct {
+	// Generation of the Addon the results were looked up for.
+	generation int64
+	failing    []string
+	// Results are looked up again after the shortest probe interval of the Addon.
+	nextCheckAt time.Time
+}
+
+func newMonitoringProbeResults() *monitoringProbeResults {
+	return &monitoringProbeResults{results: map[string]monitoringProbeResult{}}
+}
+
+// Returns the last results of the Addon, unless they are due to be looked up again.
+func (c *monitoringProbeResults) get(
+	addonName string, generation int64, now time.Time) (monitoringProbeResult, bool) {
+	if c == nil {
+		return monitoringProbeResult{}, false
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	result, ok := c.results[addonName]
+	if !ok || result.generation != generation || !now.Before(result.nextCheckAt) {
+		return monitoringProbeResult{}, false
+	}
+	return result, true
+}
+
+func (c *monitoringProbeResults) set(addonName string, result monitoringProbeResult) {
+	if c == nil {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.results[addonName] = result
+}
+
+func (c *monitoringProbeResults) forget(addonName string) {
+	if c == nil {
+		return
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.results, addonName)
+}
+
+func getMonitoringProbeModule(probe addonsv1alpha1.AddonProbe) string {
+	if len(probe.Module) == 0 {
+		return defaultMonitoringProbeModule
+	}
+	return probe.Module
+}
+
+func getMonitoringProbeInterval(probe addonsv1alpha1.AddonProbe) time.Duration {
+	if probe.Interval == nil || probe.Interval.Duration <= 0 {
+		return defaultMonitoringProbeInterval
+	}
+	return probe.Interval.Duration
+}
+
+func getMonitoringProbeFailureThreshold(probe addonsv1alpha1.AddonProbe) int32 {
+	if probe.FailureThreshold <= 0 {
+		return defaultMonitoringProbeFailureThreshold
+	}
+	return probe.FailureThreshold
+}
+
+// prometheusProbeResultChecker looks up probe results using the Prometheus HTTP API.
+type prometheusProbeResultChecker struct {
+	api promv1.API
+}
+
+// Creates a ProbeResultChecker querying the Prometheus at the given URL.
+func NewPrometheusProbeResultChecker(prometheusURL string) (ProbeResultChecker, error) {
+	api, err := newPrometheusAPI(prometheusURL)
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusProbeResultChecker{api: api}, nil
+}
+
+func (c *prometheusProbeResultChecker) ProbeFailing(
+	ctx context.Context, job string, window time.Duration,
+) (bool, error) {
+	// A single successful probe within the window resets the failures.
+	query := fmt.Sprintf(`max_over_time(probe_success{job=%q}[%s])`, job, model.Duration(window))
+	result, _, err := c.api.Query(ctx, query, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("querying probe results: %w", err)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return false, fmt.Errorf("unexpected result type %s", result.Type())
+	}
+	for _, sample := range vector {
+		if sample.Value > 0 {
+			return false, nil
+		}
+	}
+	return len(vector) > 0, nil
+}
@@ -0,0 +1,205 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type probeResultCheckerMock struct {
+	mock.Mock
+}
+
+func (m *probeResultCheckerMock) ProbeFailing(
+	ctx context.Context, job string, window time.Duration,
+) (bool, error) {
+	args := m.Called(ctx, job, window)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestMonitoringProbesReconciler(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Failing       bool
+		ExpectRequeue time.Duration
+	}{
+		"succeeding probes": {},
+		"failing probes": {
+			Failing:       true,
+			ExpectRequeue: time.Minute,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+			addon.Spec.Monitoring.Probes = []addonsv1alpha1.AddonProbe{{
+				Name:             "api",
+				URL:              "https://api.addon.example.com/healthz",
+				Interval:         &metav1.Duration{Duration: time.Minute},
+				FailureThreshold: 5,
+			}}
+
+			c := testutil.NewClient()
+			var applied *monitoringv1.Probe
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&monitoringv1.Probe{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					applied = args.Get(1).(*monitoringv1.Probe).DeepCopy()
+				}).
+				Return(nil)
+			c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.ProbeList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*monitoringv1.ProbeList).Items = []*monitoringv1.Probe{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "addon-addon-foo-removed",
+							Namespace: GetMonitoringNamespaceName(addon),
+						},
+					}}
+				}).
+				Return(nil)
+			c.On("Delete", testutil.IsContext, mock.IsType(&monitoringv1.Probe{}), mock.Anything).
+				Return(nil)
+
+			results := &probeResultCheckerMock{}
+			results.On("ProbeFailing", testutil.IsContext, "addon-addon-foo-api", 5*time.Minute).
+				Return(tc.Failing, nil)
+
+			r := &monitoringProbesReconciler{
+				client:    c,
+				scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+				proberURL: "blackbox-exporter.openshift-monitoring.svc:9115",
+				results:   results,
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectRequeue, result.RequeueAfter)
+
+			require.NotNil(t, applied)
+			assert.Equal(t, "addon-addon-foo-api", applied.Name)
+			assert.Equal(t, GetMonitoringNamespaceName(addon), applied.Namespace)
+			assert.Equal(t, "addon-addon-foo-api", applied.Spec.JobName)
+			assert.Equal(t, "blackbox-exporter.openshift-monitoring.svc:9115", applied.Spec.ProberSpec.URL)
+			assert.Equal(t, "http_2xx", applied.Spec.Module)
+			assert.Equal(t, monitoringv1.Duration("1m"), applied.Spec.Interval)
+			assert.Equal(t, []string{"https://api.addon.example.com/healthz"},
+				applied.Spec.Targets.StaticConfig.Targets)
+			assert.True(t, metav1.IsControlledBy(applied, addon))
+			c.AssertNumberOfCalls(t, "Delete", 1)
+
+			available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			if !tc.Failing {
+				assert.Nil(t, available)
+				return
+			}
+			require.NotNil(t, available)
+			assert.Equal(t, metav1.ConditionFalse, available.Status)
+			assert.Equal(t, addonsv1alpha1.AddonReasonFailingMonitoringProbes, available.Reason)
+			assert.Equal(t, "Probes are failing: api (https://api.addon.example.com/healthz)", available.Message)
+		})
+	}
+}
+
+func TestMonitoringProbesReconciler_CheckProbeResultsOncePerInterval(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	addon.Spec.Monitoring.Probes = []addonsv1alpha1.AddonProbe{{
+		Name:     "api",
+		URL:      "https://api.addon.example.com/healthz",
+		Interval: &metav1.Duration{Duration: time.Minute},
+	}}
+
+	results := &probeResultCheckerMock{}
+	results.On("ProbeFailing", testutil.IsContext, "addon-addon-foo-api", 3*time.Minute).
+		Return(true, nil).Once()
+
+	r := &monitoringProbesReconciler{
+		results: results,
+		checked: newMonitoringProbeResults(),
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := r.checkProbeResults(context.Background(), addon)
+		require.NoError(t, err)
+		assert.Greater(t, result.RequeueAfter, time.Duration(0))
+		available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+		require.NotNil(t, available)
+		assert.Equal(t, addonsv1alpha1.AddonReasonFailingMonitoringProbes, available.Reason)
+	}
+	results.AssertNumberOfCalls(t, "ProbeFailing", 1)
+
+	// A new generation of the Addon is checked right away.
+	addon.Generation++
+	results.On("ProbeFailing", testutil.IsContext, "addon-addon-foo-api", 3*time.Minute).
+		Return(false, nil).Once()
+	result, err := r.checkProbeResults(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+	results.AssertNumberOfCalls(t, "ProbeFailing", 2)
+}
+
+func TestMonitoringProbesReconciler_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		NoFederation bool
+		ProberURL    string
+	}{
+		"probes require federation": {
+			NoFederation: true,
+			ProberURL:    "blackbox-exporter.openshift-monitoring.svc:9115",
+		},
+		"probes require a blackbox exporter": {},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+			addon.Spec.Monitoring.Probes = []addonsv1alpha1.AddonProbe{{
+				Name: "api",
+				URL:  "https://api.addon.example.com/healthz",
+			}}
+			if tc.NoFederation {
+				addon.Spec.Monitoring.Federation = nil
+			}
+
+			c := testutil.NewClient()
+			r := &monitoringProbesReconciler{
+				client:    c,
+				scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+				proberURL: tc.ProberURL,
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			assert.False(t, result.IsZero())
+			c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+			available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			require.NotNil(t, available)
+			assert.Equal(t, addonsv1alpha1.AddonReasonInvalidMonitoringProbes, available.Reason)
+		})
+	}
+}
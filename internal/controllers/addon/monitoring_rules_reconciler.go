@@ -0,0 +1,162 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/promrules"
+)
+
+const MONITORING_RULES_RECONCILER_NAME = "monitoringRulesReconciler"
+
+// Renders .spec.monitoring.rules into a PrometheusRule in the monitoring namespace,
+// where cluster-monitoring evaluates them against the federated metrics.
+// Rules may be registered with a Thanos Ruler instead.
+type monitoringRulesReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// Thanos Ruler configured on the AddonOperator.
+	thanosRuler *thanosRulerTarget
+}
+
+func (r *monitoringRulesReconciler) Name() string {
+	return MONITORING_RULES_RECONCILER_NAME
+}
+
+func (r *monitoringRulesReconciler) Order() subReconcilerOrder {
+	return monitoringRulesReconcilerOrder
+}
+
+func (r *monitoringRulesReconciler) Skippable() bool {
+	return true
+}
+
+// No sub-reconciler depends on the monitoring rules,
+// so invalid rules must not block the installation of the addon.
+func (r *monitoringRulesReconciler) Independent() bool {
+	return true
+}
+
+func (r *monitoringRulesReconciler) ConditionType() string {
+	return addonsv1alpha1.MonitoringRulesReady
+}
+
+func (r *monitoringRulesReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringRules(addon)
+}
+
+func (r *monitoringRulesReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	thanosRuler := r.thanosRuler.get()
+	if !HasMonitoringRules(addon) || !evaluateRulesInThanosRuler(addon) {
+		if err := r.ensureDeletionOfThanosRulerPrometheusRules(ctx, addon, nil); err != nil {
+			return ctrl.Result{}, fmt.Errorf("deleting unwanted Thanos Ruler PrometheusRule: %w", err)
+		}
+	}
+
+	if !HasMonitoringRules(addon) {
+		if err := r.ensureDeletionOfPrometheusRule(ctx, addon); err != nil {
+			return ctrl.Result{}, fmt.Errorf("deleting unwanted PrometheusRule: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Invalid rules would be rejected by the prometheus-operator,
+	// leaving previously applied rules in place is the safer choice.
+	if err := promrules.Validate(addon.Spec.Monitoring.Rules); err != nil {
+		reportInvalidMonitoringRules(addon, err.Error())
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+	}
+
+	if evaluateRulesInThanosRuler(addon) {
+		return r.reconcileThanosRulerRules(ctx, addon, thanosRuler)
+	}
+
+	if !HasMonitoringFederation(addon) {
+		reportInvalidMonitoringRules(addon, "rules are evaluated on federated metrics and require .spec.monitoring.federation")
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+	}
+
+	desired, err := r.desiredPrometheusRule(addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying PrometheusRule: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *monitoringRulesReconciler) desiredPrometheusRule(
+	addon *addonsv1alpha1.Addon) (*monitoringv1.PrometheusRule, error) {
+	prometheusRule := &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMonitoringRulesPrometheusRuleName(addon),
+			Namespace: GetMonitoringNamespaceName(addon),
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: getMonitoringRuleGroups(addon.Spec.Monitoring.Rules),
+		},
+	}
+
+	controllers.AddCommonLabels(prometheusRule, addon)
+
+	if err := controllerutil.SetControllerReference(addon, prometheusRule, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on PrometheusRule: %w", err)
+	}
+
+	return prometheusRule, nil
+}
+
+func (r *monitoringRulesReconciler) ensureDeletionOfPrometheusRule(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	key := client.ObjectKey{
+		Name:      GetMonitoringRulesPrometheusRuleName(addon),
+		Namespace: GetMonitoringNamespaceName(addon),
+	}
+
+	prometheusRule := &monitoringv1.PrometheusRule{}
+	if err := r.client.Get(ctx, key, prometheusRule); k8sApiErrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting PrometheusRule: %w", err)
+	}
+	return client.IgnoreNotFound(r.client.Delete(ctx, prometheusRule))
+}
+
+// Converts the rule groups of the Addon API to the
+// prometheus-operator types served by cluster-monitoring.
+func getMonitoringRuleGroups(groups []monv1.RuleGroup) []monitoringv1.RuleGroup {
+	converted := make([]monitoringv1.RuleGroup, 0, len(groups))
+	for _, group := range groups {
+		rules := make([]monitoringv1.Rule, 0, len(group.Rules))
+		for _, rule := range group.Rules {
+			rules = append(rules, monitoringv1.Rule{
+				Record:      rule.Record,
+				Alert:       rule.Alert,
+				Expr:        rule.Expr,
+				For:         monitoringv1.Duration(rule.For),
+				Labels:      rule.Labels,
+				Annotations: rule.Annotations,
+			})
+		}
+		converted = append(converted, monitoringv1.RuleGroup{
+			Name:                    group.Name,
+			Interval:                monitoringv1.Duration(group.Interval),
+			Rules:                   rules,
+			PartialResponseStrategy: group.PartialResponseStrategy,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,284 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestMonitoringRulesReconciler(t *testing.T) {
+	t.Parallel()
+
+	validRules := []monv1.RuleGroup{{
+		Name:     "addon",
+		Interval: "1m",
+		Rules: []monv1.Rule{{
+			Alert:  "AddonDown",
+			Expr:   intstr.FromString(`up{job="addon"} == 0`),
+			For:    "5m",
+			Labels: map[string]string{"severity": "critical"},
+		}},
+	}}
+	invalidRules := []monv1.RuleGroup{{
+		Name: "addon",
+		Rules: []monv1.Rule{{
+			Record: "addon:up:sum",
+			Expr:   intstr.FromString("sum(up"),
+		}},
+	}}
+
+	tests := map[string]struct {
+		Rules         []monv1.RuleGroup
+		NoFederation  bool
+		ExpectApplied bool
+		ExpectInvalid bool
+	}{
+		"applies valid rules": {
+			Rules:         validRules,
+			ExpectApplied: true,
+		},
+		"keeps PrometheusRule on invalid rules": {
+			Rules:         invalidRules,
+			ExpectInvalid: true,
+		},
+		"rules require federation": {
+			Rules:         validRules,
+			NoFederation:  true,
+			ExpectInvalid: true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+			addon.Spec.Monitoring.Rules = tc.Rules
+			if tc.NoFederation {
+				addon.Spec.Monitoring.Federation = nil
+			}
+
+			c := testutil.NewClient()
+			var applied *monitoringv1.PrometheusRule
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&monitoringv1.PrometheusRule{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					applied = args.Get(1).(*monitoringv1.PrometheusRule).DeepCopy()
+				}).
+				Return(nil).Maybe()
+			c.On("List", testutil.IsContext, mock.IsType(&monv1.PrometheusRuleList{}), mock.Anything).
+				Return(nil)
+
+			r := &monitoringRulesReconciler{
+				client: c,
+				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.ExpectInvalid {
+				assert.False(t, result.IsZero())
+				c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, available)
+				assert.Equal(t, metav1.ConditionFalse, available.Status)
+				assert.Equal(t, addonsv1alpha1.AddonReasonInvalidMonitoringRules, available.Reason)
+				return
+			}
+
+			assert.True(t, result.IsZero())
+			require.NotNil(t, applied)
+			assert.Equal(t, GetMonitoringRulesPrometheusRuleName(addon), applied.Name)
+			assert.Equal(t, GetMonitoringNamespaceName(addon), applied.Namespace)
+			assert.Equal(t, []monitoringv1.RuleGroup{{
+				Name:     "addon",
+				Interval: "1m",
+				Rules: []monitoringv1.Rule{{
+					Alert:  "AddonDown",
+					Expr:   intstr.FromString(`up{job="addon"} == 0`),
+					For:    "5m",
+					Labels: map[string]string{"severity": "critical"},
+				}},
+			}}, applied.Spec.Groups)
+			assert.True(t, metav1.IsControlledBy(applied, addon))
+		})
+	}
+}
+
+func TestMonitoringRulesReconciler_DeletesUnwantedPrometheusRule(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, client.ObjectKey{
+		Name:      GetMonitoringRulesPrometheusRuleName(addon),
+		Namespace: GetMonitoringNamespaceName(addon),
+	}, mock.IsType(&monitoringv1.PrometheusRule{}), mock.Anything).
+		Return(nil)
+	c.On("Delete", testutil.IsContext, mock.IsType(&monitoringv1.PrometheusRule{}), mock.Anything).
+		Return(nil)
+	// Rules left behind with a Thanos Ruler.
+	c.On("List", testutil.IsContext, mock.IsType(&monv1.PrometheusRuleList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*monv1.PrometheusRuleList).Items = []*monv1.PrometheusRule{
+				newTestThanosRulerPrometheusRule(t, addon, "thanos-ruler"),
+			}
+		}).
+		Return(nil)
+	c.On("Delete", testutil.IsContext, mock.IsType(&monv1.PrometheusRule{}), mock.Anything).
+		Return(nil)
+
+	r := &monitoringRulesReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	result, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+	c.AssertNumberOfCalls(t, "Delete", 2)
+}
+
+func newTestThanosRulerPrometheusRule(
+	t *testing.T, addon *addonsv1alpha1.Addon, namespace string) *monv1.PrometheusRule {
+	t.Helper()
+
+	prometheusRule := &monv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMonitoringRulesPrometheusRuleName(addon),
+			Namespace: namespace,
+		},
+	}
+	require.NoError(t, controllerutil.SetControllerReference(
+		addon, prometheusRule, testutil.NewTestSchemeWithAddonsv1alpha1()))
+	return prometheusRule
+}
+
+func TestMonitoringRulesReconciler_ThanosRuler(t *testing.T) {
+	t.Parallel()
+
+	rules := []monv1.RuleGroup{{
+		Name: "addon",
+		Rules: []monv1.Rule{{
+			Alert: "AddonDown",
+			Expr:  intstr.FromString(`up{job="addon"} == 0`),
+		}},
+	}}
+
+	tests := map[string]struct {
+		ThanosRuler   *addonsv1alpha1.AddonOperatorThanosRuler
+		Existing      []string
+		ExpectInvalid bool
+		ExpectDeletes int
+	}{
+		"registers rules with the Thanos Ruler": {
+			ThanosRuler: &addonsv1alpha1.AddonOperatorThanosRuler{
+				Namespace:    "thanos-ruler",
+				RuleSelector: map[string]string{"thanos-ruler": "addons"},
+			},
+			Existing: []string{"thanos-ruler"},
+			// Local rules are removed, so rules are not evaluated twice.
+			ExpectDeletes: 1,
+		},
+		"moves rules to another Thanos Ruler": {
+			ThanosRuler: &addonsv1alpha1.AddonOperatorThanosRuler{
+				Namespace:    "thanos-ruler",
+				RuleSelector: map[string]string{"thanos-ruler": "addons"},
+			},
+			Existing:      []string{"thanos-ruler", "old-thanos-ruler"},
+			ExpectDeletes: 2,
+		},
+		"requires a Thanos Ruler": {
+			Existing:      []string{"thanos-ruler"},
+			ExpectInvalid: true,
+			ExpectDeletes: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithMonitoringFederation()
+			addon.Spec.Monitoring.Federation = nil
+			addon.Spec.Monitoring.MonitoringStack = &addonsv1alpha1.MonitoringStackSpec{
+				RuleEvaluation: addonsv1alpha1.AddonRuleEvaluationThanosRuler,
+			}
+			addon.Spec.Monitoring.Rules = rules
+
+			c := testutil.NewClient()
+			var applied *monv1.PrometheusRule
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&monv1.PrometheusRule{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					applied = args.Get(1).(*monv1.PrometheusRule).DeepCopy()
+				}).
+				Return(nil).Maybe()
+			c.On("Get", testutil.IsContext, client.ObjectKey{
+				Name:      GetMonitoringRulesPrometheusRuleName(addon),
+				Namespace: GetMonitoringNamespaceName(addon),
+			}, mock.IsType(&monitoringv1.PrometheusRule{}), mock.Anything).
+				Return(nil).Maybe()
+			c.On("Delete", testutil.IsContext, mock.IsType(&monitoringv1.PrometheusRule{}), mock.Anything).
+				Return(nil).Maybe()
+			c.On("List", testutil.IsContext, mock.IsType(&monv1.PrometheusRuleList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*monv1.PrometheusRuleList)
+					for _, namespace := range tc.Existing {
+						list.Items = append(list.Items, newTestThanosRulerPrometheusRule(t, addon, namespace))
+					}
+				}).
+				Return(nil)
+			c.On("Delete", testutil.IsContext, mock.IsType(&monv1.PrometheusRule{}), mock.Anything).
+				Return(nil).Maybe()
+
+			thanosRuler := &thanosRulerTarget{}
+			thanosRuler.set(tc.ThanosRuler)
+			r := &monitoringRulesReconciler{
+				client:      c,
+				scheme:      testutil.NewTestSchemeWithAddonsv1alpha1(),
+				thanosRuler: thanosRuler,
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.ExpectInvalid {
+				assert.False(t, result.IsZero())
+				c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, available)
+				assert.Equal(t, addonsv1alpha1.AddonReasonInvalidMonitoringRules, available.Reason)
+				c.AssertNumberOfCalls(t, "Delete", tc.ExpectDeletes)
+				return
+			}
+
+			assert.True(t, result.IsZero())
+			require.NotNil(t, applied)
+			assert.Equal(t, "thanos-ruler", applied.Namespace)
+			assert.Equal(t, "addons", applied.Labels["thanos-ruler"])
+			assert.Equal(t, rules, applied.Spec.Groups)
+			assert.True(t, metav1.IsControlledBy(applied, addon))
+			c.AssertNumberOfCalls(t, "Delete", tc.ExpectDeletes)
+		})
+	}
+}
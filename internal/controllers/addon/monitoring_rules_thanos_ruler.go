@@ -0,0 +1,149 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Thanos Ruler evaluating the monitoring rules of Addons, configured via the AddonOperator.
+type thanosRulerTarget struct {
+	mux    sync.RWMutex
+	config *addonsv1alpha1.AddonOperatorThanosRuler
+}
+
+// Replaces the Thanos Ruler. A nil config removes it.
+// Returns true, if the Thanos Ruler changed.
+func (t *thanosRulerTarget) set(config *addonsv1alpha1.AddonOperatorThanosRuler) (changed bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	changed = !reflect.DeepEqual(t.config, config)
+	t.config = config.DeepCopy()
+	return changed
+}
+
+// Returns the configured Thanos Ruler or nil.
+func (t *thanosRulerTarget) get() *addonsv1alpha1.AddonOperatorThanosRuler {
+	if t == nil {
+		return nil
+	}
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+	return t.config.DeepCopy()
+}
+
+// Sets the Thanos Ruler evaluating the monitoring rules of Addons. Concurrency safe.
+func (r *AddonReconciler) SetThanosRuler(config *addonsv1alpha1.AddonOperatorThanosRuler) {
+	if r.thanosRuler.set(config) {
+		// Rules of all Addons have to be moved to the new Thanos Ruler.
+		r.reconciled.forgetAll()
+	}
+}
+
+// Whether the rules of the Addon are evaluated by the Thanos Ruler.
+func evaluateRulesInThanosRuler(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringStack(addon) &&
+		addon.Spec.Monitoring.MonitoringStack.RuleEvaluation == addonsv1alpha1.AddonRuleEvaluationThanosRuler
+}
+
+func (r *monitoringRulesReconciler) reconcileThanosRulerRules(ctx context.Context,
+	addon *addonsv1alpha1.Addon, thanosRuler *addonsv1alpha1.AddonOperatorThanosRuler) (ctrl.Result, error) {
+	if thanosRuler == nil {
+		// Rules registered with a removed Thanos Ruler are no longer evaluated.
+		if err := r.ensureDeletionOfThanosRulerPrometheusRules(ctx, addon, nil); err != nil {
+			return ctrl.Result{}, fmt.Errorf("deleting Thanos Ruler PrometheusRules: %w", err)
+		}
+		reportInvalidMonitoringRules(addon, "no Thanos Ruler is configured on the AddonOperator")
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+	}
+
+	desired, err := r.desiredThanosRulerPrometheusRule(addon, thanosRuler)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying Thanos Ruler PrometheusRule: %w", err)
+	}
+	// The Thanos Ruler may have moved to another namespace.
+	if err := r.ensureDeletionOfThanosRulerPrometheusRules(ctx, addon, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting stale Thanos Ruler PrometheusRules: %w", err)
+	}
+
+	// Rules must not be evaluated twice.
+	if err := r.ensureDeletionOfPrometheusRule(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting local PrometheusRule: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// PrometheusRule selected by the Thanos Ruler.
+// Uses the API group of the observability-operator managing the Thanos Ruler.
+func (r *monitoringRulesReconciler) desiredThanosRulerPrometheusRule(addon *addonsv1alpha1.Addon,
+	thanosRuler *addonsv1alpha1.AddonOperatorThanosRuler) (*monv1.PrometheusRule, error) {
+	prometheusRule := &monv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMonitoringRulesPrometheusRuleName(addon),
+			Namespace: thanosRuler.Namespace,
+			Labels:    map[string]string{},
+		},
+		Spec: monv1.PrometheusRuleSpec{
+			Groups: addon.Spec.Monitoring.Rules,
+		},
+	}
+
+	for k, v := range thanosRuler.RuleSelector {
+		prometheusRule.Labels[k] = v
+	}
+	controllers.AddCommonLabels(prometheusRule, addon)
+
+	if err := controllerutil.SetControllerReference(addon, prometheusRule, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on PrometheusRule: %w", err)
+	}
+
+	return prometheusRule, nil
+}
+
+// Removes the rules of the Addon from all Thanos Rulers, except for the given PrometheusRule.
+// PrometheusRules are found by their labels, so rules registered with
+// a previously configured Thanos Ruler are removed as well.
+func (r *monitoringRulesReconciler) ensureDeletionOfThanosRulerPrometheusRules(ctx context.Context,
+	addon *addonsv1alpha1.Addon, keep *monv1.PrometheusRule) error {
+	prometheusRules := &monv1.PrometheusRuleList{}
+	err := r.client.List(ctx, prometheusRules, client.MatchingLabelsSelector{
+		Selector: controllers.CommonLabelsAsLabelSelector(addon),
+	})
+	if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+		// The PrometheusRule API is missing without the observability-operator.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("listing Thanos Ruler PrometheusRules: %w", err)
+	}
+
+	for i := range prometheusRules.Items {
+		prometheusRule := prometheusRules.Items[i]
+		if prometheusRule.Name != GetMonitoringRulesPrometheusRuleName(addon) ||
+			!metav1.IsControlledBy(prometheusRule, addon) {
+			continue
+		}
+		if keep != nil && client.ObjectKeyFromObject(prometheusRule) == client.ObjectKeyFromObject(keep) {
+			continue
+		}
+		if err := r.client.Delete(ctx, prometheusRule); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting Thanos Ruler PrometheusRule %s: %w",
+				client.ObjectKeyFromObject(prometheusRule), err)
+		}
+	}
+	return nil
+}
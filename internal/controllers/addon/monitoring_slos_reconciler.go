@@ -0,0 +1,269 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/slo"
+)
+
+const MONITORING_SLOS_RECONCILER_NAME = "monitoringSLOsReconciler"
+
+// SLOEvaluator looks up the error ratios recorded for the SLOs of Addons in a Prometheus instance.
+type SLOEvaluator interface {
+	// Returns the recorded error ratio of the SLO over the given window,
+	// or false, if no ratio has been recorded yet.
+	ErrorRatio(ctx context.Context, addon, slo string, window time.Duration) (float64, bool, error)
+}
+
+// Renders the event rates of .spec.monitoring.slos into recording rules in the monitoring namespace,
+// where cluster-monitoring evaluates them against the federated metrics,
+// and publishes the remaining error budgets in .status.slo.
+// Error budgets are evaluated whenever the reconciler runs,
+// at the latest with the drift detection of the Addon.
+type monitoringSLOsReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// Looks up the recorded error ratios in cluster-monitoring, if set.
+	evaluator SLOEvaluator
+}
+
+func (r *monitoringSLOsReconciler) Name() string {
+	return MONITORING_SLOS_RECONCILER_NAME
+}
+
+func (r *monitoringSLOsReconciler) Order() subReconcilerOrder {
+	return monitoringSLOsReconcilerOrder
+}
+
+func (r *monitoringSLOsReconciler) Skippable() bool {
+	return true
+}
+
+// No sub-reconciler depends on the SLOs,
+// so invalid SLOs must not block the installation of the addon.
+func (r *monitoringSLOsReconciler) Independent() bool {
+	return true
+}
+
+func (r *monitoringSLOsReconciler) ConditionType() string {
+	return addonsv1alpha1.MonitoringSLOsReady
+}
+
+func (r *monitoringSLOsReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringSLOs(addon)
+}
+
+func (r *monitoringSLOsReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if !HasMonitoringSLOs(addon) {
+		addon.Status.SLO = nil
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.SLOBreached)
+		if err := r.ensureDeletionOfPrometheusRule(ctx, addon); err != nil {
+			return ctrl.Result{}, fmt.Errorf("deleting unwanted SLO PrometheusRule: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	slos := addon.Spec.Monitoring.SLOs
+	if err := slo.Validate(addon, slos); err != nil {
+		reportInvalidMonitoringSLOs(ctx, err.Error())
+		return ctrl.Result{}, nil
+	}
+	if !HasMonitoringFederation(addon) {
+		reportInvalidMonitoringSLOs(ctx, "event rates are recorded on federated metrics and require .spec.monitoring.federation")
+		return ctrl.Result{}, nil
+	}
+
+	desired, err := r.desiredPrometheusRule(addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := controllers.Apply(ctx, r.client, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying SLO PrometheusRule: %w", err)
+	}
+
+	r.evaluateSLOs(ctx, addon)
+	return ctrl.Result{}, nil
+}
+
+func (r *monitoringSLOsReconciler) desiredPrometheusRule(
+	addon *addonsv1alpha1.Addon) (*monitoringv1.PrometheusRule, error) {
+	prometheusRule := &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetMonitoringSLOsPrometheusRuleName(addon),
+			Namespace: GetMonitoringNamespaceName(addon),
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: getMonitoringRuleGroups(slo.RuleGroups(addon, addon.Spec.Monitoring.SLOs)),
+		},
+	}
+
+	controllers.AddCommonLabels(prometheusRule, addon)
+
+	if err := controllerutil.SetControllerReference(addon, prometheusRule, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference on SLO PrometheusRule: %w", err)
+	}
+
+	return prometheusRule, nil
+}
+
+func (r *monitoringSLOsReconciler) ensureDeletionOfPrometheusRule(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	key := client.ObjectKey{
+		Name:      GetMonitoringSLOsPrometheusRuleName(addon),
+		Namespace: GetMonitoringNamespaceName(addon),
+	}
+
+	prometheusRule := &monitoringv1.PrometheusRule{}
+	if err := r.client.Get(ctx, key, prometheusRule); k8sApiErrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting SLO PrometheusRule: %w", err)
+	}
+	return client.IgnoreNotFound(r.client.Delete(ctx, prometheusRule))
+}
+
+// Publishes the error budgets of the SLOs and reports SLOs burning their budget too fast.
+// Failing lookups are logged and keep the last published state, as SLOs are best effort.
+func (r *monitoringSLOsReconciler) evaluateSLOs(
+	ctx context.Context, addon *addonsv1alpha1.Addon) {
+	if r.evaluator == nil {
+		return
+	}
+	log := controllers.LoggerFromContext(ctx)
+
+	previous := map[string]addonsv1alpha1.AddonSLOStatus{}
+	for _, status := range addon.Status.SLO {
+		previous[status.Name] = status
+	}
+
+	var (
+		statuses []addonsv1alpha1.AddonSLOStatus
+		breached []string
+		now      = metav1.Now()
+	)
+	for _, s := range addon.Spec.Monitoring.SLOs {
+		budget, ok, err := r.evaluateSLO(ctx, addon, s)
+		if err != nil {
+			log.Error(err, "evaluating SLO", "slo", s.Name)
+		}
+		if err != nil || !ok {
+			if status, ok := previous[s.Name]; ok {
+				statuses = append(statuses, status)
+			}
+			continue
+		}
+
+		statuses = append(statuses, addonsv1alpha1.AddonSLOStatus{
+			Name:                 s.Name,
+			ErrorBudgetRemaining: formatSLOValue(budget.Remaining * 100),
+			BurnRate:             formatSLOValue(budget.BurnRate),
+			LastEvaluationTime:   now,
+		})
+		// Validated before.
+		maxBurnRate, _ := slo.MaxBurnRate(s)
+		if budget.BurnRate > maxBurnRate {
+			breached = append(breached, fmt.Sprintf("%s (burn rate %s)", s.Name, formatSLOValue(budget.BurnRate)))
+		}
+	}
+
+	addon.Status.SLO = statuses
+	reportSLOBreaches(addon, breached)
+}
+
+func (r *monitoringSLOsReconciler) evaluateSLO(
+	ctx context.Context, addon *addonsv1alpha1.Addon, s addonsv1alpha1.AddonSLO,
+) (slo.Budget, bool, error) {
+	windowErrorRatio, ok, err := r.evaluator.ErrorRatio(ctx, addon.Name, s.Name, slo.Window(s))
+	if err != nil || !ok {
+		return slo.Budget{}, false, err
+	}
+	burnRateErrorRatio, ok, err := r.evaluator.ErrorRatio(ctx, addon.Name, s.Name, slo.BurnRateWindow)
+	if err != nil || !ok {
+		return slo.Budget{}, false, err
+	}
+	budget, err := slo.Evaluate(s, windowErrorRatio, burnRateErrorRatio)
+	return budget, err == nil, err
+}
+
+func reportSLOBreaches(addon *addonsv1alpha1.Addon, breached []string) {
+	if len(breached) == 0 {
+		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+			Type:               addonsv1alpha1.SLOBreached,
+			Status:             metav1.ConditionFalse,
+			Reason:             addonsv1alpha1.AddonReasonSLOBurnRateWithinLimit,
+			Message:            "All SLOs burn their error budget within their maximum burn rate",
+			ObservedGeneration: addon.Generation,
+		})
+		return
+	}
+
+	sort.Strings(breached)
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.SLOBreached,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonSLOBurnRateExceeded,
+		Message:            fmt.Sprintf("SLOs exceed their maximum burn rate: %s", strings.Join(breached, ", ")),
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+// Formats values published in .status.slo, e.g. "99.95".
+func formatSLOValue(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// prometheusSLOEvaluator looks up recorded error ratios using the Prometheus HTTP API.
+type prometheusSLOEvaluator struct {
+	api promv1.API
+}
+
+// Creates an SLOEvaluator querying the Prometheus at the given URL.
+func NewPrometheusSLOEvaluator(prometheusURL string) (SLOEvaluator, error) {
+	api, err := newPrometheusAPI(prometheusURL)
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusSLOEvaluator{api: api}, nil
+}
+
+func (e *prometheusSLOEvaluator) ErrorRatio(
+	ctx context.Context, addon, name string, window time.Duration,
+) (float64, bool, error) {
+	result, _, err := e.api.Query(ctx, slo.ErrorRatioQuery(addon, name, window), time.Now())
+	if err != nil {
+		return 0, false, fmt.Errorf("querying error ratio: %w", err)
+	}
+
+	vector, ok := result.(model.Vector)
+	if !ok {
+		return 0, false, fmt.Errorf("unexpected result type %s", result.Type())
+	}
+	if len(vector) == 0 {
+		return 0, false, nil
+	}
+	// Without any events within the window, none of them failed.
+	if math.IsNaN(float64(vector[0].Value)) {
+		return 0, true, nil
+	}
+	return float64(vector[0].Value), true, nil
+}
@@ -0,0 +1,208 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/slo"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type sloEvaluatorMock struct {
+	mock.Mock
+}
+
+func (m *sloEvaluatorMock) ErrorRatio(
+	ctx context.Context, addon, slo string, window time.Duration,
+) (float64, bool, error) {
+	args := m.Called(ctx, addon, slo, window)
+	return args.Get(0).(float64), args.Bool(1), args.Error(2)
+}
+
+func newTestAddonWithMonitoringSLOs() *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	addon.Spec.Monitoring.SLOs = []addonsv1alpha1.AddonSLO{{
+		Name:   "availability",
+		Target: "99.9",
+		Indicator: addonsv1alpha1.AddonSLOIndicator{
+			ErrorQuery: `http_requests_total{code=~"5.."}`,
+			TotalQuery: `http_requests_total`,
+		},
+	}}
+	return addon
+}
+
+func TestMonitoringSLOsReconciler(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		BurnRateErrorRatio     float64
+		ExpectBurnRate         string
+		ExpectBreached         bool
+		ExpectBreachedMessage  string
+		ExpectBudgetRemaining  string
+		ExpectConditionReason  string
+		ExpectConditionMessage string
+	}{
+		"within burn rate": {
+			BurnRateErrorRatio:    0.002,
+			ExpectBurnRate:        "2.00",
+			ExpectBudgetRemaining: "75.00",
+			ExpectConditionReason: addonsv1alpha1.AddonReasonSLOBurnRateWithinLimit,
+		},
+		"burn rate exceeded": {
+			BurnRateErrorRatio:     0.02,
+			ExpectBurnRate:         "20.00",
+			ExpectBreached:         true,
+			ExpectBudgetRemaining:  "75.00",
+			ExpectConditionReason:  addonsv1alpha1.AddonReasonSLOBurnRateExceeded,
+			ExpectConditionMessage: "SLOs exceed their maximum burn rate: availability (burn rate 20.00)",
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newTestAddonWithMonitoringSLOs()
+
+			c := testutil.NewClient()
+			var applied *monitoringv1.PrometheusRule
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&monitoringv1.PrometheusRule{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					applied = args.Get(1).(*monitoringv1.PrometheusRule).DeepCopy()
+				}).
+				Return(nil)
+
+			evaluator := &sloEvaluatorMock{}
+			evaluator.On("ErrorRatio", testutil.IsContext, "addon-foo", "availability", slo.DefaultWindow).
+				Return(0.00025, true, nil)
+			evaluator.On("ErrorRatio", testutil.IsContext, "addon-foo", "availability", slo.BurnRateWindow).
+				Return(tc.BurnRateErrorRatio, true, nil)
+
+			r := &monitoringSLOsReconciler{
+				client:    c,
+				scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+				evaluator: evaluator,
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			// Skippable reconcilers run again with the drift detection.
+			assert.True(t, result.IsZero())
+
+			require.NotNil(t, applied)
+			assert.Equal(t, "addon-addon-foo-slos", applied.Name)
+			assert.Equal(t, GetMonitoringNamespaceName(addon), applied.Namespace)
+			require.Len(t, applied.Spec.Groups, 1)
+			assert.Len(t, applied.Spec.Groups[0].Rules, 2)
+			assert.True(t, metav1.IsControlledBy(applied, addon))
+
+			require.Len(t, addon.Status.SLO, 1)
+			status := addon.Status.SLO[0]
+			assert.Equal(t, "availability", status.Name)
+			assert.Equal(t, tc.ExpectBudgetRemaining, status.ErrorBudgetRemaining)
+			assert.Equal(t, tc.ExpectBurnRate, status.BurnRate)
+			assert.False(t, status.LastEvaluationTime.IsZero())
+
+			breached := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.SLOBreached)
+			require.NotNil(t, breached)
+			assert.Equal(t, tc.ExpectBreached, breached.Status == metav1.ConditionTrue)
+			assert.Equal(t, tc.ExpectConditionReason, breached.Reason)
+			if tc.ExpectBreached {
+				assert.Equal(t, tc.ExpectConditionMessage, breached.Message)
+			}
+			assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+		})
+	}
+}
+
+func TestMonitoringSLOsReconciler_KeepsStatusWithoutRecordedRatio(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithMonitoringSLOs()
+	previous := addonsv1alpha1.AddonSLOStatus{
+		Name:                 "availability",
+		ErrorBudgetRemaining: "50.00",
+		BurnRate:             "1.00",
+	}
+	addon.Status.SLO = []addonsv1alpha1.AddonSLOStatus{previous}
+
+	c := testutil.NewClient()
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&monitoringv1.PrometheusRule{}), client.Apply, mock.Anything).
+		Return(nil)
+
+	evaluator := &sloEvaluatorMock{}
+	evaluator.On("ErrorRatio", testutil.IsContext, "addon-foo", "availability", slo.DefaultWindow).
+		Return(0.0, false, nil)
+
+	r := &monitoringSLOsReconciler{
+		client:    c,
+		scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+		evaluator: evaluator,
+	}
+
+	_, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, []addonsv1alpha1.AddonSLOStatus{previous}, addon.Status.SLO)
+}
+
+func TestMonitoringSLOsReconciler_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Modify func(addon *addonsv1alpha1.Addon)
+	}{
+		"slos require federation": {
+			Modify: func(addon *addonsv1alpha1.Addon) {
+				addon.Spec.Monitoring.Federation = nil
+			},
+		},
+		"invalid target": {
+			Modify: func(addon *addonsv1alpha1.Addon) {
+				addon.Spec.Monitoring.SLOs[0].Target = "100"
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newTestAddonWithMonitoringSLOs()
+			tc.Modify(addon)
+
+			c := testutil.NewClient()
+			r := &monitoringSLOsReconciler{
+				client: c,
+				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+
+			ctx, report := contextWithPhaseReport(context.Background())
+			result, err := r.Reconcile(ctx, addon)
+			require.NoError(t, err)
+			assert.True(t, result.IsZero())
+			c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+			// Invalid SLOs only mark their phase as not ready.
+			assert.Equal(t, addonsv1alpha1.AddonReasonInvalidMonitoringSLOs, report.notReadyReason)
+			assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+		})
+	}
+}
@@ -5,13 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
-	"k8s.io/apimachinery/pkg/api/equality"
+	corev1 "k8s.io/api/core/v1"
 	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/alertreceiver"
 	"github.com/openshift/addon-operator/internal/controllers"
 )
 
@@ -26,27 +29,102 @@ const MONITORING_STACK_RECONCILER_NAME = "monitoringStackReconciler"
 
 var errMonitoringStackSpecNotFound = fmt.Errorf("monitoring stack spec not found")
 
+// Retention of MonitoringStacks not configuring one.
+const defaultMonitoringStackRetention monv1.Duration = "30d"
+
 type monitoringStackReconciler struct {
 	client client.Client
 	scheme *runtime.Scheme
+	// Namespace the AddonOperator is deployed into,
+	// used to address the alert receiver service.
+	addonOperatorNamespace string
+	// Counts the series remote-written by an Addon's MonitoringStack.
+	seriesCounter seriesCounter
+	// Last series counts per Addon, so series are counted once per interval only.
+	seriesCounts *seriesCountCache
+	// Authenticates Alertmanagers to the alert receiver, optional.
+	alertReceiver *alertReceiverKey
+}
+
+// Key the bearer tokens of Addon Alertmanagers posting
+// to the alert receiver are derived from.
+type alertReceiverKey struct {
+	mux sync.RWMutex
+	key []byte
+}
+
+func (k *alertReceiverKey) set(key []byte) {
+	k.mux.Lock()
+	defer k.mux.Unlock()
+	k.key = key
+}
+
+// Returns the key or nil, if the alert receiver is disabled.
+func (k *alertReceiverKey) get() []byte {
+	if k == nil {
+		return nil
+	}
+	k.mux.RLock()
+	defer k.mux.RUnlock()
+	return k.key
+}
+
+// Enables forwarding alerts of Addon MonitoringStacks to the alert receiver,
+// authenticating with tokens derived from the given key.
+// A nil key disables forwarding. Concurrency safe.
+func (r *AddonReconciler) SetAlertReceiverTokenKey(key []byte) {
+	r.alertReceiver.set(key)
 }
 
 func (r *monitoringStackReconciler) Name() string {
 	return MONITORING_STACK_RECONCILER_NAME
 }
 
+func (r *monitoringStackReconciler) Order() subReconcilerOrder {
+	return monitoringStackReconcilerOrder
+}
+
+func (r *monitoringStackReconciler) ConditionType() string {
+	return addonsv1alpha1.MonitoringStackReady
+}
+
+func (r *monitoringStackReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringStack(addon) && !UsesUserWorkloadMonitoring(addon)
+}
+
+// The MonitoringStack is not required by any of the following
+// sub-reconcilers, so waiting for it to become available must not block them.
+func (r *monitoringStackReconciler) Independent() bool {
+	return true
+}
+
 func (r *monitoringStackReconciler) Reconcile(ctx context.Context,
 	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if HasMonitoringStack(addon) && UsesUserWorkloadMonitoring(addon) {
+		// Metrics are collected by user-workload monitoring instead.
+		if err := r.ensureDeletionOfMonitoringStack(ctx, addon); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.seriesCounts.forget(addon.Name)
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.RemoteWriteDegraded)
+		return reconcile.Result{}, nil
+	}
 
 	// ensure creation of MonitoringStack object
 	latestMonitoringStack, err := r.ensureMonitoringStack(ctx, addon)
 	if err != nil {
 		if errors.Is(err, errMonitoringStackSpecNotFound) {
+			r.seriesCounts.forget(addon.Name)
+			meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.RemoteWriteDegraded)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
 	}
 
+	if err := r.ensureAlertmanagerConfig(ctx, addon); err != nil {
+		return reconcile.Result{}, fmt.Errorf("ensuring AlertmanagerConfig: %w", err)
+	}
+
 	// propagate the recently reconciled (created/updated) monitoring stack's status to the owner Addon
 	if monitoringStackAvailable := r.propagateMonitoringStackStatusToAddon(latestMonitoringStack, addon); !monitoringStackAvailable {
 		return handleExit(resultRetry), nil
@@ -121,17 +199,22 @@ func (r *monitoringStackReconciler) getDesiredMonitoringStack(ctx context.Contex
 		return nil, fmt.Errorf("error parsing Addon config")
 	}
 
-	var (
-		remoteWriteURL     string
-		oauthConfig        *monv1.OAuth2
-		writeRelabelConfig []monv1.RelabelConfig
-	)
-
 	rhobsRemoteWriteConfig := addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig
-	if rhobsRemoteWriteConfig != nil {
-		remoteWriteURL = rhobsRemoteWriteConfig.URL
-		oauthConfig = rhobsRemoteWriteConfig.OAuth2
-		writeRelabelConfig = getWriteRelabelConfigFromAllowlist(rhobsRemoteWriteConfig.Allowlist)
+	remoteWrite, err := getRHOBSRemoteWrite(ctx, r.client, rhobsRemoteWriteConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// inject drop rules while the Addon exceeds its series limit
+	if seriesLimitExceeded := r.checkSeriesLimit(ctx, addon, commonConfig.Namespace); seriesLimitExceeded {
+		remoteWrite.WriteRelabelConfigs = append(remoteWrite.WriteRelabelConfigs,
+			getWriteRelabelConfigFromDropList(rhobsRemoteWriteConfig.CardinalityGuard.DropOnLimitExceeded)...)
+	}
+
+	monitoringStackSpec := addon.Spec.Monitoring.MonitoringStack
+	retention := monitoringStackSpec.Retention
+	if len(retention) == 0 {
+		retention = defaultMonitoringStackRetention
 	}
 
 	desiredMonitoringStack := &obov1alpha1.MonitoringStack{
@@ -140,24 +223,24 @@ func (r *monitoringStackReconciler) getDesiredMonitoringStack(ctx context.Contex
 			Namespace: commonConfig.Namespace,
 		},
 		Spec: obov1alpha1.MonitoringStackSpec{
-			Retention: "30d",
+			Retention: retention,
 			ResourceSelector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
 					controllers.MSOLabel: addon.Name,
 				},
 			},
 			PrometheusConfig: &obov1alpha1.PrometheusConfig{
-				RemoteWrite: []monv1.RemoteWriteSpec{
-					{
-						URL:                 remoteWriteURL,
-						OAuth2:              oauthConfig,
-						WriteRelabelConfigs: writeRelabelConfig,
-					},
-				},
+				Replicas:    monitoringStackSpec.Replicas,
+				RemoteWrite: []monv1.RemoteWriteSpec{remoteWrite},
 			},
 		},
 	}
 
+	// left unset, the MonitoringStack defaults apply
+	if monitoringStackSpec.Resources != nil {
+		desiredMonitoringStack.Spec.Resources = *monitoringStackSpec.Resources
+	}
+
 	// add common labels and owner references
 	controllers.AddCommonLabels(desiredMonitoringStack, addon)
 	if err := controllerutil.SetControllerReference(addon, desiredMonitoringStack,
@@ -167,6 +250,108 @@ func (r *monitoringStackReconciler) getDesiredMonitoringStack(ctx context.Contex
 	return desiredMonitoringStack, nil
 }
 
+// Keys of the OAuth2 client credentials Secret.
+const (
+	oauth2ClientIDKey     = "client-id"
+	oauth2ClientSecretKey = "client-secret"
+)
+
+// Converts OAuth2 client credentials into the remote write OAuth2 config.
+func getRemoteWriteOAuth2FromClientCredentials(
+	creds *addonsv1alpha1.RHOBSOAuth2ClientCredentials) *monv1.OAuth2 {
+	secretRef := corev1.LocalObjectReference{Name: creds.SecretName}
+	return &monv1.OAuth2{
+		ClientID: monv1.SecretOrConfigMap{
+			Secret: &corev1.SecretKeySelector{
+				LocalObjectReference: secretRef,
+				Key:                  oauth2ClientIDKey,
+			},
+		},
+		ClientSecret: corev1.SecretKeySelector{
+			LocalObjectReference: secretRef,
+			Key:                  oauth2ClientSecretKey,
+		},
+		TokenURL: creds.TokenURL,
+		Scopes:   creds.Scopes,
+	}
+}
+
+// Builds the remote write to RHOBS from the given config.
+// Returns an empty remote write, if config is nil.
+func getRHOBSRemoteWrite(ctx context.Context, c client.Client,
+	config *addonsv1alpha1.RHOBSRemoteWriteConfigSpec) (monv1.RemoteWriteSpec, error) {
+	var remoteWrite monv1.RemoteWriteSpec
+	if config == nil {
+		return remoteWrite, nil
+	}
+
+	remoteWrite.URL = config.URL
+	remoteWrite.OAuth2 = config.OAuth2
+	if creds := config.OAuth2ClientCredentials; creds != nil {
+		remoteWrite.OAuth2 = getRemoteWriteOAuth2FromClientCredentials(creds)
+	}
+	if config.CA != nil {
+		remoteWrite.TLSConfig = &monv1.TLSConfig{
+			SafeTLSConfig: monv1.SafeTLSConfig{CA: *config.CA},
+		}
+	}
+	proxyURL, err := resolveRemoteWriteProxyURL(ctx, c, config.Proxy)
+	if err != nil {
+		return remoteWrite, fmt.Errorf("resolving remote write proxy: %w", err)
+	}
+	remoteWrite.ProxyURL = proxyURL
+	remoteWrite.WriteRelabelConfigs = getWriteRelabelConfigFromAllowlist(config.Allowlist)
+	return remoteWrite, nil
+}
+
+// Returns the URL of the proxy remote write requests are sent through,
+// or an empty string, if they are sent directly.
+// Without a proxy configured, the cluster-wide Proxy is inherited.
+func resolveRemoteWriteProxyURL(ctx context.Context, c client.Client,
+	proxy *addonsv1alpha1.RHOBSRemoteWriteProxy) (string, error) {
+	if proxy != nil &&
+		(len(proxy.URL) > 0 || proxy.Mode != addonsv1alpha1.AddonProxyModeInheritCluster) {
+		return proxy.URL, nil
+	}
+
+	clusterProxy, err := getClusterProxy(ctx, c)
+	if err != nil || clusterProxy == nil {
+		return "", err
+	}
+	// RHOBS is only reachable via HTTPS.
+	return clusterProxy.Status.HTTPSProxy, nil
+}
+
+// Removes the MonitoringStack and its AlertmanagerConfig,
+// when the Addon switched to user-workload monitoring.
+func (r *monitoringStackReconciler) ensureDeletionOfMonitoringStack(ctx context.Context,
+	addon *addonsv1alpha1.Addon) error {
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		return fmt.Errorf("error parsing Addon config")
+	}
+
+	monitoringStack := &obov1alpha1.MonitoringStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getMonitoringStackName(addon.Name),
+			Namespace: commonConfig.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, monitoringStack); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting MonitoringStack: %w", err)
+	}
+	alertmanagerConfig := &monv1alpha1.AlertmanagerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getAlertmanagerConfigName(addon.Name),
+			Namespace: commonConfig.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, alertmanagerConfig); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting AlertmanagerConfig: %w", err)
+	}
+	return nil
+}
+
 func getMonitoringStackName(addonName string) string {
 	return fmt.Sprintf("%s-monitoring-stack", addonName)
 }
@@ -174,35 +359,148 @@ func getMonitoringStackName(addonName string) string {
 func (r *monitoringStackReconciler) reconcileMonitoringStack(ctx context.Context,
 	desiredMonitoringStack *obov1alpha1.MonitoringStack) (*obov1alpha1.MonitoringStack, error) {
 
-	// get existing MonitoringStack
 	currentMonitoringStack := &obov1alpha1.MonitoringStack{}
-	if err := r.client.Get(ctx, client.ObjectKey{
-		Name:      desiredMonitoringStack.Name,
-		Namespace: desiredMonitoringStack.Namespace,
-	}, currentMonitoringStack); err != nil {
-		// create desired MonitoringStack if it does not exist
-		if k8sApiErrors.IsNotFound(err) {
-			return desiredMonitoringStack, r.client.Create(ctx, desiredMonitoringStack)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredMonitoringStack),
+		currentMonitoringStack); err == nil {
+		if err := yieldToForeignController(ctx, r.client, currentMonitoringStack, desiredMonitoringStack); err != nil {
+			return nil, fmt.Errorf("adopting MonitoringStack: %w", err)
 		}
+	} else if !k8sApiErrors.IsNotFound(err) {
 		return nil, err
 	}
 
-	// only update when spec or ownerReference has changed
+	return desiredMonitoringStack, controllers.Apply(ctx, r.client, desiredMonitoringStack)
+}
+
+// ensures the AlertmanagerConfig routing the MonitoringStack's alerts
+// to the addon-operator alert receiver exists only when forwarding is enabled.
+func (r *monitoringStackReconciler) ensureAlertmanagerConfig(ctx context.Context,
+	addon *addonsv1alpha1.Addon) error {
+	key := r.alertReceiver.get()
+	desiredAlertmanagerConfig, desiredTokenSecret, err := r.getDesiredAlertmanagerConfig(ctx, addon, key)
+	if err != nil {
+		return err
+	}
+
+	if !addon.Spec.Monitoring.MonitoringStack.ForwardAlertsToServiceLogs || len(key) == 0 {
+		// Deletes from the cache first, to not call the API on every reconcile.
+		for _, obj := range []client.Object{desiredAlertmanagerConfig, desiredTokenSecret} {
+			if err := deleteCachedObject(ctx, r.client, obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := controllers.Apply(ctx, r.client, desiredTokenSecret); err != nil {
+		return fmt.Errorf("applying alert receiver token Secret: %w", err)
+	}
+
+	currentAlertmanagerConfig := &monv1alpha1.AlertmanagerConfig{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredAlertmanagerConfig),
+		currentAlertmanagerConfig); err == nil {
+		if err := yieldToForeignController(ctx, r.client, currentAlertmanagerConfig, desiredAlertmanagerConfig); err != nil {
+			return fmt.Errorf("adopting AlertmanagerConfig: %w", err)
+		}
+	} else if !k8sApiErrors.IsNotFound(err) {
+		return err
+	}
+
+	return controllers.Apply(ctx, r.client, desiredAlertmanagerConfig)
+}
+
+// helper function to generate desired AlertmanagerConfig object
+// and the Secret holding the token it authenticates to the alert receiver with.
+func (r *monitoringStackReconciler) getDesiredAlertmanagerConfig(ctx context.Context,
+	addon *addonsv1alpha1.Addon, key []byte) (*monv1alpha1.AlertmanagerConfig, *corev1.Secret, error) {
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		return nil, nil, fmt.Errorf("error parsing Addon config")
+	}
+
+	const tokenKey = "token"
+	desiredTokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getAlertReceiverTokenSecretName(addon.Name),
+			Namespace: commonConfig.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if len(key) > 0 {
+		desiredTokenSecret.StringData = map[string]string{
+			tokenKey: alertreceiver.AddonToken(key, addon.Name),
+		}
+	}
+	controllers.AddCommonLabels(desiredTokenSecret, addon)
+	if err := controllerutil.SetControllerReference(addon, desiredTokenSecret,
+		r.scheme); err != nil {
+		return nil, nil, err
+	}
+
+	const receiverName = "addon-operator-service-logs"
 	var (
-		ownedByAddon  = controllers.HasSameController(currentMonitoringStack, desiredMonitoringStack)
-		specChanged   = !equality.Semantic.DeepEqual(desiredMonitoringStack.Spec, currentMonitoringStack.Spec)
-		currentLabels = labels.Set(currentMonitoringStack.Labels)
-		newLabels     = labels.Merge(currentLabels, labels.Set(desiredMonitoringStack.Labels))
+		receiverURL  = GetAlertReceiverURL(addon, r.addonOperatorNamespace)
+		sendResolved = false
 	)
 
-	if specChanged || !ownedByAddon || !labels.Equals(newLabels, currentLabels) {
-		// copy new spec into existing object and update in the k8s api
-		currentMonitoringStack.Spec = desiredMonitoringStack.Spec
-		currentMonitoringStack.OwnerReferences = desiredMonitoringStack.OwnerReferences
-		currentMonitoringStack.Labels = newLabels
-		return currentMonitoringStack, r.client.Update(ctx, currentMonitoringStack)
+	desiredAlertmanagerConfig := &monv1alpha1.AlertmanagerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getAlertmanagerConfigName(addon.Name),
+			Namespace: commonConfig.Namespace,
+			Labels: map[string]string{
+				controllers.MSOLabel: addon.Name,
+			},
+		},
+		Spec: monv1alpha1.AlertmanagerConfigSpec{
+			Route: &monv1alpha1.Route{
+				Receiver: receiverName,
+				Matchers: []monv1alpha1.Matcher{
+					{
+						Name:      "severity",
+						Value:     "critical",
+						MatchType: monv1alpha1.MatchEqual,
+					},
+				},
+			},
+			Receivers: []monv1alpha1.Receiver{
+				{
+					Name: receiverName,
+					WebhookConfigs: []monv1alpha1.WebhookConfig{
+						{
+							URL:          &receiverURL,
+							SendResolved: &sendResolved,
+							HTTPConfig: &monv1alpha1.HTTPConfig{
+								Authorization: &monv1.SafeAuthorization{
+									Type: "Bearer",
+									Credentials: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: desiredTokenSecret.Name,
+										},
+										Key: tokenKey,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	controllers.AddCommonLabels(desiredAlertmanagerConfig, addon)
+	if err := controllerutil.SetControllerReference(addon, desiredAlertmanagerConfig,
+		r.scheme); err != nil {
+		return nil, nil, err
 	}
-	return currentMonitoringStack, nil
+	return desiredAlertmanagerConfig, desiredTokenSecret, nil
+}
+
+func getAlertmanagerConfigName(addonName string) string {
+	return fmt.Sprintf("%s-service-logs", addonName)
+}
+
+func getAlertReceiverTokenSecretName(addonName string) string {
+	return fmt.Sprintf("%s-service-logs-token", addonName)
 }
 
 func getWriteRelabelConfigFromAllowlist(allowlist []string) []monv1.RelabelConfig {
@@ -211,12 +509,30 @@ func getWriteRelabelConfigFromAllowlist(allowlist []string) []monv1.RelabelConfi
 		return relabelConfigs
 	}
 
-	regex := fmt.Sprintf("(%s)", strings.Join(allowlist[:], "|"))
 	relabelConfig := monv1.RelabelConfig{
 		Action:       "keep",
 		SourceLabels: []monv1.LabelName{"[__name__]"},
-		Regex:        regex,
+		Regex:        getAllowlistRegex(allowlist),
 	}
 	relabelConfigs = append(relabelConfigs, relabelConfig)
 	return relabelConfigs
 }
+
+// Compiles the allowlist into a single regex matching all listed metrics.
+// Anchored regex entries lose their anchors, as prometheus anchors
+// relabeling and PromQL regexes on both ends anyway.
+func getAllowlistRegex(allowlist []string) string {
+	alternatives := make([]string, len(allowlist))
+	for i, entry := range allowlist {
+		if isAnchoredRegex(entry) {
+			entry = fmt.Sprintf("(?:%s)", entry[1:len(entry)-1])
+		}
+		alternatives[i] = entry
+	}
+	return fmt.Sprintf("(%s)", strings.Join(alternatives, "|"))
+}
+
+// Whether the allowlist entry is a regular expression instead of a metric name.
+func isAnchoredRegex(entry string) bool {
+	return len(entry) >= 2 && strings.HasPrefix(entry, "^") && strings.HasSuffix(entry, "$")
+}
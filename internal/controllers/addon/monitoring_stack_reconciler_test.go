@@ -2,17 +2,24 @@ package addon
 
 import (
 	"context"
+	"regexp"
 	"testing"
 
+	configv1 "github.com/openshift/api/config/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/openshift/addon-operator/apis/addons/v1alpha1"
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/alertreceiver"
 	"github.com/openshift/addon-operator/internal/controllers"
 	"github.com/openshift/addon-operator/internal/testutil"
 )
@@ -57,6 +64,7 @@ func TestEnsureMonitoringStack_MissingConfig(t *testing.T) {
 
 func TestEnsureMonitoringStack_MonitoringStackPresentInSpec_NotPresentInCluster(t *testing.T) {
 	c := testutil.NewClient()
+	mockNoClusterProxy(c)
 	r := &monitoringStackReconciler{
 		client: c,
 		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
@@ -67,7 +75,7 @@ func TestEnsureMonitoringStack_MonitoringStackPresentInSpec_NotPresentInCluster(
 
 	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything).
 		Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything).
+	c.On("Patch", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
 			assert.False(t, stop)
@@ -79,12 +87,14 @@ func TestEnsureMonitoringStack_MonitoringStackPresentInSpec_NotPresentInCluster(
 	_, err := r.ensureMonitoringStack(ctx, addon)
 	require.NoError(t, err)
 	c.AssertExpectations(t)
-	c.AssertNumberOfCalls(t, "Get", 1)
-	c.AssertNumberOfCalls(t, "Create", 1)
+	// MonitoringStack and cluster-wide Proxy
+	c.AssertNumberOfCalls(t, "Get", 2)
+	c.AssertNumberOfCalls(t, "Patch", 1)
 }
 
 func TestEnsureMonitoringStack_MonitoringStackPresentInSpec_PresentInCluster(t *testing.T) {
 	c := testutil.NewClient()
+	mockNoClusterProxy(c)
 
 	r := &monitoringStackReconciler{
 		client: c,
@@ -96,7 +106,7 @@ func TestEnsureMonitoringStack_MonitoringStackPresentInSpec_PresentInCluster(t *
 	ctx := context.Background()
 	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything).
 		Return(nil)
-	c.On("Update", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything).
+	c.On("Patch", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
 			assert.False(t, stop)
@@ -108,8 +118,251 @@ func TestEnsureMonitoringStack_MonitoringStackPresentInSpec_PresentInCluster(t *
 	_, err := r.ensureMonitoringStack(ctx, addon)
 	require.NoError(t, err)
 	c.AssertExpectations(t)
-	c.AssertNumberOfCalls(t, "Get", 1)
-	c.AssertNumberOfCalls(t, "Update", 1)
+	// MonitoringStack and cluster-wide Proxy
+	c.AssertNumberOfCalls(t, "Get", 2)
+	c.AssertNumberOfCalls(t, "Patch", 1)
+}
+
+func TestGetDesiredMonitoringStack_Sizing(t *testing.T) {
+	replicas := int32(3)
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("2Gi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("4Gi"),
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		replicas          *int32
+		resources         *corev1.ResourceRequirements
+		retention         monv1.Duration
+		expectedRetention monv1.Duration
+	}{
+		"defaults": {
+			expectedRetention: "30d",
+		},
+		"configured": {
+			replicas:          &replicas,
+			resources:         &resources,
+			retention:         "90d",
+			expectedRetention: "90d",
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c := testutil.NewClient()
+			mockNoClusterProxy(c)
+			r := &monitoringStackReconciler{
+				client: c,
+				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+
+			addon := testutil.NewTestAddonWithMonitoringStack()
+			addon.Spec.Monitoring.MonitoringStack.Replicas = tc.replicas
+			addon.Spec.Monitoring.MonitoringStack.Resources = tc.resources
+			addon.Spec.Monitoring.MonitoringStack.Retention = tc.retention
+
+			monitoringStack, err := r.getDesiredMonitoringStack(context.Background(), addon)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedRetention, monitoringStack.Spec.Retention)
+			assert.Equal(t, tc.replicas, monitoringStack.Spec.PrometheusConfig.Replicas)
+			if tc.resources != nil {
+				assert.Equal(t, *tc.resources, monitoringStack.Spec.Resources)
+			} else {
+				assert.Equal(t, corev1.ResourceRequirements{}, monitoringStack.Spec.Resources)
+			}
+		})
+	}
+}
+
+func TestGetDesiredMonitoringStack_RemoteWriteConnection(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&configv1.Proxy{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(2).(*configv1.Proxy).Status = configv1.ProxyStatus{
+				HTTPProxy:  "http://cluster-proxy:3128",
+				HTTPSProxy: "http://cluster-https-proxy:3128",
+			}
+		}).
+		Return(nil)
+
+	r := &monitoringStackReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	ca := monv1.SecretOrConfigMap{
+		ConfigMap: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "rhobs-ca"},
+			Key:                  "ca-bundle.crt",
+		},
+	}
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	remoteWriteConfig := addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig
+	remoteWriteConfig.OAuth2ClientCredentials = &addonsv1alpha1.RHOBSOAuth2ClientCredentials{
+		SecretName: "rhobs-credentials",
+		TokenURL:   "https://sso.example.com/token",
+		Scopes:     []string{"openid"},
+	}
+	remoteWriteConfig.CA = &ca
+	remoteWriteConfig.Proxy = &addonsv1alpha1.RHOBSRemoteWriteProxy{
+		Mode: addonsv1alpha1.AddonProxyModeInheritCluster,
+	}
+
+	monitoringStack, err := r.getDesiredMonitoringStack(context.Background(), addon)
+	require.NoError(t, err)
+	require.Len(t, monitoringStack.Spec.PrometheusConfig.RemoteWrite, 1)
+	remoteWrite := monitoringStack.Spec.PrometheusConfig.RemoteWrite[0]
+
+	secretRef := corev1.LocalObjectReference{Name: "rhobs-credentials"}
+	assert.Equal(t, &monv1.OAuth2{
+		ClientID: monv1.SecretOrConfigMap{
+			Secret: &corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "client-id"},
+		},
+		ClientSecret: corev1.SecretKeySelector{LocalObjectReference: secretRef, Key: "client-secret"},
+		TokenURL:     "https://sso.example.com/token",
+		Scopes:       []string{"openid"},
+	}, remoteWrite.OAuth2)
+	require.NotNil(t, remoteWrite.TLSConfig)
+	assert.Equal(t, ca, remoteWrite.TLSConfig.CA)
+	assert.Equal(t, "http://cluster-https-proxy:3128", remoteWrite.ProxyURL)
+}
+
+func TestGetAllowlistRegex(t *testing.T) {
+	for name, tc := range map[string]struct {
+		allowlist []string
+		expected  string
+	}{
+		"exact names": {
+			allowlist: []string{"up", "addon_requests_total"},
+			expected:  "(up|addon_requests_total)",
+		},
+		"exact names and regexes": {
+			allowlist: []string{"up", `^kube_pod_.+$`, `^addon_(a|b)\.total$`},
+			expected:  `(up|(?:kube_pod_.+)|(?:addon_(a|b)\.total))`,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			regex := getAllowlistRegex(tc.allowlist)
+			assert.Equal(t, tc.expected, regex)
+
+			// Prometheus anchors the regex on both ends.
+			anchored := regexp.MustCompile("^(?:" + regex + ")$")
+			assert.True(t, anchored.MatchString("up"))
+			assert.False(t, anchored.MatchString("upper"))
+		})
+	}
+}
+
+func TestEnsureAlertmanagerConfig_ForwardingEnabled_NotPresentInCluster(t *testing.T) {
+	c := testutil.NewClient()
+
+	key := &alertReceiverKey{}
+	key.set([]byte("key"))
+	r := &monitoringStackReconciler{
+		client:                 c,
+		scheme:                 testutil.NewTestSchemeWithAddonsv1alpha1AndMsov1alpha1(),
+		addonOperatorNamespace: "addon-operator",
+		alertReceiver:          key,
+	}
+
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.Monitoring.MonitoringStack.ForwardAlertsToServiceLogs = true
+
+	ctx := context.Background()
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Secret{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			secret := args.Get(1).(*corev1.Secret)
+			assert.Equal(t, "addon-1", secret.Namespace)
+			assert.Equal(t, alertreceiver.AddonToken([]byte("key"), addon.Name), secret.StringData["token"])
+		}).
+		Return(nil)
+	c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.IsType(&monv1alpha1.AlertmanagerConfig{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", testutil.IsContext, mock.IsType(&monv1alpha1.AlertmanagerConfig{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			amConfig := args.Get(1).(*monv1alpha1.AlertmanagerConfig)
+			assert.Equal(t, "addon-1", amConfig.Namespace)
+			assert.Equal(t, addon.Name, amConfig.Labels[controllers.MSOLabel])
+			require.Len(t, amConfig.Spec.Receivers, 1)
+			require.Len(t, amConfig.Spec.Receivers[0].WebhookConfigs, 1)
+			webhook := amConfig.Spec.Receivers[0].WebhookConfigs[0]
+			assert.Equal(t,
+				"http://addon-operator-alert-receiver.addon-operator.svc:8084/alerts/addon-foo",
+				*webhook.URL)
+			require.NotNil(t, webhook.HTTPConfig)
+			require.NotNil(t, webhook.HTTPConfig.Authorization)
+			assert.Equal(t, "addon-foo-service-logs-token",
+				webhook.HTTPConfig.Authorization.Credentials.Name)
+		}).
+		Return(nil)
+
+	err := r.ensureAlertmanagerConfig(ctx, addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+}
+
+func TestEnsureAlertmanagerConfig_ForwardingDisabled(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Forward        bool
+		Key            []byte
+		PresentInCache bool
+	}{
+		"disabled in addon": {},
+		"disabled in addon, present in cache": {
+			Key:            []byte("key"),
+			PresentInCache: true,
+		},
+		"alert receiver disabled": {
+			Forward:        true,
+			PresentInCache: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			c := testutil.NewClient()
+
+			key := &alertReceiverKey{}
+			key.set(tc.Key)
+			r := &monitoringStackReconciler{
+				client:                 c,
+				scheme:                 testutil.NewTestSchemeWithAddonsv1alpha1AndMsov1alpha1(),
+				addonOperatorNamespace: "addon-operator",
+				alertReceiver:          key,
+			}
+
+			addon := testutil.NewTestAddonWithMonitoringStack()
+			addon.Spec.Monitoring.MonitoringStack.ForwardAlertsToServiceLogs = tc.Forward
+
+			ctx := context.Background()
+			if tc.PresentInCache {
+				c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.Anything, mock.Anything).
+					Return(nil)
+				c.On("Delete", testutil.IsContext, mock.IsType(&monv1alpha1.AlertmanagerConfig{}), mock.Anything).
+					Return(nil)
+				c.On("Delete", testutil.IsContext, mock.IsType(&corev1.Secret{}), mock.Anything).
+					Return(nil)
+			} else {
+				c.On("Get", testutil.IsContext, mock.IsType(types.NamespacedName{}), mock.Anything, mock.Anything).
+					Return(testutil.NewTestErrNotFound())
+			}
+
+			err := r.ensureAlertmanagerConfig(ctx, addon)
+			require.NoError(t, err)
+			c.AssertExpectations(t)
+			c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			if !tc.PresentInCache {
+				c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
 }
 
 func TestPropagateMonitoringStackStatusToAddon(t *testing.T) {
@@ -0,0 +1,168 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Maximum number of remaining monitoring objects listed in the condition message.
+const maxReportedMonitoringObjects = 5
+
+// Removes the monitoring objects of a deleted Addon and waits until they are gone.
+// Garbage collection only starts after the Addon itself is removed,
+// so monitoring objects left behind by a failed collection would leak.
+type monitoringFinalizer struct {
+	client client.Client
+	// Whether MonitoringStacks are managed, their API is only present when enabled.
+	monitoringStacks bool
+}
+
+func (f *monitoringFinalizer) Finalizer() string {
+	return "addons.managed.openshift.io/monitoring"
+}
+
+func (f *monitoringFinalizer) Order() finalizerOrder {
+	return monitoringFinalizerOrder
+}
+
+// Monitoring objects are only created for Addons configuring monitoring.
+func (f *monitoringFinalizer) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil
+}
+
+func (f *monitoringFinalizer) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	if UsesUserWorkloadMonitoring(addon) {
+		// The remote write lives in a shared ConfigMap, which is not garbage collected.
+		if err := ensureUserWorkloadRemoteWrite(ctx, f.client, addon, nil); err != nil {
+			return false, fmt.Errorf("removing user-workload remote write: %w", err)
+		}
+	}
+
+	objects, err := f.listMonitoringObjects(ctx, addon)
+	if err != nil {
+		return false, err
+	}
+
+	var remaining []string
+	for _, obj := range objects {
+		if !metav1.IsControlledBy(obj, addon) {
+			continue
+		}
+		if obj.GetDeletionTimestamp().IsZero() {
+			if err := f.client.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("deleting %T %s: %w", obj, client.ObjectKeyFromObject(obj), err)
+			}
+		}
+		remaining = append(remaining, describeMonitoringObject(obj))
+	}
+
+	reportMonitoringTeardown(addon, remaining)
+	// Monitoring objects are watched, no need to requeue.
+	return len(remaining) == 0, nil
+}
+
+// Lists the monitoring objects of the Addon.
+// Kinds whose CRD is not installed have no objects to delete.
+func (f *monitoringFinalizer) listMonitoringObjects(
+	ctx context.Context, addon *addonsv1alpha1.Addon) ([]client.Object, error) {
+	selector := client.MatchingLabelsSelector{
+		Selector: controllers.CommonLabelsAsLabelSelector(addon),
+	}
+
+	lists := []client.ObjectList{
+		&monitoringv1.ServiceMonitorList{},
+		&monitoringv1.PodMonitorList{},
+		&monitoringv1.ProbeList{},
+		&monitoringv1.PrometheusRuleList{},
+	}
+	if f.monitoringStacks {
+		lists = append(lists,
+			&obov1alpha1.MonitoringStackList{},
+			// e.g. rules registered with a Thanos Ruler outside of the Addon namespaces.
+			&monv1.PrometheusRuleList{},
+		)
+	}
+
+	var objects []client.Object
+	for _, list := range lists {
+		err := f.client.List(ctx, list, selector)
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("listing %T: %w", list, err)
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, fmt.Errorf("extracting %T: %w", list, err)
+		}
+		for _, item := range items {
+			objects = append(objects, item.(client.Object))
+		}
+	}
+	return objects, nil
+}
+
+// e.g. "ServiceMonitor redhat-monitoring-foo/federated-sm-foo (finalizers: example.com/cleanup)".
+func describeMonitoringObject(obj client.Object) string {
+	var kind string
+	switch obj.(type) {
+	case *monitoringv1.ServiceMonitor:
+		kind = "ServiceMonitor"
+	case *monitoringv1.PodMonitor:
+		kind = "PodMonitor"
+	case *monitoringv1.Probe:
+		kind = "Probe"
+	case *monitoringv1.PrometheusRule:
+		kind = "PrometheusRule"
+	case *monv1.PrometheusRule:
+		kind = "PrometheusRule"
+	case *obov1alpha1.MonitoringStack:
+		kind = "MonitoringStack"
+	}
+
+	desc := fmt.Sprintf("%s %s", kind, client.ObjectKeyFromObject(obj))
+	if finalizers := obj.GetFinalizers(); len(finalizers) > 0 {
+		desc += fmt.Sprintf(" (finalizers: %s)", strings.Join(finalizers, ", "))
+	}
+	return desc
+}
+
+func reportMonitoringTeardown(addon *addonsv1alpha1.Addon, remaining []string) {
+	if len(remaining) == 0 {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.MonitoringTeardownPending)
+		return
+	}
+
+	sort.Strings(remaining)
+	reported := remaining
+	if len(reported) > maxReportedMonitoringObjects {
+		reported = reported[:maxReportedMonitoringObjects]
+	}
+	msg := fmt.Sprintf("Waiting for the removal of %s", strings.Join(reported, ", "))
+	if omitted := len(remaining) - len(reported); omitted > 0 {
+		msg += fmt.Sprintf(" and %d more", omitted)
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.MonitoringTeardownPending,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonMonitoringObjectsRemaining,
+		Message:            msg,
+		ObservedGeneration: addon.Generation,
+	})
+}
@@ -0,0 +1,176 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestMonitoringFinalizer(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	addon.UID = "addon-uid"
+	ownerRefs := []metav1.OwnerReference{{
+		APIVersion: addonsv1alpha1.GroupVersion.String(),
+		Kind:       "Addon",
+		Name:       addon.Name,
+		UID:        addon.UID,
+		Controller: pointer.Bool(true),
+	}}
+	now := metav1.Now()
+
+	tests := map[string]struct {
+		ServiceMonitors  []*monitoringv1.ServiceMonitor
+		MonitoringStacks []obov1alpha1.MonitoringStack
+		ThanosRulerRules []*monv1.PrometheusRule
+		ExpectDone       bool
+		ExpectDeletes    int
+		ExpectedMessage  string
+	}{
+		"nothing left": {
+			ExpectDone: true,
+		},
+		"deletes remaining objects": {
+			ServiceMonitors: []*monitoringv1.ServiceMonitor{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "federated-sm-addon-foo", Namespace: "redhat-monitoring-addon-foo",
+					OwnerReferences: ownerRefs,
+				},
+			}},
+			ExpectDeletes:   1,
+			ExpectedMessage: "Waiting for the removal of ServiceMonitor redhat-monitoring-addon-foo/federated-sm-addon-foo",
+		},
+		"waits for objects being deleted": {
+			MonitoringStacks: []obov1alpha1.MonitoringStack{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "addon-foo-monitoring-stack", Namespace: "redhat-monitoring-addon-foo",
+					OwnerReferences:   ownerRefs,
+					DeletionTimestamp: &now,
+					Finalizers:        []string{"monitoring.rhobs/finalizer"},
+				},
+			}},
+			ExpectedMessage: "Waiting for the removal of MonitoringStack " +
+				"redhat-monitoring-addon-foo/addon-foo-monitoring-stack (finalizers: monitoring.rhobs/finalizer)",
+		},
+		"deletes rules registered with a Thanos Ruler": {
+			ThanosRulerRules: []*monv1.PrometheusRule{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "addon-foo-monitoring-rules", Namespace: "thanos-ruler",
+					OwnerReferences: ownerRefs,
+				},
+			}},
+			ExpectDeletes:   1,
+			ExpectedMessage: "Waiting for the removal of PrometheusRule thanos-ruler/addon-foo-monitoring-rules",
+		},
+		"ignores objects not controlled by the addon": {
+			ServiceMonitors: []*monitoringv1.ServiceMonitor{{
+				ObjectMeta: metav1.ObjectMeta{Name: "foreign", Namespace: "redhat-monitoring-addon-foo"},
+			}},
+			ExpectDone: true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := addon.DeepCopy()
+			c := testutil.NewClient()
+			c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.ServiceMonitorList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*monitoringv1.ServiceMonitorList).Items = tc.ServiceMonitors
+				}).
+				Return(nil)
+			c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.PodMonitorList{}), mock.Anything).
+				Return(nil)
+			c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.ProbeList{}), mock.Anything).
+				Return(nil)
+			c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.PrometheusRuleList{}), mock.Anything).
+				Return(nil)
+			c.On("List", testutil.IsContext, mock.IsType(&monv1.PrometheusRuleList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*monv1.PrometheusRuleList).Items = tc.ThanosRulerRules
+				}).
+				Return(nil)
+			c.On("List", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStackList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*obov1alpha1.MonitoringStackList).Items = tc.MonitoringStacks
+				}).
+				Return(nil)
+			c.On("Delete", testutil.IsContext, mock.Anything, mock.Anything).
+				Return(nil)
+
+			f := &monitoringFinalizer{client: c, monitoringStacks: true}
+			done, err := f.Finalize(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectDone, done)
+			c.AssertNumberOfCalls(t, "Delete", tc.ExpectDeletes)
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.MonitoringTeardownPending)
+			if tc.ExpectDone {
+				assert.Nil(t, cond)
+				return
+			}
+			require.NotNil(t, cond)
+			assert.Equal(t, addonsv1alpha1.AddonReasonMonitoringObjectsRemaining, cond.Reason)
+			assert.Equal(t, tc.ExpectedMessage, cond.Message)
+		})
+	}
+}
+
+func TestMonitoringFinalizer_WithoutMonitoringStacks(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.Anything, mock.Anything).
+		Return(nil)
+
+	f := &monitoringFinalizer{client: c}
+	done, err := f.Finalize(context.Background(), testutil.NewTestAddonWithMonitoringFederation())
+	require.NoError(t, err)
+	assert.True(t, done)
+	c.AssertNotCalled(t, "List", mock.Anything, mock.IsType(&obov1alpha1.MonitoringStackList{}), mock.Anything)
+}
+
+func TestMonitoringFinalizer_MissingCRDs(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.ProbeList{}), mock.Anything).
+		Return(&meta.NoKindMatchError{
+			GroupKind: schema.GroupKind{Group: monitoringv1.SchemeGroupVersion.Group, Kind: "Probe"},
+		})
+	c.On("List", testutil.IsContext, mock.Anything, mock.Anything).
+		Return(nil)
+
+	f := &monitoringFinalizer{client: c}
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	done, err := f.Finalize(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.MonitoringTeardownPending))
+}
+
+func TestMonitoringFinalizer_AppliesTo(t *testing.T) {
+	t.Parallel()
+
+	f := &monitoringFinalizer{}
+	assert.True(t, f.AppliesTo(testutil.NewTestAddonWithMonitoringFederation()))
+	assert.False(t, f.AppliesTo(testutil.NewTestAddonWithCatalogSourceImage()))
+}
@@ -0,0 +1,263 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+const USER_WORKLOAD_MONITORING_RECONCILER_NAME = "userWorkloadMonitoringReconciler"
+
+// Configuration of the cluster-monitoring-operator.
+const (
+	clusterMonitoringConfigNamespace      = "openshift-monitoring"
+	clusterMonitoringConfigName           = "cluster-monitoring-config"
+	userWorkloadMonitoringConfigNamespace = "openshift-user-workload-monitoring"
+	userWorkloadMonitoringConfigName      = "user-workload-monitoring-config"
+	monitoringConfigKey                   = "config.yaml"
+)
+
+// Opts a namespace into user-workload monitoring.
+const userWorkloadMonitoringLabel = "openshift.io/user-monitoring"
+
+// Routes the metrics of Addons in UserWorkload monitoring mode into the
+// user-workload monitoring of the cluster, instead of a dedicated MonitoringStack.
+// Addon namespaces are labeled by the namespaceReconciler,
+// this reconciler registers the RHOBS remote write of the Addon
+// with the user-workload Prometheus.
+type userWorkloadMonitoringReconciler struct {
+	client client.Client
+}
+
+func (r *userWorkloadMonitoringReconciler) Name() string {
+	return USER_WORKLOAD_MONITORING_RECONCILER_NAME
+}
+
+func (r *userWorkloadMonitoringReconciler) Order() subReconcilerOrder {
+	return userWorkloadMonitoringReconcilerOrder
+}
+
+// Only acts on the Addon and cluster-wide monitoring configuration,
+// which is re-checked every drift detection interval.
+func (r *userWorkloadMonitoringReconciler) Skippable() bool {
+	return true
+}
+
+// No sub-reconciler depends on the collected metrics.
+func (r *userWorkloadMonitoringReconciler) Independent() bool {
+	return true
+}
+
+func (r *userWorkloadMonitoringReconciler) ConditionType() string {
+	return addonsv1alpha1.UserWorkloadMonitoringReady
+}
+
+func (r *userWorkloadMonitoringReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return UsesUserWorkloadMonitoring(addon)
+}
+
+func (r *userWorkloadMonitoringReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if !UsesUserWorkloadMonitoring(addon) {
+		if err := ensureUserWorkloadRemoteWrite(ctx, r.client, addon, nil); err != nil {
+			return ctrl.Result{}, fmt.Errorf("removing user-workload remote write: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	enabled, err := r.userWorkloadMonitoringEnabled(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !enabled {
+		reportUnreadyUserWorkloadMonitoring(addon,
+			"enableUserWorkload is not set in the cluster-monitoring-config ConfigMap")
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+	}
+
+	remoteWrite, err := r.desiredRemoteWrite(ctx, addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := ensureUserWorkloadRemoteWrite(ctx, r.client, addon, remoteWrite); err != nil {
+		return ctrl.Result{}, fmt.Errorf("ensuring user-workload remote write: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// Whether user-workload monitoring is enabled in the cluster-monitoring config.
+func (r *userWorkloadMonitoringReconciler) userWorkloadMonitoringEnabled(ctx context.Context) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Name:      clusterMonitoringConfigName,
+		Namespace: clusterMonitoringConfigNamespace,
+	}, cm); k8sApiErrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting cluster-monitoring config: %w", err)
+	}
+
+	var config struct {
+		EnableUserWorkload *bool `json:"enableUserWorkload"`
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data[monitoringConfigKey]), &config); err != nil {
+		// The cluster-monitoring-operator reports invalid config itself.
+		return false, nil
+	}
+	return config.EnableUserWorkload != nil && *config.EnableUserWorkload, nil
+}
+
+// Remote write of the Addon limited to the series of the Addon namespaces,
+// or nil, if the Addon does not remote write.
+func (r *userWorkloadMonitoringReconciler) desiredRemoteWrite(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (map[string]interface{}, error) {
+	if !HasMonitoringStack(addon) || addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig == nil {
+		return nil, nil
+	}
+
+	remoteWrite, err := getRHOBSRemoteWrite(ctx, r.client,
+		addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig)
+	if err != nil {
+		return nil, err
+	}
+	remoteWrite.Name = getUserWorkloadRemoteWriteName(addon)
+	// The user-workload Prometheus scrapes all user namespaces.
+	remoteWrite.WriteRelabelConfigs = append([]monv1.RelabelConfig{{
+		Action:       "keep",
+		SourceLabels: []monv1.LabelName{"namespace"},
+		Regex:        getAddonNamespacesRegex(addon),
+	}}, remoteWrite.WriteRelabelConfigs...)
+
+	// Entries of the config are plain YAML, unknown to our API types.
+	j, err := json.Marshal(remoteWrite)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling remote write: %w", err)
+	}
+	var entry map[string]interface{}
+	if err := json.Unmarshal(j, &entry); err != nil {
+		return nil, fmt.Errorf("unmarshal remote write: %w", err)
+	}
+	return entry, nil
+}
+
+func getUserWorkloadRemoteWriteName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s", addon.Name)
+}
+
+// Regex matching the install namespace and all namespaces of the Addon.
+func getAddonNamespacesRegex(addon *addonsv1alpha1.Addon) string {
+	namespaces := map[string]struct{}{}
+	if ns := GetCommonInstallOptions(addon).Namespace; len(ns) > 0 {
+		namespaces[ns] = struct{}{}
+	}
+	for _, ns := range addon.Spec.Namespaces {
+		namespaces[ns.Name] = struct{}{}
+	}
+
+	alternatives := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		alternatives = append(alternatives, regexp.QuoteMeta(ns))
+	}
+	sort.Strings(alternatives)
+	return strings.Join(alternatives, "|")
+}
+
+// Registers the given remote write of the Addon in the user-workload monitoring config,
+// replacing a previously registered one. A nil remote write removes it.
+// The config is shared with cluster admins and other Addons,
+// so only the entry named after the Addon is touched.
+func ensureUserWorkloadRemoteWrite(ctx context.Context, c client.Client,
+	addon *addonsv1alpha1.Addon, remoteWrite map[string]interface{}) error {
+	cm := &corev1.ConfigMap{}
+	err := c.Get(ctx, client.ObjectKey{
+		Name:      userWorkloadMonitoringConfigName,
+		Namespace: userWorkloadMonitoringConfigNamespace,
+	}, cm)
+	if k8sApiErrors.IsNotFound(err) {
+		if remoteWrite == nil {
+			return nil
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      userWorkloadMonitoringConfigName,
+				Namespace: userWorkloadMonitoringConfigNamespace,
+			},
+		}
+	} else if err != nil {
+		return fmt.Errorf("getting user-workload monitoring config: %w", err)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(cm.Data[monitoringConfigKey]), &config); err != nil {
+		return fmt.Errorf("parsing user-workload monitoring config: %w", err)
+	}
+	if !setUserWorkloadRemoteWrite(config, getUserWorkloadRemoteWriteName(addon), remoteWrite) {
+		return nil
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshaling user-workload monitoring config: %w", err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[monitoringConfigKey] = string(data)
+
+	if len(cm.ResourceVersion) == 0 {
+		return c.Create(ctx, cm)
+	}
+	// Conflicting changes fail the update and are retried with the next reconcile.
+	return c.Update(ctx, cm)
+}
+
+// Replaces the remote write entry with the given name in the config,
+// or removes it, if remoteWrite is nil. Returns true, if the config changed.
+func setUserWorkloadRemoteWrite(config map[string]interface{},
+	name string, remoteWrite map[string]interface{}) (changed bool) {
+	prometheus, _ := config["prometheus"].(map[string]interface{})
+	if prometheus == nil {
+		prometheus = map[string]interface{}{}
+	}
+	current, _ := prometheus["remoteWrite"].([]interface{})
+
+	desired := make([]interface{}, 0, len(current)+1)
+	for _, entry := range current {
+		if e, ok := entry.(map[string]interface{}); ok && e["name"] == name {
+			continue
+		}
+		desired = append(desired, entry)
+	}
+	if remoteWrite != nil {
+		desired = append(desired, remoteWrite)
+	}
+	if reflect.DeepEqual(current, desired) || (len(current) == 0 && len(desired) == 0) {
+		return false
+	}
+
+	if len(desired) == 0 {
+		delete(prometheus, "remoteWrite")
+	} else {
+		prometheus["remoteWrite"] = desired
+	}
+	if len(prometheus) == 0 {
+		delete(config, "prometheus")
+	} else {
+		config["prometheus"] = prometheus
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestSetUserWorkloadRemoteWrite(t *testing.T) {
+	t.Parallel()
+
+	foreign := map[string]interface{}{"name": "admin", "url": "https://admin.example.com"}
+	current := map[string]interface{}{"name": "addon-foo", "url": "https://old.example.com"}
+	desired := map[string]interface{}{"name": "addon-foo", "url": "https://new.example.com"}
+
+	tests := map[string]struct {
+		Config        map[string]interface{}
+		RemoteWrite   map[string]interface{}
+		ExpectChanged bool
+		ExpectConfig  map[string]interface{}
+	}{
+		"adds remote write": {
+			Config: map[string]interface{}{
+				"prometheus": map[string]interface{}{"retention": "24h"},
+			},
+			RemoteWrite:   desired,
+			ExpectChanged: true,
+			ExpectConfig: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"retention":   "24h",
+					"remoteWrite": []interface{}{desired},
+				},
+			},
+		},
+		"replaces remote write, keeping foreign ones": {
+			Config: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"remoteWrite": []interface{}{foreign, current},
+				},
+			},
+			RemoteWrite:   desired,
+			ExpectChanged: true,
+			ExpectConfig: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"remoteWrite": []interface{}{foreign, desired},
+				},
+			},
+		},
+		"removes remote write": {
+			Config: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"remoteWrite": []interface{}{current},
+				},
+			},
+			ExpectChanged: true,
+			ExpectConfig:  map[string]interface{}{},
+		},
+		"unchanged": {
+			Config: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"remoteWrite": []interface{}{foreign, desired},
+				},
+			},
+			RemoteWrite: desired,
+			ExpectConfig: map[string]interface{}{
+				"prometheus": map[string]interface{}{
+					"remoteWrite": []interface{}{foreign, desired},
+				},
+			},
+		},
+		"nothing to remove": {
+			Config:       map[string]interface{}{},
+			ExpectConfig: map[string]interface{}{},
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			changed := setUserWorkloadRemoteWrite(tc.Config, "addon-foo", tc.RemoteWrite)
+			assert.Equal(t, tc.ExpectChanged, changed)
+			assert.Equal(t, tc.ExpectConfig, tc.Config)
+		})
+	}
+}
+
+func TestUserWorkloadMonitoringReconciler(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ClusterMonitoringConfig string
+		ExpectUnready           bool
+	}{
+		"registers remote write": {
+			ClusterMonitoringConfig: "enableUserWorkload: true\n",
+		},
+		"user-workload monitoring disabled": {
+			ClusterMonitoringConfig: "enableUserWorkload: false\n",
+			ExpectUnready:           true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithSingleNamespace()
+			addon.Spec.Monitoring = &addonsv1alpha1.MonitoringSpec{
+				Mode: addonsv1alpha1.AddonMonitoringModeUserWorkload,
+				MonitoringStack: &addonsv1alpha1.MonitoringStackSpec{
+					RHOBSRemoteWriteConfig: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+						URL:       "https://observatorium.example.com/api/v1/receive",
+						Allowlist: []string{"up"},
+					},
+				},
+			}
+
+			c := testutil.NewClient()
+			c.On("Get", testutil.IsContext, client.ObjectKey{
+				Name:      clusterMonitoringConfigName,
+				Namespace: clusterMonitoringConfigNamespace,
+			}, testutil.IsConfigMapPtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(2).(*corev1.ConfigMap).Data = map[string]string{
+						monitoringConfigKey: tc.ClusterMonitoringConfig,
+					}
+				}).
+				Return(nil)
+			c.On("Get", testutil.IsContext, client.ObjectKey{
+				Name:      userWorkloadMonitoringConfigName,
+				Namespace: userWorkloadMonitoringConfigNamespace,
+			}, testutil.IsConfigMapPtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					cm := args.Get(2).(*corev1.ConfigMap)
+					cm.ResourceVersion = "1"
+					cm.Data = map[string]string{
+						monitoringConfigKey: "prometheus:\n  remoteWrite:\n  - name: admin\n    url: https://admin.example.com\n",
+					}
+				}).
+				Return(nil).Maybe()
+			var updated *corev1.ConfigMap
+			c.On("Update", testutil.IsContext, testutil.IsConfigMapPtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					updated = args.Get(1).(*corev1.ConfigMap).DeepCopy()
+				}).
+				Return(nil).Maybe()
+
+			// Without a cluster-wide Proxy, remote write is sent directly.
+			c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				mock.IsType(&configv1.Proxy{}), mock.Anything).
+				Return(testutil.NewTestErrNotFound()).Maybe()
+
+			r := &userWorkloadMonitoringReconciler{client: c}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.ExpectUnready {
+				assert.False(t, result.IsZero())
+				c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+				available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, available)
+				assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyUserWorkloadMonitoring, available.Reason)
+				return
+			}
+
+			assert.True(t, result.IsZero())
+			require.NotNil(t, updated)
+
+			var config struct {
+				Prometheus struct {
+					RemoteWrite []struct {
+						Name                string `json:"name"`
+						URL                 string `json:"url"`
+						WriteRelabelConfigs []struct {
+							SourceLabels []string `json:"sourceLabels"`
+							Regex        string   `json:"regex"`
+							Action       string   `json:"action"`
+						} `json:"writeRelabelConfigs"`
+					} `json:"remoteWrite"`
+				} `json:"prometheus"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(updated.Data[monitoringConfigKey]), &config))
+			require.Len(t, config.Prometheus.RemoteWrite, 2)
+			assert.Equal(t, "admin", config.Prometheus.RemoteWrite[0].Name)
+
+			remoteWrite := config.Prometheus.RemoteWrite[1]
+			assert.Equal(t, "addon-addon-1", remoteWrite.Name)
+			assert.Equal(t, "https://observatorium.example.com/api/v1/receive", remoteWrite.URL)
+			require.Len(t, remoteWrite.WriteRelabelConfigs, 2)
+			assert.Equal(t, []string{"namespace"}, remoteWrite.WriteRelabelConfigs[0].SourceLabels)
+			assert.Equal(t, "namespace-1", remoteWrite.WriteRelabelConfigs[0].Regex)
+			assert.Equal(t, "keep", remoteWrite.WriteRelabelConfigs[0].Action)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const MONITORS_RECONCILER_NAME = "monitorsReconciler"
+
+// Marks ServiceMonitors and PodMonitors managed via .spec.monitoring,
+// to tell them apart from the federation ServiceMonitor owned by the Addon.
+const monitorLabel = "addons.managed.openshift.io/monitor"
+
+// Creates the ServiceMonitors and PodMonitors of .spec.monitoring in the addon namespaces.
+// Monitors may only scrape their own namespace and must not override target labels,
+// so addons can't scrape or impersonate workloads of other namespaces.
+type monitorsReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func (r *monitorsReconciler) Name() string {
+	return MONITORS_RECONCILER_NAME
+}
+
+func (r *monitorsReconciler) Order() subReconcilerOrder {
+	return monitorsReconcilerOrder
+}
+
+func (r *monitorsReconciler) Skippable() bool {
+	return true
+}
+
+// No sub-reconciler depends on the monitors,
+// so invalid monitors must not block the installation of the addon.
+func (r *monitorsReconciler) Independent() bool {
+	return true
+}
+
+func (r *monitorsReconciler) ConditionType() string {
+	return addonsv1alpha1.MonitorsReady
+}
+
+func (r *monitorsReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitors(addon)
+}
+
+func (r *monitorsReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if err := validateMonitorNamespaces(addon); err != nil {
+		reportInvalidMonitors(addon, err.Error())
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
+	}
+
+	serviceMonitors, err := r.desiredServiceMonitors(addon)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("building desired ServiceMonitors: %w", err)
+	}
+	podMonitors, err := r.desiredPodMonitors(addon)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("building desired PodMonitors: %w", err)
+	}
+
+	for _, sm := range serviceMonitors {
+		if err := controllers.Apply(ctx, r.client, sm); err != nil {
+			return ctrl.Result{}, fmt.Errorf("applying ServiceMonitor: %w", err)
+		}
+	}
+	for _, pm := range podMonitors {
+		if err := controllers.Apply(ctx, r.client, pm); err != nil {
+			return ctrl.Result{}, fmt.Errorf("applying PodMonitor: %w", err)
+		}
+	}
+
+	if err := r.deleteUnwantedMonitors(ctx, addon, serviceMonitors, podMonitors); err != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting unwanted monitors: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// Monitors may only be created in the namespaces of the addon.
+func validateMonitorNamespaces(addon *addonsv1alpha1.Addon) error {
+	if !HasMonitors(addon) {
+		return nil
+	}
+
+	namespaces := map[string]struct{}{}
+	for _, ns := range addon.Spec.Namespaces {
+		namespaces[ns.Name] = struct{}{}
+	}
+	for _, sm := range addon.Spec.Monitoring.ServiceMonitors {
+		if _, ok := namespaces[sm.Namespace]; !ok {
+			return fmt.Errorf("namespace %q of ServiceMonitor %q is not one of .spec.namespaces", sm.Namespace, sm.Name)
+		}
+	}
+	for _, pm := range addon.Spec.Monitoring.PodMonitors {
+		if _, ok := namespaces[pm.Namespace]; !ok {
+			return fmt.Errorf("namespace %q of PodMonitor %q is not one of .spec.namespaces", pm.Namespace, pm.Name)
+		}
+	}
+	return nil
+}
+
+func (r *monitorsReconciler) desiredServiceMonitors(
+	addon *addonsv1alpha1.Addon) ([]*monitoringv1.ServiceMonitor, error) {
+	if addon.Spec.Monitoring == nil {
+		return nil, nil
+	}
+
+	var desired []*monitoringv1.ServiceMonitor
+	for _, sm := range addon.Spec.Monitoring.ServiceMonitors {
+		serviceMonitor := &monitoringv1.ServiceMonitor{}
+		serviceMonitor.Name = sm.Name
+		serviceMonitor.Namespace = sm.Namespace
+		if err := convertMonitorSpec(sm.Spec, &serviceMonitor.Spec); err != nil {
+			return nil, fmt.Errorf("converting spec of ServiceMonitor %q: %w", sm.Name, err)
+		}
+
+		serviceMonitor.Spec.NamespaceSelector = monitoringv1.NamespaceSelector{
+			MatchNames: []string{sm.Namespace},
+		}
+		for i := range serviceMonitor.Spec.Endpoints {
+			endpoint := &serviceMonitor.Spec.Endpoints[i]
+			endpoint.HonorLabels = false
+			endpoint.RelabelConfigs = append(endpoint.RelabelConfigs, forceNamespaceLabel(sm.Namespace))
+			endpoint.MetricRelabelConfigs = append(endpoint.MetricRelabelConfigs, forceNamespaceLabel(sm.Namespace))
+		}
+
+		if err := r.addMonitorMetadata(serviceMonitor, addon); err != nil {
+			return nil, err
+		}
+		desired = append(desired, serviceMonitor)
+	}
+	return desired, nil
+}
+
+func (r *monitorsReconciler) desiredPodMonitors(
+	addon *addonsv1alpha1.Addon) ([]*monitoringv1.PodMonitor, error) {
+	if addon.Spec.Monitoring == nil {
+		return nil, nil
+	}
+
+	var desired []*monitoringv1.PodMonitor
+	for _, pm := range addon.Spec.Monitoring.PodMonitors {
+		podMonitor := &monitoringv1.PodMonitor{}
+		podMonitor.Name = pm.Name
+		podMonitor.Namespace = pm.Namespace
+		if err := convertMonitorSpec(pm.Spec, &podMonitor.Spec); err != nil {
+			return nil, fmt.Errorf("converting spec of PodMonitor %q: %w", pm.Name, err)
+		}
+
+		podMonitor.Spec.NamespaceSelector = monitoringv1.NamespaceSelector{
+			MatchNames: []string{pm.Namespace},
+		}
+		for i := range podMonitor.Spec.PodMetricsEndpoints {
+			endpoint := &podMonitor.Spec.PodMetricsEndpoints[i]
+			endpoint.HonorLabels = false
+			endpoint.RelabelConfigs = append(endpoint.RelabelConfigs, forceNamespaceLabel(pm.Namespace))
+			endpoint.MetricRelabelConfigs = append(endpoint.MetricRelabelConfigs, forceNamespaceLabel(pm.Namespace))
+		}
+
+		if err := r.addMonitorMetadata(podMonitor, addon); err != nil {
+			return nil, err
+		}
+		desired = append(desired, podMonitor)
+	}
+	return desired, nil
+}
+
+// Sets the namespace label to the namespace of the monitor.
+// Appended after the relabelings of the addon,
+// so they can't attribute series to other namespaces.
+func forceNamespaceLabel(namespace string) *monitoringv1.RelabelConfig {
+	return &monitoringv1.RelabelConfig{
+		Action:      "replace",
+		TargetLabel: "namespace",
+		Replacement: namespace,
+	}
+}
+
+func (r *monitorsReconciler) addMonitorMetadata(obj client.Object, addon *addonsv1alpha1.Addon) error {
+	controllers.AddCommonLabels(obj, addon)
+	controllers.AddCommonAnnotations(obj, addon)
+	obj.GetLabels()[monitorLabel] = "true"
+	if err := controllerutil.SetControllerReference(addon, obj, r.scheme); err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+	return nil
+}
+
+// Converts the monitor specs of the Addon API to the
+// prometheus-operator types served by cluster-monitoring.
+// Both share the same serialization.
+func convertMonitorSpec(in, out interface{}) error {
+	j, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshaling json: %w", err)
+	}
+	if err := json.Unmarshal(j, out); err != nil {
+		return fmt.Errorf("unmarshal json: %w", err)
+	}
+	return nil
+}
+
+func (r *monitorsReconciler) deleteUnwantedMonitors(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	serviceMonitors []*monitoringv1.ServiceMonitor, podMonitors []*monitoringv1.PodMonitor,
+) error {
+	selector := controllers.CommonLabelsAsLabelSelector(addon)
+	requirement, err := labels.NewRequirement(monitorLabel, "==", []string{"true"})
+	if err != nil {
+		return err
+	}
+	listOpts := client.MatchingLabelsSelector{Selector: selector.Add(*requirement)}
+
+	wanted := map[client.ObjectKey]struct{}{}
+	for _, sm := range serviceMonitors {
+		wanted[client.ObjectKeyFromObject(sm)] = struct{}{}
+	}
+	currentServiceMonitors := &monitoringv1.ServiceMonitorList{}
+	if err := r.client.List(ctx, currentServiceMonitors, listOpts); err != nil {
+		return fmt.Errorf("listing ServiceMonitors: %w", err)
+	}
+	for _, sm := range currentServiceMonitors.Items {
+		if _, ok := wanted[client.ObjectKeyFromObject(sm)]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, sm); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting ServiceMonitor: %w", err)
+		}
+	}
+
+	wanted = map[client.ObjectKey]struct{}{}
+	for _, pm := range podMonitors {
+		wanted[client.ObjectKeyFromObject(pm)] = struct{}{}
+	}
+	currentPodMonitors := &monitoringv1.PodMonitorList{}
+	if err := r.client.List(ctx, currentPodMonitors, listOpts); err != nil {
+		return fmt.Errorf("listing PodMonitors: %w", err)
+	}
+	for _, pm := range currentPodMonitors.Items {
+		if _, ok := wanted[client.ObjectKeyFromObject(pm)]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, pm); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting PodMonitor: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestMonitorsReconciler(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	addon.Spec.Monitoring = &addonsv1alpha1.MonitoringSpec{
+		ServiceMonitors: []addonsv1alpha1.AddonServiceMonitor{{
+			Name:      "metrics",
+			Namespace: "namespace-1",
+			Spec: monv1.ServiceMonitorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "addon"}},
+				Endpoints: []monv1.Endpoint{{
+					Port:        "metrics",
+					Interval:    "30s",
+					HonorLabels: true,
+					RelabelConfigs: []*monv1.RelabelConfig{{
+						Action:      "replace",
+						TargetLabel: "namespace",
+						Replacement: "openshift-monitoring",
+					}},
+				}},
+				NamespaceSelector: monv1.NamespaceSelector{Any: true},
+			},
+		}},
+		PodMonitors: []addonsv1alpha1.AddonPodMonitor{{
+			Name:      "pods",
+			Namespace: "namespace-1",
+			Spec: monv1.PodMonitorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "addon"}},
+				PodMetricsEndpoints: []monv1.PodMetricsEndpoint{{
+					Port:        "metrics",
+					HonorLabels: true,
+				}},
+				NamespaceSelector: monv1.NamespaceSelector{MatchNames: []string{"openshift-monitoring"}},
+			},
+		}},
+	}
+
+	c := testutil.NewClient()
+	var (
+		serviceMonitor *monitoringv1.ServiceMonitor
+		podMonitor     *monitoringv1.PodMonitor
+	)
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&monitoringv1.ServiceMonitor{}), client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			serviceMonitor = args.Get(1).(*monitoringv1.ServiceMonitor).DeepCopy()
+		}).
+		Return(nil)
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&monitoringv1.PodMonitor{}), client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			podMonitor = args.Get(1).(*monitoringv1.PodMonitor).DeepCopy()
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&monitoringv1.ServiceMonitorList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*monitoringv1.ServiceMonitorList)
+			list.Items = []*monitoringv1.ServiceMonitor{
+				{ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "namespace-1"}},
+				{ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: "namespace-1"}},
+			}
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&monitoringv1.PodMonitorList{}), mock.Anything).
+		Return(nil)
+	c.On("Delete", testutil.IsContext, mock.IsType(&monitoringv1.ServiceMonitor{}), mock.Anything).
+		Return(nil)
+
+	r := &monitorsReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	result, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+
+	require.NotNil(t, serviceMonitor)
+	assert.Equal(t, "namespace-1", serviceMonitor.Namespace)
+	assert.Equal(t, monitoringv1.NamespaceSelector{
+		MatchNames: []string{"namespace-1"},
+	}, serviceMonitor.Spec.NamespaceSelector)
+	// The namespace label is forced after the relabelings of the addon.
+	assert.Equal(t, []monitoringv1.Endpoint{{
+		Port:     "metrics",
+		Interval: "30s",
+		RelabelConfigs: []*monitoringv1.RelabelConfig{
+			{Action: "replace", TargetLabel: "namespace", Replacement: "openshift-monitoring"},
+			{Action: "replace", TargetLabel: "namespace", Replacement: "namespace-1"},
+		},
+		MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+			{Action: "replace", TargetLabel: "namespace", Replacement: "namespace-1"},
+		},
+	}}, serviceMonitor.Spec.Endpoints)
+	assert.Equal(t, "true", serviceMonitor.Labels[monitorLabel])
+	assert.True(t, metav1.IsControlledBy(serviceMonitor, addon))
+
+	require.NotNil(t, podMonitor)
+	assert.Equal(t, monitoringv1.NamespaceSelector{
+		MatchNames: []string{"namespace-1"},
+	}, podMonitor.Spec.NamespaceSelector)
+	assert.Equal(t, []monitoringv1.PodMetricsEndpoint{{
+		Port: "metrics",
+		RelabelConfigs: []*monitoringv1.RelabelConfig{
+			{Action: "replace", TargetLabel: "namespace", Replacement: "namespace-1"},
+		},
+		MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+			{Action: "replace", TargetLabel: "namespace", Replacement: "namespace-1"},
+		},
+	}}, podMonitor.Spec.PodMetricsEndpoints)
+
+	c.AssertNumberOfCalls(t, "Delete", 1)
+	c.AssertCalled(t, "Delete", testutil.IsContext, mock.MatchedBy(func(sm *monitoringv1.ServiceMonitor) bool {
+		return sm.Name == "removed"
+	}), mock.Anything)
+}
+
+func TestMonitorsReconciler_InvalidNamespace(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	addon.Spec.Monitoring = &addonsv1alpha1.MonitoringSpec{
+		ServiceMonitors: []addonsv1alpha1.AddonServiceMonitor{{
+			Name:      "metrics",
+			Namespace: "openshift-monitoring",
+		}},
+	}
+
+	c := testutil.NewClient()
+	r := &monitorsReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	result, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.False(t, result.IsZero())
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	require.NotNil(t, available)
+	assert.Equal(t, metav1.ConditionFalse, available.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonInvalidMonitors, available.Reason)
+}
@@ -0,0 +1,91 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Marks namespaces managed via .spec.namespaces[].adopt,
+// which must not be deleted by the addon-operator.
+const adoptedNamespaceLabel = "addons.managed.openshift.io/adopted"
+
+// Ensures labels and annotations on an existing namespace, without owning it.
+// Returns nil if the namespace does not exist (yet).
+func (r *namespaceReconciler) ensureAdoptedNamespace(
+	ctx context.Context, addon *addonsv1alpha1.Addon, namespace addonsv1alpha1.AddonNamespace,
+) (*corev1.Namespace, error) {
+	currentNamespace := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: namespace.Name}, currentNamespace); k8sApiErrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting adopted Namespace: %w", err)
+	}
+
+	// Copied, so the Addon spec is not modified below.
+	desired := &corev1.Namespace{}
+	WithNamespaceLabels(labels.Merge(nil, namespace.Labels))(desired)
+	WithNamespaceAnnotations(labels.Merge(nil, namespace.Annotations))(desired)
+	WithPodSecurityLabels(r.podSecurity.forAddon(addon))(desired)
+	controllers.AddCommonLabels(desired, addon)
+	controllers.AddCommonAnnotations(desired, addon)
+
+	newLabels := labels.Merge(currentNamespace.Labels, desired.Labels)
+	newLabels[adoptedNamespaceLabel] = "true"
+	newAnnotations := labels.Merge(currentNamespace.Annotations, desired.Annotations)
+	// Namespaces owned by the Addon before, are given up,
+	// so they are not garbage collected with the Addon.
+	newOwnerRefs := withoutOwnerReference(currentNamespace.OwnerReferences, addon)
+
+	if labels.Equals(newLabels, currentNamespace.Labels) &&
+		labels.Equals(newAnnotations, currentNamespace.Annotations) &&
+		len(newOwnerRefs) == len(currentNamespace.OwnerReferences) {
+		return currentNamespace, nil
+	}
+
+	currentNamespace.Labels = newLabels
+	currentNamespace.Annotations = newAnnotations
+	currentNamespace.OwnerReferences = newOwnerRefs
+	if err := r.client.Update(ctx, currentNamespace); err != nil {
+		return nil, fmt.Errorf("updating adopted Namespace: %w", err)
+	}
+	return currentNamespace, nil
+}
+
+// Removes the labels tying an adopted namespace to the Addon.
+func (r *namespaceReconciler) releaseAdoptedNamespace(
+	ctx context.Context, addon *addonsv1alpha1.Addon, namespace *corev1.Namespace,
+) error {
+	commonLabels := &metav1.ObjectMeta{}
+	controllers.AddCommonLabels(commonLabels, addon)
+
+	for key := range commonLabels.Labels {
+		delete(namespace.Labels, key)
+	}
+	delete(namespace.Labels, adoptedNamespaceLabel)
+	if err := r.client.Update(ctx, namespace); err != nil {
+		return fmt.Errorf("releasing adopted Namespace %s: %w", namespace.Name, err)
+	}
+	return nil
+}
+
+func withoutOwnerReference(
+	ownerRefs []metav1.OwnerReference, addon *addonsv1alpha1.Addon,
+) []metav1.OwnerReference {
+	var out []metav1.OwnerReference
+	for _, ref := range ownerRefs {
+		if ref.UID == addon.UID {
+			continue
+		}
+		out = append(out, ref)
+	}
+	return out
+}
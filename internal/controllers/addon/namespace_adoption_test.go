@@ -0,0 +1,138 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsureWantedNamespaces_Adopt(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithoutNamespace()
+	addon.UID = types.UID("addon-uid")
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{
+		{Name: "existing", Adopt: true, Labels: map[string]string{"foo": "bar"}},
+	}
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		testutil.IsCoreV1NamespacePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ns := args.Get(2).(*corev1.Namespace)
+			ns.Name = "existing"
+			ns.Labels = map[string]string{"team": "platform"}
+			ns.OwnerReferences = []metav1.OwnerReference{
+				{UID: "other-uid"},
+				{UID: addon.UID},
+			}
+			ns.Status.Phase = corev1.NamespaceActive
+		}).
+		Return(nil)
+	var updated *corev1.Namespace
+	c.On("Update", testutil.IsContext,
+		testutil.IsCoreV1NamespacePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = args.Get(1).(*corev1.Namespace)
+		}).
+		Return(nil)
+
+	res, err := r.ensureWantedNamespaces(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+	c.AssertExpectations(t)
+	// Adopted namespaces are never created.
+	c.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+
+	require.NotNil(t, updated)
+	assert.Equal(t, "platform", updated.Labels["team"])
+	assert.Equal(t, "bar", updated.Labels["foo"])
+	assert.Equal(t, "true", updated.Labels[adoptedNamespaceLabel])
+	assert.Equal(t, addon.Name, updated.Labels[controllers.CommonInstanceLabel])
+	assert.Equal(t, []metav1.OwnerReference{{UID: "other-uid"}}, updated.OwnerReferences)
+	// The Addon spec is left untouched.
+	assert.Equal(t, map[string]string{"foo": "bar"}, addon.Spec.Namespaces[0].Labels)
+}
+
+func TestEnsureWantedNamespaces_AdoptMissing(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithoutNamespace()
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{
+		{Name: "missing", Adopt: true},
+	}
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		testutil.IsCoreV1NamespacePtr, mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+
+	res, err := r.ensureWantedNamespaces(context.Background(), addon)
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+	c.AssertExpectations(t)
+	c.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnsureDeletionOfUnwantedNamespaces_ReleasesAdopted(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithoutNamespace()
+
+	adopted := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "existing",
+			Labels: map[string]string{
+				"team":                "platform",
+				adoptedNamespaceLabel: "true",
+			},
+		},
+	}
+	controllers.AddCommonLabels(adopted, addon)
+
+	c.On("List", testutil.IsContext, testutil.IsCoreV1NamespaceListPtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*corev1.NamespaceList)
+			list.Items = []corev1.Namespace{*adopted}
+		}).
+		Return(nil)
+	var released *corev1.Namespace
+	c.On("Update", testutil.IsContext,
+		testutil.IsCoreV1NamespacePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			released = args.Get(1).(*corev1.Namespace)
+		}).
+		Return(nil)
+
+	err := r.ensureDeletionOfUnwantedNamespaces(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+	c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+
+	require.NotNil(t, released)
+	assert.Equal(t, map[string]string{"team": "platform"}, released.Labels)
+}
@@ -0,0 +1,173 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Default time namespaces of a deleted Addon may take to terminate,
+// before they are reported as stuck.
+const defaultNamespaceDeletionTimeout = 15 * time.Minute
+
+// Namespace conditions the namespace controller reports,
+// while it can not finish deleting a namespace.
+var namespaceDeletionBlockedConditions = []corev1.NamespaceConditionType{
+	corev1.NamespaceContentRemaining,
+	corev1.NamespaceFinalizersRemaining,
+	corev1.NamespaceDeletionDiscoveryFailure,
+	corev1.NamespaceDeletionContentFailure,
+	corev1.NamespaceDeletionGVParsingFailure,
+}
+
+// Keeps a deleted Addon around until the namespaces it controls are gone,
+// so namespaces stuck in Terminating can be reported on the Addon.
+type namespaceFinalizer struct {
+	client client.Client
+}
+
+func (f *namespaceFinalizer) Finalizer() string {
+	return "addons.managed.openshift.io/namespaces"
+}
+
+func (f *namespaceFinalizer) Order() finalizerOrder {
+	return namespaceFinalizerOrder
+}
+
+// Every Addon may control namespaces, e.g. for monitoring federation.
+func (f *namespaceFinalizer) AppliesTo(*addonsv1alpha1.Addon) bool {
+	return true
+}
+
+// Deletes the namespaces controlled by the Addon and waits for them to terminate.
+// Namespaces orphaned by the uninstall strategy are left alone.
+// The Namespace watch requeues the Addon as they disappear.
+func (f *namespaceFinalizer) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	namespaces, err := getOwnedNamespacesViaCommonLabels(ctx, f.client, addon)
+	if err != nil {
+		return false, err
+	}
+
+	done := true
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if !metav1.IsControlledBy(ns, addon) {
+			continue
+		}
+		done = false
+		if !ns.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := f.client.Delete(ctx, ns); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("deleting Namespace %s: %w", ns.Name, err)
+		}
+	}
+	return done, nil
+}
+
+// Reports namespaces of a terminating Addon, that are stuck in Terminating
+// for longer than the namespace deletion timeout, together with the resources blocking them.
+// Requeues the Addon when the timeout of a terminating namespace expires.
+func (r *AddonReconciler) handleStuckNamespaces(
+	ctx context.Context, addon *addonsv1alpha1.Addon, now time.Time) (ctrl.Result, error) {
+	if !isAvailableReason(addon.Status.Conditions, addonsv1alpha1.AddonReasonTerminating) {
+		return ctrl.Result{}, nil
+	}
+
+	namespaces, err := getOwnedNamespacesViaCommonLabels(ctx, r.Client, addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	var (
+		timeout = namespaceDeletionTimeout(addon)
+		result  ctrl.Result
+		stuck   []addonsv1alpha1.AddonStuckNamespace
+	)
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if ns.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if deadline := ns.DeletionTimestamp.Add(timeout); now.Before(deadline) {
+			result = mergeResults(result, ctrl.Result{RequeueAfter: deadline.Sub(now)})
+			continue
+		}
+		stuck = append(stuck, addonsv1alpha1.AddonStuckNamespace{
+			Name:              ns.Name,
+			TerminatingSince:  *ns.DeletionTimestamp,
+			BlockingResources: namespaceDeletionBlockers(ns),
+		})
+	}
+	reportStuckNamespaces(addon, timeout, stuck)
+	return result, nil
+}
+
+func namespaceDeletionTimeout(addon *addonsv1alpha1.Addon) time.Duration {
+	v, ok := addon.Annotations[addonsv1alpha1.NamespaceDeletionTimeoutAnnotation]
+	if !ok {
+		return defaultNamespaceDeletionTimeout
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return defaultNamespaceDeletionTimeout
+	}
+	return d
+}
+
+// Lists what keeps the namespace from being deleted, from its conditions,
+// e.g. "Some content in the namespace has finalizers remaining: example.com/cleanup in 2 resource instances".
+// Falls back to the finalizers of the namespace itself.
+func namespaceDeletionBlockers(ns *corev1.Namespace) []string {
+	var blockers []string
+	for _, condType := range namespaceDeletionBlockedConditions {
+		for _, cond := range ns.Status.Conditions {
+			if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+				blockers = append(blockers, cond.Message)
+			}
+		}
+	}
+	if len(blockers) > 0 {
+		return blockers
+	}
+
+	for _, finalizer := range ns.Spec.Finalizers {
+		blockers = append(blockers, fmt.Sprintf("namespace finalizer %s", finalizer))
+	}
+	return blockers
+}
+
+func reportStuckNamespaces(
+	addon *addonsv1alpha1.Addon, timeout time.Duration, stuck []addonsv1alpha1.AddonStuckNamespace) {
+	addon.Status.StuckNamespaces = stuck
+	if len(stuck) == 0 {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.NamespaceDeletionStuck)
+		return
+	}
+
+	names := make([]string, len(stuck))
+	for i := range stuck {
+		names[i] = stuck[i].Name
+	}
+	sort.Strings(names)
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.NamespaceDeletionStuck,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonNamespacesStuckTerminating,
+		Message: fmt.Sprintf(
+			"Namespaces terminating for longer than %s: %s. See .status.stuckNamespaces for blocking resources.",
+			timeout, strings.Join(names, ", ")),
+		ObservedGeneration: addon.Generation,
+	})
+}
@@ -0,0 +1,167 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestHandleStuckNamespaces(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	terminating := func(name string, since time.Duration, conds ...corev1.NamespaceCondition) corev1.Namespace {
+		return corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				DeletionTimestamp: &metav1.Time{Time: now.Add(-since)},
+			},
+			Spec:   corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+			Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating, Conditions: conds},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		Namespaces        []corev1.Namespace
+		Annotations       map[string]string
+		ExpectedResult    ctrl.Result
+		ExpectedStuck     []addonsv1alpha1.AddonStuckNamespace
+		ExpectedCondition bool
+	}{
+		"not terminating": {
+			Namespaces: []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "addon-1"}}},
+		},
+		"terminating within timeout": {
+			Namespaces:     []corev1.Namespace{terminating("addon-1", 5*time.Minute)},
+			ExpectedResult: ctrl.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"stuck on finalizers": {
+			Namespaces: []corev1.Namespace{
+				terminating("addon-1", time.Hour, corev1.NamespaceCondition{
+					Type:    corev1.NamespaceFinalizersRemaining,
+					Status:  corev1.ConditionTrue,
+					Message: "Some content in the namespace has finalizers remaining: example.com/cleanup in 2 resource instances",
+				}, corev1.NamespaceCondition{
+					Type:   corev1.NamespaceDeletionDiscoveryFailure,
+					Status: corev1.ConditionFalse,
+				}),
+				terminating("addon-2", time.Minute),
+			},
+			ExpectedResult: ctrl.Result{RequeueAfter: 14 * time.Minute},
+			ExpectedStuck: []addonsv1alpha1.AddonStuckNamespace{{
+				Name:             "addon-1",
+				TerminatingSince: metav1.Time{Time: now.Add(-time.Hour)},
+				BlockingResources: []string{
+					"Some content in the namespace has finalizers remaining: example.com/cleanup in 2 resource instances",
+				},
+			}},
+			ExpectedCondition: true,
+		},
+		"stuck without conditions": {
+			Namespaces:  []corev1.Namespace{terminating("addon-1", 10*time.Minute)},
+			Annotations: map[string]string{addonsv1alpha1.NamespaceDeletionTimeoutAnnotation: "5m"},
+			ExpectedStuck: []addonsv1alpha1.AddonStuckNamespace{{
+				Name:              "addon-1",
+				TerminatingSince:  metav1.Time{Time: now.Add(-10 * time.Minute)},
+				BlockingResources: []string{"namespace finalizer kubernetes"},
+			}},
+			ExpectedCondition: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &AddonReconciler{Client: c}
+			c.On("List", testutil.IsContext, mock.IsType(&corev1.NamespaceList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1.NamespaceList).Items = tc.Namespaces
+				}).
+				Return(nil)
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Annotations = tc.Annotations
+			reportTerminationStatus(addon)
+
+			result, err := r.handleStuckNamespaces(context.Background(), addon, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+			assert.Equal(t, tc.ExpectedStuck, addon.Status.StuckNamespaces)
+			assert.Equal(t, tc.ExpectedCondition,
+				meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.NamespaceDeletionStuck))
+		})
+	}
+}
+
+func TestNamespaceFinalizer(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.UID = "addon-uid"
+	controlled := func(name string) corev1.Namespace {
+		ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		require.NoError(t, controllerutil.SetControllerReference(addon, &ns, testutil.NewTestSchemeWithAddonsv1alpha1()))
+		return ns
+	}
+
+	terminating := controlled("addon-2")
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	for name, tc := range map[string]struct {
+		Namespaces   []corev1.Namespace
+		ExpectedDone bool
+	}{
+		"no namespaces": {
+			ExpectedDone: true,
+		},
+		"orphaned namespace": {
+			Namespaces:   []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "addon-1"}}},
+			ExpectedDone: true,
+		},
+		"controlled namespaces": {
+			Namespaces: []corev1.Namespace{controlled("addon-1"), terminating},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("List", testutil.IsContext, mock.IsType(&corev1.NamespaceList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1.NamespaceList).Items = tc.Namespaces
+				}).
+				Return(nil)
+			c.On("Delete", testutil.IsContext, mock.IsType(&corev1.Namespace{}), mock.Anything).Return(nil)
+
+			f := &namespaceFinalizer{client: c}
+			done, err := f.Finalize(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedDone, done)
+
+			// Only namespaces not yet terminating are deleted.
+			var deletes int
+			for _, ns := range tc.Namespaces {
+				if metav1.IsControlledBy(&ns, addon) && ns.DeletionTimestamp.IsZero() {
+					deletes++
+				}
+			}
+			c.AssertNumberOfCalls(t, "Delete", deletes)
+		})
+	}
+}
@@ -15,3 +15,18 @@ func WithNamespaceAnnotations(annotations map[string]string) NamespaceOpts {
 		n.ObjectMeta.Annotations = annotations
 	}
 }
+
+// Opts the Namespace into the user-workload monitoring of the cluster.
+func WithUserWorkloadMonitoringLabel(enabled bool) NamespaceOpts {
+	return func(n *corev1.Namespace) {
+		if !enabled {
+			return
+		}
+		labels := map[string]string{}
+		for k, v := range n.ObjectMeta.Labels {
+			labels[k] = v
+		}
+		labels[userWorkloadMonitoringLabel] = "true"
+		n.ObjectMeta.Labels = labels
+	}
+}
@@ -0,0 +1,93 @@
+package addon
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Labels read by the PodSecurity admission plugin.
+const (
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	podSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	podSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+)
+
+// Default PodSecurity admission levels of Addon namespaces, configured via the AddonOperator.
+type podSecurityDefaults struct {
+	mux    sync.RWMutex
+	config addonsv1alpha1.PodSecurityConfig
+}
+
+// Replaces the default levels. A nil config removes all defaults.
+// Returns true, if the default levels changed.
+func (d *podSecurityDefaults) set(config *addonsv1alpha1.PodSecurityConfig) (changed bool) {
+	var newConfig addonsv1alpha1.PodSecurityConfig
+	if config != nil {
+		newConfig = *config
+	}
+
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	changed = d.config != newConfig
+	d.config = newConfig
+	return changed
+}
+
+// Returns the levels of the given Addon, falling back to the defaults per mode.
+func (d *podSecurityDefaults) forAddon(addon *addonsv1alpha1.Addon) addonsv1alpha1.PodSecurityConfig {
+	var config addonsv1alpha1.PodSecurityConfig
+	if d != nil {
+		d.mux.RLock()
+		config = d.config
+		d.mux.RUnlock()
+	}
+
+	if override := addon.Spec.PodSecurity; override != nil {
+		if len(override.Enforce) > 0 {
+			config.Enforce = override.Enforce
+		}
+		if len(override.Audit) > 0 {
+			config.Audit = override.Audit
+		}
+		if len(override.Warn) > 0 {
+			config.Warn = override.Warn
+		}
+	}
+	return config
+}
+
+// Sets the default PodSecurity admission levels of Addon namespaces. Concurrency safe.
+func (r *AddonReconciler) SetDefaultPodSecurity(config *addonsv1alpha1.PodSecurityConfig) {
+	if r.podSecurity.set(config) {
+		// Namespaces of all Addons have to be labeled again.
+		r.reconciled.forgetAll()
+	}
+}
+
+// Labels the Namespace with the given PodSecurity admission levels.
+// Labels set explicitly on the namespace entry of the Addon take precedence.
+func WithPodSecurityLabels(config addonsv1alpha1.PodSecurityConfig) NamespaceOpts {
+	return func(n *corev1.Namespace) {
+		labels := map[string]string{}
+		for label, level := range map[string]addonsv1alpha1.PodSecurityLevel{
+			podSecurityEnforceLabel: config.Enforce,
+			podSecurityAuditLabel:   config.Audit,
+			podSecurityWarnLabel:    config.Warn,
+		} {
+			if len(level) > 0 {
+				labels[label] = string(level)
+			}
+		}
+		if len(labels) == 0 {
+			return
+		}
+
+		for k, v := range n.ObjectMeta.Labels {
+			labels[k] = v
+		}
+		n.ObjectMeta.Labels = labels
+	}
+}
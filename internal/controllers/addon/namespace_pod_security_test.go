@@ -0,0 +1,101 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestPodSecurityDefaults(t *testing.T) {
+	t.Parallel()
+
+	d := &podSecurityDefaults{}
+	assert.True(t, d.set(&addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelBaseline,
+		Warn:    addonsv1alpha1.PodSecurityLevelRestricted,
+	}))
+	assert.False(t, d.set(&addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelBaseline,
+		Warn:    addonsv1alpha1.PodSecurityLevelRestricted,
+	}), "unchanged defaults")
+
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	assert.Equal(t, addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelBaseline,
+		Warn:    addonsv1alpha1.PodSecurityLevelRestricted,
+	}, d.forAddon(addon))
+
+	// Addons override the defaults per mode.
+	addon.Spec.PodSecurity = &addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelPrivileged,
+		Audit:   addonsv1alpha1.PodSecurityLevelRestricted,
+	}
+	assert.Equal(t, addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelPrivileged,
+		Audit:   addonsv1alpha1.PodSecurityLevelRestricted,
+		Warn:    addonsv1alpha1.PodSecurityLevelRestricted,
+	}, d.forAddon(addon))
+
+	assert.True(t, d.set(nil))
+	var nilDefaults *podSecurityDefaults
+	assert.Equal(t, *addon.Spec.PodSecurity, nilDefaults.forAddon(addon))
+}
+
+func TestWithPodSecurityLabels(t *testing.T) {
+	t.Parallel()
+
+	addonLabels := map[string]string{podSecurityEnforceLabel: "privileged"}
+	ns := &corev1.Namespace{}
+	WithNamespaceLabels(addonLabels)(ns)
+	WithPodSecurityLabels(addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelRestricted,
+		Warn:    addonsv1alpha1.PodSecurityLevelRestricted,
+	})(ns)
+
+	assert.Equal(t, map[string]string{
+		podSecurityEnforceLabel: "privileged",
+		podSecurityWarnLabel:    "restricted",
+	}, ns.Labels, "explicit namespace labels take precedence")
+	assert.Len(t, addonLabels, 1, "labels of the Addon must not be modified")
+
+	ns = &corev1.Namespace{}
+	WithPodSecurityLabels(addonsv1alpha1.PodSecurityConfig{})(ns)
+	assert.Nil(t, ns.Labels)
+}
+
+func TestEnsureWantedNamespaces_PodSecurity(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	addon.Spec.PodSecurity = &addonsv1alpha1.PodSecurityConfig{
+		Enforce: addonsv1alpha1.PodSecurityLevelRestricted,
+	}
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			ns := args.Get(1).(*corev1.Namespace)
+			assert.Equal(t, "restricted", ns.Labels[podSecurityEnforceLabel])
+			ns.Status.Phase = corev1.NamespaceActive
+		}).
+		Return(nil)
+
+	r := &namespaceReconciler{
+		client:      c,
+		scheme:      testutil.NewTestSchemeWithAddonsv1alpha1(),
+		podSecurity: &podSecurityDefaults{},
+	}
+	result, err := r.ensureWantedNamespaces(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, result.IsZero())
+	c.AssertCalled(t, "Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything)
+}
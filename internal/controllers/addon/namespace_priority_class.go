@@ -0,0 +1,113 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Ensures the PriorityClass of .spec.priorityClass
+// and removes it again when no longer configured.
+func (r *namespaceReconciler) ensurePriorityClass(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) error {
+	if addon.Spec.PriorityClass == nil {
+		return r.deleteUnwantedPriorityClass(ctx, addon)
+	}
+
+	preemptionPolicy := addon.Spec.PriorityClass.PreemptionPolicy
+	if len(preemptionPolicy) == 0 {
+		preemptionPolicy = corev1.PreemptNever
+	}
+	priorityClass := &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: addonPriorityClassName(addon),
+		},
+		Value:            addon.Spec.PriorityClass.Value,
+		PreemptionPolicy: &preemptionPolicy,
+		Description:      fmt.Sprintf("Priority of the workloads of Addon %s.", addon.Name),
+	}
+	if err := r.addOwnership(addon, priorityClass); err != nil {
+		return err
+	}
+	if err := reconcilePriorityClass(ctx, r.client, priorityClass); err != nil {
+		return fmt.Errorf("reconciling PriorityClass: %w", err)
+	}
+	return nil
+}
+
+func (r *namespaceReconciler) deleteUnwantedPriorityClass(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) error {
+	priorityClass := &schedulingv1.PriorityClass{}
+	if err := r.client.Get(ctx, client.ObjectKey{
+		Name: addonPriorityClassName(addon),
+	}, priorityClass); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !hasCommonLabelsOf(priorityClass, addon) {
+		return nil
+	}
+	if err := r.client.Delete(ctx, priorityClass); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting unwanted PriorityClass: %w", err)
+	}
+	return nil
+}
+
+// reconciles a PriorityClass.
+// The value and preemption policy of PriorityClasses are immutable,
+// so the PriorityClass is recreated when they or its owner change.
+func reconcilePriorityClass(ctx context.Context, c client.Client, priorityClass *schedulingv1.PriorityClass) error {
+	currentPriorityClass := &schedulingv1.PriorityClass{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(priorityClass), currentPriorityClass); err != nil {
+		if k8sApiErrors.IsNotFound(err) {
+			return c.Create(ctx, priorityClass)
+		}
+		return err
+	}
+
+	// PriorityClasses labeled for the Addon are adopted,
+	// e.g. when the Addon object itself was recreated.
+	ownedByAddon := controllers.HasSameController(currentPriorityClass, priorityClass)
+	if !ownedByAddon && !labels.SelectorFromSet(priorityClass.Labels).
+		Matches(labels.Set(currentPriorityClass.Labels)) {
+		return fmt.Errorf("PriorityClass %q: %w", priorityClass.Name, controllers.ErrNotOwnedByUs)
+	}
+
+	if ownedByAddon &&
+		currentPriorityClass.Value == priorityClass.Value &&
+		currentPriorityClass.PreemptionPolicy != nil &&
+		*currentPriorityClass.PreemptionPolicy == *priorityClass.PreemptionPolicy {
+		return nil
+	}
+
+	if err := c.Delete(ctx, currentPriorityClass); client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	return c.Create(ctx, priorityClass)
+}
+
+// Returns the name of the PriorityClass for the addon workloads,
+// or an empty string if none is configured.
+func effectivePriorityClassName(addon *addonsv1alpha1.Addon) string {
+	if len(addon.Spec.PriorityClassName) > 0 {
+		return addon.Spec.PriorityClassName
+	}
+	if addon.Spec.PriorityClass != nil {
+		return addonPriorityClassName(addon)
+	}
+	return ""
+}
+
+func addonPriorityClassName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s", addon.Name)
+}
@@ -0,0 +1,141 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsurePriorityClass_Create(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.PriorityClass = &addonsv1alpha1.AddonPriorityClass{Value: 1000}
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+	var created *schedulingv1.PriorityClass
+	c.On("Create", testutil.IsContext,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*schedulingv1.PriorityClass)
+		}).
+		Return(nil)
+
+	err := r.ensurePriorityClass(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	require.NotNil(t, created)
+	assert.Equal(t, "addon-addon-1", created.Name)
+	assert.Equal(t, int32(1000), created.Value)
+	assert.Equal(t, corev1.PreemptNever, *created.PreemptionPolicy)
+	assert.Equal(t, "addon-addon-1", effectivePriorityClassName(addon))
+}
+
+func TestEnsurePriorityClass_RecreatesOnValueChange(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.PriorityClass = &addonsv1alpha1.AddonPriorityClass{Value: 2000}
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			current := args.Get(2).(*schedulingv1.PriorityClass)
+			current.Value = 1000
+			preemptionPolicy := corev1.PreemptNever
+			current.PreemptionPolicy = &preemptionPolicy
+			_ = controllerutil.SetControllerReference(addon, current, r.scheme)
+		}).
+		Return(nil)
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Return(nil)
+	var created *schedulingv1.PriorityClass
+	c.On("Create", testutil.IsContext,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*schedulingv1.PriorityClass)
+		}).
+		Return(nil)
+
+	err := r.ensurePriorityClass(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	require.NotNil(t, created)
+	assert.Equal(t, int32(2000), created.Value)
+}
+
+func TestEnsurePriorityClass_NotOwned(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.PriorityClass = &addonsv1alpha1.AddonPriorityClass{Value: 1000}
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Return(nil)
+
+	err := r.ensurePriorityClass(context.Background(), addon)
+	require.ErrorIs(t, err, controllers.ErrNotOwnedByUs)
+	c.AssertExpectations(t)
+	c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnsurePriorityClass_DeletesUnwanted(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.PriorityClassName = "existing"
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			current := args.Get(2).(*schedulingv1.PriorityClass)
+			controllers.AddCommonLabels(current, addon)
+		}).
+		Return(nil)
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&schedulingv1.PriorityClass{}), mock.Anything).
+		Return(nil)
+
+	err := r.ensurePriorityClass(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+	assert.Equal(t, "existing", effectivePriorityClassName(addon))
+}
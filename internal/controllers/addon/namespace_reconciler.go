@@ -2,6 +2,7 @@ package addon
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -9,6 +10,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -23,10 +25,18 @@ const NAMESPACE_RECONCILER_NAME = "namespaceReconciler"
 type namespaceReconciler struct {
 	client client.Client
 	scheme *runtime.Scheme
+	drift  *driftReporter
+	// Default PodSecurity admission levels, optional.
+	podSecurity *podSecurityDefaults
 }
 
 func (r *namespaceReconciler) Reconcile(ctx context.Context,
 	addon *addonsv1alpha1.Addon) (reconcile.Result, error) {
+	// Ensure the PriorityClass referenced by wanted namespaces
+	if err := r.ensurePriorityClass(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure PriorityClass: %w", err)
+	}
+
 	// Ensure wanted namespaces
 	result, err := r.ensureWantedNamespaces(ctx, addon)
 	if err != nil {
@@ -35,6 +45,11 @@ func (r *namespaceReconciler) Reconcile(ctx context.Context,
 		return result, nil
 	}
 
+	// Ensure ResourceQuotas and LimitRanges in wanted namespaces
+	if err := r.ensureResourceConstraints(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure resource constraints: %w", err)
+	}
+
 	// Ensure unwanted namespaces are removed
 	if err := r.ensureDeletionOfUnwantedNamespaces(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure deletion of unwanted Namespaces: %w", err)
@@ -46,6 +61,22 @@ func (r *namespaceReconciler) Name() string {
 	return NAMESPACE_RECONCILER_NAME
 }
 
+func (r *namespaceReconciler) Order() subReconcilerOrder {
+	return namespaceReconcilerOrder
+}
+
+func (r *namespaceReconciler) Skippable() bool {
+	return true
+}
+
+func (r *namespaceReconciler) ConditionType() string {
+	return addonsv1alpha1.NamespacesReady
+}
+
+func (r *namespaceReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return true
+}
+
 // Ensure cleanup of Namespaces that are not needed anymore for the given Addon resource
 func (r *namespaceReconciler) ensureDeletionOfUnwantedNamespaces(
 	ctx context.Context, addon *addonsv1alpha1.Addon) error {
@@ -63,13 +94,27 @@ func (r *namespaceReconciler) ensureDeletionOfUnwantedNamespaces(
 	// separately by `phase_delete_unwanted_monitoring_federation`
 	wantedNamespaceNames[GetMonitoringNamespaceName(addon)] = struct{}{}
 
-	for _, namespace := range currentNamespaces {
+	for i := range currentNamespaces {
+		namespace := &currentNamespaces[i]
 		_, isWanted := wantedNamespaceNames[namespace.Name]
 		if isWanted {
 			// don't delete
 			continue
 		}
 
+		// Created federation namespaces are handled by the monitoringFederationReconciler.
+		if _, ok := namespace.Labels[monitoringFederationNamespaceLabel]; ok {
+			continue
+		}
+
+		if _, adopted := namespace.Labels[adoptedNamespaceLabel]; adopted {
+			// Adopted namespaces are released instead of deleted.
+			if err := r.releaseAdoptedNamespace(ctx, addon, namespace); err != nil {
+				return err
+			}
+			continue
+		}
+
 		err := ensureNamespaceDeletion(ctx, r.client, namespace.Name)
 		if err != nil {
 			return err
@@ -117,11 +162,32 @@ func getOwnedNamespacesViaCommonLabels(
 // returns a bool that signals the caller to stop reconciliation and retry later
 func (r *namespaceReconciler) ensureWantedNamespaces(
 	ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
-	var unreadyNamespaces []string
+	var (
+		unreadyNamespaces  []string
+		collidedNamespaces []string
+	)
 
 	for _, namespace := range addon.Spec.Namespaces {
-		ensuredNamespace, err := r.ensureNamespace(ctx, addon, namespace.Name, WithNamespaceLabels(namespace.Labels), WithNamespaceAnnotations(namespace.Annotations))
-		if err != nil {
+		if namespace.Adopt {
+			adoptedNamespace, err := r.ensureAdoptedNamespace(ctx, addon, namespace)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if adoptedNamespace == nil || adoptedNamespace.Status.Phase != corev1.NamespaceActive {
+				unreadyNamespaces = append(unreadyNamespaces, namespace.Name)
+			}
+			continue
+		}
+
+		ensuredNamespace, err := r.ensureNamespace(ctx, addon, namespace.Name,
+			WithNamespaceLabels(namespace.Labels),
+			WithNamespaceAnnotations(namespace.Annotations),
+			WithPodSecurityLabels(r.podSecurity.forAddon(addon)),
+			WithUserWorkloadMonitoringLabel(UsesUserWorkloadMonitoring(addon)))
+		if errors.Is(err, controllers.ErrNotOwnedByUs) {
+			collidedNamespaces = append(collidedNamespaces, namespace.Name)
+			continue
+		} else if err != nil {
 			return ctrl.Result{}, err
 		}
 
@@ -130,9 +196,14 @@ func (r *namespaceReconciler) ensureWantedNamespaces(
 		}
 	}
 
+	if len(collidedNamespaces) > 0 {
+		reportCollidedNamespaces(addon, collidedNamespaces)
+		return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
+	}
+
 	if len(unreadyNamespaces) > 0 {
 		reportUnreadyNamespaces(addon, unreadyNamespaces)
-		return ctrl.Result{RequeueAfter: defaultRetryAfterTime}, nil
+		return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
 	}
 
 	return ctrl.Result{}, nil
@@ -155,31 +226,114 @@ func (r *namespaceReconciler) ensureNamespace(ctx context.Context, addon *addons
 	if err != nil {
 		return nil, err
 	}
-	return reconcileNamespace(ctx, r.client, namespace)
+	if err := r.checkNamespaceCollision(ctx, addon, namespace); err != nil {
+		return nil, err
+	}
+	reconciledNamespace, changed, err := reconcileNamespace(ctx, r.client, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		r.drift.reportRemediated(ctx, addon, reconciledNamespace, "Namespace")
+	}
+	return reconciledNamespace, nil
+}
+
+// Checks whether an existing Namespace may be adopted
+// according to the NamespaceCollisionPolicy of the given Addon.
+// Returns ErrNotOwnedByUs, if the Namespace must not be adopted.
+func (r *namespaceReconciler) checkNamespaceCollision(
+	ctx context.Context, addon *addonsv1alpha1.Addon, namespace *corev1.Namespace) error {
+	policy := addon.Spec.NamespaceCollisionPolicy
+	if policy == "" || policy == addonsv1alpha1.NamespaceCollisionPolicyAdoptAlways {
+		return nil
+	}
+
+	currentNamespace := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: namespace.Name}, currentNamespace); k8sApiErrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if controllers.HasSameController(currentNamespace, namespace) ||
+		hasCommonLabelsOf(currentNamespace, addon) {
+		return nil
+	}
+
+	if policy == addonsv1alpha1.NamespaceCollisionPolicyAdoptIfUnowned &&
+		metav1.GetControllerOf(currentNamespace) == nil {
+		return nil
+	}
+	return fmt.Errorf("namespace %q: %w", namespace.Name, controllers.ErrNotOwnedByUs)
+}
+
+// Objects labeled with the common labels of an Addon have been created for it,
+// even when the Addon object itself was recreated in the meantime.
+func hasCommonLabelsOf(obj metav1.Object, addon *addonsv1alpha1.Addon) bool {
+	return controllers.CommonLabelsAsLabelSelector(addon).Matches(labels.Set(obj.GetLabels()))
 }
 
 // reconciles a Namespace and returns the current object as observed.
 // Warning: Will adopt existing Namespaces
-// reconciling a Namespace means: creating it when it is not present
-// and adopting it if our controller is not the owner of said Namespace
-func reconcileNamespace(ctx context.Context, c client.Client, namespace *corev1.Namespace) (*corev1.Namespace, error) {
+// reconciling a Namespace means: applying it server-side and adopting it
+// if our controller is not the owner of said Namespace
+func reconcileNamespace(ctx context.Context, c client.Client, namespace *corev1.Namespace) (
+	_ *corev1.Namespace, changed bool, err error) {
 	currentNamespace := &corev1.Namespace{}
-
-	if err := c.Get(ctx, client.ObjectKey{Name: namespace.Name}, currentNamespace); k8sApiErrors.IsNotFound(err) {
-		return namespace, c.Create(ctx, namespace)
-	} else if err != nil {
-		return nil, err
+	if err := c.Get(ctx, client.ObjectKey{Name: namespace.Name}, currentNamespace); err == nil {
+		if err := yieldToForeignController(ctx, c, currentNamespace, namespace); err != nil {
+			return nil, false, fmt.Errorf("adopting Namespace: %w", err)
+		}
+	} else if !k8sApiErrors.IsNotFound(err) {
+		return nil, false, err
 	}
 
-	currentNamespace.OwnerReferences = namespace.OwnerReferences
+	changed, err = controllers.ApplyChanged(ctx, c, currentNamespace, namespace)
+	return namespace, changed, err
+}
 
-	currentLabels := labels.Set(currentNamespace.Labels)
-	newLabels := labels.Merge(currentLabels, labels.Set(namespace.Labels))
-	currentNamespace.Labels = newLabels
+// Keeps the controller reference of another object on the current object,
+// as an object must not have more than one controller reference.
+// The desired object then only references its owner, without controlling it.
+// Controller references of a deleted and recreated owner of the same name are released.
+func yieldToForeignController(ctx context.Context, c client.Client, current, desired client.Object) error {
+	foreign := metav1.GetControllerOf(current)
+	owner := metav1.GetControllerOf(desired)
+	if foreign == nil || owner == nil || foreign.UID == owner.UID {
+		return nil
+	}
+	if foreign.APIVersion == owner.APIVersion &&
+		foreign.Kind == owner.Kind && foreign.Name == owner.Name {
+		return releaseController(ctx, c, current)
+	}
 
-	currentAnnotations := labels.Set(currentNamespace.Annotations)
-	newAnnotations := labels.Merge(currentAnnotations, labels.Set(namespace.Annotations))
-	currentNamespace.Annotations = newAnnotations
+	ownerRefs := make([]metav1.OwnerReference, len(desired.GetOwnerReferences()))
+	for i, ref := range desired.GetOwnerReferences() {
+		if ref.UID == owner.UID {
+			ref.Controller = pointer.Bool(false)
+		}
+		ownerRefs[i] = ref
+	}
+	desired.SetOwnerReferences(ownerRefs)
+	return nil
+}
 
-	return currentNamespace, c.Update(ctx, currentNamespace)
+// Removes the controller reference from the current object,
+// so another controller reference can be applied.
+func releaseController(ctx context.Context, c client.Client, current client.Object) error {
+	controller := metav1.GetControllerOf(current)
+	if controller == nil {
+		return nil
+	}
+
+	patch := client.MergeFrom(current.DeepCopyObject().(client.Object))
+	var ownerRefs []metav1.OwnerReference
+	for _, ref := range current.GetOwnerReferences() {
+		if ref.UID != controller.UID {
+			ownerRefs = append(ownerRefs, ref)
+		}
+	}
+	current.SetOwnerReferences(ownerRefs)
+	return c.Patch(ctx, current, patch)
 }
@@ -11,6 +11,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
@@ -38,7 +40,7 @@ func TestEnsureWantedNamespaces_AddonWithSingleNamespace_Adoption(t *testing.T)
 		arg := args.Get(2).(*corev1.Namespace)
 		testutil.NewTestExistingNamespace().DeepCopyInto(arg)
 	}).Return(nil)
-	c.On("Update", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).Run(func(args mock.Arguments) {
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 		arg := args.Get(1).(*corev1.Namespace)
 		arg.Status.Phase = corev1.NamespaceActive
 	}).Return(nil)
@@ -62,7 +64,7 @@ func TestEnsureWantedNamespaces_AddonWithSingleNamespace_Adoption(t *testing.T)
 func TestEnsureWantedNamespaces_AddonWithSingleNamespace_Create(t *testing.T) {
 	c := testutil.NewClient()
 	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).Run(func(args mock.Arguments) {
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 		arg := args.Get(1).(*corev1.Namespace)
 		arg.Status = corev1.NamespaceStatus{
 			Phase: corev1.NamespaceActive,
@@ -79,13 +81,13 @@ func TestEnsureWantedNamespaces_AddonWithSingleNamespace_Create(t *testing.T) {
 	require.NoError(t, err)
 	c.AssertExpectations(t)
 	c.AssertCalled(t, "Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything)
-	c.AssertCalled(t, "Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything)
+	c.AssertCalled(t, "Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, client.Apply, mock.Anything)
 }
 
 func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_Create(t *testing.T) {
 	c := testutil.NewClient()
 	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).Run(func(args mock.Arguments) {
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 		arg := args.Get(1).(*corev1.Namespace)
 		arg.Status = corev1.NamespaceStatus{
 			Phase: corev1.NamespaceActive,
@@ -104,7 +106,7 @@ func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_Create(t *testing.T)
 	namespaceCount := len(testutil.NewTestAddonWithMultipleNamespaces().Spec.Namespaces)
 	c.AssertExpectations(t)
 	c.AssertNumberOfCalls(t, "Get", namespaceCount)
-	c.AssertNumberOfCalls(t, "Create", namespaceCount)
+	c.AssertNumberOfCalls(t, "Patch", namespaceCount)
 }
 
 func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_SingleAdoption(t *testing.T) {
@@ -112,7 +114,7 @@ func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_SingleAdoption(t *te
 	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).
 		Return(testutil.NewTestErrNotFound()).
 		Once()
-	c.On("Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			arg := args.Get(1).(*corev1.Namespace)
 			arg.Status = corev1.NamespaceStatus{
@@ -127,7 +129,7 @@ func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_SingleAdoption(t *te
 			testutil.NewTestExistingNamespace().DeepCopyInto(arg)
 		}).
 		Return(nil)
-	c.On("Update", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
 		Return(nil).
 		Once()
 
@@ -143,8 +145,8 @@ func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_SingleAdoption(t *te
 	require.NoError(t, err)
 	c.AssertExpectations(t)
 	c.AssertNumberOfCalls(t, "Get", len(addonCopy.Spec.Namespaces))
-	c.AssertNumberOfCalls(t, "Create", 1)
-	c.AssertNumberOfCalls(t, "Update", 1)
+	// The adopted namespace keeps the controller reference of its previous owner.
+	c.AssertNumberOfCalls(t, "Patch", len(addonCopy.Spec.Namespaces))
 
 }
 func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_MultipleAdoptions(t *testing.T) {
@@ -155,7 +157,7 @@ func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_MultipleAdoptions(t
 			testutil.NewTestExistingNamespace().DeepCopyInto(arg)
 		}).
 		Return(nil)
-	c.On("Update", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
 		Return(nil)
 
 	r := &namespaceReconciler{
@@ -170,7 +172,44 @@ func TestEnsureWantedNamespaces_AddonWithMultipleNamespaces_MultipleAdoptions(t
 	require.NoError(t, err)
 	c.AssertExpectations(t)
 	c.AssertNumberOfCalls(t, "Get", len(addonCopy.Spec.Namespaces))
-	c.AssertNumberOfCalls(t, "Update", len(addonCopy.Spec.Namespaces))
+	// Every namespace is applied only owned, but not controlled by the Addon.
+	c.AssertNumberOfCalls(t, "Patch", len(addonCopy.Spec.Namespaces))
+	c.AssertCalled(t, "Patch", testutil.IsContext, mock.MatchedBy(func(ns *corev1.Namespace) bool {
+		return len(ns.OwnerReferences) == 1 && metav1.GetControllerOf(ns) == nil
+	}), mock.Anything, mock.Anything)
+}
+
+func TestReconcileNamespace_ReleasesControllerOfRecreatedAddon(t *testing.T) {
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	addon.UID = "new-uid"
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(2).(*corev1.Namespace)
+			arg.OwnerReferences = []metav1.OwnerReference{{
+				APIVersion: addonsv1alpha1.GroupVersion.String(),
+				Kind:       "Addon",
+				Name:       addon.Name,
+				UID:        "old-uid",
+				Controller: pointer.Bool(true),
+			}}
+		}).
+		Return(nil)
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
+		Return(nil)
+
+	r := &namespaceReconciler{
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		client: c,
+	}
+
+	ensuredNamespace, err := r.ensureNamespace(context.Background(), addon, addon.Spec.Namespaces[0].Name)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+	// The stale controller reference is released and the Addon applied as controller.
+	c.AssertNumberOfCalls(t, "Patch", 2)
+	assert.True(t, metav1.IsControlledBy(ensuredNamespace, addon))
 }
 
 func TestEnsureNamespace_Create(t *testing.T) {
@@ -178,7 +217,7 @@ func TestEnsureNamespace_Create(t *testing.T) {
 
 	c := testutil.NewClient()
 	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(nil)
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).Return(nil)
 
 	r := &namespaceReconciler{
 		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
@@ -206,7 +245,7 @@ func TestEnsureNamespace_CreateWithLabelsAndAnnotations(t *testing.T) {
 
 	c := testutil.NewClient()
 	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
 		Run(func(args mock.Arguments) {
 			ns := args.Get(1).(*corev1.Namespace)
 			for key, value := range labels {
@@ -239,10 +278,10 @@ func TestReconcileNamespace_Create(t *testing.T) {
 
 	c := testutil.NewClient()
 	c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(testutil.NewTestErrNotFound())
-	c.On("Create", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything).Return(nil, namespace)
+	c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).Return(nil)
 
 	ctx := context.Background()
-	reconciledNamespace, err := reconcileNamespace(ctx, c, namespace)
+	reconciledNamespace, _, err := reconcileNamespace(ctx, c, namespace)
 	require.NoError(t, err)
 	assert.NotNil(t, reconciledNamespace)
 	assert.Equal(t, namespaceCopy.OwnerReferences, reconciledNamespace.OwnerReferences)
@@ -250,7 +289,7 @@ func TestReconcileNamespace_Create(t *testing.T) {
 	c.AssertCalled(t, "Get", testutil.IsContext, client.ObjectKey{
 		Name: namespace.Name,
 	}, testutil.IsCoreV1NamespacePtr, mock.Anything)
-	c.AssertCalled(t, "Create", testutil.IsContext, namespace, mock.Anything)
+	c.AssertCalled(t, "Patch", testutil.IsContext, namespace, client.Apply, mock.Anything)
 }
 
 func TestReconcileNamespace_CreateWithAdoptionWithoutOwner(t *testing.T) {
@@ -262,14 +301,15 @@ func TestReconcileNamespace_CreateWithAdoptionWithoutOwner(t *testing.T) {
 		arg := args.Get(2).(*corev1.Namespace)
 		testutil.NewTestNamespaceWithoutOwner().DeepCopyInto(arg)
 	}).Return(nil)
-	c.On("Update",
+	c.On("Patch",
 		testutil.IsContext,
 		testutil.IsCoreV1NamespacePtr,
 		mock.Anything,
+		mock.Anything,
 	).Return(nil)
 
 	ctx := context.Background()
-	reconciledNamespace, err := reconcileNamespace(ctx, c, namespace)
+	reconciledNamespace, _, err := reconcileNamespace(ctx, c, namespace)
 
 	assert.NoError(t, err)
 	assert.Equal(t, namespaceCopy.OwnerReferences, reconciledNamespace.OwnerReferences)
@@ -285,16 +325,17 @@ func TestReconcileNamespace_CreateWithAdoptionWithOtherOwner(t *testing.T) {
 		arg := args.Get(2).(*corev1.Namespace)
 		testutil.NewTestNamespaceWithoutOwner().DeepCopyInto(arg)
 	}).Return(nil)
-	c.On("Update",
+	c.On("Patch",
 		testutil.IsContext,
 		testutil.IsCoreV1NamespacePtr,
 		mock.Anything,
+		mock.Anything,
 	).Return(nil)
 
 	ctx := context.Background()
 	namespace := testutil.NewTestNamespace()
 	namespaceCopy := namespace.DeepCopy()
-	reconciledNamespace, err := reconcileNamespace(ctx, c, namespace)
+	reconciledNamespace, _, err := reconcileNamespace(ctx, c, namespace)
 
 	assert.NoError(t, err)
 	assert.Equal(t, namespaceCopy.OwnerReferences, reconciledNamespace.OwnerReferences)
@@ -314,7 +355,7 @@ func TestReconcileNamespace_CreateWithClientError(t *testing.T) {
 	ctx := context.Background()
 	namespace := testutil.NewTestNamespace()
 	namespaceCopy := namespace.DeepCopy()
-	_, err := reconcileNamespace(ctx, c, namespace)
+	_, _, err := reconcileNamespace(ctx, c, namespace)
 	require.Error(t, err)
 	require.EqualError(t, err, timeoutErr.Error())
 	c.AssertExpectations(t)
@@ -442,3 +483,89 @@ func TestEnsureDeletionOfUnwantedNamespaces_NoNamespacesInSpec_WithClientError(t
 	require.EqualError(t, errors.Unwrap(err), timeoutErr.Error())
 	c.AssertExpectations(t)
 }
+
+func TestEnsureWantedNamespaces_Collision(t *testing.T) {
+	t.Parallel()
+
+	otherController := metav1.OwnerReference{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Name:       "other",
+		UID:        "other-uid",
+		Controller: pointer.Bool(true),
+	}
+
+	for name, tc := range map[string]struct {
+		Policy           addonsv1alpha1.NamespaceCollisionPolicy
+		OwnerReferences  []metav1.OwnerReference
+		ExpectedCollided bool
+	}{
+		"AdoptAlways adopts controlled Namespace": {
+			Policy:          addonsv1alpha1.NamespaceCollisionPolicyAdoptAlways,
+			OwnerReferences: []metav1.OwnerReference{otherController},
+		},
+		"AdoptIfUnowned adopts unowned Namespace": {
+			Policy: addonsv1alpha1.NamespaceCollisionPolicyAdoptIfUnowned,
+		},
+		"AdoptIfUnowned refuses controlled Namespace": {
+			Policy:           addonsv1alpha1.NamespaceCollisionPolicyAdoptIfUnowned,
+			OwnerReferences:  []metav1.OwnerReference{otherController},
+			ExpectedCollided: true,
+		},
+		"Fail refuses unowned Namespace": {
+			Policy:           addonsv1alpha1.NamespaceCollisionPolicyFail,
+			ExpectedCollided: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithSingleNamespace()
+			addon.Spec.NamespaceCollisionPolicy = tc.Policy
+
+			c := testutil.NewClient()
+			c.On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					ns := args.Get(2).(*corev1.Namespace)
+					ns.Name = addon.Spec.Namespaces[0].Name
+					ns.OwnerReferences = tc.OwnerReferences
+					ns.Status.Phase = corev1.NamespaceActive
+				}).
+				Return(nil)
+			c.On("Patch", testutil.IsContext, testutil.IsCoreV1NamespacePtr, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					ns := args.Get(1).(*corev1.Namespace)
+					ns.Status.Phase = corev1.NamespaceActive
+				}).
+				Return(nil).Maybe()
+
+			r := &namespaceReconciler{
+				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+				client: c,
+			}
+
+			result, err := r.ensureWantedNamespaces(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.ExpectedCollided {
+				assert.False(t, result.IsZero())
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+				require.NotNil(t, cond)
+				assert.Equal(t, addonsv1alpha1.AddonReasonCollidedNamespaces, cond.Reason)
+				c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.True(t, result.IsZero())
+				c.AssertCalled(t, "Patch", mock.Anything, mock.Anything, client.Apply, mock.Anything)
+				if len(tc.OwnerReferences) > 0 {
+					// The controller reference of the previous owner is kept.
+					c.AssertNumberOfCalls(t, "Patch", 1)
+					c.AssertCalled(t, "Patch", mock.Anything, mock.MatchedBy(func(ns *corev1.Namespace) bool {
+						return metav1.GetControllerOf(ns) == nil
+					}), client.Apply, mock.Anything)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Ensures the ResourceQuota and LimitRange of .spec.resourceConstraints
+// in every Addon namespace and reports whether a quota has been used up.
+func (r *namespaceReconciler) ensureResourceConstraints(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) error {
+	var (
+		constraints  = addon.Spec.ResourceConstraints
+		wantedQuotas = map[client.ObjectKey]struct{}{}
+		wantedLimits = map[client.ObjectKey]struct{}{}
+		exceeded     []string
+	)
+	for _, ns := range addon.Spec.Namespaces {
+		if constraints != nil && constraints.Quota != nil {
+			quota := &corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceQuotaName(addon),
+					Namespace: ns.Name,
+				},
+				Spec: *constraints.Quota,
+			}
+			if err := r.addOwnership(addon, quota); err != nil {
+				return err
+			}
+			observedQuota, err := reconcileResourceQuota(ctx, r.client, quota)
+			if err != nil {
+				return fmt.Errorf("reconciling ResourceQuota: %w", err)
+			}
+			wantedQuotas[client.ObjectKeyFromObject(quota)] = struct{}{}
+			exceeded = append(exceeded, exceededQuotaResources(observedQuota)...)
+		}
+
+		if constraints != nil && constraints.LimitRange != nil {
+			limitRange := &corev1.LimitRange{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      limitRangeName(addon),
+					Namespace: ns.Name,
+				},
+				Spec: *constraints.LimitRange,
+			}
+			if err := r.addOwnership(addon, limitRange); err != nil {
+				return err
+			}
+			if err := reconcileLimitRange(ctx, r.client, limitRange); err != nil {
+				return fmt.Errorf("reconciling LimitRange: %w", err)
+			}
+			wantedLimits[client.ObjectKeyFromObject(limitRange)] = struct{}{}
+		}
+	}
+
+	if err := r.deleteUnwantedResourceConstraints(ctx, addon, wantedQuotas, wantedLimits); err != nil {
+		return err
+	}
+
+	reportQuotaExceeded(addon, exceeded)
+	return nil
+}
+
+func (r *namespaceReconciler) addOwnership(addon *addonsv1alpha1.Addon, obj client.Object) error {
+	controllers.AddCommonLabels(obj, addon)
+	controllers.AddCommonAnnotations(obj, addon)
+	if err := controllerutil.SetControllerReference(addon, obj, r.scheme); err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+	return nil
+}
+
+func (r *namespaceReconciler) deleteUnwantedResourceConstraints(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	wantedQuotas, wantedLimits map[client.ObjectKey]struct{},
+) error {
+	selector := client.MatchingLabelsSelector{
+		Selector: controllers.CommonLabelsAsLabelSelector(addon),
+	}
+
+	quotaList := &corev1.ResourceQuotaList{}
+	if err := r.client.List(ctx, quotaList, selector); err != nil {
+		return fmt.Errorf("listing ResourceQuotas: %w", err)
+	}
+	for i := range quotaList.Items {
+		quota := &quotaList.Items[i]
+		if _, ok := wantedQuotas[client.ObjectKeyFromObject(quota)]; ok {
+			continue
+		}
+		// Quotas of created federation namespaces are handled by the monitoringFederationReconciler.
+		if _, ok := quota.Labels[monitoringFederationNamespaceLabel]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, quota); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting unwanted ResourceQuota: %w", err)
+		}
+	}
+
+	limitRangeList := &corev1.LimitRangeList{}
+	if err := r.client.List(ctx, limitRangeList, selector); err != nil {
+		return fmt.Errorf("listing LimitRanges: %w", err)
+	}
+	for i := range limitRangeList.Items {
+		limitRange := &limitRangeList.Items[i]
+		if _, ok := wantedLimits[client.ObjectKeyFromObject(limitRange)]; ok {
+			continue
+		}
+		// So are their LimitRanges.
+		if _, ok := limitRange.Labels[monitoringFederationNamespaceLabel]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, limitRange); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting unwanted LimitRange: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconciles a ResourceQuota and returns a new ResourceQuota object with updated state.
+func reconcileResourceQuota(ctx context.Context, c client.Client, quota *corev1.ResourceQuota) (
+	*corev1.ResourceQuota, error) {
+	return quota, controllers.Apply(ctx, c, quota)
+}
+
+func reconcileLimitRange(ctx context.Context, c client.Client, limitRange *corev1.LimitRange) error {
+	return controllers.Apply(ctx, c, limitRange)
+}
+
+// Returns the resources of the quota, which have been used up,
+// formatted as "<namespace>/<resource>".
+func exceededQuotaResources(quota *corev1.ResourceQuota) []string {
+	var exceeded []string
+	for name, hard := range quota.Status.Hard {
+		used, ok := quota.Status.Used[name]
+		if !ok || hard.IsZero() {
+			continue
+		}
+		if used.Cmp(hard) >= 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s/%s", quota.Namespace, name))
+		}
+	}
+	return exceeded
+}
+
+func reportQuotaExceeded(addon *addonsv1alpha1.Addon, exceeded []string) {
+	if addon.Spec.ResourceConstraints == nil || addon.Spec.ResourceConstraints.Quota == nil {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.QuotaExceeded)
+		return
+	}
+
+	if len(exceeded) == 0 {
+		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+			Type:               addonsv1alpha1.QuotaExceeded,
+			Status:             metav1.ConditionFalse,
+			Reason:             addonsv1alpha1.AddonReasonResourceQuotaWithinLimits,
+			Message:            "Addon workloads are within their ResourceQuotas.",
+			ObservedGeneration: addon.Generation,
+		})
+		return
+	}
+
+	sort.Strings(exceeded)
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.QuotaExceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonResourceQuotaExceeded,
+		Message:            fmt.Sprintf("ResourceQuota used up for: %s.", strings.Join(exceeded, ", ")),
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func resourceQuotaName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-quota", addon.Name)
+}
+
+func limitRangeName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-limits", addon.Name)
+}
@@ -0,0 +1,140 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsureResourceConstraints(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &namespaceReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{{Name: "addon-1"}}
+	addon.Spec.ResourceConstraints = &addonsv1alpha1.AddonResourceConstraints{
+		Quota: &corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("10"),
+			},
+		},
+		LimitRange: &corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{
+				{
+					Type: corev1.LimitTypeContainer,
+					Default: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.ResourceQuota{}), client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			quota := args.Get(1).(*corev1.ResourceQuota)
+			quota.Status.Hard = corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("10"),
+			}
+			quota.Status.Used = corev1.ResourceList{
+				corev1.ResourcePods: resource.MustParse("10"),
+			}
+		}).
+		Return(nil)
+	var createdLimitRange *corev1.LimitRange
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.LimitRange{}), client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdLimitRange = args.Get(1).(*corev1.LimitRange)
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&corev1.ResourceQuotaList{}), mock.Anything).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&corev1.LimitRangeList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*corev1.LimitRangeList)
+			list.Items = []corev1.LimitRange{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "addon-addon-1-limits",
+						Namespace: "removed-namespace",
+					},
+				},
+				{
+					// Handled by the monitoringFederationReconciler.
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "addon-addon-1-monitoring-limits",
+						Namespace: "addon-1-monitoring",
+						Labels: map[string]string{
+							monitoringFederationNamespaceLabel: "true",
+						},
+					},
+				},
+			}
+		}).
+		Return(nil)
+	var deletedLimitRange *corev1.LimitRange
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&corev1.LimitRange{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deletedLimitRange = args.Get(1).(*corev1.LimitRange)
+		}).
+		Return(nil)
+
+	err := r.ensureResourceConstraints(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	require.NotNil(t, createdLimitRange)
+	assert.Equal(t, "addon-1", createdLimitRange.Namespace)
+	assert.Equal(t, *addon.Spec.ResourceConstraints.LimitRange, createdLimitRange.Spec)
+	require.NotNil(t, deletedLimitRange)
+	assert.Equal(t, "removed-namespace", deletedLimitRange.Namespace)
+	c.AssertNumberOfCalls(t, "Delete", 1)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.QuotaExceeded)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonResourceQuotaExceeded, cond.Reason)
+	assert.Contains(t, cond.Message, "addon-1/pods")
+}
+
+func TestExceededQuotaResources(t *testing.T) {
+	t.Parallel()
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "addon-1"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("10"),
+				corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("0"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourcePods:           resource.MustParse("3"),
+				corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+				corev1.ResourceRequestsCPU:    resource.MustParse("0"),
+			},
+		},
+	}
+	assert.Equal(t, []string{"addon-1/requests.memory"}, exceededQuotaResources(quota))
+}
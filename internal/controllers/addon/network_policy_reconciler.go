@@ -0,0 +1,244 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const NETWORK_POLICY_RECONCILER_NAME = "networkPolicyReconciler"
+
+// Marks NetworkPolicies managed via .spec.network,
+// to tell them apart from other NetworkPolicies owned by the Addon.
+const networkPolicyLabel = "addons.managed.openshift.io/network-policy"
+
+// Isolates Addon namespaces with a default-deny NetworkPolicy for ingress
+// and the NetworkPolicies allowing traffic configured in .spec.network.
+// Egress is not denied by default, so pods keep reaching DNS and the API server,
+// unless policies restrict the egress of the pods they select.
+type networkPolicyReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	drift  *driftReporter
+}
+
+func (r *networkPolicyReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	// Phase 1.
+	// Build desired NetworkPolicies for every Addon namespace.
+	desired, err := r.desiredNetworkPolicies(addon)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("building desired NetworkPolicies: %w", err)
+	}
+
+	// Phase 2.
+	// Ensure desired NetworkPolicies, correcting drift.
+	var drifted []string
+	for _, np := range desired {
+		changed, err := r.reconcileNetworkPolicy(ctx, np)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("reconciling NetworkPolicy: %w", err)
+		}
+		if changed {
+			drifted = append(drifted, client.ObjectKeyFromObject(np).String())
+			r.drift.reportRemediated(ctx, addon, np, "NetworkPolicy")
+		}
+	}
+
+	// Phase 3.
+	// Remove NetworkPolicies no longer desired.
+	if err := r.deleteUnwantedNetworkPolicies(ctx, addon, desired); err != nil {
+		return ctrl.Result{}, fmt.Errorf("deleting unwanted NetworkPolicies: %w", err)
+	}
+
+	reportNetworkPolicyDrift(addon, drifted)
+	return ctrl.Result{}, nil
+}
+
+func (r *networkPolicyReconciler) Name() string {
+	return NETWORK_POLICY_RECONCILER_NAME
+}
+
+func (r *networkPolicyReconciler) Order() subReconcilerOrder {
+	return networkPolicyReconcilerOrder
+}
+
+func (r *networkPolicyReconciler) Skippable() bool {
+	return true
+}
+
+func (r *networkPolicyReconciler) ConditionType() string {
+	return addonsv1alpha1.NetworkPoliciesReady
+}
+
+func (r *networkPolicyReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return true
+}
+
+func (r *networkPolicyReconciler) desiredNetworkPolicies(
+	addon *addonsv1alpha1.Addon,
+) ([]*networkingv1.NetworkPolicy, error) {
+	if addon.Spec.Network == nil {
+		return nil, nil
+	}
+
+	var desired []*networkingv1.NetworkPolicy
+	for _, ns := range addon.Spec.Namespaces {
+		defaultDeny := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("addon-%s-default-deny", addon.Name),
+				Namespace: ns.Name,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{
+					networkingv1.PolicyTypeIngress,
+				},
+			},
+		}
+		desired = append(desired, defaultDeny)
+
+		for _, policy := range addon.Spec.Network.Policies {
+			desired = append(desired, &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("addon-%s-%s", addon.Name, policy.Name),
+					Namespace: ns.Name,
+				},
+				Spec: networkingv1.NetworkPolicySpec{
+					PodSelector: policy.PodSelector,
+					Ingress:     policy.Ingress,
+					Egress:      policy.Egress,
+					PolicyTypes: networkPolicyTypes(policy),
+				},
+			})
+		}
+	}
+
+	for _, np := range desired {
+		controllers.AddCommonLabels(np, addon)
+		controllers.AddCommonAnnotations(np, addon)
+		np.Labels[networkPolicyLabel] = "true"
+		if err := controllerutil.SetControllerReference(addon, np, r.scheme); err != nil {
+			return nil, fmt.Errorf("setting controller reference: %w", err)
+		}
+	}
+	return desired, nil
+}
+
+func networkPolicyTypes(policy addonsv1alpha1.AddonNetworkPolicy) []networkingv1.PolicyType {
+	var types []networkingv1.PolicyType
+	if len(policy.Ingress) > 0 || len(policy.Egress) == 0 {
+		types = append(types, networkingv1.PolicyTypeIngress)
+	}
+	if len(policy.Egress) > 0 {
+		types = append(types, networkingv1.PolicyTypeEgress)
+	}
+	return types
+}
+
+// Applies the given NetworkPolicy.
+// Returns true, if an existing NetworkPolicy had to be changed.
+func (r *networkPolicyReconciler) reconcileNetworkPolicy(
+	ctx context.Context, desired *networkingv1.NetworkPolicy,
+) (changed bool, err error) {
+	actual := &networkingv1.NetworkPolicy{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), actual); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, controllers.Apply(ctx, r.client, desired)
+		}
+		return false, err
+	}
+
+	currentLabels := labels.Set(actual.Labels)
+	newLabels := labels.Merge(currentLabels, labels.Set(desired.Labels))
+
+	ownedByAddon := controllers.HasSameController(actual, desired)
+	specChanged := !equality.Semantic.DeepEqual(actual.Spec, desired.Spec)
+	labelsChanged := !labels.Equals(currentLabels, newLabels)
+	if ownedByAddon && !specChanged && !labelsChanged {
+		return false, nil
+	}
+
+	return true, controllers.Apply(ctx, r.client, desired)
+}
+
+func (r *networkPolicyReconciler) deleteUnwantedNetworkPolicies(
+	ctx context.Context, addon *addonsv1alpha1.Addon, desired []*networkingv1.NetworkPolicy,
+) error {
+	selector := controllers.CommonLabelsAsLabelSelector(addon)
+	requirement, err := labels.NewRequirement(networkPolicyLabel, "==", []string{"true"})
+	if err != nil {
+		return err
+	}
+
+	currentList := &networkingv1.NetworkPolicyList{}
+	if err := r.client.List(ctx, currentList, client.MatchingLabelsSelector{
+		Selector: selector.Add(*requirement),
+	}); err != nil {
+		return fmt.Errorf("listing NetworkPolicies: %w", err)
+	}
+
+	wanted := map[client.ObjectKey]struct{}{}
+	for _, np := range desired {
+		wanted[client.ObjectKeyFromObject(np)] = struct{}{}
+	}
+	for i := range currentList.Items {
+		np := &currentList.Items[i]
+		if _, ok := wanted[client.ObjectKeyFromObject(np)]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, np); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting NetworkPolicy: %w", err)
+		}
+	}
+	return nil
+}
+
+// Reports NetworkPolicies that were changed outside of the Addon.
+// Changes of the Addon spec itself are not reported as drift.
+// Drift stays reported until the next change of the Addon spec.
+func reportNetworkPolicyDrift(addon *addonsv1alpha1.Addon, drifted []string) {
+	if addon.Spec.Network == nil {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.NetworkPolicyDrift)
+		return
+	}
+
+	specUnchanged := addon.Status.ObservedGeneration == addon.Generation
+	if len(drifted) > 0 && specUnchanged {
+		sort.Strings(drifted)
+		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+			Type:               addonsv1alpha1.NetworkPolicyDrift,
+			Status:             metav1.ConditionTrue,
+			Reason:             addonsv1alpha1.AddonReasonNetworkPolicyDriftCorrected,
+			Message:            fmt.Sprintf("Reset NetworkPolicies: %s.", strings.Join(drifted, ", ")),
+			ObservedGeneration: addon.Generation,
+		})
+		return
+	}
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.NetworkPolicyDrift)
+	if cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == addon.Generation {
+		return
+	}
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.NetworkPolicyDrift,
+		Status:             metav1.ConditionFalse,
+		Reason:             addonsv1alpha1.AddonReasonNetworkPoliciesInSync,
+		Message:            "NetworkPolicies match their desired state.",
+		ObservedGeneration: addon.Generation,
+	})
+}
@@ -0,0 +1,169 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func newTestAddonWithNetworkPolicies() *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Generation = 2
+	addon.Status.ObservedGeneration = 2
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{
+		{Name: "addon-1"},
+		{Name: "addon-2"},
+	}
+	addon.Spec.Network = &addonsv1alpha1.AddonNetwork{
+		Policies: []addonsv1alpha1.AddonNetworkPolicy{
+			{
+				Name: "allow-dns",
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}}},
+				},
+			},
+		},
+	}
+	return addon
+}
+
+func TestNetworkPolicyReconciler_Create(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &networkPolicyReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonWithNetworkPolicies()
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&networkingv1.NetworkPolicy{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+	var created []*networkingv1.NetworkPolicy
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&networkingv1.NetworkPolicy{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = append(created, args.Get(1).(*networkingv1.NetworkPolicy))
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&networkingv1.NetworkPolicyList{}), mock.Anything).
+		Return(nil)
+
+	res, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.AssertExpectations(t)
+
+	require.Len(t, created, 4)
+	var names []string
+	for _, np := range created {
+		names = append(names, np.Namespace+"/"+np.Name)
+		assert.Equal(t, "true", np.Labels[networkPolicyLabel])
+	}
+	assert.ElementsMatch(t, []string{
+		"addon-1/addon-addon-1-default-deny",
+		"addon-1/addon-addon-1-allow-dns",
+		"addon-2/addon-addon-1-default-deny",
+		"addon-2/addon-addon-1-allow-dns",
+	}, names)
+	// Egress is only restricted by policies declaring egress rules.
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, created[0].Spec.PolicyTypes)
+	assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, created[1].Spec.PolicyTypes)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.NetworkPolicyDrift)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+}
+
+func TestNetworkPolicyReconciler_Drift(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &networkPolicyReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonWithNetworkPolicies()
+
+	desired, err := r.desiredNetworkPolicies(addon)
+	require.NoError(t, err)
+
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&networkingv1.NetworkPolicy{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			key := args.Get(1)
+			out := args.Get(2).(*networkingv1.NetworkPolicy)
+			for _, np := range desired {
+				if key == client.ObjectKeyFromObject(np) {
+					*out = *np.DeepCopy()
+				}
+			}
+			if out.Name == "addon-addon-1-default-deny" && out.Namespace == "addon-2" {
+				// Changed outside of the Addon.
+				out.Spec.PolicyTypes = nil
+			}
+		}).
+		Return(nil)
+	var updated []*networkingv1.NetworkPolicy
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&networkingv1.NetworkPolicy{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = append(updated, args.Get(1).(*networkingv1.NetworkPolicy))
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&networkingv1.NetworkPolicyList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*networkingv1.NetworkPolicyList)
+			list.Items = []networkingv1.NetworkPolicy{
+				*desired[0],
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "addon-addon-1-removed",
+						Namespace: "addon-1",
+					},
+				},
+			}
+		}).
+		Return(nil)
+	var deleted *networkingv1.NetworkPolicy
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&networkingv1.NetworkPolicy{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deleted = args.Get(1).(*networkingv1.NetworkPolicy)
+		}).
+		Return(nil)
+
+	_, err = r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	require.Len(t, updated, 1)
+	assert.Equal(t, "addon-2", updated[0].Namespace)
+	require.NotNil(t, deleted)
+	assert.Equal(t, "addon-addon-1-removed", deleted.Name)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.NetworkPolicyDrift)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonNetworkPolicyDriftCorrected, cond.Reason)
+	assert.Contains(t, cond.Message, "addon-2/addon-addon-1-default-deny")
+
+	// Drift stays reported for the current generation.
+	reportNetworkPolicyDrift(addon, nil)
+	assert.True(t, meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.NetworkPolicyDrift))
+}
@@ -0,0 +1,153 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+// Bounds of the exponential backoff between retries of failed reports.
+const (
+	ocmInstallationReportMinRetryDelay = 5 * time.Second
+	ocmInstallationReportMaxRetryDelay = 5 * time.Minute
+)
+
+// Queues retries of Addon states, which failed to be reported
+// to the OCM AddOn installations API, with a per Addon backoff.
+type ocmInstallationReportRetries struct {
+	limiter workqueue.RateLimiter
+}
+
+func newOCMInstallationReportRetries() *ocmInstallationReportRetries {
+	return &ocmInstallationReportRetries{
+		limiter: workqueue.NewItemExponentialFailureRateLimiter(
+			ocmInstallationReportMinRetryDelay, ocmInstallationReportMaxRetryDelay),
+	}
+}
+
+// Returns the delay until the failed report of the Addon is retried.
+func (q *ocmInstallationReportRetries) retry(addon *addonsv1alpha1.Addon) time.Duration {
+	if q == nil {
+		return ocmInstallationReportMinRetryDelay
+	}
+	return q.limiter.When(addon.Name)
+}
+
+// Resets the backoff of the Addon after its state was reported.
+func (q *ocmInstallationReportRetries) forget(addon *addonsv1alpha1.Addon) {
+	if q == nil {
+		return
+	}
+	q.limiter.Forget(addon.Name)
+}
+
+// Reports the installation state and version of the Addon to the OCM AddOn installations API,
+// whenever they changed since the last report.
+// Failed reports are retried with backoff, while the Addon reports OCMReportingDegraded.
+func (r *AddonReconciler) handleOCMInstallationReporting(
+	ctx context.Context,
+	log logr.Logger,
+	addon *addonsv1alpha1.Addon,
+) ctrl.Result {
+	if !r.statusReportingEnabled {
+		return ctrl.Result{}
+	}
+
+	r.ocmClientMux.RLock()
+	defer r.ocmClientMux.RUnlock()
+
+	if r.ocmDisabled {
+		r.ocmInstallationReportRetries.forget(addon)
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.OCMReportingDegraded)
+		return ctrl.Result{}
+	}
+	if r.ocmClient == nil {
+		// All Addons will be requeued when the client becomes available for the first time.
+		return ctrl.Result{}
+	}
+
+	current := currentOCMInstallationStatus(addon)
+	if reported := addon.Status.OCMReportedInstallation; reported != nil &&
+		reported.State == current.State &&
+		reported.StateDescription == current.StateDescription &&
+		reported.Version == current.Version {
+		return ctrl.Result{}
+	}
+
+	req := ocm.AddOnInstallationPatchRequest{
+		ID:               addon.Name,
+		State:            ocm.AddOnInstallationState(current.State),
+		StateDescription: current.StateDescription,
+	}
+	if len(current.Version) > 0 {
+		req.AddonVersion = &ocm.AddOnInstallationVersion{ID: current.Version}
+	}
+
+	log.Info("reporting addon installation state", "state", current.State, "version", current.Version)
+	if _, err := r.ocmClient.PatchAddOnInstallation(ctx, req); err != nil {
+		delay := r.backoff.interval(failureClassOCMError)
+		if retryAfter, ok := ocm.RetryAfter(err); ok && delay == 0 {
+			// OCM is unavailable, retry once it is let through again.
+			delay = retryAfter
+		} else if delay == 0 {
+			delay = r.ocmInstallationReportRetries.retry(addon)
+		}
+		log.Error(err, "reporting addon installation state", "retryAfter", delay)
+		reportOCMReportingDegraded(addon, err)
+		return ctrl.Result{RequeueAfter: delay}
+	}
+
+	r.ocmInstallationReportRetries.forget(addon)
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.OCMReportingDegraded)
+	addon.Status.OCMReportedInstallation = &current
+	return ctrl.Result{}
+}
+
+// Computes the installation state of the Addon from its short status.
+func currentOCMInstallationStatus(addon *addonsv1alpha1.Addon) addonsv1alpha1.OCMAddOnInstallationStatus {
+	var state ocm.AddOnInstallationState
+	switch addon.Status.ShortStatus {
+	case addonsv1alpha1.ShortStatusReady:
+		state = ocm.AddOnInstallationStateReady
+	case addonsv1alpha1.ShortStatusDegraded:
+		state = ocm.AddOnInstallationStateFailed
+	case addonsv1alpha1.ShortStatusDeleting:
+		state = ocm.AddOnInstallationStateDeleting
+	default:
+		state = ocm.AddOnInstallationStateInstalling
+	}
+
+	var description string
+	if state != ocm.AddOnInstallationStateReady {
+		if available := meta.FindStatusCondition(
+			addon.Status.Conditions, addonsv1alpha1.Available); available != nil {
+			description = available.Message
+		}
+	}
+
+	return addonsv1alpha1.OCMAddOnInstallationStatus{
+		State:              string(state),
+		StateDescription:   description,
+		Version:            addon.Status.InstalledVersion,
+		ObservedGeneration: addon.Generation,
+	}
+}
+
+func reportOCMReportingDegraded(addon *addonsv1alpha1.Addon, err error) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.OCMReportingDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonOCMUnreachable,
+		Message:            fmt.Sprintf("Reporting the addon state to OCM failed, retrying: %v", err),
+		ObservedGeneration: addon.Generation,
+	})
+}
@@ -0,0 +1,180 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/ocm"
+	"github.com/openshift/addon-operator/internal/ocm/ocmtest"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func newTestAddonWithShortStatus(status addonsv1alpha1.AddonShortStatus) *addonsv1alpha1.Addon {
+	return &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "addon-1",
+		},
+		Status: addonsv1alpha1.AddonStatus{
+			ShortStatus:      status,
+			InstalledVersion: "1.0.0",
+			Conditions: []metav1.Condition{{
+				Type:    addonsv1alpha1.Available,
+				Status:  metav1.ConditionFalse,
+				Reason:  addonsv1alpha1.AddonReasonUnreadyCSV,
+				Message: "csv not ready",
+			}},
+		},
+	}
+}
+
+func TestHandleOCMInstallationReporting(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ShortStatus addonsv1alpha1.AddonShortStatus
+		Expected    ocm.AddOnInstallationPatchRequest
+	}{
+		"installing": {
+			ShortStatus: addonsv1alpha1.ShortStatusInstalling,
+			Expected: ocm.AddOnInstallationPatchRequest{
+				ID:               "addon-1",
+				State:            ocm.AddOnInstallationStateInstalling,
+				StateDescription: "csv not ready",
+				AddonVersion:     &ocm.AddOnInstallationVersion{ID: "1.0.0"},
+			},
+		},
+		"ready": {
+			ShortStatus: addonsv1alpha1.ShortStatusReady,
+			Expected: ocm.AddOnInstallationPatchRequest{
+				ID:           "addon-1",
+				State:        ocm.AddOnInstallationStateReady,
+				AddonVersion: &ocm.AddOnInstallationVersion{ID: "1.0.0"},
+			},
+		},
+		"failed": {
+			ShortStatus: addonsv1alpha1.ShortStatusDegraded,
+			Expected: ocm.AddOnInstallationPatchRequest{
+				ID:               "addon-1",
+				State:            ocm.AddOnInstallationStateFailed,
+				StateDescription: "csv not ready",
+				AddonVersion:     &ocm.AddOnInstallationVersion{ID: "1.0.0"},
+			},
+		},
+		"deleting": {
+			ShortStatus: addonsv1alpha1.ShortStatusDeleting,
+			Expected: ocm.AddOnInstallationPatchRequest{
+				ID:               "addon-1",
+				State:            ocm.AddOnInstallationStateDeleting,
+				StateDescription: "csv not ready",
+				AddonVersion:     &ocm.AddOnInstallationVersion{ID: "1.0.0"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ocmClient := ocmtest.NewClient()
+			ocmClient.On("PatchAddOnInstallation", testutil.IsContext, tc.Expected).
+				Return(ocm.AddOnInstallationPatchResponse{}, nil)
+
+			r := &AddonReconciler{
+				ocmClient:                    ocmClient,
+				statusReportingEnabled:       true,
+				ocmInstallationReportRetries: newOCMInstallationReportRetries(),
+			}
+			addon := newTestAddonWithShortStatus(tc.ShortStatus)
+
+			res := r.handleOCMInstallationReporting(context.Background(), testutil.NewLogger(t), addon)
+			assert.True(t, res.IsZero())
+			ocmClient.AssertExpectations(t)
+
+			require.NotNil(t, addon.Status.OCMReportedInstallation)
+			assert.Equal(t, string(tc.Expected.State), addon.Status.OCMReportedInstallation.State)
+			assert.Equal(t, "1.0.0", addon.Status.OCMReportedInstallation.Version)
+
+			// Nothing changed since the last report.
+			res = r.handleOCMInstallationReporting(context.Background(), testutil.NewLogger(t), addon)
+			assert.True(t, res.IsZero())
+			ocmClient.AssertNumberOfCalls(t, "PatchAddOnInstallation", 1)
+		})
+	}
+}
+
+func TestHandleOCMInstallationReporting_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	ocmClient := ocmtest.NewClient()
+	ocmClient.On("PatchAddOnInstallation", testutil.IsContext, mock.Anything).
+		Return(ocm.AddOnInstallationPatchResponse{}, errors.New("gateway timeout")).Twice()
+
+	r := &AddonReconciler{
+		ocmClient:                    ocmClient,
+		statusReportingEnabled:       true,
+		ocmInstallationReportRetries: newOCMInstallationReportRetries(),
+	}
+	addon := newTestAddonWithShortStatus(addonsv1alpha1.ShortStatusReady)
+	log := testutil.NewLogger(t)
+
+	res := r.handleOCMInstallationReporting(context.Background(), log, addon)
+	assert.Equal(t, 5*time.Second, res.RequeueAfter)
+	res = r.handleOCMInstallationReporting(context.Background(), log, addon)
+	assert.Equal(t, 10*time.Second, res.RequeueAfter)
+
+	assert.Nil(t, addon.Status.OCMReportedInstallation)
+	degraded := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.OCMReportingDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonOCMUnreachable, degraded.Reason)
+	// Not reported to OCM as part of the addon status.
+	assert.Len(t, mapToAddonStatusConditions(addon.Status.Conditions), 1)
+
+	// OCM is reachable again.
+	ocmClient.On("PatchAddOnInstallation", testutil.IsContext, mock.Anything).
+		Return(ocm.AddOnInstallationPatchResponse{}, nil)
+	res = r.handleOCMInstallationReporting(context.Background(), log, addon)
+	assert.True(t, res.IsZero())
+	assert.NotNil(t, addon.Status.OCMReportedInstallation)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.OCMReportingDegraded))
+}
+
+func TestHandleOCMInstallationReporting_Disabled(t *testing.T) {
+	t.Parallel()
+
+	ocmClient := ocmtest.NewClient()
+	r := &AddonReconciler{ocmClient: ocmClient}
+	addon := newTestAddonWithShortStatus(addonsv1alpha1.ShortStatusReady)
+
+	res := r.handleOCMInstallationReporting(context.Background(), testutil.NewLogger(t), addon)
+	assert.True(t, res.IsZero())
+	ocmClient.AssertNotCalled(t, "PatchAddOnInstallation", mock.Anything, mock.Anything)
+}
+
+func TestHandleOCMInstallationReporting_OCMDisabled(t *testing.T) {
+	t.Parallel()
+
+	r := &AddonReconciler{
+		statusReportingEnabled:       true,
+		ocmDisabled:                  true,
+		ocmInstallationReportRetries: newOCMInstallationReportRetries(),
+	}
+	addon := newTestAddonWithShortStatus(addonsv1alpha1.ShortStatusReady)
+	reportOCMReportingDegraded(addon, errors.New("gateway timeout"))
+
+	res := r.handleOCMInstallationReporting(context.Background(), testutil.NewLogger(t), addon)
+	assert.True(t, res.IsZero())
+	assert.Nil(t, addon.Status.OCMReportedInstallation)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.OCMReportingDegraded))
+}
@@ -0,0 +1,211 @@
+package addon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const (
+	defaultOCMParameterSyncInterval = 5 * time.Minute
+	// Delay before retrying a failed sync.
+	ocmParameterSyncRetryInterval = 30 * time.Second
+)
+
+type ocmParameterSource interface {
+	getOCMAddOnParameters(ctx context.Context, addonID string) (map[string]string, error)
+}
+
+// Returns the parameter values of the Addon synced from OCM.
+// Values are polled again once the sync interval elapsed.
+// Until then, or while OCM is unavailable or disabled, the values last synced
+// into the parameters Secret are kept.
+// Returns false, if the parameters were never synced and OCM is unavailable.
+// The outcome of the latest sync is reported as OCMParametersSynced condition.
+func (r *olmReconciler) syncOCMParameters(
+	ctx context.Context, addon *addonsv1alpha1.Addon, secretKey client.ObjectKey,
+) (map[string]string, bool, error) {
+	if addon.Spec.OCMParameterSync == nil {
+		addon.Status.OCMParameters = nil
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.OCMParametersSynced)
+		return nil, true, nil
+	}
+
+	now := r.clock.Now()
+	status := addon.Status.OCMParameters
+	if status == nil || !now.Before(status.LastSyncTime.Add(ocmParameterSyncInterval(addon))) {
+		values, err := r.getOCMAddOnParameters(ctx, addon)
+		if err == nil {
+			names := make([]string, 0, len(values))
+			for name := range values {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			addon.Status.OCMParameters = &addonsv1alpha1.AddonOCMParametersStatus{
+				Names:        names,
+				Hash:         ocmParametersHash(values),
+				LastSyncTime: metav1.NewTime(now),
+			}
+			reportOCMParametersSynced(addon)
+			return values, true, nil
+		}
+		reportOCMParametersSyncFailed(addon, status, err)
+
+		if errors.Is(err, errOCMDisabled) {
+			// Installs do not wait for parameters, which are never going to be synced.
+			if status == nil {
+				return nil, true, nil
+			}
+		} else {
+			controllers.LoggerFromContext(ctx).Error(err, "syncing parameters from OCM")
+			if status == nil {
+				return nil, false, nil
+			}
+		}
+	}
+
+	values, err := lastSyncedOCMParameters(ctx, r.client, status, secretKey)
+	return values, true, err
+}
+
+func reportOCMParametersSynced(addon *addonsv1alpha1.Addon) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.OCMParametersSynced,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonOCMParametersSynced,
+		Message:            "Parameters are synced from OCM.",
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func reportOCMParametersSyncFailed(
+	addon *addonsv1alpha1.Addon, status *addonsv1alpha1.AddonOCMParametersStatus, err error,
+) {
+	message := fmt.Sprintf("Syncing parameters from OCM failed: %v.", err)
+	if status != nil {
+		message += fmt.Sprintf(" Keeping parameters last synced at %s.",
+			status.LastSyncTime.UTC().Format(time.RFC3339))
+	}
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.OCMParametersSynced,
+		Status:             metav1.ConditionFalse,
+		Reason:             addonsv1alpha1.AddonReasonOCMParametersSyncFailed,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func (r *olmReconciler) getOCMAddOnParameters(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (map[string]string, error) {
+	if r.ocmParameters == nil {
+		return nil, errOCMClientNotInitialized
+	}
+	return r.ocmParameters.getOCMAddOnParameters(ctx, addon.Name)
+}
+
+// Reads the values last synced from OCM back from the parameters Secret.
+func lastSyncedOCMParameters(
+	ctx context.Context, c client.Client,
+	status *addonsv1alpha1.AddonOCMParametersStatus, secretKey client.ObjectKey,
+) (map[string]string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, secretKey, secret); k8sApiErrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting parameters Secret: %w", err)
+	}
+
+	values := map[string]string{}
+	for _, name := range status.Names {
+		if value, ok := secret.Data[name]; ok {
+			values[name] = string(value)
+		}
+	}
+	return values, nil
+}
+
+// Merges the parameters synced from OCM into .spec.parameters.
+// Synced values override parameters of the same name and are stored in the Secret otherwise.
+func mergeOCMParameters(
+	params []addonsv1alpha1.AddonParameter, values map[string]string,
+) []addonsv1alpha1.AddonParameter {
+	merged := make([]addonsv1alpha1.AddonParameter, 0, len(params)+len(values))
+	seen := map[string]struct{}{}
+	for _, param := range params {
+		if value, ok := values[param.Name]; ok {
+			param.Value = value
+		}
+		seen[param.Name] = struct{}{}
+		merged = append(merged, param)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if _, ok := seen[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		merged = append(merged, addonsv1alpha1.AddonParameter{
+			Name: name, Value: values[name], Secret: true,
+		})
+	}
+	return merged
+}
+
+// Hash over the parameter values synced from OCM.
+func ocmParametersHash(values map[string]string) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%s\x00", name, values[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Requeues Addons syncing parameters from OCM when their next sync is due.
+func requeueForOCMParameterSync(addon *addonsv1alpha1.Addon, now time.Time) ctrl.Result {
+	if addon.Spec.OCMParameterSync == nil {
+		return ctrl.Result{}
+	}
+
+	status := addon.Status.OCMParameters
+	if status == nil {
+		return ctrl.Result{RequeueAfter: ocmParameterSyncRetryInterval}
+	}
+	next := status.LastSyncTime.Add(ocmParameterSyncInterval(addon))
+	if !next.After(now) {
+		// The last sync failed.
+		return ctrl.Result{RequeueAfter: ocmParameterSyncRetryInterval}
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}
+}
+
+func ocmParameterSyncInterval(addon *addonsv1alpha1.Addon) time.Duration {
+	if interval := addon.Spec.OCMParameterSync.Interval; interval != nil && interval.Duration > 0 {
+		return interval.Duration
+	}
+	return defaultOCMParameterSyncInterval
+}
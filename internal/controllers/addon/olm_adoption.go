@@ -0,0 +1,55 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Checks whether an OLM object about to be applied for the Addon already exists,
+// without being controlled by or labeled for the Addon, e.g. because the operator
+// was installed manually. Such objects are only taken over by Addons opting into
+// adoption via the AdoptOLMObjectsAnnotation.
+// The existing object is read into the given empty current object.
+// Returns true, if the object must be left untouched.
+func (r *olmReconciler) checkOLMObjectCollision(
+	ctx context.Context, addon *addonsv1alpha1.Addon, current, desired client.Object,
+) (collided bool, err error) {
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), current); k8sApiErrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	if controllers.HasSameController(current, desired) || hasCommonLabelsOf(current, addon) {
+		return false, nil
+	}
+	if !adoptOLMObjects(addon) {
+		return true, nil
+	}
+
+	controllers.LoggerFromContext(ctx).Info("adopting existing object",
+		"object", client.ObjectKeyFromObject(desired))
+	// Applying the desired object adds the common labels and the controller reference.
+	if err := releaseController(ctx, r.client, current); err != nil {
+		return false, fmt.Errorf("adopting %s: %w", client.ObjectKeyFromObject(desired), err)
+	}
+	return false, nil
+}
+
+func adoptOLMObjects(addon *addonsv1alpha1.Addon) bool {
+	return addon.Annotations[addonsv1alpha1.AdoptOLMObjectsAnnotation] == "true"
+}
+
+func reportCollidedOLMObjects(addon *addonsv1alpha1.Addon, collided []string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonCollidedOLMObjects, fmt.Sprintf(
+		"OLM objects already exist and are not owned by this Addon: %s. "+
+			"Set the %s annotation to \"true\" to adopt them.",
+		strings.Join(collided, ", "), addonsv1alpha1.AdoptOLMObjectsAnnotation))
+}
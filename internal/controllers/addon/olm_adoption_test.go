@@ -0,0 +1,125 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestCheckOLMObjectCollision(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Existing         bool
+		Labeled          bool
+		Adopt            bool
+		ExpectedCollided bool
+		ExpectedPatch    bool
+	}{
+		"not existing": {},
+		"labeled for the addon": {
+			Existing: true,
+			Labeled:  true,
+		},
+		"created out-of-band": {
+			Existing:         true,
+			ExpectedCollided: true,
+		},
+		"adopted": {
+			Existing:      true,
+			Adopt:         true,
+			ExpectedPatch: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			if tc.Adopt {
+				addon.Annotations = map[string]string{addonsv1alpha1.AdoptOLMObjectsAnnotation: "true"}
+			}
+			desired := testutil.NewTestSubscriptionWithoutOwner()
+			controllers.AddCommonLabels(desired, addon)
+			require.NoError(t, controllerutil.SetControllerReference(
+				addon, desired, testutil.NewTestSchemeWithAddonsv1alpha1()))
+
+			c := testutil.NewClient()
+			r := &olmReconciler{client: c}
+			get := c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything)
+			if tc.Existing {
+				get.Run(func(args mock.Arguments) {
+					current := args.Get(2).(*operatorsv1alpha1.Subscription)
+					// Installed manually, e.g. via the OperatorHub console.
+					current.OwnerReferences = []metav1.OwnerReference{{
+						APIVersion: "v1",
+						Kind:       "ConfigMap",
+						Name:       "installer",
+						UID:        "installer-uid",
+						Controller: pointer.Bool(true),
+					}}
+					if tc.Labeled {
+						controllers.AddCommonLabels(current, addon)
+					}
+				}).Return(nil)
+			} else {
+				get.Return(testutil.NewTestErrNotFound())
+			}
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything, mock.Anything).
+				Return(nil)
+
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			collided, err := r.checkOLMObjectCollision(ctx, addon, &operatorsv1alpha1.Subscription{}, desired)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedCollided, collided)
+			if tc.ExpectedPatch {
+				// The foreign controller reference is removed, before the Subscription is applied.
+				c.AssertCalled(t, "Patch", testutil.IsContext,
+					mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything, mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestEnsureOperatorGroup_Collision(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	c := testutil.NewClient()
+	r := &olmReconciler{client: c, scheme: testutil.NewTestSchemeWithAddonsv1alpha1()}
+	c.On("List", testutil.IsContext,
+		mock.IsType(&operatorsv1.OperatorGroupList{}), mock.Anything).
+		Return(nil)
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		testutil.IsOperatorsV1OperatorGroupPtr, mock.Anything).
+		Return(nil)
+
+	ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	requeueResult, err := r.ensureOperatorGroup(ctx, addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultRetry, requeueResult)
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	require.NotNil(t, cond)
+	assert.Equal(t, addonsv1alpha1.AddonReasonCollidedOLMObjects, cond.Reason)
+	assert.Contains(t, cond.Message, addonsv1alpha1.AdoptOLMObjectsAnnotation)
+}
@@ -12,29 +12,66 @@ import (
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
 	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/metrics"
 )
 
 const OLM_RECONCILER_NAME = "olmReconciler"
 
+// Phases of the olmReconciler, timed individually.
+const (
+	olmPhaseOperatorGroup              = "operatorGroup"
+	olmPhaseCatalogSourceNetworkPolicy = "catalogSourceNetworkPolicy"
+	olmPhaseCatalogSource              = "catalogSource"
+	olmPhaseAdditionalCatalogSources   = "additionalCatalogSources"
+	olmPhaseParameters                 = "parameters"
+	olmPhaseSubscription               = "subscription"
+	olmPhaseAdditionalSubscriptions    = "additionalSubscriptions"
+	olmPhaseOperatorResource           = "operatorResource"
+)
+
 type olmReconciler struct {
 	scheme                  *runtime.Scheme
 	client                  client.Client
 	uncachedClient          client.Client
 	operatorResourceHandler operatorResourceHandler
+	clock                   clock
+	drift                   *driftReporter
+	backoff                 *backoffPolicy
+	catalogImages           *catalogImagePinner
+	// Queries the upgrade edges of new catalog images.
+	catalogRegistry CatalogRegistry
+	// Source of parameter values synced from OCM, optional.
+	ocmParameters ocmParameterSource
+	// Whether OCM is disabled, optional.
+	ocmMode ocmModeSource
+	// Records phase durations, optional.
+	recorder *metrics.Recorder
 }
 
 func (r *olmReconciler) Reconcile(ctx context.Context,
-	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	addon *addonsv1alpha1.Addon) (res ctrl.Result, err error) {
 	log := controllers.LoggerFromContext(ctx)
 
-	var err error
+	// Packages are installed by the packageInstallReconciler instead.
+	if addon.Spec.Install.Type == addonsv1alpha1.PackageOperator {
+		return ctrl.Result{}, nil
+	}
+
+	phases := &phaseTimer{recorder: r.recorder}
+	defer func() {
+		if err != nil || !res.IsZero() {
+			reportReconcileStopped(addon, phases.phase)
+		}
+		phases.end(err)
+	}()
 
 	// Phase 1.
 	// Ensure OperatorGroup
+	phases.begin(olmPhaseOperatorGroup)
 	if requeueResult, err := r.ensureOperatorGroup(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure OperatorGroup: %w", err)
 	} else if requeueResult != resultNil {
-		return handleExit(requeueResult), nil
+		return r.backoff.handleOLMExit(requeueResult), nil
 	}
 
 	// Phase 2.
@@ -42,10 +79,11 @@ func (r *olmReconciler) Reconcile(ctx context.Context,
 	// Note: This Phase must preempt CatalogSource reconciliation
 	// as the CatalogSources will never report 'ready' if OLM
 	// cannot verify the status of the GRPC connection.
+	phases.begin(olmPhaseCatalogSourceNetworkPolicy)
 	if requeueResult, err := r.ensureCatalogSourcesNetworkPolicy(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure NetworkPolicy for CatalogSources: %w", err)
 	} else if requeueResult != resultNil {
-		return handleExit(requeueResult), nil
+		return r.backoff.handleOLMExit(requeueResult), nil
 	}
 
 	// Phase 3.
@@ -54,42 +92,84 @@ func (r *olmReconciler) Reconcile(ctx context.Context,
 		catalogSource *operatorsv1alpha1.CatalogSource
 		requeueResult requeueResult
 	)
+	phases.begin(olmPhaseCatalogSource)
 	if requeueResult, catalogSource, err = r.ensureCatalogSource(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure CatalogSource: %w", err)
 	} else if requeueResult != resultNil {
-		return handleExit(requeueResult), nil
+		return r.backoff.handleOLMExit(requeueResult), nil
 	}
 
 	// Phase 4.
 	// Ensure Additional CatalogSources
+	phases.begin(olmPhaseAdditionalCatalogSources)
 	if requeueResult, err = r.ensureAdditionalCatalogSources(ctx, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure additional CatalogSource: %w", err)
+	} else if requeueResult == resultRetry {
+		// CatalogSources are watched, so the Addon is requeued as soon as
+		// the connection state of one changes. No need to poll them.
+		return ctrl.Result{RequeueAfter: getDriftDetectionInterval()}, nil
 	} else if requeueResult != resultNil {
-		return handleExit(requeueResult), nil
+		return r.backoff.handleOLMExit(requeueResult), nil
 	}
 
 	// Phase 5.
+	// Ensure parameters ConfigMap and Secret
+	phases.begin(olmPhaseParameters)
+	if requeueResult, err = r.ensureParameters(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure parameters: %w", err)
+	} else if requeueResult != resultNil {
+		return r.backoff.handleOLMExit(requeueResult), nil
+	}
+
+	// Phase 6.
 	// Ensure Subscription for this Addon.
+	phases.begin(olmPhaseSubscription)
 	requeueResult, currentCSVKey, err := r.ensureSubscription(
 		ctx, log.WithName("phase-ensure-subscription"),
 		addon, catalogSource)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to ensure Subscription: %w", err)
 	} else if requeueResult != resultNil {
-		return handleExit(requeueResult), nil
+		return r.backoff.handleOLMExit(requeueResult), nil
 	}
 
-	// Phase 6
+	// Phase 7.
+	// Ensure Subscriptions against additional CatalogSources.
+	phases.begin(olmPhaseAdditionalSubscriptions)
+	if requeueResult, err := r.ensureAdditionalSubscriptions(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to ensure additional Subscriptions: %w", err)
+	} else if requeueResult != resultNil {
+		return r.backoff.handleOLMExit(requeueResult), nil
+	}
+
+	// Phase 8
 	// Observe operator API
+	phases.begin(olmPhaseOperatorResource)
 	if requeueResult, err := r.observeOperatorResource(ctx, addon, currentCSVKey); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to observe current CSV: %w", err)
 	} else if requeueResult != resultNil {
-		return handleExit(requeueResult), nil
+		return r.backoff.handleOLMExit(requeueResult), nil
 	}
 	reportLastObservedAvailableCSV(addon, currentCSVKey.String())
+	if err := r.reportInstalledVersion(ctx, addon); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to report installed version: %w", err)
+	}
+	reportKnownGood(addon, currentCSVKey.String(), catalogSource.Spec.Image)
 	return reconcile.Result{}, nil
 }
 
 func (r *olmReconciler) Name() string {
 	return OLM_RECONCILER_NAME
 }
+
+func (r *olmReconciler) Order() subReconcilerOrder {
+	return olmReconcilerOrder
+}
+
+func (r *olmReconciler) ConditionType() string {
+	return addonsv1alpha1.OLMReady
+}
+
+func (r *olmReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Install.Type != addonsv1alpha1.PackageOperator
+}
@@ -0,0 +1,202 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	multierror "github.com/hashicorp/go-multierror"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Objects younger than this are never collected,
+// so objects of Addons created while a pass is running are left alone.
+const orphanMinAge = 10 * time.Minute
+
+// Kinds of objects created for Addons, that may be left behind by crashed teardowns.
+// Namespaces and OperatorGroups are kept on purpose by the Orphan uninstall strategies,
+// so they are never collected.
+var orphanCollectedKinds = []schema.GroupVersionKind{
+	{Group: "", Version: "v1", Kind: "Secret"},
+	{Group: "", Version: "v1", Kind: "ResourceQuota"},
+	{Group: "", Version: "v1", Kind: "LimitRange"},
+	{Group: "batch", Version: "v1", Kind: "Job"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+	{Group: "scheduling.k8s.io", Version: "v1", Kind: "PriorityClass"},
+	{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "CatalogSource"},
+	{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "Subscription"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+	{Group: "addons.managed.openshift.io", Version: "v1alpha1", Kind: "AddonInstance"},
+}
+
+// Counts collected orphaned objects.
+type OrphanRecorder interface {
+	RecordOrphanCollected(kind string, dryRun bool)
+}
+
+// Periodically deletes objects labeled as managed by the addon-operator,
+// whose Addon no longer exists, e.g. because its teardown crashed.
+// Runs on the leader only.
+type OrphanCollector struct {
+	// Reads Addons.
+	client client.Client
+	// Lists and deletes object metadata, without setting up informers for every kind.
+	uncachedClient client.Client
+	recorder       OrphanRecorder
+	log            logr.Logger
+	clock          clock
+
+	interval time.Duration
+	dryRun   bool
+}
+
+type OrphanCollectorOptions struct {
+	// Interval between collection passes.
+	Interval time.Duration
+	// Only logs and counts orphaned objects, without deleting them.
+	DryRun bool
+}
+
+func NewOrphanCollector(
+	c client.Client, uncachedClient client.Client, recorder OrphanRecorder,
+	log logr.Logger, opts OrphanCollectorOptions,
+) *OrphanCollector {
+	return &OrphanCollector{
+		client:         c,
+		uncachedClient: uncachedClient,
+		recorder:       recorder,
+		log:            log,
+		clock:          defaultClock{},
+		interval:       opts.Interval,
+		dryRun:         opts.DryRun,
+	}
+}
+
+// Start implements manager.Runnable.
+func (c *OrphanCollector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := c.collect(ctx); err != nil {
+				c.log.Error(err, "collecting orphaned objects")
+			}
+		}
+	}
+}
+
+// Runs a single collection pass over all collected kinds.
+// Failing kinds and objects don't stop the pass, their errors are returned combined.
+func (c *OrphanCollector) collect(ctx context.Context) error {
+	// Objects are listed before the Addons,
+	// so an object can not belong to an Addon created in between.
+	selector, err := orphanCandidateSelector()
+	if err != nil {
+		return err
+	}
+	var (
+		multiErr   *multierror.Error
+		candidates []*metav1.PartialObjectMetadata
+	)
+	for _, gvk := range orphanCollectedKinds {
+		objs, err := c.listCandidates(ctx, gvk, selector)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		candidates = append(candidates, objs...)
+	}
+
+	addons := &addonsv1alpha1.AddonList{}
+	if err := c.client.List(ctx, addons); err != nil {
+		return multierror.Append(multiErr, fmt.Errorf("listing Addons: %w", err)).ErrorOrNil()
+	}
+	existing := map[string]struct{}{}
+	for _, addon := range addons.Items {
+		existing[addon.Name] = struct{}{}
+	}
+
+	minCreation := c.clock.Now().Add(-orphanMinAge)
+	for _, obj := range candidates {
+		addonName := obj.Labels[controllers.CommonInstanceLabel]
+		if _, ok := existing[addonName]; ok ||
+			!obj.DeletionTimestamp.IsZero() ||
+			obj.CreationTimestamp.Time.After(minCreation) {
+			continue
+		}
+		if err := c.collectOrphan(ctx, addonName, obj); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+	return multiErr.ErrorOrNil()
+}
+
+func (c *OrphanCollector) listCandidates(
+	ctx context.Context, gvk schema.GroupVersionKind, selector labels.Selector,
+) ([]*metav1.PartialObjectMetadata, error) {
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.uncachedClient.List(ctx, list,
+		client.MatchingLabelsSelector{Selector: selector}); meta.IsNoMatchError(err) {
+		// API not installed in this cluster.
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", gvk.Kind, err)
+	}
+
+	objs := make([]*metav1.PartialObjectMetadata, 0, len(list.Items))
+	for i := range list.Items {
+		obj := &list.Items[i]
+		obj.SetGroupVersionKind(gvk)
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
+
+func (c *OrphanCollector) collectOrphan(
+	ctx context.Context, addonName string, obj *metav1.PartialObjectMetadata,
+) error {
+	kind := obj.GroupVersionKind().Kind
+	log := c.log.WithValues("addon", addonName, "kind", kind, "object", client.ObjectKeyFromObject(obj))
+	if c.recorder != nil {
+		c.recorder.RecordOrphanCollected(kind, c.dryRun)
+	}
+	if c.dryRun {
+		log.Info("would delete orphaned object (dry run)")
+		return nil
+	}
+
+	log.Info("deleting orphaned object")
+	if err := c.uncachedClient.Delete(ctx, obj,
+		client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting orphaned %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+	}
+	return nil
+}
+
+// Selects objects carrying the managed-by and instance labels of the addon-operator.
+func orphanCandidateSelector() (labels.Selector, error) {
+	managedBy, err := labels.NewRequirement(controllers.CommonManagedByLabel,
+		selection.Equals, []string{controllers.CommonManagedByValue})
+	if err != nil {
+		return nil, err
+	}
+	instance, err := labels.NewRequirement(controllers.CommonInstanceLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	return labels.NewSelector().Add(*managedBy, *instance), nil
+}
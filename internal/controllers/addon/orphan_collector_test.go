@@ -0,0 +1,197 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type orphanRecorderMock struct {
+	mock.Mock
+}
+
+func (r *orphanRecorderMock) RecordOrphanCollected(kind string, dryRun bool) {
+	r.Called(kind, dryRun)
+}
+
+func TestOrphanCollector_collect(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	old := metav1.NewTime(now.Add(-time.Hour))
+
+	secret := func(name, addonName string, created metav1.Time) metav1.PartialObjectMetadata {
+		return metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "addon-ns",
+				CreationTimestamp: created,
+				Labels: map[string]string{
+					controllers.CommonManagedByLabel: controllers.CommonManagedByValue,
+					controllers.CommonInstanceLabel:  addonName,
+				},
+			},
+		}
+	}
+	terminating := secret("terminating", "deleted-addon", old)
+	terminating.DeletionTimestamp = &old
+
+	tests := map[string]struct {
+		DryRun   bool
+		Secrets  []metav1.PartialObjectMetadata
+		Expected []string
+	}{
+		"deletes objects of deleted Addons": {
+			Secrets: []metav1.PartialObjectMetadata{
+				secret("orphan", "deleted-addon", old),
+				secret("owned", "existing-addon", old),
+			},
+			Expected: []string{"orphan"},
+		},
+		"ignores young and terminating objects": {
+			Secrets: []metav1.PartialObjectMetadata{
+				secret("young", "deleted-addon", metav1.NewTime(now.Add(-time.Minute))),
+				terminating,
+			},
+		},
+		"dry run": {
+			DryRun: true,
+			Secrets: []metav1.PartialObjectMetadata{
+				secret("orphan", "deleted-addon", old),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			c.On("List", testutil.IsContext, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*addonsv1alpha1.AddonList)
+					list.Items = []addonsv1alpha1.Addon{{
+						ObjectMeta: metav1.ObjectMeta{Name: "existing-addon"},
+					}}
+				}).
+				Return(nil)
+
+			uncachedClient := testutil.NewClient()
+			uncachedClient.On("List", testutil.IsContext,
+				mock.IsType(&metav1.PartialObjectMetadataList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*metav1.PartialObjectMetadataList)
+					if list.Kind == "SecretList" {
+						list.Items = append([]metav1.PartialObjectMetadata{}, tc.Secrets...)
+					}
+				}).
+				Return(nil)
+			var deleted []string
+			uncachedClient.On("Delete", testutil.IsContext,
+				mock.IsType(&metav1.PartialObjectMetadata{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					obj := args.Get(1).(*metav1.PartialObjectMetadata)
+					assert.Equal(t, "Secret", obj.Kind)
+					deleted = append(deleted, obj.Name)
+				}).
+				Return(nil)
+
+			recorder := &orphanRecorderMock{}
+			recorder.On("RecordOrphanCollected", "Secret", tc.DryRun).Return()
+
+			clock := &testClock{}
+			clock.On("Now").Return(now)
+
+			collector := NewOrphanCollector(c, uncachedClient, recorder,
+				testutil.NewLogger(t), OrphanCollectorOptions{
+					Interval: time.Hour,
+					DryRun:   tc.DryRun,
+				})
+			collector.clock = clock
+
+			require.NoError(t, collector.collect(context.Background()))
+			assert.Equal(t, tc.Expected, deleted)
+			if tc.DryRun {
+				recorder.AssertNumberOfCalls(t, "RecordOrphanCollected", 1)
+			} else {
+				recorder.AssertNumberOfCalls(t, "RecordOrphanCollected", len(tc.Expected))
+			}
+		})
+	}
+}
+
+func TestOrphanCollector_collect_ContinuesOnErrors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	orphan := func(name string) metav1.PartialObjectMetadata {
+		return metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "addon-ns",
+				CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+				Labels: map[string]string{
+					controllers.CommonManagedByLabel: controllers.CommonManagedByValue,
+					controllers.CommonInstanceLabel:  "deleted-addon",
+				},
+			},
+		}
+	}
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).
+		Return(nil)
+
+	uncachedClient := testutil.NewClient()
+	uncachedClient.On("List", testutil.IsContext,
+		mock.MatchedBy(func(list *metav1.PartialObjectMetadataList) bool {
+			return list.Kind == "JobList"
+		}), mock.Anything).
+		Return(errors.New("listing failed"))
+	uncachedClient.On("List", testutil.IsContext,
+		mock.IsType(&metav1.PartialObjectMetadataList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*metav1.PartialObjectMetadataList)
+			if list.Kind == "SecretList" {
+				list.Items = []metav1.PartialObjectMetadata{orphan("forbidden"), orphan("orphan")}
+			}
+		}).
+		Return(nil)
+	uncachedClient.On("Delete", testutil.IsContext,
+		mock.MatchedBy(func(obj *metav1.PartialObjectMetadata) bool {
+			return obj.Name == "forbidden"
+		}), mock.Anything).
+		Return(errors.New("deleting failed"))
+	var deleted []string
+	uncachedClient.On("Delete", testutil.IsContext,
+		mock.IsType(&metav1.PartialObjectMetadata{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deleted = append(deleted, args.Get(1).(*metav1.PartialObjectMetadata).Name)
+		}).
+		Return(nil)
+
+	clock := &testClock{}
+	clock.On("Now").Return(now)
+
+	collector := NewOrphanCollector(c, uncachedClient, nil,
+		testutil.NewLogger(t), OrphanCollectorOptions{Interval: time.Hour})
+	collector.clock = clock
+
+	err := collector.collect(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "listing failed")
+	assert.Contains(t, err.Error(), "deleting failed")
+	assert.Equal(t, []string{"orphan"}, deleted)
+}
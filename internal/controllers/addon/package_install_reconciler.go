@@ -0,0 +1,137 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+
+	pkov1alpha1 "package-operator.run/apis/core/v1alpha1"
+)
+
+const packageInstallReconcilerName = "packageInstallReconciler"
+
+// Installs Addons of the PackageOperator install type
+// by deploying their package image as a ClusterPackage.
+type packageInstallReconciler struct {
+	client         client.Client
+	scheme         *runtime.Scheme
+	clusterID      string
+	ocmClusterInfo OcmClusterInfoGetter
+}
+
+func (r *packageInstallReconciler) Name() string { return packageInstallReconcilerName }
+
+func (r *packageInstallReconciler) Order() subReconcilerOrder { return packageInstallReconcilerOrder }
+
+func (r *packageInstallReconciler) ConditionType() string { return addonsv1alpha1.PackageInstallReady }
+
+func (r *packageInstallReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Install.Type == addonsv1alpha1.PackageOperator
+}
+
+func (r *packageInstallReconciler) Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if addon.Spec.Install.Type != addonsv1alpha1.PackageOperator {
+		return ctrl.Result{}, nil
+	}
+
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		return ctrl.Result{}, nil
+	}
+
+	desired, err := r.desiredClusterPackage(addon, commonConfig.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	observed, err := r.reconcileClusterPackage(ctx, desired)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling ClusterPackage: %w", err)
+	}
+
+	// The ClusterPackage is watched, so there is no need to requeue
+	// while it is rolling out.
+	reportClusterPackageStatus(addon, observed)
+	return ctrl.Result{}, nil
+}
+
+func (r *packageInstallReconciler) desiredClusterPackage(
+	addon *addonsv1alpha1.Addon, targetNamespace string,
+) (*pkov1alpha1.ClusterPackage, error) {
+	ocmClusterInfo := r.ocmClusterInfo()
+
+	// Same configuration as passed to packages deployed via spec.packageOperator.
+	config, err := json.Marshal(map[string]interface{}{
+		"addonsv1": map[string]string{
+			ClusterIDConfigKey:       r.clusterID,
+			OcmClusterIDConfigKey:    ocmClusterInfo.ID,
+			OcmClusterNameConfigKey:  ocmClusterInfo.Name,
+			TargetNamespaceConfigKey: targetNamespace,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling ClusterPackage config: %w", err)
+	}
+
+	pkg := &pkov1alpha1.ClusterPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: addon.Name,
+		},
+		Spec: pkov1alpha1.PackageSpec{
+			Image:  addon.Spec.Install.PackageOperator.Image,
+			Config: &runtime.RawExtension{Raw: config},
+		},
+	}
+	controllers.AddCommonLabels(pkg, addon)
+	controllers.AddCommonAnnotations(pkg, addon)
+	if err := controllerutil.SetControllerReference(addon, pkg, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting controller reference: %w", err)
+	}
+	return pkg, nil
+}
+
+func (r *packageInstallReconciler) reconcileClusterPackage(
+	ctx context.Context, pkg *pkov1alpha1.ClusterPackage,
+) (*pkov1alpha1.ClusterPackage, error) {
+	current := &pkov1alpha1.ClusterPackage{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(pkg), current); err == nil {
+		if err := yieldToForeignController(ctx, r.client, current, pkg); err != nil {
+			return nil, fmt.Errorf("adopting ClusterPackage: %w", err)
+		}
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return pkg, controllers.Apply(ctx, r.client, pkg)
+}
+
+// Maps the rollout status of the ClusterPackage into Addon conditions.
+func reportClusterPackageStatus(addon *addonsv1alpha1.Addon, pkg *pkov1alpha1.ClusterPackage) {
+	if invalid := meta.FindStatusCondition(pkg.Status.Conditions, pkov1alpha1.PackageInvalid); invalid != nil &&
+		invalid.Status == metav1.ConditionTrue {
+		reportUnreadyClusterPackage(addon, fmt.Sprintf("invalid: %s", invalid.Message))
+		return
+	}
+
+	available := meta.FindStatusCondition(pkg.Status.Conditions, pkov1alpha1.PackageAvailable)
+	if available == nil ||
+		available.ObservedGeneration != pkg.GetGeneration() ||
+		available.Status != metav1.ConditionTrue {
+		reportUnreadyClusterPackage(addon, "rollout in progress")
+		return
+	}
+
+	reportInstalledCondition(addon)
+	reportReadinessStatus(addon)
+}
@@ -0,0 +1,126 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	pkov1alpha1 "package-operator.run/apis/core/v1alpha1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestPackageInstallReconciler_SkipsOLMAddons(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &packageInstallReconciler{client: c}
+
+	res, err := r.Reconcile(context.Background(), testutil.NewTestAddonWithCatalogSourceImage())
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	c.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPackageInstallReconciler_Create(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey, mock.IsType(&pkov1alpha1.ClusterPackage{}), mock.Anything).
+		Return(k8serrors.NewNotFound(schema.GroupResource{}, ""))
+
+	var created *pkov1alpha1.ClusterPackage
+	c.On("Patch", testutil.IsContext, mock.IsType(&pkov1alpha1.ClusterPackage{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*pkov1alpha1.ClusterPackage)
+		}).
+		Return(nil)
+
+	r := &packageInstallReconciler{
+		client:         c,
+		scheme:         testutil.NewTestSchemeWithAddonsv1alpha1(),
+		clusterID:      "cluster-1",
+		ocmClusterInfo: func() OcmClusterInfo { return OcmClusterInfo{} },
+	}
+
+	addon := newPackageInstallTestAddon()
+	_, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	require.NotNil(t, created)
+	assert.Equal(t, addon.Name, created.Name)
+	assert.Equal(t, "quay.io/osd-addons/test-package:v1", created.Spec.Image)
+	assert.JSONEq(t,
+		`{"addonsv1":{"clusterID":"cluster-1","ocmClusterID":"","ocmClusterName":"","targetNamespace":"test"}}`,
+		string(created.Spec.Config.Raw))
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	require.NotNil(t, cond)
+	assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyClusterPackage, cond.Reason)
+}
+
+func TestReportClusterPackageStatus(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Conditions        []metav1.Condition
+		ExpectedAvailable bool
+	}{
+		"progressing": {},
+		"available": {
+			Conditions: []metav1.Condition{{
+				Type:   pkov1alpha1.PackageAvailable,
+				Status: metav1.ConditionTrue,
+			}},
+			ExpectedAvailable: true,
+		},
+		"invalid": {
+			Conditions: []metav1.Condition{{
+				Type:   pkov1alpha1.PackageInvalid,
+				Status: metav1.ConditionTrue,
+			}},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newPackageInstallTestAddon()
+			pkg := &pkov1alpha1.ClusterPackage{
+				Status: pkov1alpha1.PackageStatus{Conditions: tc.Conditions},
+			}
+
+			reportClusterPackageStatus(addon, pkg)
+			assert.Equal(t, tc.ExpectedAvailable,
+				meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Available))
+			assert.Equal(t, tc.ExpectedAvailable,
+				meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed))
+		})
+	}
+}
+
+func newPackageInstallTestAddon() *addonsv1alpha1.Addon {
+	return &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "addon-1",
+		},
+		Spec: addonsv1alpha1.AddonSpec{
+			Install: addonsv1alpha1.AddonInstallSpec{
+				Type: addonsv1alpha1.PackageOperator,
+				PackageOperator: &addonsv1alpha1.AddonInstallPackageOperator{
+					Namespace: "test",
+					Image:     "quay.io/osd-addons/test-package:v1",
+				},
+			},
+		},
+	}
+}
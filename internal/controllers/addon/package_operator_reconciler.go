@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
 
 	pkov1alpha1 "package-operator.run/apis/core/v1alpha1"
 )
@@ -63,6 +64,16 @@ type PackageOperatorReconciler struct {
 
 func (r *PackageOperatorReconciler) Name() string { return packageOperatorName }
 
+func (r *PackageOperatorReconciler) Order() subReconcilerOrder { return packageOperatorReconcilerOrder }
+
+func (r *PackageOperatorReconciler) ConditionType() string {
+	return addonsv1alpha1.PackageOperatorReady
+}
+
+func (r *PackageOperatorReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.AddonPackageOperator != nil
+}
+
 func (r *PackageOperatorReconciler) Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
 	if addon.Spec.AddonPackageOperator == nil {
 		return ctrl.Result{}, r.ensureClusterObjectTemplateTornDown(ctx, addon)
@@ -144,22 +155,10 @@ func (r *PackageOperatorReconciler) reconcileClusterObjectTemplate(ctx context.C
 		return ctrl.Result{}, fmt.Errorf("setting owner reference: %w", err)
 	}
 
-	existingClusterObjectTemplate, err := r.getExistingClusterObjectTemplate(ctx, addon)
-	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			if err := r.Client.Create(ctx, clusterObjectTemplate); err != nil {
-				return ctrl.Result{}, fmt.Errorf("creating ClusterObjectTemplate object: %w", err)
-			}
-			return ctrl.Result{}, nil
-		}
-		return ctrl.Result{}, fmt.Errorf("getting ClusterObjectTemplate object: %w", err)
-	}
-
-	clusterObjectTemplate.ResourceVersion = existingClusterObjectTemplate.ResourceVersion
-	if err := r.Client.Update(ctx, clusterObjectTemplate); err != nil {
-		return ctrl.Result{}, fmt.Errorf("updating ClusterObjectTemplate object: %w", err)
+	if err := controllers.Apply(ctx, r.Client, clusterObjectTemplate); err != nil {
+		return ctrl.Result{}, fmt.Errorf("applying ClusterObjectTemplate object: %w", err)
 	}
-	r.updateAddonStatus(addon, existingClusterObjectTemplate)
+	r.updateAddonStatus(addon, clusterObjectTemplate)
 
 	return ctrl.Result{}, nil
 }
@@ -183,6 +182,8 @@ func extractDestinationNamespace(addon *addonsv1alpha1.Addon) string {
 		return specNamespace
 	case addonsv1alpha1.OLMOwnNamespace:
 		return addon.Spec.Install.OLMOwnNamespace.Namespace
+	case addonsv1alpha1.PackageOperator:
+		return addon.Spec.Install.PackageOperator.Namespace
 	default:
 		return ""
 	}
@@ -60,23 +60,9 @@ func TestPackageOperatorReconcilerLogic(t *testing.T) {
 		"ClusterObjectTemplateMustExist": {
 			addonWithPKO,
 			map[string]subTest{
-				"Found": {
-					func(ctx context.Context, c *testutil.Client, identifier types.NamespacedName) {
-						c.On("Get", ctx, identifier, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), []client.GetOption(nil)).
-							Return(nil).
-							Once()
-						c.On("Update", ctx, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), []client.UpdateOption(nil)).
-							Return(nil).
-							Once()
-					},
-					false,
-				},
-				"NotFound": {
+				"Applied": {
 					func(ctx context.Context, c *testutil.Client, identifier types.NamespacedName) {
-						c.On("Get", ctx, identifier, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), []client.GetOption(nil)).
-							Return(errors.NewNotFound(schema.GroupResource{}, "test")).
-							Once()
-						c.On("Create", ctx, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), []client.CreateOption(nil)).
+						c.On("Patch", ctx, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), client.Apply, mock.Anything).
 							Return(nil).
 							Once()
 					},
@@ -84,12 +70,8 @@ func TestPackageOperatorReconcilerLogic(t *testing.T) {
 				},
 				"Error": {
 					func(ctx context.Context, c *testutil.Client, identifier types.NamespacedName) {
-						errIn := io.ErrClosedPipe
-						c.
-							On("Get", ctx, identifier, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), []client.GetOption(nil)).
-							Return(errors.NewNotFound(schema.GroupResource{}, "test")).
-							Once().
-							Return(errIn).
+						c.On("Patch", ctx, mock.AnythingOfType("*v1alpha1.ClusterObjectTemplate"), client.Apply, mock.Anything).
+							Return(io.ErrClosedPipe).
 							Once()
 					},
 					true,
@@ -0,0 +1,399 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/pagerduty"
+)
+
+const PAGERDUTY_RECONCILER_NAME = "pagerDutyReconciler"
+
+const (
+	// Key of the routing key in the PagerDuty Secret.
+	pagerDutyRoutingKeyKey = "routing-key"
+	// Annotation on the PagerDuty Secret recording the ID of the service.
+	pagerDutyServiceAnnotation = "addons.managed.openshift.io/pagerduty-service"
+)
+
+type pagerDutyClient interface {
+	CreateService(ctx context.Context, req pagerduty.CreateServiceRequest) (pagerduty.Service, error)
+	GetService(ctx context.Context, id string) (pagerduty.Service, error)
+	FindService(ctx context.Context, name string) (pagerduty.Service, error)
+	UpdateService(ctx context.Context, id string, req pagerduty.UpdateServiceRequest) (pagerduty.Service, error)
+	DeleteService(ctx context.Context, id string) error
+	CreateEventsIntegration(ctx context.Context, serviceID, name string) (pagerduty.Integration, error)
+}
+
+// PagerDuty account provisioning the services of Addons, configured via the AddonOperator.
+type pagerDutyAccount struct {
+	mux    sync.RWMutex
+	client pagerDutyClient
+	// Escalation policy of services, unless overridden by the Addon.
+	escalationPolicyID string
+}
+
+// Replaces the client and default escalation policy of the account. A nil client removes it.
+// Returns true, if the account became available or unavailable or the escalation policy changed.
+func (a *pagerDutyAccount) set(c pagerDutyClient, escalationPolicyID string) (changed bool) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	changed = (a.client == nil) != (c == nil) || a.escalationPolicyID != escalationPolicyID
+	a.client = c
+	a.escalationPolicyID = escalationPolicyID
+	return changed
+}
+
+// Returns the client of the account or nil and the default escalation policy.
+func (a *pagerDutyAccount) get() (pagerDutyClient, string) {
+	if a == nil {
+		return nil, ""
+	}
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+	return a.client, a.escalationPolicyID
+}
+
+// Injects the client provisioning the PagerDuty services of Addons and
+// the escalation policy used by default, a nil client disables provisioning.
+// Concurrency safe.
+func (r *AddonReconciler) InjectPagerDutyClient(c *pagerduty.Client, escalationPolicyID string) {
+	var services pagerDutyClient
+	if c != nil {
+		services = c
+	}
+	if r.pagerDuty.set(services, escalationPolicyID) {
+		// Addons waiting for the account or using the default escalation policy
+		// have to be picked up again.
+		r.reconciled.forgetAll()
+	}
+}
+
+// Provisions a PagerDuty service per Addon, so addon teams are paged
+// via an escalation policy of their own instead of a shared one.
+// The Events API v2 routing key of the service is kept in a Secret in the install namespace,
+// services deleted in PagerDuty are replaced with new ones.
+// Services are named after the Addon and the cluster, an existing service
+// of the same name is adopted, e.g. after its Secret was lost.
+type pagerDutyReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// External ID of the cluster, used to name services.
+	clusterID string
+	services  *pagerDutyAccount
+}
+
+func (r *pagerDutyReconciler) Name() string {
+	return PAGERDUTY_RECONCILER_NAME
+}
+
+func (r *pagerDutyReconciler) Order() subReconcilerOrder {
+	return pagerDutyReconcilerOrder
+}
+
+// Only acts on the Addon and the PagerDuty service itself,
+// which is re-checked every drift detection interval.
+func (r *pagerDutyReconciler) Skippable() bool {
+	return true
+}
+
+// No sub-reconciler depends on the PagerDuty service.
+func (r *pagerDutyReconciler) Independent() bool {
+	return true
+}
+
+func (r *pagerDutyReconciler) ConditionType() string {
+	return addonsv1alpha1.PagerDutyReady
+}
+
+func (r *pagerDutyReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasPagerDuty(addon)
+}
+
+func (r *pagerDutyReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	if !HasPagerDuty(addon) {
+		if _, err := deletePagerDutyService(ctx, r.client, r.services, addon); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// The skip cache is reset when the account changes,
+	// so no need to requeue while waiting for it.
+	services, defaultEscalationPolicyID := r.services.get()
+	if services == nil {
+		reportUnreadyPagerDuty(ctx, "no PagerDuty API key is configured on the AddonOperator")
+		return ctrl.Result{}, nil
+	}
+	escalationPolicyID := addon.Spec.Monitoring.PagerDuty.EscalationPolicyID
+	if len(escalationPolicyID) == 0 {
+		escalationPolicyID = defaultEscalationPolicyID
+	}
+	if len(escalationPolicyID) == 0 {
+		reportUnreadyPagerDuty(ctx, "no escalation policy is configured on the Addon or the AddonOperator")
+		return ctrl.Result{}, nil
+	}
+	if len(r.clusterID) == 0 {
+		// Services of all clusters would share the same name otherwise.
+		reportUnreadyPagerDuty(ctx, "the external ID of the cluster is unknown")
+		return ctrl.Result{}, nil
+	}
+
+	current, err := getPagerDutySecret(ctx, r.client, addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if current != nil {
+		done, err := r.reconcileExistingService(ctx, addon, services, escalationPolicyID, current)
+		if done || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.createService(ctx, addon, services, escalationPolicyID, current); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// Keeps the service recorded in the current Secret up to date.
+// Returns false, if the service is gone and has to be replaced.
+func (r *pagerDutyReconciler) reconcileExistingService(ctx context.Context,
+	addon *addonsv1alpha1.Addon, services pagerDutyClient,
+	escalationPolicyID string, current *corev1.Secret) (done bool, err error) {
+	serviceID := current.Annotations[pagerDutyServiceAnnotation]
+	service, err := services.GetService(ctx, serviceID)
+	if errors.Is(err, pagerduty.ErrNotFound) {
+		controllers.LoggerFromContext(ctx).Info("replacing deleted PagerDuty service", "service", serviceID)
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("getting PagerDuty service: %w", err)
+	}
+
+	if service.EscalationPolicy.ID != escalationPolicyID {
+		if _, err := services.UpdateService(ctx, serviceID, pagerduty.UpdateServiceRequest{
+			EscalationPolicyID: escalationPolicyID,
+		}); err != nil {
+			return false, fmt.Errorf("updating PagerDuty service: %w", err)
+		}
+	}
+
+	// Follow changes to .spec.monitoring.pagerDuty.secretName.
+	if current.Name != getPagerDutySecretName(addon) {
+		if err := r.storeRoutingKey(ctx, addon, serviceID,
+			current.Data[pagerDutyRoutingKeyKey], current); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Creates a new service with an Events API v2 integration and stores its routing key,
+// replacing the given current Secret, if any.
+// An existing service of the same name is adopted instead of creating a new one.
+func (r *pagerDutyReconciler) createService(ctx context.Context,
+	addon *addonsv1alpha1.Addon, services pagerDutyClient,
+	escalationPolicyID string, current *corev1.Secret) error {
+	name := fmt.Sprintf("%s (%s)", addon.Name, r.clusterID)
+	service, err := services.FindService(ctx, name)
+	switch {
+	case err == nil:
+		controllers.LoggerFromContext(ctx).Info("adopting existing PagerDuty service", "service", service.ID)
+		return r.adoptService(ctx, addon, services, escalationPolicyID, service, current)
+	case !errors.Is(err, pagerduty.ErrNotFound):
+		return fmt.Errorf("looking up PagerDuty service: %w", err)
+	}
+
+	service, err = services.CreateService(ctx, pagerduty.CreateServiceRequest{
+		Name:               name,
+		Description:        fmt.Sprintf("Alerts of Addon %s on cluster %s.", addon.Name, r.clusterID),
+		EscalationPolicyID: escalationPolicyID,
+	})
+	if err != nil {
+		return fmt.Errorf("creating PagerDuty service: %w", err)
+	}
+
+	integration, err := services.CreateEventsIntegration(ctx, service.ID, "addon-operator")
+	if err == nil {
+		err = r.storeRoutingKey(ctx, addon, service.ID, []byte(integration.IntegrationKey), current)
+	}
+	if err != nil {
+		// Don't leak the service, a new one is created with the next reconcile.
+		if delErr := services.DeleteService(ctx, service.ID); delErr != nil {
+			controllers.LoggerFromContext(ctx).Error(delErr, "deleting unreferenced PagerDuty service", "service", service.ID)
+		}
+		return fmt.Errorf("provisioning PagerDuty routing key: %w", err)
+	}
+	return nil
+}
+
+// Takes over an existing service, reusing its Events API v2 integration, if any.
+func (r *pagerDutyReconciler) adoptService(ctx context.Context,
+	addon *addonsv1alpha1.Addon, services pagerDutyClient,
+	escalationPolicyID string, service pagerduty.Service, current *corev1.Secret) error {
+	if service.EscalationPolicy.ID != escalationPolicyID {
+		if _, err := services.UpdateService(ctx, service.ID, pagerduty.UpdateServiceRequest{
+			EscalationPolicyID: escalationPolicyID,
+		}); err != nil {
+			return fmt.Errorf("updating PagerDuty service: %w", err)
+		}
+	}
+
+	var routingKey string
+	for _, integration := range service.Integrations {
+		if integration.IsEventsAPIV2() && len(integration.IntegrationKey) > 0 {
+			routingKey = integration.IntegrationKey
+			break
+		}
+	}
+	if len(routingKey) == 0 {
+		integration, err := services.CreateEventsIntegration(ctx, service.ID, "addon-operator")
+		if err != nil {
+			return fmt.Errorf("provisioning PagerDuty routing key: %w", err)
+		}
+		routingKey = integration.IntegrationKey
+	}
+	return r.storeRoutingKey(ctx, addon, service.ID, []byte(routingKey), current)
+}
+
+// Applies the Secret holding the routing key of the given service,
+// removing the current Secret, if it was stored under a different name.
+func (r *pagerDutyReconciler) storeRoutingKey(ctx context.Context,
+	addon *addonsv1alpha1.Addon, serviceID string, routingKey []byte, current *corev1.Secret) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getPagerDutySecretName(addon),
+			Namespace: GetCommonInstallOptions(addon).Namespace,
+			Annotations: map[string]string{
+				pagerDutyServiceAnnotation: serviceID,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			pagerDutyRoutingKeyKey: routingKey,
+		},
+	}
+	controllers.AddCommonLabels(secret, addon)
+	if err := controllerutil.SetControllerReference(addon, secret, r.scheme); err != nil {
+		return fmt.Errorf("setting controller reference on PagerDuty Secret: %w", err)
+	}
+	if err := controllers.Apply(ctx, r.client, secret); err != nil {
+		return fmt.Errorf("applying PagerDuty Secret: %w", err)
+	}
+
+	if current == nil || current.Name == secret.Name {
+		return nil
+	}
+	if err := r.client.Delete(ctx, current); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting previous PagerDuty Secret: %w", err)
+	}
+	return nil
+}
+
+// Returns the Secret recording the PagerDuty service of the Addon or nil.
+// Looked up by annotation, as the name of the Secret may change.
+func getPagerDutySecret(ctx context.Context, c client.Client,
+	addon *addonsv1alpha1.Addon) (*corev1.Secret, error) {
+	namespace := GetCommonInstallOptions(addon).Namespace
+	if len(namespace) == 0 {
+		return nil, nil
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabelsSelector{
+		Selector: controllers.CommonLabelsAsLabelSelector(addon),
+	}); err != nil {
+		return nil, fmt.Errorf("listing Secrets: %w", err)
+	}
+	for i := range secrets.Items {
+		if _, ok := secrets.Items[i].Annotations[pagerDutyServiceAnnotation]; ok {
+			return &secrets.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Deletes the PagerDuty service of the Addon, followed by the Secret recording it.
+// Without a configured account the Secret is kept, so the service
+// can still be deleted once an account is configured. Returns false in that case.
+func deletePagerDutyService(ctx context.Context, c client.Client,
+	account *pagerDutyAccount, addon *addonsv1alpha1.Addon) (deleted bool, err error) {
+	secret, err := getPagerDutySecret(ctx, c, addon)
+	if err != nil || secret == nil {
+		return err == nil, err
+	}
+
+	serviceID := secret.Annotations[pagerDutyServiceAnnotation]
+	services, _ := account.get()
+	switch {
+	case len(serviceID) == 0:
+	case services == nil:
+		controllers.LoggerFromContext(ctx).Info(
+			"no PagerDuty API key configured, keeping service", "service", serviceID)
+		return false, nil
+	default:
+		if err := services.DeleteService(ctx, serviceID); err != nil &&
+			!errors.Is(err, pagerduty.ErrNotFound) {
+			return false, fmt.Errorf("deleting PagerDuty service: %w", err)
+		}
+	}
+
+	if err := c.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("deleting PagerDuty Secret: %w", err)
+	}
+	return true, nil
+}
+
+// Deletes the PagerDuty service of a deleted Addon.
+type pagerDutyFinalizer struct {
+	client   client.Client
+	services *pagerDutyAccount
+}
+
+func (f *pagerDutyFinalizer) Finalizer() string {
+	return "addons.managed.openshift.io/pagerduty"
+}
+
+func (f *pagerDutyFinalizer) Order() finalizerOrder {
+	return pagerDutyFinalizerOrder
+}
+
+func (f *pagerDutyFinalizer) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return HasPagerDuty(addon)
+}
+
+// Holds back the removal of the Addon while no account is configured
+// to delete its service, retrying with backoff.
+func (f *pagerDutyFinalizer) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	deleted, err := deletePagerDutyService(ctx, f.client, f.services, addon)
+	if err != nil {
+		return false, err
+	}
+	if !deleted {
+		return false, errPagerDutyAccountMissing
+	}
+	return true, nil
+}
+
+var errPagerDutyAccountMissing = errors.New(
+	"no PagerDuty API key is configured on the AddonOperator to delete the PagerDuty service")
+
+func getPagerDutySecretName(addon *addonsv1alpha1.Addon) string {
+	if HasPagerDuty(addon) && len(addon.Spec.Monitoring.PagerDuty.SecretName) > 0 {
+		return addon.Spec.Monitoring.PagerDuty.SecretName
+	}
+	return fmt.Sprintf("%s-pagerduty", addon.Name)
+}
@@ -0,0 +1,343 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/pagerduty"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type pagerDutyClientMock struct {
+	mock.Mock
+}
+
+func (m *pagerDutyClientMock) CreateService(
+	ctx context.Context, req pagerduty.CreateServiceRequest,
+) (pagerduty.Service, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(pagerduty.Service), args.Error(1)
+}
+
+func (m *pagerDutyClientMock) GetService(ctx context.Context, id string) (pagerduty.Service, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(pagerduty.Service), args.Error(1)
+}
+
+func (m *pagerDutyClientMock) FindService(ctx context.Context, name string) (pagerduty.Service, error) {
+	args := m.Called(ctx, name)
+	return args.Get(0).(pagerduty.Service), args.Error(1)
+}
+
+func (m *pagerDutyClientMock) UpdateService(
+	ctx context.Context, id string, req pagerduty.UpdateServiceRequest,
+) (pagerduty.Service, error) {
+	args := m.Called(ctx, id, req)
+	return args.Get(0).(pagerduty.Service), args.Error(1)
+}
+
+func (m *pagerDutyClientMock) DeleteService(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *pagerDutyClientMock) CreateEventsIntegration(
+	ctx context.Context, serviceID, name string,
+) (pagerduty.Integration, error) {
+	args := m.Called(ctx, serviceID, name)
+	return args.Get(0).(pagerduty.Integration), args.Error(1)
+}
+
+func newTestAddonWithPagerDuty() *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.Monitoring.PagerDuty = &addonsv1alpha1.PagerDutySpec{}
+	return addon
+}
+
+func TestPagerDutyReconciler(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		// Secret recording the current service, none if nil.
+		Existing *corev1.Secret
+		// Escalation policy of the current service, deleted if empty.
+		ExistingEscalationPolicyID string
+		SecretName                 string
+		// Service of the same name found in PagerDuty, none if nil.
+		Found               *pagerduty.Service
+		ExpectCreate        bool
+		ExpectAdopt         bool
+		ExpectUpdate        bool
+		ExpectDeletedSecret bool
+	}{
+		"creates service": {
+			ExpectCreate: true,
+		},
+		"keeps existing service": {
+			Existing:                   newTestPagerDutySecret("addon-foo-pagerduty"),
+			ExistingEscalationPolicyID: "PEP123",
+		},
+		"updates escalation policy": {
+			Existing:                   newTestPagerDutySecret("addon-foo-pagerduty"),
+			ExistingEscalationPolicyID: "POLD",
+			ExpectUpdate:               true,
+		},
+		"replaces deleted service": {
+			Existing:     newTestPagerDutySecret("addon-foo-pagerduty"),
+			ExpectCreate: true,
+		},
+		"adopts service of the same name": {
+			Found: &pagerduty.Service{
+				ID:               "PSVCFOUND",
+				EscalationPolicy: pagerduty.Reference{ID: "PEP123"},
+				Integrations: []pagerduty.Integration{
+					{Type: "events_api_v2_inbound_integration", IntegrationKey: "found-routing-key"},
+				},
+			},
+			ExpectAdopt: true,
+		},
+		"renames secret": {
+			Existing:                   newTestPagerDutySecret("addon-foo-pagerduty"),
+			ExistingEscalationPolicyID: "PEP123",
+			SecretName:                 "pagerduty",
+			ExpectDeletedSecret:        true,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newTestAddonWithPagerDuty()
+			addon.Spec.Monitoring.PagerDuty.SecretName = tc.SecretName
+
+			c := testutil.NewClient()
+			c.On("List", testutil.IsContext, mock.IsType(&corev1.SecretList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					if tc.Existing != nil {
+						args.Get(1).(*corev1.SecretList).Items = []corev1.Secret{*tc.Existing}
+					}
+				}).
+				Return(nil)
+			var secret *corev1.Secret
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					secret = args.Get(1).(*corev1.Secret).DeepCopy()
+				}).
+				Return(nil).Maybe()
+			c.On("Delete", testutil.IsContext, mock.IsType(&corev1.Secret{}), mock.Anything).
+				Return(nil).Maybe()
+
+			services := &pagerDutyClientMock{}
+			if len(tc.ExistingEscalationPolicyID) == 0 {
+				services.On("GetService", testutil.IsContext, "PSVCOLD").
+					Return(pagerduty.Service{}, pagerduty.ErrNotFound).Maybe()
+			} else {
+				services.On("GetService", testutil.IsContext, "PSVCOLD").
+					Return(pagerduty.Service{
+						ID:               "PSVCOLD",
+						EscalationPolicy: pagerduty.Reference{ID: tc.ExistingEscalationPolicyID},
+					}, nil)
+			}
+			services.On("UpdateService", testutil.IsContext, "PSVCOLD", mock.Anything).
+				Return(pagerduty.Service{}, nil).Maybe()
+			if tc.Found != nil {
+				services.On("FindService", testutil.IsContext, "addon-foo (cluster-1)").
+					Return(*tc.Found, nil).Maybe()
+			} else {
+				services.On("FindService", testutil.IsContext, "addon-foo (cluster-1)").
+					Return(pagerduty.Service{}, pagerduty.ErrNotFound).Maybe()
+			}
+			services.On("CreateService", testutil.IsContext, mock.Anything).
+				Return(pagerduty.Service{ID: "PSVCNEW"}, nil).Maybe()
+			services.On("CreateEventsIntegration", testutil.IsContext, "PSVCNEW", "addon-operator").
+				Return(pagerduty.Integration{IntegrationKey: "new-routing-key"}, nil).Maybe()
+
+			account := &pagerDutyAccount{}
+			account.set(services, "PEP123")
+			r := &pagerDutyReconciler{
+				client:    c,
+				scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+				clusterID: "cluster-1",
+				services:  account,
+			}
+
+			result, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			assert.True(t, result.IsZero())
+
+			if tc.ExpectUpdate {
+				services.AssertCalled(t, "UpdateService", testutil.IsContext, "PSVCOLD",
+					pagerduty.UpdateServiceRequest{EscalationPolicyID: "PEP123"})
+			} else {
+				services.AssertNotCalled(t, "UpdateService", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tc.ExpectDeletedSecret {
+				c.AssertCalled(t, "Delete", testutil.IsContext, tc.Existing, mock.Anything)
+				require.NotNil(t, secret)
+				assert.Equal(t, tc.SecretName, secret.Name)
+				assert.Equal(t, []byte("old-routing-key"), secret.Data[pagerDutyRoutingKeyKey])
+			}
+
+			if tc.ExpectAdopt {
+				services.AssertNotCalled(t, "CreateService", mock.Anything, mock.Anything)
+				services.AssertNotCalled(t, "CreateEventsIntegration", mock.Anything, mock.Anything, mock.Anything)
+				require.NotNil(t, secret)
+				assert.Equal(t, "PSVCFOUND", secret.Annotations[pagerDutyServiceAnnotation])
+				assert.Equal(t, []byte("found-routing-key"), secret.Data[pagerDutyRoutingKeyKey])
+				return
+			}
+			if !tc.ExpectCreate {
+				services.AssertNotCalled(t, "CreateService", mock.Anything, mock.Anything)
+				return
+			}
+			services.AssertCalled(t, "CreateService", testutil.IsContext, pagerduty.CreateServiceRequest{
+				Name:               "addon-foo (cluster-1)",
+				Description:        "Alerts of Addon addon-foo on cluster cluster-1.",
+				EscalationPolicyID: "PEP123",
+			})
+			require.NotNil(t, secret)
+			assert.Equal(t, "addon-foo-pagerduty", secret.Name)
+			assert.Equal(t, "addon-1", secret.Namespace)
+			assert.Equal(t, "PSVCNEW", secret.Annotations[pagerDutyServiceAnnotation])
+			assert.Equal(t, []byte("new-routing-key"), secret.Data[pagerDutyRoutingKeyKey])
+			assert.True(t, metav1.IsControlledBy(secret, addon))
+		})
+	}
+}
+
+func TestPagerDutyReconciler_Unready(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Services           pagerDutyClient
+		EscalationPolicyID string
+		ClusterID          string
+	}{
+		"no account": {
+			EscalationPolicyID: "PEP123",
+			ClusterID:          "cluster-1",
+		},
+		"no escalation policy": {
+			Services:  &pagerDutyClientMock{},
+			ClusterID: "cluster-1",
+		},
+		"no cluster ID": {
+			Services:           &pagerDutyClientMock{},
+			EscalationPolicyID: "PEP123",
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := newTestAddonWithPagerDuty()
+			c := testutil.NewClient()
+			account := &pagerDutyAccount{}
+			account.set(tc.Services, tc.EscalationPolicyID)
+			r := &pagerDutyReconciler{
+				client:    c,
+				scheme:    testutil.NewTestSchemeWithAddonsv1alpha1(),
+				clusterID: tc.ClusterID,
+				services:  account,
+			}
+
+			ctx, report := contextWithPhaseReport(context.Background())
+			result, err := r.Reconcile(ctx, addon)
+			require.NoError(t, err)
+			assert.True(t, result.IsZero())
+			c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+			assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyPagerDuty, report.notReadyReason)
+			assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+		})
+	}
+}
+
+func TestPagerDutyFinalizer(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithPagerDuty()
+	existing := newTestPagerDutySecret("addon-foo-pagerduty")
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&corev1.SecretList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1.SecretList).Items = []corev1.Secret{*existing}
+		}).
+		Return(nil)
+	c.On("Delete", testutil.IsContext, existing, mock.Anything).
+		Return(nil)
+
+	services := &pagerDutyClientMock{}
+	services.On("DeleteService", testutil.IsContext, "PSVCOLD").
+		Return(nil)
+
+	account := &pagerDutyAccount{}
+	account.set(services, "")
+	f := &pagerDutyFinalizer{client: c, services: account}
+
+	done, err := f.Finalize(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, done)
+	services.AssertExpectations(t)
+	c.AssertExpectations(t)
+}
+
+func TestPagerDutyFinalizer_NoAccount(t *testing.T) {
+	t.Parallel()
+
+	addon := newTestAddonWithPagerDuty()
+	existing := newTestPagerDutySecret("addon-foo-pagerduty")
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&corev1.SecretList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*corev1.SecretList).Items = []corev1.Secret{*existing}
+		}).
+		Return(nil)
+
+	f := &pagerDutyFinalizer{client: c, services: &pagerDutyAccount{}}
+
+	done, err := f.Finalize(context.Background(), addon)
+	require.ErrorIs(t, err, errPagerDutyAccountMissing)
+	assert.False(t, done)
+	c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPagerDutyFinalizer_AppliesTo(t *testing.T) {
+	t.Parallel()
+
+	f := &pagerDutyFinalizer{}
+	assert.True(t, f.AppliesTo(newTestAddonWithPagerDuty()))
+	assert.False(t, f.AppliesTo(testutil.NewTestAddonWithMonitoringStack()))
+}
+
+func newTestPagerDutySecret(name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "addon-1",
+			Annotations: map[string]string{
+				pagerDutyServiceAnnotation: "PSVCOLD",
+			},
+		},
+		Data: map[string][]byte{
+			pagerDutyRoutingKeyKey: []byte("old-routing-key"),
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Managed resources that are annotated with the pause state of their Addon.
+func pausePropagationTargets() []client.ObjectList {
+	return []client.ObjectList{
+		&operatorsv1alpha1.CatalogSourceList{},
+		&operatorsv1alpha1.SubscriptionList{},
+		&monitoringv1.ServiceMonitorList{},
+	}
+}
+
+// Annotates the resources managed for the given Addon with the pause reason
+// and the time the Addon was paused at.
+func (r *AddonReconciler) propagatePauseState(ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Paused)
+	if cond == nil {
+		return nil
+	}
+
+	return r.updatePauseAnnotations(ctx, addon, func(annotations map[string]string) {
+		annotations[addonsv1alpha1.PausedReasonAnnotation] = cond.Reason
+		annotations[addonsv1alpha1.PausedSinceAnnotation] = cond.LastTransitionTime.UTC().Format(time.RFC3339)
+	})
+}
+
+// Removes the pause annotations from the resources managed for the given Addon.
+func (r *AddonReconciler) removePauseState(ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	return r.updatePauseAnnotations(ctx, addon, func(annotations map[string]string) {
+		delete(annotations, addonsv1alpha1.PausedReasonAnnotation)
+		delete(annotations, addonsv1alpha1.PausedSinceAnnotation)
+	})
+}
+
+func (r *AddonReconciler) updatePauseAnnotations(
+	ctx context.Context, addon *addonsv1alpha1.Addon, mutate func(annotations map[string]string),
+) error {
+	selector := controllers.CommonLabelsAsLabelSelector(addon)
+
+	for _, list := range pausePropagationTargets() {
+		if err := r.List(ctx, list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return fmt.Errorf("listing %T: %w", list, err)
+		}
+
+		objs, err := meta.ExtractList(list)
+		if err != nil {
+			return fmt.Errorf("extracting %T: %w", list, err)
+		}
+
+		for _, o := range objs {
+			obj, ok := o.(client.Object)
+			if !ok {
+				continue
+			}
+
+			annotations := map[string]string{}
+			for k, v := range obj.GetAnnotations() {
+				annotations[k] = v
+			}
+			mutate(annotations)
+			if annotationsEqual(obj.GetAnnotations(), annotations) {
+				continue
+			}
+
+			obj.SetAnnotations(annotations)
+			if err := r.Update(ctx, obj); err != nil {
+				return fmt.Errorf("updating pause annotations: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+func annotationsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestPropagatePauseState(t *testing.T) {
+	c := testutil.NewClient()
+	r := &AddonReconciler{Client: c}
+
+	pausedAt := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Status.Conditions = []metav1.Condition{
+		{
+			Type:               addonsv1alpha1.Paused,
+			Status:             metav1.ConditionTrue,
+			Reason:             addonsv1alpha1.AddonReasonPaused,
+			LastTransitionTime: metav1.NewTime(pausedAt),
+		},
+	}
+
+	c.On("List", testutil.IsContext, mock.IsType(&operatorsv1alpha1.CatalogSourceList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*operatorsv1alpha1.CatalogSourceList)
+			list.Items = []operatorsv1alpha1.CatalogSource{*testutil.NewTestCatalogSource()}
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext, mock.IsType(&operatorsv1alpha1.SubscriptionList{}), mock.Anything).
+		Return(nil)
+	c.On("List", testutil.IsContext, mock.IsType(&monitoringv1.ServiceMonitorList{}), mock.Anything).
+		Return(nil)
+
+	var updated *operatorsv1alpha1.CatalogSource
+	c.On("Update", testutil.IsContext, testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = args.Get(1).(*operatorsv1alpha1.CatalogSource)
+		}).
+		Return(nil)
+
+	require.NoError(t, r.propagatePauseState(context.Background(), addon))
+	c.AssertExpectations(t)
+
+	require.NotNil(t, updated)
+	assert.Equal(t, addonsv1alpha1.AddonReasonPaused,
+		updated.Annotations[addonsv1alpha1.PausedReasonAnnotation])
+	assert.Equal(t, "2023-05-01T12:00:00Z",
+		updated.Annotations[addonsv1alpha1.PausedSinceAnnotation])
+}
+
+func TestRemovePauseState_Unchanged(t *testing.T) {
+	c := testutil.NewClient()
+	r := &AddonReconciler{Client: c}
+
+	c.On("List", testutil.IsContext, mock.IsType(&operatorsv1alpha1.CatalogSourceList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*operatorsv1alpha1.CatalogSourceList)
+			list.Items = []operatorsv1alpha1.CatalogSource{*testutil.NewTestCatalogSource()}
+		}).
+		Return(nil)
+	c.On("List", testutil.IsContext, mock.Anything, mock.Anything).
+		Return(nil)
+
+	require.NoError(t, r.removePauseState(context.Background(), testutil.NewTestAddonWithCatalogSourceImage()))
+	c.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,159 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Marks Subscriptions created against additional CatalogSources,
+// to tell them apart from the Subscription of the Addon package.
+const additionalSubscriptionLabel = "addons.managed.openshift.io/additional-subscription"
+
+// Name of the Subscription to the package declared by an additional CatalogSource.
+func AdditionalSubscriptionName(
+	addon *addonsv1alpha1.Addon, subscription addonsv1alpha1.AdditionalCatalogSourceSubscription,
+) string {
+	return fmt.Sprintf("addon-%s-%s", addon.Name, subscription.PackageName)
+}
+
+// Ensures Subscriptions to the packages declared by additional CatalogSources
+// and waits for OLM to install their CSVs.
+// Subscriptions no longer declared are removed together with their CSV.
+func (r *olmReconciler) ensureAdditionalSubscriptions(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (requeueResult, error) {
+	commonInstallOptions := GetCommonInstallOptions(addon)
+	proxy, err := r.resolveProxyConfig(ctx, addon)
+	if err != nil {
+		return resultNil, fmt.Errorf("resolving proxy config: %w", err)
+	}
+
+	var (
+		desired  []*operatorsv1alpha1.Subscription
+		collided []string
+		unready  []string
+	)
+	for _, additionalCatalogSrc := range commonInstallOptions.AdditionalCatalogSources {
+		if additionalCatalogSrc.Subscription == nil {
+			continue
+		}
+		desiredSubscription := &operatorsv1alpha1.Subscription{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      AdditionalSubscriptionName(addon, *additionalCatalogSrc.Subscription),
+				Namespace: commonInstallOptions.Namespace,
+			},
+			Spec: &operatorsv1alpha1.SubscriptionSpec{
+				CatalogSource:          additionalCatalogSrc.Name,
+				CatalogSourceNamespace: commonInstallOptions.Namespace,
+				Channel:                additionalCatalogSrc.Subscription.Channel,
+				Package:                additionalCatalogSrc.Subscription.PackageName,
+				Config: applyProxyConfig(
+					createSubscriptionConfigObject(commonInstallOptions, addon.Spec.Placement), proxy),
+				// InstallPlanApproval and StartingCSV are bound to
+				// .spec.version of the Addon package and left unset.
+			},
+		}
+		addAdditionalMetadata(desiredSubscription, commonInstallOptions.SubscriptionMetadata)
+		controllers.AddCommonLabels(desiredSubscription, addon)
+		controllers.AddCommonAnnotations(desiredSubscription, addon)
+		metav1.SetMetaDataLabel(&desiredSubscription.ObjectMeta, additionalSubscriptionLabel, "true")
+		if err := controllerutil.SetControllerReference(addon, desiredSubscription, r.scheme); err != nil {
+			return resultNil, fmt.Errorf("setting controller reference: %w", err)
+		}
+		desired = append(desired, desiredSubscription)
+
+		if isCollided, err := r.checkOLMObjectCollision(
+			ctx, addon, &operatorsv1alpha1.Subscription{}, desiredSubscription); err != nil {
+			return resultNil, fmt.Errorf("checking Subscription collision: %w", err)
+		} else if isCollided {
+			collided = append(collided,
+				fmt.Sprintf("Subscription %s", client.ObjectKeyFromObject(desiredSubscription)))
+			continue
+		}
+
+		observedSubscription, changed, err := r.reconcileSubscription(ctx, desiredSubscription)
+		if err != nil {
+			return resultNil, fmt.Errorf("reconciling Subscription: %w", err)
+		}
+		if changed {
+			r.drift.reportRemediated(ctx, addon, observedSubscription, "Subscription")
+		}
+		if len(observedSubscription.Status.InstalledCSV) == 0 {
+			unready = append(unready, client.ObjectKeyFromObject(observedSubscription).String())
+		}
+	}
+
+	if err := r.deleteUnwantedAdditionalSubscriptions(ctx, addon, desired); err != nil {
+		return resultNil, fmt.Errorf("deleting unwanted additional Subscriptions: %w", err)
+	}
+
+	if len(collided) > 0 {
+		reportCollidedOLMObjects(addon, collided)
+		return resultRetry, nil
+	}
+	if len(unready) > 0 {
+		sort.Strings(unready)
+		reportPendingStatus(addon, addonsv1alpha1.AddonReasonUnreadyAdditionalSubscription,
+			fmt.Sprintf("CSV not yet installed for additional Subscriptions: %s", strings.Join(unready, ", ")))
+		return resultRetry, nil
+	}
+	return resultNil, nil
+}
+
+// Deletes additional Subscriptions of the Addon, that are no longer desired.
+// Their CSV is not owned by the Addon and deleted as well,
+// as OLM keeps operators installed after their Subscription is gone.
+func (r *olmReconciler) deleteUnwantedAdditionalSubscriptions(
+	ctx context.Context, addon *addonsv1alpha1.Addon, desired []*operatorsv1alpha1.Subscription,
+) error {
+	selector := controllers.CommonLabelsAsLabelSelector(addon)
+	requirement, err := labels.NewRequirement(additionalSubscriptionLabel, "==", []string{"true"})
+	if err != nil {
+		return err
+	}
+
+	currentList := &operatorsv1alpha1.SubscriptionList{}
+	if err := r.client.List(ctx, currentList, client.MatchingLabelsSelector{
+		Selector: selector.Add(*requirement),
+	}); err != nil {
+		return fmt.Errorf("listing Subscriptions: %w", err)
+	}
+
+	wanted := map[client.ObjectKey]struct{}{}
+	for _, subscription := range desired {
+		wanted[client.ObjectKeyFromObject(subscription)] = struct{}{}
+	}
+	for i := range currentList.Items {
+		subscription := &currentList.Items[i]
+		if _, ok := wanted[client.ObjectKeyFromObject(subscription)]; ok {
+			continue
+		}
+		if err := r.client.Delete(ctx, subscription); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting Subscription: %w", err)
+		}
+		if len(subscription.Status.InstalledCSV) == 0 {
+			continue
+		}
+		csv := &operatorsv1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      subscription.Status.InstalledCSV,
+				Namespace: subscription.Namespace,
+			},
+		}
+		if err := r.client.Delete(ctx, csv); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting CSV of Subscription: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsureAdditionalSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		InstalledCSV   string
+		ExpectedResult requeueResult
+	}{
+		"CSV installed": {
+			InstalledCSV:   "dependency.v1.0.0",
+			ExpectedResult: resultNil,
+		},
+		"CSV not yet installed": {
+			ExpectedResult: resultRetry,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := &addonsv1alpha1.Addon{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", UID: "1234"},
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type: addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{
+							AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+								Namespace:   "test-ns",
+								PackageName: "test",
+								Channel:     "alpha",
+								AdditionalCatalogSources: []addonsv1alpha1.AdditionalCatalogSource{
+									{Name: "catalog-only", Image: "quay.io/osd-addons/catalog-only:v1"},
+									{
+										Name:  "dependency-catalog",
+										Image: "quay.io/osd-addons/dependency-catalog:v1",
+										Subscription: &addonsv1alpha1.AdditionalCatalogSourceSubscription{
+											PackageName: "dependency",
+											Channel:     "stable",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			c := testutil.NewClient()
+			mockNoClusterProxy(c)
+			c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				testutil.IsOperatorsV1Alpha1SubscriptionPtr, mock.Anything).
+				Return(testutil.NewTestErrNotFound())
+			var applied *operatorsv1alpha1.Subscription
+			c.On("Patch", testutil.IsContext,
+				testutil.IsOperatorsV1Alpha1SubscriptionPtr, client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					subscription := args.Get(1).(*operatorsv1alpha1.Subscription)
+					applied = subscription.DeepCopy()
+					subscription.Status.InstalledCSV = tc.InstalledCSV
+				}).
+				Return(nil)
+			c.On("List", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.SubscriptionList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*operatorsv1alpha1.SubscriptionList)
+					list.Items = []operatorsv1alpha1.Subscription{
+						{ObjectMeta: metav1.ObjectMeta{Name: "addon-test-dependency", Namespace: "test-ns"}},
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "addon-test-removed", Namespace: "test-ns"},
+							Status:     operatorsv1alpha1.SubscriptionStatus{InstalledCSV: "removed.v1.0.0"},
+						},
+					}
+				}).
+				Return(nil)
+			var deleted []string
+			c.On("Delete", testutil.IsContext, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					deleted = append(deleted, args.Get(1).(client.Object).GetName())
+				}).
+				Return(nil)
+
+			r := &olmReconciler{
+				client: c,
+				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+
+			result, err := r.ensureAdditionalSubscriptions(ctx, addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+
+			require.NotNil(t, applied)
+			assert.Equal(t, "addon-test-dependency", applied.Name)
+			assert.Equal(t, "test-ns", applied.Namespace)
+			assert.Equal(t, "true", applied.Labels[additionalSubscriptionLabel])
+			assert.Equal(t, "dependency-catalog", applied.Spec.CatalogSource)
+			assert.Equal(t, "test-ns", applied.Spec.CatalogSourceNamespace)
+			assert.Equal(t, "dependency", applied.Spec.Package)
+			assert.Equal(t, "stable", applied.Spec.Channel)
+			c.AssertNumberOfCalls(t, "Patch", 1)
+
+			// Subscriptions no longer declared are removed with their CSV.
+			assert.Equal(t, []string{"addon-test-removed", "removed.v1.0.0"}, deleted)
+
+			available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			if tc.ExpectedResult == resultRetry {
+				require.NotNil(t, available)
+				assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyAdditionalSubscription, available.Reason)
+			} else {
+				assert.Nil(t, available)
+			}
+		})
+	}
+}
@@ -3,12 +3,13 @@ package addon
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
-	"k8s.io/apimachinery/pkg/api/equality"
 	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -43,12 +44,12 @@ func (r *olmReconciler) ensureCatalogSource(
 			Image:       commonConfig.CatalogSourceImage,
 		},
 	}
-	if len(commonConfig.PullSecretName) > 0 {
-		catalogSource.Spec.Secrets = []string{
-			commonConfig.PullSecretName,
-		}
+	if pullSecrets := commonConfig.GetPullSecrets(); len(pullSecrets) > 0 {
+		catalogSource.Spec.Secrets = pullSecrets
 	}
 
+	applyCatalogSourceConfig(catalogSource, addon)
+	addAdditionalMetadata(catalogSource, commonConfig.CatalogSourceMetadata)
 	controllers.AddCommonLabels(catalogSource, addon)
 	controllers.AddCommonAnnotations(catalogSource, addon)
 
@@ -56,13 +57,47 @@ func (r *olmReconciler) ensureCatalogSource(
 		return resultNil, nil, err
 	}
 
+	if err := r.catalogImages.pin(ctx, addon, catalogSource, *commonConfig); err != nil {
+		return resultNil, nil, fmt.Errorf("pinning catalog image digest: %w", err)
+	}
+
+	if target := rollbackTarget(addon); target != nil {
+		// Rollbacks restore the known good catalog image right away,
+		// there is no upgrade edge to validate for a downgrade.
+		catalogSource.Spec.Image = target.CatalogSourceImage
+	} else if requeueResult, err := r.verifyCatalogImage(
+		ctx, addon, catalogSource, *commonConfig); err != nil {
+		return resultNil, nil, fmt.Errorf("verifying catalog image: %w", err)
+	} else if requeueResult != resultNil {
+		return requeueResult, nil, nil
+	} else if requeueResult, err := r.gateCatalogUpgrade(
+		ctx, addon, catalogSource, *commonConfig); err != nil {
+		return resultNil, nil, err
+	} else if requeueResult != resultNil {
+		return requeueResult, nil, nil
+	}
+
+	if collided, err := r.checkOLMObjectCollision(ctx, addon, &operatorsv1alpha1.CatalogSource{}, catalogSource); err != nil {
+		return resultNil, nil, fmt.Errorf("checking CatalogSource collision: %w", err)
+	} else if collided {
+		reportCollidedOLMObjects(addon, []string{
+			fmt.Sprintf("CatalogSource %s", client.ObjectKeyFromObject(catalogSource))})
+		return resultRetry, nil, nil
+	}
+
 	var observedCatalogSource *operatorsv1alpha1.CatalogSource
 	{
-		var err error
-		observedCatalogSource, err = reconcileCatalogSource(ctx, r.client, catalogSource)
+		var (
+			changed bool
+			err     error
+		)
+		observedCatalogSource, changed, err = reconcileCatalogSource(ctx, r.client, catalogSource)
 		if err != nil {
 			return resultNil, nil, err
 		}
+		if changed {
+			r.drift.reportRemediated(ctx, addon, observedCatalogSource, "CatalogSource")
+		}
 	}
 
 	if observedCatalogSource.Status.GRPCConnectionState == nil {
@@ -83,19 +118,59 @@ func (r *olmReconciler) ensureCatalogSource(
 	return resultNil, observedCatalogSource, nil
 }
 
+// Holds back new catalog images of the desired CatalogSource,
+// until they may be rolled out to the Subscription.
+func (r *olmReconciler) gateCatalogUpgrade(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	catalogSource *operatorsv1alpha1.CatalogSource,
+	commonConfig addonsv1alpha1.AddonInstallOLMCommon,
+) (requeueResult, error) {
+	// New catalog images of Canary upgrades are staged and health checked first.
+	if err := r.stageCanaryUpgrade(ctx, addon, catalogSource,
+		commonConfig.PackageName, commonConfig.Channel); err != nil {
+		return resultNil, fmt.Errorf("staging canary upgrade: %w", err)
+	}
+
+	// New catalog images are only rolled out within the Addons maintenance windows.
+	if err := r.deferCatalogUpgrade(ctx, addon, catalogSource); err != nil {
+		return resultNil, fmt.Errorf("deferring catalog upgrade: %w", err)
+	}
+
+	// New catalog images of Manual upgrades are only rolled out after approval in OCM.
+	if err := r.awaitUpgradeApproval(ctx, addon, catalogSource); err != nil {
+		return resultNil, fmt.Errorf("awaiting upgrade approval: %w", err)
+	}
+
+	// Validate upgrade edges before switching to a new catalog image.
+	requeueResult, err := r.validateCatalogUpgrade(ctx, addon, catalogSource,
+		commonConfig.PackageName, commonConfig.Channel)
+	if err != nil {
+		return resultNil, fmt.Errorf("validating catalog upgrade: %w", err)
+	}
+	return requeueResult, nil
+}
+
 func (r *olmReconciler) ensureAdditionalCatalogSources(
 	ctx context.Context, addon *addonsv1alpha1.Addon,
 ) (requeueResult, error) {
 	if !HasAdditionalCatalogSources(addon) {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.CatalogSourcesReady)
 		return resultNil, nil
 	}
-	additionalCatalogSrcs, targetNamespace, pullSecret, stop := parseAddonInstallConfigForAdditionalCatalogSources(
+	additionalCatalogSrcs, targetNamespace, pullSecrets, stop := parseAddonInstallConfigForAdditionalCatalogSources(
 		controllers.LoggerFromContext(ctx),
 		addon,
 	)
 	if stop {
 		return resultStop, nil
 	}
+	// CatalogSources with a higher priority are created first.
+	sort.SliceStable(additionalCatalogSrcs, func(i, j int) bool {
+		return additionalCatalogSrcs[i].Priority > additionalCatalogSrcs[j].Priority
+	})
+
+	var collided []string
+	readiness := make([]catalogSourceReadiness, 0, len(additionalCatalogSrcs))
 	for _, additionalCatalogSrc := range additionalCatalogSrcs {
 		currentCatalogSrc := &operatorsv1alpha1.CatalogSource{
 			ObjectMeta: metav1.ObjectMeta{
@@ -107,75 +182,123 @@ func (r *olmReconciler) ensureAdditionalCatalogSources(
 				Publisher:   catalogSourcePublisher,
 				DisplayName: addon.Spec.DisplayName,
 				Image:       additionalCatalogSrc.Image,
+				Priority:    additionalCatalogSrc.Priority,
 			},
 		}
 
-		if len(pullSecret) > 0 {
-			currentCatalogSrc.Spec.Secrets = []string{
-				pullSecret,
-			}
+		if len(pullSecrets) > 0 {
+			currentCatalogSrc.Spec.Secrets = pullSecrets
 		}
 
+		applyCatalogSourceConfig(currentCatalogSrc, addon)
+		addAdditionalMetadata(currentCatalogSrc, GetCommonInstallOptions(addon).CatalogSourceMetadata)
 		controllers.AddCommonLabels(currentCatalogSrc, addon)
 		controllers.AddCommonAnnotations(currentCatalogSrc, addon)
 
 		if err := controllerutil.SetControllerReference(addon, currentCatalogSrc, r.scheme); err != nil {
 			return resultNil, err
 		}
-		var observedCatalogSource *operatorsv1alpha1.CatalogSource
-		var err error
-		observedCatalogSource, err = reconcileCatalogSource(ctx, r.client, currentCatalogSrc)
+		if isCollided, err := r.checkOLMObjectCollision(ctx, addon, &operatorsv1alpha1.CatalogSource{}, currentCatalogSrc); err != nil {
+			return resultNil, fmt.Errorf("checking CatalogSource collision: %w", err)
+		} else if isCollided {
+			collided = append(collided,
+				fmt.Sprintf("CatalogSource %s", client.ObjectKeyFromObject(currentCatalogSrc)))
+			continue
+		}
+		observedCatalogSource, changed, err := reconcileCatalogSource(ctx, r.client, currentCatalogSrc)
 		if err != nil {
 			return resultNil, err
 		}
-
-		if observedCatalogSource.Status.GRPCConnectionState == nil {
-			reportAdditionalCatalogSourceUnreadinessStatus(addon, ".Status.GRPCConnectionState is nil")
-			return resultRetry, nil
+		if changed {
+			r.drift.reportRemediated(ctx, addon, observedCatalogSource, "CatalogSource")
 		}
-		if observedCatalogSource.Status.GRPCConnectionState.LastObservedState != "READY" {
-			reportAdditionalCatalogSourceUnreadinessStatus(
-				addon,
-				fmt.Sprintf(
-					".Status.GRPCConnectionState.LastObservedState == %s",
-					observedCatalogSource.Status.GRPCConnectionState.LastObservedState,
-				),
-			)
-			return resultRetry, nil
+
+		readiness = append(readiness, observeCatalogSourceReadiness(
+			additionalCatalogSrc.Name, additionalCatalogSrc.IsRequired(), observedCatalogSource))
+	}
+
+	if len(collided) > 0 {
+		reportCollidedOLMObjects(addon, collided)
+		return resultRetry, nil
+	}
+
+	// Optional CatalogSources don't block the Subscription.
+	unreadyRequired := reportCatalogSourcesReady(addon, readiness)
+	if len(unreadyRequired) > 0 {
+		unready := make([]string, len(unreadyRequired))
+		for i := range unreadyRequired {
+			unready[i] = unreadyRequired[i].String()
 		}
+		reportAdditionalCatalogSourceUnreadinessStatus(addon, strings.Join(unready, ", "))
+		return resultRetry, nil
 	}
 	return resultNil, nil
 }
 
+// Applies the update strategy and registry pod overrides of the Addon.
+// The Addon placement and priority are used for settings not set in the overrides.
+func applyCatalogSourceConfig(
+	catalogSource *operatorsv1alpha1.CatalogSource, addon *addonsv1alpha1.Addon,
+) {
+	var (
+		config    = GetCommonInstallOptions(addon).CatalogSource
+		placement = addon.Spec.Placement
+		podConfig addonsv1alpha1.CatalogSourceGrpcPodConfig
+	)
+	if config != nil && config.GrpcPodConfig != nil {
+		podConfig = *config.GrpcPodConfig
+	}
+	if placement != nil {
+		if len(podConfig.NodeSelector) == 0 {
+			podConfig.NodeSelector = placement.NodeSelector
+		}
+		if len(podConfig.Tolerations) == 0 {
+			podConfig.Tolerations = placement.Tolerations
+		}
+	}
+	if len(podConfig.PriorityClassName) == 0 {
+		podConfig.PriorityClassName = effectivePriorityClassName(addon)
+	}
+
+	if (config != nil && config.GrpcPodConfig != nil) || placement != nil ||
+		len(podConfig.PriorityClassName) > 0 {
+		grpcPodConfig := &operatorsv1alpha1.GrpcPodConfig{
+			NodeSelector: podConfig.NodeSelector,
+			Tolerations:  podConfig.Tolerations,
+		}
+		if len(podConfig.PriorityClassName) > 0 {
+			priorityClassName := podConfig.PriorityClassName
+			grpcPodConfig.PriorityClassName = &priorityClassName
+		}
+		catalogSource.Spec.GrpcPodConfig = grpcPodConfig
+	}
+
+	if config != nil && config.UpdateStrategy != nil && config.UpdateStrategy.RegistryPoll != nil {
+		interval := config.UpdateStrategy.RegistryPoll.Interval
+		catalogSource.Spec.UpdateStrategy = &operatorsv1alpha1.UpdateStrategy{
+			RegistryPoll: &operatorsv1alpha1.RegistryPoll{
+				// Interval is not serialized, but populated when decoding
+				// the observed object, so both are set to compare equal.
+				RawInterval: interval.Duration.String(),
+				Interval:    &interval,
+			},
+		}
+	}
+}
+
 // reconciles a CatalogSource and returns a new CatalogSource object with updated state.
 // Warning: Will adopt existing CatalogSource
 func reconcileCatalogSource(ctx context.Context, c client.Client, catalogSource *operatorsv1alpha1.CatalogSource) (
-	*operatorsv1alpha1.CatalogSource, error) {
+	_ *operatorsv1alpha1.CatalogSource, changed bool, err error) {
 	currentCatalogSource := &operatorsv1alpha1.CatalogSource{}
-
-	{
-		err := c.Get(ctx, client.ObjectKey{
-			Name:      catalogSource.Name,
-			Namespace: catalogSource.Namespace,
-		}, currentCatalogSource)
-		if err != nil {
-			if k8sApiErrors.IsNotFound(err) {
-				return catalogSource, c.Create(ctx, catalogSource)
-			}
-			return nil, err
+	if err := c.Get(ctx, client.ObjectKeyFromObject(catalogSource), currentCatalogSource); err == nil {
+		if err := yieldToForeignController(ctx, c, currentCatalogSource, catalogSource); err != nil {
+			return nil, false, fmt.Errorf("adopting CatalogSource: %w", err)
 		}
+	} else if !k8sApiErrors.IsNotFound(err) {
+		return nil, false, err
 	}
 
-	ownedByAddon := controllers.HasSameController(currentCatalogSource, catalogSource)
-	specChanged := !equality.Semantic.DeepEqual(catalogSource.Spec, currentCatalogSource.Spec)
-	currentLabels := labels.Set(currentCatalogSource.Labels)
-	newLabels := labels.Merge(currentLabels, labels.Set(catalogSource.Labels))
-	if specChanged || !ownedByAddon || !labels.Equals(newLabels, currentLabels) {
-		currentCatalogSource.Spec = catalogSource.Spec
-		currentCatalogSource.OwnerReferences = catalogSource.OwnerReferences
-		currentCatalogSource.Labels = newLabels
-		return currentCatalogSource, c.Update(ctx, currentCatalogSource)
-	}
-
-	return currentCatalogSource, nil
+	changed, err = controllers.ApplyChanged(ctx, c, currentCatalogSource, catalogSource)
+	return catalogSource, changed, err
 }
@@ -36,7 +36,7 @@ func (r *olmReconciler) ensureCatalogSourcesNetworkPolicy(ctx context.Context, a
 			return resultStop, nil
 		}
 		if k8serrors.IsNotFound(err) {
-			return resultNil, r.client.Create(ctx, desired)
+			return resultNil, controllers.Apply(ctx, r.client, desired)
 		}
 
 		return resultNil, fmt.Errorf("retrieving actual NetworkPolicy: %w", err)
@@ -53,11 +53,7 @@ func (r *olmReconciler) ensureCatalogSourcesNetworkPolicy(ctx context.Context, a
 		return resultNil, nil
 	}
 
-	actual.OwnerReferences = desired.OwnerReferences
-	actual.Spec = desired.Spec
-	actual.Labels = newLabels
-
-	return resultNil, r.client.Update(ctx, actual)
+	return resultNil, controllers.Apply(ctx, r.client, desired)
 }
 
 var errInstallConfigParseFailure = errors.New("failed to parse addon install config")
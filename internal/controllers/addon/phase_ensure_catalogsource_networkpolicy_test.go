@@ -111,10 +111,10 @@ func TestReconcileCatalogSourceNetworkPolicy_NotPresent(t *testing.T) {
 					mock.Anything).
 				Return(testutil.NewTestErrNotFound())
 			client.
-				On("Create",
+				On("Patch",
 					testutil.IsContext,
 					testutil.IsNetworkingV1NetworkPolicyPtr,
-					mock.Anything).
+					mock.Anything, mock.Anything).
 				Run(func(args mock.Arguments) {
 					created = args.Get(1).(*networkingv1.NetworkPolicy)
 				}).
@@ -227,10 +227,10 @@ func TestReconcileCatalogSourceNetworkPolicy_Present(t *testing.T) {
 				}).
 				Return(nil)
 			client.
-				On("Update",
+				On("Patch",
 					testutil.IsContext,
 					testutil.IsNetworkingV1NetworkPolicyPtr,
-					mock.Anything).
+					mock.Anything, mock.Anything).
 				Run(func(args mock.Arguments) {
 					updated = args.Get(1).(*networkingv1.NetworkPolicy)
 				}).
@@ -300,10 +300,10 @@ func TestEnsureCatalogSourceNetworkPolicy_Adoption(t *testing.T) {
 				}).
 				Return(nil)
 			client.
-				On("Update",
+				On("Patch",
 					testutil.IsContext,
 					testutil.IsNetworkingV1NetworkPolicyPtr,
-					mock.Anything).
+					mock.Anything, mock.Anything).
 				Return(nil).
 				Maybe()
 
@@ -2,16 +2,24 @@ package addon
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
 	"github.com/openshift/addon-operator/internal/controllers"
 	"github.com/openshift/addon-operator/internal/testutil"
 )
@@ -24,15 +32,16 @@ func TestReconcileCatalogSource_NotExistingYet_HappyPath(t *testing.T) {
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
 	).Return(testutil.NewTestErrNotFound())
-	c.On("Create",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
+		mock.Anything,
 	).Return(nil)
 
 	ctx := context.Background()
 	catalogSource := testutil.NewTestCatalogSource()
-	reconciledCatalogSource, err := reconcileCatalogSource(ctx, c, catalogSource.DeepCopy())
+	reconciledCatalogSource, _, err := reconcileCatalogSource(ctx, c, catalogSource.DeepCopy())
 	assert.NoError(t, err)
 	assert.NotNil(t, reconciledCatalogSource)
 	c.AssertExpectations(t)
@@ -40,8 +49,8 @@ func TestReconcileCatalogSource_NotExistingYet_HappyPath(t *testing.T) {
 		Name:      catalogSource.Name,
 		Namespace: catalogSource.Namespace,
 	}, testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything)
-	c.AssertCalled(t, "Create", mock.Anything,
-		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything)
+	c.AssertCalled(t, "Patch", mock.Anything,
+		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything, mock.Anything)
 }
 
 func TestReconcileCatalogSource_NotExistingYet_WithClientErrorGet(t *testing.T) {
@@ -56,7 +65,7 @@ func TestReconcileCatalogSource_NotExistingYet_WithClientErrorGet(t *testing.T)
 	).Return(timeoutErr)
 
 	ctx := context.Background()
-	_, err := reconcileCatalogSource(ctx, c, testutil.NewTestCatalogSource())
+	_, _, err := reconcileCatalogSource(ctx, c, testutil.NewTestCatalogSource())
 	assert.Error(t, err)
 	assert.EqualError(t, err, timeoutErr.Error())
 	c.AssertExpectations(t)
@@ -72,14 +81,15 @@ func TestReconcileCatalogSource_NotExistingYet_WithClientErrorCreate(t *testing.
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
 	).Return(testutil.NewTestErrNotFound())
-	c.On("Create",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
+		mock.Anything,
 	).Return(timeoutErr)
 
 	ctx := context.Background()
-	_, err := reconcileCatalogSource(ctx, c, testutil.NewTestCatalogSource())
+	_, _, err := reconcileCatalogSource(ctx, c, testutil.NewTestCatalogSource())
 	assert.Error(t, err)
 	assert.EqualError(t, err, timeoutErr.Error())
 	c.AssertExpectations(t)
@@ -99,15 +109,16 @@ func TestReconcileCatalogSource_Adoption(t *testing.T) {
 		catalogSourceWithoutOwner.DeepCopyInto(args.Get(2).(*operatorsv1alpha1.CatalogSource))
 	}).Return(nil)
 
-	c.On("Update",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
+		mock.Anything,
 	).Return(nil)
 
 	ctx := context.Background()
 
-	reconciledCatalogSource, err := reconcileCatalogSource(ctx, c, catalogSource.DeepCopy())
+	reconciledCatalogSource, _, err := reconcileCatalogSource(ctx, c, catalogSource.DeepCopy())
 
 	assert.NoError(t, err)
 	assert.NotNil(t, reconciledCatalogSource)
@@ -127,10 +138,11 @@ func TestEnsureCatalogSource_Create(t *testing.T) {
 	).Return(testutil.NewTestErrNotFound())
 
 	var createdCatalogSource *operatorsv1alpha1.CatalogSource
-	c.On("Create",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
+		mock.Anything,
 	).Run(func(args mock.Arguments) {
 		catalogSource := args.Get(1).(*operatorsv1alpha1.CatalogSource)
 		catalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
@@ -164,10 +176,11 @@ func TestEnsureAdditionalCatalogSource_Create(t *testing.T) {
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
 	).Return(testutil.NewTestErrNotFound())
-	c.On("Create",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
+		mock.Anything,
 	).Run(func(args mock.Arguments) {
 		catalogSource := args.Get(1).(*operatorsv1alpha1.CatalogSource)
 		catalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
@@ -185,8 +198,8 @@ func TestEnsureAdditionalCatalogSource_Create(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, resultNil, requeueResult)
 	c.AssertExpectations(t)
-	c.AssertNumberOfCalls(t, "Get", 2)
-	c.AssertNumberOfCalls(t, "Create", 2)
+	c.AssertNumberOfCalls(t, "Get", 4)
+	c.AssertNumberOfCalls(t, "Patch", 2)
 }
 
 func TestEnsureAdditionalCatalogSource_Update(t *testing.T) {
@@ -198,16 +211,20 @@ func TestEnsureAdditionalCatalogSource_Update(t *testing.T) {
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
 	).Run(func(args mock.Arguments) {
-		currentCatalogSource := args.Get(2).(*operatorsv1alpha1.CatalogSource)
-		currentCatalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
-			LastObservedState: "READY",
-		}
+		// Created for the Addon before.
+		controllers.AddCommonLabels(args.Get(2).(*operatorsv1alpha1.CatalogSource), addon)
 	}).Return(nil)
-	c.On("Update",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
-	).Return(nil)
+		mock.Anything,
+	).Run(func(args mock.Arguments) {
+		appliedCatalogSource := args.Get(1).(*operatorsv1alpha1.CatalogSource)
+		appliedCatalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
+			LastObservedState: "READY",
+		}
+	}).Return(nil)
 	r := &olmReconciler{
 		client: c,
 		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
@@ -218,8 +235,8 @@ func TestEnsureAdditionalCatalogSource_Update(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, resultNil, requeueResult)
 	c.AssertExpectations(t)
-	c.AssertNumberOfCalls(t, "Get", 2)
-	c.AssertNumberOfCalls(t, "Update", 2)
+	c.AssertNumberOfCalls(t, "Get", 4)
+	c.AssertNumberOfCalls(t, "Patch", 2)
 }
 
 func TestEnsureCatalogSource_Update(t *testing.T) {
@@ -232,16 +249,20 @@ func TestEnsureCatalogSource_Update(t *testing.T) {
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
 	).Run(func(args mock.Arguments) {
-		currentCatalogSource := args.Get(2).(*operatorsv1alpha1.CatalogSource)
-		currentCatalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
-			LastObservedState: "READY",
-		}
+		// Created for the Addon before.
+		controllers.AddCommonLabels(args.Get(2).(*operatorsv1alpha1.CatalogSource), addon)
 	}).Return(nil)
-	c.On("Update",
+	c.On("Patch",
 		mock.Anything,
 		testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
 		mock.Anything,
-	).Return(nil)
+		mock.Anything,
+	).Run(func(args mock.Arguments) {
+		appliedCatalogSource := args.Get(1).(*operatorsv1alpha1.CatalogSource)
+		appliedCatalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
+			LastObservedState: "READY",
+		}
+	}).Return(nil)
 
 	r := &olmReconciler{
 		client: c,
@@ -255,6 +276,117 @@ func TestEnsureCatalogSource_Update(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, resultNil, requeueResult)
 	c.AssertExpectations(t)
-	c.AssertNumberOfCalls(t, "Get", 1)
-	c.AssertNumberOfCalls(t, "Update", 1)
+	c.AssertNumberOfCalls(t, "Get", 2)
+	c.AssertNumberOfCalls(t, "Patch", 1)
+}
+
+func TestApplyCatalogSourceConfig(t *testing.T) {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.Install.OLMOwnNamespace.CatalogSource = &addonsv1alpha1.CatalogSourceConfig{
+		UpdateStrategy: &addonsv1alpha1.CatalogSourceUpdateStrategy{
+			RegistryPoll: &addonsv1alpha1.CatalogSourceRegistryPoll{
+				Interval: metav1.Duration{Duration: 45 * time.Minute},
+			},
+		},
+		GrpcPodConfig: &addonsv1alpha1.CatalogSourceGrpcPodConfig{
+			PriorityClassName: "system-cluster-critical",
+			NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": ""},
+		},
+	}
+	addon.Spec.Placement = &addonsv1alpha1.AddonPlacement{
+		NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+		Tolerations: []corev1.Toleration{
+			{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists},
+		},
+	}
+	addon.Spec.PriorityClassName = "addon-priority"
+
+	catalogSource := testutil.NewTestCatalogSource()
+	applyCatalogSourceConfig(catalogSource, addon)
+
+	require.NotNil(t, catalogSource.Spec.UpdateStrategy)
+	assert.Equal(t, "45m0s", catalogSource.Spec.UpdateStrategy.RegistryPoll.RawInterval)
+	require.NotNil(t, catalogSource.Spec.GrpcPodConfig)
+	assert.Equal(t, "system-cluster-critical", *catalogSource.Spec.GrpcPodConfig.PriorityClassName)
+	// The overrides take precedence over the Addon placement and priority.
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""},
+		catalogSource.Spec.GrpcPodConfig.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{
+		{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists},
+	}, catalogSource.Spec.GrpcPodConfig.Tolerations)
+
+	// The spec must not change when read back from the API server.
+	b, err := json.Marshal(catalogSource)
+	require.NoError(t, err)
+	observed := &operatorsv1alpha1.CatalogSource{}
+	require.NoError(t, json.Unmarshal(b, observed))
+	assert.True(t, equality.Semantic.DeepEqual(catalogSource.Spec, observed.Spec))
+}
+
+func TestEnsureAdditionalCatalogSource_PriorityAndReadiness(t *testing.T) {
+	for name, tc := range map[string]struct {
+		Required        *bool
+		ExpectedRequeue requeueResult
+		ExpectedStatus  metav1.ConditionStatus
+	}{
+		"required by default": {
+			ExpectedRequeue: resultRetry,
+			ExpectedStatus:  metav1.ConditionFalse,
+		},
+		"optional": {
+			Required:        pointer.Bool(false),
+			ExpectedRequeue: resultNil,
+			ExpectedStatus:  metav1.ConditionTrue,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			addon := testutil.NewTestAddonWithAdditionalCatalogSources()
+			catalogSrcs := addon.Spec.Install.OLMOwnNamespace.AdditionalCatalogSources
+			catalogSrcs[1].Priority = 10
+			catalogSrcs[1].Required = tc.Required
+
+			c := testutil.NewClient()
+			c.On("Get",
+				mock.Anything,
+				testutil.IsObjectKey,
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
+				mock.Anything,
+			).Return(testutil.NewTestErrNotFound())
+			var created []string
+			c.On("Patch",
+				mock.Anything,
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr,
+				mock.Anything,
+				mock.Anything,
+			).Run(func(args mock.Arguments) {
+				catalogSource := args.Get(1).(*operatorsv1alpha1.CatalogSource)
+				created = append(created, catalogSource.Name)
+				// Only test-2 is not ready yet.
+				if catalogSource.Name == "test-1" {
+					catalogSource.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
+						LastObservedState: "READY",
+					}
+				}
+			}).Return(nil)
+			r := &olmReconciler{
+				client: c,
+				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			requeueResult, err := r.ensureAdditionalCatalogSources(ctx, addon)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedRequeue, requeueResult)
+			c.AssertExpectations(t)
+			assert.Equal(t, []string{"test-2", "test-1"}, created)
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.CatalogSourcesReady)
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.ExpectedStatus, cond.Status)
+			assert.Equal(t, addonsv1alpha1.AddonReasonCatalogSourceConnecting, cond.Reason)
+			assert.Contains(t, cond.Message, "test-2")
+			assert.NotContains(t, cond.Message, "test-1")
+		})
+	}
 }
@@ -3,12 +3,12 @@ package addon
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -41,32 +41,90 @@ func (r *olmReconciler) ensureOperatorGroup(
 	if err := controllerutil.SetControllerReference(addon, desiredOperatorGroup, r.scheme); err != nil {
 		return resultNil, fmt.Errorf("setting controller reference: %w", err)
 	}
+	// Adoptable OperatorGroups are taken over below instead of being reported as conflicts.
+	if conflicting, err := r.conflictingOperatorGroups(ctx, addon, desiredOperatorGroup); err != nil {
+		return resultNil, err
+	} else if len(conflicting) > 0 {
+		reportOperatorGroupConflict(addon, desiredOperatorGroup.Namespace, conflicting)
+		return resultRetry, nil
+	}
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.OperatorGroupConflict)
+
+	if collided, err := r.checkOLMObjectCollision(ctx, addon, &operatorsv1.OperatorGroup{}, desiredOperatorGroup); err != nil {
+		return resultNil, fmt.Errorf("checking OperatorGroup collision: %w", err)
+	} else if collided {
+		reportCollidedOLMObjects(addon, []string{
+			fmt.Sprintf("OperatorGroup %s", client.ObjectKeyFromObject(desiredOperatorGroup))})
+		return resultRetry, nil
+	}
 	return resultNil, r.reconcileOperatorGroup(ctx, desiredOperatorGroup)
 }
 
-// Reconciles the Spec of the given OperatorGroup if needed by updating or creating the OperatorGroup.
-// The given OperatorGroup is updated to reflect the latest state from the kube-apiserver.
-func (r *olmReconciler) reconcileOperatorGroup(
-	ctx context.Context, operatorGroup *operatorsv1.OperatorGroup) error {
-	currentOperatorGroup := &operatorsv1.OperatorGroup{}
+// OLM refuses to install CSVs into namespaces with more than one OperatorGroup,
+// failing the CSV with a TooManyOperatorGroups reason.
+// Returns the names of the OperatorGroups besides the desired one in its namespace.
+// A single other OperatorGroup is not a conflict, when it already belongs to the Addon
+// or the Addon adopts OLM objects. The desired OperatorGroup is renamed to take it over.
+func (r *olmReconciler) conflictingOperatorGroups(
+	ctx context.Context, addon *addonsv1alpha1.Addon, desired *operatorsv1.OperatorGroup,
+) ([]string, error) {
+	operatorGroups := &operatorsv1.OperatorGroupList{}
+	if err := r.client.List(ctx, operatorGroups, client.InNamespace(desired.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing OperatorGroups: %w", err)
+	}
 
-	err := r.client.Get(ctx, client.ObjectKeyFromObject(operatorGroup), currentOperatorGroup)
-	if errors.IsNotFound(err) {
-		return r.client.Create(ctx, operatorGroup)
+	var (
+		others     []*operatorsv1.OperatorGroup
+		hasDesired bool
+	)
+	for i := range operatorGroups.Items {
+		operatorGroup := &operatorGroups.Items[i]
+		if operatorGroup.Name == desired.Name {
+			hasDesired = true
+			continue
+		}
+		others = append(others, operatorGroup)
 	}
-	if err != nil {
-		return fmt.Errorf("getting OperatorGroup: %w", err)
+	if !hasDesired && len(others) == 1 && isAdoptableOperatorGroup(addon, others[0]) {
+		desired.Name = others[0].Name
+		return nil, nil
 	}
 
-	currentLabels := labels.Set(currentOperatorGroup.Labels)
-	newLabels := labels.Merge(currentLabels, labels.Set(operatorGroup.Labels))
-	ownedByAddon := controllers.HasSameController(currentOperatorGroup, operatorGroup)
-	specChanged := !equality.Semantic.DeepEqual(currentOperatorGroup.Spec, operatorGroup.Spec)
-	if specChanged || !ownedByAddon || !labels.Equals(currentLabels, newLabels) {
-		currentOperatorGroup.Spec = operatorGroup.Spec
-		currentOperatorGroup.OwnerReferences = operatorGroup.OwnerReferences
-		currentOperatorGroup.Labels = newLabels
-		return r.client.Update(ctx, currentOperatorGroup)
+	conflicting := make([]string, len(others))
+	for i := range others {
+		conflicting[i] = others[i].Name
+	}
+	sort.Strings(conflicting)
+	return conflicting, nil
+}
+
+func isAdoptableOperatorGroup(addon *addonsv1alpha1.Addon, operatorGroup *operatorsv1.OperatorGroup) bool {
+	return metav1.IsControlledBy(operatorGroup, addon) ||
+		hasCommonLabelsOf(operatorGroup, addon) ||
+		adoptOLMObjects(addon)
+}
+
+func reportOperatorGroupConflict(addon *addonsv1alpha1.Addon, namespace string, conflicting []string) {
+	message := fmt.Sprintf(
+		"Namespace %s contains OperatorGroups not belonging to the Addon: %s. "+
+			"OLM only installs operators into namespaces with a single OperatorGroup.",
+		namespace, strings.Join(conflicting, ", "))
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.OperatorGroupConflict,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonConflictingOperatorGroups,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonConflictingOperatorGroups, message)
+}
+
+// Applies the given OperatorGroup.
+// The given OperatorGroup is updated to reflect the latest state from the kube-apiserver.
+func (r *olmReconciler) reconcileOperatorGroup(
+	ctx context.Context, operatorGroup *operatorsv1.OperatorGroup) error {
+	if err := controllers.Apply(ctx, r.client, operatorGroup); err != nil {
+		return fmt.Errorf("applying OperatorGroup: %w", err)
 	}
 	return nil
 }
@@ -8,10 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
@@ -72,24 +70,19 @@ func TestEnsureOperatorGroup(t *testing.T) {
 			addon := test.addon
 
 			// Mock Setup
-			c.
-				On(
-					"Get",
-					mock.Anything,
-					client.ObjectKey{
-						Name:      controllers.DefaultOperatorGroupName,
-						Namespace: test.targetNamespace,
-					},
-					mock.IsType(&operatorsv1.OperatorGroup{}),
-					mock.Anything,
-				).
-				Return(errors.NewNotFound(schema.GroupResource{}, ""))
 			var createdOperatorGroup *operatorsv1.OperatorGroup
+			c.On("List", testutil.IsContext,
+				mock.IsType(&operatorsv1.OperatorGroupList{}), mock.Anything).
+				Return(nil)
+			c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+				testutil.IsOperatorsV1OperatorGroupPtr, mock.Anything).
+				Return(testutil.NewTestErrNotFound())
 			c.
 				On(
-					"Create",
+					"Patch",
 					mock.Anything,
 					mock.IsType(&operatorsv1.OperatorGroup{}),
+					client.Apply,
 					mock.Anything,
 				).
 				Run(func(args mock.Arguments) {
@@ -104,9 +97,10 @@ func TestEnsureOperatorGroup(t *testing.T) {
 			assert.Equal(t, resultNil, requeueResult)
 
 			if c.AssertCalled(
-				t, "Create",
+				t, "Patch",
 				mock.Anything,
 				mock.IsType(&operatorsv1.OperatorGroup{}),
+				client.Apply,
 				mock.Anything,
 			) {
 				assert.Equal(t, controllers.DefaultOperatorGroupName, createdOperatorGroup.Name)
@@ -210,55 +204,105 @@ func TestEnsureOperatorGroup(t *testing.T) {
 }
 
 func TestReconcileOperatorGroup_Adoption(t *testing.T) {
-	for name, tc := range map[string]struct {
-		AlreadyOwnedByAddon bool
-	}{
-		"Operator group not owned by addon": {
-			AlreadyOwnedByAddon: false,
-		},
-		"Operator group already owned by addon": {
-			AlreadyOwnedByAddon: true,
-		},
-	} {
-		t.Run(name, func(t *testing.T) {
-			operatorGroup := testutil.NewTestOperatorGroup()
-			c := testutil.NewClient()
+	operatorGroup := testutil.NewTestOperatorGroup()
+	c := testutil.NewClient()
 
-			c.On("Get",
-				testutil.IsContext,
-				testutil.IsObjectKey,
-				testutil.IsOperatorsV1OperatorGroupPtr,
-				mock.Anything,
-			).Run(func(args mock.Arguments) {
-				var og *operatorsv1.OperatorGroup
-
-				if tc.AlreadyOwnedByAddon {
-					og = testutil.NewTestOperatorGroup()
-					// Unrelated spec change to force reconciliation
-					og.Spec.StaticProvidedAPIs = true
-				} else {
-					og = testutil.NewTestOperatorGroupWithoutOwner()
-				}
+	// Existing OperatorGroups are adopted by applying the controller reference.
+	var applied *operatorsv1.OperatorGroup
+	c.On("Patch",
+		testutil.IsContext,
+		testutil.IsOperatorsV1OperatorGroupPtr,
+		client.Apply,
+		mock.Anything,
+	).Run(func(args mock.Arguments) {
+		applied = args.Get(1).(*operatorsv1.OperatorGroup)
+	}).Return(nil)
+
+	rec := &olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
 
-				og.DeepCopyInto(args.Get(2).(*operatorsv1.OperatorGroup))
-			}).Return(nil)
+	ctx := context.Background()
+	err := rec.reconcileOperatorGroup(ctx, operatorGroup.DeepCopy())
 
-			c.On("Update",
-				testutil.IsContext,
-				testutil.IsOperatorsV1OperatorGroupPtr,
-				mock.Anything,
-			).Return(nil)
+	assert.NoError(t, err)
+	c.AssertExpectations(t)
+	require.NotNil(t, applied)
+	assert.Equal(t, operatorGroup.OwnerReferences, applied.OwnerReferences)
+}
 
-			rec := &olmReconciler{
-				client: c,
-				scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+func TestEnsureOperatorGroup_Conflict(t *testing.T) {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	c := testutil.NewClient()
+	r := &olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	c.On("List", testutil.IsContext,
+		mock.IsType(&operatorsv1.OperatorGroupList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*operatorsv1.OperatorGroupList)
+			list.Items = []operatorsv1.OperatorGroup{
+				{ObjectMeta: metav1.ObjectMeta{Name: controllers.DefaultOperatorGroupName}},
+				// Created when installing an operator via the console.
+				{ObjectMeta: metav1.ObjectMeta{Name: "addon-1-x7k2p"}},
 			}
+		}).
+		Return(nil)
 
-			ctx := context.Background()
-			err := rec.reconcileOperatorGroup(ctx, operatorGroup.DeepCopy())
+	ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	requeueResult, err := r.ensureOperatorGroup(ctx, addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultRetry, requeueResult)
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 
-			assert.NoError(t, err)
-			c.AssertExpectations(t)
-		})
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.OperatorGroupConflict)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonConflictingOperatorGroups, cond.Reason)
+	assert.Contains(t, cond.Message, "addon-1-x7k2p")
+}
+
+func TestEnsureOperatorGroup_AdoptRenamed(t *testing.T) {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Annotations = map[string]string{addonsv1alpha1.AdoptOLMObjectsAnnotation: "true"}
+	c := testutil.NewClient()
+	r := &olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
 	}
+
+	c.On("List", testutil.IsContext,
+		mock.IsType(&operatorsv1.OperatorGroupList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*operatorsv1.OperatorGroupList)
+			list.Items = []operatorsv1.OperatorGroup{
+				// Created when installing an operator via the console.
+				{ObjectMeta: metav1.ObjectMeta{Name: "addon-1-x7k2p"}},
+			}
+		}).
+		Return(nil)
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		testutil.IsOperatorsV1OperatorGroupPtr, mock.Anything).
+		Return(nil)
+	var applied *operatorsv1.OperatorGroup
+	c.On("Patch", testutil.IsContext, testutil.IsOperatorsV1OperatorGroupPtr,
+		client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			applied = args.Get(1).(*operatorsv1.OperatorGroup)
+		}).
+		Return(nil)
+
+	ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	requeueResult, err := r.ensureOperatorGroup(ctx, addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultNil, requeueResult)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.OperatorGroupConflict))
+
+	// The existing OperatorGroup is taken over under its own name.
+	require.NotNil(t, applied)
+	assert.Equal(t, "addon-1-x7k2p", applied.Name)
+	assert.True(t, metav1.IsControlledBy(applied, addon))
 }
@@ -0,0 +1,143 @@
+package addon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Renders .spec.parameters and the parameters synced from OCM into the parameters ConfigMap
+// and Secret in the Addon install namespace, removing them when there are no parameters.
+func (r *olmReconciler) ensureParameters(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (requeueResult, error) {
+	commonConfig, stop := parseAddonInstallConfig(controllers.LoggerFromContext(ctx), addon)
+	if stop {
+		return resultStop, nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ParametersName(addon),
+			Namespace: commonConfig.Namespace,
+		},
+		Data: map[string]string{},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ParametersName(addon),
+			Namespace: commonConfig.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{},
+	}
+
+	ocmValues, synced, err := r.syncOCMParameters(ctx, addon, client.ObjectKeyFromObject(secret))
+	if err != nil {
+		return resultNil, fmt.Errorf("syncing parameters from OCM: %w", err)
+	}
+	if !synced {
+		// Parameters must be known, before the addon is installed.
+		return resultRetry, nil
+	}
+
+	params := mergeOCMParameters(addon.Spec.Parameters, ocmValues)
+	for _, param := range params {
+		if param.Secret {
+			secret.Data[param.Name] = []byte(param.Value)
+			continue
+		}
+		configMap.Data[param.Name] = param.Value
+	}
+
+	for _, obj := range []client.Object{configMap, secret} {
+		if len(params) == 0 {
+			if err := deleteCachedObject(ctx, r.client, obj); err != nil {
+				return resultNil, fmt.Errorf("deleting parameters: %w", err)
+			}
+			continue
+		}
+
+		controllers.AddCommonLabels(obj, addon)
+		controllers.AddCommonAnnotations(obj, addon)
+		if err := controllerutil.SetControllerReference(addon, obj, r.scheme); err != nil {
+			return resultNil, fmt.Errorf("setting controller reference: %w", err)
+		}
+	}
+	if len(params) == 0 {
+		return resultNil, nil
+	}
+
+	if err := reconcileParametersConfigMap(ctx, r.client, configMap); err != nil {
+		return resultNil, fmt.Errorf("reconciling parameters ConfigMap: %w", err)
+	}
+	if err := reconcileSecret(ctx, r.client, secret); err != nil {
+		return resultNil, fmt.Errorf("reconciling parameters Secret: %w", err)
+	}
+	return resultNil, nil
+}
+
+// Sets the parameters hash as environment variable of the addon operator,
+// so OLM rolls out the operator again, whenever the parameters change.
+func applyParametersHash(
+	subscriptionConfig *operatorsv1alpha1.SubscriptionConfig, addon *addonsv1alpha1.Addon,
+) *operatorsv1alpha1.SubscriptionConfig {
+	hash := parametersHash(addon)
+	if len(hash) == 0 {
+		return subscriptionConfig
+	}
+	if subscriptionConfig == nil {
+		subscriptionConfig = &operatorsv1alpha1.SubscriptionConfig{}
+	}
+	subscriptionConfig.Env = append(subscriptionConfig.Env, corev1.EnvVar{
+		Name:  addonsv1alpha1.ParametersHashEnvVar,
+		Value: hash,
+	})
+	return subscriptionConfig
+}
+
+func reconcileParametersConfigMap(ctx context.Context, c client.Client, configMap *corev1.ConfigMap) error {
+	return controllers.Apply(ctx, c, configMap)
+}
+
+// Hash over all parameters of the Addon, including those synced from OCM.
+// Returns an empty string, if the Addon has no parameters.
+func parametersHash(addon *addonsv1alpha1.Addon) string {
+	var ocmHash string
+	if status := addon.Status.OCMParameters; status != nil && len(status.Names) > 0 {
+		ocmHash = status.Hash
+	}
+	if len(addon.Spec.Parameters) == 0 && len(ocmHash) == 0 {
+		return ""
+	}
+
+	params := make([]addonsv1alpha1.AddonParameter, len(addon.Spec.Parameters))
+	copy(params, addon.Spec.Parameters)
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+
+	h := sha256.New()
+	for _, param := range params {
+		fmt.Fprintf(h, "%s\x00%t\x00%s\x00", param.Name, param.Secret, param.Value)
+	}
+	if len(ocmHash) > 0 {
+		fmt.Fprintf(h, "ocm\x00%s\x00", ocmHash)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func ParametersName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-parameters", addon.Name)
+}
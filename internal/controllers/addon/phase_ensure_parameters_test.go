@@ -0,0 +1,377 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestEnsureParameters(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.Parameters = []addonsv1alpha1.AddonParameter{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "API_TOKEN", Value: "s3cr3t", Secret: true},
+	}
+
+	var (
+		createdConfigMap *corev1.ConfigMap
+		createdSecret    *corev1.Secret
+	)
+	c.On("Get", testutil.IsContext, mock.Anything,
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.ConfigMap{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdConfigMap = args.Get(1).(*corev1.ConfigMap)
+		}).
+		Return(nil)
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.Secret{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdSecret = args.Get(1).(*corev1.Secret)
+		}).
+		Return(nil)
+
+	res, err := r.ensureParameters(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultNil, res)
+	c.AssertExpectations(t)
+
+	require.NotNil(t, createdConfigMap)
+	assert.Equal(t, "addon-addon-1-parameters", createdConfigMap.Name)
+	assert.Equal(t, "addon-1", createdConfigMap.Namespace)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, createdConfigMap.Data)
+
+	require.NotNil(t, createdSecret)
+	assert.Equal(t, "addon-addon-1-parameters", createdSecret.Name)
+	assert.Equal(t, map[string][]byte{"API_TOKEN": []byte("s3cr3t")}, createdSecret.Data)
+}
+
+func TestEnsureParameters_Cleanup(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	// Only the Secret is left over from earlier parameters.
+	c.On("Get", testutil.IsContext, mock.Anything,
+		mock.IsType(&corev1.ConfigMap{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+	c.On("Get", testutil.IsContext, mock.Anything,
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(nil)
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(nil)
+
+	res, err := r.ensureParameters(context.Background(), testutil.NewTestAddonWithCatalogSourceImage())
+	require.NoError(t, err)
+	assert.Equal(t, resultNil, res)
+	c.AssertExpectations(t)
+	c.AssertNotCalled(t, "Delete", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), mock.Anything)
+}
+
+func TestApplyParametersHash(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	assert.Nil(t, applyParametersHash(nil, addon))
+
+	addon.Spec.Parameters = []addonsv1alpha1.AddonParameter{{Name: "LOG_LEVEL", Value: "debug"}}
+	config := applyParametersHash(&operatorsv1alpha1.SubscriptionConfig{
+		Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+	}, addon)
+	require.NotNil(t, config)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "FOO", Value: "bar"},
+		{Name: addonsv1alpha1.ParametersHashEnvVar, Value: parametersHash(addon)},
+	}, config.Env)
+}
+
+func TestParametersHash(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	assert.Empty(t, parametersHash(addon))
+
+	addon.Spec.Parameters = []addonsv1alpha1.AddonParameter{
+		{Name: "a", Value: "1"},
+		{Name: "b", Value: "2"},
+	}
+	hash := parametersHash(addon)
+	assert.Len(t, hash, 16)
+
+	// Order independent.
+	addon.Spec.Parameters = []addonsv1alpha1.AddonParameter{
+		{Name: "b", Value: "2"},
+		{Name: "a", Value: "1"},
+	}
+	assert.Equal(t, hash, parametersHash(addon))
+
+	addon.Spec.Parameters[0].Value = "3"
+	assert.NotEqual(t, hash, parametersHash(addon))
+}
+
+type ocmParameterSourceMock struct {
+	mock.Mock
+}
+
+func (m *ocmParameterSourceMock) getOCMAddOnParameters(
+	ctx context.Context, addonID string,
+) (map[string]string, error) {
+	args := m.Called(ctx, addonID)
+	values, _ := args.Get(0).(map[string]string)
+	return values, args.Error(1)
+}
+
+func newTestAddonWithOCMParameterSync() *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.Parameters = []addonsv1alpha1.AddonParameter{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "REPLICAS", Value: "1"},
+	}
+	addon.Spec.OCMParameterSync = &addonsv1alpha1.AddonOCMParameterSync{}
+	return addon
+}
+
+func TestEnsureParameters_OCMSync(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.April, 3, 3, 30, 0, 0, time.UTC)
+
+	c := testutil.NewClient()
+	clock := &testClock{}
+	clock.On("Now").Return(now)
+	source := &ocmParameterSourceMock{}
+	source.On("getOCMAddOnParameters", testutil.IsContext, "addon-1").
+		Return(map[string]string{"REPLICAS": "3", "API_TOKEN": "s3cr3t"}, nil)
+	r := &olmReconciler{
+		client:        c,
+		scheme:        testutil.NewTestSchemeWithAddonsv1alpha1(),
+		clock:         clock,
+		ocmParameters: source,
+	}
+
+	addon := newTestAddonWithOCMParameterSync()
+	specHash := parametersHash(addon)
+
+	var (
+		createdConfigMap *corev1.ConfigMap
+		createdSecret    *corev1.Secret
+	)
+	c.On("Get", testutil.IsContext, mock.Anything,
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.ConfigMap{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdConfigMap = args.Get(1).(*corev1.ConfigMap)
+		}).
+		Return(nil)
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.Secret{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdSecret = args.Get(1).(*corev1.Secret)
+		}).
+		Return(nil)
+
+	res, err := r.ensureParameters(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultNil, res)
+	source.AssertExpectations(t)
+
+	require.NotNil(t, createdConfigMap)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "REPLICAS": "3"}, createdConfigMap.Data)
+	require.NotNil(t, createdSecret)
+	assert.Equal(t, map[string][]byte{"API_TOKEN": []byte("s3cr3t")}, createdSecret.Data)
+
+	require.NotNil(t, addon.Status.OCMParameters)
+	assert.Equal(t, []string{"API_TOKEN", "REPLICAS"}, addon.Status.OCMParameters.Names)
+	assert.Len(t, addon.Status.OCMParameters.Hash, 16)
+	assert.Equal(t, now, addon.Status.OCMParameters.LastSyncTime.Time)
+
+	assert.True(t, meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.OCMParametersSynced))
+
+	// Synced values roll out the addon.
+	assert.NotEqual(t, specHash, parametersHash(addon))
+	assert.Equal(t, defaultOCMParameterSyncInterval,
+		requeueForOCMParameterSync(addon, now).RequeueAfter)
+}
+
+func TestEnsureParameters_OCMSyncKeepsLastValues(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.April, 3, 3, 30, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		lastSync time.Time
+		ocmErr   error
+	}{
+		"sync not due": {
+			lastSync: now.Add(-time.Minute),
+		},
+		"ocm unavailable": {
+			lastSync: now.Add(-time.Hour),
+			ocmErr:   errors.New("explosion"),
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			clock := &testClock{}
+			clock.On("Now").Return(now)
+			source := &ocmParameterSourceMock{}
+			source.On("getOCMAddOnParameters", testutil.IsContext, "addon-1").
+				Return(nil, tc.ocmErr)
+			r := &olmReconciler{
+				client:        c,
+				scheme:        testutil.NewTestSchemeWithAddonsv1alpha1(),
+				clock:         clock,
+				ocmParameters: source,
+			}
+
+			addon := newTestAddonWithOCMParameterSync()
+			status := &addonsv1alpha1.AddonOCMParametersStatus{
+				Names:        []string{"API_TOKEN"},
+				Hash:         ocmParametersHash(map[string]string{"API_TOKEN": "s3cr3t"}),
+				LastSyncTime: metav1.NewTime(tc.lastSync),
+			}
+			addon.Status.OCMParameters = status.DeepCopy()
+
+			c.On("Get", testutil.IsContext, client.ObjectKey{
+				Name: "addon-addon-1-parameters", Namespace: "addon-1",
+			}, mock.IsType(&corev1.Secret{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					secret := args.Get(2).(*corev1.Secret)
+					secret.Data = map[string][]byte{"API_TOKEN": []byte("s3cr3t")}
+					secret.Type = corev1.SecretTypeOpaque
+				}).
+				Return(nil)
+
+			var createdSecret *corev1.Secret
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&corev1.ConfigMap{}), mock.Anything, mock.Anything).
+				Return(nil)
+			c.On("Patch", testutil.IsContext,
+				mock.IsType(&corev1.Secret{}), mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					createdSecret = args.Get(1).(*corev1.Secret)
+				}).
+				Return(nil)
+
+			res, err := r.ensureParameters(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, resultNil, res)
+
+			require.NotNil(t, createdSecret)
+			assert.Equal(t, map[string][]byte{"API_TOKEN": []byte("s3cr3t")}, createdSecret.Data)
+			assert.Equal(t, status, addon.Status.OCMParameters)
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.OCMParametersSynced)
+			if tc.ocmErr == nil {
+				source.AssertNotCalled(t, "getOCMAddOnParameters", mock.Anything, mock.Anything)
+				assert.Nil(t, cond)
+			} else {
+				assert.Equal(t, ocmParameterSyncRetryInterval,
+					requeueForOCMParameterSync(addon, now).RequeueAfter)
+				require.NotNil(t, cond)
+				assert.Equal(t, metav1.ConditionFalse, cond.Status)
+				assert.Equal(t, addonsv1alpha1.AddonReasonOCMParametersSyncFailed, cond.Reason)
+				assert.Contains(t, cond.Message, "explosion")
+				assert.Contains(t, cond.Message, tc.lastSync.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestEnsureParameters_OCMSyncUnavailable(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	clock := &testClock{}
+	clock.On("Now").Return(time.Now())
+	r := &olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+		clock:  clock,
+	}
+
+	addon := newTestAddonWithOCMParameterSync()
+	res, err := r.ensureParameters(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultRetry, res)
+	assert.Nil(t, addon.Status.OCMParameters)
+	assert.True(t, meta.IsStatusConditionFalse(addon.Status.Conditions, addonsv1alpha1.OCMParametersSynced))
+	c.AssertNotCalled(t, "Patch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEnsureParameters_OCMDisabled(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	clock := &testClock{}
+	clock.On("Now").Return(time.Now())
+	source := &ocmParameterSourceMock{}
+	source.On("getOCMAddOnParameters", testutil.IsContext, "addon-1").
+		Return(nil, errOCMDisabled)
+	r := &olmReconciler{
+		client:        c,
+		scheme:        testutil.NewTestSchemeWithAddonsv1alpha1(),
+		clock:         clock,
+		ocmParameters: source,
+	}
+
+	c.On("Get", testutil.IsContext, mock.Anything,
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.ConfigMap{}), mock.Anything, mock.Anything).
+		Return(nil)
+	var createdSecret *corev1.Secret
+	c.On("Patch", testutil.IsContext,
+		mock.IsType(&corev1.Secret{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdSecret = args.Get(1).(*corev1.Secret)
+		}).
+		Return(nil).
+		Maybe()
+
+	// Installs with the spec parameters only, instead of waiting for a sync.
+	addon := newTestAddonWithOCMParameterSync()
+	res, err := r.ensureParameters(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, resultNil, res)
+	assert.Nil(t, addon.Status.OCMParameters)
+	if createdSecret != nil {
+		assert.NotContains(t, createdSecret.Data, "API_TOKEN")
+	}
+}
@@ -3,14 +3,12 @@ package addon
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
-	"k8s.io/apimachinery/pkg/api/equality"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -39,7 +37,12 @@ func (r *olmReconciler) ensureSubscription(
 		commonInstallOptions = addon.Spec.Install.
 			OLMOwnNamespace.AddonInstallOLMCommon
 	}
-	subscriptionConfigObject := createSubscriptionConfigObject(commonInstallOptions)
+	proxy, err := r.resolveProxyConfig(ctx, addon)
+	if err != nil {
+		return resultNil, client.ObjectKey{}, fmt.Errorf("resolving proxy config: %w", err)
+	}
+	subscriptionConfigObject := applyParametersHash(applyProxyConfig(
+		createSubscriptionConfigObject(commonInstallOptions, addon.Spec.Placement), proxy), addon)
 	desiredSubscription := &operatorsv1alpha1.Subscription{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      SubscriptionName(addon),
@@ -51,7 +54,7 @@ func (r *olmReconciler) ensureSubscription(
 			Channel:                commonInstallOptions.Channel,
 			Package:                commonInstallOptions.PackageName,
 			Config:                 subscriptionConfigObject,
-			// InstallPlanApproval is deliberately unmanaged
+			// InstallPlanApproval is unmanaged, unless set in the Addon.
 			// API default is `Automatic`
 			// Legacy behavior of existing managed-tenants tooling is:
 			// All addons initially have to be installed with `Automatic`
@@ -60,18 +63,39 @@ func (r *olmReconciler) ensureSubscription(
 			// change the Subscription.Spec.InstallPlanApproval value to `Manual`
 			// ATTENTION: When reconciling the subscription, we need to
 			// make sure to keep the current value of this field
+			InstallPlanApproval: operatorsv1alpha1.Approval(commonInstallOptions.InstallPlanApproval),
+			StartingCSV:         startingCSV(addon, commonInstallOptions),
 		},
 	}
+	addAdditionalMetadata(desiredSubscription, commonInstallOptions.SubscriptionMetadata)
 	controllers.AddCommonLabels(desiredSubscription, addon)
 	controllers.AddCommonAnnotations(desiredSubscription, addon)
 	if err := controllerutil.SetControllerReference(addon, desiredSubscription, r.scheme); err != nil {
 		return resultNil, client.ObjectKey{}, fmt.Errorf("setting controller reference: %w", err)
 	}
 
-	observedSubscription, err := r.reconcileSubscription(ctx, desiredSubscription)
+	if collided, err := r.checkOLMObjectCollision(ctx, addon, &operatorsv1alpha1.Subscription{}, desiredSubscription); err != nil {
+		return resultNil, client.ObjectKey{}, fmt.Errorf("checking Subscription collision: %w", err)
+	} else if collided {
+		reportCollidedOLMObjects(addon, []string{
+			fmt.Sprintf("Subscription %s", client.ObjectKeyFromObject(desiredSubscription))})
+		return resultRetry, client.ObjectKey{}, nil
+	}
+
+	observedSubscription, changed, err := r.reconcileSubscription(ctx, desiredSubscription)
 	if err != nil {
 		return resultNil, client.ObjectKey{}, fmt.Errorf("reconciling Subscription: %w", err)
 	}
+	if changed {
+		r.drift.reportRemediated(ctx, addon, observedSubscription, "Subscription")
+	}
+	if requeueResult, err := r.rollbackSubscription(ctx, addon, observedSubscription); err != nil {
+		return resultNil, client.ObjectKey{}, err
+	} else if requeueResult != resultNil {
+		return requeueResult, client.ObjectKey{}, nil
+	}
+
+	reportSubscriptionHealth(addon, observedSubscription)
 
 	if len(observedSubscription.Status.InstalledCSV) == 0 ||
 		len(observedSubscription.Status.CurrentCSV) == 0 {
@@ -82,15 +106,19 @@ func (r *olmReconciler) ensureSubscription(
 		// reason: ConstraintsNotSatisfiable
 		// status: "True"
 		// type: ResolutionFailed
+		reason, message := addonsv1alpha1.AddonReasonUnreadyCSV, "CSV not linked in Subscription. Dependency issue?"
+		if failure, failed := subscriptionResolutionFailure(addon); failed {
+			reason, message = addonsv1alpha1.AddonReasonResolutionFailed, failure
+		}
 		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
 			Type:               addonsv1alpha1.Available,
 			Status:             metav1.ConditionFalse,
-			Reason:             addonsv1alpha1.AddonReasonUnreadyCSV,
-			Message:            "CSV not linked in Subscription. Dependency issue?",
+			Reason:             reason,
+			Message:            message,
 			ObservedGeneration: addon.Generation,
 		})
 		addon.Status.ObservedGeneration = addon.Generation
-		addon.Status.Phase = addonsv1alpha1.PhaseError
+		reportPhase(addon, addonsv1alpha1.PhaseError)
 
 		log.Info("requeue", "reason", "csv not linked in subscription")
 		return resultRetry, client.ObjectKey{}, nil
@@ -104,49 +132,65 @@ func (r *olmReconciler) ensureSubscription(
 	return resultNil, currentCSVKey, nil
 }
 
+// Applies the given Subscription and returns the current object as observed.
+// An installPlanApproval not set by the Addon is left to other field managers.
+// Returns true, if fields of an existing Subscription had to be reverted.
 func (r *olmReconciler) reconcileSubscription(
 	ctx context.Context,
 	subscription *operatorsv1alpha1.Subscription,
-) (currentSubscription *operatorsv1alpha1.Subscription, err error) {
+) (currentSubscription *operatorsv1alpha1.Subscription, changed bool, err error) {
 	currentSubscription = &operatorsv1alpha1.Subscription{}
-	err = r.client.Get(ctx, client.ObjectKey{
-		Name:      subscription.Name,
-		Namespace: subscription.Namespace,
-	}, currentSubscription)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			return subscription, r.client.Create(ctx, subscription)
-		}
-		return nil, err
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(subscription),
+		currentSubscription); client.IgnoreNotFound(err) != nil {
+		return nil, false, err
 	}
 
-	// keep installPlanApproval value of existing object
-	subscription.Spec.InstallPlanApproval = currentSubscription.Spec.InstallPlanApproval
+	changed, err = controllers.ApplyChanged(ctx, r.client, currentSubscription, subscription)
+	return subscription, changed, err
+}
 
-	// Only update when spec, controllerRef, or labels have changed
-	specChanged := !equality.Semantic.DeepEqual(subscription.Spec, currentSubscription.Spec)
-	ownedByAddon := controllers.HasSameController(currentSubscription, subscription)
-	currentLabels := labels.Set(currentSubscription.Labels)
-	newLabels := labels.Merge(currentLabels, labels.Set(subscription.Labels))
-	if specChanged || !ownedByAddon || !labels.Equals(currentLabels, newLabels) {
-		currentSubscription.Spec = subscription.Spec
-		currentSubscription.OwnerReferences = subscription.OwnerReferences
-		currentSubscription.Labels = newLabels
-		return currentSubscription, r.client.Update(ctx, currentSubscription)
+// Pins the initial install to the CSV of .spec.version,
+// if InstallPlans are only approved for that version.
+// Otherwise OLM would resolve the channel head,
+// which would never be approved.
+// Addons rolled back start at their known good CSV instead.
+func startingCSV(addon *addonsv1alpha1.Addon, commonInstallOptions addonsv1alpha1.AddonInstallOLMCommon) string {
+	if targetCSV := rollbackTargetCSVName(addon); len(targetCSV) > 0 {
+		return targetCSV
 	}
-
-	return currentSubscription, nil
+	if commonInstallOptions.InstallPlanApproval != addonsv1alpha1.InstallPlanApprovalManual ||
+		len(addon.Spec.Version) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s.v%s", commonInstallOptions.PackageName, strings.TrimPrefix(addon.Spec.Version, "v"))
 }
 
-// Returns the subscription config object to be created from the passed AddonInstallOLMCommon object
-func createSubscriptionConfigObject(commonInstallOptions addonsv1alpha1.AddonInstallOLMCommon) *operatorsv1alpha1.SubscriptionConfig {
-	if commonInstallOptions.Config != nil {
-		subscriptionConfig := &operatorsv1alpha1.SubscriptionConfig{
-			Env: getSubscriptionEnvObjects(commonInstallOptions.Config.EnvironmentVariables),
+// Returns the subscription config object to be created from the passed AddonInstallOLMCommon object.
+// The Addon placement is used for scheduling constraints not set in the install config.
+func createSubscriptionConfigObject(
+	commonInstallOptions addonsv1alpha1.AddonInstallOLMCommon, placement *addonsv1alpha1.AddonPlacement,
+) *operatorsv1alpha1.SubscriptionConfig {
+	if commonInstallOptions.Config == nil && placement == nil {
+		return nil
+	}
+
+	subscriptionConfig := &operatorsv1alpha1.SubscriptionConfig{}
+	if config := commonInstallOptions.Config; config != nil {
+		subscriptionConfig.Env = getSubscriptionEnvObjects(config.EnvironmentVariables)
+		subscriptionConfig.Resources = config.Resources
+		subscriptionConfig.Tolerations = config.Tolerations
+		subscriptionConfig.NodeSelector = config.NodeSelector
+	}
+	if placement != nil {
+		if len(subscriptionConfig.Tolerations) == 0 {
+			subscriptionConfig.Tolerations = placement.Tolerations
+		}
+		if len(subscriptionConfig.NodeSelector) == 0 {
+			subscriptionConfig.NodeSelector = placement.NodeSelector
 		}
-		return subscriptionConfig
+		subscriptionConfig.Affinity = placement.Affinity
 	}
-	return nil
+	return subscriptionConfig
 }
 
 // Converts addonsv1alpha1.EnvObjects to corev1.EnvVar's
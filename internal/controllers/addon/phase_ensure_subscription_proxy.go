@@ -0,0 +1,177 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+const (
+	clusterProxyName = "cluster"
+
+	trustedCAVolumeName = "addon-trusted-ca"
+	trustedCAMountPath  = "/etc/pki/ca-trust/extracted/pem"
+)
+
+// Proxy settings resolved from .spec.proxy and the cluster-wide Proxy.
+type proxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	TrustedCA  string
+}
+
+// Returns the proxy settings of the Addon or nil, if none are configured.
+// Addons without .spec.proxy inherit the cluster-wide Proxy.
+func (r *olmReconciler) resolveProxyConfig(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (*proxyConfig, error) {
+	proxy := addon.Spec.Proxy
+	config := &proxyConfig{}
+	if proxy != nil {
+		config.HTTPProxy = proxy.HTTPProxy
+		config.HTTPSProxy = proxy.HTTPSProxy
+		config.NoProxy = proxy.NoProxy
+		if proxy.TrustedCA != nil {
+			config.TrustedCA = proxy.TrustedCA.Name
+		}
+		if proxy.Mode != addonsv1alpha1.AddonProxyModeInheritCluster {
+			return config, nil
+		}
+	}
+
+	clusterProxy, err := getClusterProxy(ctx, r.client)
+	if err != nil {
+		return nil, err
+	}
+	if clusterProxy == nil {
+		// Nothing to inherit.
+		if proxy == nil {
+			return nil, nil
+		}
+		return config, nil
+	}
+
+	// The status holds the settings in effect for the cluster.
+	if len(config.HTTPProxy) == 0 {
+		config.HTTPProxy = clusterProxy.Status.HTTPProxy
+	}
+	if len(config.HTTPSProxy) == 0 {
+		config.HTTPSProxy = clusterProxy.Status.HTTPSProxy
+	}
+	if len(config.NoProxy) == 0 {
+		config.NoProxy = clusterProxy.Status.NoProxy
+	}
+	return config, nil
+}
+
+// Whether the Addon inherits settings from the cluster-wide Proxy,
+// either for its operator or for its RHOBS remote write.
+func inheritsClusterProxy(addon *addonsv1alpha1.Addon) bool {
+	if addon.Spec.Proxy == nil ||
+		addon.Spec.Proxy.Mode == addonsv1alpha1.AddonProxyModeInheritCluster {
+		return true
+	}
+	if !HasMonitoringStack(addon) {
+		return false
+	}
+	config := addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig
+	return config != nil && (config.Proxy == nil ||
+		config.Proxy.Mode == addonsv1alpha1.AddonProxyModeInheritCluster)
+}
+
+// Returns the cluster-wide Proxy or nil, if it does not exist.
+// The Proxy is watched, so the given client may be cached.
+func getClusterProxy(ctx context.Context, c client.Client) (*configv1.Proxy, error) {
+	clusterProxy := &configv1.Proxy{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name: clusterProxyName,
+	}, clusterProxy); k8sApiErrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting cluster Proxy: %w", err)
+	}
+	return clusterProxy, nil
+}
+
+// Requeues all Addons inheriting settings from the cluster-wide Proxy.
+func (r *AddonReconciler) enqueueClusterProxyAddons(obj client.Object) []reconcile.Request {
+	if obj.GetName() != clusterProxyName {
+		return nil
+	}
+
+	addonList := &addonsv1alpha1.AddonList{}
+	if err := r.List(context.Background(), addonList); err != nil {
+		r.Log.Error(err, "listing Addons inheriting the cluster Proxy")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for i := range addonList.Items {
+		addon := &addonList.Items[i]
+		if !inheritsClusterProxy(addon) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: addon.Name},
+		})
+	}
+	return reqs
+}
+
+// Injects the proxy settings into the given SubscriptionConfig.
+// Environment variables set in .spec.install.olm*.config take precedence.
+func applyProxyConfig(
+	subscriptionConfig *operatorsv1alpha1.SubscriptionConfig, proxy *proxyConfig,
+) *operatorsv1alpha1.SubscriptionConfig {
+	if proxy == nil {
+		return subscriptionConfig
+	}
+	if subscriptionConfig == nil {
+		subscriptionConfig = &operatorsv1alpha1.SubscriptionConfig{}
+	}
+
+	existing := map[string]struct{}{}
+	for _, env := range subscriptionConfig.Env {
+		existing[env.Name] = struct{}{}
+	}
+	for _, env := range []corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: proxy.HTTPProxy},
+		{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy},
+		{Name: "NO_PROXY", Value: proxy.NoProxy},
+	} {
+		if _, ok := existing[env.Name]; ok || len(env.Value) == 0 {
+			continue
+		}
+		subscriptionConfig.Env = append(subscriptionConfig.Env, env)
+	}
+
+	if len(proxy.TrustedCA) > 0 {
+		subscriptionConfig.Volumes = append(subscriptionConfig.Volumes, corev1.Volume{
+			Name: trustedCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: proxy.TrustedCA},
+					Items: []corev1.KeyToPath{
+						{Key: "ca-bundle.crt", Path: "tls-ca-bundle.pem"},
+					},
+				},
+			},
+		})
+		subscriptionConfig.VolumeMounts = append(subscriptionConfig.VolumeMounts, corev1.VolumeMount{
+			Name:      trustedCAVolumeName,
+			MountPath: trustedCAMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return subscriptionConfig
+}
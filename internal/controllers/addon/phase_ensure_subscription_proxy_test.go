@@ -0,0 +1,172 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestResolveProxyConfig(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Proxy       *addonsv1alpha1.AddonProxy
+		ClusterGet  bool
+		ClusterErr  error
+		Expected    *proxyConfig
+		ExpectedErr bool
+	}{
+		"no proxy inherits cluster": {
+			ClusterGet: true,
+			Expected: &proxyConfig{
+				HTTPProxy:  "http://cluster-proxy:3128",
+				HTTPSProxy: "http://cluster-proxy:3128",
+				NoProxy:    ".cluster.local",
+			},
+		},
+		"no proxy without cluster proxy": {
+			ClusterGet: true,
+			ClusterErr: testutil.NewTestErrNotFound(),
+		},
+		"explicit": {
+			Proxy: &addonsv1alpha1.AddonProxy{
+				HTTPProxy: "http://proxy:3128",
+				TrustedCA: &corev1.LocalObjectReference{Name: "proxy-ca"},
+			},
+			Expected: &proxyConfig{
+				HTTPProxy: "http://proxy:3128",
+				TrustedCA: "proxy-ca",
+			},
+		},
+		"inherit cluster": {
+			Proxy: &addonsv1alpha1.AddonProxy{
+				Mode:      addonsv1alpha1.AddonProxyModeInheritCluster,
+				HTTPProxy: "http://addon-proxy:3128",
+			},
+			ClusterGet: true,
+			Expected: &proxyConfig{
+				HTTPProxy:  "http://addon-proxy:3128",
+				HTTPSProxy: "http://cluster-proxy:3128",
+				NoProxy:    ".cluster.local",
+			},
+		},
+		"inherit without cluster proxy": {
+			Proxy: &addonsv1alpha1.AddonProxy{
+				Mode: addonsv1alpha1.AddonProxyModeInheritCluster,
+			},
+			ClusterGet: true,
+			ClusterErr: testutil.NewTestErrNotFound(),
+			Expected:   &proxyConfig{},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &olmReconciler{client: c}
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Proxy = tc.Proxy
+
+			if tc.ClusterGet {
+				c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+					mock.IsType(&configv1.Proxy{}), mock.Anything).
+					Run(func(args mock.Arguments) {
+						proxy := args.Get(2).(*configv1.Proxy)
+						proxy.Status = configv1.ProxyStatus{
+							HTTPProxy:  "http://cluster-proxy:3128",
+							HTTPSProxy: "http://cluster-proxy:3128",
+							NoProxy:    ".cluster.local",
+						}
+					}).
+					Return(tc.ClusterErr)
+			}
+
+			config, err := r.resolveProxyConfig(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, config)
+			c.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEnqueueClusterProxyAddons(t *testing.T) {
+	t.Parallel()
+
+	unset := testutil.NewTestAddonWithCatalogSourceImage()
+	unset.Name = "unset"
+	explicit := testutil.NewTestAddonWithCatalogSourceImage()
+	explicit.Name = "explicit"
+	explicit.Spec.Proxy = &addonsv1alpha1.AddonProxy{HTTPProxy: "http://proxy:3128"}
+	remoteWrite := testutil.NewTestAddonWithMonitoringStack()
+	remoteWrite.Name = "remote-write"
+	remoteWrite.Spec.Proxy = &addonsv1alpha1.AddonProxy{HTTPProxy: "http://proxy:3128"}
+
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*addonsv1alpha1.AddonList).Items = []addonsv1alpha1.Addon{
+				*unset, *explicit, *remoteWrite,
+			}
+		}).
+		Return(nil)
+	r := &AddonReconciler{Client: c}
+
+	reqs := r.enqueueClusterProxyAddons(&configv1.Proxy{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterProxyName},
+	})
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "unset"}},
+		{NamespacedName: types.NamespacedName{Name: "remote-write"}},
+	}, reqs)
+
+	assert.Empty(t, r.enqueueClusterProxyAddons(&configv1.Proxy{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+	}))
+}
+
+// Mocks the lookup of the cluster-wide Proxy,
+// as if the cluster is not using one.
+func mockNoClusterProxy(c *testutil.Client) {
+	c.On("Get", testutil.IsContext, testutil.IsObjectKey,
+		mock.IsType(&configv1.Proxy{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).Maybe()
+}
+
+func TestApplyProxyConfig(t *testing.T) {
+	t.Parallel()
+
+	subscriptionConfig := applyProxyConfig(&operatorsv1alpha1.SubscriptionConfig{
+		Env: []corev1.EnvVar{
+			{Name: "NO_PROXY", Value: "example.com"},
+		},
+	}, &proxyConfig{
+		HTTPProxy: "http://proxy:3128",
+		NoProxy:   ".cluster.local",
+		TrustedCA: "proxy-ca",
+	})
+
+	// Environment variables set in the install config take precedence.
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "NO_PROXY", Value: "example.com"},
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+	}, subscriptionConfig.Env)
+	require.Len(t, subscriptionConfig.Volumes, 1)
+	assert.Equal(t, "proxy-ca", subscriptionConfig.Volumes[0].ConfigMap.Name)
+	require.Len(t, subscriptionConfig.VolumeMounts, 1)
+	assert.Equal(t, trustedCAMountPath, subscriptionConfig.VolumeMounts[0].MountPath)
+
+	assert.Nil(t, applyProxyConfig(nil, nil))
+}
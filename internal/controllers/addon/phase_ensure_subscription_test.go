@@ -10,8 +10,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
 	"github.com/openshift/addon-operator/internal/testutil"
 )
 
@@ -19,19 +22,17 @@ func TestEnsureSubscription_Adoption(t *testing.T) {
 	subscription := testutil.NewTestSubscription()
 
 	c := testutil.NewClient()
+	mockNoClusterProxy(c)
 	c.On("Get",
 		testutil.IsContext,
 		testutil.IsObjectKey,
 		testutil.IsOperatorsV1Alpha1SubscriptionPtr,
 		mock.Anything,
-	).Run(func(args mock.Arguments) {
-		subscriptionWithoutOwner := testutil.NewTestSubscriptionWithoutOwner()
-		subscriptionWithoutOwner.DeepCopyInto(args.Get(2).(*operatorsv1alpha1.Subscription))
-	}).Return(nil)
-
-	c.On("Update",
+	).Return(testutil.NewTestErrNotFound())
+	c.On("Patch",
 		testutil.IsContext,
 		testutil.IsOperatorsV1Alpha1SubscriptionPtr,
+		client.Apply,
 		mock.Anything,
 	).Return(nil)
 
@@ -41,7 +42,7 @@ func TestEnsureSubscription_Adoption(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	reconciledSubscription, err := rec.reconcileSubscription(ctx, subscription.DeepCopy())
+	reconciledSubscription, _, err := rec.reconcileSubscription(ctx, subscription.DeepCopy())
 
 	assert.NoError(t, err)
 	assert.NotNil(t, reconciledSubscription)
@@ -53,6 +54,7 @@ func TestEnsureSubscription_Adoption(t *testing.T) {
 func TestCreateSubscriptionConfigObject(t *testing.T) {
 	testCases := []struct {
 		commonOLMInstallOptions    addonsv1alpha1.AddonInstallOLMCommon
+		placement                  *addonsv1alpha1.AddonPlacement
 		expectedSubscriptionConfig *operatorsv1alpha1.SubscriptionConfig
 	}{
 		{
@@ -109,11 +111,101 @@ func TestCreateSubscriptionConfigObject(t *testing.T) {
 				},
 			},
 		},
+		{
+			commonOLMInstallOptions: addonsv1alpha1.AddonInstallOLMCommon{
+				Config: &addonsv1alpha1.SubscriptionConfig{
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					},
+					Tolerations: []corev1.Toleration{
+						{
+							Key:      "node-role.kubernetes.io/infra",
+							Operator: corev1.TolerationOpExists,
+							Effect:   corev1.TaintEffectNoSchedule,
+						},
+					},
+					NodeSelector: map[string]string{
+						"node-role.kubernetes.io/infra": "",
+					},
+				},
+			},
+			expectedSubscriptionConfig: &operatorsv1alpha1.SubscriptionConfig{
+				Env: []corev1.EnvVar{},
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					},
+				},
+				NodeSelector: map[string]string{
+					"node-role.kubernetes.io/infra": "",
+				},
+			},
+		},
+		{
+			placement: &addonsv1alpha1.AddonPlacement{
+				NodeSelector: map[string]string{
+					"node-role.kubernetes.io/infra": "",
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{},
+				},
+			},
+			expectedSubscriptionConfig: &operatorsv1alpha1.SubscriptionConfig{
+				NodeSelector: map[string]string{
+					"node-role.kubernetes.io/infra": "",
+				},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{},
+				},
+			},
+		},
+		{
+			commonOLMInstallOptions: addonsv1alpha1.AddonInstallOLMCommon{
+				Config: &addonsv1alpha1.SubscriptionConfig{
+					NodeSelector: map[string]string{
+						"node-role.kubernetes.io/worker": "",
+					},
+				},
+			},
+			placement: &addonsv1alpha1.AddonPlacement{
+				NodeSelector: map[string]string{
+					"node-role.kubernetes.io/infra": "",
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+			},
+			expectedSubscriptionConfig: &operatorsv1alpha1.SubscriptionConfig{
+				Env: []corev1.EnvVar{},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "node-role.kubernetes.io/infra",
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+				// The install config takes precedence.
+				NodeSelector: map[string]string{
+					"node-role.kubernetes.io/worker": "",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run("create subscription config object test", func(t *testing.T) {
-			subscriptionConfig := createSubscriptionConfigObject(tc.commonOLMInstallOptions)
+			subscriptionConfig := createSubscriptionConfigObject(tc.commonOLMInstallOptions, tc.placement)
 			assert.Equal(t, tc.expectedSubscriptionConfig, subscriptionConfig)
 		})
 	}
@@ -173,3 +265,88 @@ func TestGetSubscriptionEnvObjects(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileSubscription_AppliesServerSide(t *testing.T) {
+	subscription := testutil.NewTestSubscription()
+	subscription.Annotations = map[string]string{
+		"example.com/scrape": "true",
+	}
+
+	c := testutil.NewClient()
+	mockNoClusterProxy(c)
+	c.On("Get",
+		testutil.IsContext,
+		testutil.IsObjectKey,
+		testutil.IsOperatorsV1Alpha1SubscriptionPtr,
+		mock.Anything,
+	).Return(testutil.NewTestErrNotFound())
+	var applied *operatorsv1alpha1.Subscription
+	c.On("Patch",
+		testutil.IsContext,
+		testutil.IsOperatorsV1Alpha1SubscriptionPtr,
+		client.Apply,
+		[]client.PatchOption{client.FieldOwner(controllers.FieldManager)},
+	).Run(func(args mock.Arguments) {
+		applied = args.Get(1).(*operatorsv1alpha1.Subscription).DeepCopy()
+		// Fields of other field managers are kept by the API server.
+		args.Get(1).(*operatorsv1alpha1.Subscription).Annotations["olm.example.com/managed"] = "true"
+	}).Return(nil)
+
+	rec := olmReconciler{
+		client: c,
+		scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+
+	reconciledSubscription, _, err := rec.reconcileSubscription(context.Background(), subscription.DeepCopy())
+
+	assert.NoError(t, err)
+	c.AssertExpectations(t)
+	// Only fields managed by the Addon are applied.
+	assert.Equal(t, map[string]string{"example.com/scrape": "true"}, applied.Annotations)
+	assert.Equal(t, "Subscription", applied.Kind)
+	assert.Equal(t, map[string]string{
+		"example.com/scrape":      "true",
+		"olm.example.com/managed": "true",
+	}, reconciledSubscription.Annotations)
+}
+
+func TestStartingCSV(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Version             string
+		InstallPlanApproval addonsv1alpha1.AddonInstallPlanApproval
+		Expected            string
+	}{
+		"manual approval": {
+			Version:             "0.2.0",
+			InstallPlanApproval: addonsv1alpha1.InstallPlanApprovalManual,
+			Expected:            "reference-addon.v0.2.0",
+		},
+		"prefixed version": {
+			Version:             "v0.2.0",
+			InstallPlanApproval: addonsv1alpha1.InstallPlanApprovalManual,
+			Expected:            "reference-addon.v0.2.0",
+		},
+		"automatic approval": {
+			Version:             "0.2.0",
+			InstallPlanApproval: addonsv1alpha1.InstallPlanApprovalAutomatic,
+		},
+		"unmanaged approval": {
+			Version: "0.2.0",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Version = tc.Version
+			assert.Equal(t, tc.Expected, startingCSV(addon, addonsv1alpha1.AddonInstallOLMCommon{
+				PackageName:         "reference-addon",
+				InstallPlanApproval: tc.InstallPlanApproval,
+			}))
+		})
+	}
+}
@@ -43,6 +43,11 @@ func (r *olmReconciler) observeOperatorResource(
 	}
 
 	addonCSVRef := findConcernedCSVReference(csvKey, operator)
+	if addonCSVRef != nil {
+		if err := r.reportOLMStatus(ctx, addon, csvKey); err != nil {
+			return resultNil, err
+		}
+	}
 
 	// Handle installed Condition.
 	if res, err := r.handleInstalledCondition(ctx, addon, addonCSVRef); err != nil {
@@ -65,9 +70,12 @@ func (r *olmReconciler) observeOperatorResource(
 	var message string
 	switch phase {
 	case operatorsv1alpha1.CSVPhaseSucceeded:
-		// do nothing here
+		addon.Status.Recovery = nil
 	case operatorsv1alpha1.CSVPhaseFailed:
-		message = "failed"
+		if err := r.recoverFailedCSV(ctx, addon, csvKey); err != nil {
+			return resultNil, fmt.Errorf("recovering failed CSV: %w", err)
+		}
+		message = failedCSVMessage(addon)
 	default:
 		message = "unkown/pending"
 	}
@@ -129,3 +137,45 @@ func addonUpgradeConcluded(addon *addonsv1alpha1.Addon, currentCSV client.Object
 	}
 	return false
 }
+
+// Reports the version of the last observed available CSV.
+func (r *olmReconciler) reportInstalledVersion(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) error {
+	csv, err := r.getInstalledCSV(ctx, addon)
+	if err != nil {
+		return err
+	}
+	if csv == nil {
+		return nil
+	}
+	addon.Status.InstalledVersion = csv.Spec.Version.String()
+	return nil
+}
+
+// Reports the state of the current CSV,
+// so it can be inspected without access to the CSV itself.
+func (r *olmReconciler) reportOLMStatus(
+	ctx context.Context, addon *addonsv1alpha1.Addon, csvKey client.ObjectKey,
+) error {
+	csv := &operatorsv1alpha1.ClusterServiceVersion{}
+	if err := r.uncachedClient.Get(ctx, csvKey, csv); err != nil {
+		if k8serrors.IsNotFound(err) {
+			addon.Status.OLM = nil
+			return nil
+		}
+		return fmt.Errorf("getting current CSV: %w", err)
+	}
+
+	message := csv.Status.Message
+	if n := len(csv.Status.Conditions); n > 0 {
+		message = csv.Status.Conditions[n-1].Message
+	}
+	addon.Status.OLM = &addonsv1alpha1.AddonOLMStatus{
+		InstalledCSV: csv.Name,
+		Version:      csv.Spec.Version.String(),
+		Phase:        string(csv.Status.Phase),
+		Message:      message,
+	}
+	return nil
+}
@@ -17,7 +17,9 @@ import (
 
 	"github.com/openshift/addon-operator/internal/testutil"
 
+	"github.com/blang/semver/v4"
 	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
@@ -186,6 +188,18 @@ func TestObserveOperatorResource(t *testing.T) {
 
 			call.Return(nil)
 
+			// Only looked up once the CSV is referenced by the Operator.
+			c.On("Get",
+				mock.Anything,
+				mock.IsType(client.ObjectKey{}),
+				mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}),
+				mock.Anything,
+			).Run(func(args mock.Arguments) {
+				csv := args.Get(2).(*operatorsv1alpha1.ClusterServiceVersion)
+				csv.Name = referenceAddonCSVName
+				csv.Status.Phase = operatorsv1alpha1.CSVPhaseSucceeded
+			}).Return(nil).Maybe()
+
 			operatorResourceHandler := internalhandler.NewOperatorResourceHandler()
 			csvKey := client.ObjectKey{
 				Namespace: referenceAddonNamespace,
@@ -330,3 +344,60 @@ type nonTransientCondition struct {
 	Reason  string
 	Message string
 }
+
+func TestReportInstalledVersion(t *testing.T) {
+	uncachedClient := testutil.NewClient()
+	r := &olmReconciler{uncachedClient: uncachedClient}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Status.LastObservedAvailableCSV = "addon-1/reference-addon.v0.2.0"
+
+	uncachedClient.On("Get", testutil.IsContext, client.ObjectKey{
+		Name:      "reference-addon.v0.2.0",
+		Namespace: "addon-1",
+	}, mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			csv := args.Get(2).(*operatorsv1alpha1.ClusterServiceVersion)
+			csv.Spec.Version.Version = semver.MustParse("0.2.0")
+		}).
+		Return(nil)
+
+	require.NoError(t, r.reportInstalledVersion(context.Background(), addon))
+	assert.Equal(t, "0.2.0", addon.Status.InstalledVersion)
+	uncachedClient.AssertExpectations(t)
+}
+
+func TestReportOLMStatus(t *testing.T) {
+	uncachedClient := testutil.NewClient()
+	r := &olmReconciler{uncachedClient: uncachedClient}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	csvKey := client.ObjectKey{
+		Name:      "reference-addon.v0.2.0",
+		Namespace: "addon-1",
+	}
+
+	uncachedClient.On("Get", testutil.IsContext, csvKey,
+		mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			csv := args.Get(2).(*operatorsv1alpha1.ClusterServiceVersion)
+			csv.Name = csvKey.Name
+			csv.Spec.Version.Version = semver.MustParse("0.2.0")
+			csv.Status.Phase = operatorsv1alpha1.CSVPhaseInstalling
+			csv.Status.Message = "waiting for install components to report healthy"
+			csv.Status.Conditions = []operatorsv1alpha1.ClusterServiceVersionCondition{
+				{Phase: operatorsv1alpha1.CSVPhasePending, Message: "requirements not yet checked"},
+				{Phase: operatorsv1alpha1.CSVPhaseInstalling, Message: "installing: waiting for deployment"},
+			}
+		}).
+		Return(nil)
+
+	require.NoError(t, r.reportOLMStatus(context.Background(), addon, csvKey))
+	uncachedClient.AssertExpectations(t)
+	assert.Equal(t, &addonsv1alpha1.AddonOLMStatus{
+		InstalledCSV: "reference-addon.v0.2.0",
+		Version:      "0.2.0",
+		Phase:        "Installing",
+		Message:      "installing: waiting for deployment",
+	}, addon.Status.OLM)
+}
@@ -0,0 +1,33 @@
+package addon
+
+import (
+	"time"
+
+	"github.com/openshift/addon-operator/internal/metrics"
+)
+
+// Records the duration and failure of consecutive phases of a sub-reconciler.
+// The recorder is optional.
+type phaseTimer struct {
+	recorder *metrics.Recorder
+
+	phase string
+	start time.Time
+}
+
+// Starts timing the given phase, completing the previous phase successfully.
+func (t *phaseTimer) begin(phase string) {
+	t.end(nil)
+	t.phase, t.start = phase, time.Now()
+}
+
+// Completes the current phase, which failed if err is not nil.
+func (t *phaseTimer) end(err error) {
+	if t.phase == "" {
+		return
+	}
+	if t.recorder != nil {
+		t.recorder.RecordAddonPhaseResult(t.phase, time.Since(t.start), err)
+	}
+	t.phase = ""
+}
@@ -0,0 +1,198 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/catalogregistry"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Label set by the OLM packageserver on PackageManifests,
+// referencing the CatalogSource they are served from.
+const packageManifestCatalogLabel = "catalog"
+
+var packageManifestListGVK = schema.GroupVersionKind{
+	Group:   "packages.operators.coreos.com",
+	Version: "v1",
+	Kind:    "PackageManifestList",
+}
+
+// Subset of the PackageManifest status served by the OLM packageserver.
+type packageManifestStatus struct {
+	Channels []packageChannel `json:"channels"`
+}
+
+type packageChannel struct {
+	Name       string `json:"name"`
+	CurrentCSV string `json:"currentCSV"`
+}
+
+// Ensures that a new catalog image contains an upgrade edge for the installed CSV
+// before the Addon's CatalogSource is switched to it.
+// The new image is served by a candidate CatalogSource until it has been validated,
+// otherwise OLM would strand the Subscription in ResolutionFailed.
+func (r *olmReconciler) validateCatalogUpgrade(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	desiredCatalogSource *operatorsv1alpha1.CatalogSource,
+	packageName, channel string,
+) (requeueResult, error) {
+	// Reported again below, while the new image has no valid upgrade path.
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.UpgradePathInvalid)
+
+	if len(addon.Status.LastObservedAvailableCSV) == 0 {
+		// Nothing installed yet that could be stranded.
+		return resultNil, nil
+	}
+
+	candidateCatalogSource := desiredCatalogSource.DeepCopy()
+	candidateCatalogSource.Name = candidateCatalogSourceName(desiredCatalogSource.Name)
+
+	currentCatalogSource := &operatorsv1alpha1.CatalogSource{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredCatalogSource), currentCatalogSource); err != nil {
+		if k8sApiErrors.IsNotFound(err) {
+			return resultNil, r.deleteCandidateCatalogSource(ctx, candidateCatalogSource)
+		}
+		return resultNil, fmt.Errorf("getting CatalogSource: %w", err)
+	}
+
+	if currentCatalogSource.Spec.Image == desiredCatalogSource.Spec.Image {
+		return resultNil, r.deleteCandidateCatalogSource(ctx, candidateCatalogSource)
+	}
+
+	installedCSV, err := r.getInstalledCSV(ctx, addon)
+	if err != nil {
+		return resultNil, err
+	}
+	if installedCSV == nil {
+		return resultNil, r.deleteCandidateCatalogSource(ctx, candidateCatalogSource)
+	}
+
+	observedCandidate, _, err := reconcileCatalogSource(ctx, r.client, candidateCatalogSource)
+	if err != nil {
+		return resultNil, fmt.Errorf("reconciling candidate CatalogSource: %w", err)
+	}
+	if observedCandidate.Status.GRPCConnectionState == nil ||
+		observedCandidate.Status.GRPCConnectionState.LastObservedState != "READY" {
+		reportCatalogSourceUnreadinessStatus(addon, "validating upgrade edges of the new catalog image")
+		return resultRetry, nil
+	}
+
+	address := catalogRegistryAddress(observedCandidate)
+	pkg, err := r.catalogRegistry.GetPackage(ctx, address, packageName)
+	if err != nil && !errors.Is(err, catalogregistry.ErrNotFound) {
+		return resultNil, fmt.Errorf("querying registry of candidate CatalogSource: %w", err)
+	}
+	packageChannel, found := pkg.Channel(channel)
+	if !found {
+		reportUpgradePathInvalid(addon, addonsv1alpha1.AddonReasonMissingUpgradeEdge, fmt.Sprintf(
+			"channel %q of package %q not found in catalog %s",
+			channel, packageName, desiredCatalogSource.Spec.Image))
+		return resultRetry, nil
+	}
+
+	plan, err := planUpgrade(ctx, r.catalogRegistry, address, packageName,
+		packageChannel, installedCSV.Name, installedCSV.Spec.Version.Version)
+	if err != nil {
+		return resultNil, fmt.Errorf("querying registry of candidate CatalogSource: %w", err)
+	}
+	if !plan.valid() {
+		reportUpgradePathInvalid(addon, addonsv1alpha1.AddonReasonVersionsSkipped, fmt.Sprintf(
+			"%s in catalog %s", plan.invalid, desiredCatalogSource.Spec.Image))
+		return resultRetry, nil
+	}
+	controllers.LoggerFromContext(ctx).Info("validated upgrade path of new catalog image",
+		"installedCSV", installedCSV.Name, "steps", plan.steps)
+
+	return resultNil, r.deleteCandidateCatalogSource(ctx, candidateCatalogSource)
+}
+
+// Returns the last CSV that was observed available for this Addon or nil.
+func (r *olmReconciler) getInstalledCSV(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	namespace, name, found := strings.Cut(addon.Status.LastObservedAvailableCSV, "/")
+	if !found {
+		return nil, nil
+	}
+
+	csv := &operatorsv1alpha1.ClusterServiceVersion{}
+	if err := r.uncachedClient.Get(ctx, client.ObjectKey{
+		Name:      name,
+		Namespace: namespace,
+	}, csv); err != nil {
+		if k8sApiErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting installed CSV: %w", err)
+	}
+	return csv, nil
+}
+
+// Looks up the given channel of a package served by the given CatalogSource.
+// Returns nil if the package or channel is not part of the catalog.
+func (r *olmReconciler) getPackageChannel(
+	ctx context.Context, catalogSource *operatorsv1alpha1.CatalogSource,
+	packageName, channel string,
+) (*packageChannel, error) {
+	packageManifests := &unstructured.UnstructuredList{}
+	packageManifests.SetGroupVersionKind(packageManifestListGVK)
+	if err := r.uncachedClient.List(ctx, packageManifests,
+		client.InNamespace(catalogSource.Namespace),
+		client.MatchingLabels{packageManifestCatalogLabel: catalogSource.Name},
+	); err != nil {
+		return nil, fmt.Errorf("listing PackageManifests: %w", err)
+	}
+
+	for _, packageManifest := range packageManifests.Items {
+		if packageManifest.GetName() != packageName {
+			continue
+		}
+
+		rawStatus, _, err := unstructured.NestedMap(packageManifest.Object, "status")
+		if err != nil {
+			return nil, fmt.Errorf("reading PackageManifest status: %w", err)
+		}
+		status := packageManifestStatus{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawStatus, &status); err != nil {
+			return nil, fmt.Errorf("converting PackageManifest status: %w", err)
+		}
+
+		for i := range status.Channels {
+			if status.Channels[i].Name == channel {
+				return &status.Channels[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *olmReconciler) deleteCandidateCatalogSource(
+	ctx context.Context, candidateCatalogSource *operatorsv1alpha1.CatalogSource,
+) error {
+	// check the cache first to not issue a delete request on every reconcile
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(candidateCatalogSource),
+		&operatorsv1alpha1.CatalogSource{}); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if err := r.client.Delete(ctx, candidateCatalogSource); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("deleting candidate CatalogSource: %w", err)
+	}
+	return nil
+}
+
+func candidateCatalogSourceName(catalogSourceName string) string {
+	return fmt.Sprintf("%s-candidate", catalogSourceName)
+}
@@ -0,0 +1,110 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/catalogregistry"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestValidateCatalogUpgrade_NothingInstalled(t *testing.T) {
+	c := testutil.NewClient()
+	r := &olmReconciler{client: c}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	requeueResult, err := r.validateCatalogUpgrade(context.Background(), addon,
+		testutil.NewTestCatalogSource(), "reference-addon", "alpha")
+	require.NoError(t, err)
+	assert.Equal(t, resultNil, requeueResult)
+	c.AssertExpectations(t)
+}
+
+func TestValidateCatalogUpgrade_MissingUpgradeEdge(t *testing.T) {
+	c := testutil.NewClient()
+	uncachedClient := testutil.NewClient()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Status.LastObservedAvailableCSV = "addon-1/reference-addon.v0.0.5"
+
+	desired := testutil.NewTestCatalogSource()
+	desired.Spec.Image = "quay.io/osd-addons/reference-addon-index:new"
+
+	c.On("Get", testutil.IsContext, client.ObjectKeyFromObject(desired),
+		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			current := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+			current.Spec.Image = "quay.io/osd-addons/reference-addon-index:old"
+		}).
+		Return(nil)
+	c.On("Get", testutil.IsContext, client.ObjectKey{
+		Name:      candidateCatalogSourceName(desired.Name),
+		Namespace: desired.Namespace,
+	}, testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+			candidate.Spec = desired.Spec
+		}).
+		Return(nil)
+	c.On("Patch", testutil.IsContext,
+		testutil.IsOperatorsV1Alpha1CatalogSourcePtr, client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			candidate := args.Get(1).(*operatorsv1alpha1.CatalogSource)
+			candidate.Status.GRPCConnectionState = &operatorsv1alpha1.GRPCConnectionState{
+				LastObservedState: "READY",
+			}
+		}).
+		Return(nil)
+
+	uncachedClient.On("Get", testutil.IsContext, mock.Anything,
+		mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			csv := args.Get(2).(*operatorsv1alpha1.ClusterServiceVersion)
+			csv.Name = "reference-addon.v0.0.5"
+			csv.Spec.Version.Version = semver.MustParse("0.0.5")
+		}).
+		Return(nil)
+	r := &olmReconciler{
+		client:         c,
+		uncachedClient: uncachedClient,
+		catalogRegistry: &fakeCatalogRegistry{
+			packages: []catalogregistry.Package{
+				{
+					Name: "reference-addon",
+					Channels: []catalogregistry.Channel{
+						{Name: "alpha", CSVName: "reference-addon.v0.3.0"},
+					},
+				},
+			},
+			bundles: []catalogregistry.Bundle{
+				newTestBundle("reference-addon.v0.3.0", "", ""),
+			},
+		},
+	}
+
+	ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	requeueResult, err := r.validateCatalogUpgrade(ctx, addon, desired, "reference-addon", "alpha")
+	require.NoError(t, err)
+	assert.Equal(t, resultRetry, requeueResult)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	require.NotNil(t, cond)
+	assert.Equal(t, addonsv1alpha1.AddonReasonVersionsSkipped, cond.Reason)
+	cond = meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradePathInvalid)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonVersionsSkipped, cond.Reason)
+	c.AssertExpectations(t)
+	uncachedClient.AssertExpectations(t)
+}
@@ -0,0 +1,448 @@
+package addon
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const READINESS_PROBE_RECONCILER_NAME = "readinessProbeReconciler"
+
+// Maximum duration of a single readiness probe.
+const readinessProbeTimeout = 10 * time.Second
+
+// Interval between two runs of the ReadinessProbes of an Addon.
+const readinessProbeInterval = 30 * time.Second
+
+// ClusterRole allowing to exec into Pods, bound only in namespaces with exec probes.
+// The Addon Operator is allowed to bind, but not granted this ClusterRole cluster-wide.
+const readinessProbeExecClusterRole = "addon-operator-readiness-probes"
+
+// Runs the ReadinessProbes of an Addon periodically in the background,
+// reports their latest outcome in the ReadinessProbesReady condition
+// and keeps the Addon unavailable until all of them succeed.
+type readinessProbeReconciler struct {
+	// Client used for RoleBindings, so they are not cached cluster-wide.
+	client client.Client
+	scheme *runtime.Scheme
+	// ServiceAccount of the Addon Operator, granted to exec into Pods.
+	serviceAccount client.ObjectKey
+	prober         readinessProber
+	results        *readinessProbeResults
+}
+
+type readinessProber interface {
+	ProbeHTTPGet(ctx context.Context, namespace string, probe *addonsv1alpha1.AddonReadinessProbeHTTPGet) error
+	ProbeExec(ctx context.Context, namespace string, probe *addonsv1alpha1.AddonReadinessProbeExec) error
+}
+
+func (r *readinessProbeReconciler) Name() string {
+	return READINESS_PROBE_RECONCILER_NAME
+}
+
+func (r *readinessProbeReconciler) Order() subReconcilerOrder {
+	return readinessProbeReconcilerOrder
+}
+
+// Probes are run in the background,
+// so their requeue does not hold back any other sub-reconciler.
+func (r *readinessProbeReconciler) Independent() bool {
+	return true
+}
+
+func (r *readinessProbeReconciler) Reconcile(ctx context.Context,
+	addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	key := client.ObjectKeyFromObject(addon)
+	if len(addon.Spec.ReadinessProbes) == 0 {
+		if r.results.forget(key) {
+			if err := r.reconcileExecRoleBindings(ctx, addon); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.ReadinessProbesReady)
+		return ctrl.Result{}, nil
+	}
+
+	result, ok := r.results.get(key, addon.Generation)
+	if r.results.startRun(key, addon.Generation, time.Now(), readinessProbeInterval) {
+		// Exec probes can only run, once the Addon Operator is allowed to exec into Pods.
+		if err := r.reconcileExecRoleBindings(ctx, addon); err != nil {
+			r.results.finishRun(key, addon.Generation, []string{err.Error()})
+			return ctrl.Result{}, err
+		}
+		probes := append([]addonsv1alpha1.AddonReadinessProbe(nil), addon.Spec.ReadinessProbes...)
+		go r.run(ctx, key, addon.Generation, addon.DeepCopy(), probes)
+	}
+
+	switch {
+	case !ok:
+		reportReadinessProbesCondition(addon, metav1.ConditionUnknown,
+			addonsv1alpha1.AddonReasonPendingReadinessProbes, "ReadinessProbes did not complete yet.")
+		reportUnreadyReadinessProbes(addon, addonsv1alpha1.AddonReasonPendingReadinessProbes,
+			"ReadinessProbes did not complete yet.")
+	case len(result.failed) > 0:
+		message := fmt.Sprintf("ReadinessProbes are failing: %s", strings.Join(result.failed, ", "))
+		reportReadinessProbesCondition(addon, metav1.ConditionFalse,
+			addonsv1alpha1.AddonReasonUnreadyReadinessProbes, message)
+		reportUnreadyReadinessProbes(addon, addonsv1alpha1.AddonReasonUnreadyReadinessProbes, message)
+	default:
+		reportReadinessProbesCondition(addon, metav1.ConditionTrue,
+			addonsv1alpha1.AddonReasonReadyReadinessProbes, "All ReadinessProbes succeed.")
+	}
+	return ctrl.Result{RequeueAfter: readinessProbeInterval}, nil
+}
+
+// Binds the exec ClusterRole to the Addon Operator in every Addon namespace
+// with exec probes and removes the RoleBindings from all other Addon namespaces.
+func (r *readinessProbeReconciler) reconcileExecRoleBindings(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	execNamespaces := map[string]struct{}{}
+	for _, probe := range addon.Spec.ReadinessProbes {
+		if probe.Exec != nil {
+			execNamespaces[readinessProbeNamespace(addon, probe)] = struct{}{}
+		}
+	}
+
+	for _, namespace := range addonNamespaceNames(addon) {
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("addon-%s-readiness-probes", addon.Name),
+				Namespace: namespace,
+			},
+		}
+		if _, ok := execNamespaces[namespace]; !ok {
+			if err := r.client.Delete(ctx, roleBinding); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("deleting unwanted RoleBinding: %w", err)
+			}
+			continue
+		}
+
+		roleBinding.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     readinessProbeExecClusterRole,
+		}
+		roleBinding.Subjects = []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      r.serviceAccount.Name,
+			Namespace: r.serviceAccount.Namespace,
+		}}
+		controllers.AddCommonLabels(roleBinding, addon)
+		controllers.AddCommonAnnotations(roleBinding, addon)
+		if err := controllerutil.SetControllerReference(addon, roleBinding, r.scheme); err != nil {
+			return fmt.Errorf("setting controller reference: %w", err)
+		}
+		if err := controllers.Apply(ctx, r.client, roleBinding); err != nil {
+			return fmt.Errorf("applying RoleBinding: %w", err)
+		}
+	}
+	return nil
+}
+
+// Runs all probes of an Addon and stores their outcome.
+func (r *readinessProbeReconciler) run(ctx context.Context, key client.ObjectKey,
+	generation int64, addon *addonsv1alpha1.Addon, probes []addonsv1alpha1.AddonReadinessProbe) {
+	var failed []string
+	for _, probe := range probes {
+		if err := r.probe(ctx, addon, probe); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", probe.Name, err))
+		}
+	}
+	r.results.finishRun(key, generation, failed)
+}
+
+func (r *readinessProbeReconciler) probe(ctx context.Context,
+	addon *addonsv1alpha1.Addon, probe addonsv1alpha1.AddonReadinessProbe) error {
+	namespace := readinessProbeNamespace(addon, probe)
+	if !isAddonNamespace(addon, namespace) {
+		return fmt.Errorf("namespace %q does not belong to the Addon", namespace)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessProbeTimeout)
+	defer cancel()
+
+	switch {
+	case probe.HTTPGet != nil:
+		return r.prober.ProbeHTTPGet(ctx, namespace, probe.HTTPGet)
+	case probe.Exec != nil:
+		return r.prober.ProbeExec(ctx, namespace, probe.Exec)
+	default:
+		return fmt.Errorf("neither httpGet nor exec configured")
+	}
+}
+
+// Namespace the probe runs in, defaulting to the Addon install namespace.
+func readinessProbeNamespace(addon *addonsv1alpha1.Addon, probe addonsv1alpha1.AddonReadinessProbe) string {
+	if len(probe.Namespace) > 0 {
+		return probe.Namespace
+	}
+	return GetCommonInstallOptions(addon).Namespace
+}
+
+func isAddonNamespace(addon *addonsv1alpha1.Addon, namespace string) bool {
+	for _, ns := range addonNamespaceNames(addon) {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// Install namespace and namespaces of the Addon.
+func addonNamespaceNames(addon *addonsv1alpha1.Addon) []string {
+	installNamespace := GetCommonInstallOptions(addon).Namespace
+	names := []string{installNamespace}
+	for _, ns := range addon.Spec.Namespaces {
+		if ns.Name != installNamespace {
+			names = append(names, ns.Name)
+		}
+	}
+	return names
+}
+
+// Keeps the Addon unavailable, once its ClusterServiceVersion succeeded,
+// while its ReadinessProbes are failing or did not complete yet.
+// Addons unavailable for other reasons keep reporting these.
+func reportUnreadyReadinessProbes(addon *addonsv1alpha1.Addon, reason, message string) {
+	if !meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Available) {
+		return
+	}
+	reportPendingStatus(addon, reason, message)
+}
+
+func reportReadinessProbesCondition(addon *addonsv1alpha1.Addon,
+	status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.ReadinessProbesReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+// Latest outcome of the ReadinessProbes per Addon.
+type readinessProbeResults struct {
+	mux     sync.Mutex
+	results map[client.ObjectKey]readinessProbeResult
+}
+
+type readinessProbeResult struct {
+	// Generation of the Addon the probes were run for.
+	generation int64
+	// Start of the latest run.
+	startedAt time.Time
+	running   bool
+	// Whether a run completed for the generation.
+	completed bool
+	failed    []string
+}
+
+func newReadinessProbeResults() *readinessProbeResults {
+	return &readinessProbeResults{
+		results: map[client.ObjectKey]readinessProbeResult{},
+	}
+}
+
+// Returns the outcome of the latest run completed for the given generation.
+func (r *readinessProbeResults) get(key client.ObjectKey, generation int64) (readinessProbeResult, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	result, ok := r.results[key]
+	if !ok || !result.completed || result.generation != generation {
+		return readinessProbeResult{}, false
+	}
+	return result, true
+}
+
+// Marks a run as started and returns true, unless a run of the same generation
+// is in progress or started within the interval.
+func (r *readinessProbeResults) startRun(key client.ObjectKey,
+	generation int64, now time.Time, interval time.Duration) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	result, ok := r.results[key]
+	if ok && result.generation == generation &&
+		(result.running || now.Sub(result.startedAt) < interval) {
+		return false
+	}
+	if result.generation != generation {
+		result = readinessProbeResult{generation: generation}
+	}
+	result.startedAt = now
+	result.running = true
+	r.results[key] = result
+	return true
+}
+
+func (r *readinessProbeResults) finishRun(key client.ObjectKey, generation int64, failed []string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	result, ok := r.results[key]
+	if !ok || result.generation != generation {
+		// Forgotten or superseded in the meantime.
+		return
+	}
+	result.running = false
+	result.completed = true
+	result.failed = failed
+	r.results[key] = result
+}
+
+// Drops the results of an Addon.
+// Returns true, if the Addon had results.
+func (r *readinessProbeResults) forget(key client.ObjectKey) bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	_, ok := r.results[key]
+	delete(r.results, key)
+	return ok
+}
+
+// defaultReadinessProber probes HTTP endpoints from within the Addon Operator
+// and executes commands via the pods/exec subresource.
+type defaultReadinessProber struct {
+	// Client used to look up Pods, so they are not cached cluster-wide.
+	client     client.Client
+	clientset  kubernetes.Interface
+	restConfig *rest.Config
+	httpClient *http.Client
+}
+
+func newDefaultReadinessProber(c client.Client,
+	clientset kubernetes.Interface, restConfig *rest.Config) *defaultReadinessProber {
+	return &defaultReadinessProber{
+		client:     c,
+		clientset:  clientset,
+		restConfig: restConfig,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // same as kubelet probes
+			},
+			// Redirects are not followed, same as for kubelet probes.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (p *defaultReadinessProber) ProbeHTTPGet(ctx context.Context,
+	namespace string, probe *addonsv1alpha1.AddonReadinessProbeHTTPGet) error {
+	uriScheme := strings.ToLower(string(corev1.URISchemeHTTP))
+	if len(probe.Scheme) > 0 {
+		uriScheme = strings.ToLower(string(probe.Scheme))
+	}
+	path := probe.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	url := fmt.Sprintf("%s://%s.%s.svc:%d%s", uriScheme, probe.Service, namespace, probe.Port, path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("requesting %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	// Any code in the 2xx-3xx range is considered a success, same as for kubelet probes.
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s returned status %d", url, res.StatusCode)
+	}
+	return nil
+}
+
+func (p *defaultReadinessProber) ProbeExec(ctx context.Context,
+	namespace string, probe *addonsv1alpha1.AddonReadinessProbeExec) error {
+	selector, err := metav1.LabelSelectorAsSelector(&probe.PodSelector)
+	if err != nil {
+		return fmt.Errorf("parsing podSelector: %w", err)
+	}
+
+	podList := &corev1.PodList{}
+	if err := p.client.List(ctx, podList,
+		client.InNamespace(namespace),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return fmt.Errorf("listing pods: %w", err)
+	}
+
+	pod := firstRunningPod(podList.Items)
+	if pod == nil {
+		return fmt.Errorf("no running pod matches podSelector")
+	}
+
+	container := probe.Container
+	if len(container) == 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	req := p.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(pod.Namespace).
+		Name(pod.Name).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   probe.Command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(p.restConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return fmt.Errorf("creating executor: %w", err)
+	}
+
+	var output bytes.Buffer
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &output,
+		Stderr: &output,
+	}); err != nil {
+		return fmt.Errorf("executing command in pod %s: %w: %s",
+			pod.Name, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+func firstRunningPod(pods []corev1.Pod) *corev1.Pod {
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning &&
+			pods[i].DeletionTimestamp.IsZero() {
+			return &pods[i]
+		}
+	}
+	return nil
+}
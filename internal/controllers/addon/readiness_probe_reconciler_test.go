@@ -0,0 +1,322 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type readinessProberMock struct {
+	mock.Mock
+}
+
+func (m *readinessProberMock) ProbeHTTPGet(ctx context.Context,
+	namespace string, probe *addonsv1alpha1.AddonReadinessProbeHTTPGet) error {
+	args := m.Called(ctx, namespace, probe)
+	return args.Error(0)
+}
+
+func (m *readinessProberMock) ProbeExec(ctx context.Context,
+	namespace string, probe *addonsv1alpha1.AddonReadinessProbeExec) error {
+	args := m.Called(ctx, namespace, probe)
+	return args.Error(0)
+}
+
+// Reports the ClusterServiceVersion of the Addon as succeeded.
+func reportAddonCSVSucceeded(addon *addonsv1alpha1.Addon) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.Available,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonFullyReconciled,
+	})
+}
+
+func TestReadinessProbeReconciler_NoProbes(t *testing.T) {
+	prober := &readinessProberMock{}
+	r := &readinessProbeReconciler{
+		client:  testutil.NewClient(),
+		prober:  prober,
+		results: newReadinessProbeResults(),
+	}
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.ReadinessProbesReady,
+		Status: metav1.ConditionFalse,
+		Reason: addonsv1alpha1.AddonReasonUnreadyReadinessProbes,
+	})
+	res, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+	assert.True(t, res.IsZero())
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.ReadinessProbesReady))
+	prober.AssertExpectations(t)
+}
+
+func TestReadinessProbeReconciler(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Probe          addonsv1alpha1.AddonReadinessProbe
+		ProbeErr       error
+		ExpectedNS     string
+		ExpectedProbed bool
+		ExpectedStatus metav1.ConditionStatus
+		ExpectedReason string
+	}{
+		"http probe succeeds": {
+			Probe: addonsv1alpha1.AddonReadinessProbe{
+				Name:    "api",
+				HTTPGet: &addonsv1alpha1.AddonReadinessProbeHTTPGet{Service: "api", Port: 8080},
+			},
+			ExpectedNS:     "addon-1",
+			ExpectedProbed: true,
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: addonsv1alpha1.AddonReasonReadyReadinessProbes,
+		},
+		"http probe fails": {
+			Probe: addonsv1alpha1.AddonReadinessProbe{
+				Name:    "api",
+				HTTPGet: &addonsv1alpha1.AddonReadinessProbeHTTPGet{Service: "api", Port: 8080},
+			},
+			ProbeErr:       fmt.Errorf("returned status 503"),
+			ExpectedNS:     "addon-1",
+			ExpectedProbed: true,
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: addonsv1alpha1.AddonReasonUnreadyReadinessProbes,
+		},
+		"exec probe succeeds": {
+			Probe: addonsv1alpha1.AddonReadinessProbe{
+				Name: "db",
+				Exec: &addonsv1alpha1.AddonReadinessProbeExec{Command: []string{"true"}},
+			},
+			ExpectedNS:     "addon-1",
+			ExpectedProbed: true,
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: addonsv1alpha1.AddonReasonReadyReadinessProbes,
+		},
+		"exec probe fails": {
+			Probe: addonsv1alpha1.AddonReadinessProbe{
+				Name: "db",
+				Exec: &addonsv1alpha1.AddonReadinessProbeExec{Command: []string{"true"}},
+			},
+			ProbeErr:       fmt.Errorf("exit code 1"),
+			ExpectedNS:     "addon-1",
+			ExpectedProbed: true,
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: addonsv1alpha1.AddonReasonUnreadyReadinessProbes,
+		},
+		"foreign namespace": {
+			Probe: addonsv1alpha1.AddonReadinessProbe{
+				Name:      "api",
+				Namespace: "kube-system",
+				HTTPGet:   &addonsv1alpha1.AddonReadinessProbeHTTPGet{Service: "api", Port: 8080},
+			},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: addonsv1alpha1.AddonReasonUnreadyReadinessProbes,
+		},
+		"no probe configured": {
+			Probe: addonsv1alpha1.AddonReadinessProbe{
+				Name: "empty",
+			},
+			ExpectedStatus: metav1.ConditionFalse,
+			ExpectedReason: addonsv1alpha1.AddonReasonUnreadyReadinessProbes,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			prober := &readinessProberMock{}
+			if tc.ExpectedProbed {
+				method := "ProbeHTTPGet"
+				if tc.Probe.Exec != nil {
+					method = "ProbeExec"
+				}
+				prober.On(method, mock.Anything, tc.ExpectedNS, mock.Anything).
+					Return(tc.ProbeErr).Once()
+			}
+
+			c := testutil.NewClient()
+			c.On("Patch", testutil.IsContext, mock.IsType(&rbacv1.RoleBinding{}),
+				mock.Anything, mock.Anything).Return(nil).Maybe()
+			c.On("Delete", testutil.IsContext, mock.IsType(&rbacv1.RoleBinding{}),
+				mock.Anything).Return(nil).Maybe()
+
+			results := newReadinessProbeResults()
+			r := &readinessProbeReconciler{
+				client:  c,
+				scheme:  testutil.NewTestScheme(),
+				prober:  prober,
+				results: results,
+			}
+
+			addon := testutil.NewTestAddonWithMonitoringStack()
+			addon.Spec.ReadinessProbes = []addonsv1alpha1.AddonReadinessProbe{tc.Probe}
+			reportAddonCSVSucceeded(addon)
+
+			// The first reconcile starts the probes in the background.
+			res, err := r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			assert.Equal(t, readinessProbeInterval, res.RequeueAfter)
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.ReadinessProbesReady)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionUnknown, cond.Status)
+			assert.Equal(t, addonsv1alpha1.AddonReasonPendingReadinessProbes, cond.Reason)
+
+			// Pending probes keep the Addon unavailable.
+			available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			require.NotNil(t, available)
+			assert.Equal(t, metav1.ConditionFalse, available.Status)
+			assert.Equal(t, addonsv1alpha1.AddonReasonPendingReadinessProbes, available.Reason)
+
+			key := client.ObjectKeyFromObject(addon)
+			require.Eventually(t, func() bool {
+				_, ok := results.get(key, addon.Generation)
+				return ok
+			}, time.Second, 10*time.Millisecond)
+
+			// The next reconcile within the interval reports the outcome without probing again.
+			reportAddonCSVSucceeded(addon)
+			_, err = r.Reconcile(context.Background(), addon)
+			require.NoError(t, err)
+			prober.AssertExpectations(t)
+
+			cond = meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.ReadinessProbesReady)
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.ExpectedStatus, cond.Status)
+			assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			if tc.ExpectedStatus == metav1.ConditionFalse {
+				assert.Contains(t, cond.Message, tc.Probe.Name)
+			}
+
+			available = meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+			require.NotNil(t, available)
+			if tc.ExpectedStatus == metav1.ConditionTrue {
+				assert.Equal(t, metav1.ConditionTrue, available.Status)
+				return
+			}
+			assert.Equal(t, metav1.ConditionFalse, available.Status)
+			assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyReadinessProbes, available.Reason)
+			assert.Contains(t, available.Message, tc.Probe.Name)
+		})
+	}
+}
+
+func TestReadinessProbeReconciler_CSVNotSucceeded(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Delete", testutil.IsContext, mock.IsType(&rbacv1.RoleBinding{}),
+		mock.Anything).Return(nil)
+
+	prober := &readinessProberMock{}
+	prober.On("ProbeHTTPGet", mock.Anything, "addon-1", mock.Anything).Return(nil).Maybe()
+	r := &readinessProbeReconciler{
+		client:  c,
+		prober:  prober,
+		results: newReadinessProbeResults(),
+	}
+
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.ReadinessProbes = []addonsv1alpha1.AddonReadinessProbe{{
+		Name:    "api",
+		HTTPGet: &addonsv1alpha1.AddonReadinessProbeHTTPGet{Service: "api", Port: 8080},
+	}}
+	reportUnreadyCSV(addon, "Installing")
+
+	_, err := r.Reconcile(context.Background(), addon)
+	require.NoError(t, err)
+
+	// Addons unavailable for other reasons keep reporting these.
+	available := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available)
+	require.NotNil(t, available)
+	assert.Equal(t, addonsv1alpha1.AddonReasonUnreadyCSV, available.Reason)
+}
+
+func TestReadinessProbeReconciler_ExecRoleBindings(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Patch", testutil.IsContext, mock.IsType(&rbacv1.RoleBinding{}),
+		mock.Anything, mock.Anything).Return(nil)
+	c.On("Delete", testutil.IsContext, mock.IsType(&rbacv1.RoleBinding{}),
+		mock.Anything).Return(nil)
+
+	r := &readinessProbeReconciler{
+		client:         c,
+		scheme:         testutil.NewTestScheme(),
+		serviceAccount: client.ObjectKey{Namespace: "addon-operator", Name: "addon-operator"},
+	}
+
+	addon := testutil.NewTestAddonWithMonitoringStack()
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{{Name: "addon-1"}, {Name: "addon-2"}}
+	addon.Spec.ReadinessProbes = []addonsv1alpha1.AddonReadinessProbe{
+		{
+			Name: "db",
+			Exec: &addonsv1alpha1.AddonReadinessProbeExec{Command: []string{"true"}},
+		},
+		{
+			Name:      "api",
+			Namespace: "addon-2",
+			HTTPGet:   &addonsv1alpha1.AddonReadinessProbeHTTPGet{Service: "api", Port: 8080},
+		},
+	}
+
+	err := r.reconcileExecRoleBindings(context.Background(), addon)
+	require.NoError(t, err)
+
+	// The Addon Operator is allowed to exec into Pods only in namespaces with exec probes.
+	c.AssertNumberOfCalls(t, "Patch", 1)
+	var roleBinding *rbacv1.RoleBinding
+	for _, call := range c.Calls {
+		if call.Method == "Patch" {
+			roleBinding = call.Arguments.Get(1).(*rbacv1.RoleBinding)
+		}
+	}
+	require.NotNil(t, roleBinding)
+	assert.Equal(t, "addon-1", roleBinding.Namespace)
+	assert.Equal(t, readinessProbeExecClusterRole, roleBinding.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "addon-operator",
+		Namespace: "addon-operator",
+	}}, roleBinding.Subjects)
+	assert.Len(t, roleBinding.OwnerReferences, 1)
+
+	c.AssertCalled(t, "Delete", testutil.IsContext, mock.MatchedBy(func(rb *rbacv1.RoleBinding) bool {
+		return rb.Namespace == "addon-2"
+	}), mock.Anything)
+}
+
+func TestReadinessProbeResults_StartRun(t *testing.T) {
+	results := newReadinessProbeResults()
+	key := client.ObjectKey{Name: "addon-1"}
+	now := time.Now()
+
+	assert.True(t, results.startRun(key, 1, now, time.Minute))
+	// A run is in progress.
+	assert.False(t, results.startRun(key, 1, now.Add(2*time.Minute), time.Minute))
+
+	results.finishRun(key, 1, nil)
+	// Within the interval.
+	assert.False(t, results.startRun(key, 1, now.Add(time.Second), time.Minute))
+	// A new generation is probed right away, discarding older results.
+	assert.True(t, results.startRun(key, 2, now.Add(time.Second), time.Minute))
+	_, ok := results.get(key, 2)
+	assert.False(t, ok)
+
+	// Outcomes of superseded runs are dropped.
+	results.finishRun(key, 1, []string{"api: failed"})
+	_, ok = results.get(key, 1)
+	assert.False(t, ok)
+}
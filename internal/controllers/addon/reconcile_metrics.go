@@ -0,0 +1,130 @@
+package addon
+
+import (
+	"errors"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/metrics"
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+// Classes of errors returned from reconciling an Addon.
+const (
+	// Requests to OCM failed or were not sent.
+	reconcileErrorClassOCM = "ocm"
+	// An object was modified concurrently.
+	reconcileErrorClassConflict = "conflict"
+	// Any other request to the kube-apiserver failed.
+	reconcileErrorClassKubeAPI = "kube_api"
+	reconcileErrorClassOther   = "other"
+)
+
+// Records the duration and result of reconciling the Addon.
+func (r *AddonReconciler) recordReconcile(
+	addonName string, start time.Time, res ctrl.Result, err error,
+) {
+	if r.Recorder == nil {
+		return
+	}
+
+	result := metrics.ReconcileResultSuccess
+	switch {
+	case err != nil:
+		result = metrics.ReconcileResultError
+	case !res.IsZero():
+		result = metrics.ReconcileResultRequeue
+	}
+	r.Recorder.RecordAddonReconcile(addonName, time.Since(start), result, reconcileErrorClasses(err)...)
+}
+
+// Status of conditions absent before or after reconciling.
+const conditionStatusNone = "None"
+
+// Records the status conditions of the Addon, which changed their status
+// compared to the conditions before reconciling it.
+func (r *AddonReconciler) recordConditionTransitions(
+	addon *addonsv1alpha1.Addon, previous []metav1.Condition,
+) {
+	if r.Recorder == nil {
+		return
+	}
+
+	for _, t := range conditionTransitions(previous, addon.Status.Conditions) {
+		r.Recorder.RecordConditionTransition(addon.Name, t.Type, t.From, t.To)
+	}
+}
+
+type conditionTransition struct {
+	Type, From, To string
+}
+
+// Returns the conditions changing their status from previous to current,
+// including added and removed conditions.
+func conditionTransitions(previous, current []metav1.Condition) []conditionTransition {
+	status := func(conds []metav1.Condition, condType string) string {
+		if cond := meta.FindStatusCondition(conds, condType); cond != nil {
+			return string(cond.Status)
+		}
+		return conditionStatusNone
+	}
+
+	var transitions []conditionTransition
+	for _, cond := range current {
+		if from := status(previous, cond.Type); from != string(cond.Status) {
+			transitions = append(transitions, conditionTransition{
+				Type: cond.Type, From: from, To: string(cond.Status),
+			})
+		}
+	}
+	for _, cond := range previous {
+		if meta.FindStatusCondition(current, cond.Type) == nil {
+			transitions = append(transitions, conditionTransition{
+				Type: cond.Type, From: string(cond.Status), To: conditionStatusNone,
+			})
+		}
+	}
+	return transitions
+}
+
+// Returns the class of each error wrapped by err.
+func reconcileErrorClasses(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	var multiErr *multierror.Error
+	if !errors.As(err, &multiErr) {
+		return []string{reconcileErrorClass(err)}
+	}
+	classes := make([]string, 0, len(multiErr.Errors))
+	for _, err := range multiErr.Errors {
+		classes = append(classes, reconcileErrorClasses(err)...)
+	}
+	return classes
+}
+
+func reconcileErrorClass(err error) string {
+	var (
+		ocmErr    ocm.OCMError
+		apiStatus k8sApiErrors.APIStatus
+	)
+	switch {
+	case errors.As(err, &ocmErr),
+		errors.Is(err, ocm.ErrCircuitOpen),
+		errors.Is(err, errOCMClientNotInitialized):
+		return reconcileErrorClassOCM
+	case k8sApiErrors.IsConflict(err):
+		return reconcileErrorClassConflict
+	case errors.As(err, &apiStatus):
+		return reconcileErrorClassKubeAPI
+	default:
+		return reconcileErrorClassOther
+	}
+}
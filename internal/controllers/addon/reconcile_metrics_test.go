@@ -0,0 +1,88 @@
+package addon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+func TestReconcileErrorClasses(t *testing.T) {
+	t.Parallel()
+
+	conflict := k8sApiErrors.NewConflict(schema.GroupResource{}, "addon-1", errors.New("modified"))
+	forbidden := k8sApiErrors.NewForbidden(schema.GroupResource{}, "addon-1", errors.New("denied"))
+
+	for name, tc := range map[string]struct {
+		Err      error
+		Expected []string
+	}{
+		"no error": {},
+		"ocm": {
+			Err:      ocm.OCMError{StatusCode: 500},
+			Expected: []string{reconcileErrorClassOCM},
+		},
+		"ocm circuit open": {
+			Err:      fmt.Errorf("patching upgrade policy: %w", ocm.CircuitOpenError{}),
+			Expected: []string{reconcileErrorClassOCM},
+		},
+		"conflict": {
+			Err:      fmt.Errorf("olmReconciler : failed to reconcile : %w", conflict),
+			Expected: []string{reconcileErrorClassConflict},
+		},
+		"kube api": {
+			Err:      forbidden,
+			Expected: []string{reconcileErrorClassKubeAPI},
+		},
+		"other": {
+			Err:      errors.New("boom"),
+			Expected: []string{reconcileErrorClassOther},
+		},
+		"multiple": {
+			Err:      multierror.Append(nil, errOCMClientNotInitialized, conflict, errors.New("boom")),
+			Expected: []string{reconcileErrorClassOCM, reconcileErrorClassConflict, reconcileErrorClassOther},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.Expected, reconcileErrorClasses(tc.Err))
+		})
+	}
+}
+
+func TestConditionTransitions(t *testing.T) {
+	t.Parallel()
+
+	cond := func(condType string, status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: condType, Status: status}
+	}
+
+	transitions := conditionTransitions(
+		[]metav1.Condition{
+			cond(addonsv1alpha1.Available, metav1.ConditionTrue),
+			cond(addonsv1alpha1.Installed, metav1.ConditionTrue),
+			cond(addonsv1alpha1.UpgradeStarted, metav1.ConditionTrue),
+		},
+		[]metav1.Condition{
+			cond(addonsv1alpha1.Available, metav1.ConditionFalse),
+			cond(addonsv1alpha1.Installed, metav1.ConditionTrue),
+			cond(addonsv1alpha1.Paused, metav1.ConditionTrue),
+		},
+	)
+	assert.Equal(t, []conditionTransition{
+		{Type: addonsv1alpha1.Available, From: "True", To: "False"},
+		{Type: addonsv1alpha1.Paused, From: conditionStatusNone, To: "True"},
+		{Type: addonsv1alpha1.UpgradeStarted, From: "True", To: conditionStatusNone},
+	}, transitions)
+}
@@ -0,0 +1,154 @@
+package addon
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Remembers Addons whose skippable sub-reconcilers completed,
+// so they can be skipped while neither the Addon
+// nor any of the objects owned by it changed.
+type reconciledAddons struct {
+	mux    sync.Mutex
+	addons map[string]reconciledAddon
+}
+
+type reconciledAddon struct {
+	uid          types.UID
+	generation   int64
+	metadataHash uint64
+	at           time.Time
+}
+
+func newReconciledAddons() *reconciledAddons {
+	return &reconciledAddons{addons: map[string]reconciledAddon{}}
+}
+
+// Records that the skippable sub-reconcilers completed for the current state of the Addon.
+func (r *reconciledAddons) observe(addon *addonsv1alpha1.Addon, now time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.addons[addon.Name] = reconciledAddon{
+		uid:          addon.UID,
+		generation:   addon.Generation,
+		metadataHash: hashAddonMetadata(addon),
+		at:           now,
+	}
+}
+
+// Forgets the Addon with the given name, e.g. because an object owned by it changed.
+func (r *reconciledAddons) forget(name string) {
+	if r == nil {
+		return
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	delete(r.addons, name)
+}
+
+// Forgets all Addons, e.g. because configuration shared by all Addons changed.
+func (r *reconciledAddons) forgetAll() {
+	if r == nil {
+		return
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	r.addons = map[string]reconciledAddon{}
+}
+
+// Returns true, if the skippable sub-reconcilers completed for the current state
+// of the Addon no longer than maxAge ago and no object owned by it changed since.
+func (r *reconciledAddons) unchanged(
+	addon *addonsv1alpha1.Addon, now time.Time, maxAge time.Duration) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	reconciled, ok := r.addons[addon.Name]
+	return ok &&
+		reconciled.uid == addon.UID &&
+		reconciled.generation == addon.Generation &&
+		reconciled.metadataHash == hashAddonMetadata(addon) &&
+		now.Sub(reconciled.at) < maxAge
+}
+
+// Labels and annotations are not covered by the generation of the Addon.
+func hashAddonMetadata(addon *addonsv1alpha1.Addon) uint64 {
+	hasher := fnv.New64a()
+	fmt.Fprintf(hasher, "%v%v", addon.Labels, addon.Annotations)
+	return hasher.Sum64()
+}
+
+// Enqueues the owners of changed objects like the wrapped handler,
+// while making sure their skippable sub-reconcilers run again.
+type ownedObjectHandler struct {
+	owner      handler.EventHandler
+	reconciled *reconciledAddons
+}
+
+func (r *AddonReconciler) handleOwnedObjects(isController bool) handler.EventHandler {
+	return &ownedObjectHandler{
+		owner: &handler.EnqueueRequestForOwner{
+			OwnerType:    &addonsv1alpha1.Addon{},
+			IsController: isController,
+		},
+		reconciled: r.reconciled,
+	}
+}
+
+// Passes scheme and RESTMapper on to the wrapped handler.
+func (h *ownedObjectHandler) InjectFunc(f inject.Func) error {
+	return f(h.owner)
+}
+
+func (h *ownedObjectHandler) Create(e event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.owner.Create(e, h.queue(q))
+}
+
+func (h *ownedObjectHandler) Update(e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	h.owner.Update(e, h.queue(q))
+}
+
+func (h *ownedObjectHandler) Delete(e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.owner.Delete(e, h.queue(q))
+}
+
+func (h *ownedObjectHandler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	h.owner.Generic(e, h.queue(q))
+}
+
+func (h *ownedObjectHandler) queue(q workqueue.RateLimitingInterface) workqueue.RateLimitingInterface {
+	return &forgettingQueue{RateLimitingInterface: q, reconciled: h.reconciled}
+}
+
+// Forgets Addons as reconciled, when they are added to the queue.
+type forgettingQueue struct {
+	workqueue.RateLimitingInterface
+	reconciled *reconciledAddons
+}
+
+func (q *forgettingQueue) Add(item interface{}) {
+	if req, ok := item.(reconcile.Request); ok {
+		q.reconciled.forget(req.Name)
+	}
+	q.RateLimitingInterface.Add(item)
+}
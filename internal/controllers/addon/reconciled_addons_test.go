@@ -0,0 +1,47 @@
+package addon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestReconciledAddons(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	r := newReconciledAddons()
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.UID = types.UID("1")
+	assert.False(t, r.unchanged(addon, now, time.Hour))
+
+	r.observe(addon, now)
+	assert.True(t, r.unchanged(addon, now.Add(time.Minute), time.Hour))
+	assert.False(t, r.unchanged(addon, now.Add(2*time.Hour), time.Hour), "outdated")
+
+	recreated := addon.DeepCopy()
+	recreated.UID = types.UID("2")
+	assert.False(t, r.unchanged(recreated, now, time.Hour), "recreated")
+
+	// Addons are forgotten, when added to the queue for a changed owned object.
+	q := &forgettingQueue{RateLimitingInterface: &queueMock{}, reconciled: r}
+	q.RateLimitingInterface.(*queueMock).On("Add", mock.Anything)
+	q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: addon.Name}})
+	assert.False(t, r.unchanged(addon, now, time.Hour))
+}
+
+type queueMock struct {
+	workqueue.RateLimitingInterface
+	mock.Mock
+}
+
+func (q *queueMock) Add(item interface{}) {
+	q.Called(item)
+}
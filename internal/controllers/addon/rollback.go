@@ -0,0 +1,106 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Returns the known good installation to restore,
+// if a rollback was requested and there is one.
+func rollbackTarget(addon *addonsv1alpha1.Addon) *addonsv1alpha1.AddonKnownGoodStatus {
+	if !addon.Spec.Rollback {
+		return nil
+	}
+	return addon.Status.LastKnownGood
+}
+
+// Name of the CSV to roll back to, empty if no rollback was requested.
+func rollbackTargetCSVName(addon *addonsv1alpha1.Addon) string {
+	target := rollbackTarget(addon)
+	if target == nil {
+		return ""
+	}
+	_, name, _ := strings.Cut(target.CSV, "/")
+	return name
+}
+
+// OLM does not downgrade operators within a Subscription.
+// To roll back, the Subscription and the CSV it installed are deleted once,
+// so the Subscription is recreated, starting at the known good CSV.
+func (r *olmReconciler) rollbackSubscription(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	subscription *operatorsv1alpha1.Subscription,
+) (requeueResult, error) {
+	if !addon.Spec.Rollback {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.RolledBack)
+		return resultNil, nil
+	}
+
+	targetCSV := rollbackTargetCSVName(addon)
+	if len(targetCSV) == 0 {
+		reportRollback(addon, metav1.ConditionFalse, addonsv1alpha1.AddonReasonNoKnownGoodVersion,
+			"No known good version to roll back to.")
+		return resultNil, nil
+	}
+
+	installedCSV := subscription.Status.InstalledCSV
+	if len(installedCSV) == 0 || installedCSV == targetCSV ||
+		meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.RolledBack) != nil {
+		// Nothing installed to replace, or the Subscription was already recreated.
+		return resultNil, nil
+	}
+
+	if err := r.deleteSubscriptionAndCSV(ctx, addon, client.ObjectKey{
+		Name:      installedCSV,
+		Namespace: subscription.Namespace,
+	}); err != nil {
+		return resultNil, fmt.Errorf("rolling back: %w", err)
+	}
+	controllers.LoggerFromContext(ctx).Info("rolling back",
+		"installedCSV", installedCSV, "targetCSV", targetCSV)
+	reportRollback(addon, metav1.ConditionFalse, addonsv1alpha1.AddonReasonRollingBack,
+		fmt.Sprintf("Rolling back from %s to %s.", installedCSV, targetCSV))
+	return resultRetry, nil
+}
+
+// Records the CSV and catalog image the Addon is successfully installed with,
+// keeping the previous known good installation to roll back to.
+// While rolled back, reports whether the known good CSV has been restored instead.
+func reportKnownGood(addon *addonsv1alpha1.Addon, csv, catalogSourceImage string) {
+	if addon.Spec.Rollback {
+		if target := addon.Status.LastKnownGood; target != nil && target.CSV == csv {
+			reportRollback(addon, metav1.ConditionTrue, addonsv1alpha1.AddonReasonRollbackSucceeded,
+				fmt.Sprintf("Rolled back to %s.", csv))
+		}
+		return
+	}
+
+	knownGood := &addonsv1alpha1.AddonKnownGoodStatus{
+		CSV:                csv,
+		Version:            addon.Status.InstalledVersion,
+		CatalogSourceImage: catalogSourceImage,
+	}
+	if current := addon.Status.KnownGood; current != nil && current.CSV != csv {
+		addon.Status.LastKnownGood = current
+	}
+	addon.Status.KnownGood = knownGood
+}
+
+func reportRollback(addon *addonsv1alpha1.Addon, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.RolledBack,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
@@ -0,0 +1,137 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestReportKnownGood(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Status.InstalledVersion = "1.0.0"
+	reportKnownGood(addon, "addon-1/operator.v1.0.0", "quay.io/osd-addons/test:v1")
+	assert.Nil(t, addon.Status.LastKnownGood)
+
+	// Same CSV again does not shift the known good installation.
+	reportKnownGood(addon, "addon-1/operator.v1.0.0", "quay.io/osd-addons/test:v1")
+	assert.Nil(t, addon.Status.LastKnownGood)
+
+	addon.Status.InstalledVersion = "1.1.0"
+	reportKnownGood(addon, "addon-1/operator.v1.1.0", "quay.io/osd-addons/test:v2")
+	assert.Equal(t, &addonsv1alpha1.AddonKnownGoodStatus{
+		CSV:                "addon-1/operator.v1.1.0",
+		Version:            "1.1.0",
+		CatalogSourceImage: "quay.io/osd-addons/test:v2",
+	}, addon.Status.KnownGood)
+	assert.Equal(t, &addonsv1alpha1.AddonKnownGoodStatus{
+		CSV:                "addon-1/operator.v1.0.0",
+		Version:            "1.0.0",
+		CatalogSourceImage: "quay.io/osd-addons/test:v1",
+	}, addon.Status.LastKnownGood)
+
+	// Rolled back installations are not recorded as known good.
+	addon.Spec.Rollback = true
+	reportKnownGood(addon, "addon-1/operator.v1.0.0", "quay.io/osd-addons/test:v1")
+	assert.Equal(t, "addon-1/operator.v1.1.0", addon.Status.KnownGood.CSV)
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.RolledBack)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, addonsv1alpha1.AddonReasonRollbackSucceeded, cond.Reason)
+}
+
+func TestRollbackSubscription(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		LastKnownGood    *addonsv1alpha1.AddonKnownGoodStatus
+		RollingBack      bool
+		InstalledCSV     string
+		ExpectedDelete   bool
+		ExpectedResult   requeueResult
+		ExpectedReason   string
+		ExpectedStarting string
+	}{
+		"no known good version": {
+			InstalledCSV:   "operator.v1.1.0",
+			ExpectedResult: resultNil,
+			ExpectedReason: addonsv1alpha1.AddonReasonNoKnownGoodVersion,
+		},
+		"deletes newer CSV": {
+			LastKnownGood: &addonsv1alpha1.AddonKnownGoodStatus{
+				CSV:                "addon-1/operator.v1.0.0",
+				CatalogSourceImage: "quay.io/osd-addons/test:v1",
+			},
+			InstalledCSV:     "operator.v1.1.0",
+			ExpectedDelete:   true,
+			ExpectedResult:   resultRetry,
+			ExpectedReason:   addonsv1alpha1.AddonReasonRollingBack,
+			ExpectedStarting: "operator.v1.0.0",
+		},
+		"waits for recreated Subscription": {
+			LastKnownGood: &addonsv1alpha1.AddonKnownGoodStatus{
+				CSV:                "addon-1/operator.v1.0.0",
+				CatalogSourceImage: "quay.io/osd-addons/test:v1",
+			},
+			RollingBack:      true,
+			InstalledCSV:     "operator.v1.1.0",
+			ExpectedResult:   resultNil,
+			ExpectedReason:   addonsv1alpha1.AddonReasonRollingBack,
+			ExpectedStarting: "operator.v1.0.0",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &olmReconciler{client: c}
+			c.On("Delete", testutil.IsContext, mock.Anything, mock.Anything).Return(nil)
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Rollback = true
+			addon.Status.LastKnownGood = tc.LastKnownGood
+			if tc.RollingBack {
+				reportRollback(addon, metav1.ConditionFalse,
+					addonsv1alpha1.AddonReasonRollingBack, "Rolling back.")
+			}
+			subscription := &operatorsv1alpha1.Subscription{
+				ObjectMeta: metav1.ObjectMeta{Name: SubscriptionName(addon), Namespace: "addon-1"},
+				Status:     operatorsv1alpha1.SubscriptionStatus{InstalledCSV: tc.InstalledCSV},
+			}
+
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			result, err := r.rollbackSubscription(ctx, addon, subscription)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedResult, result)
+
+			if tc.ExpectedDelete {
+				c.AssertCalled(t, "Delete", testutil.IsContext,
+					mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything)
+				c.AssertCalled(t, "Delete", testutil.IsContext,
+					mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.RolledBack)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionFalse, cond.Status)
+			assert.Equal(t, tc.ExpectedReason, cond.Reason)
+			assert.Equal(t, tc.ExpectedStarting,
+				startingCSV(addon, GetCommonInstallOptions(addon)))
+		})
+	}
+}
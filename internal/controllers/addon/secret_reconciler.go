@@ -3,11 +3,13 @@ package addon
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -26,10 +28,12 @@ type addonSecretPropagationReconciler struct {
 }
 
 func (r *addonSecretPropagationReconciler) Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
-	if addon.Spec.SecretPropagation == nil ||
-		len(addon.Spec.SecretPropagation.Secrets) == 0 {
+	if !hasSecretsToPropagate(addon) {
 		// just ensure all propagated secrets are gone
-		return ctrl.Result{}, r.cleanupUnknownSecrets(ctx, map[client.ObjectKey]struct{}{}, addon)
+		if err := r.cleanupUnknownSecrets(ctx, map[client.ObjectKey]struct{}{}, addon); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.attachPullSecrets(ctx, addon)
 	}
 
 	destinationSecretsWithoutNamespace, result, err := r.getDestinationSecretsWithoutNamespace(ctx, addon)
@@ -40,22 +44,55 @@ func (r *addonSecretPropagationReconciler) Reconcile(ctx context.Context, addon
 		return result, nil
 	}
 
+	namespacedDestinationSecrets, result, err := r.getNamespacedDestinationSecrets(ctx, addon)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !result.IsZero() {
+		return result, nil
+	}
+
 	knownSecrets, err := r.reconcileSecretsInAddonNamespaces(ctx, destinationSecretsWithoutNamespace, addon)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	for i := range namespacedDestinationSecrets {
+		destSecret := &namespacedDestinationSecrets[i]
+		key := client.ObjectKeyFromObject(destSecret)
+		knownSecrets[key] = struct{}{}
+
+		if err := reconcileSecret(ctx, r.cachedClient, destSecret); err != nil {
+			return ctrl.Result{}, fmt.Errorf("reconciling secret %s: %w", key, err)
+		}
+	}
 
 	if err := r.cleanupUnknownSecrets(ctx, knownSecrets, addon); err != nil {
 		return ctrl.Result{}, fmt.Errorf("propagated secret cleanup: %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	return r.attachPullSecrets(ctx, addon)
 }
 
 func (r *addonSecretPropagationReconciler) Name() string {
 	return SECRET_RECONCILER_NAME
 }
 
+func (r *addonSecretPropagationReconciler) Order() subReconcilerOrder {
+	return secretPropagationReconcilerOrder
+}
+
+func (r *addonSecretPropagationReconciler) Skippable() bool {
+	return true
+}
+
+func (r *addonSecretPropagationReconciler) ConditionType() string {
+	return addonsv1alpha1.SecretsReady
+}
+
+func (r *addonSecretPropagationReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool {
+	return hasSecretsToPropagate(addon) || len(addonPullSecrets(addon)) > 0
+}
+
 // Lookup all secret sources for secret propagation
 // returns a list of destination secrets, just missing their namespace
 func (r *addonSecretPropagationReconciler) getDestinationSecretsWithoutNamespace(
@@ -80,23 +117,78 @@ func (r *addonSecretPropagationReconciler) getDestinationSecretsWithoutNamespace
 		}
 
 		// Build destination secret -> will get applied into multiple addon namespaces
-		destSecret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: secretRef.DestinationSecret.Name,
-			},
-			Data: srcSecret.Data,
-			Type: srcSecret.Type,
-		}
-		controllers.AddCommonLabels(destSecret, addon)
-		controllers.AddCommonAnnotations(destSecret, addon)
-		if err := controllerutil.SetControllerReference(addon, destSecret, r.scheme); err != nil {
-			return nil, ctrl.Result{}, fmt.Errorf("setting owner reference: %w", err)
+		destSecret, err := r.newDestinationSecret(addon, srcSecret, secretRef.DestinationSecret.Name)
+		if err != nil {
+			return nil, ctrl.Result{}, err
 		}
 		destinationSecrets = append(destinationSecrets, *destSecret)
 	}
 	return destinationSecrets, ctrl.Result{}, nil
 }
 
+// Lookup all secret sources listed in .spec.namespaces[].propagateSecrets,
+// returns a list of destination secrets in their addon namespace.
+func (r *addonSecretPropagationReconciler) getNamespacedDestinationSecrets(
+	ctx context.Context,
+	addon *addonsv1alpha1.Addon,
+) ([]corev1.Secret, ctrl.Result, error) {
+	var destinationSecrets []corev1.Secret
+	for _, ns := range addon.Spec.Namespaces {
+		for _, secretName := range ns.PropagateSecrets {
+			srcSecret, result, err := r.getReferencedSecret(ctx, addon, client.ObjectKey{
+				Name:      secretName,
+				Namespace: r.addonOperatorNamespace,
+			})
+			if err != nil {
+				return nil, ctrl.Result{}, err
+			}
+			if !result.IsZero() {
+				return nil, result, nil
+			}
+
+			destSecret, err := r.newDestinationSecret(addon, srcSecret, secretName)
+			if err != nil {
+				return nil, ctrl.Result{}, err
+			}
+			destSecret.Namespace = ns.Name
+			destinationSecrets = append(destinationSecrets, *destSecret)
+		}
+	}
+	return destinationSecrets, ctrl.Result{}, nil
+}
+
+// Builds a copy of the source secret, owned by the Addon.
+func (r *addonSecretPropagationReconciler) newDestinationSecret(
+	addon *addonsv1alpha1.Addon, srcSecret *corev1.Secret, name string,
+) (*corev1.Secret, error) {
+	destSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Data: srcSecret.Data,
+		Type: srcSecret.Type,
+	}
+	controllers.AddCommonLabels(destSecret, addon)
+	controllers.AddCommonAnnotations(destSecret, addon)
+	if err := controllerutil.SetControllerReference(addon, destSecret, r.scheme); err != nil {
+		return nil, fmt.Errorf("setting owner reference: %w", err)
+	}
+	return destSecret, nil
+}
+
+func hasSecretsToPropagate(addon *addonsv1alpha1.Addon) bool {
+	if addon.Spec.SecretPropagation != nil &&
+		len(addon.Spec.SecretPropagation.Secrets) > 0 {
+		return true
+	}
+	for _, ns := range addon.Spec.Namespaces {
+		if len(ns.PropagateSecrets) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // Get a single referenced source secret for propagation
 func (r *addonSecretPropagationReconciler) getReferencedSecret(
 	ctx context.Context, addon *addonsv1alpha1.Addon, secretKey client.ObjectKey,
@@ -111,7 +203,7 @@ func (r *addonSecretPropagationReconciler) getReferencedSecret(
 		if err := r.uncachedClient.Get(ctx, secretKey, referencedSecret); errors.IsNotFound(err) {
 			// Secret does not exist for sure, break and keep retrying later.
 			reportPendingStatus(addon, addonsv1alpha1.AddonReasonMissingSecretForPropagation, err.Error())
-			return nil, ctrl.Result{RequeueAfter: defaultRetryAfterTime}, nil
+			return nil, ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
 		} else if err != nil {
 			return nil, ctrl.Result{}, fmt.Errorf("getting source Secret for propagation via uncached client: %w", err)
 		}
@@ -172,6 +264,10 @@ func (r *addonSecretPropagationReconciler) cleanupUnknownSecrets(
 			// secret is known to us and should continue to exist
 			continue
 		}
+		if secret.Name == ParametersName(addon) {
+			// managed by the olmReconciler
+			continue
+		}
 
 		if err := r.cachedClient.Delete(ctx, secret); err != nil {
 			return fmt.Errorf("deleting unknown propagated secret: %w", err)
@@ -184,28 +280,127 @@ func reconcileSecret(
 	ctx context.Context, c client.Client, desiredSecret *corev1.Secret) error {
 	actualSecret := &corev1.Secret{}
 	err := c.Get(ctx, client.ObjectKeyFromObject(desiredSecret), actualSecret)
-	if errors.IsNotFound(err) {
-		if err := c.Create(ctx, desiredSecret); err != nil && !errors.IsAlreadyExists(err) {
-			return fmt.Errorf("creating secret: %w", err)
-		}
-		return nil
-	} else if err != nil {
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
 		return fmt.Errorf("getting secret: %w", err)
+	case actualSecret.Type != desiredSecret.Type:
+		// Type is immutable, so the secret has to be recreated.
+		if err := c.Delete(ctx, actualSecret, client.Preconditions{
+			UID: &actualSecret.UID,
+		}); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting secret to change its type: %w", err)
+		}
+	default:
+		if err := yieldToForeignController(ctx, c, actualSecret, desiredSecret); err != nil {
+			return fmt.Errorf("adopting secret: %w", err)
+		}
 	}
 
-	if actualSecret.Labels == nil {
-		actualSecret.Labels = map[string]string{}
+	if err := controllers.Apply(ctx, c, desiredSecret); err != nil {
+		return fmt.Errorf("applying secret: %w", err)
 	}
-	for k, v := range desiredSecret.GetLabels() {
-		actualSecret.Labels[k] = v
+	return nil
+}
+
+// Annotation on the default ServiceAccounts of Addon namespaces,
+// recording the pull secrets attached for the Addon.
+const attachedPullSecretsAnnotation = "addons.managed.openshift.io/attached-pull-secrets"
+
+// Adds the pull secrets of the Addon to the default ServiceAccount
+// of every Addon namespace and removes the ones no longer configured.
+func (r *addonSecretPropagationReconciler) attachPullSecrets(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (ctrl.Result, error) {
+	pullSecrets := addonPullSecrets(addon)
+
+	for _, ns := range addon.Spec.Namespaces {
+		sa := &corev1.ServiceAccount{}
+		if err := r.cachedClient.Get(ctx, client.ObjectKey{
+			Name:      "default",
+			Namespace: ns.Name,
+		}, sa); errors.IsNotFound(err) {
+			if len(pullSecrets) == 0 {
+				continue
+			}
+			// The ServiceAccount is created asynchronously for new namespaces.
+			return ctrl.Result{RequeueAfter: getRetryAfterTime()}, nil
+		} else if err != nil {
+			return ctrl.Result{}, fmt.Errorf("getting default ServiceAccount: %w", err)
+		}
+
+		if !syncPullSecrets(sa, pullSecrets) {
+			continue
+		}
+		if err := r.cachedClient.Update(ctx, sa); err != nil {
+			return ctrl.Result{}, fmt.Errorf("attaching pull secrets to ServiceAccount %s: %w",
+				client.ObjectKeyFromObject(sa), err)
+		}
 	}
-	// Type is immutable, so we can't reconcile it
-	// actualSecret.Type = desiredSecret.Type
-	actualSecret.Data = desiredSecret.Data
-	actualSecret.OwnerReferences = desiredSecret.OwnerReferences
+	return ctrl.Result{}, nil
+}
 
-	if err := c.Update(ctx, actualSecret); err != nil {
-		return fmt.Errorf("updating secret: %w", err)
+// Attaches the given pull secrets to the ServiceAccount and detaches the ones
+// attached previously, which are no longer given. Pull secrets attached by
+// someone else are left alone. Returns true, if the ServiceAccount changed.
+func syncPullSecrets(sa *corev1.ServiceAccount, pullSecrets []string) (changed bool) {
+	var (
+		desired  = sets.New(pullSecrets...)
+		previous = sets.New[string]()
+		owned    = sets.New[string]()
+		attached = sets.New[string]()
+	)
+	if v := sa.Annotations[attachedPullSecretsAnnotation]; len(v) != 0 {
+		previous.Insert(strings.Split(v, ",")...)
+	}
+
+	var refs []corev1.LocalObjectReference
+	for _, ref := range sa.ImagePullSecrets {
+		if previous.Has(ref.Name) {
+			if !desired.Has(ref.Name) {
+				changed = true
+				continue
+			}
+			owned.Insert(ref.Name)
+		}
+		attached.Insert(ref.Name)
+		refs = append(refs, ref)
+	}
+	for _, name := range pullSecrets {
+		if attached.Has(name) {
+			continue
+		}
+		refs = append(refs, corev1.LocalObjectReference{Name: name})
+		attached.Insert(name)
+		owned.Insert(name)
+		changed = true
+	}
+	sa.ImagePullSecrets = refs
+
+	annotation := strings.Join(sets.List(owned), ",")
+	if sa.Annotations[attachedPullSecretsAnnotation] == annotation {
+		return changed
+	}
+	if len(annotation) == 0 {
+		delete(sa.Annotations, attachedPullSecretsAnnotation)
+	} else {
+		if sa.Annotations == nil {
+			sa.Annotations = map[string]string{}
+		}
+		sa.Annotations[attachedPullSecretsAnnotation] = annotation
+	}
+	return true
+}
+
+func addonPullSecrets(addon *addonsv1alpha1.Addon) []string {
+	switch {
+	case addon.Spec.Install.Type == addonsv1alpha1.OLMOwnNamespace &&
+		addon.Spec.Install.OLMOwnNamespace != nil:
+		return addon.Spec.Install.OLMOwnNamespace.GetPullSecrets()
+	case addon.Spec.Install.Type == addonsv1alpha1.OLMAllNamespaces &&
+		addon.Spec.Install.OLMAllNamespaces != nil:
+		return addon.Spec.Install.OLMAllNamespaces.GetPullSecrets()
+	default:
+		return nil
 	}
-	return nil
 }
@@ -32,10 +32,12 @@ func TestReconcileSecret_CreateWithClientError(t *testing.T) {
 	timeoutErr := k8sApiErrors.NewTimeoutError("for testing", 1)
 
 	c := testutil.NewClient()
-	c.On("Get", mock.Anything,
-		testutil.IsObjectKey,
+	c.On("Get", mock.Anything, client.ObjectKeyFromObject(secret),
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", mock.Anything,
 		mock.IsType(&corev1.Secret{}),
-		mock.Anything,
+		client.Apply,
 		mock.Anything).
 		Return(timeoutErr)
 
@@ -44,10 +46,41 @@ func TestReconcileSecret_CreateWithClientError(t *testing.T) {
 	require.Error(t, err)
 	require.ErrorIs(t, err, timeoutErr)
 	c.AssertExpectations(t)
-	c.AssertCalled(t, "Get", mock.Anything, client.ObjectKey{
-		Name:      secret.Name,
-		Namespace: secret.Namespace,
-	}, mock.IsType(&corev1.Secret{}), mock.Anything)
+	c.AssertCalled(t, "Patch", mock.Anything, mock.MatchedBy(func(obj *corev1.Secret) bool {
+		return obj.Name == secret.Name && obj.Namespace == secret.Namespace
+	}), client.Apply, mock.Anything)
+}
+
+func TestReconcileSecret_RecreateOnTypeChange(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "lorem-ipsum",
+			Namespace: "default",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}
+
+	c := testutil.NewClient()
+	c.On("Get", mock.Anything, client.ObjectKeyFromObject(secret),
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			arg := args.Get(2).(*corev1.Secret)
+			arg.Name, arg.Namespace = secret.Name, secret.Namespace
+			arg.UID = "1234"
+			arg.Type = corev1.SecretTypeOpaque
+		}).
+		Return(nil)
+	c.On("Delete", mock.Anything, mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(nil)
+	c.On("Patch", mock.Anything, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
+		Return(nil)
+
+	err := reconcileSecret(context.Background(), c, secret)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+	c.AssertCalled(t, "Patch", mock.Anything, mock.MatchedBy(func(obj *corev1.Secret) bool {
+		return obj.Type == corev1.SecretTypeDockerConfigJson
+	}), client.Apply, mock.Anything)
 }
 
 func Test_getReferencedPullSecret_uncachedFallback(t *testing.T) {
@@ -96,6 +129,11 @@ func Test_getReferencedPullSecret_uncachedFallback(t *testing.T) {
 		mock.Anything,
 	).Return(nil)
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		uncachedClient:         uncachedC,
@@ -151,6 +189,11 @@ func Test_getReferencedPullSecret_retry(t *testing.T) {
 		).
 		Return(testutil.NewTestErrNotFound())
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		uncachedClient:         uncachedC,
@@ -182,13 +225,11 @@ func Test_reconcileSecret_CreateWithClientError(t *testing.T) {
 			Namespace: "test-ns",
 		},
 	}
-	key := client.ObjectKey{
-		Name:      secret.Name,
-		Namespace: secret.Namespace,
-	}
-
 	c := testutil.NewClient()
-	c.On("Get", mock.Anything, key, mock.IsType(&corev1.Secret{}), mock.Anything).
+	c.On("Get", mock.Anything, client.ObjectKeyFromObject(secret),
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	c.On("Patch", mock.Anything, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
 		Return(timeoutErr)
 
 	ctx := context.Background()
@@ -205,17 +246,12 @@ func Test_reconcileSecret_Create(t *testing.T) {
 			Namespace: "test-ns",
 		},
 	}
-	key := client.ObjectKey{
-		Name:      secret.Name,
-		Namespace: secret.Namespace,
-	}
-
 	c := testutil.NewClient()
+	c.On("Get", mock.Anything, client.ObjectKeyFromObject(secret),
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
 	c.
-		On("Get", mock.Anything, key, mock.IsType(&corev1.Secret{}), mock.Anything).
-		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
-	c.
-		On("Create", mock.Anything, secret, mock.Anything).
+		On("Patch", mock.Anything, secret, client.Apply, mock.Anything).
 		Return(nil)
 
 	ctx := context.Background()
@@ -235,18 +271,13 @@ func Test_reconcileSecret_Update(t *testing.T) {
 			},
 		},
 	}
-	key := client.ObjectKey{
-		Name:      secret.Name,
-		Namespace: secret.Namespace,
-	}
-
 	c := testutil.NewClient()
-	c.
-		On("Get", mock.Anything, key, mock.IsType(&corev1.Secret{}), mock.Anything).
-		Return(nil)
+	c.On("Get", mock.Anything, client.ObjectKeyFromObject(secret),
+		mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
 	var updatedSecret *corev1.Secret
 	c.
-		On("Update", mock.Anything, mock.IsType(&corev1.Secret{}), mock.Anything).
+		On("Patch", mock.Anything, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
 		Run(func(args mock.Arguments) {
 			updatedSecret = args.Get(1).(*corev1.Secret)
 		}).
@@ -321,10 +352,10 @@ func TestEnsureSecretPropagation(t *testing.T) {
 	}
 	c.
 		On("Get", mock.Anything, destSecret1Key, mock.IsType(&corev1.Secret{}), mock.Anything).
-		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+		Return(testutil.NewTestErrNotFound())
 	var createdDestSecret *corev1.Secret
 	c.
-		On("Create", mock.Anything, mock.IsType(&corev1.Secret{}), mock.Anything).
+		On("Patch", mock.Anything, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
 		Run(func(args mock.Arguments) {
 			createdDestSecret = args.Get(1).(*corev1.Secret)
 		}).
@@ -360,6 +391,11 @@ func TestEnsureSecretPropagation(t *testing.T) {
 		On("Delete", mock.Anything, secretToDelete, mock.Anything).
 		Return(nil)
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		scheme:                 testutil.NewTestSchemeWithAddonsv1alpha1(),
@@ -382,6 +418,91 @@ func TestEnsureSecretPropagation(t *testing.T) {
 	}
 }
 
+func TestEnsureSecretPropagation_namespaced(t *testing.T) {
+	addon := &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "addon-xxx",
+		},
+		Spec: addonsv1alpha1.AddonSpec{
+			Namespaces: []addonsv1alpha1.AddonNamespace{
+				{Name: "test", PropagateSecrets: []string{"tls-cert"}},
+				{Name: "other"},
+			},
+			Install: addonsv1alpha1.AddonInstallSpec{
+				Type: addonsv1alpha1.OLMOwnNamespace,
+				OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{
+					AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+						CatalogSourceImage: "xxx",
+						Namespace:          "test",
+					},
+				},
+			},
+		},
+	}
+
+	c := testutil.NewClient() // default cached client
+
+	srcSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tls-cert",
+			Namespace: "xxx-addon-operator",
+		},
+		Data: map[string][]byte{
+			"tls.crt": []byte("xxx"),
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+	c.
+		On("Get", mock.Anything, client.ObjectKeyFromObject(srcSecret), mock.IsType(&corev1.Secret{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			out := args.Get(2).(*corev1.Secret)
+			*out = *srcSecret
+		}).
+		Return(nil)
+
+	destSecretKey := client.ObjectKey{
+		Name:      "tls-cert",
+		Namespace: "test",
+	}
+	c.
+		On("Get", mock.Anything, destSecretKey, mock.IsType(&corev1.Secret{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound())
+	var createdDestSecret *corev1.Secret
+	c.
+		On("Patch", mock.Anything, mock.IsType(&corev1.Secret{}), client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			createdDestSecret = args.Get(1).(*corev1.Secret)
+		}).
+		Return(nil)
+	c.
+		On("List", mock.Anything, mock.IsType(&corev1.SecretList{}), mock.Anything).
+		Return(nil)
+
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
+	r := &addonSecretPropagationReconciler{
+		cachedClient:           c,
+		scheme:                 testutil.NewTestSchemeWithAddonsv1alpha1(),
+		addonOperatorNamespace: "xxx-addon-operator",
+	}
+
+	ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	result, err := r.Reconcile(ctx, addon)
+	c.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	c.AssertNumberOfCalls(t, "Patch", 1)
+	if assert.NotNil(t, createdDestSecret) {
+		assert.Equal(t, destSecretKey, client.ObjectKeyFromObject(createdDestSecret))
+		assert.Equal(t, srcSecret.Type, createdDestSecret.Type)
+		assert.Equal(t, srcSecret.Data, createdDestSecret.Data)
+	}
+}
+
 func TestEnsureSecretPropagation_cleanup_when_nil(t *testing.T) {
 	addon := &addonsv1alpha1.Addon{
 		ObjectMeta: metav1.ObjectMeta{
@@ -429,6 +550,11 @@ func TestEnsureSecretPropagation_cleanup_when_nil(t *testing.T) {
 		On("Delete", mock.Anything, secretToDelete, mock.Anything).
 		Return(nil)
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		scheme:                 testutil.NewTestSchemeWithAddonsv1alpha1(),
@@ -487,6 +613,11 @@ func TestGetDestinationSecretWithoutNamespace_NoSecrets(t *testing.T) {
 	uncachedC.
 		On("Get", testutil.IsContext, testutil.IsObjectKey, testutil.IsCoreV1NamespacePtr).Return(nil)
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		uncachedClient:         uncachedC,
@@ -549,6 +680,11 @@ func TestGetDestinationSecretWithoutNamespace_WithSecrets(t *testing.T) {
 
 	ctx := context.Background()
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		uncachedClient:         uncachedC,
@@ -613,6 +749,11 @@ func TestGetDestinationSecretWithoutNamespace_WithSecretsUncachedFallback(t *tes
 		mock.Anything,
 	).Return(nil)
 
+	c.
+		On("Get", mock.Anything, mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(testutil.NewTestErrNotFound()).
+		Maybe()
+
 	r := &addonSecretPropagationReconciler{
 		cachedClient:           c,
 		uncachedClient:         uncachedC,
@@ -627,3 +768,111 @@ func TestGetDestinationSecretWithoutNamespace_WithSecretsUncachedFallback(t *tes
 	assert.Equal(t, ctrl.Result{}, result)
 	assert.NotNil(t, secret)
 }
+
+func TestAttachPullSecrets(t *testing.T) {
+	addon := &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "addon-xxx",
+		},
+		Spec: addonsv1alpha1.AddonSpec{
+			Namespaces: []addonsv1alpha1.AddonNamespace{
+				{Name: "test"},
+			},
+			Install: addonsv1alpha1.AddonInstallSpec{
+				Type: addonsv1alpha1.OLMOwnNamespace,
+				OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{
+					AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+						Namespace:      "test",
+						PullSecretName: "legacy-pull-secret",
+						PullSecrets:    []string{"pull-secret-1", "pull-secret-2"},
+					},
+				},
+			},
+		},
+	}
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, client.ObjectKey{Name: "default", Namespace: "test"},
+			mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			sa := args.Get(2).(*corev1.ServiceAccount)
+			sa.Annotations = map[string]string{
+				attachedPullSecretsAnnotation: "pull-secret-1,removed-pull-secret",
+			}
+			sa.ImagePullSecrets = []corev1.LocalObjectReference{
+				{Name: "default-dockercfg-xxx"},
+				{Name: "removed-pull-secret"},
+				{Name: "pull-secret-1"},
+			}
+		}).
+		Return(nil)
+	var updatedSA *corev1.ServiceAccount
+	c.
+		On("Update", mock.Anything, mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			updatedSA = args.Get(1).(*corev1.ServiceAccount)
+		}).
+		Return(nil)
+
+	r := &addonSecretPropagationReconciler{
+		cachedClient: c,
+	}
+
+	result, err := r.attachPullSecrets(context.Background(), addon)
+	require.NoError(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+	c.AssertExpectations(t)
+
+	if assert.NotNil(t, updatedSA) {
+		assert.Equal(t, []corev1.LocalObjectReference{
+			{Name: "default-dockercfg-xxx"},
+			{Name: "pull-secret-1"},
+			{Name: "legacy-pull-secret"},
+			{Name: "pull-secret-2"},
+		}, updatedSA.ImagePullSecrets)
+		assert.Equal(t, "legacy-pull-secret,pull-secret-1,pull-secret-2",
+			updatedSA.Annotations[attachedPullSecretsAnnotation])
+	}
+}
+
+func TestSyncPullSecrets(t *testing.T) {
+	sa := &corev1.ServiceAccount{
+		ImagePullSecrets: []corev1.LocalObjectReference{
+			{Name: "default-dockercfg-xxx"},
+			{Name: "shared-pull-secret"},
+		},
+	}
+
+	// Pull secrets attached by someone else are not recorded.
+	assert.True(t, syncPullSecrets(sa, []string{"shared-pull-secret", "pull-secret-1"}))
+	assert.Equal(t, "pull-secret-1", sa.Annotations[attachedPullSecretsAnnotation])
+	assert.False(t, syncPullSecrets(sa, []string{"shared-pull-secret", "pull-secret-1"}))
+
+	assert.True(t, syncPullSecrets(sa, nil))
+	assert.Equal(t, []corev1.LocalObjectReference{
+		{Name: "default-dockercfg-xxx"},
+		{Name: "shared-pull-secret"},
+	}, sa.ImagePullSecrets)
+	assert.NotContains(t, sa.Annotations, attachedPullSecretsAnnotation)
+}
+
+func TestAttachPullSecrets_ServiceAccountMissing(t *testing.T) {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{{Name: "test"}}
+
+	c := testutil.NewClient()
+	c.
+		On("Get", mock.Anything, mock.Anything,
+			mock.IsType(&corev1.ServiceAccount{}), mock.Anything).
+		Return(k8sApiErrors.NewNotFound(schema.GroupResource{}, ""))
+
+	r := &addonSecretPropagationReconciler{
+		cachedClient: c,
+	}
+
+	result, err := r.attachPullSecrets(context.Background(), addon)
+	require.NoError(t, err)
+	assert.NotZero(t, result.RequeueAfter)
+	c.AssertExpectations(t)
+}
@@ -0,0 +1,73 @@
+package addon
+
+import (
+	"hash/fnv"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// Same per-item backoff as the default rate limiter of controller-runtime.
+	shardFailureBaseDelay = 5 * time.Millisecond
+	shardFailureMaxDelay  = 1000 * time.Second
+)
+
+// Rate limits requeues of Addons per shard of Addon names.
+// The default rate limiter of controller-runtime shares a single token bucket
+// across all Addons, so an Addon requeued at a high rate delays every other Addon.
+// Here each shard has its own token bucket, confining the delay to Addons of the same shard.
+type shardedRateLimiter struct {
+	// Exponential backoff per Addon.
+	failures workqueue.RateLimiter
+	// Token bucket per shard.
+	shards []workqueue.RateLimiter
+}
+
+func newShardedRateLimiter(shards int, qps float64, burst int) *shardedRateLimiter {
+	if shards < 1 {
+		shards = 1
+	}
+
+	l := &shardedRateLimiter{
+		failures: workqueue.NewItemExponentialFailureRateLimiter(
+			shardFailureBaseDelay, shardFailureMaxDelay),
+		shards: make([]workqueue.RateLimiter, shards),
+	}
+	for i := range l.shards {
+		l.shards[i] = &workqueue.BucketRateLimiter{
+			Limiter: rate.NewLimiter(rate.Limit(qps), burst),
+		}
+	}
+	return l
+}
+
+// Assigns Addons to shards by their name.
+func (l *shardedRateLimiter) shard(item interface{}) workqueue.RateLimiter {
+	req, ok := item.(reconcile.Request)
+	if !ok {
+		return l.shards[0]
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(req.Name))
+	return l.shards[h.Sum32()%uint32(len(l.shards))]
+}
+
+func (l *shardedRateLimiter) When(item interface{}) time.Duration {
+	failureDelay := l.failures.When(item)
+	if shardDelay := l.shard(item).When(item); shardDelay > failureDelay {
+		return shardDelay
+	}
+	return failureDelay
+}
+
+func (l *shardedRateLimiter) Forget(item interface{}) {
+	l.failures.Forget(item)
+}
+
+func (l *shardedRateLimiter) NumRequeues(item interface{}) int {
+	return l.failures.NumRequeues(item)
+}
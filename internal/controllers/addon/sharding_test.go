@@ -0,0 +1,52 @@
+package addon
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestShardedRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	l := newShardedRateLimiter(2, 1, 1)
+
+	// Find Addons assigned to different shards.
+	noisy := reconcile.Request{NamespacedName: types.NamespacedName{Name: "addon-0"}}
+	var other reconcile.Request
+	for i := 1; other.Name == ""; i++ {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("addon-%d", i)}}
+		if l.shard(req) != l.shard(noisy) {
+			other = req
+		}
+	}
+
+	// The noisy Addon exhausts the burst of its shard.
+	l.When(noisy)
+	assert.Greater(t, l.When(noisy), 500*time.Millisecond,
+		"exhausted shard must delay requeues beyond the per-item backoff")
+
+	// Addons of the other shard are not delayed by the noisy Addon.
+	assert.Equal(t, shardFailureBaseDelay, l.When(other))
+	assert.Equal(t, 1, l.NumRequeues(other))
+
+	l.Forget(other)
+	assert.Equal(t, 0, l.NumRequeues(other))
+}
+
+func TestShardedRateLimiter_Shard(t *testing.T) {
+	t.Parallel()
+
+	l := newShardedRateLimiter(0, 1, 1)
+	require.Len(t, l.shards, 1)
+
+	l = newShardedRateLimiter(4, 1, 1)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "addon-1"}}
+	assert.Same(t, l.shard(req), l.shard(req), "Addons are assigned to shards by name")
+	assert.Same(t, l.shards[0], l.shard("not a request"))
+}
@@ -17,6 +17,9 @@ func (r *AddonReconciler) handleOCMAddOnStatusReporting(
 	log logr.Logger,
 	addon *addonsv1alpha1.Addon,
 ) (err error) {
+	if r.IsOCMDisabled() {
+		return nil
+	}
 	if !r.statusReportingRequired(addon) {
 		log.Info("skipping status reporting")
 		return nil
@@ -73,13 +76,17 @@ func (r *AddonReconciler) recordAddonServiceRequestDuration(reqFunc func()) {
 }
 
 func mapToAddonStatusConditions(in []metav1.Condition) []addonsv1alpha1.AddOnStatusCondition {
-	res := make([]addonsv1alpha1.AddOnStatusCondition, len(in))
-	for i, obj := range in {
-		res[i] = addonsv1alpha1.AddOnStatusCondition{
+	res := make([]addonsv1alpha1.AddOnStatusCondition, 0, len(in))
+	for _, obj := range in {
+		// Reporting to OCM failing is of no use to OCM.
+		if obj.Type == addonsv1alpha1.OCMReportingDegraded {
+			continue
+		}
+		res = append(res, addonsv1alpha1.AddOnStatusCondition{
 			StatusType:  obj.Type,
 			StatusValue: obj.Status,
 			Reason:      obj.Reason,
-		}
+		})
 	}
 	return res
 }
@@ -0,0 +1,257 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Position of a sub-reconciler within the Addon reconcile chain.
+// Sub-reconcilers run serially in ascending order. Orders are spaced
+// apart, so new subsystems can be slotted in between existing ones.
+type subReconcilerOrder int
+
+const (
+	addonDeletionReconcilerOrder          subReconcilerOrder = 100
+	namespaceReconcilerOrder              subReconcilerOrder = 200
+	networkPolicyReconcilerOrder          subReconcilerOrder = 250
+	secretPropagationReconcilerOrder      subReconcilerOrder = 300
+	lifecycleHookReconcilerOrder          subReconcilerOrder = 350
+	addonInstanceReconcilerOrder          subReconcilerOrder = 400
+	installPlanReconcilerOrder            subReconcilerOrder = 450
+	olmReconcilerOrder                    subReconcilerOrder = 500
+	packageInstallReconcilerOrder         subReconcilerOrder = 550
+	monitoringFederationReconcilerOrder   subReconcilerOrder = 600
+	monitoringRulesReconcilerOrder        subReconcilerOrder = 650
+	monitoringSLOsReconcilerOrder         subReconcilerOrder = 660
+	monitorsReconcilerOrder               subReconcilerOrder = 675
+	monitoringStackReconcilerOrder        subReconcilerOrder = 700
+	userWorkloadMonitoringReconcilerOrder subReconcilerOrder = 710
+	deadMansSnitchReconcilerOrder         subReconcilerOrder = 720
+	pagerDutyReconcilerOrder              subReconcilerOrder = 730
+	packageOperatorReconcilerOrder        subReconcilerOrder = 800
+	monitoringProbesReconcilerOrder       subReconcilerOrder = 850
+	readinessProbeReconcilerOrder         subReconcilerOrder = 900
+)
+
+type addonReconciler interface {
+	Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error)
+	Name() string
+	Order() subReconcilerOrder
+}
+
+// Implemented by sub-reconcilers whose requeue requests should not stop
+// the sub-reconcilers following them. Their result is merged into the
+// overall result instead, while conditions they report on the Addon
+// are kept as they are.
+type independentAddonReconciler interface {
+	addonReconciler
+	Independent() bool
+}
+
+// Implemented by sub-reconcilers acting only on the Addon and the objects
+// owned by it, which are expensive to run, e.g. due to many API calls.
+// They are skipped while neither the Addon nor its owned objects changed
+// since they last completed, but at least once per drift detection interval.
+type skippableAddonReconciler interface {
+	addonReconciler
+	Skippable() bool
+}
+
+// Implemented by sub-reconcilers reporting the outcome of their phase
+// as a condition of its own on the Addon, so a not yet available Addon
+// can be attributed to the phase holding it back.
+type phaseConditionReporter interface {
+	addonReconciler
+	// Type of the condition reported for the phase.
+	ConditionType() string
+	// Whether the phase applies to the given Addon at all.
+	// The condition is removed from Addons the phase does not apply to.
+	AppliesTo(addon *addonsv1alpha1.Addon) bool
+}
+
+// Registers the given sub-reconciler, keeping the chain sorted by order.
+// Sub-reconcilers with the same order keep their registration order.
+func (r *AddonReconciler) registerSubReconciler(reconciler addonReconciler) {
+	r.subReconcilers = append(r.subReconcilers, reconciler)
+	sort.SliceStable(r.subReconcilers, func(i, j int) bool {
+		return r.subReconcilers[i].Order() < r.subReconcilers[j].Order()
+	})
+}
+
+// Runs each sub-reconciler serially in order.
+// The chain stops at the first error or at the first requeue request
+// of a sub-reconciler, which is not independent.
+func (r *AddonReconciler) runSubReconcilers(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+) (ctrl.Result, error) {
+	var mergedResult ctrl.Result
+
+	// Skippable sub-reconcilers only need to run again,
+	// when the Addon or the objects owned by it changed.
+	now := time.Now()
+	unchanged := r.reconciled.unchanged(addon, now, getDriftDetectionInterval())
+	skippablesCompleted := true
+
+	for i, reconciler := range r.subReconcilers {
+		if unchanged && isSkippable(reconciler) {
+			continue
+		}
+
+		// Sub-reconcilers may report their own phase stopping the reconcile.
+		resetReconcileStopped(addon)
+		start := time.Now()
+		phaseCtx, report := contextWithPhaseReport(ctx)
+		result, err := reconciler.Reconcile(phaseCtx, addon)
+
+		if r.Recorder != nil {
+			r.Recorder.RecordSubReconcilerResult(reconciler.Name(), time.Since(start), err)
+		}
+		reportPhaseCondition(addon, reconciler, result, err, report)
+
+		if err != nil {
+			reportReconcileStopped(addon, reconciler.Name())
+			reportBlockedPhases(addon, r.subReconcilers[i+1:], reconciler.Name())
+			return ctrl.Result{}, fmt.Errorf("%s : failed to reconcile : %w", reconciler.Name(), err)
+		}
+		if result.IsZero() {
+			continue
+		}
+		if isSkippable(reconciler) {
+			skippablesCompleted = false
+		}
+		if !isIndependent(reconciler) {
+			reportReconcileStopped(addon, reconciler.Name())
+			reportBlockedPhases(addon, r.subReconcilers[i+1:], reconciler.Name())
+			return mergeResults(mergedResult, result), nil
+		}
+		mergedResult = mergeResults(mergedResult, result)
+	}
+	resetReconcileStopped(addon)
+
+	if !unchanged && skippablesCompleted {
+		r.reconciled.observe(addon, now)
+	}
+	return mergedResult, nil
+}
+
+type phaseReportKey struct{}
+
+// Outcome of a phase reported by its sub-reconciler during the current run.
+type phaseReport struct {
+	notReadyReason  string
+	notReadyMessage string
+}
+
+func contextWithPhaseReport(ctx context.Context) (context.Context, *phaseReport) {
+	report := &phaseReport{}
+	return context.WithValue(ctx, phaseReportKey{}, report), report
+}
+
+// Reports the phase of the running sub-reconciler as not ready in its condition,
+// e.g. while configuration the phase depends on is missing.
+// Unlike a requeue, neither the Available condition nor the following
+// sub-reconcilers are held back: the phase runs again with the next
+// reconcile of the Addon, that is not skipped.
+func reportPhaseNotReady(ctx context.Context, reason, message string) {
+	if report, ok := ctx.Value(phaseReportKey{}).(*phaseReport); ok {
+		report.notReadyReason = reason
+		report.notReadyMessage = message
+	}
+}
+
+// Reports the condition of the phase of the given sub-reconciler
+// from the outcome of its latest run.
+func reportPhaseCondition(addon *addonsv1alpha1.Addon,
+	reconciler addonReconciler, result ctrl.Result, err error, report *phaseReport) {
+	reporter, ok := reconciler.(phaseConditionReporter)
+	if !ok {
+		return
+	}
+	if !reporter.AppliesTo(addon) {
+		meta.RemoveStatusCondition(&addon.Status.Conditions, reporter.ConditionType())
+		return
+	}
+
+	cond := metav1.Condition{
+		Type:               reporter.ConditionType(),
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.PhaseReasonReconciled,
+		ObservedGeneration: addon.Generation,
+	}
+	switch {
+	case err != nil:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = addonsv1alpha1.PhaseReasonReconcileError
+		cond.Message = err.Error()
+	case report != nil && len(report.notReadyReason) > 0:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = report.notReadyReason
+		cond.Message = report.notReadyMessage
+	case !result.IsZero():
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = addonsv1alpha1.PhaseReasonProgressing
+		// Sub-reconcilers explain why they wait via the Available condition.
+		if available := meta.FindStatusCondition(
+			addon.Status.Conditions, addonsv1alpha1.Available,
+		); available != nil && available.Status != metav1.ConditionTrue {
+			cond.Message = available.Message
+		}
+	}
+	meta.SetStatusCondition(&addon.Status.Conditions, cond)
+}
+
+// Reports the phases of the given sub-reconcilers as blocked,
+// as they did not run after the named sub-reconciler stopped the chain.
+func reportBlockedPhases(addon *addonsv1alpha1.Addon,
+	reconcilers []addonReconciler, blockedBy string) {
+	for _, reconciler := range reconcilers {
+		reporter, ok := reconciler.(phaseConditionReporter)
+		if !ok || !reporter.AppliesTo(addon) {
+			continue
+		}
+		meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+			Type:               reporter.ConditionType(),
+			Status:             metav1.ConditionUnknown,
+			Reason:             addonsv1alpha1.PhaseReasonBlocked,
+			Message:            fmt.Sprintf("Waiting for %s.", blockedBy),
+			ObservedGeneration: addon.Generation,
+		})
+	}
+}
+
+func isSkippable(reconciler addonReconciler) bool {
+	skippable, ok := reconciler.(skippableAddonReconciler)
+	return ok && skippable.Skippable()
+}
+
+func isIndependent(reconciler addonReconciler) bool {
+	independent, ok := reconciler.(independentAddonReconciler)
+	return ok && independent.Independent()
+}
+
+// Merges two results, preferring the earliest requeue.
+func mergeResults(a, b ctrl.Result) ctrl.Result {
+	if a.IsZero() {
+		return b
+	}
+	if b.IsZero() {
+		return a
+	}
+
+	// A requeue without delay wins over a delayed one.
+	if (a.Requeue && a.RequeueAfter == 0) || (b.Requeue && b.RequeueAfter == 0) {
+		return ctrl.Result{Requeue: true}
+	}
+	if a.RequeueAfter < b.RequeueAfter {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,276 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/metrics"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type orderedSubReconciler struct {
+	name        string
+	order       subReconcilerOrder
+	independent bool
+	skippable   bool
+	result      ctrl.Result
+	err         error
+	calls       *[]string
+}
+
+func (r *orderedSubReconciler) Name() string { return r.name }
+
+func (r *orderedSubReconciler) Order() subReconcilerOrder { return r.order }
+
+func (r *orderedSubReconciler) Independent() bool { return r.independent }
+
+func (r *orderedSubReconciler) Skippable() bool { return r.skippable }
+
+func (r *orderedSubReconciler) Reconcile(ctx context.Context, addon *addonsv1alpha1.Addon) (ctrl.Result, error) {
+	*r.calls = append(*r.calls, r.name)
+	return r.result, r.err
+}
+
+func TestRegisterSubReconciler_Order(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	r := &AddonReconciler{}
+	r.registerSubReconciler(&orderedSubReconciler{name: "c", order: 300, calls: &calls})
+	r.registerSubReconciler(&orderedSubReconciler{name: "a", order: 100, calls: &calls})
+	r.registerSubReconciler(&orderedSubReconciler{name: "b1", order: 200, calls: &calls})
+	r.registerSubReconciler(&orderedSubReconciler{name: "b2", order: 200, calls: &calls})
+
+	_, err := r.runSubReconcilers(context.Background(), testutil.NewTestAddonWithCatalogSourceImage())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b1", "b2", "c"}, calls)
+}
+
+func TestRunSubReconcilers_SkipsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	r := &AddonReconciler{reconciled: newReconciledAddons()}
+	r.registerSubReconciler(&orderedSubReconciler{name: "a", order: 100, skippable: true, calls: &calls})
+	r.registerSubReconciler(&orderedSubReconciler{name: "b", order: 200, calls: &calls})
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.Generation = 1
+
+	run := func() {
+		calls = nil
+		_, err := r.runSubReconcilers(context.Background(), addon)
+		require.NoError(t, err)
+	}
+
+	run()
+	assert.Equal(t, []string{"a", "b"}, calls)
+
+	run()
+	assert.Equal(t, []string{"b"}, calls, "unchanged Addon")
+
+	r.reconciled.forget(addon.Name)
+	run()
+	assert.Equal(t, []string{"a", "b"}, calls, "owned object changed")
+
+	addon.Generation = 2
+	run()
+	assert.Equal(t, []string{"a", "b"}, calls, "spec changed")
+
+	addon.Annotations = map[string]string{"test": "true"}
+	run()
+	assert.Equal(t, []string{"a", "b"}, calls, "metadata changed")
+}
+
+func TestRunSubReconcilers(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Reconcilers       []orderedSubReconciler
+		ExpectedCalls     []string
+		ExpectedResult    ctrl.Result
+		ExpectedErr       bool
+		ExpectedStoppedAt string
+	}{
+		"requeue stops the chain": {
+			Reconcilers: []orderedSubReconciler{
+				{name: "a", order: 100, result: ctrl.Result{RequeueAfter: time.Minute}},
+				{name: "b", order: 200},
+			},
+			ExpectedCalls:     []string{"a"},
+			ExpectedResult:    ctrl.Result{RequeueAfter: time.Minute},
+			ExpectedStoppedAt: "a",
+		},
+		"error stops the chain": {
+			Reconcilers: []orderedSubReconciler{
+				{name: "a", order: 100, err: errors.New("boom")},
+				{name: "b", order: 200},
+			},
+			ExpectedCalls:     []string{"a"},
+			ExpectedErr:       true,
+			ExpectedStoppedAt: "a",
+		},
+		"independent requeue is merged": {
+			Reconcilers: []orderedSubReconciler{
+				{name: "a", order: 100, independent: true, result: ctrl.Result{RequeueAfter: time.Minute}},
+				{name: "b", order: 200, result: ctrl.Result{RequeueAfter: 10 * time.Second}},
+				{name: "c", order: 300},
+			},
+			ExpectedCalls:     []string{"a", "b"},
+			ExpectedResult:    ctrl.Result{RequeueAfter: 10 * time.Second},
+			ExpectedStoppedAt: "b",
+		},
+		"independent requeue only": {
+			Reconcilers: []orderedSubReconciler{
+				{name: "a", order: 100, independent: true, result: ctrl.Result{RequeueAfter: time.Minute}},
+				{name: "b", order: 200},
+			},
+			ExpectedCalls:  []string{"a", "b"},
+			ExpectedResult: ctrl.Result{RequeueAfter: time.Minute},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []string
+			r := &AddonReconciler{
+				Recorder: metrics.NewRecorder(false, "test"),
+			}
+			for i := range tc.Reconcilers {
+				tc.Reconcilers[i].calls = &calls
+				r.registerSubReconciler(&tc.Reconcilers[i])
+			}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			result, err := r.runSubReconcilers(context.Background(), addon)
+			if tc.ExpectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.ExpectedResult, result)
+			assert.Equal(t, tc.ExpectedCalls, calls)
+			if tc.ExpectedStoppedAt == "" {
+				assert.Nil(t, addon.Status.LastReconcile)
+			} else {
+				require.NotNil(t, addon.Status.LastReconcile)
+				assert.Equal(t, tc.ExpectedStoppedAt, addon.Status.LastReconcile.StoppedAt)
+			}
+		})
+	}
+}
+
+type phaseSubReconciler struct {
+	orderedSubReconciler
+	conditionType string
+	applies       bool
+}
+
+func (r *phaseSubReconciler) ConditionType() string { return r.conditionType }
+
+func (r *phaseSubReconciler) AppliesTo(addon *addonsv1alpha1.Addon) bool { return r.applies }
+
+func TestRunSubReconcilers_PhaseConditions(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	r := &AddonReconciler{}
+	r.registerSubReconciler(&phaseSubReconciler{
+		orderedSubReconciler: orderedSubReconciler{name: "a", order: 100, calls: &calls},
+		conditionType:        "AReady",
+		applies:              true,
+	})
+	r.registerSubReconciler(&phaseSubReconciler{
+		orderedSubReconciler: orderedSubReconciler{name: "b", order: 200, calls: &calls},
+		conditionType:        "BReady",
+	})
+	r.registerSubReconciler(&phaseSubReconciler{
+		orderedSubReconciler: orderedSubReconciler{
+			name: "c", order: 300, calls: &calls,
+			result: ctrl.Result{RequeueAfter: time.Minute},
+		},
+		conditionType: "CReady",
+		applies:       true,
+	})
+	r.registerSubReconciler(&phaseSubReconciler{
+		orderedSubReconciler: orderedSubReconciler{name: "d", order: 400, calls: &calls},
+		conditionType:        "DReady",
+		applies:              true,
+	})
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	// Left over from before the phase stopped applying.
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type: "BReady", Status: metav1.ConditionTrue, Reason: "Reconciled",
+	})
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type: addonsv1alpha1.Available, Status: metav1.ConditionFalse,
+		Reason: addonsv1alpha1.AddonReasonUnreadyCatalogSource, Message: "CatalogSource not ready",
+	})
+
+	_, err := r.runSubReconcilers(context.Background(), addon)
+	require.NoError(t, err)
+
+	a := meta.FindStatusCondition(addon.Status.Conditions, "AReady")
+	require.NotNil(t, a)
+	assert.Equal(t, metav1.ConditionTrue, a.Status)
+	assert.Equal(t, addonsv1alpha1.PhaseReasonReconciled, a.Reason)
+
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, "BReady"))
+
+	c := meta.FindStatusCondition(addon.Status.Conditions, "CReady")
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, addonsv1alpha1.PhaseReasonProgressing, c.Reason)
+	assert.Equal(t, "CatalogSource not ready", c.Message)
+
+	d := meta.FindStatusCondition(addon.Status.Conditions, "DReady")
+	require.NotNil(t, d)
+	assert.Equal(t, metav1.ConditionUnknown, d.Status)
+	assert.Equal(t, addonsv1alpha1.PhaseReasonBlocked, d.Reason)
+}
+
+func TestRunSubReconcilers_PhaseConditionOnError(t *testing.T) {
+	t.Parallel()
+
+	var calls []string
+	r := &AddonReconciler{}
+	r.registerSubReconciler(&phaseSubReconciler{
+		orderedSubReconciler: orderedSubReconciler{
+			name: "a", order: 100, calls: &calls, err: errors.New("boom"),
+		},
+		conditionType: "AReady",
+		applies:       true,
+	})
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	_, err := r.runSubReconcilers(context.Background(), addon)
+	require.Error(t, err)
+
+	a := meta.FindStatusCondition(addon.Status.Conditions, "AReady")
+	require.NotNil(t, a)
+	assert.Equal(t, metav1.ConditionFalse, a.Status)
+	assert.Equal(t, addonsv1alpha1.PhaseReasonReconcileError, a.Reason)
+	assert.Equal(t, "boom", a.Message)
+}
+
+func TestMergeResults(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Second},
+		mergeResults(ctrl.Result{RequeueAfter: time.Minute}, ctrl.Result{RequeueAfter: time.Second}))
+	assert.Equal(t, ctrl.Result{Requeue: true},
+		mergeResults(ctrl.Result{RequeueAfter: time.Minute}, ctrl.Result{Requeue: true}))
+	assert.Equal(t, ctrl.Result{RequeueAfter: time.Minute},
+		mergeResults(ctrl.Result{}, ctrl.Result{RequeueAfter: time.Minute}))
+}
@@ -0,0 +1,82 @@
+package addon
+
+import (
+	"fmt"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Subscription conditions surfaced in the Addon status in order of precedence,
+// with the reason reported on the Addon and what to do about them.
+var subscriptionHealthConditions = []struct {
+	condType operatorsv1alpha1.SubscriptionConditionType
+	reason   string
+	action   string
+}{
+	{
+		condType: operatorsv1alpha1.SubscriptionResolutionFailed,
+		reason:   addonsv1alpha1.AddonReasonResolutionFailed,
+		action:   "Check that the dependencies of the operator bundle are served by a CatalogSource in the cluster.",
+	},
+	{
+		condType: operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+		reason:   addonsv1alpha1.AddonReasonSubscriptionCatalogSourcesUnhealthy,
+		action:   "Check the CatalogSources of the Subscription and their registry pods.",
+	},
+	{
+		condType: operatorsv1alpha1.SubscriptionInstallPlanPending,
+		reason:   addonsv1alpha1.AddonReasonInstallPlanPending,
+		action:   "Approve the InstallPlan or check it for failed steps.",
+	},
+}
+
+// Copies Subscription conditions that need attention verbatim into the Addon status
+// and reports the SubscriptionHealthy condition from the most severe one.
+func reportSubscriptionHealth(addon *addonsv1alpha1.Addon, subscription *operatorsv1alpha1.Subscription) {
+	status := &addonsv1alpha1.AddonSubscriptionStatus{
+		State: string(subscription.Status.State),
+	}
+	healthy := metav1.Condition{
+		Type:               addonsv1alpha1.SubscriptionHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonSubscriptionHealthy,
+		Message:            "Subscription reports no conditions that need attention.",
+		ObservedGeneration: addon.Generation,
+	}
+
+	for _, hc := range subscriptionHealthConditions {
+		cond := subscription.Status.GetCondition(hc.condType)
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		status.Conditions = append(status.Conditions, addonsv1alpha1.AddonSubscriptionCondition{
+			Type:               string(cond.Type),
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+			LastTransitionTime: cond.LastTransitionTime,
+		})
+
+		if healthy.Status == metav1.ConditionTrue {
+			healthy.Status = metav1.ConditionFalse
+			healthy.Reason = hc.reason
+			healthy.Message = fmt.Sprintf("%s: %s. %s", cond.Type, cond.Message, hc.action)
+		}
+	}
+
+	addon.Status.Subscription = status
+	meta.SetStatusCondition(&addon.Status.Conditions, healthy)
+}
+
+// Returns the message of a failed resolution of the Addon's Subscription, if any.
+func subscriptionResolutionFailure(addon *addonsv1alpha1.Addon) (message string, failed bool) {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.SubscriptionHealthy)
+	if cond == nil || cond.Reason != addonsv1alpha1.AddonReasonResolutionFailed {
+		return "", false
+	}
+	return cond.Message, true
+}
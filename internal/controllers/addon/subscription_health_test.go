@@ -0,0 +1,106 @@
+package addon
+
+import (
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestReportSubscriptionHealth(t *testing.T) {
+	t.Parallel()
+
+	const resolutionMessage = "constraints not satisfiable: bundle mcg-osd-deployer.v1.0.0 " +
+		"requires an operator with package: mcg-operator"
+
+	for name, tc := range map[string]struct {
+		Conditions         []operatorsv1alpha1.SubscriptionCondition
+		ExpectedStatus     metav1.ConditionStatus
+		ExpectedReason     string
+		ExpectedConditions []string
+	}{
+		"healthy": {
+			Conditions: []operatorsv1alpha1.SubscriptionCondition{
+				{
+					Type:   operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+					Status: corev1.ConditionFalse,
+				},
+			},
+			ExpectedStatus: metav1.ConditionTrue,
+			ExpectedReason: addonsv1alpha1.AddonReasonSubscriptionHealthy,
+		},
+		"install plan pending": {
+			Conditions: []operatorsv1alpha1.SubscriptionCondition{
+				{
+					Type:    operatorsv1alpha1.SubscriptionInstallPlanPending,
+					Status:  corev1.ConditionTrue,
+					Reason:  "RequiresApproval",
+					Message: "InstallPlan requires approval",
+				},
+			},
+			ExpectedStatus:     metav1.ConditionFalse,
+			ExpectedReason:     addonsv1alpha1.AddonReasonInstallPlanPending,
+			ExpectedConditions: []string{"InstallPlanPending"},
+		},
+		"resolution failure takes precedence": {
+			Conditions: []operatorsv1alpha1.SubscriptionCondition{
+				{
+					Type:    operatorsv1alpha1.SubscriptionCatalogSourcesUnhealthy,
+					Status:  corev1.ConditionTrue,
+					Reason:  "UnhealthyCatalogSourceFound",
+					Message: "targeted catalogsource addon-1/addon-1-catalog unhealthy",
+				},
+				{
+					Type:    operatorsv1alpha1.SubscriptionResolutionFailed,
+					Status:  corev1.ConditionTrue,
+					Reason:  "ConstraintsNotSatisfiable",
+					Message: resolutionMessage,
+				},
+			},
+			ExpectedStatus:     metav1.ConditionFalse,
+			ExpectedReason:     addonsv1alpha1.AddonReasonResolutionFailed,
+			ExpectedConditions: []string{"ResolutionFailed", "CatalogSourcesUnhealthy"},
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			subscription := &operatorsv1alpha1.Subscription{
+				Status: operatorsv1alpha1.SubscriptionStatus{
+					State:      operatorsv1alpha1.SubscriptionStateUpgradePending,
+					Conditions: tc.Conditions,
+				},
+			}
+			reportSubscriptionHealth(addon, subscription)
+
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.SubscriptionHealthy)
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.ExpectedStatus, cond.Status)
+			assert.Equal(t, tc.ExpectedReason, cond.Reason)
+
+			require.NotNil(t, addon.Status.Subscription)
+			assert.Equal(t, "UpgradePending", addon.Status.Subscription.State)
+			var surfaced []string
+			for _, c := range addon.Status.Subscription.Conditions {
+				surfaced = append(surfaced, c.Type)
+			}
+			assert.Equal(t, tc.ExpectedConditions, surfaced)
+
+			failure, failed := subscriptionResolutionFailure(addon)
+			assert.Equal(t, tc.ExpectedReason == addonsv1alpha1.AddonReasonResolutionFailed, failed)
+			if failed {
+				assert.Contains(t, failure, resolutionMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Releases the objects of the Addon according to its uninstall strategy
+// and clears the Addon from the operator resource cache.
+type cacheFinalizerHandler struct {
+	reconciler *AddonReconciler
+}
+
+func (f *cacheFinalizerHandler) Finalizer() string {
+	return cacheFinalizer
+}
+
+func (f *cacheFinalizerHandler) Order() finalizerOrder {
+	return cacheFinalizerOrder
+}
+
+// Every Addon has objects to release according to its uninstall strategy.
+func (f *cacheFinalizerHandler) AppliesTo(*addonsv1alpha1.Addon) bool {
+	return true
+}
+
+func (f *cacheFinalizerHandler) Finalize(
+	ctx context.Context, addon *addonsv1alpha1.Addon) (bool, error) {
+	if err := f.reconciler.applyUninstallStrategy(ctx, addon); err != nil {
+		return false, fmt.Errorf("applying uninstall strategy: %w", err)
+	}
+
+	// Clear from CSV Event Handler
+	f.reconciler.operatorResourceHandler.Free(addon)
+	return true, nil
+}
+
+// Prepares the objects of the Addon for the removal of its finalizer.
+// Objects still owned by the Addon are garbage collected afterwards,
+// so objects that should be kept are orphaned here.
+func (r *AddonReconciler) applyUninstallStrategy(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	strategy := uninstallStrategy(addon)
+	reportUninstallingStatus(addon, strategy)
+
+	switch strategy {
+	case addonsv1alpha1.UninstallStrategyOrphanWorkloads:
+		if err := r.orphanNamespaces(ctx, addon); err != nil {
+			return err
+		}
+		// The OperatorGroup and the CSV are kept, so the operator keeps running.
+		// Only the Subscription and CatalogSources are garbage collected.
+		return r.orphanOperatorGroup(ctx, addon)
+
+	case addonsv1alpha1.UninstallStrategyOrphanNamespace:
+		if err := r.orphanNamespaces(ctx, addon); err != nil {
+			return err
+		}
+		// The CSV is not owned by the Addon and would survive
+		// the garbage collection of the Subscription.
+		return r.deleteInstalledCSV(ctx, addon)
+
+	default:
+		return nil
+	}
+}
+
+func uninstallStrategy(addon *addonsv1alpha1.Addon) addonsv1alpha1.AddonUninstallStrategy {
+	if len(addon.Spec.UninstallStrategy) == 0 {
+		return addonsv1alpha1.UninstallStrategyCascade
+	}
+	return addon.Spec.UninstallStrategy
+}
+
+// Removes the Addon owner reference from the namespaces in .spec.namespaces.
+func (r *AddonReconciler) orphanNamespaces(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	namespaces, err := getOwnedNamespacesViaCommonLabels(ctx, r.Client, addon)
+	if err != nil {
+		return err
+	}
+
+	wanted := map[string]struct{}{}
+	for _, namespace := range addon.Spec.Namespaces {
+		wanted[namespace.Name] = struct{}{}
+	}
+
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if _, ok := wanted[ns.Name]; !ok {
+			// e.g. the monitoring namespace is not kept.
+			continue
+		}
+		if err := r.removeAddonOwnerReference(ctx, addon, ns); err != nil {
+			return fmt.Errorf("orphaning Namespace %s: %w", ns.Name, err)
+		}
+	}
+	return nil
+}
+
+// Removes the Addon owner reference from its OperatorGroup,
+// which may have been adopted under a different name.
+func (r *AddonReconciler) orphanOperatorGroup(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	namespace, ok := olmInstallNamespace(addon)
+	if !ok {
+		return nil
+	}
+
+	operatorGroups := &operatorsv1.OperatorGroupList{}
+	if err := r.List(ctx, operatorGroups, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("listing OperatorGroups: %w", err)
+	}
+	for i := range operatorGroups.Items {
+		operatorGroup := &operatorGroups.Items[i]
+		if err := r.removeAddonOwnerReference(ctx, addon, operatorGroup); err != nil {
+			return fmt.Errorf("orphaning OperatorGroup %s: %w", operatorGroup.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *AddonReconciler) deleteInstalledCSV(
+	ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	if _, ok := olmInstallNamespace(addon); !ok {
+		return nil
+	}
+
+	namespace, name, found := strings.Cut(addon.Status.LastObservedAvailableCSV, "/")
+	if !found {
+		return nil
+	}
+
+	csv := &operatorsv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	if err := r.Delete(ctx, csv); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("deleting installed CSV: %w", err)
+	}
+	return nil
+}
+
+func (r *AddonReconciler) removeAddonOwnerReference(
+	ctx context.Context, addon *addonsv1alpha1.Addon, obj client.Object) error {
+	var (
+		ownerRefs []metav1.OwnerReference
+		found     bool
+	)
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == addon.UID {
+			found = true
+			continue
+		}
+		ownerRefs = append(ownerRefs, ref)
+	}
+	if !found {
+		return nil
+	}
+
+	obj.SetOwnerReferences(ownerRefs)
+	return r.Update(ctx, obj)
+}
+
+// Returns the install namespace of Addons installed via OLM.
+func olmInstallNamespace(addon *addonsv1alpha1.Addon) (string, bool) {
+	switch addon.Spec.Install.Type {
+	case addonsv1alpha1.OLMOwnNamespace:
+		if addon.Spec.Install.OLMOwnNamespace == nil {
+			return "", false
+		}
+		return addon.Spec.Install.OLMOwnNamespace.Namespace, true
+	case addonsv1alpha1.OLMAllNamespaces:
+		if addon.Spec.Install.OLMAllNamespaces == nil {
+			return "", false
+		}
+		return addon.Spec.Install.OLMAllNamespaces.Namespace, true
+	default:
+		return "", false
+	}
+}
+
+func reportUninstallingStatus(addon *addonsv1alpha1.Addon, strategy addonsv1alpha1.AddonUninstallStrategy) {
+	var reason, msg string
+	switch strategy {
+	case addonsv1alpha1.UninstallStrategyOrphanWorkloads:
+		reason = addonsv1alpha1.AddonReasonOrphanWorkloadsUninstall
+		msg = "Keeping the Addon namespaces and the workloads of its operator."
+	case addonsv1alpha1.UninstallStrategyOrphanNamespace:
+		reason = addonsv1alpha1.AddonReasonOrphanNamespaceUninstall
+		msg = "Keeping the Addon namespaces, while uninstalling its operator."
+	default:
+		reason = addonsv1alpha1.AddonReasonCascadeUninstall
+		msg = "Deleting all objects of the Addon, including its namespaces."
+	}
+
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.Uninstalling,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            msg,
+		ObservedGeneration: addon.Generation,
+	})
+}
@@ -0,0 +1,252 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	operatorsv1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func newTestAddonForUninstall(strategy addonsv1alpha1.AddonUninstallStrategy) *addonsv1alpha1.Addon {
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	addon.UID = types.UID("addon-uid")
+	addon.Spec.Namespaces = []addonsv1alpha1.AddonNamespace{{Name: "addon-1"}}
+	addon.Spec.UninstallStrategy = strategy
+	addon.Status.LastObservedAvailableCSV = "addon-1/addon-1.v1.0.0"
+	return addon
+}
+
+func newTestNamespaceListWithOwner(addon *addonsv1alpha1.Addon) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		list := args.Get(1).(*corev1.NamespaceList)
+		for _, name := range []string{"addon-1", GetMonitoringNamespaceName(addon)} {
+			list.Items = append(list.Items, corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					OwnerReferences: []metav1.OwnerReference{
+						{UID: addon.UID, Name: addon.Name},
+					},
+				},
+			})
+		}
+	}
+}
+
+func TestApplyUninstallStrategy_Cascade(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &AddonReconciler{
+		Client: c,
+		Log:    testutil.NewLogger(t),
+		Scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonForUninstall("")
+
+	err := r.applyUninstallStrategy(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Uninstalling)
+	require.NotNil(t, cond)
+	assert.Equal(t, addonsv1alpha1.AddonReasonCascadeUninstall, cond.Reason)
+}
+
+func TestApplyUninstallStrategy_OrphanNamespace(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &AddonReconciler{
+		Client: c,
+		Log:    testutil.NewLogger(t),
+		Scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonForUninstall(addonsv1alpha1.UninstallStrategyOrphanNamespace)
+
+	c.On("List", testutil.IsContext,
+		mock.IsType(&corev1.NamespaceList{}), mock.Anything).
+		Run(newTestNamespaceListWithOwner(addon)).
+		Return(nil)
+	var orphaned []*corev1.Namespace
+	c.On("Update", testutil.IsContext,
+		mock.IsType(&corev1.Namespace{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			orphaned = append(orphaned, args.Get(1).(*corev1.Namespace))
+		}).
+		Return(nil)
+	var deletedCSV *operatorsv1alpha1.ClusterServiceVersion
+	c.On("Delete", testutil.IsContext,
+		mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deletedCSV = args.Get(1).(*operatorsv1alpha1.ClusterServiceVersion)
+		}).
+		Return(nil)
+
+	err := r.applyUninstallStrategy(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+
+	// The monitoring namespace is not kept.
+	require.Len(t, orphaned, 1)
+	assert.Equal(t, "addon-1", orphaned[0].Name)
+	assert.Empty(t, orphaned[0].OwnerReferences)
+
+	require.NotNil(t, deletedCSV)
+	assert.Equal(t, "addon-1", deletedCSV.Namespace)
+	assert.Equal(t, "addon-1.v1.0.0", deletedCSV.Name)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Uninstalling)
+	require.NotNil(t, cond)
+	assert.Equal(t, addonsv1alpha1.AddonReasonOrphanNamespaceUninstall, cond.Reason)
+}
+
+func TestApplyUninstallStrategy_OrphanWorkloads(t *testing.T) {
+	t.Parallel()
+
+	c := testutil.NewClient()
+	r := &AddonReconciler{
+		Client: c,
+		Log:    testutil.NewLogger(t),
+		Scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+	}
+	addon := newTestAddonForUninstall(addonsv1alpha1.UninstallStrategyOrphanWorkloads)
+
+	c.On("List", testutil.IsContext,
+		mock.IsType(&corev1.NamespaceList{}), mock.Anything).
+		Run(newTestNamespaceListWithOwner(addon)).
+		Return(nil)
+	c.On("Update", testutil.IsContext,
+		mock.IsType(&corev1.Namespace{}), mock.Anything).
+		Return(nil)
+	c.On("List", testutil.IsContext,
+		mock.IsType(&operatorsv1.OperatorGroupList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*operatorsv1.OperatorGroupList)
+			list.Items = []operatorsv1.OperatorGroup{{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "addon-1-x7k2p",
+					OwnerReferences: []metav1.OwnerReference{
+						{UID: "other-uid"},
+						{UID: addon.UID},
+					},
+				},
+			}}
+		}).
+		Return(nil)
+	var orphanedOG *operatorsv1.OperatorGroup
+	c.On("Update", testutil.IsContext,
+		mock.IsType(&operatorsv1.OperatorGroup{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			orphanedOG = args.Get(1).(*operatorsv1.OperatorGroup)
+		}).
+		Return(nil)
+
+	err := r.applyUninstallStrategy(context.Background(), addon)
+	require.NoError(t, err)
+	c.AssertExpectations(t)
+	// The operator keeps running.
+	c.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+
+	require.NotNil(t, orphanedOG)
+	assert.Equal(t, []metav1.OwnerReference{{UID: "other-uid"}}, orphanedOG.OwnerReferences)
+
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Uninstalling)
+	require.NotNil(t, cond)
+	assert.Equal(t, addonsv1alpha1.AddonReasonOrphanWorkloadsUninstall, cond.Reason)
+}
+
+func TestApplyUninstallStrategy_KeptOLMObjects(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Strategy addonsv1alpha1.AddonUninstallStrategy
+		// Kept by removing the Addon owner reference.
+		KeepsOperatorGroup bool
+		// The CSV is not owned by the Addon. It is deleted explicitly,
+		// when its namespace is kept, but the operator is not.
+		DeletesCSV bool
+	}{
+		// Everything goes with the install namespace.
+		"Cascade": {
+			Strategy: addonsv1alpha1.UninstallStrategyCascade,
+		},
+		"OrphanWorkloads": {
+			Strategy:           addonsv1alpha1.UninstallStrategyOrphanWorkloads,
+			KeepsOperatorGroup: true,
+		},
+		"OrphanNamespace": {
+			Strategy:   addonsv1alpha1.UninstallStrategyOrphanNamespace,
+			DeletesCSV: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &AddonReconciler{
+				Client: c,
+				Log:    testutil.NewLogger(t),
+				Scheme: testutil.NewTestSchemeWithAddonsv1alpha1(),
+			}
+			addon := newTestAddonForUninstall(tc.Strategy)
+
+			c.On("List", testutil.IsContext,
+				mock.IsType(&corev1.NamespaceList{}), mock.Anything).
+				Return(nil).Maybe()
+			c.On("List", testutil.IsContext,
+				mock.IsType(&operatorsv1.OperatorGroupList{}), mock.Anything).
+				Run(func(args mock.Arguments) {
+					list := args.Get(1).(*operatorsv1.OperatorGroupList)
+					list.Items = []operatorsv1.OperatorGroup{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            "addon-1",
+							OwnerReferences: []metav1.OwnerReference{{UID: addon.UID}},
+						},
+					}}
+				}).
+				Return(nil).Maybe()
+			c.On("Update", testutil.IsContext,
+				mock.IsType(&operatorsv1.OperatorGroup{}), mock.Anything).
+				Return(nil).Maybe()
+			c.On("Delete", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything).
+				Return(nil).Maybe()
+
+			err := r.applyUninstallStrategy(context.Background(), addon)
+			require.NoError(t, err)
+
+			if tc.KeepsOperatorGroup {
+				c.AssertCalled(t, "Update", testutil.IsContext,
+					mock.IsType(&operatorsv1.OperatorGroup{}), mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Update", testutil.IsContext,
+					mock.IsType(&operatorsv1.OperatorGroup{}), mock.Anything)
+			}
+			if tc.DeletesCSV {
+				c.AssertCalled(t, "Delete", testutil.IsContext,
+					mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "Delete", testutil.IsContext,
+					mock.IsType(&operatorsv1alpha1.ClusterServiceVersion{}), mock.Anything)
+			}
+			// Subscriptions and CatalogSources are always garbage collected.
+			c.AssertNotCalled(t, "Update", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.Subscription{}), mock.Anything)
+			c.AssertNotCalled(t, "Update", testutil.IsContext,
+				mock.IsType(&operatorsv1alpha1.CatalogSource{}), mock.Anything)
+		})
+	}
+}
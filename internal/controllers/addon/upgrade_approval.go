@@ -0,0 +1,72 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Interval in which OCM is polled for the approval of Manual upgrades.
+const upgradeApprovalPollInterval = time.Minute
+
+type ocmModeSource interface {
+	IsOCMDisabled() bool
+}
+
+// Keeps the current image of the CatalogSource for Manual upgrades,
+// until OCM approved or acknowledged the upgrade policy for the current version.
+// The initial install is never held back, neither are upgrades while OCM is disabled.
+func (r *olmReconciler) awaitUpgradeApproval(
+	ctx context.Context, addon *addonsv1alpha1.Addon,
+	desiredCatalogSource *operatorsv1alpha1.CatalogSource,
+) error {
+	if addon.UpgradeApprovedForCurrentVersion() {
+		return nil
+	}
+	if r.ocmMode != nil && r.ocmMode.IsOCMDisabled() {
+		return nil
+	}
+
+	currentCatalogSource := &operatorsv1alpha1.CatalogSource{}
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desiredCatalogSource), currentCatalogSource); err != nil {
+		if k8sApiErrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("getting CatalogSource: %w", err)
+	}
+
+	if currentCatalogSource.Spec.Image == desiredCatalogSource.Spec.Image {
+		// Nothing to roll out or already deferred until the next maintenance window.
+		return nil
+	}
+
+	desiredCatalogSource.Spec.Image = currentCatalogSource.Spec.Image
+	reportAwaitingUpgradeApproval(addon)
+	return nil
+}
+
+func reportAwaitingUpgradeApproval(addon *addonsv1alpha1.Addon) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:   addonsv1alpha1.UpgradeDeferred,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonAwaitingUpgradeApproval,
+		Message: fmt.Sprintf(
+			"CatalogSource image update to version %q is deferred until the upgrade policy is approved in OCM.",
+			addon.Spec.Version),
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+func awaitingUpgradeApproval(addon *addonsv1alpha1.Addon) bool {
+	cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradeDeferred)
+	return cond != nil && cond.Status == metav1.ConditionTrue &&
+		cond.Reason == addonsv1alpha1.AddonReasonAwaitingUpgradeApproval
+}
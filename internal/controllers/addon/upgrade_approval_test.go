@@ -0,0 +1,119 @@
+package addon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestAwaitUpgradeApproval(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		mode          addonsv1alpha1.AddonUpgradeMode
+		status        *addonsv1alpha1.AddonUpgradePolicyStatus
+		ocmDisabled   bool
+		expectedImage string
+		awaiting      bool
+	}{
+		"automatic": {
+			mode:          addonsv1alpha1.UpgradeModeAutomatic,
+			expectedImage: "quay.io/osd-addons/test:new",
+		},
+		"manual scheduled": {
+			mode: addonsv1alpha1.UpgradeModeManual,
+			status: &addonsv1alpha1.AddonUpgradePolicyStatus{
+				Version: "1.1.0",
+				Value:   addonsv1alpha1.AddonUpgradePolicyValueScheduled,
+			},
+			expectedImage: "quay.io/osd-addons/test:old",
+			awaiting:      true,
+		},
+		"manual approved for previous version": {
+			mode: addonsv1alpha1.UpgradeModeManual,
+			status: &addonsv1alpha1.AddonUpgradePolicyStatus{
+				Version: "1.0.0",
+				Value:   addonsv1alpha1.AddonUpgradePolicyValueApproved,
+			},
+			expectedImage: "quay.io/osd-addons/test:old",
+			awaiting:      true,
+		},
+		"manual with ocm disabled": {
+			mode: addonsv1alpha1.UpgradeModeManual,
+			status: &addonsv1alpha1.AddonUpgradePolicyStatus{
+				Version: "1.0.0",
+				Value:   addonsv1alpha1.AddonUpgradePolicyValueCompleted,
+			},
+			ocmDisabled:   true,
+			expectedImage: "quay.io/osd-addons/test:new",
+		},
+		"manual approved": {
+			mode: addonsv1alpha1.UpgradeModeManual,
+			status: &addonsv1alpha1.AddonUpgradePolicyStatus{
+				Version: "1.1.0",
+				Value:   addonsv1alpha1.AddonUpgradePolicyValueApproved,
+			},
+			expectedImage: "quay.io/osd-addons/test:new",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := testutil.NewClient()
+			r := &olmReconciler{client: c, ocmMode: ocmModeStub(tc.ocmDisabled)}
+
+			addon := testutil.NewTestAddonWithCatalogSourceImage()
+			addon.Spec.Version = "1.1.0"
+			addon.Spec.UpgradePolicy = &addonsv1alpha1.AddonUpgradePolicy{
+				ID:   "123",
+				Mode: tc.mode,
+			}
+			addon.Status.UpgradePolicy = tc.status
+
+			desired := testutil.NewTestCatalogSource()
+			desired.Spec.Image = "quay.io/osd-addons/test:new"
+
+			c.On("Get", testutil.IsContext, client.ObjectKeyFromObject(desired),
+				testutil.IsOperatorsV1Alpha1CatalogSourcePtr, mock.Anything).
+				Run(func(args mock.Arguments) {
+					current := args.Get(2).(*operatorsv1alpha1.CatalogSource)
+					current.Spec.Image = "quay.io/osd-addons/test:old"
+				}).
+				Return(nil)
+
+			err := r.awaitUpgradeApproval(context.Background(), addon, desired)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedImage, desired.Spec.Image)
+			assert.Equal(t, tc.awaiting, awaitingUpgradeApproval(addon))
+
+			result := requeueForDeferredUpgrade(addon, time.Now())
+			if tc.awaiting {
+				cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradeDeferred)
+				require.NotNil(t, cond)
+				assert.Contains(t, cond.Message, `"1.1.0"`)
+				assert.Equal(t, upgradeApprovalPollInterval, result.RequeueAfter)
+			} else {
+				assert.True(t, result.IsZero())
+			}
+		})
+	}
+}
+
+type ocmModeStub bool
+
+func (s ocmModeStub) IsOCMDisabled() bool {
+	return bool(s)
+}
@@ -0,0 +1,127 @@
+package addon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/catalogregistry"
+)
+
+// CatalogRegistry reads packages and bundles from the gRPC registry API of CatalogSources.
+type CatalogRegistry interface {
+	GetPackage(ctx context.Context, address, name string) (catalogregistry.Package, error)
+	GetBundle(ctx context.Context, address, packageName, channelName, csvName string) (catalogregistry.Bundle, error)
+}
+
+// Upgrade path OLM takes from the installed CSV to the head of a channel.
+type upgradePlan struct {
+	// CSVs upgraded through in order, ending with the channel head.
+	// Empty if the installed CSV is the channel head.
+	steps []string
+	// Explains why there is no upgrade path, empty if the plan is valid.
+	invalid string
+}
+
+// Plans the upgrade from the installed CSV to the channel head,
+// walking the upgrade edges of the channel's bundles in the catalog registry,
+// starting with the channel head and following the CSVs they replace.
+// A bundle upgrades from the installed CSV, if it replaces or skips it,
+// or if its olm.skipRange includes the installed version.
+// Otherwise the catalog skips versions and OLM can not resolve the Subscription.
+func planUpgrade(
+	ctx context.Context, registry CatalogRegistry, address, packageName string,
+	channel catalogregistry.Channel, installedCSV string, installedVersion semver.Version,
+) (upgradePlan, error) {
+	skipsVersions := upgradePlan{invalid: fmt.Sprintf(
+		"upgrading %s to %s skips versions, no bundle of channel %s replaces or skips it "+
+			"or declares an olm.skipRange including %s",
+		installedCSV, channel.CSVName, channel.Name, installedVersion)}
+
+	var steps []string
+	walked := map[string]struct{}{}
+	for csvName := channel.CSVName; csvName != installedCSV; {
+		if _, ok := walked[csvName]; ok {
+			return upgradePlan{invalid: fmt.Sprintf(
+				"the replaces chain of %s loops at %s", channel.CSVName, csvName)}, nil
+		}
+		walked[csvName] = struct{}{}
+
+		bundle, err := registry.GetBundle(ctx, address, packageName, channel.Name, csvName)
+		if errors.Is(err, catalogregistry.ErrNotFound) {
+			// Replaced CSVs may have been pruned from the catalog.
+			return skipsVersions, nil
+		}
+		if err != nil {
+			return upgradePlan{}, err
+		}
+		steps = append([]string{csvName}, steps...)
+
+		if upgradesFrom(bundle, installedCSV) {
+			return upgradePlan{steps: steps}, nil
+		}
+		if len(bundle.SkipRange) > 0 {
+			inRange, err := semver.ParseRange(bundle.SkipRange)
+			if err != nil {
+				return upgradePlan{invalid: fmt.Sprintf(
+					"olm.skipRange of %s is invalid: %v", csvName, err)}, nil
+			}
+			if inRange(installedVersion) {
+				return upgradePlan{steps: steps}, nil
+			}
+		}
+		if len(bundle.Replaces) == 0 {
+			return skipsVersions, nil
+		}
+		csvName = bundle.Replaces
+	}
+	return upgradePlan{steps: steps}, nil
+}
+
+// Whether the bundle replaces or skips the given CSV.
+func upgradesFrom(bundle catalogregistry.Bundle, csvName string) bool {
+	if bundle.Replaces == csvName {
+		return true
+	}
+	for _, skipped := range bundle.Skips {
+		if skipped == csvName {
+			return true
+		}
+	}
+	return false
+}
+
+// Address of the gRPC registry serving the given CatalogSource.
+func catalogRegistryAddress(catalogSource *operatorsv1alpha1.CatalogSource) string {
+	if state := catalogSource.Status.GRPCConnectionState; state != nil && len(state.Address) > 0 {
+		return state.Address
+	}
+	if service := catalogSource.Status.RegistryServiceStatus; service != nil {
+		return service.Address()
+	}
+	return ""
+}
+
+func (p upgradePlan) valid() bool {
+	return len(p.invalid) == 0
+}
+
+// Reports that a new catalog image is not rolled out,
+// because its channel graph has no upgrade path from the installed CSV.
+func reportUpgradePathInvalid(addon *addonsv1alpha1.Addon, reason, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.UpgradePathInvalid,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+	reportPendingStatus(addon, reason,
+		fmt.Sprintf("Refusing to switch CatalogSource image: %s", message))
+}
@@ -0,0 +1,149 @@
+package addon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/addon-operator/internal/catalogregistry"
+)
+
+// Serves packages and bundles of a single catalog.
+type fakeCatalogRegistry struct {
+	packages []catalogregistry.Package
+	bundles  []catalogregistry.Bundle
+}
+
+func (r *fakeCatalogRegistry) GetPackage(
+	_ context.Context, _, name string,
+) (catalogregistry.Package, error) {
+	for _, pkg := range r.packages {
+		if pkg.Name == name {
+			return pkg, nil
+		}
+	}
+	return catalogregistry.Package{}, catalogregistry.ErrNotFound
+}
+
+func (r *fakeCatalogRegistry) GetBundle(
+	_ context.Context, _, packageName, channelName, csvName string,
+) (catalogregistry.Bundle, error) {
+	for _, bundle := range r.bundles {
+		if bundle.PackageName == packageName &&
+			bundle.ChannelName == channelName &&
+			bundle.CSVName == csvName {
+			return bundle, nil
+		}
+	}
+	return catalogregistry.Bundle{}, catalogregistry.ErrNotFound
+}
+
+func newTestBundle(csvName, replaces, skipRange string, skips ...string) catalogregistry.Bundle {
+	return catalogregistry.Bundle{
+		CSVName:     csvName,
+		PackageName: "reference-addon",
+		ChannelName: "alpha",
+		Replaces:    replaces,
+		SkipRange:   skipRange,
+		Skips:       skips,
+	}
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	t.Parallel()
+
+	registry := &fakeCatalogRegistry{
+		bundles: []catalogregistry.Bundle{
+			newTestBundle("reference-addon.v0.3.0", "reference-addon.v0.1.0", ">=0.1.0 <0.3.0"),
+			newTestBundle("reference-addon.v0.1.0", "reference-addon.v0.0.9", "", "reference-addon.v0.0.8"),
+			newTestBundle("reference-addon.v0.0.9", "", ""),
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		Registry         *fakeCatalogRegistry
+		InstalledCSV     string
+		InstalledVersion string
+		ExpectedSteps    []string
+		ExpectedValid    bool
+	}{
+		"channel head": {
+			Registry:         registry,
+			InstalledCSV:     "reference-addon.v0.3.0",
+			InstalledVersion: "0.3.0",
+			ExpectedValid:    true,
+		},
+		"replaces chain": {
+			Registry:         registry,
+			InstalledCSV:     "reference-addon.v0.0.9",
+			InstalledVersion: "0.0.9",
+			ExpectedSteps:    []string{"reference-addon.v0.1.0", "reference-addon.v0.3.0"},
+			ExpectedValid:    true,
+		},
+		"skips": {
+			Registry:         registry,
+			InstalledCSV:     "reference-addon.v0.0.8",
+			InstalledVersion: "0.0.8",
+			ExpectedSteps:    []string{"reference-addon.v0.1.0", "reference-addon.v0.3.0"},
+			ExpectedValid:    true,
+		},
+		"skip range": {
+			Registry:         registry,
+			InstalledCSV:     "reference-addon.v0.2.0",
+			InstalledVersion: "0.2.0",
+			ExpectedSteps:    []string{"reference-addon.v0.3.0"},
+			ExpectedValid:    true,
+		},
+		"skips versions outside of skip range": {
+			Registry:         registry,
+			InstalledCSV:     "reference-addon.v0.0.5",
+			InstalledVersion: "0.0.5",
+		},
+		"replaced bundle pruned": {
+			Registry: &fakeCatalogRegistry{
+				bundles: []catalogregistry.Bundle{
+					newTestBundle("reference-addon.v0.3.0", "reference-addon.v0.2.0", ""),
+				},
+			},
+			InstalledCSV:     "reference-addon.v0.1.0",
+			InstalledVersion: "0.1.0",
+		},
+		"invalid skip range": {
+			Registry: &fakeCatalogRegistry{
+				bundles: []catalogregistry.Bundle{
+					newTestBundle("reference-addon.v0.3.0", "", "not a range"),
+				},
+			},
+			InstalledCSV:     "reference-addon.v0.2.0",
+			InstalledVersion: "0.2.0",
+		},
+		"replaces loop": {
+			Registry: &fakeCatalogRegistry{
+				bundles: []catalogregistry.Bundle{
+					newTestBundle("reference-addon.v0.3.0", "reference-addon.v0.2.0", ""),
+					newTestBundle("reference-addon.v0.2.0", "reference-addon.v0.3.0", ""),
+				},
+			},
+			InstalledCSV:     "reference-addon.v0.1.0",
+			InstalledVersion: "0.1.0",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := planUpgrade(context.Background(), tc.Registry, "catalog:50051", "reference-addon",
+				catalogregistry.Channel{Name: "alpha", CSVName: "reference-addon.v0.3.0"},
+				tc.InstalledCSV, semver.MustParse(tc.InstalledVersion))
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedValid, plan.valid(), plan.invalid)
+			if tc.ExpectedValid {
+				assert.Equal(t, tc.ExpectedSteps, plan.steps)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Interval queued upgrades recheck for a free slot at,
+// in addition to being requeued when another Addon changes.
+const upgradeQueuePollInterval = time.Minute
+
+// Maximum number of Addons upgrading at the same time, configured via the AddonOperator.
+// 0 does not limit concurrent upgrades.
+type upgradeConcurrency struct {
+	max int64
+}
+
+func (c *upgradeConcurrency) set(max int) {
+	atomic.StoreInt64(&c.max, int64(max))
+}
+
+func (c *upgradeConcurrency) limit() int {
+	if c == nil {
+		return 0
+	}
+	return int(atomic.LoadInt64(&c.max))
+}
+
+// Sets the maximum number of Addons upgrading at the same time. Concurrency safe.
+func (r *AddonReconciler) SetMaxConcurrentAddonUpgrades(max int) {
+	r.upgradeConcurrency.set(max)
+}
+
+// Field index of Addons by the state of their upgrade scheduled by an OCM upgrade policy.
+const upgradeStateIndexKey = ".status.upgradeState"
+
+const (
+	upgradeStateQueued     = "Queued"
+	upgradeStateRollingOut = "RollingOut"
+)
+
+// Paused and deleted Addons are not indexed, they would hold back upgrades indefinitely.
+func indexAddonUpgradeState(obj client.Object) []string {
+	addon, ok := obj.(*addonsv1alpha1.Addon)
+	if !ok || addon.Spec.UpgradePolicy == nil ||
+		addon.Spec.Paused || pausedByAnnotation(addon) || !addon.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	switch {
+	case upgradeQueued(addon):
+		return []string{upgradeStateQueued}
+	case upgradeRollingOut(addon):
+		return []string{upgradeStateRollingOut}
+	}
+	return nil
+}
+
+func setupUpgradeStateIndex(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(
+		context.Background(), &addonsv1alpha1.Addon{}, upgradeStateIndexKey, indexAddonUpgradeState)
+}
+
+// Holds back the upgrade of the Addon while as many other Addons are upgrading
+// as the AddonOperator allows. Only upgrades scheduled by an OCM upgrade policy
+// and rolled out within an open maintenance window overlap and are queued,
+// upgrades outside of all windows are deferred instead.
+// Queued upgrades start in the order they were queued,
+// the position of the Addon is reported in .status.upgradeQueue.
+// Returns true, if the Addon has to wait.
+func (r *AddonReconciler) waitForUpgradeSlot(
+	ctx context.Context, addon *addonsv1alpha1.Addon, now time.Time) (waiting bool, err error) {
+	max := r.upgradeConcurrency.limit()
+	if max == 0 || addon.Spec.UpgradePolicy == nil ||
+		!addonIsBeingUpgraded(addon) || addonUpgradeStarted(addon) {
+		dequeueUpgrade(addon)
+		return false, nil
+	}
+
+	if windows := maintenanceWindows(addon); len(windows) > 0 {
+		open, _, err := evaluateMaintenanceWindows(windows, now)
+		if err != nil {
+			return false, err
+		}
+		if !open {
+			dequeueUpgrade(addon)
+			return false, nil
+		}
+	}
+
+	queuedList := &addonsv1alpha1.AddonList{}
+	if err := r.List(ctx, queuedList, client.MatchingFields{
+		upgradeStateIndexKey: upgradeStateQueued,
+	}); err != nil {
+		return false, fmt.Errorf("listing Addons with a queued upgrade: %w", err)
+	}
+	rollingOutList := &addonsv1alpha1.AddonList{}
+	if err := r.List(ctx, rollingOutList, client.MatchingFields{
+		upgradeStateIndexKey: upgradeStateRollingOut,
+	}); err != nil {
+		return false, fmt.Errorf("listing Addons with a rolling out upgrade: %w", err)
+	}
+
+	if addon.Status.UpgradeQueue == nil {
+		addon.Status.UpgradeQueue = &addonsv1alpha1.AddonUpgradeQueueStatus{
+			QueuedSince: metav1.NewTime(now),
+		}
+	}
+	queue := []queuedUpgrade{{name: addon.Name, since: addon.Status.UpgradeQueue.QueuedSince}}
+	for _, other := range queuedList.Items {
+		if other.Name == addon.Name {
+			continue
+		}
+		queue = append(queue, queuedUpgrade{name: other.Name, since: other.Status.UpgradeQueue.QueuedSince})
+	}
+	var upgrading int
+	for _, other := range rollingOutList.Items {
+		if other.Name != addon.Name {
+			upgrading++
+		}
+	}
+
+	sort.Slice(queue, func(i, j int) bool {
+		if !queue[i].since.Equal(&queue[j].since) {
+			return queue[i].since.Before(&queue[j].since)
+		}
+		return queue[i].name < queue[j].name
+	})
+	var position int
+	for i, q := range queue {
+		if q.name == addon.Name {
+			position = i + 1
+			break
+		}
+	}
+
+	if position <= max-upgrading {
+		dequeueUpgrade(addon)
+		return false, nil
+	}
+
+	addon.Status.UpgradeQueue.Position = position
+	reportUpgradeQueued(addon, fmt.Sprintf(
+		"Upgrade to version %q is queued at position %d, %d of at most %d Addons are upgrading.",
+		addon.Spec.Version, position, upgrading, max))
+	return true, nil
+}
+
+type queuedUpgrade struct {
+	name  string
+	since metav1.Time
+}
+
+// Whether the Addon started upgrading and is not held back by
+// its maintenance windows or a pending approval.
+func upgradeRollingOut(addon *addonsv1alpha1.Addon) bool {
+	return addonUpgradeStarted(addon) && !upgradeDeferred(addon)
+}
+
+func upgradeQueued(addon *addonsv1alpha1.Addon) bool {
+	return addon.Status.UpgradeQueue != nil
+}
+
+// Enqueues all Addons with a queued upgrade,
+// so they recheck for a free slot when another Addon changes.
+func (r *AddonReconciler) enqueueQueuedUpgrades(obj client.Object) []reconcile.Request {
+	addon, ok := obj.(*addonsv1alpha1.Addon)
+	if !ok || r.upgradeConcurrency.limit() == 0 {
+		return nil
+	}
+
+	addonList := &addonsv1alpha1.AddonList{}
+	if err := r.List(context.Background(), addonList, client.MatchingFields{
+		upgradeStateIndexKey: upgradeStateQueued,
+	}); err != nil {
+		r.Log.Error(err, "listing Addons to requeue queued upgrades")
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, other := range addonList.Items {
+		if other.Name == addon.Name {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: other.Name},
+		})
+	}
+	return reqs
+}
+
+func reportUpgradeQueued(addon *addonsv1alpha1.Addon, message string) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.UpgradeQueued,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonReasonUpgradeQueued,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
+	})
+}
+
+// Removes the Addon from the upgrade queue.
+func dequeueUpgrade(addon *addonsv1alpha1.Addon) {
+	addon.Status.UpgradeQueue = nil
+	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.UpgradeQueued)
+}
@@ -0,0 +1,252 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestWaitForUpgradeSlot(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	upgradeStarted := metav1.Condition{
+		Type:   addonsv1alpha1.UpgradeStarted,
+		Status: metav1.ConditionTrue,
+	}
+	upgradeDeferred := metav1.Condition{
+		Type:   addonsv1alpha1.UpgradeDeferred,
+		Status: metav1.ConditionTrue,
+	}
+
+	for name, tc := range map[string]struct {
+		Max                  int
+		Others               []addonsv1alpha1.Addon
+		QueuedSince          time.Time
+		UpgradePolicy        *addonsv1alpha1.AddonUpgradePolicy
+		WithoutUpgradePolicy bool
+		ExpectedWaiting      bool
+		ExpectedPosition     int
+	}{
+		"unlimited": {
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted),
+			},
+		},
+		"free slot": {
+			Max: 2,
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted),
+			},
+		},
+		"no free slot": {
+			Max: 1,
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted),
+			},
+			ExpectedWaiting:  true,
+			ExpectedPosition: 1,
+		},
+		"not scheduled by an OCM upgrade policy": {
+			Max: 1,
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted),
+			},
+			WithoutUpgradePolicy: true,
+		},
+		"outside of maintenance windows": {
+			Max: 1,
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted),
+			},
+			UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+				ID: "123",
+				MaintenanceWindows: []addonsv1alpha1.AddonMaintenanceWindow{{
+					Schedule: fmt.Sprintf("%d * * * *", (now.Minute()+30)%60),
+					Duration: metav1.Duration{Duration: time.Minute},
+				}},
+			},
+		},
+		"upgrade without OCM upgrade policy holds no slot": {
+			Max: 1,
+			Others: func() []addonsv1alpha1.Addon {
+				a := newUpgradeQueueTestAddon("logging", upgradeStarted)
+				a.Spec.UpgradePolicy = nil
+				return []addonsv1alpha1.Addon{a}
+			}(),
+		},
+		"deferred upgrade holds no slot": {
+			Max: 1,
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted, upgradeDeferred),
+			},
+		},
+		"paused upgrade holds no slot": {
+			Max: 1,
+			Others: func() []addonsv1alpha1.Addon {
+				a := newUpgradeQueueTestAddon("logging", upgradeStarted)
+				a.Spec.Paused = true
+				return []addonsv1alpha1.Addon{a}
+			}(),
+		},
+		"queued behind earlier upgrade": {
+			Max:         1,
+			QueuedSince: now,
+			Others: []addonsv1alpha1.Addon{
+				newUpgradeQueueTestAddon("logging", upgradeStarted),
+				queuedUpgradeTestAddon("monitoring", now.Add(-time.Minute)),
+				queuedUpgradeTestAddon("tracing", now.Add(time.Minute)),
+			},
+			ExpectedWaiting:  true,
+			ExpectedPosition: 2,
+		},
+		"first in queue gets free slot": {
+			Max:         1,
+			QueuedSince: now.Add(-time.Hour),
+			Others: []addonsv1alpha1.Addon{
+				queuedUpgradeTestAddon("monitoring", now.Add(-time.Minute)),
+			},
+		},
+		"later in queue waits for free slot": {
+			Max:         1,
+			QueuedSince: now,
+			Others: []addonsv1alpha1.Addon{
+				queuedUpgradeTestAddon("monitoring", now.Add(-time.Minute)),
+			},
+			ExpectedWaiting:  true,
+			ExpectedPosition: 2,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newUpgradeStateIndexTestClient(tc.Others)
+
+			r := &AddonReconciler{Client: c, upgradeConcurrency: &upgradeConcurrency{}}
+			r.SetMaxConcurrentAddonUpgrades(tc.Max)
+
+			addon := newUpgradeQueueTestAddon("backup")
+			if !tc.QueuedSince.IsZero() {
+				addon = queuedUpgradeTestAddon("backup", tc.QueuedSince)
+			}
+			if tc.UpgradePolicy != nil {
+				addon.Spec.UpgradePolicy = tc.UpgradePolicy
+			}
+			if tc.WithoutUpgradePolicy {
+				addon.Spec.UpgradePolicy = nil
+			}
+
+			waiting, err := r.waitForUpgradeSlot(context.Background(), &addon, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedWaiting, waiting)
+			assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.Available))
+
+			if !tc.ExpectedWaiting {
+				assert.Nil(t, addon.Status.UpgradeQueue)
+				assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradeQueued))
+				return
+			}
+			require.NotNil(t, addon.Status.UpgradeQueue)
+			assert.Equal(t, tc.ExpectedPosition, addon.Status.UpgradeQueue.Position)
+			if !tc.QueuedSince.IsZero() {
+				assert.True(t, tc.QueuedSince.Equal(addon.Status.UpgradeQueue.QueuedSince.Time))
+			}
+			cond := meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradeQueued)
+			require.NotNil(t, cond)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status)
+			assert.Equal(t, addonsv1alpha1.AddonReasonUpgradeQueued, cond.Reason)
+		})
+	}
+}
+
+func TestWaitForUpgradeSlot_NotUpgrading(t *testing.T) {
+	t.Parallel()
+
+	r := &AddonReconciler{Client: testutil.NewClient(), upgradeConcurrency: &upgradeConcurrency{}}
+	r.SetMaxConcurrentAddonUpgrades(1)
+
+	addon := queuedUpgradeTestAddon("backup", time.Now())
+	addon.Status.ObservedVersion = addon.Spec.Version
+	reportUpgradeQueued(&addon, "queued")
+
+	waiting, err := r.waitForUpgradeSlot(context.Background(), &addon, time.Now())
+	require.NoError(t, err)
+	assert.False(t, waiting)
+	assert.Nil(t, addon.Status.UpgradeQueue)
+	assert.Nil(t, meta.FindStatusCondition(addon.Status.Conditions, addonsv1alpha1.UpgradeQueued))
+}
+
+func TestEnqueueQueuedUpgrades(t *testing.T) {
+	t.Parallel()
+
+	c := newUpgradeStateIndexTestClient([]addonsv1alpha1.Addon{
+		newUpgradeQueueTestAddon("logging"),
+		queuedUpgradeTestAddon("monitoring", time.Now()),
+	})
+
+	r := &AddonReconciler{Client: c, upgradeConcurrency: &upgradeConcurrency{}}
+	backup := newUpgradeQueueTestAddon("backup")
+	assert.Empty(t, r.enqueueQueuedUpgrades(&backup))
+
+	r.SetMaxConcurrentAddonUpgrades(1)
+	reqs := r.enqueueQueuedUpgrades(&backup)
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "monitoring", reqs[0].Name)
+}
+
+// Returns a client listing the given Addons matching the upgrade state index.
+func newUpgradeStateIndexTestClient(addons []addonsv1alpha1.Addon) *testutil.Client {
+	c := testutil.NewClient()
+	c.On("List", testutil.IsContext, mock.IsType(&addonsv1alpha1.AddonList{}), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(1).(*addonsv1alpha1.AddonList)
+			fields := args.Get(2).([]client.ListOption)[0].(client.MatchingFields)
+			list.Items = nil
+			for i := range addons {
+				for _, state := range indexAddonUpgradeState(&addons[i]) {
+					if state == fields[upgradeStateIndexKey] {
+						list.Items = append(list.Items, addons[i])
+					}
+				}
+			}
+		}).
+		Return(nil).
+		Maybe()
+	return c
+}
+
+// Returns an Addon upgrading from version 1.0.0 to 2.0.0, scheduled by an OCM upgrade policy.
+func newUpgradeQueueTestAddon(name string, conds ...metav1.Condition) addonsv1alpha1.Addon {
+	return addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: addonsv1alpha1.AddonSpec{
+			Version:       "2.0.0",
+			UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{ID: "123"},
+		},
+		Status: addonsv1alpha1.AddonStatus{
+			ObservedVersion: "1.0.0",
+			Conditions:      conds,
+		},
+	}
+}
+
+func queuedUpgradeTestAddon(name string, since time.Time) addonsv1alpha1.Addon {
+	a := newUpgradeQueueTestAddon(name)
+	a.Status.UpgradeQueue = &addonsv1alpha1.AddonUpgradeQueueStatus{
+		QueuedSince: metav1.NewTime(since),
+	}
+	return a
+}
@@ -0,0 +1,233 @@
+package addon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	promconfig "github.com/prometheus/common/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+// Default time alerts are silenced for during an addon upgrade.
+const defaultUpgradeSilenceDuration = time.Hour
+
+// ErrSilenceNotFound is returned when expiring a silence unknown to the Alertmanager.
+var ErrSilenceNotFound = errors.New("silence not found")
+
+// Silence of all alerts matching the label matchers.
+type Silence struct {
+	Matchers []SilenceMatcher
+	StartsAt time.Time
+	EndsAt   time.Time
+	Comment  string
+}
+
+// SilenceMatcher matches the value of an alert label.
+type SilenceMatcher struct {
+	Name    string
+	Value   string
+	IsRegex bool
+}
+
+// AlertSilencer manages silences in an Alertmanager.
+type AlertSilencer interface {
+	// Creates the silence and returns its ID.
+	CreateSilence(ctx context.Context, silence Silence) (id string, err error)
+	// Expires the silence with the given ID.
+	ExpireSilence(ctx context.Context, id string) error
+}
+
+// alertmanagerSilencer manages silences using the Alertmanager v2 HTTP API.
+type alertmanagerSilencer struct {
+	url    string
+	client *http.Client
+}
+
+// Creates an AlertSilencer for the Alertmanager at the given URL,
+// authenticated as the ServiceAccount of the addon-operator.
+func NewAlertmanagerSilencer(alertmanagerURL string) (AlertSilencer, error) {
+	if _, err := url.Parse(alertmanagerURL); err != nil {
+		return nil, fmt.Errorf("parsing alertmanager url: %w", err)
+	}
+
+	rt, err := promconfig.NewRoundTripperFromConfig(promconfig.HTTPClientConfig{
+		BearerTokenFile: serviceAccountTokenFile,
+		TLSConfig: promconfig.TLSConfig{
+			CAFile: serviceCAFile,
+		},
+	}, "addon-operator")
+	if err != nil {
+		return nil, fmt.Errorf("creating alertmanager round tripper: %w", err)
+	}
+
+	return &alertmanagerSilencer{
+		url:    strings.TrimSuffix(alertmanagerURL, "/"),
+		client: &http.Client{Transport: rt, Timeout: 10 * time.Second},
+	}, nil
+}
+
+type alertmanagerMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type alertmanagerSilence struct {
+	Matchers  []alertmanagerMatcher `json:"matchers"`
+	StartsAt  time.Time             `json:"startsAt"`
+	EndsAt    time.Time             `json:"endsAt"`
+	CreatedBy string                `json:"createdBy"`
+	Comment   string                `json:"comment"`
+}
+
+func (s *alertmanagerSilencer) CreateSilence(ctx context.Context, silence Silence) (string, error) {
+	body := alertmanagerSilence{
+		StartsAt:  silence.StartsAt,
+		EndsAt:    silence.EndsAt,
+		CreatedBy: "addon-operator",
+		Comment:   silence.Comment,
+	}
+	for _, m := range silence.Matchers {
+		body.Matchers = append(body.Matchers, alertmanagerMatcher{
+			Name:    m.Name,
+			Value:   m.Value,
+			IsRegex: m.IsRegex,
+			IsEqual: true,
+		})
+	}
+	j, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("marshaling json: %w", err)
+	}
+
+	res, err := s.do(ctx, http.MethodPost, "/api/v2/silences", bytes.NewReader(j))
+	if err != nil {
+		return "", err
+	}
+	var created struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := json.Unmarshal(res, &created); err != nil {
+		return "", fmt.Errorf("unmarshal json response: %w", err)
+	}
+	return created.SilenceID, nil
+}
+
+func (s *alertmanagerSilencer) ExpireSilence(ctx context.Context, id string) error {
+	_, err := s.do(ctx, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil)
+	return err
+}
+
+func (s *alertmanagerSilencer) do(
+	ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.url+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing http request: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	if res.StatusCode == http.StatusNotFound {
+		return nil, ErrSilenceNotFound
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("HTTP %d: %s", res.StatusCode, string(resBody))
+	}
+	return resBody, nil
+}
+
+// Silences the alerts of the addon namespaces while the addon is upgraded
+// and expires the silence as soon as the upgrade completes.
+// Failures are logged and retried with the next reconcile,
+// as they must not hold up the upgrade itself.
+func (r *AddonReconciler) reconcileUpgradeSilence(
+	ctx context.Context, addon *addonsv1alpha1.Addon) {
+	if r.alertSilencer == nil {
+		return
+	}
+	log := controllers.LoggerFromContext(ctx)
+
+	wanted := addon.DeletionTimestamp.IsZero() &&
+		silenceDuringUpgrade(addon) && addonUpgradeStarted(addon)
+	switch {
+	case wanted && addon.Status.UpgradeSilence == nil:
+		silence, ok := getUpgradeSilence(addon, time.Now())
+		if !ok {
+			return
+		}
+		id, err := r.alertSilencer.CreateSilence(ctx, silence)
+		if err != nil {
+			log.Error(err, "creating upgrade silence")
+			return
+		}
+		addon.Status.UpgradeSilence = &addonsv1alpha1.AddonUpgradeSilenceStatus{
+			ID:     id,
+			EndsAt: metav1.NewTime(silence.EndsAt),
+		}
+
+	case !wanted && addon.Status.UpgradeSilence != nil:
+		err := r.alertSilencer.ExpireSilence(ctx, addon.Status.UpgradeSilence.ID)
+		if err != nil && !errors.Is(err, ErrSilenceNotFound) {
+			log.Error(err, "expiring upgrade silence")
+			return
+		}
+		addon.Status.UpgradeSilence = nil
+	}
+}
+
+func silenceDuringUpgrade(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil && addon.Spec.Monitoring.SilenceDuringUpgrade != nil
+}
+
+// Builds the silence matching all alerts of the addon namespaces.
+// Returns false, if the addon has no namespaces to silence.
+func getUpgradeSilence(addon *addonsv1alpha1.Addon, now time.Time) (Silence, bool) {
+	if len(addon.Spec.Namespaces) == 0 {
+		return Silence{}, false
+	}
+	namespaces := make([]string, len(addon.Spec.Namespaces))
+	for i, ns := range addon.Spec.Namespaces {
+		namespaces[i] = regexp.QuoteMeta(ns.Name)
+	}
+
+	duration := defaultUpgradeSilenceDuration
+	if d := addon.Spec.Monitoring.SilenceDuringUpgrade.Duration; d != nil {
+		duration = d.Duration
+	}
+
+	return Silence{
+		Matchers: []SilenceMatcher{{
+			Name:    "namespace",
+			Value:   strings.Join(namespaces, "|"),
+			IsRegex: true,
+		}},
+		StartsAt: now,
+		EndsAt:   now.Add(duration),
+		Comment: fmt.Sprintf("Upgrade of addon %s to version %s",
+			addon.Name, addon.Spec.Version),
+	}, true
+}
@@ -0,0 +1,236 @@
+package addon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+type alertSilencerMock struct {
+	mock.Mock
+}
+
+func (m *alertSilencerMock) CreateSilence(ctx context.Context, silence Silence) (string, error) {
+	args := m.Called(ctx, silence)
+	return args.String(0), args.Error(1)
+}
+
+func (m *alertSilencerMock) ExpireSilence(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestReconcileUpgradeSilence(t *testing.T) {
+	t.Parallel()
+
+	existing := &addonsv1alpha1.AddonUpgradeSilenceStatus{ID: "existing"}
+
+	tests := map[string]struct {
+		Silence        *addonsv1alpha1.SilenceDuringUpgradeSpec
+		Upgrading      bool
+		Status         *addonsv1alpha1.AddonUpgradeSilenceStatus
+		CreateErr      error
+		ExpireErr      error
+		ExpectCreate   bool
+		ExpectExpire   bool
+		ExpectedStatus *addonsv1alpha1.AddonUpgradeSilenceStatus
+	}{
+		"creates silence when upgrade starts": {
+			Silence:        &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+			Upgrading:      true,
+			ExpectCreate:   true,
+			ExpectedStatus: &addonsv1alpha1.AddonUpgradeSilenceStatus{ID: "created"},
+		},
+		"keeps existing silence during upgrade": {
+			Silence:        &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+			Upgrading:      true,
+			Status:         existing,
+			ExpectedStatus: existing,
+		},
+		"retries failed creation": {
+			Silence:      &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+			Upgrading:    true,
+			CreateErr:    errors.New("HTTP 503"),
+			ExpectCreate: true,
+		},
+		"not opted in": {
+			Upgrading: true,
+		},
+		"expires silence when upgrade completes": {
+			Silence:      &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+			Status:       existing,
+			ExpectExpire: true,
+		},
+		"expires silence when opted out": {
+			Upgrading:    true,
+			Status:       existing,
+			ExpectExpire: true,
+		},
+		"silence already gone": {
+			Silence:      &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+			Status:       existing,
+			ExpireErr:    ErrSilenceNotFound,
+			ExpectExpire: true,
+		},
+		"retries failed expiry": {
+			Silence:        &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+			Status:         existing,
+			ExpireErr:      errors.New("HTTP 503"),
+			ExpectExpire:   true,
+			ExpectedStatus: existing,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := testutil.NewTestAddonWithSingleNamespace()
+			addon.Spec.Monitoring = &addonsv1alpha1.MonitoringSpec{
+				SilenceDuringUpgrade: tc.Silence,
+			}
+			if tc.Upgrading {
+				reportAddonUpgradeStarted(addon)
+			}
+			addon.Status.UpgradeSilence = tc.Status
+
+			silencer := &alertSilencerMock{}
+			silencer.On("CreateSilence", testutil.IsContext, mock.IsType(Silence{})).
+				Return("created", tc.CreateErr).Maybe()
+			silencer.On("ExpireSilence", testutil.IsContext, "existing").
+				Return(tc.ExpireErr).Maybe()
+
+			r := &AddonReconciler{alertSilencer: silencer}
+			ctx := controllers.ContextWithLogger(context.Background(), testutil.NewLogger(t))
+			r.reconcileUpgradeSilence(ctx, addon)
+
+			if tc.ExpectCreate {
+				silencer.AssertCalled(t, "CreateSilence", mock.Anything, mock.Anything)
+			} else {
+				silencer.AssertNotCalled(t, "CreateSilence", mock.Anything, mock.Anything)
+			}
+			if tc.ExpectExpire {
+				silencer.AssertCalled(t, "ExpireSilence", mock.Anything, "existing")
+			} else {
+				silencer.AssertNotCalled(t, "ExpireSilence", mock.Anything, mock.Anything)
+			}
+
+			if tc.ExpectedStatus == nil {
+				assert.Nil(t, addon.Status.UpgradeSilence)
+				return
+			}
+			require.NotNil(t, addon.Status.UpgradeSilence)
+			assert.Equal(t, tc.ExpectedStatus.ID, addon.Status.UpgradeSilence.ID)
+		})
+	}
+}
+
+func TestReconcileUpgradeSilence_Disabled(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	addon.Spec.Monitoring = &addonsv1alpha1.MonitoringSpec{
+		SilenceDuringUpgrade: &addonsv1alpha1.SilenceDuringUpgradeSpec{},
+	}
+	reportAddonUpgradeStarted(addon)
+
+	r := &AddonReconciler{}
+	r.reconcileUpgradeSilence(context.Background(), addon)
+
+	assert.Nil(t, addon.Status.UpgradeSilence)
+	assert.True(t, meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.UpgradeStarted))
+}
+
+func TestGetUpgradeSilence(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	addon := &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: "addon-foo"},
+		Spec: addonsv1alpha1.AddonSpec{
+			Version: "1.1.0",
+			Namespaces: []addonsv1alpha1.AddonNamespace{
+				{Name: "addon-foo"},
+				{Name: "addon-foo.workloads"},
+			},
+			Monitoring: &addonsv1alpha1.MonitoringSpec{
+				SilenceDuringUpgrade: &addonsv1alpha1.SilenceDuringUpgradeSpec{
+					Duration: &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			},
+		},
+	}
+
+	silence, ok := getUpgradeSilence(addon, now)
+	require.True(t, ok)
+	assert.Equal(t, Silence{
+		Matchers: []SilenceMatcher{{
+			Name:    "namespace",
+			Value:   `addon-foo|addon-foo\.workloads`,
+			IsRegex: true,
+		}},
+		StartsAt: now,
+		EndsAt:   now.Add(30 * time.Minute),
+		Comment:  "Upgrade of addon addon-foo to version 1.1.0",
+	}, silence)
+
+	addon.Spec.Monitoring.SilenceDuringUpgrade.Duration = nil
+	silence, ok = getUpgradeSilence(addon, now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(defaultUpgradeSilenceDuration), silence.EndsAt)
+
+	addon.Spec.Namespaces = nil
+	_, ok = getUpgradeSilence(addon, now)
+	assert.False(t, ok)
+}
+
+func TestAlertmanagerSilencer(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			var body alertmanagerSilence
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "addon-operator", body.CreatedBy)
+			assert.Equal(t, []alertmanagerMatcher{
+				{Name: "namespace", Value: "addon-foo", IsRegex: true, IsEqual: true},
+			}, body.Matchers)
+			fmt.Fprint(w, `{"silenceID":"abc"}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/abc":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	silencer := &alertmanagerSilencer{url: server.URL, client: server.Client()}
+	ctx := context.Background()
+
+	id, err := silencer.CreateSilence(ctx, Silence{
+		Matchers: []SilenceMatcher{{Name: "namespace", Value: "addon-foo", IsRegex: true}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", id)
+
+	require.NoError(t, silencer.ExpireSilence(ctx, "abc"))
+	assert.ErrorIs(t, silencer.ExpireSilence(ctx, "unknown"), ErrSilenceNotFound)
+}
@@ -24,6 +24,9 @@ func (r *AddonReconciler) handleUpgradePolicyStatusReporting(
 	r.ocmClientMux.RLock()
 	defer r.ocmClientMux.RUnlock()
 
+	if r.ocmDisabled {
+		return nil
+	}
 	if r.ocmClient == nil {
 		// OCM Client is not initialized.
 		// Either the AddonOperatorReconciler did not yet create and inject the client or
@@ -47,9 +50,9 @@ func (r *AddonReconciler) handleUpgradePolicyStatusReporting(
 	)
 
 	if addon.Status.UpgradePolicy == nil {
-		log.Info("UpgradePolicy status unknown; reporting upgrade as started")
+		log.Info("UpgradePolicy status unknown; reporting upgrade progress")
 
-		return r.reportUpgradeStarted(ctx, addon)
+		return r.reportUpgradeProgress(ctx, log, addon, stateVal)
 	}
 	if addon.Status.UpgradePolicy.Version == "" {
 		log.Info("previous upgrade version unknown")
@@ -71,13 +74,17 @@ func (r *AddonReconciler) handleUpgradePolicyStatusReporting(
 
 		log.Info(
 			fmt.Sprintf(
-				"version %q from UpgradePolicy status is stale; reporting upgrade for version %q as started",
+				"version %q from UpgradePolicy status is stale; reporting upgrade progress for version %q",
 				prevVer,
 				addon.Spec.Version,
 			),
 		)
 
-		return r.reportUpgradeStarted(ctx, addon)
+		return r.reportUpgradeProgress(ctx, log, addon, stateVal)
+	}
+	if val := addon.Status.UpgradePolicy.Value; val == addonsv1alpha1.AddonUpgradePolicyValueScheduled ||
+		val == addonsv1alpha1.AddonUpgradePolicyValueApproved {
+		return r.reportUpgradeProgress(ctx, log, addon, stateVal)
 	}
 	if addon.IsAvailable() {
 		if stateVal == ocm.UpgradePolicyValueScheduled {
@@ -96,6 +103,42 @@ func (r *AddonReconciler) handleUpgradePolicyStatusReporting(
 	return nil
 }
 
+// Reports upgrades, which are not rolled out yet, as scheduled
+// and records the OCM approval of Manual upgrades,
+// until the upgrade can be reported as started.
+func (r *AddonReconciler) reportUpgradeProgress(
+	ctx context.Context,
+	log logr.Logger,
+	addon *addonsv1alpha1.Addon,
+	stateVal ocm.UpgradePolicyValue,
+) error {
+	if !addon.UpgradeApprovedForCurrentVersion() &&
+		(stateVal == ocm.UpgradePolicyValueApproved || stateVal == ocm.UpgradePolicyValueAcknowledged) {
+		log.Info("UpgradePolicy approved; rolling out upgrade")
+		addon.SetUpgradePolicyStatus(addonsv1alpha1.AddonUpgradePolicyValueApproved)
+
+		return nil
+	}
+
+	if upgradeDeferred(addon) || upgradeQueued(addon) {
+		if status := addon.Status.UpgradePolicy; status != nil &&
+			status.Version == addon.Spec.Version &&
+			status.Value == addonsv1alpha1.AddonUpgradePolicyValueScheduled {
+			// Already reported, waiting for approval, the next maintenance window
+			// or other Addons to finish upgrading.
+			return nil
+		}
+
+		log.Info("upgrade is deferred; reporting upgrade as scheduled")
+
+		return r.reportUpgradeScheduled(ctx, addon)
+	}
+
+	log.Info("reporting upgrade as started")
+
+	return r.reportUpgradeStarted(ctx, addon)
+}
+
 func requiresReporting(addon *addonsv1alpha1.Addon) bool {
 	return addon.Spec.Version != "" &&
 		addon.Spec.UpgradePolicy != nil &&
@@ -117,16 +160,53 @@ func (r *AddonReconciler) getPreviousUpgradePolicyStateValue(ctx context.Context
 	return res.Value, nil
 }
 
+func (r *AddonReconciler) reportUpgradeScheduled(ctx context.Context, addon *addonsv1alpha1.Addon) error {
+	var (
+		policyID = addon.Spec.UpgradePolicy.ID
+		version  = addon.Spec.Version
+	)
+
+	description := fmt.Sprintf("Upgrade of addon to version %q is scheduled.", version)
+	if installed := addon.Status.InstalledVersion; installed != "" {
+		description = fmt.Sprintf(
+			"Upgrade of addon from CSV version %q to version %q is scheduled.", installed, version)
+	}
+	if addon.Spec.UpgradePolicy.Mode == addonsv1alpha1.UpgradeModeManual {
+		description += " Awaiting approval."
+	}
+
+	req := ocm.UpgradePolicyPatchRequest{
+		ID:          policyID,
+		Value:       ocm.UpgradePolicyValueScheduled,
+		Description: description,
+	}
+
+	if err := r.handlePatchUpgradePolicy(ctx, req); err != nil {
+		return fmt.Errorf(
+			"patching UpgradePolicy %q at version %q as 'Scheduled': %w", policyID, version, err,
+		)
+	}
+
+	addon.SetUpgradePolicyStatus(addonsv1alpha1.AddonUpgradePolicyValueScheduled)
+
+	return nil
+}
+
 func (r *AddonReconciler) reportUpgradeStarted(ctx context.Context, addon *addonsv1alpha1.Addon) error {
 	var (
 		policyID = addon.Spec.UpgradePolicy.ID
 		version  = addon.Spec.Version
 	)
 
+	description := fmt.Sprintf("Upgrading addon to version %q.", version)
+	if installed := addon.Status.InstalledVersion; installed != "" && installed != version {
+		description = fmt.Sprintf("Upgrading addon from CSV version %q to version %q.", installed, version)
+	}
+
 	req := ocm.UpgradePolicyPatchRequest{
 		ID:          policyID,
 		Value:       ocm.UpgradePolicyValueStarted,
-		Description: fmt.Sprintf("Upgrading addon to version %q.", version),
+		Description: description,
 	}
 
 	if err := r.handlePatchUpgradePolicy(ctx, req); err != nil {
@@ -146,10 +226,16 @@ func (r *AddonReconciler) reportUpgradeCompleted(ctx context.Context, addon *add
 		version  = addon.Spec.Version
 	)
 
+	description := fmt.Sprintf("Addon was healthy at least once at version %q.", version)
+	if installed := addon.Status.InstalledVersion; installed != "" {
+		description = fmt.Sprintf(
+			"Addon was healthy at least once at version %q with CSV version %q.", version, installed)
+	}
+
 	req := ocm.UpgradePolicyPatchRequest{
 		ID:          policyID,
 		Value:       ocm.UpgradePolicyValueCompleted,
-		Description: fmt.Sprintf("Addon was healthy at least once at version %q.", version),
+		Description: description,
 	}
 
 	if err := r.handlePatchUpgradePolicy(ctx, req); err != nil {
@@ -2,6 +2,8 @@ package addon
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/openshift/addon-operator/internal/metrics"
@@ -266,4 +268,210 @@ func TestAddonReconciler_handleUpgradePolicyStatusReporting(t *testing.T) {
 				addon.Status.UpgradePolicy.ObservedGeneration)
 		}
 	})
+
+	newManualUpgradeAddon := func(value addonsv1alpha1.AddonUpgradePolicyValue, version string) *addonsv1alpha1.Addon {
+		return &addonsv1alpha1.Addon{
+			Spec: addonsv1alpha1.AddonSpec{
+				Version: "1.1.0",
+				UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+					ID:   "1234",
+					Mode: addonsv1alpha1.UpgradeModeManual,
+				},
+			},
+			Status: addonsv1alpha1.AddonStatus{
+				InstalledVersion: "1.0.0",
+				UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicyStatus{
+					ID:      "1234",
+					Version: version,
+					Value:   value,
+				},
+			},
+		}
+	}
+
+	t.Run("post `scheduled` with CSV versions while awaiting approval", func(t *testing.T) {
+		ocmClient := ocmtest.NewClient()
+		r := &AddonReconciler{ocmClient: ocmClient}
+
+		addon := newManualUpgradeAddon(addonsv1alpha1.AddonUpgradePolicyValueCompleted, "1.0.0")
+		reportAwaitingUpgradeApproval(addon)
+
+		ocmClient.
+			On("GetUpgradePolicy", mock.Anything, ocm.UpgradePolicyGetRequest{ID: "1234"}).
+			Return(ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValuePending}, nil)
+		ocmClient.
+			On("PatchUpgradePolicy", mock.Anything, ocm.UpgradePolicyPatchRequest{
+				ID:          "1234",
+				Value:       ocm.UpgradePolicyValueScheduled,
+				Description: `Upgrade of addon from CSV version "1.0.0" to version "1.1.0" is scheduled. Awaiting approval.`,
+			}).
+			Return(ocm.UpgradePolicyPatchResponse{}, nil)
+
+		err := r.handleUpgradePolicyStatusReporting(
+			context.Background(), testutil.NewLogger(t), addon)
+		require.NoError(t, err)
+
+		ocmClient.AssertExpectations(t)
+		assert.Equal(t, addonsv1alpha1.AddonUpgradePolicyValueScheduled, addon.Status.UpgradePolicy.Value)
+		assert.Equal(t, "1.1.0", addon.Status.UpgradePolicy.Version)
+		assert.False(t, addon.UpgradeApprovedForCurrentVersion())
+	})
+
+	t.Run("noop while scheduled upgrade awaits approval", func(t *testing.T) {
+		ocmClient := ocmtest.NewClient()
+		r := &AddonReconciler{ocmClient: ocmClient}
+
+		addon := newManualUpgradeAddon(addonsv1alpha1.AddonUpgradePolicyValueScheduled, "1.1.0")
+		reportAwaitingUpgradeApproval(addon)
+
+		ocmClient.
+			On("GetUpgradePolicy", mock.Anything, ocm.UpgradePolicyGetRequest{ID: "1234"}).
+			Return(ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValueScheduled}, nil)
+
+		err := r.handleUpgradePolicyStatusReporting(
+			context.Background(), testutil.NewLogger(t), addon)
+		require.NoError(t, err)
+
+		ocmClient.AssertExpectations(t)
+		ocmClient.AssertNotCalled(t, "PatchUpgradePolicy", mock.Anything, mock.Anything)
+		assert.Equal(t, addonsv1alpha1.AddonUpgradePolicyValueScheduled, addon.Status.UpgradePolicy.Value)
+	})
+
+	for _, stateVal := range []ocm.UpgradePolicyValue{
+		ocm.UpgradePolicyValueApproved, ocm.UpgradePolicyValueAcknowledged,
+	} {
+		stateVal := stateVal
+
+		t.Run(fmt.Sprintf("record approval when OCM returns %q", stateVal), func(t *testing.T) {
+			ocmClient := ocmtest.NewClient()
+			r := &AddonReconciler{ocmClient: ocmClient}
+
+			addon := newManualUpgradeAddon(addonsv1alpha1.AddonUpgradePolicyValueScheduled, "1.1.0")
+			reportAwaitingUpgradeApproval(addon)
+
+			ocmClient.
+				On("GetUpgradePolicy", mock.Anything, ocm.UpgradePolicyGetRequest{ID: "1234"}).
+				Return(ocm.UpgradePolicyGetResponse{Value: stateVal}, nil)
+
+			err := r.handleUpgradePolicyStatusReporting(
+				context.Background(), testutil.NewLogger(t), addon)
+			require.NoError(t, err)
+
+			ocmClient.AssertNotCalled(t, "PatchUpgradePolicy", mock.Anything, mock.Anything)
+			assert.Equal(t, addonsv1alpha1.AddonUpgradePolicyValueApproved, addon.Status.UpgradePolicy.Value)
+			assert.True(t, addon.UpgradeApprovedForCurrentVersion())
+		})
+	}
+
+	t.Run("post `started` with CSV versions once approved upgrade rolls out", func(t *testing.T) {
+		ocmClient := ocmtest.NewClient()
+		r := &AddonReconciler{ocmClient: ocmClient}
+
+		addon := newManualUpgradeAddon(addonsv1alpha1.AddonUpgradePolicyValueApproved, "1.1.0")
+
+		ocmClient.
+			On("GetUpgradePolicy", mock.Anything, ocm.UpgradePolicyGetRequest{ID: "1234"}).
+			Return(ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValueApproved}, nil)
+		ocmClient.
+			On("PatchUpgradePolicy", mock.Anything, ocm.UpgradePolicyPatchRequest{
+				ID:          "1234",
+				Value:       ocm.UpgradePolicyValueStarted,
+				Description: `Upgrading addon from CSV version "1.0.0" to version "1.1.0".`,
+			}).
+			Return(ocm.UpgradePolicyPatchResponse{}, nil)
+
+		err := r.handleUpgradePolicyStatusReporting(
+			context.Background(), testutil.NewLogger(t), addon)
+		require.NoError(t, err)
+
+		ocmClient.AssertExpectations(t)
+		assert.Equal(t, addonsv1alpha1.AddonUpgradePolicyValueStarted, addon.Status.UpgradePolicy.Value)
+	})
+}
+
+func TestAddonReconciler_handleUpgradePolicyStatusReporting_OCMServer(t *testing.T) {
+	s := ocmtest.NewServer()
+	defer s.Close()
+
+	ctx := context.Background()
+	ocmClient, err := ocm.NewClient(ctx,
+		ocm.WithEndpoint(s.URL),
+		ocm.WithClusterExternalID("123"),
+		ocm.WithAccessToken("token"),
+	)
+	require.NoError(t, err)
+
+	r := &AddonReconciler{ocmClient: ocmClient}
+	log := testutil.NewLogger(t)
+
+	addon := &addonsv1alpha1.Addon{
+		Spec: addonsv1alpha1.AddonSpec{
+			Version: "1.1.0",
+			UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+				ID:   "1234",
+				Mode: addonsv1alpha1.UpgradeModeManual,
+			},
+		},
+		Status: addonsv1alpha1.AddonStatus{
+			InstalledVersion: "1.0.0",
+		},
+	}
+	reportAwaitingUpgradeApproval(addon)
+	s.SetUpgradePolicyState("1234", ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValuePending})
+
+	// Scheduled while awaiting approval.
+	require.NoError(t, r.handleUpgradePolicyStatusReporting(ctx, log, addon))
+	state, _ := s.UpgradePolicyState("1234")
+	assert.Equal(t, ocm.UpgradePolicyValueScheduled, state.Value)
+
+	// Approved in OCM.
+	s.SetUpgradePolicyState("1234", ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValueApproved})
+	require.NoError(t, r.handleUpgradePolicyStatusReporting(ctx, log, addon))
+	assert.True(t, addon.UpgradeApprovedForCurrentVersion())
+
+	// Started, once the new catalog is rolled out.
+	removeUpgradeDeferredCondition(addon)
+	require.NoError(t, r.handleUpgradePolicyStatusReporting(ctx, log, addon))
+	state, _ = s.UpgradePolicyState("1234")
+	assert.Equal(t, ocm.UpgradePolicyValueStarted, state.Value)
+
+	assert.Len(t, s.RequestsTo(http.MethodPatch, ocmtest.UpgradePolicyStatePath("1234")), 2)
+}
+
+func TestAddonReconciler_handleUpgradePolicyStatusReporting_UpgradeQueued(t *testing.T) {
+	s := ocmtest.NewServer()
+	defer s.Close()
+
+	ctx := context.Background()
+	ocmClient, err := ocm.NewClient(ctx,
+		ocm.WithEndpoint(s.URL),
+		ocm.WithClusterExternalID("123"),
+		ocm.WithAccessToken("token"),
+	)
+	require.NoError(t, err)
+
+	r := &AddonReconciler{ocmClient: ocmClient}
+	log := testutil.NewLogger(t)
+
+	addon := &addonsv1alpha1.Addon{
+		Spec: addonsv1alpha1.AddonSpec{
+			Version:       "1.1.0",
+			UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{ID: "1234"},
+		},
+		Status: addonsv1alpha1.AddonStatus{
+			UpgradeQueue: &addonsv1alpha1.AddonUpgradeQueueStatus{Position: 1},
+		},
+	}
+	s.SetUpgradePolicyState("1234", ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValuePending})
+
+	// Scheduled while queued behind other Addon upgrades.
+	require.NoError(t, r.handleUpgradePolicyStatusReporting(ctx, log, addon))
+	state, _ := s.UpgradePolicyState("1234")
+	assert.Equal(t, ocm.UpgradePolicyValueScheduled, state.Value)
+
+	// Started, once dequeued.
+	dequeueUpgrade(addon)
+	require.NoError(t, r.handleUpgradePolicyStatusReporting(ctx, log, addon))
+	state, _ = s.UpgradePolicyState("1234")
+	assert.Equal(t, ocm.UpgradePolicyValueStarted, state.Value)
 }
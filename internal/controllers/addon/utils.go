@@ -10,14 +10,19 @@ import (
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/addonphase"
+	"github.com/openshift/addon-operator/internal/alertreceiver"
+	"github.com/openshift/addon-operator/internal/controllers"
 )
 
 // use this type for exit handling
@@ -41,7 +46,7 @@ func handleExit(result requeueResult) ctrl.Result {
 	switch result {
 	case resultRetry:
 		return ctrl.Result{
-			RequeueAfter: defaultRetryAfterTime,
+			RequeueAfter: getRetryAfterTime(),
 		}
 	default:
 		return ctrl.Result{}
@@ -57,24 +62,23 @@ func markedForDeletion(addon *addonsv1alpha1.Addon) bool {
 func (r *AddonReconciler) handleAddonCRDeletion(
 	ctx context.Context, addon *addonsv1alpha1.Addon,
 ) error {
-	if !controllerutil.ContainsFinalizer(addon, cacheFinalizer) {
-		// The finalizer is already gone and the deletion timestamp is set.
+	if !r.hasFinalizers(addon) {
+		// The finalizers are already gone and the deletion timestamp is set.
 		// kube-apiserver should have garbage collected this object already,
 		// this delete signal does not need further processing.
 		return nil
 	}
 
-	reportTerminationStatus(addon)
-
-	// Clear from CSV Event Handler
-	r.operatorResourceHandler.Free(addon)
-
-	controllerutil.RemoveFinalizer(addon, cacheFinalizer)
-	if err := r.Update(ctx, addon); err != nil {
-		return fmt.Errorf("failed to remove finalizer: %w", err)
+	if addon.Spec.DeletionProtection {
+		// Deletion continues once protection is turned off,
+		// which triggers another reconcile.
+		reportDeletionProtectedStatus(addon)
+		return nil
 	}
 
-	return nil
+	reportTerminationStatus(addon)
+	r.reconciled.forget(addon.Name)
+	return r.runFinalizers(ctx, addon)
 }
 
 // Report Addon status to communicate that everything is alright
@@ -86,13 +90,44 @@ func reportReadinessStatus(addon *addonsv1alpha1.Addon) {
 		ObservedGeneration: addon.Generation,
 	})
 	addon.Status.ObservedGeneration = addon.Generation
-	addon.Status.Phase = addonsv1alpha1.PhaseReady
+	reportPhase(addon, addonsv1alpha1.PhaseReady)
 
 	// When everything is ready, we are also operating on the current version of the Addon.
 	// Otherwise we would be in a pending or error state.
 	addon.Status.ObservedVersion = addon.Spec.Version
 }
 
+// Summarizes the Addon state for kubectl in .status.shortStatus.
+// Paused Addons keep their last short status.
+func reportShortStatus(addon *addonsv1alpha1.Addon) {
+	if meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Paused) {
+		return
+	}
+	addon.Status.ShortStatus = shortStatus(addon)
+}
+
+func shortStatus(addon *addonsv1alpha1.Addon) addonsv1alpha1.AddonShortStatus {
+	conds := addon.Status.Conditions
+	switch {
+	case !addon.DeletionTimestamp.IsZero() ||
+		addon.Status.Phase == addonsv1alpha1.PhaseTerminating:
+		return addonsv1alpha1.ShortStatusDeleting
+	case addon.Status.Phase == addonsv1alpha1.PhaseError ||
+		meta.IsStatusConditionTrue(conds, addonsv1alpha1.Degraded):
+		return addonsv1alpha1.ShortStatusDegraded
+	case addon.Status.Phase == addonsv1alpha1.PhaseReady:
+		return addonsv1alpha1.ShortStatusReady
+	case !meta.IsStatusConditionTrue(conds, addonsv1alpha1.Installed):
+		return addonsv1alpha1.ShortStatusInstalling
+	case addonUpgradeStarted(addon) ||
+		len(addon.Spec.Version) != 0 && addon.Status.InstalledVersion != addon.Spec.Version:
+		return addonsv1alpha1.ShortStatusUpgrading
+	default:
+		// Installed, but no longer available.
+		return addonsv1alpha1.ShortStatusDegraded
+	}
+}
+
 func reportObservedVersion(addon *addonsv1alpha1.Addon) {
 	// When everything is ready, we are also operating on the current version of the Addon.
 	// Otherwise we would be in a pending or error state.
@@ -108,19 +143,33 @@ func reportTerminationStatus(addon *addonsv1alpha1.Addon) {
 		ObservedGeneration: addon.Generation,
 	})
 	addon.Status.ObservedGeneration = addon.Generation
-	addon.Status.Phase = addonsv1alpha1.PhaseTerminating
+	reportPhase(addon, addonsv1alpha1.PhaseTerminating)
+}
+
+// Report Addon status to communicate that the deletion is blocked by deletion protection
+func reportDeletionProtectedStatus(addon *addonsv1alpha1.Addon) {
+	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.Available,
+		Status:             metav1.ConditionFalse,
+		Reason:             addonsv1alpha1.AddonReasonDeletionProtected,
+		Message:            "Deletion is blocked, set .spec.deletionProtection to false to delete the Addon.",
+		ObservedGeneration: addon.Generation,
+	})
+	addon.Status.ObservedGeneration = addon.Generation
+	reportPhase(addon, addonsv1alpha1.PhaseTerminating)
 }
 
 // Report Addon status to communicate that the resource is misconfigured
 func reportConfigurationError(addon *addonsv1alpha1.Addon, message string) {
 	meta.SetStatusCondition(&addon.Status.Conditions, metav1.Condition{
-		Type:    addonsv1alpha1.Available,
-		Status:  metav1.ConditionFalse,
-		Reason:  addonsv1alpha1.AddonReasonConfigError,
-		Message: message,
+		Type:               addonsv1alpha1.Available,
+		Status:             metav1.ConditionFalse,
+		Reason:             addonsv1alpha1.AddonReasonConfigError,
+		Message:            message,
+		ObservedGeneration: addon.Generation,
 	})
 	addon.Status.ObservedGeneration = addon.Generation
-	addon.Status.Phase = addonsv1alpha1.PhaseError
+	reportPhase(addon, addonsv1alpha1.PhaseError)
 }
 
 // Marks Addon as paused
@@ -169,6 +218,11 @@ func reportAddonDeletionTimedOut(addon *addonsv1alpha1.Addon) {
 	addon.Status.ObservedGeneration = addon.Generation
 }
 
+// Whether reconciliation of the Addon is paused via the paused annotation.
+func pausedByAnnotation(addon *addonsv1alpha1.Addon) bool {
+	return addon.Annotations[addonsv1alpha1.PausedAnnotation] == "true"
+}
+
 // remove Paused condition from Addon
 func (r *AddonReconciler) removeAddonPauseCondition(addon *addonsv1alpha1.Addon) {
 	meta.RemoveStatusCondition(&addon.Status.Conditions, addonsv1alpha1.Paused)
@@ -212,6 +266,7 @@ func reportAddonUpgradeStarted(addon *addonsv1alpha1.Addon) {
 			ObservedGeneration: addon.Generation,
 		})
 	addon.Status.ObservedGeneration = addon.Generation
+	reportPhase(addon, addonsv1alpha1.PhaseUpgrading)
 }
 
 func reportUninstalledCondition(addon *addonsv1alpha1.Addon) {
@@ -293,6 +348,11 @@ func reportUnreadyNamespaces(addon *addonsv1alpha1.Addon, unreadyNamespaces []st
 		fmt.Sprintf("Namespaces not yet in Active phase: %s", strings.Join(unreadyNamespaces, ", ")))
 }
 
+func reportCollidedNamespaces(addon *addonsv1alpha1.Addon, collidedNamespaces []string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonCollidedNamespaces,
+		fmt.Sprintf("Namespaces already exist and are not owned by this Addon: %s", strings.Join(collidedNamespaces, ", ")))
+}
+
 func reportUnreadyCSV(addon *addonsv1alpha1.Addon, message string) {
 	reportPendingStatus(addon, addonsv1alpha1.AddonReasonUnreadyCSV,
 		fmt.Sprintf("ClusterServiceVersion is not ready: %s", message))
@@ -311,6 +371,51 @@ func reportUnreadyMonitoringStack(addon *addonsv1alpha1.Addon, message string) {
 		fmt.Sprintf("MonitoringStack is not ready: %s", message))
 }
 
+func reportUnreadyUserWorkloadMonitoring(addon *addonsv1alpha1.Addon, message string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonUnreadyUserWorkloadMonitoring,
+		fmt.Sprintf("User-workload monitoring is not ready: %s", message))
+}
+
+func reportUnreadyDeadMansSnitch(ctx context.Context, message string) {
+	reportPhaseNotReady(ctx, addonsv1alpha1.AddonReasonUnreadyDeadMansSnitch,
+		fmt.Sprintf("Dead Man's Snitch is not ready: %s", message))
+}
+
+func reportUnreadyPagerDuty(ctx context.Context, message string) {
+	reportPhaseNotReady(ctx, addonsv1alpha1.AddonReasonUnreadyPagerDuty,
+		fmt.Sprintf("PagerDuty is not ready: %s", message))
+}
+
+func reportInvalidMonitoringRules(addon *addonsv1alpha1.Addon, message string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonInvalidMonitoringRules,
+		fmt.Sprintf("Monitoring rules are invalid: %s", message))
+}
+
+func reportInvalidMonitoringSLOs(ctx context.Context, message string) {
+	reportPhaseNotReady(ctx, addonsv1alpha1.AddonReasonInvalidMonitoringSLOs,
+		fmt.Sprintf("SLOs are invalid: %s", message))
+}
+
+func reportInvalidMonitors(addon *addonsv1alpha1.Addon, message string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonInvalidMonitors,
+		fmt.Sprintf("Monitors are invalid: %s", message))
+}
+
+func reportInvalidMonitoringProbes(addon *addonsv1alpha1.Addon, message string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonInvalidMonitoringProbes,
+		fmt.Sprintf("Probes are invalid: %s", message))
+}
+
+func reportFailingMonitoringProbes(addon *addonsv1alpha1.Addon, message string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonFailingMonitoringProbes,
+		fmt.Sprintf("Probes are failing: %s", message))
+}
+
+func reportUnreadyClusterPackage(addon *addonsv1alpha1.Addon, message string) {
+	reportPendingStatus(addon, addonsv1alpha1.AddonReasonUnreadyClusterPackage,
+		fmt.Sprintf("PackageOperator ClusterPackage is not ready: %s", message))
+}
+
 func reportUnreadyClusterObjectTemplate(addon *addonsv1alpha1.Addon) {
 	reportPendingStatus(addon, addonsv1alpha1.AddonReasonUnreadyClusterPackageTemplate,
 		"PackageOperator ClusterPackageTemplate is not ready")
@@ -327,7 +432,37 @@ func reportPendingStatus(addon *addonsv1alpha1.Addon, reason, msg string) {
 		})
 
 	addon.Status.ObservedGeneration = addon.Generation
-	addon.Status.Phase = addonsv1alpha1.PhasePending
+	reportPhase(addon, unavailablePhase(addon))
+}
+
+// Moves the Addon into the given phase.
+// Transitions rejected by the phase state machine are dropped,
+// e.g. Terminating Addons stay Terminating.
+func reportPhase(addon *addonsv1alpha1.Addon, phase addonsv1alpha1.AddonPhase) {
+	_, _ = addonphase.Transition(&addon.Status, phase, metav1.Now())
+}
+
+// Phase of an Addon that is not available.
+func unavailablePhase(addon *addonsv1alpha1.Addon) addonsv1alpha1.AddonPhase {
+	switch {
+	case installedConditionMissing(addon):
+		// Installation has not started yet.
+		return addonsv1alpha1.PhasePending
+	case meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.InstallTimedOut):
+		return addonsv1alpha1.PhaseError
+	case addonUpgradeStarted(addon):
+		return addonsv1alpha1.PhaseUpgrading
+	case meta.IsStatusConditionTrue(addon.Status.Conditions, addonsv1alpha1.Installed):
+		return addonsv1alpha1.PhaseDegraded
+	}
+
+	switch addon.Status.Phase {
+	case addonsv1alpha1.PhaseReady, addonsv1alpha1.PhaseUpgrading, addonsv1alpha1.PhaseDegraded:
+		// Uninstalled after having been available.
+		return addonsv1alpha1.PhaseDegraded
+	default:
+		return addonsv1alpha1.PhaseInstalling
+	}
 }
 
 // Validate addon.Spec.Install then extract
@@ -375,6 +510,22 @@ func parseAddonInstallConfig(
 
 		return &addon.Spec.Install.OLMAllNamespaces.AddonInstallOLMCommon, false
 
+	case addonsv1alpha1.PackageOperator:
+		if addon.Spec.Install.PackageOperator == nil ||
+			len(addon.Spec.Install.PackageOperator.Namespace) == 0 {
+			// invalid/missing configuration
+			reportConfigurationError(addon,
+				".spec.install.packageOperator.namespace is required when"+
+					" .spec.install.type = PackageOperator")
+			return nil, true
+		}
+
+		// Packages are not installed via OLM,
+		// only the target namespace is shared with the OLM install types.
+		return &addonsv1alpha1.AddonInstallOLMCommon{
+			Namespace: addon.Spec.Install.PackageOperator.Namespace,
+		}, false
+
 	default:
 		// Unsupported Install Type
 		// This should never happen, unless the schema validation is wrong.
@@ -389,7 +540,7 @@ func parseAddonInstallConfigForAdditionalCatalogSources(
 	log logr.Logger, addon *addonsv1alpha1.Addon) (
 	additionalCatalogSrcs []addonsv1alpha1.AdditionalCatalogSource,
 	targetNamespace string,
-	pullSecretName string,
+	pullSecrets []string,
 	stop bool) {
 	switch addon.Spec.Install.Type {
 	case addonsv1alpha1.OLMOwnNamespace:
@@ -398,33 +549,33 @@ func parseAddonInstallConfigForAdditionalCatalogSources(
 				reportConfigurationError(addon,
 					".spec.install.ownNamespace.additionalCatalogSources"+
 						"requires both image and name")
-				return []addonsv1alpha1.AdditionalCatalogSource{}, "", "", true
+				return []addonsv1alpha1.AdditionalCatalogSource{}, "", nil, true
 			}
 			additionalCatalogSrcs = append(additionalCatalogSrcs, additionalCatalogSrc)
 		}
 		targetNamespace = addon.Spec.Install.OLMOwnNamespace.Namespace
-		pullSecretName = addon.Spec.Install.OLMOwnNamespace.PullSecretName
+		pullSecrets = addon.Spec.Install.OLMOwnNamespace.GetPullSecrets()
 	case addonsv1alpha1.OLMAllNamespaces:
 		for _, additionalCatalogSrc := range addon.Spec.Install.OLMAllNamespaces.AdditionalCatalogSources {
 			if len(additionalCatalogSrc.Image) == 0 || len(additionalCatalogSrc.Name) == 0 {
 				reportConfigurationError(addon,
 					".spec.install.allNamespaces.additionalCatalogSources"+
 						"requires both image and name")
-				return []addonsv1alpha1.AdditionalCatalogSource{}, "", "", true
+				return []addonsv1alpha1.AdditionalCatalogSource{}, "", nil, true
 			}
 			additionalCatalogSrcs = append(additionalCatalogSrcs, additionalCatalogSrc)
 		}
 		targetNamespace = addon.Spec.Install.OLMAllNamespaces.Namespace
-		pullSecretName = addon.Spec.Install.OLMAllNamespaces.PullSecretName
+		pullSecrets = addon.Spec.Install.OLMAllNamespaces.GetPullSecrets()
 	default:
 		// Unsupported Install Type
 		// This should never happen, unless the schema validation is wrong.
 		// The .install.type property is set to only allow known enum values.
 		log.Error(fmt.Errorf("invalid Addon install type: %q", addon.Spec.Install.Type),
 			"stopping Addon reconcilation")
-		return []addonsv1alpha1.AdditionalCatalogSource{}, "", "", true
+		return []addonsv1alpha1.AdditionalCatalogSource{}, "", nil, true
 	}
-	return additionalCatalogSrcs, targetNamespace, pullSecretName, false
+	return additionalCatalogSrcs, targetNamespace, pullSecrets, false
 }
 
 // HasMonitoringFederation is a helper to determine if a given addon's spec
@@ -439,6 +590,51 @@ func HasMonitoringStack(addon *addonsv1alpha1.Addon) bool {
 	return addon.Spec.Monitoring != nil && addon.Spec.Monitoring.MonitoringStack != nil
 }
 
+// UsesUserWorkloadMonitoring is a helper to determine if a given addon's spec
+// routes its metrics into the user-workload monitoring of the cluster.
+func UsesUserWorkloadMonitoring(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil &&
+		addon.Spec.Monitoring.Mode == addonsv1alpha1.AddonMonitoringModeUserWorkload
+}
+
+// HasDeadMansSnitch is a helper to determine if a given addon's spec
+// requests a Dead Man's Snitch checked in by its MonitoringStack.
+func HasDeadMansSnitch(addon *addonsv1alpha1.Addon) bool {
+	return HasMonitoringStack(addon) && !UsesUserWorkloadMonitoring(addon) &&
+		addon.Spec.Monitoring.MonitoringStack.DeadMansSnitch != nil
+}
+
+// HasPagerDuty is a helper to determine if a given addon's spec
+// requests a PagerDuty service of its own.
+func HasPagerDuty(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil && addon.Spec.Monitoring.PagerDuty != nil
+}
+
+// HasMonitoringRules is a helper to determine if a given addon's spec
+// declares Monitoring.Rules.
+func HasMonitoringRules(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil && len(addon.Spec.Monitoring.Rules) > 0
+}
+
+// HasMonitors is a helper to determine if a given addon's spec
+// declares Monitoring.ServiceMonitors or Monitoring.PodMonitors.
+func HasMonitors(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil &&
+		(len(addon.Spec.Monitoring.ServiceMonitors) > 0 || len(addon.Spec.Monitoring.PodMonitors) > 0)
+}
+
+// HasMonitoringProbes is a helper to determine if a given addon's spec
+// declares Monitoring.Probes.
+func HasMonitoringProbes(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil && len(addon.Spec.Monitoring.Probes) > 0
+}
+
+// HasMonitoringSLOs is a helper to determine if a given addon's spec
+// declares Monitoring.SLOs.
+func HasMonitoringSLOs(addon *addonsv1alpha1.Addon) bool {
+	return addon.Spec.Monitoring != nil && len(addon.Spec.Monitoring.SLOs) > 0
+}
+
 // HasAdditionalCatalogSources determines whether the passed addon's spec
 // contains additional catalog sources
 func HasAdditionalCatalogSources(addon *addonsv1alpha1.Addon) bool {
@@ -457,11 +653,34 @@ func GetMonitoringNamespaceName(addon *addonsv1alpha1.Addon) string {
 	return fmt.Sprintf("redhat-monitoring-%s", addon.Name)
 }
 
+// Helper function to compute the URL the MonitoringStack Alertmanager
+// of an addon sends its alerts to for forwarding into OCM service logs.
+func GetAlertReceiverURL(addon *addonsv1alpha1.Addon, addonOperatorNamespace string) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d%s%s",
+		controllers.AlertReceiverServiceName, addonOperatorNamespace,
+		controllers.AlertReceiverServicePort, alertreceiver.AlertsPathPrefix, addon.Name)
+}
+
 // Helper function to compute monitoring federation ServiceMonitor name from addon object
 func GetMonitoringFederationServiceMonitorName(addon *addonsv1alpha1.Addon) string {
 	return fmt.Sprintf("federated-sm-%s", addon.Name)
 }
 
+// Helper function to compute the name of the Probe of an addon probe, also used as its job name
+func GetMonitoringProbeName(addon *addonsv1alpha1.Addon, probe addonsv1alpha1.AddonProbe) string {
+	return fmt.Sprintf("addon-%s-%s", addon.Name, probe.Name)
+}
+
+// Helper function to compute the name of the PrometheusRule holding the monitoring rules of an addon
+func GetMonitoringRulesPrometheusRuleName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-rules", addon.Name)
+}
+
+// Helper function to compute the name of the PrometheusRule recording the SLO error ratios of an addon
+func GetMonitoringSLOsPrometheusRuleName(addon *addonsv1alpha1.Addon) string {
+	return fmt.Sprintf("addon-%s-slos", addon.Name)
+}
+
 // GetMonitoringFederationServiceMonitorEndpoints generates a slice of monitoringv1.Endpoint
 // instances from an addon's Monitoring.Federation specification.
 func GetMonitoringFederationServiceMonitorEndpoints(addon *addonsv1alpha1.Addon) []monitoringv1.Endpoint {
@@ -480,7 +699,7 @@ func GetMonitoringFederationServiceMonitorEndpoints(addon *addonsv1alpha1.Addon)
 		matchParams = append(matchParams, fmt.Sprintf(`{__name__="%s"}`, name))
 	}
 
-	return []monitoringv1.Endpoint{{
+	endpoint := monitoringv1.Endpoint{
 		BearerTokenFile: "/var/run/secrets/kubernetes.io/serviceaccount/token",
 		HonorLabels:     true,
 		Port:            addon.Spec.Monitoring.Federation.PortName,
@@ -489,7 +708,42 @@ func GetMonitoringFederationServiceMonitorEndpoints(addon *addonsv1alpha1.Addon)
 		Interval:        "30s",
 		TLSConfig:       tlsConfig,
 		Params:          map[string][]string{"match[]": matchParams},
-	}}
+	}
+	applyMonitoringFederationAuth(addon, &endpoint)
+	endpoint.MetricRelabelConfigs = getMonitoringFederationMetricRelabelings(addon)
+	return []monitoringv1.Endpoint{endpoint}
+}
+
+// Converts the metric relabelings of an addon's Monitoring.Federation specification
+// into the relabel configs of the ServiceMonitor API.
+// The label identifying the addon is enforced last, so it cannot be overridden.
+func getMonitoringFederationMetricRelabelings(addon *addonsv1alpha1.Addon) []*monitoringv1.RelabelConfig {
+	relabelings := addon.Spec.Monitoring.Federation.MetricRelabelings
+
+	configs := make([]*monitoringv1.RelabelConfig, 0, len(relabelings)+1)
+	for _, relabeling := range relabelings {
+		if relabeling == nil {
+			continue
+		}
+		var sourceLabels []monitoringv1.LabelName
+		for _, label := range relabeling.SourceLabels {
+			sourceLabels = append(sourceLabels, monitoringv1.LabelName(label))
+		}
+		configs = append(configs, &monitoringv1.RelabelConfig{
+			SourceLabels: sourceLabels,
+			Separator:    relabeling.Separator,
+			TargetLabel:  relabeling.TargetLabel,
+			Regex:        relabeling.Regex,
+			Modulus:      relabeling.Modulus,
+			Replacement:  relabeling.Replacement,
+			Action:       relabeling.Action,
+		})
+	}
+	return append(configs, &monitoringv1.RelabelConfig{
+		TargetLabel: controllers.MonitoringFederationAddonLabel,
+		Replacement: addon.Name,
+		Action:      "replace",
+	})
 }
 
 func getPrimaryCatalogSourceName(addon *addonsv1alpha1.Addon) string {
@@ -516,10 +770,23 @@ func GetCommonInstallOptions(addon *addonsv1alpha1.Addon) (commonInstallOptions
 	case addonsv1alpha1.OLMOwnNamespace:
 		commonInstallOptions = addon.Spec.Install.
 			OLMOwnNamespace.AddonInstallOLMCommon
+	case addonsv1alpha1.PackageOperator:
+		commonInstallOptions.Namespace = addon.Spec.Install.
+			PackageOperator.Namespace
 	}
 	return
 }
 
+// Adds the labels and annotations passed through via the Addon spec to obj.
+// Call before controllers.AddCommonLabels, so managed labels take precedence.
+func addAdditionalMetadata(obj metav1.Object, metadata *addonsv1alpha1.AdditionalMetadata) {
+	if metadata == nil {
+		return
+	}
+	obj.SetLabels(labels.Merge(obj.GetLabels(), metadata.Labels))
+	obj.SetAnnotations(labels.Merge(obj.GetAnnotations(), metadata.Annotations))
+}
+
 func corev1ProtocolPtr(proto corev1.Protocol) *corev1.Protocol   { return &proto }
 func intOrStringPtr(iors intstr.IntOrString) *intstr.IntOrString { return &iors }
 
@@ -544,3 +811,14 @@ func hashOCMAddonStatus(ocmAddonStatus addonsv1alpha1.OCMAddOnStatus) string {
 	printer.Fprintf(hasher, "%#v", ocmAddonStatus)
 	return rand.SafeEncodeString(fmt.Sprint(hasher.Sum32()))
 }
+
+// Deletes the given object, if it exists in the cache.
+func deleteCachedObject(ctx context.Context, c client.Client, obj client.Object) error {
+	current := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); k8sApiErrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting %T: %w", obj, err)
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, current))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -37,6 +39,7 @@ func TestHandleAddonDeletion(t *testing.T) {
 			Scheme:                  testutil.NewTestSchemeWithAddonsv1alpha1(),
 			operatorResourceHandler: operatorResourceHandlerMock,
 		}
+		r.registerFinalizer(&cacheFinalizerHandler{reconciler: r})
 
 		c.
 			On("Update", mock.Anything, mock.Anything, mock.Anything).
@@ -90,6 +93,44 @@ func TestHandleAddonDeletion(t *testing.T) {
 		c.AssertNotCalled(
 			t, "Update", mock.Anything, mock.Anything, mock.Anything)
 	})
+
+	t.Run("keeps finalizer with deletion protection", func(t *testing.T) {
+		addonToDelete := &addonsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{
+				Finalizers: []string{
+					cacheFinalizer,
+				},
+			},
+			Spec: addonsv1alpha1.AddonSpec{
+				DeletionProtection: true,
+			},
+		}
+
+		c := testutil.NewClient()
+
+		csvEventHandlerMock := &operatorResourceHandlerMock{}
+		r := &AddonReconciler{
+			Client:                  c,
+			Log:                     testutil.NewLogger(t),
+			Scheme:                  testutil.NewTestSchemeWithAddonsv1alpha1(),
+			operatorResourceHandler: csvEventHandlerMock,
+		}
+		r.registerFinalizer(&cacheFinalizerHandler{reconciler: r})
+
+		ctx := context.Background()
+		err := r.handleAddonCRDeletion(ctx, addonToDelete)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{cacheFinalizer}, addonToDelete.Finalizers)
+		c.AssertNotCalled(
+			t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		csvEventHandlerMock.AssertNotCalled(t, "Free", mock.Anything)
+
+		availableCond := meta.FindStatusCondition(addonToDelete.Status.Conditions, addonsv1alpha1.Available)
+		if assert.NotNil(t, availableCond) {
+			assert.Equal(t, addonsv1alpha1.AddonReasonDeletionProtected, availableCond.Reason)
+		}
+	})
 }
 
 type operatorResourceHandlerMock struct {
@@ -135,7 +176,7 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 	type Expected struct {
 		additionalCatalogSource []addonsv1alpha1.AdditionalCatalogSource
 		targetNamespace         string
-		pullSecretName          string
+		pullSecrets             []string
 		stop                    bool
 	}
 
@@ -180,7 +221,7 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 					},
 				},
 				targetNamespace: "test-namespace-OLMOwnNamespace",
-				pullSecretName:  "test-pullSecretName",
+				pullSecrets:     []string{"test-pullSecretName"},
 				stop:            false,
 			},
 		},
@@ -211,7 +252,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -242,7 +282,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -273,7 +312,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -313,7 +351,7 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 					},
 				},
 				targetNamespace: "test-namespace-OLMAllNamespaces",
-				pullSecretName:  "test-pullSecretName",
+				pullSecrets:     []string{"test-pullSecretName"},
 				stop:            false,
 			},
 		},
@@ -344,7 +382,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -375,7 +412,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -406,7 +442,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -415,7 +450,6 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 			expected: Expected{
 				additionalCatalogSource: []addonsv1alpha1.AdditionalCatalogSource{},
 				targetNamespace:         "",
-				pullSecretName:          "",
 				stop:                    true,
 			},
 		},
@@ -424,13 +458,13 @@ func TestParseAddonInstallConfigurationForAdditionalCatalogSources(t *testing.T)
 	for _, tc := range testCases {
 		t.Run("parse addon install configuration for additional catalogsource test", func(t *testing.T) {
 			addon := tc.addon.DeepCopy()
-			additionalCatalogSource, targetNamespace, pullSecretName, stop := parseAddonInstallConfigForAdditionalCatalogSources(log, addon)
+			additionalCatalogSource, targetNamespace, pullSecrets, stop := parseAddonInstallConfigForAdditionalCatalogSources(log, addon)
 			// additionalCatalogSource check
 			assert.Equal(t, tc.expected.additionalCatalogSource, additionalCatalogSource)
 			// targetNamespace check
 			assert.Equal(t, tc.expected.targetNamespace, targetNamespace)
-			// pullSecretName check
-			assert.Equal(t, tc.expected.pullSecretName, pullSecretName)
+			// pullSecrets check
+			assert.Equal(t, tc.expected.pullSecrets, pullSecrets)
 			// stop check
 			assert.Equal(t, tc.expected.stop, stop)
 		})
@@ -806,6 +840,58 @@ func TestHasMonitoringFederation(t *testing.T) {
 	}
 }
 
+func TestGetMonitoringFederationServiceMonitorEndpoints_MetricRelabelings(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+	addon.Spec.Monitoring.Federation.MetricRelabelings = []*monv1.RelabelConfig{
+		{
+			SourceLabels: []monv1.LabelName{"__name__"},
+			Regex:        "apiserver_request_duration_.*",
+			Action:       "drop",
+		},
+		{
+			Regex:  "pod_template_hash",
+			Action: "labeldrop",
+		},
+	}
+
+	endpoints := GetMonitoringFederationServiceMonitorEndpoints(addon)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, []*monitoringv1.RelabelConfig{
+		{
+			SourceLabels: []monitoringv1.LabelName{"__name__"},
+			Regex:        "apiserver_request_duration_.*",
+			Action:       "drop",
+		},
+		{
+			Regex:  "pod_template_hash",
+			Action: "labeldrop",
+		},
+		{
+			TargetLabel: "addon",
+			Replacement: "addon-foo",
+			Action:      "replace",
+		},
+	}, endpoints[0].MetricRelabelConfigs)
+}
+
+func TestGetMonitoringFederationServiceMonitorEndpoints_AddonLabel(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithMonitoringFederation()
+
+	endpoints := GetMonitoringFederationServiceMonitorEndpoints(addon)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, []*monitoringv1.RelabelConfig{
+		{
+			TargetLabel: "addon",
+			Replacement: "addon-foo",
+			Action:      "replace",
+		},
+	}, endpoints[0].MetricRelabelConfigs)
+}
+
 func TestHasMonitoringStack(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -864,3 +950,170 @@ func TestHasMonitoringStack(t *testing.T) {
 		})
 	}
 }
+
+func TestAddAdditionalMetadata(t *testing.T) {
+	t.Parallel()
+
+	addon := testutil.NewTestAddonWithCatalogSourceImage()
+	obj := &metav1.ObjectMeta{
+		Annotations: map[string]string{"existing": "annotation"},
+	}
+
+	addAdditionalMetadata(obj, &addonsv1alpha1.AdditionalMetadata{
+		Labels: map[string]string{
+			"cost-center":                    "1234",
+			controllers.CommonManagedByLabel: "someone-else",
+		},
+		Annotations: map[string]string{"example.com/scrape": "true"},
+	})
+	controllers.AddCommonLabels(obj, addon)
+
+	assert.Equal(t, "1234", obj.Labels["cost-center"])
+	assert.Equal(t, controllers.CommonManagedByValue, obj.Labels[controllers.CommonManagedByLabel])
+	assert.Equal(t, map[string]string{
+		"existing":           "annotation",
+		"example.com/scrape": "true",
+	}, obj.Annotations)
+}
+
+func TestShortStatus(t *testing.T) {
+	t.Parallel()
+
+	installed := metav1.Condition{
+		Type:   addonsv1alpha1.Installed,
+		Status: metav1.ConditionTrue,
+	}
+	now := metav1.Now()
+
+	for name, tc := range map[string]struct {
+		addon    *addonsv1alpha1.Addon
+		expected addonsv1alpha1.AddonShortStatus
+	}{
+		"installing": {
+			addon: &addonsv1alpha1.Addon{
+				Status: addonsv1alpha1.AddonStatus{Phase: addonsv1alpha1.PhasePending},
+			},
+			expected: addonsv1alpha1.ShortStatusInstalling,
+		},
+		"ready": {
+			addon: &addonsv1alpha1.Addon{
+				Status: addonsv1alpha1.AddonStatus{
+					Phase:      addonsv1alpha1.PhaseReady,
+					Conditions: []metav1.Condition{installed},
+				},
+			},
+			expected: addonsv1alpha1.ShortStatusReady,
+		},
+		"upgrading": {
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{Version: "1.1.0"},
+				Status: addonsv1alpha1.AddonStatus{
+					Phase:            addonsv1alpha1.PhasePending,
+					Conditions:       []metav1.Condition{installed},
+					InstalledVersion: "1.0.0",
+				},
+			},
+			expected: addonsv1alpha1.ShortStatusUpgrading,
+		},
+		"degraded after install": {
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{Version: "1.0.0"},
+				Status: addonsv1alpha1.AddonStatus{
+					Phase:            addonsv1alpha1.PhasePending,
+					Conditions:       []metav1.Condition{installed},
+					InstalledVersion: "1.0.0",
+				},
+			},
+			expected: addonsv1alpha1.ShortStatusDegraded,
+		},
+		"error": {
+			addon: &addonsv1alpha1.Addon{
+				Status: addonsv1alpha1.AddonStatus{Phase: addonsv1alpha1.PhaseError},
+			},
+			expected: addonsv1alpha1.ShortStatusDegraded,
+		},
+		"deleting": {
+			addon: &addonsv1alpha1.Addon{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Status:     addonsv1alpha1.AddonStatus{Phase: addonsv1alpha1.PhaseReady},
+			},
+			expected: addonsv1alpha1.ShortStatusDeleting,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reportShortStatus(tc.addon)
+			assert.Equal(t, tc.expected, tc.addon.Status.ShortStatus)
+		})
+	}
+}
+
+func TestUnavailablePhase(t *testing.T) {
+	t.Parallel()
+
+	installed := func(status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: addonsv1alpha1.Installed, Status: status}
+	}
+	upgradeStarted := metav1.Condition{
+		Type:   addonsv1alpha1.UpgradeStarted,
+		Status: metav1.ConditionTrue,
+	}
+
+	for name, tc := range map[string]struct {
+		status   addonsv1alpha1.AddonStatus
+		expected addonsv1alpha1.AddonPhase
+	}{
+		"not started": {
+			expected: addonsv1alpha1.PhasePending,
+		},
+		"installing": {
+			status: addonsv1alpha1.AddonStatus{
+				Phase:      addonsv1alpha1.PhasePending,
+				Conditions: []metav1.Condition{installed(metav1.ConditionFalse)},
+			},
+			expected: addonsv1alpha1.PhaseInstalling,
+		},
+		"upgrading": {
+			status: addonsv1alpha1.AddonStatus{
+				Phase:      addonsv1alpha1.PhaseReady,
+				Conditions: []metav1.Condition{installed(metav1.ConditionTrue), upgradeStarted},
+			},
+			expected: addonsv1alpha1.PhaseUpgrading,
+		},
+		"degraded": {
+			status: addonsv1alpha1.AddonStatus{
+				Phase:      addonsv1alpha1.PhaseReady,
+				Conditions: []metav1.Condition{installed(metav1.ConditionTrue)},
+			},
+			expected: addonsv1alpha1.PhaseDegraded,
+		},
+		"uninstalled": {
+			status: addonsv1alpha1.AddonStatus{
+				Phase:      addonsv1alpha1.PhaseReady,
+				Conditions: []metav1.Condition{installed(metav1.ConditionFalse)},
+			},
+			expected: addonsv1alpha1.PhaseDegraded,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := &addonsv1alpha1.Addon{Status: tc.status}
+			assert.Equal(t, tc.expected, unavailablePhase(addon))
+		})
+	}
+}
+
+func TestReportPhase_KeepsTerminating(t *testing.T) {
+	t.Parallel()
+
+	addon := &addonsv1alpha1.Addon{}
+	reportTerminationStatus(addon)
+	reportReadinessStatus(addon)
+
+	assert.Equal(t, addonsv1alpha1.PhaseTerminating, addon.Status.Phase)
+	require.Len(t, addon.Status.PhaseTransitions, 1)
+}
@@ -2,11 +2,15 @@ package addoninstance
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -49,6 +53,10 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	}
 
 	defer func() {
+		if err := c.recordHistory(ctx, instance); err != nil {
+			log.Error(err, "recording AddonInstance history")
+		}
+
 		log.Info("updating status conditions")
 
 		if err := c.client.UpdateStatus(ctx, instance); err != nil {
@@ -77,10 +85,25 @@ func (c *Controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	return ctrl.Result{RequeueAfter: c.cfg.PollingInterval}, nil
 }
 
+// Records the history of the instance into its history ConfigMap
+// and mirrors it into the status of the instance.
+func (c *Controller) recordHistory(ctx context.Context, instance *av1alpha1.AddonInstance) error {
+	history, err := c.client.GetHistory(ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	instance.Status.History = history
+	recordHistory(instance, c.cfg.HistoryLimit)
+
+	return c.client.UpdateHistory(ctx, instance)
+}
+
 type ControllerConfig struct {
 	Log             logr.Logger
 	PollingInterval time.Duration
 	SerialPhases    []Phase
+	HistoryLimit    int
 }
 
 func (c *ControllerConfig) Option(opts ...ControllerOption) {
@@ -97,6 +120,10 @@ func (c *ControllerConfig) Default() {
 	if c.PollingInterval == 0 {
 		c.PollingInterval = 10 * time.Second
 	}
+
+	if c.HistoryLimit == 0 {
+		c.HistoryLimit = av1alpha1.DefaultAddonInstanceHistoryLimit
+	}
 }
 
 type ControllerOption interface {
@@ -111,6 +138,8 @@ type Phase interface {
 type AddonInstanceClient interface {
 	Get(ctx context.Context, name, namespace string) (*av1alpha1.AddonInstance, error)
 	UpdateStatus(ctx context.Context, instance *av1alpha1.AddonInstance) error
+	GetHistory(ctx context.Context, instance *av1alpha1.AddonInstance) ([]av1alpha1.AddonInstanceHistoryEvent, error)
+	UpdateHistory(ctx context.Context, instance *av1alpha1.AddonInstance) error
 }
 
 func NewAddonInstanceClient(client client.Client) *AddonInstanceClientImpl {
@@ -146,3 +175,74 @@ func (c *AddonInstanceClientImpl) UpdateStatus(ctx context.Context, instance *av
 
 	return nil
 }
+
+// Clients updating the AddonInstance status without knowing .status.history drop it,
+// so the history is persisted in a ConfigMap owned by the AddonInstance.
+const historyConfigMapDataKey = "history"
+
+func historyConfigMapKey(instance *av1alpha1.AddonInstance) client.ObjectKey {
+	return client.ObjectKey{
+		Name:      instance.Name + "-history",
+		Namespace: instance.Namespace,
+	}
+}
+
+// GetHistory returns the history persisted for the AddonInstance.
+// Falls back to the status of the AddonInstance, until the history is persisted the first time.
+func (c *AddonInstanceClientImpl) GetHistory(
+	ctx context.Context, instance *av1alpha1.AddonInstance,
+) ([]av1alpha1.AddonInstanceHistoryEvent, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, historyConfigMapKey(instance), cm); errors.IsNotFound(err) {
+		return instance.Status.History, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting history ConfigMap: %w", err)
+	}
+
+	var history []av1alpha1.AddonInstanceHistoryEvent
+	if err := json.Unmarshal([]byte(cm.Data[historyConfigMapDataKey]), &history); err != nil {
+		return nil, fmt.Errorf("decoding history ConfigMap: %w", err)
+	}
+
+	return history, nil
+}
+
+// UpdateHistory persists the status history of the AddonInstance.
+func (c *AddonInstanceClientImpl) UpdateHistory(ctx context.Context, instance *av1alpha1.AddonInstance) error {
+	data, err := json.Marshal(instance.Status.History)
+	if err != nil {
+		return fmt.Errorf("encoding history: %w", err)
+	}
+
+	key := historyConfigMapKey(instance)
+	cm := &corev1.ConfigMap{}
+	if err := c.client.Get(ctx, key, cm); errors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: key.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(instance, av1alpha1.GroupVersion.WithKind("AddonInstance")),
+				},
+			},
+			Data: map[string]string{historyConfigMapDataKey: string(data)},
+		}
+		if err := c.client.Create(ctx, cm); err != nil {
+			return fmt.Errorf("creating history ConfigMap: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting history ConfigMap: %w", err)
+	}
+
+	if cm.Data[historyConfigMapDataKey] == string(data) {
+		return nil
+	}
+
+	cm.Data = map[string]string{historyConfigMapDataKey: string(data)}
+	if err := c.client.Update(ctx, cm); err != nil {
+		return fmt.Errorf("updating history ConfigMap: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -115,6 +116,7 @@ func TestController(t *testing.T) {
 
 			scheme := runtime.NewScheme()
 			require.NoError(t, av1alpha1.AddToScheme(scheme))
+			require.NoError(t, corev1.AddToScheme(scheme))
 
 			c := fake.NewClientBuilder().
 				WithScheme(scheme).
@@ -180,3 +182,58 @@ func (m *PhaseMock) String() string {
 
 	return args.String(0)
 }
+
+func TestController_HistorySurvivesStatusUpdates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, av1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	// a client unaware of .status.history dropped it from the status
+	instance := &av1alpha1.AddonInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      av1alpha1.DefaultAddonInstanceName,
+			Namespace: "test-namespace",
+		},
+	}
+	historyCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      av1alpha1.DefaultAddonInstanceName + "-history",
+			Namespace: "test-namespace",
+		},
+		Data: map[string]string{
+			"history": `[{"type":"HeartbeatGap","time":"2023-05-01T12:00:00.000000Z","duration":"5m0s"}]`,
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(instance, historyCM).
+		Build()
+
+	var mPhase PhaseMock
+	mPhase.
+		On("Execute", mock.Anything, mock.AnythingOfType("phase.Request")).
+		Return(phase.Success(metav1.Condition{
+			Type:   av1alpha1.AddonInstanceConditionDegraded.String(),
+			Status: "True",
+			Reason: "ServiceXUnavailable",
+		}))
+	mPhase.On("String").Return("PhaseMock")
+
+	aiCtrl := addoninstance.NewController(c, addoninstance.WithSerialPhases{&mPhase})
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(instance)}
+
+	_, err := aiCtrl.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, req.NamespacedName, instance))
+	require.Len(t, instance.Status.History, 2)
+	require.Equal(t, av1alpha1.AddonInstanceHistoryEventHeartbeatGap, instance.Status.History[0].Type)
+	require.Equal(t, av1alpha1.AddonInstanceHistoryEventConditionTransition, instance.Status.History[1].Type)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(historyCM), historyCM))
+	require.Contains(t, historyCM.Data["history"], "ConditionTransition")
+}
@@ -0,0 +1,105 @@
+package addoninstance
+
+import (
+	"sort"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// recordHistory appends heartbeat gaps and condition transitions,
+// which happened since the history was last recorded, to the status history
+// of the given instance and drops the oldest events exceeding the limit.
+func recordHistory(instance *av1alpha1.AddonInstance, limit int) {
+	history := instance.Status.History
+
+	history = append(history, conditionTransitionsSince(
+		instance.Status.Conditions, lastConditionTransitionTime(history))...)
+	history = recordHeartbeatGap(history, instance)
+
+	if len(history) > limit {
+		history = history[len(history)-limit:]
+	}
+	instance.Status.History = history
+}
+
+// Conditions are compared via their LastTransitionTime, so transitions
+// reported by the addon itself are recorded the same as the ones set by this controller.
+func conditionTransitionsSince(
+	conds []metav1.Condition, since metav1.MicroTime,
+) []av1alpha1.AddonInstanceHistoryEvent {
+	var events []av1alpha1.AddonInstanceHistoryEvent
+
+	for _, cond := range conds {
+		if !since.BeforeTime(&cond.LastTransitionTime) {
+			continue
+		}
+
+		events = append(events, av1alpha1.AddonInstanceHistoryEvent{
+			Type:          av1alpha1.AddonInstanceHistoryEventConditionTransition,
+			Time:          metav1.NewMicroTime(cond.LastTransitionTime.Time),
+			ConditionType: cond.Type,
+			Status:        cond.Status,
+			Reason:        cond.Reason,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(&events[j].Time)
+	})
+
+	return events
+}
+
+func lastConditionTransitionTime(history []av1alpha1.AddonInstanceHistoryEvent) metav1.MicroTime {
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Type == av1alpha1.AddonInstanceHistoryEventConditionTransition {
+			return history[i].Time
+		}
+	}
+	return metav1.MicroTime{}
+}
+
+// Opens a HeartbeatGap event when heartbeats time out
+// and records its duration once heartbeats are received again.
+func recordHeartbeatGap(
+	history []av1alpha1.AddonInstanceHistoryEvent, instance *av1alpha1.AddonInstance,
+) []av1alpha1.AddonInstanceHistoryEvent {
+	healthy := apimeta.FindStatusCondition(
+		instance.Status.Conditions, av1alpha1.AddonInstanceConditionHealthy.String())
+	if healthy == nil {
+		return history
+	}
+
+	openGap := -1
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Type == av1alpha1.AddonInstanceHistoryEventHeartbeatGap {
+			if history[i].Duration == nil {
+				openGap = i
+			}
+			break
+		}
+	}
+
+	lastHeartbeatTime := instance.Status.LastHeartbeatTime
+
+	switch healthy.Reason {
+	case av1alpha1.AddonInstanceHealthyReasonHeartbeatTimeout.String():
+		if openGap == -1 {
+			history = append(history, av1alpha1.AddonInstanceHistoryEvent{
+				Type: av1alpha1.AddonInstanceHistoryEventHeartbeatGap,
+				Time: metav1.NewMicroTime(lastHeartbeatTime.Time),
+			})
+		}
+	case av1alpha1.AddonInstanceHealthyReasonReceivingHeartbeats.String():
+		if openGap != -1 {
+			history[openGap].Duration = &metav1.Duration{
+				Duration: lastHeartbeatTime.Sub(history[openGap].Time.Time),
+			}
+		}
+	}
+
+	return history
+}
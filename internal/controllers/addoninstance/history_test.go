@@ -0,0 +1,131 @@
+package addoninstance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	av1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+func TestRecordHistory_ConditionTransitions(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	instance := &av1alpha1.AddonInstance{
+		Status: av1alpha1.AddonInstanceStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:               av1alpha1.AddonInstanceConditionDegraded.String(),
+					Status:             metav1.ConditionTrue,
+					Reason:             "Flapping",
+					LastTransitionTime: metav1.NewTime(start.Add(2 * time.Minute)),
+				},
+				{
+					Type:               av1alpha1.AddonInstanceConditionInstalled.String(),
+					Status:             metav1.ConditionTrue,
+					Reason:             av1alpha1.AddonInstanceInstalledReasonSetupComplete.String(),
+					LastTransitionTime: metav1.NewTime(start),
+				},
+			},
+		},
+	}
+
+	recordHistory(instance, 10)
+	require.Len(t, instance.Status.History, 2)
+	assert.Equal(t, av1alpha1.AddonInstanceConditionInstalled.String(), instance.Status.History[0].ConditionType)
+	assert.Equal(t, av1alpha1.AddonInstanceConditionDegraded.String(), instance.Status.History[1].ConditionType)
+
+	// nothing changed, nothing recorded
+	recordHistory(instance, 10)
+	require.Len(t, instance.Status.History, 2)
+
+	// condition flaps back
+	instance.Status.Conditions[0].Status = metav1.ConditionFalse
+	instance.Status.Conditions[0].LastTransitionTime = metav1.NewTime(start.Add(3 * time.Minute))
+
+	recordHistory(instance, 10)
+	require.Len(t, instance.Status.History, 3)
+	assert.Equal(t, metav1.ConditionFalse, instance.Status.History[2].Status)
+}
+
+func TestRecordHistory_HeartbeatGap(t *testing.T) {
+	t.Parallel()
+
+	lastHeartbeat := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	instance := &av1alpha1.AddonInstance{
+		Status: av1alpha1.AddonInstanceStatus{
+			LastHeartbeatTime: metav1.NewTime(lastHeartbeat),
+			Conditions: []metav1.Condition{
+				{
+					Type:               av1alpha1.AddonInstanceConditionHealthy.String(),
+					Status:             metav1.ConditionUnknown,
+					Reason:             av1alpha1.AddonInstanceHealthyReasonHeartbeatTimeout.String(),
+					LastTransitionTime: metav1.NewTime(lastHeartbeat.Add(30 * time.Second)),
+				},
+			},
+		},
+	}
+
+	recordHistory(instance, 10)
+	gap := findHistoryEvent(instance.Status.History, av1alpha1.AddonInstanceHistoryEventHeartbeatGap)
+	require.NotNil(t, gap)
+	assert.Equal(t, metav1.NewMicroTime(lastHeartbeat), gap.Time)
+	assert.Nil(t, gap.Duration)
+
+	// gap is only opened once
+	recordHistory(instance, 10)
+	require.Len(t, instance.Status.History, 2)
+
+	instance.Status.LastHeartbeatTime = metav1.NewTime(lastHeartbeat.Add(5 * time.Minute))
+	instance.Status.Conditions[0] = metav1.Condition{
+		Type:               av1alpha1.AddonInstanceConditionHealthy.String(),
+		Status:             metav1.ConditionTrue,
+		Reason:             av1alpha1.AddonInstanceHealthyReasonReceivingHeartbeats.String(),
+		LastTransitionTime: metav1.NewTime(lastHeartbeat.Add(5 * time.Minute)),
+	}
+
+	recordHistory(instance, 10)
+	gap = findHistoryEvent(instance.Status.History, av1alpha1.AddonInstanceHistoryEventHeartbeatGap)
+	require.NotNil(t, gap)
+	require.NotNil(t, gap.Duration)
+	assert.Equal(t, 5*time.Minute, gap.Duration.Duration)
+}
+
+func TestRecordHistory_Limit(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	instance := &av1alpha1.AddonInstance{}
+	for i := 0; i < 5; i++ {
+		instance.Status.Conditions = []metav1.Condition{
+			{
+				Type:               av1alpha1.AddonInstanceConditionDegraded.String(),
+				Status:             metav1.ConditionStatus([]string{"True", "False"}[i%2]),
+				LastTransitionTime: metav1.NewTime(start.Add(time.Duration(i) * time.Minute)),
+			},
+		}
+		recordHistory(instance, 3)
+	}
+
+	require.Len(t, instance.Status.History, 3)
+	assert.Equal(t, metav1.NewMicroTime(start.Add(2*time.Minute)), instance.Status.History[0].Time)
+	assert.Equal(t, metav1.NewMicroTime(start.Add(4*time.Minute)), instance.Status.History[2].Time)
+}
+
+func findHistoryEvent(
+	history []av1alpha1.AddonInstanceHistoryEvent, eventType av1alpha1.AddonInstanceHistoryEventType,
+) *av1alpha1.AddonInstanceHistoryEvent {
+	for i := range history {
+		if history[i].Type == eventType {
+			return &history[i]
+		}
+	}
+	return nil
+}
@@ -12,6 +12,12 @@ func (w WithClock) ConfigurePhaseCheckHeartbeat(c *PhaseCheckHeartbeatConfig) {
 	c.Clock = w.Clock
 }
 
+type WithHistoryLimit int
+
+func (w WithHistoryLimit) ConfigureController(c *ControllerConfig) {
+	c.HistoryLimit = int(w)
+}
+
 type WithLog struct{ Log logr.Logger }
 
 func (w WithLog) ConfigureController(c *ControllerConfig) {
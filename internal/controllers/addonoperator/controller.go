@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/openshift/addon-operator/internal/metrics"
@@ -17,12 +18,18 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/deadmanssnitch"
+	"github.com/openshift/addon-operator/internal/extensionhook"
 	"github.com/openshift/addon-operator/internal/ocm"
+	"github.com/openshift/addon-operator/internal/pagerduty"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -45,6 +52,62 @@ type AddonOperatorReconciler struct {
 	Recorder            *metrics.Recorder
 	ClusterExternalID   string
 	FeatureTogglesState []string // no need to guard this with a mutex considering the fact that no two goroutines would ever try to update it as this is only initialized at startup
+
+	// Internal OCM cluster ID and name, looked up in OCM by the external ID when empty.
+	ClusterID   string
+	ClusterName string
+	// Records recent OCM requests of all OCM clients created, when set.
+	OCMAuditLog *ocm.AuditLog
+	// Namespace the AddonOperator is deployed into.
+	AddonOperatorNamespace string
+	// Whether the MonitoringStack feature toggle is enabled,
+	// required to remote write the addon-operator's own metrics.
+	MonitoringStackEnabled bool
+	// Receives the client calling the extension hook.
+	ExtensionHookManager extensionHookManager
+	// Defers Addon installs and upgrades during cluster maintenance.
+	MaintenanceModeManager maintenanceModeManager
+	// Receives the requeue intervals of Addons per class of failure.
+	BackoffPolicyManager backoffPolicyManager
+	// Receives the default PodSecurity admission levels of Addon namespaces.
+	PodSecurityManager podSecurityManager
+	// Receives the Thanos Ruler evaluating Addon monitoring rules.
+	ThanosRulerManager thanosRulerManager
+	// Receives the client provisioning the Dead Man's Snitches of Addons.
+	DeadMansSnitchManager deadMansSnitchManager
+	// Receives the client provisioning the PagerDuty services of Addons.
+	PagerDutyManager pagerDutyManager
+	// Receives the maximum number of Addons upgrading at the same time.
+	UpgradeConcurrencyManager upgradeConcurrencyManager
+
+	// Interval the AddonOperator object is requeued at,
+	// defaults to defaultAddonOperatorRequeueTime when unset.
+	requeueInterval int64
+	// Shared by all OCM clients created, so OCM outages
+	// are remembered when the client is recreated.
+	ocmCircuitBreaker *ocm.CircuitBreaker
+	// Shared by all OCM clients created, so access tokens
+	// are reused until they expire. Recreated when the auth config changes.
+	ocmTokenSource    ocm.TokenSource
+	ocmTokenSourceKey string
+	// Key of the Secret version the Dead Man's Snitch client was created from,
+	// the client is only recreated when the Secret changes.
+	deadMansSnitchClientKey string
+	// Extension hook configuration the extension hook client was created from,
+	// the client and its connections are only recreated when it changes.
+	extensionHook *addonsv1alpha1.AddonOperatorExtensionHook
+}
+
+// Sets the interval the AddonOperator object is requeued at. Concurrency safe.
+func (r *AddonOperatorReconciler) SetRequeueInterval(d time.Duration) {
+	atomic.StoreInt64(&r.requeueInterval, int64(d))
+}
+
+func (r *AddonOperatorReconciler) getRequeueInterval() time.Duration {
+	if d := time.Duration(atomic.LoadInt64(&r.requeueInterval)); d > 0 {
+		return d
+	}
+	return defaultAddonOperatorRequeueTime
 }
 
 func (r *AddonOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -101,10 +164,44 @@ func (r *AddonOperatorReconciler) Reconcile(
 		return ctrl.Result{}, fmt.Errorf("handling global pause: %w", err)
 	}
 
+	if err := r.handleMaintenanceMode(ctx, addonOperator); err != nil {
+		return ctrl.Result{}, fmt.Errorf("handling maintenance mode: %w", err)
+	}
+
 	if err := r.handleOCMClient(ctx, log, addonOperator); err != nil {
 		return ctrl.Result{}, fmt.Errorf("handling OCM client: %w", err)
 	}
 
+	if err := r.handleMetricsRemoteWrite(ctx, addonOperator); err != nil {
+		return ctrl.Result{}, fmt.Errorf("handling metrics remote write: %w", err)
+	}
+
+	if err := r.handleExtensionHook(addonOperator); err != nil {
+		return ctrl.Result{}, fmt.Errorf("handling extension hook: %w", err)
+	}
+
+	if err := r.handleDeadMansSnitch(ctx, addonOperator); err != nil {
+		return ctrl.Result{}, fmt.Errorf("handling dead man's snitch: %w", err)
+	}
+
+	if err := r.handlePagerDuty(ctx, addonOperator); err != nil {
+		return ctrl.Result{}, fmt.Errorf("handling pagerduty: %w", err)
+	}
+
+	if r.BackoffPolicyManager != nil {
+		r.BackoffPolicyManager.SetBackoffPolicy(addonOperator.Spec.BackoffPolicy)
+	}
+	if r.PodSecurityManager != nil {
+		r.PodSecurityManager.SetDefaultPodSecurity(addonOperator.Spec.PodSecurity)
+	}
+	if r.ThanosRulerManager != nil {
+		r.ThanosRulerManager.SetThanosRuler(addonOperator.Spec.ThanosRuler)
+	}
+	if r.UpgradeConcurrencyManager != nil {
+		r.UpgradeConcurrencyManager.SetMaxConcurrentAddonUpgrades(addonOperator.Spec.MaxConcurrentAddonUpgrades)
+	}
+	controllers.SetCommonObjectMetadata(addonOperator.Spec.ObjectMetadata)
+
 	// TODO: This is where all the checking / validation happens
 	// for "in-depth" status reporting
 
@@ -112,7 +209,7 @@ func (r *AddonOperatorReconciler) Reconcile(
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{RequeueAfter: defaultAddonOperatorRequeueTime}, nil
+	return ctrl.Result{RequeueAfter: r.getRequeueInterval()}, nil
 }
 
 func areSlicesEquivalent(sliceA []string, sliceB []string) bool {
@@ -140,7 +237,10 @@ func areSlicesEquivalent(sliceA []string, sliceB []string) bool {
 // Creates an OCM API client and injects it into the OCM Client Manager for distribution.
 func (r *AddonOperatorReconciler) handleOCMClient(
 	ctx context.Context, log logr.Logger, addonOperator *addonsv1alpha1.AddonOperator) error {
-	if addonOperator.Spec.OCM == nil {
+	if err := r.OCMClientManager.SetOCMDisabled(ctx, addonOperator.Spec.OCMDisabled); err != nil {
+		return fmt.Errorf("setting ocm disabled: %w", err)
+	}
+	if addonOperator.Spec.OCMDisabled || addonOperator.Spec.OCM == nil {
 		return nil
 	}
 
@@ -155,20 +255,26 @@ func (r *AddonOperatorReconciler) handleOCMClient(
 		return fmt.Errorf("getting ocm secret: %w", err)
 	}
 
-	accessToken, err := accessTokenFromDockerConfig(secret.Data[corev1.DockerConfigJsonKey])
+	tokenSource, err := r.getOCMTokenSource(addonOperator.Spec.OCM.Auth, secret)
 	if err != nil {
-		return fmt.Errorf("extracting access token from .dockerconfigjson: %w", err)
+		return fmt.Errorf("configuring ocm authentication: %w", err)
 	}
 
-	c, _ := ocm.NewClient(
-		ctx,
+	opts := []ocm.Option{
 		ocm.WithEndpoint(addonOperator.Spec.OCM.Endpoint),
-		ocm.WithAccessToken(accessToken),
+		ocm.WithTokenSource(tokenSource),
 		ocm.WithClusterExternalID(r.ClusterExternalID),
-	)
+		ocm.WithClusterID(r.ClusterID),
+		ocm.WithClusterName(r.ClusterName),
+		ocm.WithCircuitBreaker(r.getOCMCircuitBreaker()),
+		ocm.WithAuditLog(r.OCMAuditLog),
+		ocm.WithRequestHandler(r.recordOCMRequest),
+	}
+	opts = append(opts, ocmTimeoutOptions(addonOperator.Spec.OCM.Timeouts)...)
+	c, _ := ocm.NewClient(ctx, opts...)
 
 	//ocm client not initialized, usually because the OCM API is not yet
-	//available or because the ClusterID from the ClusterVersion doesn't
+	//available or because the discovered external cluster ID doesn't
 	//properly translate into an internal_id
 	if c == nil {
 		log.Info("delaying ocm client initialization until the OCM API is available")
@@ -181,6 +287,265 @@ func (r *AddonOperatorReconciler) handleOCMClient(
 	return nil
 }
 
+func (r *AddonOperatorReconciler) getOCMCircuitBreaker() *ocm.CircuitBreaker {
+	if r.ocmCircuitBreaker == nil {
+		r.ocmCircuitBreaker = ocm.NewCircuitBreaker(
+			ocm.WithCircuitTransitionHandler(func(from, to ocm.CircuitState) {
+				r.Log.Info("ocm circuit breaker transitioned", "from", from, "to", to)
+				if r.Recorder != nil {
+					r.Recorder.RecordOCMCircuitTransition(string(from), string(to))
+				}
+			}),
+		)
+	}
+	return r.ocmCircuitBreaker
+}
+
+// Keys of the OCM secret holding credentials of OAuth mechanisms.
+const (
+	ocmSecretOfflineTokenKey = "offlineToken"
+	ocmSecretClientIDKey     = "clientID"
+	ocmSecretClientSecretKey = "clientSecret"
+)
+
+// Returns the TokenSource of the configured auth mechanism,
+// reusing the previous one, while neither the config nor the secret changed.
+func (r *AddonOperatorReconciler) getOCMTokenSource(
+	auth *addonsv1alpha1.AddonOperatorOCMAuth, secret *corev1.Secret,
+) (ocm.TokenSource, error) {
+	if auth == nil || auth.Type == "" || auth.Type == addonsv1alpha1.OCMAuthAccessToken {
+		accessToken, err := accessTokenFromDockerConfig(secret.Data[corev1.DockerConfigJsonKey])
+		if err != nil {
+			return nil, fmt.Errorf("extracting access token from .dockerconfigjson: %w", err)
+		}
+		return ocm.NewAccessTokenSource(accessToken), nil
+	}
+
+	key := fmt.Sprintf("%s/%s/%s/%s/%s",
+		auth.Type, auth.TokenURL, auth.ServiceAccountTokenPath, secret.UID, secret.ResourceVersion)
+	if r.ocmTokenSource != nil && r.ocmTokenSourceKey == key {
+		return r.ocmTokenSource, nil
+	}
+
+	var (
+		clientID = string(secret.Data[ocmSecretClientIDKey])
+		opts     = []ocm.TokenSourceOption{ocm.WithTokenRefreshHandler(r.recordOCMTokenRefresh)}
+		ts       ocm.TokenSource
+	)
+	switch auth.Type {
+	case addonsv1alpha1.OCMAuthOfflineToken:
+		offlineToken, ok := secret.Data[ocmSecretOfflineTokenKey]
+		if !ok {
+			return nil, fmt.Errorf("missing %q key in ocm secret", ocmSecretOfflineTokenKey)
+		}
+		ts = ocm.NewOfflineTokenSource(auth.TokenURL, clientID, string(offlineToken), opts...)
+	case addonsv1alpha1.OCMAuthClientCredentials:
+		clientSecret, ok := secret.Data[ocmSecretClientSecretKey]
+		if len(clientID) == 0 || !ok {
+			return nil, fmt.Errorf("missing %q or %q key in ocm secret",
+				ocmSecretClientIDKey, ocmSecretClientSecretKey)
+		}
+		ts = ocm.NewClientCredentialsTokenSource(auth.TokenURL, clientID, string(clientSecret), opts...)
+	case addonsv1alpha1.OCMAuthServiceAccount:
+		if len(clientID) == 0 {
+			return nil, fmt.Errorf("missing %q key in ocm secret", ocmSecretClientIDKey)
+		}
+		if len(auth.ServiceAccountTokenPath) == 0 {
+			return nil, fmt.Errorf(".spec.ocm.auth.serviceAccountTokenPath is required for the ServiceAccount mechanism")
+		}
+		ts = ocm.NewServiceAccountTokenSource(auth.TokenURL, clientID, auth.ServiceAccountTokenPath, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported ocm auth mechanism %q", auth.Type)
+	}
+
+	r.ocmTokenSource = ts
+	r.ocmTokenSourceKey = key
+	return ts, nil
+}
+
+func (r *AddonOperatorReconciler) recordOCMTokenRefresh(mechanism string, expiry time.Time, err error) {
+	if err != nil {
+		r.Log.Error(err, "refreshing ocm access token", "mechanism", mechanism)
+	} else {
+		r.Log.Info("refreshed ocm access token", "mechanism", mechanism, "expiry", expiry)
+	}
+	if r.Recorder != nil {
+		r.Recorder.RecordOCMTokenRefresh(mechanism, expiry, err)
+	}
+}
+
+func (r *AddonOperatorReconciler) recordOCMRequest(
+	endpoint ocm.Endpoint, method string, statusCode int, latency time.Duration,
+) {
+	if r.Recorder != nil {
+		r.Recorder.RecordOCMAPIRequest(string(endpoint), method, statusCode, latency)
+	}
+}
+
+func ocmTimeoutOptions(timeouts *addonsv1alpha1.AddonOperatorOCMTimeouts) []ocm.Option {
+	if timeouts == nil {
+		return nil
+	}
+
+	var opts []ocm.Option
+	for endpoint, timeout := range map[ocm.Endpoint]*metav1.Duration{
+		ocm.EndpointClusters:           timeouts.Clusters,
+		ocm.EndpointUpgradePolicies:    timeouts.UpgradePolicies,
+		ocm.EndpointAddOnStatus:        timeouts.AddOnStatus,
+		ocm.EndpointAddOnInstallations: timeouts.AddOnInstallations,
+		ocm.EndpointServiceLogs:        timeouts.ServiceLogs,
+	} {
+		if timeout != nil && timeout.Duration > 0 {
+			opts = append(opts, ocm.WithTimeout(endpoint, timeout.Duration))
+		}
+	}
+	return opts
+}
+
+// Creates an extension hook client and injects it into the Extension Hook Manager,
+// or removes it when no extension hook is configured.
+// The client is kept, while the extension hook configuration did not change.
+func (r *AddonOperatorReconciler) handleExtensionHook(addonOperator *addonsv1alpha1.AddonOperator) error {
+	if r.ExtensionHookManager == nil {
+		return nil
+	}
+
+	hook := addonOperator.Spec.ExtensionHook
+	if hook == nil {
+		r.ExtensionHookManager.InjectExtensionHookClient(nil)
+		r.extensionHook = nil
+		return nil
+	}
+	if r.extensionHook != nil && equality.Semantic.DeepEqual(r.extensionHook, hook) {
+		return nil
+	}
+
+	c, err := extensionhook.NewClient(
+		extensionhook.WithURL(hook.URL),
+		extensionhook.WithCABundle(hook.CABundle),
+		extensionhook.WithIgnoreFailures(
+			hook.FailurePolicy == addonsv1alpha1.ExtensionHookFailurePolicyIgnore),
+	)
+	if err != nil {
+		return fmt.Errorf("creating extension hook client: %w", err)
+	}
+
+	r.ExtensionHookManager.InjectExtensionHookClient(c)
+	r.extensionHook = hook.DeepCopy()
+	return nil
+}
+
+// Key of the API token in the Dead Man's Snitch Secret.
+const deadMansSnitchAPITokenKey = "api-token"
+
+// Creates a Dead Man's Snitch client and injects it into the Dead Man's Snitch Manager,
+// or removes it when no Dead Man's Snitch account is configured.
+// The client is kept, while the Secret holding the API token did not change.
+func (r *AddonOperatorReconciler) handleDeadMansSnitch(
+	ctx context.Context, addonOperator *addonsv1alpha1.AddonOperator) error {
+	if r.DeadMansSnitchManager == nil {
+		return nil
+	}
+
+	dms := addonOperator.Spec.DeadMansSnitch
+	if dms == nil {
+		r.DeadMansSnitchManager.InjectDeadMansSnitchClient(nil)
+		r.deadMansSnitchClientKey = ""
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	// Use an uncached client to get this secret,
+	// so we don't setup a cluster-wide cache for Secrets.
+	if err := r.UncachedClient.Get(ctx, client.ObjectKey{
+		Name:      dms.APITokenSecret.Name,
+		Namespace: dms.APITokenSecret.Namespace,
+	}, secret); err != nil {
+		return fmt.Errorf("getting dead man's snitch secret: %w", err)
+	}
+	key := fmt.Sprintf("%s/%s", secret.UID, secret.ResourceVersion)
+	if r.deadMansSnitchClientKey == key {
+		return nil
+	}
+
+	c, err := deadmanssnitch.NewClient(
+		deadmanssnitch.WithAPIToken(string(secret.Data[deadMansSnitchAPITokenKey])),
+	)
+	if err != nil {
+		return fmt.Errorf("creating dead man's snitch client: %w", err)
+	}
+
+	r.DeadMansSnitchManager.InjectDeadMansSnitchClient(c)
+	r.deadMansSnitchClientKey = key
+	return nil
+}
+
+// Key of the REST API key in the PagerDuty Secret.
+const pagerDutyAPIKeyKey = "api-key"
+
+// Creates a PagerDuty client and injects it into the PagerDuty Manager,
+// or removes it when no PagerDuty account is configured.
+func (r *AddonOperatorReconciler) handlePagerDuty(
+	ctx context.Context, addonOperator *addonsv1alpha1.AddonOperator) error {
+	if r.PagerDutyManager == nil {
+		return nil
+	}
+
+	pd := addonOperator.Spec.PagerDuty
+	if pd == nil {
+		r.PagerDutyManager.InjectPagerDutyClient(nil, "")
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	// Use an uncached client to get this secret,
+	// so we don't setup a cluster-wide cache for Secrets.
+	if err := r.UncachedClient.Get(ctx, client.ObjectKey{
+		Name:      pd.APIKeySecret.Name,
+		Namespace: pd.APIKeySecret.Namespace,
+	}, secret); err != nil {
+		return fmt.Errorf("getting pagerduty secret: %w", err)
+	}
+
+	c, err := pagerduty.NewClient(
+		pagerduty.WithAPIKey(string(secret.Data[pagerDutyAPIKeyKey])),
+	)
+	if err != nil {
+		return fmt.Errorf("creating pagerduty client: %w", err)
+	}
+
+	r.PagerDutyManager.InjectPagerDutyClient(c, pd.EscalationPolicyID)
+	return nil
+}
+
+// Propagates the cluster maintenance signal to the Maintenance Mode Manager.
+// The MaintenanceMode condition is persisted with the readiness status.
+func (r *AddonOperatorReconciler) handleMaintenanceMode(
+	ctx context.Context, addonOperator *addonsv1alpha1.AddonOperator) error {
+	if r.MaintenanceModeManager == nil {
+		return nil
+	}
+
+	enabled := addonOperator.Spec.MaintenanceMode
+	if err := r.MaintenanceModeManager.SetMaintenanceMode(ctx, enabled); err != nil {
+		return fmt.Errorf("setting maintenance mode: %w", err)
+	}
+
+	if !enabled {
+		meta.RemoveStatusCondition(&addonOperator.Status.Conditions,
+			addonsv1alpha1.AddonOperatorMaintenanceMode)
+		return nil
+	}
+	meta.SetStatusCondition(&addonOperator.Status.Conditions, metav1.Condition{
+		Type:               addonsv1alpha1.AddonOperatorMaintenanceMode,
+		Status:             metav1.ConditionTrue,
+		Reason:             addonsv1alpha1.AddonOperatorReasonMaintenanceMode,
+		Message:            "Addon installs and upgrades are deferred during cluster maintenance",
+		ObservedGeneration: addonOperator.Generation,
+	})
+	return nil
+}
+
 func (r *AddonOperatorReconciler) handleGlobalPause(
 	ctx context.Context, addonOperator *addonsv1alpha1.AddonOperator) error {
 	// Check if addonoperator.spec.paused == true
@@ -3,14 +3,21 @@ package addonoperator
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/deadmanssnitch"
+	"github.com/openshift/addon-operator/internal/extensionhook"
+	"github.com/openshift/addon-operator/internal/ocm"
+	"github.com/openshift/addon-operator/internal/pagerduty"
 	"github.com/openshift/addon-operator/internal/testutil"
 )
 
@@ -146,3 +153,278 @@ func (r *globalPauseManagerMock) DisableGlobalPause(ctx context.Context) error {
 	args := r.Called(ctx)
 	return args.Error(0)
 }
+
+func TestHandleExtensionHook(t *testing.T) {
+	t.Run("injects client", func(t *testing.T) {
+		ehm := &extensionHookManagerMock{}
+		r := &AddonOperatorReconciler{
+			ExtensionHookManager: ehm,
+		}
+		ao := &addonsv1alpha1.AddonOperator{
+			Spec: addonsv1alpha1.AddonOperatorSpec{
+				ExtensionHook: &addonsv1alpha1.AddonOperatorExtensionHook{
+					URL: "https://hook.example.com",
+				},
+			},
+		}
+
+		ehm.On("InjectExtensionHookClient", mock.AnythingOfType("*extensionhook.Client"))
+
+		require.NoError(t, r.handleExtensionHook(ao))
+		ehm.AssertExpectations(t)
+
+		// The client is kept, while the configuration did not change.
+		require.NoError(t, r.handleExtensionHook(ao.DeepCopy()))
+		ehm.AssertNumberOfCalls(t, "InjectExtensionHookClient", 1)
+
+		ao.Spec.ExtensionHook.FailurePolicy = addonsv1alpha1.ExtensionHookFailurePolicyIgnore
+		require.NoError(t, r.handleExtensionHook(ao))
+		ehm.AssertNumberOfCalls(t, "InjectExtensionHookClient", 2)
+	})
+
+	t.Run("removes client", func(t *testing.T) {
+		ehm := &extensionHookManagerMock{}
+		r := &AddonOperatorReconciler{
+			ExtensionHookManager: ehm,
+		}
+
+		ehm.On("InjectExtensionHookClient", (*extensionhook.Client)(nil))
+
+		require.NoError(t, r.handleExtensionHook(&addonsv1alpha1.AddonOperator{}))
+		ehm.AssertExpectations(t)
+	})
+}
+
+type extensionHookManagerMock struct {
+	mock.Mock
+}
+
+func (r *extensionHookManagerMock) InjectExtensionHookClient(c *extensionhook.Client) {
+	r.Called(c)
+}
+
+func TestHandleDeadMansSnitch(t *testing.T) {
+	t.Run("injects client", func(t *testing.T) {
+		c := testutil.NewClient()
+		dmsm := &deadMansSnitchManagerMock{}
+		r := &AddonOperatorReconciler{
+			UncachedClient:        c,
+			DeadMansSnitchManager: dmsm,
+		}
+		ao := &addonsv1alpha1.AddonOperator{
+			Spec: addonsv1alpha1.AddonOperatorSpec{
+				DeadMansSnitch: &addonsv1alpha1.AddonOperatorDeadMansSnitch{
+					APITokenSecret: addonsv1alpha1.ClusterSecretReference{
+						Name:      "dead-mans-snitch",
+						Namespace: "addon-operator",
+					},
+				},
+			},
+		}
+
+		resourceVersion := "1"
+		c.On("Get", testutil.IsContext, client.ObjectKey{
+			Name:      "dead-mans-snitch",
+			Namespace: "addon-operator",
+		}, mock.IsType(&corev1.Secret{}), mock.Anything).
+			Run(func(args mock.Arguments) {
+				secret := args.Get(2).(*corev1.Secret)
+				secret.ResourceVersion = resourceVersion
+				secret.Data = map[string][]byte{
+					deadMansSnitchAPITokenKey: []byte("secret"),
+				}
+			}).
+			Return(nil)
+		dmsm.On("InjectDeadMansSnitchClient", mock.AnythingOfType("*deadmanssnitch.Client"))
+
+		require.NoError(t, r.handleDeadMansSnitch(context.Background(), ao))
+		dmsm.AssertNumberOfCalls(t, "InjectDeadMansSnitchClient", 1)
+
+		// The client is kept, while the Secret is unchanged.
+		require.NoError(t, r.handleDeadMansSnitch(context.Background(), ao))
+		dmsm.AssertNumberOfCalls(t, "InjectDeadMansSnitchClient", 1)
+
+		resourceVersion = "2"
+		require.NoError(t, r.handleDeadMansSnitch(context.Background(), ao))
+		dmsm.AssertNumberOfCalls(t, "InjectDeadMansSnitchClient", 2)
+	})
+
+	t.Run("removes client", func(t *testing.T) {
+		dmsm := &deadMansSnitchManagerMock{}
+		r := &AddonOperatorReconciler{
+			DeadMansSnitchManager: dmsm,
+		}
+
+		dmsm.On("InjectDeadMansSnitchClient", (*deadmanssnitch.Client)(nil))
+
+		require.NoError(t, r.handleDeadMansSnitch(context.Background(), &addonsv1alpha1.AddonOperator{}))
+		dmsm.AssertExpectations(t)
+	})
+}
+
+type deadMansSnitchManagerMock struct {
+	mock.Mock
+}
+
+func (r *deadMansSnitchManagerMock) InjectDeadMansSnitchClient(c *deadmanssnitch.Client) {
+	r.Called(c)
+}
+
+func TestHandlePagerDuty(t *testing.T) {
+	t.Run("injects client", func(t *testing.T) {
+		c := testutil.NewClient()
+		pdm := &pagerDutyManagerMock{}
+		r := &AddonOperatorReconciler{
+			UncachedClient:   c,
+			PagerDutyManager: pdm,
+		}
+		ao := &addonsv1alpha1.AddonOperator{
+			Spec: addonsv1alpha1.AddonOperatorSpec{
+				PagerDuty: &addonsv1alpha1.AddonOperatorPagerDuty{
+					APIKeySecret: addonsv1alpha1.ClusterSecretReference{
+						Name:      "pagerduty",
+						Namespace: "addon-operator",
+					},
+					EscalationPolicyID: "PEP123",
+				},
+			},
+		}
+
+		c.On("Get", testutil.IsContext, client.ObjectKey{
+			Name:      "pagerduty",
+			Namespace: "addon-operator",
+		}, mock.IsType(&corev1.Secret{}), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(2).(*corev1.Secret).Data = map[string][]byte{
+					pagerDutyAPIKeyKey: []byte("secret"),
+				}
+			}).
+			Return(nil)
+		pdm.On("InjectPagerDutyClient", mock.AnythingOfType("*pagerduty.Client"), "PEP123")
+
+		require.NoError(t, r.handlePagerDuty(context.Background(), ao))
+		pdm.AssertExpectations(t)
+	})
+
+	t.Run("removes client", func(t *testing.T) {
+		pdm := &pagerDutyManagerMock{}
+		r := &AddonOperatorReconciler{
+			PagerDutyManager: pdm,
+		}
+
+		pdm.On("InjectPagerDutyClient", (*pagerduty.Client)(nil), "")
+
+		require.NoError(t, r.handlePagerDuty(context.Background(), &addonsv1alpha1.AddonOperator{}))
+		pdm.AssertExpectations(t)
+	})
+}
+
+type pagerDutyManagerMock struct {
+	mock.Mock
+}
+
+func (r *pagerDutyManagerMock) InjectPagerDutyClient(c *pagerduty.Client, escalationPolicyID string) {
+	r.Called(c, escalationPolicyID)
+}
+
+func TestHandleMaintenanceMode(t *testing.T) {
+	mmm := &maintenanceModeManagerMock{}
+	r := &AddonOperatorReconciler{
+		MaintenanceModeManager: mmm,
+	}
+	ao := &addonsv1alpha1.AddonOperator{
+		Spec: addonsv1alpha1.AddonOperatorSpec{
+			MaintenanceMode: true,
+		},
+	}
+
+	mmm.On("SetMaintenanceMode", mock.Anything, true).Return(nil)
+	require.NoError(t, r.handleMaintenanceMode(context.Background(), ao))
+	assert.True(t, meta.IsStatusConditionTrue(
+		ao.Status.Conditions, addonsv1alpha1.AddonOperatorMaintenanceMode))
+
+	ao.Spec.MaintenanceMode = false
+	mmm.On("SetMaintenanceMode", mock.Anything, false).Return(nil)
+	require.NoError(t, r.handleMaintenanceMode(context.Background(), ao))
+	assert.Nil(t, meta.FindStatusCondition(
+		ao.Status.Conditions, addonsv1alpha1.AddonOperatorMaintenanceMode))
+	mmm.AssertExpectations(t)
+}
+
+type maintenanceModeManagerMock struct {
+	mock.Mock
+}
+
+func (r *maintenanceModeManagerMock) SetMaintenanceMode(ctx context.Context, enabled bool) error {
+	args := r.Called(ctx, enabled)
+	return args.Error(0)
+}
+
+func TestGetOCMCircuitBreaker(t *testing.T) {
+	r := &AddonOperatorReconciler{Log: testutil.NewLogger(t)}
+
+	cb := r.getOCMCircuitBreaker()
+	require.NotNil(t, cb)
+	// Shared by all clients created.
+	assert.Same(t, cb, r.getOCMCircuitBreaker())
+}
+
+func TestOCMTimeoutOptions(t *testing.T) {
+	assert.Empty(t, ocmTimeoutOptions(nil))
+
+	var o ocm.ClientOptions
+	for _, opt := range ocmTimeoutOptions(&addonsv1alpha1.AddonOperatorOCMTimeouts{
+		UpgradePolicies: &metav1.Duration{Duration: 5 * time.Second},
+		ServiceLogs:     &metav1.Duration{Duration: 0},
+	}) {
+		opt(&o)
+	}
+	assert.Equal(t, map[ocm.Endpoint]time.Duration{
+		ocm.EndpointUpgradePolicies: 5 * time.Second,
+	}, o.Timeouts)
+}
+
+func TestGetOCMTokenSource(t *testing.T) {
+	r := &AddonOperatorReconciler{Log: testutil.NewLogger(t)}
+
+	pullSecret := &corev1.Secret{
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"cloud.openshift.com":{"auth":"token"}}}`),
+		},
+	}
+	ts, err := r.getOCMTokenSource(nil, pullSecret)
+	require.NoError(t, err)
+	assert.Equal(t, ocm.AuthMechanismAccessToken, ts.Mechanism())
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{UID: "1234", ResourceVersion: "1"},
+		Data: map[string][]byte{
+			"clientID":     []byte("id"),
+			"clientSecret": []byte("secret"),
+		},
+	}
+	auth := &addonsv1alpha1.AddonOperatorOCMAuth{Type: addonsv1alpha1.OCMAuthClientCredentials}
+	ts, err = r.getOCMTokenSource(auth, secret)
+	require.NoError(t, err)
+	assert.Equal(t, ocm.AuthMechanismClientCredentials, ts.Mechanism())
+
+	// Reused, until the secret changes.
+	reused, err := r.getOCMTokenSource(auth, secret)
+	require.NoError(t, err)
+	assert.Same(t, ts, reused)
+
+	secret.ResourceVersion = "2"
+	recreated, err := r.getOCMTokenSource(auth, secret)
+	require.NoError(t, err)
+	assert.NotSame(t, ts, recreated)
+
+	_, err = r.getOCMTokenSource(&addonsv1alpha1.AddonOperatorOCMAuth{
+		Type: addonsv1alpha1.OCMAuthOfflineToken,
+	}, secret)
+	assert.EqualError(t, err, `missing "offlineToken" key in ocm secret`)
+
+	_, err = r.getOCMTokenSource(&addonsv1alpha1.AddonOperatorOCMAuth{
+		Type: addonsv1alpha1.OCMAuthServiceAccount,
+	}, secret)
+	assert.Error(t, err)
+}
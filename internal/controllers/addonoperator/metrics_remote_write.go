@@ -0,0 +1,158 @@
+package addonoperator
+
+import (
+	"context"
+	"fmt"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+)
+
+const (
+	// Name of the MonitoringStack and ServiceMonitor shipping the addon-operator's own metrics.
+	metricsRemoteWriteName = "addon-operator-metrics-remote-write"
+	// Name of the Service exposing the addon-operator's metrics.
+	metricsServiceName = "addon-operator-metrics"
+	// ConfigMap injected into every namespace by the service-ca-operator,
+	// containing the CA the metrics serving certificate is signed with.
+	serviceCAConfigMapName = "openshift-service-ca.crt"
+	serviceCAConfigMapKey  = "service-ca.crt"
+	// External label identifying the cluster on all remote written series.
+	clusterIDLabel = "cluster_id"
+)
+
+// Ensures a MonitoringStack remote writing the addon-operator's own metrics to RHOBS,
+// when configured and the MonitoringStack feature toggle is enabled.
+func (r *AddonOperatorReconciler) handleMetricsRemoteWrite(
+	ctx context.Context, addonOperator *addonsv1alpha1.AddonOperator) error {
+	if !r.MonitoringStackEnabled {
+		return nil
+	}
+
+	serviceMonitor := r.desiredMetricsServiceMonitor()
+	monitoringStack := r.desiredMetricsMonitoringStack(addonOperator)
+
+	if addonOperator.Spec.MetricsRemoteWrite == nil {
+		if err := r.Delete(ctx, monitoringStack); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting MonitoringStack: %w", err)
+		}
+		if err := r.Delete(ctx, serviceMonitor); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting ServiceMonitor: %w", err)
+		}
+		return nil
+	}
+
+	for _, obj := range []client.Object{serviceMonitor, monitoringStack} {
+		if err := controllerutil.SetControllerReference(addonOperator, obj, r.Scheme); err != nil {
+			return err
+		}
+	}
+
+	if err := r.reconcileMetricsServiceMonitor(ctx, serviceMonitor); err != nil {
+		return fmt.Errorf("reconciling ServiceMonitor: %w", err)
+	}
+	if err := r.reconcileMetricsMonitoringStack(ctx, monitoringStack); err != nil {
+		return fmt.Errorf("reconciling MonitoringStack: %w", err)
+	}
+	return nil
+}
+
+func (r *AddonOperatorReconciler) desiredMetricsMonitoringStack(
+	addonOperator *addonsv1alpha1.AddonOperator) *obov1alpha1.MonitoringStack {
+	var (
+		replicas          int32 = 1
+		remoteWriteURL    string
+		remoteWriteOAuth2 *monv1.OAuth2
+	)
+	if remoteWrite := addonOperator.Spec.MetricsRemoteWrite; remoteWrite != nil {
+		remoteWriteURL = remoteWrite.URL
+		remoteWriteOAuth2 = remoteWrite.OAuth2
+	}
+
+	return &obov1alpha1.MonitoringStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metricsRemoteWriteName,
+			Namespace: r.AddonOperatorNamespace,
+		},
+		Spec: obov1alpha1.MonitoringStackSpec{
+			// Metrics are only buffered locally until they are shipped.
+			Retention: "1d",
+			ResourceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					controllers.MSOLabel: addonsv1alpha1.DefaultAddonOperatorName,
+				},
+			},
+			AlertmanagerConfig: obov1alpha1.AlertmanagerConfig{
+				Disabled: true,
+			},
+			PrometheusConfig: &obov1alpha1.PrometheusConfig{
+				Replicas: &replicas,
+				ExternalLabels: map[string]string{
+					clusterIDLabel: r.ClusterExternalID,
+				},
+				RemoteWrite: []monv1.RemoteWriteSpec{
+					{
+						URL:    remoteWriteURL,
+						OAuth2: remoteWriteOAuth2,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AddonOperatorReconciler) desiredMetricsServiceMonitor() *monv1.ServiceMonitor {
+	return &monv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metricsRemoteWriteName,
+			Namespace: r.AddonOperatorNamespace,
+			Labels: map[string]string{
+				controllers.MSOLabel: addonsv1alpha1.DefaultAddonOperatorName,
+			},
+		},
+		Spec: monv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name": addonsv1alpha1.DefaultAddonOperatorName,
+				},
+			},
+			Endpoints: []monv1.Endpoint{
+				{
+					Port:   "https",
+					Path:   "/metrics",
+					Scheme: "https",
+					TLSConfig: &monv1.TLSConfig{
+						SafeTLSConfig: monv1.SafeTLSConfig{
+							CA: monv1.SecretOrConfigMap{
+								ConfigMap: &corev1.ConfigMapKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: serviceCAConfigMapName,
+									},
+									Key: serviceCAConfigMapKey,
+								},
+							},
+							ServerName: fmt.Sprintf("%s.%s.svc", metricsServiceName, r.AddonOperatorNamespace),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AddonOperatorReconciler) reconcileMetricsMonitoringStack(
+	ctx context.Context, desired *obov1alpha1.MonitoringStack) error {
+	return controllers.Apply(ctx, r.Client, desired)
+}
+
+func (r *AddonOperatorReconciler) reconcileMetricsServiceMonitor(
+	ctx context.Context, desired *monv1.ServiceMonitor) error {
+	return controllers.Apply(ctx, r.Client, desired)
+}
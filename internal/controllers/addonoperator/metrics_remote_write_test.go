@@ -0,0 +1,88 @@
+package addonoperator
+
+import (
+	"context"
+	"testing"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestHandleMetricsRemoteWrite(t *testing.T) {
+	t.Run("noop when MonitoringStack feature toggle is disabled", func(t *testing.T) {
+		c := testutil.NewClient()
+		r := &AddonOperatorReconciler{
+			Client: c,
+		}
+		ao := &addonsv1alpha1.AddonOperator{
+			Spec: addonsv1alpha1.AddonOperatorSpec{
+				MetricsRemoteWrite: &addonsv1alpha1.AddonOperatorMetricsRemoteWrite{
+					URL: "https://rhobs.example.com/receive",
+				},
+			},
+		}
+
+		require.NoError(t, r.handleMetricsRemoteWrite(context.Background(), ao))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("creates MonitoringStack and ServiceMonitor", func(t *testing.T) {
+		c := testutil.NewClient()
+		r := &AddonOperatorReconciler{
+			Client:                 c,
+			Scheme:                 testutil.NewTestSchemeWithAddonsv1alpha1AndMsov1alpha1(),
+			ClusterExternalID:      "cluster-1",
+			AddonOperatorNamespace: "addon-operator",
+			MonitoringStackEnabled: true,
+		}
+		ao := &addonsv1alpha1.AddonOperator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: addonsv1alpha1.DefaultAddonOperatorName,
+			},
+			Spec: addonsv1alpha1.AddonOperatorSpec{
+				MetricsRemoteWrite: &addonsv1alpha1.AddonOperatorMetricsRemoteWrite{
+					URL: "https://rhobs.example.com/receive",
+				},
+			},
+		}
+
+		c.On("Patch", testutil.IsContext, mock.IsType(&monv1.ServiceMonitor{}), mock.Anything, mock.Anything).
+			Return(nil)
+		c.On("Patch", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				ms := args.Get(1).(*obov1alpha1.MonitoringStack)
+				assert.Equal(t, "addon-operator", ms.Namespace)
+				assert.Equal(t, "cluster-1", ms.Spec.PrometheusConfig.ExternalLabels[clusterIDLabel])
+				assert.Equal(t, "https://rhobs.example.com/receive", ms.Spec.PrometheusConfig.RemoteWrite[0].URL)
+			}).
+			Return(nil)
+
+		require.NoError(t, r.handleMetricsRemoteWrite(context.Background(), ao))
+		c.AssertExpectations(t)
+	})
+
+	t.Run("deletes MonitoringStack and ServiceMonitor when not configured", func(t *testing.T) {
+		c := testutil.NewClient()
+		r := &AddonOperatorReconciler{
+			Client:                 c,
+			AddonOperatorNamespace: "addon-operator",
+			MonitoringStackEnabled: true,
+		}
+		ao := &addonsv1alpha1.AddonOperator{}
+
+		c.On("Delete", testutil.IsContext, mock.IsType(&obov1alpha1.MonitoringStack{}), mock.Anything).
+			Return(testutil.NewTestErrNotFound())
+		c.On("Delete", testutil.IsContext, mock.IsType(&monv1.ServiceMonitor{}), mock.Anything).
+			Return(nil)
+
+		require.NoError(t, r.handleMetricsRemoteWrite(context.Background(), ao))
+		c.AssertExpectations(t)
+	})
+}
@@ -8,7 +8,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/deadmanssnitch"
+	"github.com/openshift/addon-operator/internal/extensionhook"
 	"github.com/openshift/addon-operator/internal/ocm"
+	"github.com/openshift/addon-operator/internal/pagerduty"
 )
 
 // globalPauseManager is an interface used for coordinating
@@ -21,6 +24,39 @@ type globalPauseManager interface {
 
 type ocmClientManager interface {
 	InjectOCMClient(ctx context.Context, c *ocm.Client) error
+	SetOCMDisabled(ctx context.Context, disabled bool) error
+}
+
+type maintenanceModeManager interface {
+	SetMaintenanceMode(ctx context.Context, enabled bool) error
+}
+
+type extensionHookManager interface {
+	InjectExtensionHookClient(c *extensionhook.Client)
+}
+
+type backoffPolicyManager interface {
+	SetBackoffPolicy(policy *addonsv1alpha1.AddonOperatorBackoffPolicy)
+}
+
+type podSecurityManager interface {
+	SetDefaultPodSecurity(config *addonsv1alpha1.PodSecurityConfig)
+}
+
+type thanosRulerManager interface {
+	SetThanosRuler(config *addonsv1alpha1.AddonOperatorThanosRuler)
+}
+
+type upgradeConcurrencyManager interface {
+	SetMaxConcurrentAddonUpgrades(max int)
+}
+
+type deadMansSnitchManager interface {
+	InjectDeadMansSnitchClient(c *deadmanssnitch.Client)
+}
+
+type pagerDutyManager interface {
+	InjectPagerDutyClient(c *pagerduty.Client, escalationPolicyID string)
 }
 
 func (r *AddonOperatorReconciler) handleAddonOperatorCreation(
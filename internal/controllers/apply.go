@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Field manager of the addon-operator when applying objects server-side.
+const FieldManager = "addon-operator"
+
+// Field managers of older addon-operator versions, which created and
+// updated objects via Create and Update requests. The API server derives
+// them from the user agent, when no field manager is set.
+var legacyFieldManagers = sets.New("addon-operator-manager")
+
+// Applies the given object server-side, taking ownership of all fields set on it.
+// Fields set by other field managers are kept, fields previously applied,
+// but no longer set on the object are removed.
+// The object is updated with the state returned by the API server.
+func Apply(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := apply(ctx, c, obj); err != nil {
+		return err
+	}
+	return migrateLegacyFieldManagers(ctx, c, obj)
+}
+
+// Applies the given object like Apply.
+// Returns true, if fields previously applied to the current object
+// had to be changed, e.g. to revert changes made by someone else.
+// Objects applied for the first time are never reported as changed.
+func ApplyChanged(ctx context.Context, c client.Client, current, obj client.Object) (bool, error) {
+	before := appliedAt(current)
+	if err := apply(ctx, c, obj); err != nil {
+		return false, err
+	}
+	after := appliedAt(obj)
+	changed := before != nil && after != nil && !before.Equal(after)
+	return changed, migrateLegacyFieldManagers(ctx, c, obj)
+}
+
+func apply(ctx context.Context, c client.Client, obj client.Object) error {
+	if obj.GetObjectKind().GroupVersionKind().Empty() {
+		gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+		if err != nil {
+			return fmt.Errorf("looking up GroupVersionKind: %w", err)
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	// Apply requests must neither contain managed fields nor
+	// be bound to a resourceVersion of the object.
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+
+	err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager))
+	if k8sApiErrors.IsConflict(err) {
+		// Another field manager changed fields the addon-operator is the source of truth for.
+		LoggerFromContext(ctx).Info("taking over conflicting fields",
+			"object", client.ObjectKeyFromObject(obj), "conflict", err.Error())
+		err = c.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	}
+	return err
+}
+
+// Returns the time fields of the object were last changed by an apply
+// of the addon-operator. The API server keeps the time for applies
+// not changing the object.
+func appliedAt(obj client.Object) *metav1.Time {
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == FieldManager &&
+			entry.Operation == metav1.ManagedFieldsOperationApply &&
+			len(entry.Subresource) == 0 {
+			return entry.Time
+		}
+	}
+	return nil
+}
+
+// Hands the fields owned by older addon-operator versions over to the
+// apply field manager, so they are removed when no longer applied.
+func migrateLegacyFieldManagers(ctx context.Context, c client.Client, obj client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(obj, legacyFieldManagers, FieldManager)
+	if err != nil {
+		return fmt.Errorf("migrating managed fields: %w", err)
+	}
+	if patch == nil {
+		return nil
+	}
+	if err := c.Patch(ctx, obj, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+		return fmt.Errorf("migrating managed fields: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestApply(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), client.Apply, mock.Anything).
+		Return(nil)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test",
+			Namespace:       "test",
+			ResourceVersion: "42",
+		},
+	}
+	require.NoError(t, Apply(context.Background(), c, cm))
+	c.AssertExpectations(t)
+
+	assert.Equal(t, "ConfigMap", cm.Kind)
+	assert.Equal(t, "v1", cm.APIVersion)
+	assert.Empty(t, cm.ResourceVersion)
+}
+
+func TestApply_ForcesOwnershipOnConflict(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), client.Apply,
+		[]client.PatchOption{client.FieldOwner(FieldManager)}).
+		Return(k8sApiErrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test", nil)).
+		Once()
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), client.Apply,
+		[]client.PatchOption{client.FieldOwner(FieldManager), client.ForceOwnership}).
+		Return(nil).
+		Once()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+	}
+	ctx := ContextWithLogger(context.Background(), testutil.NewLogger(t))
+	require.NoError(t, Apply(ctx, c, cm))
+	c.AssertExpectations(t)
+}
+
+func TestApply_MigratesLegacyFieldManagers(t *testing.T) {
+	c := testutil.NewClient()
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), client.Apply, mock.Anything).
+		Run(func(args mock.Arguments) {
+			cm := args.Get(1).(*corev1.ConfigMap)
+			cm.ManagedFields = []metav1.ManagedFieldsEntry{
+				{
+					Manager:    "addon-operator-manager",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+				},
+				{
+					Manager:    FieldManager,
+					Operation:  metav1.ManagedFieldsOperationApply,
+					APIVersion: "v1",
+					FieldsType: "FieldsV1",
+					FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)},
+				},
+			}
+		}).
+		Return(nil).
+		Once()
+	var migration client.Patch
+	c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			migration = args.Get(2).(client.Patch)
+		}).
+		Return(nil).
+		Once()
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+		Data:       map[string]string{"key": "value"},
+	}
+	require.NoError(t, Apply(context.Background(), c, cm))
+	c.AssertExpectations(t)
+
+	require.NotNil(t, migration)
+	assert.Equal(t, "application/json-patch+json", string(migration.Type()))
+}
+
+func TestApplyChanged(t *testing.T) {
+	var (
+		before = metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+		after  = metav1.NewTime(before.Add(time.Minute))
+	)
+	applied := func(at metav1.Time) []metav1.ManagedFieldsEntry {
+		return []metav1.ManagedFieldsEntry{{
+			Manager:    FieldManager,
+			Operation:  metav1.ManagedFieldsOperationApply,
+			APIVersion: "v1",
+			Time:       &at,
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{}}`)},
+		}}
+	}
+
+	for name, tc := range map[string]struct {
+		Current  []metav1.ManagedFieldsEntry
+		Applied  []metav1.ManagedFieldsEntry
+		Expected bool
+	}{
+		"first apply": {
+			Applied: applied(after),
+		},
+		"unchanged": {
+			Current: applied(before),
+			Applied: applied(before),
+		},
+		"changed": {
+			Current:  applied(before),
+			Applied:  applied(after),
+			Expected: true,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c := testutil.NewClient()
+			c.On("Patch", testutil.IsContext, mock.IsType(&corev1.ConfigMap{}), client.Apply, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).(*corev1.ConfigMap).ManagedFields = tc.Applied
+				}).
+				Return(nil)
+
+			current := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{ManagedFields: tc.Current},
+			}
+			desired := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			}
+			changed, err := ApplyChanged(context.Background(), c, current, desired)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, changed)
+		})
+	}
+}
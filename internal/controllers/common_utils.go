@@ -3,6 +3,7 @@ package controllers
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -23,12 +24,16 @@ const (
 	MSOLabel = "addons.managed.openshift.io/mso"
 )
 
-func AddCommonLabels(obj metav1.Object, addon *addonsv1alpha1.Addon) {
-	labels := obj.GetLabels()
-	if labels == nil {
-		labels = map[string]string{}
-	}
+const (
+	// Service fronting the alert receiver hosted by the addon-operator.
+	AlertReceiverServiceName = "addon-operator-alert-receiver"
+	AlertReceiverServicePort = 8084
+)
 
+// The common labels of the Addon take precedence over object specific labels,
+// which take precedence over the labels configured via the AddonOperator.
+func AddCommonLabels(obj metav1.Object, addon *addonsv1alpha1.Addon) {
+	labels := k8slabels.Merge(commonObjectMetadata.getLabels(), obj.GetLabels())
 	labels[CommonManagedByLabel] = CommonManagedByValue
 	labels[CommonCacheLabel] = CommonCacheValue
 	labels[CommonInstanceLabel] = addon.Name
@@ -38,18 +43,56 @@ func AddCommonLabels(obj metav1.Object, addon *addonsv1alpha1.Addon) {
 	obj.SetLabels(labels)
 }
 
+// The common annotations of the Addon take precedence over object specific annotations,
+// which take precedence over the annotations configured via the AddonOperator.
 func AddCommonAnnotations(obj metav1.Object, addon *addonsv1alpha1.Addon) {
-	if len(addon.Spec.CommonAnnotations) == 0 {
+	commonAnnotations := commonObjectMetadata.getAnnotations()
+	if len(commonAnnotations) == 0 && len(addon.Spec.CommonAnnotations) == 0 {
 		return
 	}
-	annotations := obj.GetAnnotations()
-	if annotations == nil {
-		annotations = map[string]string{}
-	}
+	annotations := k8slabels.Merge(commonAnnotations, obj.GetAnnotations())
 	annotations = k8slabels.Merge(annotations, addon.Spec.CommonAnnotations)
 	obj.SetAnnotations(annotations)
 }
 
+// Labels and annotations stamped onto every object created for Addons,
+// configured fleet-wide via the AddonOperator.
+var commonObjectMetadata objectMetadata
+
+type objectMetadata struct {
+	mux         sync.RWMutex
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// Sets the labels and annotations stamped onto every object created for Addons
+// by AddCommonLabels and AddCommonAnnotations.
+// Labels and annotations configured on an Addon take precedence. Concurrency safe.
+func SetCommonObjectMetadata(m *addonsv1alpha1.AddonOperatorObjectMetadata) {
+	var labels, annotations map[string]string
+	if m != nil {
+		labels = k8slabels.Merge(nil, m.Labels)
+		annotations = k8slabels.Merge(nil, m.Annotations)
+	}
+
+	commonObjectMetadata.mux.Lock()
+	defer commonObjectMetadata.mux.Unlock()
+	commonObjectMetadata.labels = labels
+	commonObjectMetadata.annotations = annotations
+}
+
+func (m *objectMetadata) getLabels() map[string]string {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.labels
+}
+
+func (m *objectMetadata) getAnnotations() map[string]string {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return m.annotations
+}
+
 func CommonLabelsAsLabelSelector(addon *addonsv1alpha1.Addon) labels.Selector {
 	labelSet := make(labels.Set)
 	labelSet[CommonManagedByLabel] = CommonManagedByValue
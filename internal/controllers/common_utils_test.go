@@ -64,3 +64,54 @@ func TestCommonLabelsAsLabelSelector(t *testing.T) {
 		t.Fatal("selector is empty but should filter on common labels")
 	}
 }
+
+func TestCommonObjectMetadata(t *testing.T) {
+	SetCommonObjectMetadata(&addonsv1alpha1.AddonOperatorObjectMetadata{
+		Labels: map[string]string{
+			"cost-center":        "1234",
+			"team":               "fleet",
+			CommonManagedByLabel: "someone-else",
+		},
+		Annotations: map[string]string{
+			"team": "fleet",
+		},
+	})
+	t.Cleanup(func() { SetCommonObjectMetadata(nil) })
+
+	addon := &addonsv1alpha1.Addon{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: addonsv1alpha1.AddonSpec{
+			CommonLabels:      map[string]string{"team": "addon"},
+			CommonAnnotations: map[string]string{"team": "addon"},
+		},
+	}
+
+	obj := &unstructured.Unstructured{} // some arbitrary object
+	AddCommonLabels(obj, addon)
+	AddCommonAnnotations(obj, addon)
+
+	labels := obj.GetLabels()
+	require.Equal(t, "1234", labels["cost-center"])
+	require.Equal(t, "addon", labels["team"], "Addon labels take precedence")
+	require.Equal(t, CommonManagedByValue, labels[CommonManagedByLabel])
+	require.Equal(t, map[string]string{"team": "addon"}, obj.GetAnnotations())
+
+	obj = &unstructured.Unstructured{}
+	obj.SetLabels(map[string]string{"cost-center": "5678", "team": "object"})
+	obj.SetAnnotations(map[string]string{"owner": "object", "team": "object"})
+	AddCommonLabels(obj, addon)
+	AddCommonAnnotations(obj, addon)
+	require.Equal(t, "5678", obj.GetLabels()["cost-center"], "object labels take precedence over fleet labels")
+	require.Equal(t, "addon", obj.GetLabels()["team"], "Addon labels take precedence over object labels")
+	require.Equal(t, map[string]string{"owner": "object", "team": "addon"}, obj.GetAnnotations(),
+		"Addon annotations take precedence over object annotations")
+
+	SetCommonObjectMetadata(nil)
+	obj = &unstructured.Unstructured{}
+	AddCommonLabels(obj, &addonsv1alpha1.Addon{ObjectMeta: v1.ObjectMeta{Name: "test"}})
+	AddCommonAnnotations(obj, &addonsv1alpha1.Addon{ObjectMeta: v1.ObjectMeta{Name: "test"}})
+	require.NotContains(t, obj.GetLabels(), "cost-center")
+	require.Empty(t, obj.GetAnnotations())
+}
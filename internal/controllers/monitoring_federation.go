@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"errors"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Label identifying the Addon on every series federated into cluster-monitoring.
+// Set by an enforced relabeling, so it cannot be overridden by the federated series.
+const MonitoringFederationAddonLabel = "addon"
+
+// Namespaces of prometheus servers holding the series of all tenants.
+var sharedMonitoringNamespaces = map[string]struct{}{
+	"openshift-monitoring":               {},
+	"openshift-user-workload-monitoring": {},
+}
+
+var (
+	ErrMonitoringFederationNamespaceShared = errors.New(
+		".spec.monitoring.federation.namespace must not be a namespace shared between tenants")
+	ErrMonitoringFederationMatchLabelsForeign = errors.New(
+		".spec.monitoring.federation.matchLabels must not select objects of other Addons")
+)
+
+// Ensures the federation of an Addon only selects prometheus servers of that Addon,
+// instead of scooping up the series of other tenants.
+func ValidateMonitoringFederationTenancy(addon *addonsv1alpha1.Addon) error {
+	if addon.Spec.Monitoring == nil || addon.Spec.Monitoring.Federation == nil {
+		return nil
+	}
+	federation := addon.Spec.Monitoring.Federation
+
+	if _, ok := sharedMonitoringNamespaces[federation.Namespace]; ok {
+		return ErrMonitoringFederationNamespaceShared
+	}
+	if instance, ok := federation.MatchLabels[CommonInstanceLabel]; ok && instance != addon.Name {
+		return ErrMonitoringFederationMatchLabelsForeign
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Package cron parses standard five field cron expressions
+// and computes the times matching them.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidSchedule = errors.New("invalid cron schedule")
+
+// Schedule is a parsed cron expression of the form
+// "minute hour day-of-month month day-of-week", evaluated in UTC.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// Whether day-of-month or day-of-week are restricted,
+	// if both are, a day matches if either of them matches.
+	domRestricted, dowRestricted bool
+}
+
+type fieldBounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds = fieldBounds{"minute", 0, 59}
+	hourBounds   = fieldBounds{"hour", 0, 23}
+	domBounds    = fieldBounds{"day-of-month", 1, 31}
+	monthBounds  = fieldBounds{"month", 1, 12}
+	// 7 is accepted as an alias for Sunday.
+	dowBounds = fieldBounds{"day-of-week", 0, 7}
+)
+
+// Parse parses a five field cron expression.
+// Fields support "*", single values, ranges "a-b",
+// steps "*/n" and "a-b/n" and comma separated lists of those.
+func Parse(spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return Schedule{}, fmt.Errorf("%w: expected 5 fields, got %d", errInvalidSchedule, len(fields))
+	}
+
+	var (
+		s   Schedule
+		err error
+	)
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return Schedule{}, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1 << 0
+	}
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+	return s, nil
+}
+
+// Next returns the first time matching the schedule strictly after t.
+// Returns the zero time, if there is no match within the next 5 years,
+// e.g. for "0 0 31 2 *".
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domRestricted && s.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parsePart(part, bounds)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s field %q: %v", errInvalidSchedule, bounds.name, field, err)
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+func parsePart(part string, bounds fieldBounds) (uint64, error) {
+	rangePart, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		var err error
+		if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+			return 0, fmt.Errorf("invalid step %q", part[i+1:])
+		}
+		rangePart = part[:i]
+	}
+
+	start, end := bounds.min, bounds.max
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if start, err = strconv.Atoi(bounds[0]); err != nil {
+			return 0, fmt.Errorf("invalid range %q", rangePart)
+		}
+		if end, err = strconv.Atoi(bounds[1]); err != nil {
+			return 0, fmt.Errorf("invalid range %q", rangePart)
+		}
+	default:
+		v, err := strconv.Atoi(rangePart)
+		if err != nil {
+			return 0, fmt.Errorf("invalid value %q", rangePart)
+		}
+		start, end = v, v
+		if step > 1 {
+			// "a/n" is shorthand for "a-max/n".
+			end = bounds.max
+		}
+	}
+
+	if start < bounds.min || end > bounds.max || start > end {
+		return 0, fmt.Errorf("%q out of range %d-%d", rangePart, bounds.min, bounds.max)
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for name, spec := range map[string]string{
+		"too few fields":    "* * * *",
+		"too many fields":   "* * * * * *",
+		"out of range":      "60 * * * *",
+		"inverted range":    "* 5-2 * * *",
+		"invalid step":      "*/0 * * * *",
+		"non numeric value": "* * * jan *",
+	} {
+		spec := spec
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(spec)
+			assert.ErrorIs(t, err, errInvalidSchedule)
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	// Saturday
+	from := time.Date(2023, time.April, 1, 10, 30, 15, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		spec     string
+		expected time.Time
+	}{
+		"every minute": {
+			spec:     "* * * * *",
+			expected: time.Date(2023, time.April, 1, 10, 31, 0, 0, time.UTC),
+		},
+		"daily": {
+			spec:     "0 2 * * *",
+			expected: time.Date(2023, time.April, 2, 2, 0, 0, 0, time.UTC),
+		},
+		"later today": {
+			spec:     "0 22 * * *",
+			expected: time.Date(2023, time.April, 1, 22, 0, 0, 0, time.UTC),
+		},
+		"step": {
+			spec:     "*/20 * * * *",
+			expected: time.Date(2023, time.April, 1, 10, 40, 0, 0, time.UTC),
+		},
+		"weekdays": {
+			spec:     "0 9 * * 1-5",
+			expected: time.Date(2023, time.April, 3, 9, 0, 0, 0, time.UTC),
+		},
+		"sunday as 7": {
+			spec:     "0 0 * * 7",
+			expected: time.Date(2023, time.April, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"day of month or day of week": {
+			spec:     "0 0 15 * 1",
+			expected: time.Date(2023, time.April, 3, 0, 0, 0, 0, time.UTC),
+		},
+		"list of months": {
+			spec:     "0 0 1 1,7 *",
+			expected: time.Date(2023, time.July, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"never": {
+			spec: "0 0 31 2 *",
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := Parse(tc.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s.Next(from))
+		})
+	}
+}
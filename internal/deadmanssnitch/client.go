@@ -0,0 +1,172 @@
+package deadmanssnitch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift/addon-operator/internal/version"
+)
+
+const (
+	defaultURL     = "https://api.deadmanssnitch.com/v1"
+	defaultTimeout = 10 * time.Second
+)
+
+// ErrSnitchNotFound is returned for snitches unknown to Dead Man's Snitch,
+// e.g. because they were deleted in the Dead Man's Snitch UI.
+var ErrSnitchNotFound = errors.New("snitch not found")
+
+// Client manages snitches using the Dead Man's Snitch API.
+type Client struct {
+	opts       ClientOptions
+	httpClient *http.Client
+}
+
+// Creates a new Dead Man's Snitch client with the given options.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		opts: ClientOptions{
+			URL:     defaultURL,
+			Timeout: defaultTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	if len(c.opts.APIToken) == 0 {
+		return nil, errors.New("dead man's snitch API token must not be empty")
+	}
+	if _, err := url.Parse(c.opts.URL); err != nil {
+		return nil, fmt.Errorf("parsing dead man's snitch url: %w", err)
+	}
+	c.opts.URL = strings.TrimSuffix(c.opts.URL, "/")
+
+	c.httpClient = &http.Client{
+		Timeout: c.opts.Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+	return c, nil
+}
+
+type ClientOptions struct {
+	URL      string
+	APIToken string
+	Timeout  time.Duration
+}
+
+type Option func(o *ClientOptions)
+
+func WithURL(url string) Option {
+	return func(o *ClientOptions) {
+		o.URL = url
+	}
+}
+
+func WithAPIToken(token string) Option {
+	return func(o *ClientOptions) {
+		o.APIToken = token
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// Creates a new snitch.
+func (c *Client) CreateSnitch(ctx context.Context, req CreateSnitchRequest) (Snitch, error) {
+	var snitch Snitch
+	err := c.do(ctx, http.MethodPost, "/snitches", req, &snitch)
+	return snitch, err
+}
+
+// Returns the snitch with the given token.
+func (c *Client) GetSnitch(ctx context.Context, token string) (Snitch, error) {
+	var snitch Snitch
+	err := c.do(ctx, http.MethodGet, "/snitches/"+url.PathEscape(token), nil, &snitch)
+	return snitch, err
+}
+
+// Returns the snitch with the given name, carrying all of the given tags.
+// Returns ErrSnitchNotFound, if there is none.
+func (c *Client) FindSnitch(ctx context.Context, name string, tags []string) (Snitch, error) {
+	path := "/snitches"
+	if len(tags) > 0 {
+		path += "?" + url.Values{"tags": {strings.Join(tags, ",")}}.Encode()
+	}
+	var snitches []Snitch
+	if err := c.do(ctx, http.MethodGet, path, nil, &snitches); err != nil {
+		return Snitch{}, err
+	}
+	for _, snitch := range snitches {
+		if snitch.Name == name {
+			return snitch, nil
+		}
+	}
+	return Snitch{}, ErrSnitchNotFound
+}
+
+// Deletes the snitch with the given token.
+func (c *Client) DeleteSnitch(ctx context.Context, token string) error {
+	return c.do(ctx, http.MethodDelete, "/snitches/"+url.PathEscape(token), nil, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		j, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("marshaling json: %w", err)
+		}
+		body = bytes.NewBuffer(j)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.opts.URL+path, body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	// The API token is passed as username without password.
+	httpReq.SetBasicAuth(c.opts.APIToken, "")
+	httpReq.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+	if in != nil {
+		httpReq.Header.Add("Content-Type", "application/json")
+	}
+
+	httpRes, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("executing http request: %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	resBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if httpRes.StatusCode == http.StatusNotFound {
+		return ErrSnitchNotFound
+	}
+	if httpRes.StatusCode < 200 || httpRes.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d: %s", httpRes.StatusCode, string(resBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, out); err != nil {
+		return fmt.Errorf("unmarshal json response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package deadmanssnitch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateSnitch(t *testing.T) {
+	var (
+		recordedRequest CreateSnitchRequest
+		recordedToken   string
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/snitches", r.URL.Path)
+		recordedToken, _, _ = r.BasicAuth()
+		_ = json.NewDecoder(r.Body).Decode(&recordedRequest)
+		fmt.Fprintln(rw, `{"token":"c2354d53d2","name":"addon-1","interval":"15_minute","status":"pending","check_in_url":"https://nosnch.in/c2354d53d2"}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL+"/v1/"), WithAPIToken("secret"))
+	require.NoError(t, err)
+
+	snitch, err := c.CreateSnitch(context.Background(), CreateSnitchRequest{
+		Name:     "addon-1",
+		Interval: "15_minute",
+		Tags:     []string{"addon-operator"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "c2354d53d2", snitch.Token)
+	assert.Equal(t, "https://nosnch.in/c2354d53d2", snitch.CheckInURL)
+	assert.Equal(t, "secret", recordedToken)
+	assert.Equal(t, "addon-1", recordedRequest.Name)
+	assert.Equal(t, []string{"addon-operator"}, recordedRequest.Tags)
+}
+
+func TestClientGetSnitch_NotFound(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/snitches/c2354d53d2", r.URL.Path)
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL), WithAPIToken("secret"))
+	require.NoError(t, err)
+
+	_, err = c.GetSnitch(context.Background(), "c2354d53d2")
+	require.ErrorIs(t, err, ErrSnitchNotFound)
+}
+
+func TestClientFindSnitch(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/snitches", r.URL.Path)
+		assert.Equal(t, "addon-1,addon-operator", r.URL.Query().Get("tags"))
+		fmt.Fprintln(rw, `[{"token":"a1","name":"addon-1 (cluster-2)"},{"token":"b2","name":"addon-1 (cluster-1)"}]`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL), WithAPIToken("secret"))
+	require.NoError(t, err)
+
+	snitch, err := c.FindSnitch(context.Background(), "addon-1 (cluster-1)", []string{"addon-1", "addon-operator"})
+	require.NoError(t, err)
+	assert.Equal(t, "b2", snitch.Token)
+
+	_, err = c.FindSnitch(context.Background(), "addon-1 (cluster-3)", []string{"addon-1", "addon-operator"})
+	require.ErrorIs(t, err, ErrSnitchNotFound)
+}
+
+func TestClientDeleteSnitch_HTTPError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL), WithAPIToken("secret"))
+	require.NoError(t, err)
+
+	err = c.DeleteSnitch(context.Background(), "c2354d53d2")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrSnitchNotFound)
+}
+
+func TestNewClient_MissingAPIToken(t *testing.T) {
+	_, err := NewClient()
+	require.Error(t, err)
+}
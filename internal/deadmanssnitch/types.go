@@ -0,0 +1,28 @@
+package deadmanssnitch
+
+// Snitch as returned by the Dead Man's Snitch API.
+type Snitch struct {
+	// Unique token identifying the snitch.
+	Token string `json:"token"`
+	Name  string `json:"name"`
+	// Expected check-in interval, e.g. "15_minute".
+	Interval string   `json:"interval"`
+	Tags     []string `json:"tags,omitempty"`
+	// One of "pending", "healthy", "failed", "errored" or "paused".
+	Status string `json:"status"`
+	// URL the snitch is checked in with.
+	CheckInURL string `json:"check_in_url"`
+}
+
+// Request to create a new snitch.
+type CreateSnitchRequest struct {
+	Name     string   `json:"name"`
+	Interval string   `json:"interval"`
+	Tags     []string `json:"tags,omitempty"`
+	Notes    string   `json:"notes,omitempty"`
+	// Alert when no check-in is received within the interval.
+	AlertType string `json:"alert_type,omitempty"`
+}
+
+// Alerts when the snitch misses a check-in.
+const AlertTypeBasic = "basic"
@@ -0,0 +1,154 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+func TestSink_Webhook(t *testing.T) {
+	var recorded webhookRequest
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/hook", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&recorded)
+	}))
+	defer s.Close()
+
+	sink, err := NewSink(WithURL(s.URL + "/hook"))
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), []Event{{Addon: "addon-1", Reason: "UpgradeStarted"}})
+	require.NoError(t, err)
+	require.Len(t, recorded.Events, 1)
+	assert.Equal(t, "addon-1", recorded.Events[0].Addon)
+	assert.Equal(t, "UpgradeStarted", recorded.Events[0].Reason)
+}
+
+func TestSink_Kafka(t *testing.T) {
+	var recorded kafkaProduceRequest
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/addon-events", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		_ = json.NewDecoder(r.Body).Decode(&recorded)
+	}))
+	defer s.Close()
+
+	sink, err := NewSink(WithType(TypeKafka), WithURL(s.URL+"/"), WithKafkaTopic("addon-events"))
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), []Event{{ClusterID: "cluster-1", Addon: "addon-1"}})
+	require.NoError(t, err)
+	require.Len(t, recorded.Records, 1)
+	assert.Equal(t, "cluster-1/addon-1", recorded.Records[0].Key)
+	assert.Equal(t, "addon-1", recorded.Records[0].Value.Addon)
+}
+
+func TestSink_Error(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+
+	sink, err := NewSink(WithURL(s.URL))
+	require.NoError(t, err)
+	assert.EqualError(t, sink.Send(context.Background(), []Event{{}}), "HTTP 502: ")
+}
+
+func TestNewSink_Invalid(t *testing.T) {
+	for name, opts := range map[string][]SinkOption{
+		"missing url":         {},
+		"missing kafka topic": {WithType(TypeKafka), WithURL("http://kafka-rest")},
+		"unsupported type":    {WithType("carrier-pigeon"), WithURL("http://coop")},
+	} {
+		_, err := NewSink(opts...)
+		assert.Error(t, err, name)
+	}
+}
+
+type sinkStub struct {
+	mux     sync.Mutex
+	fail    int
+	batches [][]Event
+}
+
+func (s *sinkStub) Send(_ context.Context, events []Event) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.fail > 0 {
+		s.fail--
+		return errors.New("unavailable")
+	}
+	s.batches = append(s.batches, events)
+	return nil
+}
+
+func (s *sinkStub) sent() [][]Event {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.batches
+}
+
+func TestForwarder(t *testing.T) {
+	sink := &sinkStub{fail: 1}
+	f := NewForwarder(sink,
+		WithClusterID("cluster-1"),
+		WithBufferSize(2),
+		WithMaxRetries(1, time.Millisecond),
+	)
+
+	// Queued before starting, so both are sent as one batch.
+	assert.True(t, f.Enqueue(Event{Addon: "addon-1"}))
+	assert.True(t, f.Enqueue(Event{Addon: "addon-2"}))
+	assert.False(t, f.Enqueue(Event{Addon: "addon-3"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = f.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return len(sink.sent()) == 1 },
+		time.Second, time.Millisecond)
+	batch := sink.sent()[0]
+	require.Len(t, batch, 2)
+	assert.Equal(t, "cluster-1", batch[0].ClusterID)
+	assert.Equal(t, "addon-2", batch[1].Addon)
+}
+
+func TestEventRecorder(t *testing.T) {
+	events := record.NewFakeRecorder(10)
+	f := NewForwarder(&sinkStub{}, WithClusterID("cluster-1"))
+	r := NewEventRecorder(events, f)
+
+	addon := &addonsv1alpha1.Addon{
+		ObjectMeta: metav1.ObjectMeta{Name: "addon-1", UID: "1234"},
+		Spec:       addonsv1alpha1.AddonSpec{Version: "1.0.0"},
+	}
+	r.Eventf(addon, corev1.EventTypeNormal, "UpgradeSucceeded", "Upgraded to version %q.", "1.0.0")
+	r.Event(&corev1.Namespace{}, corev1.EventTypeNormal, "Ignored", "not an Addon")
+
+	assert.Len(t, events.Events, 2)
+	require.Len(t, f.events, 1)
+	e := <-f.events
+	assert.Equal(t, "cluster-1", e.ClusterID)
+	assert.Equal(t, "addon-1", e.Addon)
+	assert.Equal(t, "1234", e.AddonUID)
+	assert.Equal(t, "1.0.0", e.Version)
+	assert.Equal(t, "UpgradeSucceeded", e.Reason)
+	assert.Equal(t, `Upgraded to version "1.0.0".`, e.Message)
+}
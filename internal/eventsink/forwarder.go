@@ -0,0 +1,145 @@
+package eventsink
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// Defaults of the Forwarder.
+const (
+	DefaultBufferSize   = 1000
+	DefaultMaxBatchSize = 100
+	DefaultMaxRetries   = 3
+	defaultRetryBackoff = time.Second
+)
+
+// Forwarder delivers Events to a Sink in the background,
+// so recording Events never blocks on the external system.
+// Events are dropped when the buffer is full or all retries failed.
+type Forwarder struct {
+	sink Sink
+	opts ForwarderOptions
+
+	events chan Event
+}
+
+type ForwarderOptions struct {
+	// External ID of the cluster, added to all Events.
+	ClusterID string
+	Log       logr.Logger
+	// Number of Events buffered while the Sink is slow or unavailable.
+	BufferSize int
+	// Maximum number of Events sent at once.
+	MaxBatchSize int
+	// Number of times a failed batch is retried.
+	MaxRetries int
+	// Backoff before the first retry, doubled with each retry.
+	RetryBackoff time.Duration
+}
+
+type ForwarderOption func(o *ForwarderOptions)
+
+func WithClusterID(clusterID string) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.ClusterID = clusterID
+	}
+}
+
+func WithLog(log logr.Logger) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.Log = log
+	}
+}
+
+func WithBufferSize(size int) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.BufferSize = size
+	}
+}
+
+func WithMaxRetries(retries int, backoff time.Duration) ForwarderOption {
+	return func(o *ForwarderOptions) {
+		o.MaxRetries = retries
+		o.RetryBackoff = backoff
+	}
+}
+
+func NewForwarder(sink Sink, opts ...ForwarderOption) *Forwarder {
+	f := &Forwarder{
+		sink: sink,
+		opts: ForwarderOptions{
+			Log:          logr.Discard(),
+			BufferSize:   DefaultBufferSize,
+			MaxBatchSize: DefaultMaxBatchSize,
+			MaxRetries:   DefaultMaxRetries,
+			RetryBackoff: defaultRetryBackoff,
+		},
+	}
+	for _, opt := range opts {
+		opt(&f.opts)
+	}
+	f.events = make(chan Event, f.opts.BufferSize)
+	return f
+}
+
+// Queues the Event for delivery, returns false if it was dropped.
+func (f *Forwarder) Enqueue(e Event) bool {
+	e.ClusterID = f.opts.ClusterID
+	select {
+	case f.events <- e:
+		return true
+	default:
+		f.opts.Log.Info("dropping event, buffer is full",
+			"addon", e.Addon, "reason", e.Reason)
+		return false
+	}
+}
+
+// Delivers queued Events until the context is cancelled.
+func (f *Forwarder) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-f.events:
+			f.send(ctx, f.batch(e))
+		}
+	}
+}
+
+// Collects the given and all queued Events up to the maximum batch size.
+func (f *Forwarder) batch(first Event) []Event {
+	batch := []Event{first}
+	for len(batch) < f.opts.MaxBatchSize {
+		select {
+		case e := <-f.events:
+			batch = append(batch, e)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (f *Forwarder) send(ctx context.Context, events []Event) {
+	backoff := f.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := f.sink.Send(ctx, events)
+		if err == nil {
+			return
+		}
+		if attempt >= f.opts.MaxRetries {
+			f.opts.Log.Error(err, "dropping events, sending failed", "events", len(events))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,63 @@
+package eventsink
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// EventRecorder records Events like the wrapped EventRecorder
+// and additionally forwards Events on Addons to a Forwarder.
+type EventRecorder struct {
+	record.EventRecorder
+
+	forwarder *Forwarder
+	now       func() time.Time
+}
+
+func NewEventRecorder(recorder record.EventRecorder, forwarder *Forwarder) *EventRecorder {
+	return &EventRecorder{
+		EventRecorder: recorder,
+		forwarder:     forwarder,
+		now:           time.Now,
+	}
+}
+
+func (r *EventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.EventRecorder.Event(object, eventtype, reason, message)
+	r.forward(object, eventtype, reason, message)
+}
+
+func (r *EventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	r.forward(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *EventRecorder) AnnotatedEventf(
+	object runtime.Object, annotations map[string]string,
+	eventtype, reason, messageFmt string, args ...interface{},
+) {
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	r.forward(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *EventRecorder) forward(object runtime.Object, eventtype, reason, message string) {
+	addon, ok := object.(*addonsv1alpha1.Addon)
+	if !ok {
+		return
+	}
+
+	r.forwarder.Enqueue(Event{
+		Time:     r.now(),
+		Addon:    addon.Name,
+		AddonUID: string(addon.UID),
+		Version:  addon.Spec.Version,
+		Type:     eventtype,
+		Reason:   reason,
+		Message:  message,
+	})
+}
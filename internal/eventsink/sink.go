@@ -0,0 +1,149 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift/addon-operator/internal/version"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Type of the system Events are forwarded to.
+type Type string
+
+const (
+	// Posts Events as JSON to an HTTP webhook.
+	TypeWebhook Type = "webhook"
+	// Produces Events to a Kafka topic via the Kafka REST Proxy.
+	TypeKafka Type = "kafka"
+)
+
+// Sink delivers batches of Events to an external system.
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+type SinkOptions struct {
+	Type Type
+	// URL of the webhook or base URL of the Kafka REST Proxy.
+	URL string
+	// Kafka topic Events are produced to.
+	KafkaTopic string
+	Timeout    time.Duration
+}
+
+type SinkOption func(o *SinkOptions)
+
+func WithType(t Type) SinkOption {
+	return func(o *SinkOptions) {
+		o.Type = t
+	}
+}
+
+func WithURL(url string) SinkOption {
+	return func(o *SinkOptions) {
+		o.URL = url
+	}
+}
+
+func WithKafkaTopic(topic string) SinkOption {
+	return func(o *SinkOptions) {
+		o.KafkaTopic = topic
+	}
+}
+
+func WithTimeout(timeout time.Duration) SinkOption {
+	return func(o *SinkOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// Creates a new Sink posting to a webhook or the Kafka REST Proxy.
+func NewSink(opts ...SinkOption) (Sink, error) {
+	s := &httpSink{
+		opts: SinkOptions{
+			Type:    TypeWebhook,
+			Timeout: defaultTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+
+	if len(s.opts.URL) == 0 {
+		return nil, fmt.Errorf("event sink url must not be empty")
+	}
+	if _, err := url.Parse(s.opts.URL); err != nil {
+		return nil, fmt.Errorf("parsing event sink url: %w", err)
+	}
+	switch s.opts.Type {
+	case TypeWebhook:
+	case TypeKafka:
+		if len(s.opts.KafkaTopic) == 0 {
+			return nil, fmt.Errorf("kafka topic must not be empty")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported event sink type %q", s.opts.Type)
+	}
+
+	s.httpClient = &http.Client{
+		Timeout: s.opts.Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+	return s, nil
+}
+
+type httpSink struct {
+	opts       SinkOptions
+	httpClient *http.Client
+}
+
+func (s *httpSink) Send(ctx context.Context, events []Event) error {
+	if s.opts.Type == TypeKafka {
+		req := kafkaProduceRequest{Records: make([]kafkaRecord, len(events))}
+		for i, e := range events {
+			// Keyed by Addon, so Events of an Addon keep their order within a partition.
+			req.Records[i] = kafkaRecord{Key: e.ClusterID + "/" + e.Addon, Value: e}
+		}
+		return s.post(ctx,
+			strings.TrimSuffix(s.opts.URL, "/")+"/topics/"+url.PathEscape(s.opts.KafkaTopic),
+			"application/vnd.kafka.json.v2+json", req)
+	}
+	return s.post(ctx, s.opts.URL, "application/json", webhookRequest{Events: events})
+}
+
+func (s *httpSink) post(ctx context.Context, url, contentType string, in interface{}) error {
+	j, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("marshaling json: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(j))
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	httpReq.Header.Add("Content-Type", contentType)
+	httpReq.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+
+	httpRes, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("executing http request: %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	if httpRes.StatusCode < 200 || httpRes.StatusCode > 299 {
+		resBody, _ := io.ReadAll(httpRes.Body)
+		return fmt.Errorf("HTTP %d: %s", httpRes.StatusCode, string(resBody))
+	}
+	return nil
+}
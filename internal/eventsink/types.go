@@ -0,0 +1,33 @@
+package eventsink
+
+import "time"
+
+// Event is an Addon lifecycle Event forwarded to a Sink as JSON.
+type Event struct {
+	Time time.Time `json:"time"`
+	// External ID of the cluster the Addon is installed on.
+	ClusterID string `json:"clusterID"`
+	Addon     string `json:"addon"`
+	AddonUID  string `json:"addonUID"`
+	// Desired version of the Addon.
+	Version string `json:"version,omitempty"`
+	// Kubernetes Event type, Normal or Warning.
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// Body posted to webhook sinks.
+type webhookRequest struct {
+	Events []Event `json:"events"`
+}
+
+// Body posted to the Kafka REST Proxy v2 API.
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value Event  `json:"value"`
+}
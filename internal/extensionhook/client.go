@@ -0,0 +1,136 @@
+package extensionhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/openshift/addon-operator/internal/version"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Client calls an external extension hook
+// at defined points of the Addon lifecycle.
+type Client struct {
+	opts       ClientOptions
+	httpClient *http.Client
+}
+
+// Creates a new extension hook client with the given options.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		opts: ClientOptions{
+			Timeout: defaultTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	if len(c.opts.URL) == 0 {
+		return nil, errors.New("extension hook URL must not be empty")
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(c.opts.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(c.opts.CABundle) {
+			return nil, errors.New("no certificates found in extension hook CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	c.httpClient = &http.Client{
+		Timeout: c.opts.Timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	return c, nil
+}
+
+type ClientOptions struct {
+	URL      string
+	CABundle []byte
+	Timeout  time.Duration
+	// Proceed with operations when the hook call fails.
+	IgnoreFailures bool
+}
+
+type Option func(o *ClientOptions)
+
+func WithURL(url string) Option {
+	return func(o *ClientOptions) {
+		o.URL = url
+	}
+}
+
+func WithCABundle(caBundle []byte) Option {
+	return func(o *ClientOptions) {
+		o.CABundle = caBundle
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.Timeout = timeout
+	}
+}
+
+func WithIgnoreFailures(ignore bool) Option {
+	return func(o *ClientOptions) {
+		o.IgnoreFailures = ignore
+	}
+}
+
+// Whether failed hook calls should not block the operation.
+func (c *Client) IgnoreFailures() bool {
+	return c.opts.IgnoreFailures
+}
+
+// Calls the extension hook with the given request.
+func (c *Client) Call(ctx context.Context, req Request) (res Response, err error) {
+	j, err := json.Marshal(req)
+	if err != nil {
+		return res, fmt.Errorf("marshaling json: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, c.opts.URL, bytes.NewBuffer(j))
+	if err != nil {
+		return res, fmt.Errorf("creating http request: %w", err)
+	}
+	httpReq.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+	httpReq.Header.Add("Content-Type", "application/json")
+
+	httpRes, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return res, fmt.Errorf("executing http request: %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	body, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return res, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if httpRes.StatusCode < 200 || httpRes.StatusCode > 299 {
+		return res, fmt.Errorf("HTTP %d: %s", httpRes.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &res); err != nil {
+		return res, fmt.Errorf("unmarshal json response: %w", err)
+	}
+	return res, nil
+}
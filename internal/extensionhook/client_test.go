@@ -0,0 +1,72 @@
+package extensionhook
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+func TestClientCall(t *testing.T) {
+	var recordedRequest Request
+	s := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&recordedRequest)
+		fmt.Fprintln(rw, `{"allowed":false,"message":"change freeze","annotations":{"example.com/ticket":"CHG-1"}}`)
+	}))
+	defer s.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.Certificate().Raw,
+	})
+
+	c, err := NewClient(WithURL(s.URL), WithCABundle(caBundle))
+	require.NoError(t, err)
+
+	res, err := c.Call(context.Background(), Request{
+		Stage:     StagePreInstall,
+		ClusterID: "123",
+		Addon: &addonsv1alpha1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: "reference-addon"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, res.Allowed)
+	assert.Equal(t, "change freeze", res.Message)
+	assert.Equal(t, map[string]string{"example.com/ticket": "CHG-1"}, res.Annotations)
+	assert.Equal(t, StagePreInstall, recordedRequest.Stage)
+	assert.Equal(t, "reference-addon", recordedRequest.Addon.Name)
+}
+
+func TestClientCall_HTTPError(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer s.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: s.Certificate().Raw,
+	})
+
+	c, err := NewClient(WithURL(s.URL), WithCABundle(caBundle))
+	require.NoError(t, err)
+
+	_, err = c.Call(context.Background(), Request{Stage: StagePostDelete})
+	require.Error(t, err)
+}
+
+func TestNewClient_InvalidCABundle(t *testing.T) {
+	_, err := NewClient(WithURL("https://hook.example.com"), WithCABundle([]byte("garbage")))
+	require.Error(t, err)
+}
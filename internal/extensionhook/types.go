@@ -0,0 +1,41 @@
+package extensionhook
+
+import (
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+// Point of the Addon lifecycle the extension hook is called at.
+type Stage string
+
+const (
+	// Called before an Addon is installed.
+	// The hook may veto the installation.
+	StagePreInstall Stage = "PreInstall"
+	// Called before an Addon is upgraded to a new version.
+	// The hook may veto the upgrade.
+	StagePreUpgrade Stage = "PreUpgrade"
+	// Called after an Addon was deleted.
+	// The response is informational only.
+	StagePostDelete Stage = "PostDelete"
+)
+
+// Request posted to the extension hook.
+type Request struct {
+	Stage Stage `json:"stage"`
+	// External ID of the cluster the Addon is installed on.
+	ClusterID string `json:"clusterID"`
+	// Version of the Addon currently installed, empty for new installations.
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	// Addon the operation is performed on.
+	Addon *addonsv1alpha1.Addon `json:"addon"`
+}
+
+// Response returned by the extension hook.
+type Response struct {
+	// Whether the operation may proceed.
+	Allowed bool `json:"allowed"`
+	// Human readable explanation, surfaced on the Addon status when vetoed.
+	Message string `json:"message,omitempty"`
+	// Annotations recorded in the extension hook status of the Addon.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
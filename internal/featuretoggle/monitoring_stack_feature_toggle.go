@@ -6,6 +6,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
 
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -33,6 +35,8 @@ func (m *MonitoringStackFeatureToggle) GetFeatureToggleIdentifier() string {
 func (m *MonitoringStackFeatureToggle) PreManagerSetupHandle(ctx context.Context) error {
 	// nothing to handle before the manager is setup
 	_ = obov1alpha1.AddToScheme(m.SchemeToUpdate)
+	_ = monv1alpha1.AddToScheme(m.SchemeToUpdate)
+	_ = monv1.AddToScheme(m.SchemeToUpdate)
 	return nil
 }
 
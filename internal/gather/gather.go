@@ -0,0 +1,398 @@
+package gather
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	addoncontroller "github.com/openshift/addon-operator/internal/controllers/addon"
+)
+
+// Defaults of the Gatherer.
+const (
+	DefaultLogTailLines = 1000
+	// Label selecting the addon-operator manager Pods.
+	DefaultOperatorPodSelector = "app.kubernetes.io/name=addon-operator"
+)
+
+// Kinds of objects labelled as owned by the Addon.
+var ownedKinds = []schema.GroupVersionKind{
+	{Version: "v1", Kind: "Namespace"},
+	{Group: "operators.coreos.com", Version: "v1", Kind: "OperatorGroup"},
+	{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "CatalogSource"},
+	{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "Subscription"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"},
+	{Group: "monitoring.coreos.com", Version: "v1", Kind: "Probe"},
+	{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+}
+
+// Kinds of objects collected from all namespaces of the Addon,
+// as they are created by OLM or the Addon itself instead of the addon-operator.
+var namespacedKinds = []schema.GroupVersionKind{
+	{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersion"},
+	{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "InstallPlan"},
+	{Group: "addons.managed.openshift.io", Version: "v1alpha1", Kind: "AddonInstance"},
+	{Version: "v1", Kind: "Pod"},
+}
+
+// Gatherer collects everything needed to troubleshoot an Addon
+// into an archive, which can be attached to support cases.
+type Gatherer struct {
+	client    client.Reader
+	clientset kubernetes.Interface
+	opts      GathererOptions
+}
+
+type GathererOptions struct {
+	// Namespace of the addon-operator, to capture its logs from.
+	// Logs of the addon-operator are not captured when empty.
+	OperatorNamespace string
+	// Label selector of the addon-operator manager Pods.
+	OperatorPodSelector string
+	// Number of lines captured from the end of each container log.
+	LogTailLines int64
+}
+
+type GathererOption func(o *GathererOptions)
+
+func WithOperatorNamespace(namespace string) GathererOption {
+	return func(o *GathererOptions) {
+		o.OperatorNamespace = namespace
+	}
+}
+
+func WithOperatorPodSelector(selector string) GathererOption {
+	return func(o *GathererOptions) {
+		o.OperatorPodSelector = selector
+	}
+}
+
+func WithLogTailLines(lines int64) GathererOption {
+	return func(o *GathererOptions) {
+		o.LogTailLines = lines
+	}
+}
+
+func NewGatherer(c client.Reader, clientset kubernetes.Interface, opts ...GathererOption) *Gatherer {
+	g := &Gatherer{
+		client:    c,
+		clientset: clientset,
+		opts: GathererOptions{
+			OperatorPodSelector: DefaultOperatorPodSelector,
+			LogTailLines:        DefaultLogTailLines,
+		},
+	}
+	for _, opt := range opts {
+		opt(&g.opts)
+	}
+	return g
+}
+
+// Writes a gzipped tar archive to w, containing the Addon, its conditions,
+// the objects owned by it, related Events and recent logs.
+//
+// Collecting is best effort: objects and logs which could not be collected
+// are listed in errors.txt within the archive instead of failing altogether.
+func (g *Gatherer) Gather(ctx context.Context, addonName string, w io.Writer) error {
+	addon := &addonsv1alpha1.Addon{}
+	if err := g.client.Get(ctx, client.ObjectKey{Name: addonName}, addon); err != nil {
+		return fmt.Errorf("getting Addon: %w", err)
+	}
+	addon.SetGroupVersionKind(addonsv1alpha1.GroupVersion.WithKind("Addon"))
+
+	gz := gzip.NewWriter(w)
+	a := &archive{
+		tw:  tar.NewWriter(gz),
+		dir: "addon-" + addon.Name,
+		now: time.Now(),
+	}
+
+	if err := a.writeYAML("addon.yaml", addon); err != nil {
+		return err
+	}
+	if err := a.writeYAML("conditions.yaml", addon.Status.Conditions); err != nil {
+		return err
+	}
+
+	namespaces := addonNamespaces(addon)
+	if err := g.gatherOwnedObjects(ctx, a, addon); err != nil {
+		return err
+	}
+	if err := g.gatherNamespacedObjects(ctx, a, namespaces); err != nil {
+		return err
+	}
+	if err := g.gatherEvents(ctx, a, addon, namespaces); err != nil {
+		return err
+	}
+	if err := g.gatherAddonLogs(ctx, a, namespaces); err != nil {
+		return err
+	}
+	if err := g.gatherOperatorLogs(ctx, a, addon); err != nil {
+		return err
+	}
+
+	if len(a.errors) > 0 {
+		if err := a.write("errors.txt", []byte(strings.Join(a.errors, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+	if err := a.tw.Close(); err != nil {
+		return fmt.Errorf("closing tar archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("closing gzip stream: %w", err)
+	}
+	return nil
+}
+
+func (g *Gatherer) gatherOwnedObjects(
+	ctx context.Context, a *archive, addon *addonsv1alpha1.Addon,
+) error {
+	selector := controllers.CommonLabelsAsLabelSelector(addon)
+	for _, gvk := range ownedKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := g.client.List(ctx, list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			a.recordError("listing %s: %v", gvk.Kind, err)
+			continue
+		}
+		if err := a.writeObjects(gvk.Kind, list.Items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Gatherer) gatherNamespacedObjects(
+	ctx context.Context, a *archive, namespaces []string,
+) error {
+	for _, gvk := range namespacedKinds {
+		for _, ns := range namespaces {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := g.client.List(ctx, list, client.InNamespace(ns)); err != nil {
+				a.recordError("listing %s in namespace %s: %v", gvk.Kind, ns, err)
+				continue
+			}
+			if err := a.writeObjects(gvk.Kind, list.Items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Collects the Events on the Addon itself and all Events in its namespaces.
+func (g *Gatherer) gatherEvents(
+	ctx context.Context, a *archive,
+	addon *addonsv1alpha1.Addon, namespaces []string,
+) error {
+	var events []corev1.Event
+
+	addonEvents, err := g.clientset.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "Addon",
+			"involvedObject.name": addon.Name,
+		}.String(),
+	})
+	if err != nil {
+		a.recordError("listing Events of the Addon: %v", err)
+	} else {
+		for _, e := range addonEvents.Items {
+			if e.InvolvedObject.Kind == "Addon" && e.InvolvedObject.Name == addon.Name {
+				events = append(events, e)
+			}
+		}
+	}
+
+	for _, ns := range namespaces {
+		nsEvents, err := g.clientset.CoreV1().Events(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			a.recordError("listing Events in namespace %s: %v", ns, err)
+			continue
+		}
+		events = append(events, nsEvents.Items...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	return a.writeYAML("events.yaml", events)
+}
+
+// Captures the recent logs of all containers in the namespaces of the Addon.
+func (g *Gatherer) gatherAddonLogs(ctx context.Context, a *archive, namespaces []string) error {
+	for _, ns := range namespaces {
+		pods, err := g.clientset.CoreV1().Pods(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			a.recordError("listing Pods in namespace %s: %v", ns, err)
+			continue
+		}
+		if err := g.gatherPodLogs(ctx, a, pods.Items, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Captures the recent log lines of the addon-operator mentioning the Addon.
+func (g *Gatherer) gatherOperatorLogs(
+	ctx context.Context, a *archive, addon *addonsv1alpha1.Addon,
+) error {
+	if len(g.opts.OperatorNamespace) == 0 {
+		return nil
+	}
+
+	pods, err := g.clientset.CoreV1().Pods(g.opts.OperatorNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: g.opts.OperatorPodSelector,
+	})
+	if err != nil {
+		a.recordError("listing addon-operator Pods: %v", err)
+		return nil
+	}
+	return g.gatherPodLogs(ctx, a, pods.Items, func(line string) bool {
+		return strings.Contains(line, addon.Name)
+	})
+}
+
+// Writes the logs of all containers of the given Pods,
+// keeping only the lines matching filter, if not nil.
+func (g *Gatherer) gatherPodLogs(
+	ctx context.Context, a *archive, pods []corev1.Pod, filter func(line string) bool,
+) error {
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			raw, err := g.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: c.Name,
+				TailLines: &g.opts.LogTailLines,
+			}).DoRaw(ctx)
+			if err != nil {
+				a.recordError("getting logs of container %s in Pod %s/%s: %v",
+					c.Name, pod.Namespace, pod.Name, err)
+				continue
+			}
+			if filter != nil {
+				raw = filterLines(raw, filter)
+			}
+
+			name := path.Join("logs", pod.Namespace, pod.Name, c.Name+".log")
+			if err := a.write(name, raw); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the namespaces of the Addon, including the namespace it is installed into.
+func addonNamespaces(addon *addonsv1alpha1.Addon) []string {
+	set := map[string]struct{}{}
+	for _, ns := range addon.Spec.Namespaces {
+		set[ns.Name] = struct{}{}
+	}
+	if ns := addoncontroller.GetCommonInstallOptions(addon).Namespace; len(ns) > 0 {
+		set[ns] = struct{}{}
+	}
+
+	namespaces := make([]string, 0, len(set))
+	for ns := range set {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+func eventTime(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	return e.EventTime.Time
+}
+
+func filterLines(raw []byte, filter func(line string) bool) []byte {
+	var filtered bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); filter(line) {
+			filtered.WriteString(line)
+			filtered.WriteByte('\n')
+		}
+	}
+	return filtered.Bytes()
+}
+
+// Files written to the tar archive below a common directory.
+type archive struct {
+	tw  *tar.Writer
+	dir string
+	now time.Time
+
+	// Problems collecting parts of the archive.
+	errors []string
+}
+
+func (a *archive) recordError(format string, args ...interface{}) {
+	a.errors = append(a.errors, fmt.Sprintf(format, args...))
+}
+
+// Writes each object to its own file, grouped by kind and namespace.
+func (a *archive) writeObjects(kind string, objs []unstructured.Unstructured) error {
+	for i := range objs {
+		obj := &objs[i]
+		name := path.Join("objects", strings.ToLower(kind), obj.GetNamespace(), obj.GetName()+".yaml")
+		if err := a.writeYAML(name, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *archive) writeYAML(name string, obj interface{}) error {
+	if metaObj, ok := obj.(metav1.Object); ok {
+		// Managed fields only add noise to the archive.
+		metaObj.SetManagedFields(nil)
+	}
+
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", name, err)
+	}
+	return a.write(name, b)
+}
+
+func (a *archive) write(name string, content []byte) error {
+	if err := a.tw.WriteHeader(&tar.Header{
+		Name:    path.Join(a.dir, name),
+		Mode:    0o644,
+		Size:    int64(len(content)),
+		ModTime: a.now,
+	}); err != nil {
+		return fmt.Errorf("writing tar header of %s: %w", name, err)
+	}
+	if _, err := a.tw.Write(content); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package gather
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/testutil"
+)
+
+func TestGatherer_Gather(t *testing.T) {
+	addon := testutil.NewTestAddonWithSingleNamespace()
+	ownedLabels := map[string]string{
+		controllers.CommonManagedByLabel: controllers.CommonManagedByValue,
+		controllers.CommonCacheLabel:     controllers.CommonCacheValue,
+		controllers.CommonInstanceLabel:  addon.Name,
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(testutil.NewTestScheme()).
+		WithObjects(
+			addon,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: "namespace-1", Labels: ownedLabels,
+			}},
+			&operatorsv1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{
+				Name: "addon-addon-1-catalog", Namespace: "namespace-1", Labels: ownedLabels,
+			}},
+			&operatorsv1alpha1.CatalogSource{ObjectMeta: metav1.ObjectMeta{
+				Name: "unrelated", Namespace: "namespace-1",
+			}},
+			&operatorsv1alpha1.ClusterServiceVersion{ObjectMeta: metav1.ObjectMeta{
+				Name: "addon-1.v1.0.0", Namespace: "namespace-1",
+			}},
+		).
+		Build()
+	clientset := k8sfake.NewSimpleClientset(
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "addon-1.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Addon", Name: addon.Name},
+			Reason:         "UpgradeStarted",
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "other.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Addon", Name: "other"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "operator-1", Namespace: "namespace-1"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "addon-operator-manager-1", Namespace: "addon-operator",
+				Labels: map[string]string{"app.kubernetes.io/name": "addon-operator"},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+		},
+	)
+
+	g := NewGatherer(c, clientset, WithOperatorNamespace("addon-operator"))
+	var buf bytes.Buffer
+	require.NoError(t, g.Gather(context.Background(), addon.Name, &buf))
+
+	files := readArchive(t, &buf)
+	assert.Contains(t, files["addon-addon-1/addon.yaml"], "kind: Addon")
+	assert.Contains(t, files, "addon-addon-1/conditions.yaml")
+	assert.Contains(t, files, "addon-addon-1/objects/namespace/namespace-1.yaml")
+	assert.Contains(t, files, "addon-addon-1/objects/catalogsource/namespace-1/addon-addon-1-catalog.yaml")
+	assert.NotContains(t, files, "addon-addon-1/objects/catalogsource/namespace-1/unrelated.yaml")
+	assert.Contains(t, files, "addon-addon-1/objects/clusterserviceversion/namespace-1/addon-1.v1.0.0.yaml")
+	assert.Contains(t, files["addon-addon-1/events.yaml"], "UpgradeStarted")
+	assert.NotContains(t, files["addon-addon-1/events.yaml"], "other")
+	// The fake clientset returns "fake logs" for every container.
+	assert.Equal(t, "fake logs", files["addon-addon-1/logs/namespace-1/operator-1/manager.log"])
+	// Lines of the addon-operator not mentioning the Addon are dropped.
+	assert.Equal(t, "", files["addon-addon-1/logs/addon-operator/addon-operator-manager-1/manager.log"])
+}
+
+func TestGatherer_Gather_AddonNotFound(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(testutil.NewTestScheme()).Build()
+	g := NewGatherer(c, k8sfake.NewSimpleClientset())
+
+	err := g.Gather(context.Background(), "missing", io.Discard)
+	assert.Error(t, err)
+}
+
+func readArchive(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+}
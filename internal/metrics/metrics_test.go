@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -132,6 +133,8 @@ func TestAddonMetrics_AddonHealth(t *testing.T) {
 				recorder.addonHealthInfo.WithLabelValues(
 					addon.Name,
 					"0.0.0",
+					"",
+					"",
 				),
 			))
 		})
@@ -209,3 +212,165 @@ func TestAddonMetrics_AddonConditions(t *testing.T) {
 		assert.Equal(t, float64(0), testutil.ToFloat64(recorder.addonOperatorPaused))
 	})
 }
+
+func TestRecordSubReconcilerResult(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordSubReconcilerResult("olmReconciler", time.Second, nil)
+	recorder.RecordSubReconcilerResult("olmReconciler", time.Second, fmt.Errorf("boom"))
+
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.subReconcilerDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.subReconcilerErrors.WithLabelValues("olmReconciler")))
+}
+
+func TestRecordDriftRemediation(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordDriftRemediation("addon-1", "Namespace")
+	recorder.RecordDriftRemediation("addon-1", "Namespace")
+	recorder.RecordDriftRemediation("addon-1", "Subscription")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.driftRemediations.WithLabelValues("addon-1", "Namespace")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.driftRemediations.WithLabelValues("addon-1", "Subscription")))
+}
+
+func TestRecordAddonPhaseTransition(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordAddonPhaseTransition("Installing", "Ready")
+	recorder.RecordAddonPhaseTransition("Ready", "Degraded")
+	recorder.RecordAddonPhaseTransition("Degraded", "Ready")
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.phaseTransitions.WithLabelValues("Installing", "Ready")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.phaseTransitions.WithLabelValues("Ready", "Degraded")))
+}
+
+func TestRecordOCMCircuitTransition(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordOCMCircuitTransition("closed", "open")
+	recorder.RecordOCMCircuitTransition("open", "half-open")
+	recorder.RecordOCMCircuitTransition("half-open", "open")
+	recorder.RecordOCMCircuitTransition("open", "half-open")
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.ocmCircuitTransitions.WithLabelValues("closed", "open")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.ocmCircuitTransitions.WithLabelValues("open", "half-open")))
+}
+
+func TestRecordOCMTokenRefresh(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	expiry := time.Unix(1700000000, 0)
+	recorder.RecordOCMTokenRefresh("ClientCredentials", expiry, nil)
+	recorder.RecordOCMTokenRefresh("ClientCredentials", time.Time{}, errors.New("explosion"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.ocmTokenRefreshes.WithLabelValues("ClientCredentials", "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.ocmTokenRefreshes.WithLabelValues("ClientCredentials", "failure")))
+	assert.Equal(t, float64(1700000000), testutil.ToFloat64(
+		recorder.ocmTokenExpiry.WithLabelValues("ClientCredentials")))
+}
+
+func TestRecordAddonReconcile(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordAddonReconcile("addon-1", time.Second, ReconcileResultSuccess)
+	recorder.RecordAddonReconcile("addon-1", time.Second, ReconcileResultError, "ocm", "conflict")
+	recorder.RecordAddonReconcile("addon-1", time.Second, ReconcileResultError, "ocm")
+	recorder.RecordAddonReconcile("addon-2", time.Second, ReconcileResultRequeue)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(recorder.addonReconcileDuration))
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.addonReconcileErrors.WithLabelValues("addon-1", "ocm")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.addonReconcileErrors.WithLabelValues("addon-1", "conflict")))
+
+	recorder.ForgetAddon("addon-1")
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.addonReconcileDuration))
+	assert.Equal(t, 0, testutil.CollectAndCount(recorder.addonReconcileErrors))
+}
+
+func TestRecordAddonPhaseResult(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordAddonPhaseResult("catalogSource", time.Second, nil)
+	recorder.RecordAddonPhaseResult("catalogSource", time.Second, errors.New("boom"))
+	recorder.RecordAddonPhaseResult("subscription", time.Second, nil)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(recorder.addonPhaseDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.addonPhaseFailures.WithLabelValues("catalogSource")))
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.addonPhaseFailures))
+}
+
+func TestAddonMetrics_AddonHealthSingleSeries(t *testing.T) {
+	recorder := NewRecorder(false, "fdj41ddk")
+
+	addon := newTestAddon("o672wxBaW9iR", []metav1.Condition{
+		{
+			Type:   addonsv1alpha1.Available,
+			Status: metav1.ConditionFalse,
+			Reason: addonsv1alpha1.AddonReasonUnreadyCSV,
+		},
+	})
+	addon.Name = "addon-1"
+	addon.Status.Phase = addonsv1alpha1.PhaseDegraded
+	addon.Status.ObservedVersion = "1.0.0"
+	recorder.RecordAddonMetrics(addon)
+	assert.Equal(t, float64(0), testutil.ToFloat64(recorder.addonHealthInfo.WithLabelValues(
+		"addon-1", "1.0.0", string(addonsv1alpha1.PhaseDegraded), addonsv1alpha1.AddonReasonUnreadyCSV)))
+
+	addon.Status.Phase = addonsv1alpha1.PhaseReady
+	addon.Status.ObservedVersion = "1.1.0"
+	addon.Status.Conditions = []metav1.Condition{{
+		Type:   addonsv1alpha1.Available,
+		Status: metav1.ConditionTrue,
+		Reason: addonsv1alpha1.AddonReasonFullyReconciled,
+	}}
+	recorder.RecordAddonMetrics(addon)
+	assert.Equal(t, 1, testutil.CollectAndCount(recorder.addonHealthInfo))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.addonHealthInfo.WithLabelValues(
+		"addon-1", "1.1.0", string(addonsv1alpha1.PhaseReady), addonsv1alpha1.AddonReasonFullyReconciled)))
+
+	recorder.ForgetAddon("addon-1")
+	assert.Equal(t, 0, testutil.CollectAndCount(recorder.addonHealthInfo))
+}
+
+func TestRecordOCMAPIRequest(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordOCMAPIRequest("upgradePolicies", "PATCH", 200, time.Second)
+	recorder.RecordOCMAPIRequest("upgradePolicies", "PATCH", 204, time.Second)
+	recorder.RecordOCMAPIRequest("addonStatus", "POST", 503, time.Second)
+	recorder.RecordOCMAPIRequest("addonStatus", "POST", 0, time.Second)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.ocmAPIRequests.WithLabelValues("PATCH", "upgradePolicies", "2xx")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.ocmAPIRequests.WithLabelValues("POST", "addonStatus", "5xx")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(
+		recorder.ocmAPIRequests.WithLabelValues("POST", "addonStatus", "error")))
+	assert.Equal(t, 3, testutil.CollectAndCount(recorder.ocmAPIRequestLatency))
+}
+
+func TestRecordConditionTransition(t *testing.T) {
+	recorder := NewRecorder(false, "sdjkl83hjd")
+
+	recorder.RecordConditionTransition("addon-1", "Available", "True", "False")
+	recorder.RecordConditionTransition("addon-1", "Available", "False", "True")
+	recorder.RecordConditionTransition("addon-1", "Available", "True", "False")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(
+		recorder.conditionTransitions.WithLabelValues("addon-1", "Available", "True", "False")))
+
+	recorder.ForgetAddon("addon-1")
+	assert.Equal(t, 0, testutil.CollectAndCount(recorder.conditionTransitions))
+}
@@ -1,7 +1,10 @@
 package metrics
 
 import (
+	"fmt"
+	"strconv"
 	"sync"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -18,7 +21,9 @@ import (
 // This state will be used for updating condition metrics.
 type addonState struct {
 	conditionMap map[string]addonConditions
-	lock         sync.RWMutex
+	// Label values of the health info series of each Addon by name.
+	healthInfoLabels map[string][]string
+	lock             sync.RWMutex
 }
 
 type addonConditions struct {
@@ -36,6 +41,23 @@ type Recorder struct {
 	ocmAPIRequestDuration          prometheus.Summary
 	addonServiceAPIRequestDuration prometheus.Summary
 	addonHealthInfo                *prometheus.GaugeVec
+
+	subReconcilerDuration *prometheus.HistogramVec
+	subReconcilerErrors   *prometheus.CounterVec
+	driftRemediations     *prometheus.CounterVec
+	phaseTransitions      *prometheus.CounterVec
+	orphansCollected      *prometheus.CounterVec
+	ocmCircuitTransitions *prometheus.CounterVec
+	ocmTokenRefreshes     *prometheus.CounterVec
+	ocmTokenExpiry        *prometheus.GaugeVec
+
+	addonReconcileDuration *prometheus.HistogramVec
+	addonReconcileErrors   *prometheus.CounterVec
+	addonPhaseDuration     *prometheus.HistogramVec
+	addonPhaseFailures     *prometheus.CounterVec
+	ocmAPIRequests         *prometheus.CounterVec
+	ocmAPIRequestLatency   *prometheus.HistogramVec
+	conditionTransitions   *prometheus.CounterVec
 	// .. TODO: More metrics!
 }
 
@@ -85,9 +107,133 @@ func NewRecorder(register bool, clusterId string) *Recorder {
 	addonHealthInfo := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name:        "addon_operator_addon_health_info",
-			Help:        "Addon Health information",
+			Help:        "Addon Health information, a single series per Addon",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"addon", "version", "phase", "reason"},
+	)
+
+	subReconcilerDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "addon_operator_addon_sub_reconciler_duration_seconds",
+			Help:        "Addon sub-reconciler latencies in seconds, grouped by sub-reconciler",
+			Buckets:     prometheus.DefBuckets,
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"sub_reconciler"},
+	)
+
+	subReconcilerErrors := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_addon_sub_reconciler_errors_total",
+			Help:        "Total number of Addon sub-reconciler errors, grouped by sub-reconciler",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"sub_reconciler"},
+	)
+
+	driftRemediations := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_drift_remediations_total",
+			Help:        "Total number of manual changes to Addon objects reverted, grouped by Addon and kind",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"name", "kind"},
+	)
+
+	phaseTransitions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_addon_phase_transitions_total",
+			Help:        "Total number of Addon phase transitions, grouped by previous and new phase",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"from", "to"},
+	)
+
+	orphansCollected := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_orphaned_objects_collected_total",
+			Help:        "Total number of objects of deleted Addons collected, grouped by kind and dry run",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"kind", "dry_run"},
+	)
+
+	ocmCircuitTransitions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_ocm_circuit_breaker_transitions_total",
+			Help:        "Total number of OCM API circuit breaker state transitions, grouped by previous and new state",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"from", "to"},
+	)
+
+	ocmTokenRefreshes := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_ocm_token_refreshes_total",
+			Help:        "Total number of OCM API access token refreshes, grouped by auth mechanism and result",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"mechanism", "result"},
+	)
+
+	ocmTokenExpiry := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "addon_operator_ocm_token_expiry_timestamp_seconds",
+			Help:        "Unix timestamp at which the current OCM API access token expires, grouped by auth mechanism",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"mechanism"},
+	)
+
+	addonReconcileDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "addon_operator_reconcile_duration_seconds",
+			Help:        "Addon reconcile latencies in seconds, grouped by Addon and result",
+			Buckets:     prometheus.DefBuckets,
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"addon", "result"},
+	)
+
+	addonReconcileErrors := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_reconcile_errors_total",
+			Help:        "Total number of Addon reconcile errors, grouped by Addon and error class",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"addon", "class"},
+	)
+
+	addonPhaseDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "addon_operator_addon_phase_duration_seconds",
+			Help:        "Addon OLM reconcile phase latencies in seconds, grouped by phase",
+			Buckets:     prometheus.DefBuckets,
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"phase"},
+	)
+
+	addonPhaseFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_addon_phase_failures_total",
+			Help:        "Total number of failed Addon OLM reconcile phases, grouped by phase",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"phase"},
+	)
+
+	ocmAPIRequests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_ocm_api_requests_total",
+			Help:        "Total number of OCM API requests, grouped by method, endpoint and HTTP status class",
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"method", "endpoint", "status_class"},
+	)
+
+	ocmAPIRequestLatency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "addon_operator_ocm_api_request_duration_seconds",
+			Help:        "OCM API request latencies in seconds, grouped by method, endpoint and HTTP status class",
+			Buckets:     prometheus.DefBuckets,
+			ConstLabels: prometheus.Labels{"_id": clusterId},
+		}, []string{"method", "endpoint", "status_class"},
+	)
+
+	conditionTransitions := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "addon_operator_condition_transitions_total",
+			Help:        "Total number of Addon status condition transitions, grouped by Addon, condition type and previous and new status",
 			ConstLabels: prometheus.Labels{"_id": clusterId},
-		}, []string{"name", "version"},
+		}, []string{"addon", "type", "from", "to"},
 	)
 
 	// Register metrics if `register` is true
@@ -100,18 +246,49 @@ func NewRecorder(register bool, clusterId string) *Recorder {
 			ocmAPIReqDuration,
 			addonServiceAPIReqDuration,
 			addonHealthInfo,
+			subReconcilerDuration,
+			subReconcilerErrors,
+			driftRemediations,
+			phaseTransitions,
+			orphansCollected,
+			ocmCircuitTransitions,
+			ocmTokenRefreshes,
+			ocmTokenExpiry,
+			addonReconcileDuration,
+			addonReconcileErrors,
+			addonPhaseDuration,
+			addonPhaseFailures,
+			ocmAPIRequests,
+			ocmAPIRequestLatency,
+			conditionTransitions,
 		)
 	}
 
 	return &Recorder{
 		addonState: &addonState{
-			conditionMap: map[string]addonConditions{},
+			conditionMap:     map[string]addonConditions{},
+			healthInfoLabels: map[string][]string{},
 		},
 		addonsCount:                    addonsCount,
 		addonOperatorPaused:            addonOperatorPaused,
 		ocmAPIRequestDuration:          ocmAPIReqDuration,
 		addonServiceAPIRequestDuration: addonServiceAPIReqDuration,
 		addonHealthInfo:                addonHealthInfo,
+		subReconcilerDuration:          subReconcilerDuration,
+		subReconcilerErrors:            subReconcilerErrors,
+		driftRemediations:              driftRemediations,
+		phaseTransitions:               phaseTransitions,
+		orphansCollected:               orphansCollected,
+		ocmCircuitTransitions:          ocmCircuitTransitions,
+		ocmTokenRefreshes:              ocmTokenRefreshes,
+		ocmTokenExpiry:                 ocmTokenExpiry,
+		addonReconcileDuration:         addonReconcileDuration,
+		addonReconcileErrors:           addonReconcileErrors,
+		addonPhaseDuration:             addonPhaseDuration,
+		addonPhaseFailures:             addonPhaseFailures,
+		ocmAPIRequests:                 ocmAPIRequests,
+		ocmAPIRequestLatency:           ocmAPIRequestLatency,
+		conditionTransitions:           conditionTransitions,
 	}
 }
 
@@ -157,6 +334,112 @@ func (r *Recorder) RecordAddonServiceAPIRequests(us float64) {
 	r.addonServiceAPIRequestDuration.Observe(us)
 }
 
+// RecordSubReconcilerResult records the duration of a single
+// Addon sub-reconciler run and whether it failed.
+func (r *Recorder) RecordSubReconcilerResult(name string, d time.Duration, err error) {
+	r.subReconcilerDuration.WithLabelValues(name).Observe(d.Seconds())
+	if err != nil {
+		r.subReconcilerErrors.WithLabelValues(name).Inc()
+	}
+}
+
+// RecordAddonPhaseResult records the duration of a single phase
+// of the Addon OLM reconciler and whether it failed.
+// Sub-reconcilers are recorded by RecordSubReconcilerResult.
+func (r *Recorder) RecordAddonPhaseResult(phase string, d time.Duration, err error) {
+	r.addonPhaseDuration.WithLabelValues(phase).Observe(d.Seconds())
+	if err != nil {
+		r.addonPhaseFailures.WithLabelValues(phase).Inc()
+	}
+}
+
+// RecordConditionTransition counts a status condition of the given Addon
+// changing its status. Absent conditions are counted as status "None".
+func (r *Recorder) RecordConditionTransition(addonName, condType, from, to string) {
+	r.conditionTransitions.WithLabelValues(addonName, condType, from, to).Inc()
+}
+
+// RecordDriftRemediation counts a manual change to an object
+// of the given Addon, which was reverted to its desired state.
+func (r *Recorder) RecordDriftRemediation(addonName, kind string) {
+	r.driftRemediations.WithLabelValues(addonName, kind).Inc()
+}
+
+// RecordAddonPhaseTransition counts an Addon moving between phases.
+func (r *Recorder) RecordAddonPhaseTransition(from, to string) {
+	r.phaseTransitions.WithLabelValues(from, to).Inc()
+}
+
+// RecordOrphanCollected counts an object of a deleted Addon,
+// which was deleted or, in dry run, would have been deleted.
+func (r *Recorder) RecordOrphanCollected(kind string, dryRun bool) {
+	r.orphansCollected.WithLabelValues(kind, strconv.FormatBool(dryRun)).Inc()
+}
+
+// RecordOCMCircuitTransition counts the OCM API circuit breaker
+// moving between the closed, open and half-open states.
+func (r *Recorder) RecordOCMCircuitTransition(from, to string) {
+	r.ocmCircuitTransitions.WithLabelValues(from, to).Inc()
+}
+
+// RecordOCMTokenRefresh counts the refresh of an OCM API access token
+// and records the expiry of the new token, if the refresh succeeded.
+func (r *Recorder) RecordOCMTokenRefresh(mechanism string, expiry time.Time, err error) {
+	if err != nil {
+		r.ocmTokenRefreshes.WithLabelValues(mechanism, "failure").Inc()
+		return
+	}
+	r.ocmTokenRefreshes.WithLabelValues(mechanism, "success").Inc()
+	r.ocmTokenExpiry.WithLabelValues(mechanism).Set(float64(expiry.Unix()))
+}
+
+// RecordOCMAPIRequest counts a request to the given OCM API endpoint
+// and records its latency. The status code is 0, if no response was received.
+func (r *Recorder) RecordOCMAPIRequest(endpoint, method string, statusCode int, d time.Duration) {
+	class := httpStatusClass(statusCode)
+	r.ocmAPIRequests.WithLabelValues(method, endpoint, class).Inc()
+	r.ocmAPIRequestLatency.WithLabelValues(method, endpoint, class).Observe(d.Seconds())
+}
+
+// Returns the class of the given HTTP status code, e.g. "2xx",
+// or "error" if no response was received.
+func httpStatusClass(statusCode int) string {
+	if statusCode == 0 {
+		return "error"
+	}
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
+
+// Results of an Addon reconcile.
+const (
+	ReconcileResultSuccess = "success"
+	ReconcileResultRequeue = "requeue"
+	ReconcileResultError   = "error"
+)
+
+// RecordAddonReconcile records the duration and result of a single
+// reconcile of the given Addon and counts its errors by class.
+func (r *Recorder) RecordAddonReconcile(addonName string, d time.Duration, result string, errClasses ...string) {
+	r.addonReconcileDuration.WithLabelValues(addonName, result).Observe(d.Seconds())
+	for _, class := range errClasses {
+		r.addonReconcileErrors.WithLabelValues(addonName, class).Inc()
+	}
+}
+
+// ForgetAddon removes the metrics of a deleted Addon.
+func (r *Recorder) ForgetAddon(addonName string) {
+	r.addonState.lock.Lock()
+	defer r.addonState.lock.Unlock()
+
+	if labels, ok := r.addonState.healthInfoLabels[addonName]; ok {
+		r.addonHealthInfo.DeleteLabelValues(labels...)
+		delete(r.addonState.healthInfoLabels, addonName)
+	}
+	r.addonReconcileDuration.DeletePartialMatch(prometheus.Labels{"addon": addonName})
+	r.addonReconcileErrors.DeletePartialMatch(prometheus.Labels{"addon": addonName})
+	r.conditionTransitions.DeletePartialMatch(prometheus.Labels{"addon": addonName})
+}
+
 // SetAddonOperatorPaused sets the `addon_operator_paused` metric
 // 0 - Not paused , 1 - Paused
 func (r *Recorder) SetAddonOperatorPaused(paused bool) {
@@ -266,7 +549,29 @@ func (r *Recorder) recordAddonHealthInfo(addon *addonsv1alpha1.Addon) {
 		addonVersion = addon.Status.ObservedVersion
 	}
 
-	r.addonHealthInfo.WithLabelValues(addon.Name,
-		addonVersion,
-	).Set(float64(healthStatus))
+	var reason string
+	if healthCond != nil {
+		reason = healthCond.Reason
+	}
+
+	// Drop the previous series when the version, phase or reason changed,
+	// so fleet dashboards can count Addons by counting series.
+	labels := []string{addon.Name, addonVersion, string(addon.Status.Phase), reason}
+	if prev, ok := r.addonState.healthInfoLabels[addon.Name]; ok && !equalLabelValues(prev, labels) {
+		r.addonHealthInfo.DeleteLabelValues(prev...)
+	}
+	r.addonState.healthInfoLabels[addon.Name] = labels
+	r.addonHealthInfo.WithLabelValues(labels...).Set(float64(healthStatus))
+}
+
+func equalLabelValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,82 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type AddOnInstallationState string
+
+const (
+	AddOnInstallationStateInstalling AddOnInstallationState = "installing"
+	AddOnInstallationStateReady      AddOnInstallationState = "ready"
+	AddOnInstallationStateFailed     AddOnInstallationState = "failed"
+	AddOnInstallationStateDeleting   AddOnInstallationState = "deleting"
+)
+
+type AddOnInstallationPatchRequest struct {
+	// ID of the addon, not part of the payload.
+	ID               string                    `json:"-"`
+	State            AddOnInstallationState    `json:"state"`
+	StateDescription string                    `json:"state_description"`
+	AddonVersion     *AddOnInstallationVersion `json:"addon_version,omitempty"`
+}
+
+type AddOnInstallationVersion struct {
+	ID string `json:"id"`
+}
+
+type AddOnInstallationPatchResponse struct{}
+
+func (c *Client) PatchAddOnInstallation(
+	ctx context.Context,
+	req AddOnInstallationPatchRequest,
+) (res AddOnInstallationPatchResponse, err error) {
+	urlParams := url.Values{}
+	return res, c.do(ctx, EndpointAddOnInstallations, http.MethodPatch, fmt.Sprintf(
+		"api/clusters_mgmt/v1/clusters/%s/addons/%s",
+		c.opts.ClusterID,
+		req.ID,
+	),
+		urlParams,
+		req,
+		&res,
+	)
+}
+
+type AddOnInstallationGetRequest struct {
+	// ID of the addon.
+	ID string `json:"id"`
+}
+
+type AddOnInstallationGetResponse struct {
+	ID         string                         `json:"id"`
+	Parameters AddOnInstallationParameterList `json:"parameters"`
+}
+
+type AddOnInstallationParameterList struct {
+	Items []AddOnInstallationParameter `json:"items"`
+}
+
+type AddOnInstallationParameter struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+func (c *Client) GetAddOnInstallation(
+	ctx context.Context,
+	req AddOnInstallationGetRequest,
+) (res AddOnInstallationGetResponse, err error) {
+	urlParams := url.Values{}
+	return res, c.do(ctx, EndpointAddOnInstallations, http.MethodGet, fmt.Sprintf(
+		"api/clusters_mgmt/v1/clusters/%s/addons/%s",
+		c.opts.ClusterID,
+		req.ID,
+	),
+		urlParams,
+		nil,
+		&res,
+	)
+}
@@ -0,0 +1,81 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	ioutil "io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPatchAddOnInstallation(t *testing.T) {
+	var (
+		recordedHttpRequest *http.Request
+		recordedBody        []byte
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		recordedHttpRequest = r
+		recordedBody, _ = ioutil.ReadAll(recordedHttpRequest.Body)
+		if r.URL.Path == "/proxy/apis/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+		} else {
+			fmt.Fprintln(rw, `{}`)
+		}
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+
+	c, ocmClientError := NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL+"/proxy/apis"),
+	)
+	require.NoError(t, ocmClientError)
+
+	_, err := c.PatchAddOnInstallation(
+		ctx, AddOnInstallationPatchRequest{
+			ID:               "addon-1",
+			State:            AddOnInstallationStateReady,
+			StateDescription: "",
+			AddonVersion:     &AddOnInstallationVersion{ID: "1.0.0"},
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPatch, recordedHttpRequest.Method)
+	assert.Equal(t, `{"state":"ready","state_description":"","addon_version":{"id":"1.0.0"}}`, string(recordedBody))
+	assert.Equal(t, "/proxy/apis/api/clusters_mgmt/v1/clusters/1ou/addons/addon-1", recordedHttpRequest.URL.Path)
+}
+
+func TestClientGetAddOnInstallation(t *testing.T) {
+	var recordedHttpRequest *http.Request
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		recordedHttpRequest = r
+		if r.URL.Path == "/proxy/apis/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+		} else {
+			fmt.Fprintln(rw, `{"id":"addon-1","parameters":{"items":[{"id":"API_TOKEN","value":"s3cr3t"}]}}`)
+		}
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+
+	c, ocmClientError := NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL+"/proxy/apis"),
+	)
+	require.NoError(t, ocmClientError)
+
+	res, err := c.GetAddOnInstallation(ctx, AddOnInstallationGetRequest{ID: "addon-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodGet, recordedHttpRequest.Method)
+	assert.Equal(t, "/proxy/apis/api/clusters_mgmt/v1/clusters/1ou/addons/addon-1", recordedHttpRequest.URL.Path)
+	assert.Equal(t, []AddOnInstallationParameter{{ID: "API_TOKEN", Value: "s3cr3t"}}, res.Parameters.Items)
+}
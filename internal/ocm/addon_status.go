@@ -39,6 +39,7 @@ func (c *Client) GetAddOnStatus(ctx context.Context, addonID string) (AddOnStatu
 	res := &AddOnStatusResponse{}
 	err := c.do(
 		ctx,
+		EndpointAddOnStatus,
 		http.MethodGet,
 		fmt.Sprintf("/api/addons_mgmt/v1/clusters/%s/status/%s", c.opts.ClusterID, addonID),
 		url.Values{},
@@ -55,6 +56,7 @@ func (c *Client) PostAddOnStatus(ctx context.Context, payload AddOnStatusPostReq
 	res := &AddOnStatusResponse{}
 	err := c.do(
 		ctx,
+		EndpointAddOnStatus,
 		http.MethodPost,
 		fmt.Sprintf("/api/addons_mgmt/v1/clusters/%s/status", c.opts.ClusterID),
 		url.Values{},
@@ -71,6 +73,7 @@ func (c *Client) PatchAddOnStatus(ctx context.Context, addonID string, payload A
 	res := &AddOnStatusResponse{}
 	err := c.do(
 		ctx,
+		EndpointAddOnStatus,
 		http.MethodPatch,
 		fmt.Sprintf("/api/addons_mgmt/v1/clusters/%s/status/%s", c.opts.ClusterID, addonID),
 		url.Values{},
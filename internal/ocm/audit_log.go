@@ -0,0 +1,185 @@
+package ocm
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults of the AuditLog.
+const (
+	DefaultAuditLogSize = 100
+	// Request and response bodies are truncated to this many bytes.
+	DefaultAuditLogMaxBodySize = 2048
+)
+
+// Endpoints, whose bodies are never recorded, as they may contain secrets.
+var auditLogRedactedEndpoints = map[Endpoint]bool{
+	// Addon parameters may hold credentials.
+	EndpointAddOnInstallations: true,
+}
+
+// AuditEntry is a request to the OCM API recorded by an AuditLog.
+type AuditEntry struct {
+	Time     time.Time
+	Endpoint Endpoint
+	Method   string
+	// Path and query of the request.
+	Path string
+	// HTTP status code of the response, 0 if no response was received.
+	StatusCode int
+	Latency    time.Duration
+	// Transport error of the request.
+	Error string
+	// Truncated request and response bodies.
+	RequestBody  string
+	ResponseBody string
+	// Whether bodies were truncated or redacted.
+	Truncated bool
+	Redacted  bool
+}
+
+// AuditLog keeps the most recent requests to the OCM API in a ring buffer,
+// to diagnose OCM integration failures without raising log verbosity.
+// It is safe for concurrent use and may be shared across Clients,
+// to keep its entries when clients are recreated.
+type AuditLog struct {
+	opts AuditLogOptions
+
+	mux     sync.Mutex
+	entries []AuditEntry
+	// Index the next entry is written to.
+	next int
+	full bool
+}
+
+type AuditLogOptions struct {
+	// Number of entries kept.
+	Size int
+	// Request and response bodies are truncated to this many bytes.
+	MaxBodySize int
+}
+
+type AuditLogOption func(o *AuditLogOptions)
+
+func WithAuditLogSize(size int) AuditLogOption {
+	return func(o *AuditLogOptions) {
+		o.Size = size
+	}
+}
+
+func WithAuditLogMaxBodySize(size int) AuditLogOption {
+	return func(o *AuditLogOptions) {
+		o.MaxBodySize = size
+	}
+}
+
+func NewAuditLog(opts ...AuditLogOption) *AuditLog {
+	l := &AuditLog{
+		opts: AuditLogOptions{
+			Size:        DefaultAuditLogSize,
+			MaxBodySize: DefaultAuditLogMaxBodySize,
+		},
+	}
+	for _, opt := range opts {
+		opt(&l.opts)
+	}
+	if l.opts.Size < 1 {
+		l.opts.Size = 1
+	}
+	l.entries = make([]AuditEntry, l.opts.Size)
+	return l
+}
+
+// Returns the recorded entries, oldest first.
+func (l *AuditLog) Entries() []AuditEntry {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if !l.full {
+		entries := make([]AuditEntry, l.next)
+		copy(entries, l.entries[:l.next])
+		return entries
+	}
+	entries := make([]AuditEntry, 0, len(l.entries))
+	entries = append(entries, l.entries[l.next:]...)
+	return append(entries, l.entries[:l.next]...)
+}
+
+// Records the given entry, truncating or redacting its bodies.
+func (l *AuditLog) record(entry AuditEntry, reqBody, resBody []byte) {
+	if l == nil {
+		return
+	}
+
+	if auditLogRedactedEndpoints[entry.Endpoint] {
+		entry.Redacted = len(reqBody) > 0 || len(resBody) > 0
+	} else {
+		var reqTruncated, resTruncated bool
+		entry.RequestBody, reqTruncated = l.truncate(reqBody)
+		entry.ResponseBody, resTruncated = l.truncate(resBody)
+		entry.Truncated = reqTruncated || resTruncated
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+func (l *AuditLog) truncate(body []byte) (string, bool) {
+	if len(body) <= l.opts.MaxBodySize {
+		return string(body), false
+	}
+	return string(body[:l.opts.MaxBodySize]), true
+}
+
+// JSON representation of an AuditEntry served by the AuditLog.
+type auditEntryJSON struct {
+	Time         time.Time `json:"time"`
+	Endpoint     Endpoint  `json:"endpoint"`
+	Method       string    `json:"method"`
+	Path         string    `json:"path"`
+	StatusCode   int       `json:"statusCode,omitempty"`
+	Latency      string    `json:"latency"`
+	Error        string    `json:"error,omitempty"`
+	RequestBody  string    `json:"requestBody,omitempty"`
+	ResponseBody string    `json:"responseBody,omitempty"`
+	Truncated    bool      `json:"truncated,omitempty"`
+	Redacted     bool      `json:"redacted,omitempty"`
+}
+
+// Serves the recorded entries as JSON, newest first.
+func (l *AuditLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	entries := l.Entries()
+	res := make([]auditEntryJSON, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		res = append(res, auditEntryJSON{
+			Time:         e.Time,
+			Endpoint:     e.Endpoint,
+			Method:       e.Method,
+			Path:         e.Path,
+			StatusCode:   e.StatusCode,
+			Latency:      e.Latency.String(),
+			Error:        e.Error,
+			RequestBody:  e.RequestBody,
+			ResponseBody: e.ResponseBody,
+			Truncated:    e.Truncated,
+			Redacted:     e.Redacted,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,110 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog_Ring(t *testing.T) {
+	t.Parallel()
+
+	l := NewAuditLog(WithAuditLogSize(3))
+	assert.Empty(t, l.Entries())
+
+	for i := 0; i < 5; i++ {
+		l.record(AuditEntry{Path: fmt.Sprintf("/%d", i)}, nil, nil)
+	}
+
+	entries := l.Entries()
+	require.Len(t, entries, 3)
+	assert.Equal(t, "/2", entries[0].Path)
+	assert.Equal(t, "/3", entries[1].Path)
+	assert.Equal(t, "/4", entries[2].Path)
+}
+
+func TestAuditLog_Bodies(t *testing.T) {
+	t.Parallel()
+
+	l := NewAuditLog(WithAuditLogMaxBodySize(4))
+	l.record(AuditEntry{Endpoint: EndpointUpgradePolicies}, []byte("1234"), []byte("123456"))
+	l.record(AuditEntry{Endpoint: EndpointAddOnInstallations}, nil, []byte(`{"value":"s3cr3t"}`))
+
+	entries := l.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "1234", entries[0].RequestBody)
+	assert.Equal(t, "1234", entries[0].ResponseBody)
+	assert.True(t, entries[0].Truncated)
+
+	assert.Empty(t, entries[1].ResponseBody)
+	assert.True(t, entries[1].Redacted)
+}
+
+func TestAuditLog_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	l := NewAuditLog()
+	l.record(AuditEntry{Method: http.MethodGet, Path: "/first", StatusCode: 200}, nil, nil)
+	l.record(AuditEntry{Method: http.MethodPatch, Path: "/second", StatusCode: 500}, nil, nil)
+
+	rec := httptest.NewRecorder()
+	l.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/ocm/requests", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var entries []auditEntryJSON
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "/second", entries[0].Path)
+	assert.Equal(t, 500, entries[0].StatusCode)
+	assert.Equal(t, "/first", entries[1].Path)
+
+	rec = httptest.NewRecorder()
+	l.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/ocm/requests", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestClientDo_AuditLog(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+			return
+		}
+		rw.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(rw, `{"code":"bad","reason":"request"}`)
+	}))
+	defer s.Close()
+
+	l := NewAuditLog()
+	c, err := NewClient(
+		context.Background(),
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL),
+		WithAuditLog(l),
+	)
+	require.NoError(t, err)
+
+	err = c.do(context.Background(), EndpointUpgradePolicies, http.MethodPatch,
+		"/state", nil, map[string]string{"value": "started"}, nil)
+	require.Error(t, err)
+
+	entries := l.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, EndpointClusters, entries[0].Endpoint)
+	assert.True(t, strings.HasPrefix(entries[0].Path, "/api/clusters_mgmt/v1/clusters?search="))
+	assert.Equal(t, http.StatusOK, entries[0].StatusCode)
+
+	assert.Equal(t, EndpointUpgradePolicies, entries[1].Endpoint)
+	assert.Equal(t, http.MethodPatch, entries[1].Method)
+	assert.Equal(t, "/state", entries[1].Path)
+	assert.Equal(t, http.StatusBadRequest, entries[1].StatusCode)
+	assert.JSONEq(t, `{"value":"started"}`, entries[1].RequestBody)
+	assert.JSONEq(t, `{"code":"bad","reason":"request"}`, entries[1].ResponseBody)
+	assert.NotZero(t, entries[1].Latency)
+}
@@ -0,0 +1,249 @@
+package ocm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mechanisms authenticating requests to OCM.
+const (
+	AuthMechanismAccessToken       = "AccessToken"
+	AuthMechanismOfflineToken      = "OfflineToken"
+	AuthMechanismClientCredentials = "ClientCredentials"
+	AuthMechanismServiceAccount    = "ServiceAccount"
+)
+
+// Defaults of OAuth TokenSources.
+const (
+	// Red Hat SSO token endpoint.
+	DefaultTokenURL = "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+	// Client ID offline tokens from console.redhat.com are issued for.
+	DefaultOfflineTokenClientID = "cloud-services"
+	// Tokens are refreshed this long before they expire.
+	DefaultTokenRefreshMargin = time.Minute
+	// Timeout of requests to the token endpoint.
+	DefaultTokenRequestTimeout = 30 * time.Second
+)
+
+// TokenSource provides the Authorization header of requests to OCM.
+type TokenSource interface {
+	// Returns the Authorization header value for requests on behalf of the given cluster.
+	Authorization(ctx context.Context, clusterID string) (string, error)
+	// Returns the name of the authentication mechanism.
+	Mechanism() string
+}
+
+// Authenticates with the static access token of the cluster pull secret.
+type AccessTokenSource struct {
+	accessToken string
+}
+
+func NewAccessTokenSource(accessToken string) *AccessTokenSource {
+	return &AccessTokenSource{accessToken: accessToken}
+}
+
+func (s *AccessTokenSource) Authorization(_ context.Context, clusterID string) (string, error) {
+	return fmt.Sprintf("AccessToken %s:%s", clusterID, s.accessToken), nil
+}
+
+func (s *AccessTokenSource) Mechanism() string {
+	return AuthMechanismAccessToken
+}
+
+// OAuthTokenSource authenticates with bearer tokens from an OAuth token endpoint.
+// Tokens are cached and refreshed shortly before they expire.
+// It is safe for concurrent use and may be shared across Clients,
+// to keep its token when clients are recreated.
+type OAuthTokenSource struct {
+	mechanism string
+	tokenURL  string
+	// Builds the form of token requests.
+	form func() (url.Values, error)
+	opts TokenSourceOptions
+
+	mux    sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+type TokenSourceOptions struct {
+	// Tokens are refreshed this long before they expire.
+	RefreshMargin time.Duration
+	HTTPClient    *http.Client
+	// Called with each token refresh, e.g. to record metrics.
+	OnRefresh func(mechanism string, expiry time.Time, err error)
+
+	now func() time.Time
+}
+
+type TokenSourceOption func(o *TokenSourceOptions)
+
+func WithTokenRefreshMargin(margin time.Duration) TokenSourceOption {
+	return func(o *TokenSourceOptions) {
+		o.RefreshMargin = margin
+	}
+}
+
+func WithTokenHTTPClient(c *http.Client) TokenSourceOption {
+	return func(o *TokenSourceOptions) {
+		o.HTTPClient = c
+	}
+}
+
+func WithTokenRefreshHandler(onRefresh func(mechanism string, expiry time.Time, err error)) TokenSourceOption {
+	return func(o *TokenSourceOptions) {
+		o.OnRefresh = onRefresh
+	}
+}
+
+// Exchanges an offline token, e.g. from console.redhat.com/openshift/token, for access tokens.
+func NewOfflineTokenSource(
+	tokenURL, clientID, offlineToken string, opts ...TokenSourceOption,
+) *OAuthTokenSource {
+	if len(clientID) == 0 {
+		clientID = DefaultOfflineTokenClientID
+	}
+	return newOAuthTokenSource(AuthMechanismOfflineToken, tokenURL, func() (url.Values, error) {
+		return url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {clientID},
+			"refresh_token": {offlineToken},
+		}, nil
+	}, opts)
+}
+
+// Requests access tokens with the credentials of an OCM service account.
+func NewClientCredentialsTokenSource(
+	tokenURL, clientID, clientSecret string, opts ...TokenSourceOption,
+) *OAuthTokenSource {
+	return newOAuthTokenSource(AuthMechanismClientCredentials, tokenURL, func() (url.Values, error) {
+		return url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+		}, nil
+	}, opts)
+}
+
+// Requests access tokens asserting the identity of the given client
+// with a cloud service account JWT, e.g. a projected Kubernetes ServiceAccount token.
+// The JWT is read from the given path for every token request, as it is rotated on disk.
+func NewServiceAccountTokenSource(
+	tokenURL, clientID, jwtPath string, opts ...TokenSourceOption,
+) *OAuthTokenSource {
+	return newOAuthTokenSource(AuthMechanismServiceAccount, tokenURL, func() (url.Values, error) {
+		jwt, err := os.ReadFile(jwtPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading service account token: %w", err)
+		}
+		return url.Values{
+			"grant_type":            {"client_credentials"},
+			"client_id":             {clientID},
+			"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+			"client_assertion":      {strings.TrimSpace(string(jwt))},
+		}, nil
+	}, opts)
+}
+
+func newOAuthTokenSource(
+	mechanism, tokenURL string, form func() (url.Values, error), opts []TokenSourceOption,
+) *OAuthTokenSource {
+	if len(tokenURL) == 0 {
+		tokenURL = DefaultTokenURL
+	}
+	s := &OAuthTokenSource{
+		mechanism: mechanism,
+		tokenURL:  tokenURL,
+		form:      form,
+		opts: TokenSourceOptions{
+			RefreshMargin: DefaultTokenRefreshMargin,
+			HTTPClient:    &http.Client{},
+			now:           time.Now,
+		},
+	}
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+	return s
+}
+
+func (s *OAuthTokenSource) Mechanism() string {
+	return s.mechanism
+}
+
+func (s *OAuthTokenSource) Authorization(ctx context.Context, _ string) (string, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if len(s.token) == 0 || !s.opts.now().Add(s.opts.RefreshMargin).Before(s.expiry) {
+		token, expiry, err := s.requestToken(ctx)
+		if s.opts.OnRefresh != nil {
+			s.opts.OnRefresh(s.mechanism, expiry, err)
+		}
+		if err != nil {
+			return "", fmt.Errorf("refreshing %s access token: %w", s.mechanism, err)
+		}
+		s.token, s.expiry = token, expiry
+	}
+	return "Bearer " + s.token, nil
+}
+
+// Response of an OAuth token endpoint.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	// Seconds until the access token expires.
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (s *OAuthTokenSource) requestToken(ctx context.Context) (string, time.Time, error) {
+	form, err := s.form()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultTokenRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, s.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("creating token request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Accept", "application/json")
+
+	issuedAt := s.opts.now()
+	res, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("executing token request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("reading token response: %w", err)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil && res.StatusCode < 400 {
+		return "", time.Time{}, fmt.Errorf("unmarshalling token response: %w", err)
+	}
+	if res.StatusCode >= 400 {
+		return "", time.Time{}, fmt.Errorf(
+			"token request failed with HTTP %d: %s: %s", res.StatusCode, token.Error, token.ErrorDescription)
+	}
+	if len(token.AccessToken) == 0 {
+		return "", time.Time{}, fmt.Errorf("token response contains no access token")
+	}
+	return token.AccessToken, issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second), nil
+}
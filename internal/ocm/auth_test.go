@@ -0,0 +1,141 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTokenServer(t *testing.T, forms *[]url.Values) *httptest.Server {
+	t.Helper()
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		*forms = append(*forms, r.PostForm)
+		if r.PostForm.Get("client_secret") == "wrong" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(rw, `{"error":"unauthorized_client","error_description":"Invalid client secret"}`)
+			return
+		}
+		fmt.Fprintf(rw, `{"access_token":"token-%d","expires_in":300}`, len(*forms))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestOAuthTokenSource_Refresh(t *testing.T) {
+	var forms []url.Values
+	s := newTokenServer(t, &forms)
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var refreshes []time.Time
+	ts := NewOfflineTokenSource(s.URL, "", "offline",
+		WithTokenRefreshHandler(func(mechanism string, expiry time.Time, err error) {
+			assert.Equal(t, AuthMechanismOfflineToken, mechanism)
+			assert.NoError(t, err)
+			refreshes = append(refreshes, expiry)
+		}))
+	ts.opts.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	auth, err := ts.Authorization(ctx, "cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", auth)
+	require.Len(t, forms, 1)
+	assert.Equal(t, url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {DefaultOfflineTokenClientID},
+		"refresh_token": {"offline"},
+	}, forms[0])
+
+	// Cached until shortly before the token expires.
+	now = now.Add(3 * time.Minute)
+	auth, err = ts.Authorization(ctx, "cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", auth)
+
+	now = now.Add(time.Minute)
+	auth, err = ts.Authorization(ctx, "cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-2", auth)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2023, 1, 1, 0, 5, 0, 0, time.UTC),
+		time.Date(2023, 1, 1, 0, 9, 0, 0, time.UTC),
+	}, refreshes)
+}
+
+func TestOAuthTokenSource_ClientCredentials(t *testing.T) {
+	var forms []url.Values
+	s := newTokenServer(t, &forms)
+
+	auth, err := NewClientCredentialsTokenSource(s.URL, "id", "secret").
+		Authorization(context.Background(), "cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", auth)
+	assert.Equal(t, url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {"id"},
+		"client_secret": {"secret"},
+	}, forms[0])
+
+	var refreshErr error
+	_, err = NewClientCredentialsTokenSource(s.URL, "id", "wrong",
+		WithTokenRefreshHandler(func(_ string, _ time.Time, err error) {
+			refreshErr = err
+		})).
+		Authorization(context.Background(), "cluster")
+	require.EqualError(t, err, "refreshing ClientCredentials access token: "+
+		"token request failed with HTTP 401: unauthorized_client: Invalid client secret")
+	assert.Error(t, refreshErr)
+}
+
+func TestOAuthTokenSource_ServiceAccount(t *testing.T) {
+	var forms []url.Values
+	s := newTokenServer(t, &forms)
+
+	jwtPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(jwtPath, []byte("header.payload.signature\n"), 0o600))
+
+	auth, err := NewServiceAccountTokenSource(s.URL, "id", jwtPath).
+		Authorization(context.Background(), "cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", auth)
+	assert.Equal(t, url.Values{
+		"grant_type":            {"client_credentials"},
+		"client_id":             {"id"},
+		"client_assertion_type": {"urn:ietf:params:oauth:client-assertion-type:jwt-bearer"},
+		"client_assertion":      {"header.payload.signature"},
+	}, forms[0])
+}
+
+func TestClient_TokenSource(t *testing.T) {
+	var forms []url.Values
+	tokenServer := newTokenServer(t, &forms)
+
+	var authorization string
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		fmt.Fprintln(rw, clustersMockAPIResponseBody)
+	}))
+	defer s.Close()
+
+	_, err := NewClient(
+		context.Background(),
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL),
+		WithAccessToken("ignored"),
+		WithTokenSource(NewClientCredentialsTokenSource(tokenServer.URL, "id", "secret")),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", authorization)
+}
@@ -0,0 +1,208 @@
+package ocm
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type CircuitState string
+
+const (
+	// Requests are sent to OCM.
+	CircuitStateClosed CircuitState = "closed"
+	// Requests fail fast, until the backoff elapsed.
+	CircuitStateOpen CircuitState = "open"
+	// A single trial request is sent to OCM, to probe whether it recovered.
+	CircuitStateHalfOpen CircuitState = "half-open"
+)
+
+// Defaults of the CircuitBreaker.
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitMinBackoff       = 5 * time.Second
+	DefaultCircuitMaxBackoff       = 10 * time.Minute
+	// Ratio the backoff is randomly extended by,
+	// so all clusters of an OCM outage do not retry at once.
+	DefaultCircuitBackoffJitter = 0.2
+)
+
+var ErrCircuitOpen = errors.New("ocm circuit breaker is open")
+
+// CircuitOpenError is returned for requests failed fast by an open CircuitBreaker.
+type CircuitOpenError struct {
+	// Time until the next trial request is let through.
+	RetryAfter time.Duration
+}
+
+func (e CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s, retrying in %s", ErrCircuitOpen, e.RetryAfter.Round(time.Second))
+}
+
+func (e CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// Returns the time until the open CircuitBreaker, which failed the request, lets requests through again.
+func RetryAfter(err error) (time.Duration, bool) {
+	var circuitErr CircuitOpenError
+	if !errors.As(err, &circuitErr) {
+		return 0, false
+	}
+	return circuitErr.RetryAfter, true
+}
+
+// CircuitBreaker stops requests to OCM after consecutive failures
+// and lets a trial request through after a jittered exponential backoff.
+// It is safe for concurrent use and may be shared across Clients,
+// to keep its state when clients are recreated.
+type CircuitBreaker struct {
+	opts CircuitBreakerOptions
+
+	mux   sync.Mutex
+	state CircuitState
+	// Consecutive failures while closed.
+	failures int
+	// Consecutive times the circuit opened without recovering.
+	opened    int
+	openUntil time.Time
+}
+
+type CircuitBreakerOptions struct {
+	// Consecutive failures opening the circuit.
+	FailureThreshold int
+	// Backoff after the circuit opened the first time,
+	// doubling each time the trial request fails.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	Jitter     float64
+	// Called with each state transition, e.g. to record metrics.
+	OnTransition func(from, to CircuitState)
+
+	now   func() time.Time
+	float func() float64
+}
+
+type CircuitBreakerOption func(o *CircuitBreakerOptions)
+
+func WithCircuitFailureThreshold(threshold int) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.FailureThreshold = threshold
+	}
+}
+
+func WithCircuitBackoff(min, max time.Duration) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.MinBackoff = min
+		o.MaxBackoff = max
+	}
+}
+
+func WithCircuitTransitionHandler(onTransition func(from, to CircuitState)) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.OnTransition = onTransition
+	}
+}
+
+func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
+	cb := &CircuitBreaker{
+		state: CircuitStateClosed,
+		opts: CircuitBreakerOptions{
+			FailureThreshold: DefaultCircuitFailureThreshold,
+			MinBackoff:       DefaultCircuitMinBackoff,
+			MaxBackoff:       DefaultCircuitMaxBackoff,
+			Jitter:           DefaultCircuitBackoffJitter,
+			now:              time.Now,
+			float:            rand.Float64,
+		},
+	}
+	for _, opt := range opts {
+		opt(&cb.opts)
+	}
+	return cb
+}
+
+// Returns the current state of the circuit.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+	return cb.state
+}
+
+// Returns a CircuitOpenError, if the request must not be sent.
+func (cb *CircuitBreaker) allow() error {
+	if cb == nil {
+		return nil
+	}
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+
+	switch cb.state {
+	case CircuitStateOpen:
+		if wait := cb.openUntil.Sub(cb.opts.now()); wait > 0 {
+			return CircuitOpenError{RetryAfter: wait}
+		}
+		cb.transition(CircuitStateHalfOpen)
+		return nil
+	case CircuitStateHalfOpen:
+		// A trial request is in flight already.
+		return CircuitOpenError{RetryAfter: cb.opts.MinBackoff}
+	default:
+		return nil
+	}
+}
+
+// Records the outcome of a request let through,
+// failed if OCM was unavailable, as opposed to rejecting the individual request.
+func (cb *CircuitBreaker) record(failed bool) {
+	if cb == nil {
+		return
+	}
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+
+	if !failed {
+		cb.failures = 0
+		cb.opened = 0
+		if cb.state != CircuitStateClosed {
+			cb.transition(CircuitStateClosed)
+		}
+		return
+	}
+
+	switch cb.state {
+	case CircuitStateHalfOpen:
+		cb.open()
+	case CircuitStateClosed:
+		cb.failures++
+		if cb.failures >= cb.opts.FailureThreshold {
+			cb.open()
+		}
+	}
+}
+
+func (cb *CircuitBreaker) open() {
+	backoff := cb.opts.MinBackoff
+	for i := 0; i < cb.opened && backoff < cb.opts.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > cb.opts.MaxBackoff {
+		backoff = cb.opts.MaxBackoff
+	}
+	backoff += time.Duration(cb.opts.Jitter * cb.opts.float() * float64(backoff))
+
+	cb.opened++
+	cb.failures = 0
+	cb.openUntil = cb.opts.now().Add(backoff)
+	cb.transition(CircuitStateOpen)
+}
+
+func (cb *CircuitBreaker) transition(to CircuitState) {
+	from := cb.state
+	cb.state = to
+	if cb.opts.OnTransition != nil && from != to {
+		cb.opts.OnTransition(from, to)
+	}
+}
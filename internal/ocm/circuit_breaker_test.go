@@ -0,0 +1,67 @@
+package ocm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var transitions []string
+	cb := NewCircuitBreaker(
+		WithCircuitFailureThreshold(2),
+		WithCircuitBackoff(time.Second, 3*time.Second),
+		WithCircuitTransitionHandler(func(from, to CircuitState) {
+			transitions = append(transitions, string(from)+"->"+string(to))
+		}),
+	)
+	cb.opts.now = func() time.Time { return now }
+	// Maximum jitter.
+	cb.opts.float = func() float64 { return 1 }
+
+	// Rejected requests do not open the circuit.
+	require.NoError(t, cb.allow())
+	cb.record(false)
+	require.NoError(t, cb.allow())
+	cb.record(true)
+	require.NoError(t, cb.allow())
+	cb.record(true)
+	assert.Equal(t, CircuitStateOpen, cb.State())
+
+	retryAfter, ok := RetryAfter(cb.allow())
+	require.True(t, ok)
+	assert.Equal(t, 1200*time.Millisecond, retryAfter)
+
+	// Failing trial request doubles the backoff.
+	now = now.Add(retryAfter)
+	require.NoError(t, cb.allow())
+	assert.Equal(t, CircuitStateHalfOpen, cb.State())
+	assert.ErrorIs(t, cb.allow(), ErrCircuitOpen, "only a single trial request")
+	cb.record(true)
+	retryAfter, _ = RetryAfter(cb.allow())
+	assert.Equal(t, 2400*time.Millisecond, retryAfter)
+
+	// Backoff is capped.
+	now = now.Add(retryAfter)
+	require.NoError(t, cb.allow())
+	cb.record(true)
+	retryAfter, _ = RetryAfter(cb.allow())
+	assert.Equal(t, 3600*time.Millisecond, retryAfter)
+
+	// Succeeding trial request closes the circuit.
+	now = now.Add(retryAfter)
+	require.NoError(t, cb.allow())
+	cb.record(false)
+	assert.Equal(t, CircuitStateClosed, cb.State())
+	require.NoError(t, cb.allow())
+
+	assert.Equal(t, []string{
+		"closed->open",
+		"open->half-open", "half-open->open",
+		"open->half-open", "half-open->open",
+		"open->half-open", "half-open->closed",
+	}, transitions)
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/openshift/addon-operator/internal/version"
 )
@@ -27,6 +28,14 @@ func NewClient(ctx context.Context, opts ...Option) (*Client, error) {
 	}
 
 	c.httpClient = &http.Client{}
+	if c.opts.CircuitBreaker == nil {
+		c.opts.CircuitBreaker = NewCircuitBreaker()
+	}
+
+	// Internal ID and name are known upfront, e.g. discovered from a ConfigMap.
+	if len(c.opts.ClusterID) > 0 && len(c.opts.ClusterName) > 0 {
+		return c, nil
+	}
 
 	// Getting the Cluster Internal ID from the External ID
 	clusterInfo, err := c.GetCluster(ctx, ClusterGetRequest{})
@@ -37,17 +46,54 @@ func NewClient(ctx context.Context, opts ...Option) (*Client, error) {
 		return nil, fmt.Errorf("cluster %s not found", c.opts.ClusterExternalID)
 	}
 
-	c.opts.ClusterID = clusterInfo.Items[0].Id
-	c.opts.ClusterName = clusterInfo.Items[0].Name
+	if len(c.opts.ClusterID) == 0 {
+		c.opts.ClusterID = clusterInfo.Items[0].Id
+	}
+	if len(c.opts.ClusterName) == 0 {
+		c.opts.ClusterName = clusterInfo.Items[0].Name
+	}
 	return c, nil
 }
 
+// Endpoint groups the OCM API requests sharing a timeout.
+type Endpoint string
+
+const (
+	EndpointClusters           Endpoint = "clusters"
+	EndpointUpgradePolicies    Endpoint = "upgradePolicies"
+	EndpointAddOnStatus        Endpoint = "addonStatus"
+	EndpointAddOnInstallations Endpoint = "addonInstallations"
+	EndpointServiceLogs        Endpoint = "serviceLogs"
+)
+
+// Timeout of requests to endpoints without a configured timeout.
+const DefaultTimeout = 30 * time.Second
+
 type ClientOptions struct {
 	Endpoint          string
 	ClusterExternalID string
 	ClusterID         string
 	ClusterName       string
 	AccessToken       string
+	// Authenticates requests, takes precedence over AccessToken.
+	TokenSource TokenSource
+	// Timeouts of requests per endpoint.
+	Timeouts map[Endpoint]time.Duration
+	// Fails requests fast while OCM is unavailable.
+	// Defaults to a CircuitBreaker per Client.
+	CircuitBreaker *CircuitBreaker
+	// Records recent requests, when set.
+	AuditLog *AuditLog
+	// Called with each request, e.g. to record metrics.
+	// The status code is 0, if no response was received.
+	OnRequest func(endpoint Endpoint, method string, statusCode int, latency time.Duration)
+}
+
+func (o ClientOptions) timeout(endpoint Endpoint) time.Duration {
+	if timeout, ok := o.Timeouts[endpoint]; ok && timeout > 0 {
+		return timeout
+	}
+	return DefaultTimeout
 }
 
 type Option func(o *ClientOptions)
@@ -65,12 +111,77 @@ func WithClusterExternalID(clusterExternalID string) Option {
 	}
 }
 
+// Sets the internal OCM cluster ID, instead of looking it up by the external ID.
+func WithClusterID(clusterID string) Option {
+	return func(o *ClientOptions) {
+		o.ClusterID = clusterID
+	}
+}
+
+// Sets the name of the cluster in OCM, instead of looking it up by the external ID.
+func WithClusterName(clusterName string) Option {
+	return func(o *ClientOptions) {
+		o.ClusterName = clusterName
+	}
+}
+
 func WithAccessToken(accessToken string) Option {
 	return func(o *ClientOptions) {
 		o.AccessToken = accessToken
 	}
 }
 
+// Authenticates requests with the given TokenSource, instead of a static access token.
+func WithTokenSource(ts TokenSource) Option {
+	return func(o *ClientOptions) {
+		o.TokenSource = ts
+	}
+}
+
+func WithTimeout(endpoint Endpoint, timeout time.Duration) Option {
+	return func(o *ClientOptions) {
+		if o.Timeouts == nil {
+			o.Timeouts = map[Endpoint]time.Duration{}
+		}
+		o.Timeouts[endpoint] = timeout
+	}
+}
+
+// Shares the given CircuitBreaker, to keep its state when the Client is recreated.
+func WithCircuitBreaker(cb *CircuitBreaker) Option {
+	return func(o *ClientOptions) {
+		o.CircuitBreaker = cb
+	}
+}
+
+// Records requests in the given AuditLog, sharing it across recreated Clients.
+func WithAuditLog(l *AuditLog) Option {
+	return func(o *ClientOptions) {
+		o.AuditLog = l
+	}
+}
+
+func WithRequestHandler(
+	onRequest func(endpoint Endpoint, method string, statusCode int, latency time.Duration),
+) Option {
+	return func(o *ClientOptions) {
+		o.OnRequest = onRequest
+	}
+}
+
+func (c *Client) onRequest(audit AuditEntry) {
+	if c.opts.OnRequest != nil {
+		c.opts.OnRequest(audit.Endpoint, audit.Method, audit.StatusCode, audit.Latency)
+	}
+}
+
+func (c *Client) tokenSource() TokenSource {
+	if c.opts.TokenSource != nil {
+		return c.opts.TokenSource
+	}
+	return NewAccessTokenSource(c.opts.AccessToken)
+}
+
 type OCMError struct {
 	StatusCode int    `json:"-"`
 	Code       string `json:"code"`
@@ -83,6 +194,7 @@ func (e OCMError) Error() string {
 
 func (c *Client) do(
 	ctx context.Context,
+	endpoint Endpoint,
 	httpMethod string,
 	path string,
 	params url.Values,
@@ -98,13 +210,17 @@ func (c *Client) do(
 	})
 
 	// Payload
-	var resBody io.Reader
+	var (
+		reqPayload []byte
+		resBody    io.Reader
+	)
 	if payload != nil {
 		j, err := json.Marshal(payload)
 		if err != nil {
 			return fmt.Errorf("marshaling json: %w", err)
 		}
 
+		reqPayload = j
 		resBody = bytes.NewBuffer(j)
 	}
 
@@ -115,30 +231,60 @@ func (c *Client) do(
 		fullUrl = reqURL.String()
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, c.opts.timeout(endpoint))
+	defer cancel()
+
 	httpReq, err := http.NewRequestWithContext(ctx, httpMethod, fullUrl, resBody)
 	if err != nil {
 		return fmt.Errorf("creating http request: %w", err)
 	}
 
 	// Headers
-	httpReq.Header.Add("Authorization", fmt.Sprintf(
-		"AccessToken %s:%s", c.opts.ClusterID, c.opts.AccessToken))
+	authorization, err := c.tokenSource().Authorization(ctx, c.opts.ClusterID)
+	if err != nil {
+		return fmt.Errorf("authenticating request: %w", err)
+	}
+	httpReq.Header.Add("Authorization", authorization)
 	httpReq.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
 	httpReq.Header.Add("Content-Type", "application/json")
 
+	if err := c.opts.CircuitBreaker.allow(); err != nil {
+		return err
+	}
+	audit := AuditEntry{
+		Time:     time.Now(),
+		Endpoint: endpoint,
+		Method:   httpMethod,
+		Path:     httpReq.URL.RequestURI(),
+	}
 	httpRes, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		c.opts.CircuitBreaker.record(true)
+		audit.Latency = time.Since(audit.Time)
+		audit.Error = err.Error()
+		c.opts.AuditLog.record(audit, reqPayload, nil)
+		c.onRequest(audit)
 		return fmt.Errorf("executing http request: %w", err)
 	}
 	defer httpRes.Body.Close()
+	// Only OCM being unavailable opens the circuit, not rejected requests.
+	c.opts.CircuitBreaker.record(httpRes.StatusCode >= http.StatusInternalServerError ||
+		httpRes.StatusCode == http.StatusTooManyRequests)
+
+	body, err := ioutil.ReadAll(httpRes.Body)
+	audit.StatusCode = httpRes.StatusCode
+	audit.Latency = time.Since(audit.Time)
+	if err != nil {
+		audit.Error = err.Error()
+	}
+	c.opts.AuditLog.record(audit, reqPayload, body)
+	c.onRequest(audit)
+	if err != nil {
+		return fmt.Errorf("reading response body %s: %w", fullUrl, err)
+	}
 
 	// HTTP Error handling
 	if httpRes.StatusCode >= 400 && httpRes.StatusCode <= 599 {
-		body, err := ioutil.ReadAll(httpRes.Body)
-		if err != nil {
-			return fmt.Errorf("reading error response body %s: %w", fullUrl, err)
-		}
-
 		var ocmErr OCMError
 		if err := json.Unmarshal(body, &ocmErr); err != nil {
 			return fmt.Errorf(
@@ -150,11 +296,6 @@ func (c *Client) do(
 
 	// Read response
 	if result != nil {
-		body, err := ioutil.ReadAll(httpRes.Body)
-		if err != nil {
-			return fmt.Errorf("reading response body %s: %w", fullUrl, err)
-		}
-
 		if err := json.Unmarshal(body, result); err != nil {
 			return fmt.Errorf("unmarshal json response %s: %w", fullUrl, err)
 		}
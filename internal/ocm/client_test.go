@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,7 +95,7 @@ func TestClientDo_Success(t *testing.T) {
 			ctx := context.Background()
 
 			err := c.do(
-				ctx, test.method, test.path, test.params, test.payload, test.response)
+				ctx, EndpointClusters, test.method, test.path, test.params, test.payload, test.response)
 			require.NoError(t, err)
 
 			assert.Equal(t, test.method, recordedHttpRequest.Method)
@@ -139,6 +140,144 @@ func TestClientDo_Error(t *testing.T) {
 	require.NoError(t, ocmClientError)
 
 	err := c.do(
-		ctx, http.MethodPatch, "/broken", nil, nil, nil)
+		ctx, EndpointClusters, http.MethodPatch, "/broken", nil, nil, nil)
 	assert.EqualError(t, err, "HTTP 500: swordfish: olm dance")
 }
+
+func TestClientDo_Timeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/proxy/apis/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+			return
+		}
+		<-r.Context().Done()
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+
+	c, ocmClientError := NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL+"/proxy/apis"),
+		WithTimeout(EndpointUpgradePolicies, 10*time.Millisecond),
+	)
+	require.NoError(t, ocmClientError)
+
+	err := c.do(
+		ctx, EndpointUpgradePolicies, http.MethodGet, "/slow", nil, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClientDo_CircuitBreaker(t *testing.T) {
+	var requests int
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/proxy/apis/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+			return
+		}
+		requests++
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(rw, `{"code":"unavailable","reason":"maintenance"}`)
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+
+	c, ocmClientError := NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL+"/proxy/apis"),
+		WithCircuitBreaker(NewCircuitBreaker(WithCircuitFailureThreshold(2))),
+	)
+	require.NoError(t, ocmClientError)
+
+	for i := 0; i < 2; i++ {
+		err := c.do(ctx, EndpointUpgradePolicies, http.MethodGet, "/down", nil, nil, nil)
+		assert.EqualError(t, err, "HTTP 503: unavailable: maintenance")
+	}
+
+	err := c.do(ctx, EndpointUpgradePolicies, http.MethodGet, "/down", nil, nil, nil)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	retryAfter, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Greater(t, retryAfter, time.Duration(0))
+	assert.Equal(t, 2, requests)
+}
+
+func TestNewClient_ClusterIDPreset(t *testing.T) {
+	var clusterLookups int
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/clusters_mgmt/v1/clusters" {
+			clusterLookups++
+		}
+		fmt.Fprintln(rw, clustersMockAPIResponseBody)
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+
+	c, err := NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithClusterID("internal"),
+		WithClusterName("name"),
+		WithEndpoint(s.URL),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 0, clusterLookups)
+
+	id, name := c.GetClusterIDAndName()
+	assert.Equal(t, "internal", id)
+	assert.Equal(t, "name", name)
+
+	// Without a name, the cluster is still looked up, keeping the preset ID.
+	c, err = NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithClusterID("internal"),
+		WithEndpoint(s.URL),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, clusterLookups)
+
+	id, _ = c.GetClusterIDAndName()
+	assert.Equal(t, "internal", id)
+}
+
+func TestClientDo_RequestHandler(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/proxy/apis/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+			return
+		}
+		rw.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(rw, `{"code":"not-found","reason":"no upgrade policy"}`)
+	}))
+	defer s.Close()
+
+	type request struct {
+		Endpoint   Endpoint
+		Method     string
+		StatusCode int
+	}
+	var requests []request
+	c, ocmClientError := NewClient(
+		context.Background(),
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL+"/proxy/apis"),
+		WithRequestHandler(func(endpoint Endpoint, method string, statusCode int, latency time.Duration) {
+			assert.NotZero(t, latency)
+			requests = append(requests, request{endpoint, method, statusCode})
+		}),
+	)
+	require.NoError(t, ocmClientError)
+
+	err := c.do(context.Background(), EndpointUpgradePolicies, http.MethodGet, "/missing", nil, nil, nil)
+	require.Error(t, err)
+
+	assert.Equal(t, []request{
+		{EndpointClusters, http.MethodGet, http.StatusOK},
+		{EndpointUpgradePolicies, http.MethodGet, http.StatusNotFound},
+	}, requests)
+}
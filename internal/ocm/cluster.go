@@ -31,7 +31,7 @@ func (c *Client) GetCluster(
 	urlParams.Add("search",
 		fmt.Sprintf("external_id = '%s'", c.opts.ClusterExternalID))
 
-	return res, c.do(ctx, http.MethodGet, fmt.Sprintf(
+	return res, c.do(ctx, EndpointClusters, http.MethodGet, fmt.Sprintf(
 		"/api/clusters_mgmt/v1/clusters",
 	),
 		urlParams,
@@ -79,3 +79,30 @@ func (c *Client) GetAddOnStatus(
 	return args.Get(0).(ocm.AddOnStatusResponse),
 		args.Error(1)
 }
+
+func (c *Client) PatchAddOnInstallation(
+	ctx context.Context,
+	req ocm.AddOnInstallationPatchRequest,
+) (ocm.AddOnInstallationPatchResponse, error) {
+	args := c.Called(ctx, req)
+	return args.Get(0).(ocm.AddOnInstallationPatchResponse),
+		args.Error(1)
+}
+
+func (c *Client) GetAddOnInstallation(
+	ctx context.Context,
+	req ocm.AddOnInstallationGetRequest,
+) (ocm.AddOnInstallationGetResponse, error) {
+	args := c.Called(ctx, req)
+	return args.Get(0).(ocm.AddOnInstallationGetResponse),
+		args.Error(1)
+}
+
+func (c *Client) PostServiceLog(
+	ctx context.Context,
+	req ocm.ServiceLogPostRequest,
+) (ocm.ServiceLogPostResponse, error) {
+	args := c.Called(ctx, req)
+	return args.Get(0).(ocm.ServiceLogPostResponse),
+		args.Error(1)
+}
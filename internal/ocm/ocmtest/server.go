@@ -0,0 +1,305 @@
+package ocmtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+// Server is an in-process mock of the OCM API, serving the clusters,
+// upgrade policy state and addon status endpoints from memory.
+// Responses can be programmed per request and all requests are recorded,
+// so tests can exercise a real ocm.Client without reaching OCM.
+// Every cluster external ID resolves to MockClusterId.
+type Server struct {
+	*httptest.Server
+
+	mux             sync.Mutex
+	upgradePolicies map[string]ocm.UpgradePolicyGetResponse
+	addonStatuses   map[string]ocm.AddOnStatusResponse
+	responses       map[requestKey][]Response
+	requests        []RecordedRequest
+}
+
+// Response programmed to be served instead of the default behavior of an endpoint.
+type Response struct {
+	StatusCode int
+	// JSON body, e.g. an OCM error `{"code":"...","reason":"..."}`.
+	Body string
+}
+
+// RecordedRequest is a request received by the Server.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+type requestKey struct {
+	method, path string
+}
+
+// Starts a new Server. Callers must Close it.
+func NewServer() *Server {
+	s := &Server{
+		upgradePolicies: map[string]ocm.UpgradePolicyGetResponse{},
+		addonStatuses:   map[string]ocm.AddOnStatusResponse{},
+		responses:       map[requestKey][]Response{},
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/api/clusters_mgmt/v1/clusters", s.handleClusters).
+		Methods(http.MethodGet)
+	r.HandleFunc("/api/clusters_mgmt/v1/clusters/{cluster_id}/addon_upgrade_policies/{upgrade_policy_id}/state",
+		s.handleUpgradePolicyState).
+		Methods(http.MethodGet, http.MethodPatch)
+	r.HandleFunc("/api/addons_mgmt/v1/clusters/{cluster_id}/status", s.handleAddOnStatusCreate).
+		Methods(http.MethodPost)
+	r.HandleFunc("/api/addons_mgmt/v1/clusters/{cluster_id}/status/{addon_id}", s.handleAddOnStatus).
+		Methods(http.MethodGet, http.MethodPatch)
+	r.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusNotFound, "not found", "endpoint not mocked")
+	})
+	r.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed", "method not mocked")
+	})
+
+	s.Server = httptest.NewServer(s.record(r))
+	return s
+}
+
+// Path of the upgrade policy state endpoint of the mock cluster.
+func UpgradePolicyStatePath(upgradePolicyID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/addon_upgrade_policies/%s/state",
+		MockClusterId, upgradePolicyID)
+}
+
+// Path of the addon status endpoint of the mock cluster.
+func AddOnStatusPath(addonID string) string {
+	return fmt.Sprintf("/api/addons_mgmt/v1/clusters/%s/status/%s", MockClusterId, addonID)
+}
+
+// Programs the responses to the next requests with the given method and path.
+// Each response is served once and in order, before the endpoint falls back to its default behavior.
+func (s *Server) Respond(method, path string, responses ...Response) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	key := requestKey{method: method, path: path}
+	s.responses[key] = append(s.responses[key], responses...)
+}
+
+// Sets the state of an upgrade policy, e.g. to approve it.
+func (s *Server) SetUpgradePolicyState(upgradePolicyID string, state ocm.UpgradePolicyGetResponse) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.upgradePolicies[upgradePolicyID] = state
+}
+
+// Returns the current state of an upgrade policy.
+func (s *Server) UpgradePolicyState(upgradePolicyID string) (ocm.UpgradePolicyGetResponse, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	state, ok := s.upgradePolicies[upgradePolicyID]
+	return state, ok
+}
+
+// Sets the status of an addon.
+func (s *Server) SetAddOnStatus(addonID string, status ocm.AddOnStatusResponse) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	status.AddonID = addonID
+	s.addonStatuses[addonID] = status
+}
+
+// Returns the current status of an addon.
+func (s *Server) AddOnStatus(addonID string) (ocm.AddOnStatusResponse, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	status, ok := s.addonStatuses[addonID]
+	return status, ok
+}
+
+// Returns all requests received so far.
+func (s *Server) Requests() []RecordedRequest {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	requests := make([]RecordedRequest, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// Returns the requests received so far with the given method and path.
+func (s *Server) RequestsTo(method, path string) []RecordedRequest {
+	var requests []RecordedRequest
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			requests = append(requests, req)
+		}
+	}
+	return requests
+}
+
+// Records requests and serves programmed responses.
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal error", err.Error())
+			return
+		}
+
+		s.mux.Lock()
+		s.requests = append(s.requests, RecordedRequest{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.Query(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		})
+		key := requestKey{method: r.Method, path: r.URL.Path}
+		var (
+			res        Response
+			programmed bool
+		)
+		if queued := s.responses[key]; len(queued) > 0 {
+			res, programmed = queued[0], true
+			s.responses[key] = queued[1:]
+		}
+		s.mux.Unlock()
+
+		if programmed {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(res.StatusCode)
+			fmt.Fprintln(w, res.Body)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+var externalIDSearch = regexp.MustCompile(`'.*'`)
+
+func (s *Server) handleClusters(w http.ResponseWriter, r *http.Request) {
+	// e.g. external_id = 'a440b136-b2d6-406b-a884-fca2d62cd170'
+	match := externalIDSearch.FindString(r.URL.Query().Get("search"))
+	if len(match) == 0 {
+		writeJSON(w, http.StatusOK, ocm.ClusterGetResponse{Items: []ocm.Cluster{}})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ocm.ClusterGetResponse{
+		Items: []ocm.Cluster{{
+			Id:         MockClusterId,
+			Name:       MockClusterName,
+			ExternalId: strings.Trim(match, "'"),
+		}},
+	})
+}
+
+func (s *Server) handleUpgradePolicyState(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["upgrade_policy_id"]
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	switch r.Method {
+	case http.MethodPatch:
+		var req ocm.UpgradePolicyPatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "bad request", err.Error())
+			return
+		}
+		s.upgradePolicies[id] = ocm.UpgradePolicyGetResponse{
+			Value:       req.Value,
+			Description: req.Description,
+		}
+		writeJSON(w, http.StatusOK, ocm.UpgradePolicyPatchResponse{})
+
+	default:
+		state, ok := s.upgradePolicies[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "not found", "upgrade policy not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, state)
+	}
+}
+
+func (s *Server) handleAddOnStatusCreate(w http.ResponseWriter, r *http.Request) {
+	var req ocm.AddOnStatusPostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "bad request", err.Error())
+		return
+	}
+	if len(req.AddonID) == 0 {
+		writeError(w, http.StatusBadRequest, "error", "addonID missing")
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	status := ocm.AddOnStatusResponse{
+		AddonID:          req.AddonID,
+		CorrelationID:    req.CorrelationID,
+		StatusConditions: req.StatusConditions,
+	}
+	s.addonStatuses[req.AddonID] = status
+	writeJSON(w, http.StatusCreated, status)
+}
+
+func (s *Server) handleAddOnStatus(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["addon_id"]
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	status, ok := s.addonStatuses[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found", "addon status not found")
+		return
+	}
+
+	if r.Method == http.MethodPatch {
+		var req ocm.AddOnStatusPatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "bad request", err.Error())
+			return
+		}
+		status.CorrelationID = req.CorrelationID
+		status.StatusConditions = req.StatusConditions
+		s.addonStatuses[id] = status
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, reason string) {
+	writeJSON(w, statusCode, ocm.OCMError{Code: code, Reason: reason})
+}
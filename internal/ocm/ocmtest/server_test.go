@@ -0,0 +1,116 @@
+package ocmtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/ocm"
+)
+
+func newTestClient(t *testing.T, s *Server) *ocm.Client {
+	t.Helper()
+
+	c, err := ocm.NewClient(
+		context.Background(),
+		ocm.WithEndpoint(s.URL),
+		ocm.WithClusterExternalID("123"),
+		ocm.WithAccessToken("token"),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func TestServer_UpgradePolicyState(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	id, name := c.GetClusterIDAndName()
+	assert.Equal(t, MockClusterId, id)
+	assert.Equal(t, MockClusterName, name)
+
+	_, err := c.GetUpgradePolicy(ctx, ocm.UpgradePolicyGetRequest{ID: "policy"})
+	var ocmErr ocm.OCMError
+	require.True(t, errors.As(err, &ocmErr))
+	assert.Equal(t, http.StatusNotFound, ocmErr.StatusCode)
+
+	s.SetUpgradePolicyState("policy", ocm.UpgradePolicyGetResponse{Value: ocm.UpgradePolicyValueScheduled})
+	res, err := c.GetUpgradePolicy(ctx, ocm.UpgradePolicyGetRequest{ID: "policy"})
+	require.NoError(t, err)
+	assert.Equal(t, ocm.UpgradePolicyValueScheduled, res.Value)
+
+	_, err = c.PatchUpgradePolicy(ctx, ocm.UpgradePolicyPatchRequest{
+		ID: "policy", Value: ocm.UpgradePolicyValueStarted, Description: "Upgrading.",
+	})
+	require.NoError(t, err)
+	state, ok := s.UpgradePolicyState("policy")
+	require.True(t, ok)
+	assert.Equal(t, ocm.UpgradePolicyGetResponse{
+		Value: ocm.UpgradePolicyValueStarted, Description: "Upgrading.",
+	}, state)
+
+	patches := s.RequestsTo(http.MethodPatch, UpgradePolicyStatePath("policy"))
+	require.Len(t, patches, 1)
+	assert.JSONEq(t, `{"id":"policy","value":"started","description":"Upgrading."}`, string(patches[0].Body))
+	assert.Equal(t, "AccessToken "+MockClusterId+":token", patches[0].Header.Get("Authorization"))
+}
+
+func TestServer_AddOnStatus(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	conditions := []addonsv1alpha1.AddOnStatusCondition{{StatusType: "Available", StatusValue: "True"}}
+	_, err := c.PostAddOnStatus(ctx, ocm.AddOnStatusPostRequest{
+		AddonID: "addon", CorrelationID: "1", StatusConditions: conditions,
+	})
+	require.NoError(t, err)
+
+	_, err = c.PatchAddOnStatus(ctx, "addon", ocm.AddOnStatusPatchRequest{CorrelationID: "2"})
+	require.NoError(t, err)
+
+	res, err := c.GetAddOnStatus(ctx, "addon")
+	require.NoError(t, err)
+	assert.Equal(t, "addon", res.AddonID)
+	assert.Equal(t, "2", res.CorrelationID)
+
+	status, ok := s.AddOnStatus("addon")
+	require.True(t, ok)
+	assert.Equal(t, "2", status.CorrelationID)
+}
+
+func TestServer_Respond(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	s.SetAddOnStatus("addon", ocm.AddOnStatusResponse{CorrelationID: "1"})
+	s.Respond(http.MethodGet, AddOnStatusPath("addon"), Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       `{"code":"unavailable","reason":"maintenance"}`,
+	})
+
+	_, err := c.GetAddOnStatus(ctx, "addon")
+	var ocmErr ocm.OCMError
+	require.True(t, errors.As(err, &ocmErr))
+	assert.Equal(t, http.StatusServiceUnavailable, ocmErr.StatusCode)
+	assert.Equal(t, "maintenance", ocmErr.Reason)
+
+	// Programmed responses are served once.
+	res, err := c.GetAddOnStatus(ctx, "addon")
+	require.NoError(t, err)
+	assert.Equal(t, "1", res.CorrelationID)
+	assert.Len(t, s.RequestsTo(http.MethodGet, AddOnStatusPath("addon")), 2)
+}
@@ -0,0 +1,51 @@
+package ocm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type ServiceLogSeverity string
+
+const (
+	ServiceLogSeverityDebug    ServiceLogSeverity = "Debug"
+	ServiceLogSeverityInfo     ServiceLogSeverity = "Info"
+	ServiceLogSeverityWarning  ServiceLogSeverity = "Warning"
+	ServiceLogSeverityError    ServiceLogSeverity = "Error"
+	ServiceLogSeverityFatal    ServiceLogSeverity = "Fatal"
+	ServiceLogSeverityCritical ServiceLogSeverity = "Critical"
+)
+
+type ServiceLogPostRequest struct {
+	// UUID of the cluster the log entry belongs to.
+	// Defaults to the clusters external ID if left empty.
+	ClusterUUID string             `json:"cluster_uuid"`
+	Severity    ServiceLogSeverity `json:"severity"`
+	ServiceName string             `json:"service_name"`
+	Summary     string             `json:"summary"`
+	Description string             `json:"description"`
+	// Internal only entries are not visible to the cluster owner.
+	InternalOnly bool `json:"internal_only"`
+}
+
+type ServiceLogPostResponse struct {
+	ID string `json:"id"`
+}
+
+func (c *Client) PostServiceLog(
+	ctx context.Context,
+	req ServiceLogPostRequest,
+) (res ServiceLogPostResponse, err error) {
+	if len(req.ClusterUUID) == 0 {
+		req.ClusterUUID = c.opts.ClusterExternalID
+	}
+
+	urlParams := url.Values{}
+	return res, c.do(ctx, EndpointServiceLogs, http.MethodPost,
+		"api/service_logs/v1/cluster_logs",
+		urlParams,
+		req,
+		&res,
+	)
+}
@@ -0,0 +1,52 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+	ioutil "io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientPostServiceLog(t *testing.T) {
+	var (
+		recordedHttpRequest *http.Request
+		recordedBody        []byte
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		recordedHttpRequest = r
+		recordedBody, _ = ioutil.ReadAll(recordedHttpRequest.Body)
+		if r.URL.Path == "/proxy/apis/api/clusters_mgmt/v1/clusters" {
+			fmt.Fprintln(rw, clustersMockAPIResponseBody)
+		} else {
+			fmt.Fprintln(rw, `{"id":"log-1"}`)
+		}
+	}))
+	defer s.Close()
+
+	ctx := context.Background()
+
+	c, ocmClientError := NewClient(
+		ctx,
+		WithClusterExternalID("123"),
+		WithEndpoint(s.URL+"/proxy/apis"), // test existing path + trailing / handling
+	)
+	require.NoError(t, ocmClientError)
+
+	res, err := c.PostServiceLog(
+		ctx, ServiceLogPostRequest{
+			Severity:    ServiceLogSeverityCritical,
+			ServiceName: "reference-addon",
+			Summary:     "works",
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, "log-1", res.ID)
+	assert.Equal(t, http.MethodPost, recordedHttpRequest.Method)
+	assert.Equal(t, `{"cluster_uuid":"123","severity":"Critical","service_name":"reference-addon","summary":"works","description":"","internal_only":false}`, string(recordedBody))
+	assert.Equal(t, "/proxy/apis/api/service_logs/v1/cluster_logs", recordedHttpRequest.URL.Path)
+}
@@ -15,6 +15,9 @@ const (
 	UpgradePolicyValueScheduled UpgradePolicyValue = "scheduled"
 	UpgradePolicyValueStarted   UpgradePolicyValue = "started"
 	UpgradePolicyValueCompleted UpgradePolicyValue = "completed"
+	// Manual upgrades are approved or acknowledged in OCM, after being scheduled.
+	UpgradePolicyValueApproved     UpgradePolicyValue = "approved"
+	UpgradePolicyValueAcknowledged UpgradePolicyValue = "acknowledged"
 )
 
 type UpgradePolicyPatchRequest struct {
@@ -30,7 +33,7 @@ func (c *Client) PatchUpgradePolicy(
 	req UpgradePolicyPatchRequest,
 ) (res UpgradePolicyPatchResponse, err error) {
 	urlParams := url.Values{}
-	return res, c.do(ctx, http.MethodPatch, fmt.Sprintf(
+	return res, c.do(ctx, EndpointUpgradePolicies, http.MethodPatch, fmt.Sprintf(
 		"api/clusters_mgmt/v1/clusters/%s/addon_upgrade_policies/%s/state",
 		c.opts.ClusterID,
 		req.ID,
@@ -55,7 +58,7 @@ func (c *Client) GetUpgradePolicy(
 	req UpgradePolicyGetRequest,
 ) (res UpgradePolicyGetResponse, err error) {
 	urlParams := url.Values{}
-	return res, c.do(ctx, http.MethodGet, fmt.Sprintf(
+	return res, c.do(ctx, EndpointUpgradePolicies, http.MethodGet, fmt.Sprintf(
 		"api/clusters_mgmt/v1/clusters/%s/addon_upgrade_policies/%s/state",
 		c.opts.ClusterID,
 		req.ID,
@@ -0,0 +1,230 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/addon-operator/internal/version"
+)
+
+const (
+	defaultURL     = "https://api.pagerduty.com"
+	defaultTimeout = 10 * time.Second
+)
+
+// ErrNotFound is returned for objects unknown to PagerDuty,
+// e.g. services deleted in the PagerDuty UI.
+var ErrNotFound = errors.New("not found")
+
+// Client manages services using the PagerDuty REST API.
+type Client struct {
+	opts       ClientOptions
+	httpClient *http.Client
+}
+
+// Creates a new PagerDuty client with the given options.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		opts: ClientOptions{
+			URL:     defaultURL,
+			Timeout: defaultTimeout,
+		},
+	}
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	if len(c.opts.APIKey) == 0 {
+		return nil, errors.New("pagerduty API key must not be empty")
+	}
+	if _, err := url.Parse(c.opts.URL); err != nil {
+		return nil, fmt.Errorf("parsing pagerduty url: %w", err)
+	}
+	c.opts.URL = strings.TrimSuffix(c.opts.URL, "/")
+
+	c.httpClient = &http.Client{
+		Timeout: c.opts.Timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		},
+	}
+	return c, nil
+}
+
+type ClientOptions struct {
+	URL     string
+	APIKey  string
+	Timeout time.Duration
+}
+
+type Option func(o *ClientOptions)
+
+func WithURL(url string) Option {
+	return func(o *ClientOptions) {
+		o.URL = url
+	}
+}
+
+func WithAPIKey(key string) Option {
+	return func(o *ClientOptions) {
+		o.APIKey = key
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *ClientOptions) {
+		o.Timeout = timeout
+	}
+}
+
+type serviceEnvelope struct {
+	Service Service `json:"service"`
+}
+
+type integrationEnvelope struct {
+	Integration Integration `json:"integration"`
+}
+
+// Creates a new service, raising incidents for all events.
+func (c *Client) CreateService(ctx context.Context, req CreateServiceRequest) (Service, error) {
+	in := serviceEnvelope{Service: Service{
+		Name:        req.Name,
+		Description: req.Description,
+		EscalationPolicy: Reference{
+			ID:   req.EscalationPolicyID,
+			Type: escalationPolicyReferenceType,
+		},
+	}}
+	var out serviceEnvelope
+	err := c.do(ctx, http.MethodPost, "/services", in, &out)
+	return out.Service, err
+}
+
+// Returns the service with the given ID.
+func (c *Client) GetService(ctx context.Context, id string) (Service, error) {
+	var out serviceEnvelope
+	err := c.do(ctx, http.MethodGet, "/services/"+url.PathEscape(id), nil, &out)
+	return out.Service, err
+}
+
+type serviceListEnvelope struct {
+	Services []Service `json:"services"`
+	More     bool      `json:"more"`
+}
+
+// Page size when listing services.
+const listServicesLimit = 100
+
+// Returns the service with the given name, including its integrations.
+func (c *Client) FindService(ctx context.Context, name string) (Service, error) {
+	for offset := 0; ; offset += listServicesLimit {
+		query := url.Values{
+			"query":     {name},
+			"include[]": {"integrations"},
+			"limit":     {strconv.Itoa(listServicesLimit)},
+			"offset":    {strconv.Itoa(offset)},
+		}
+		var out serviceListEnvelope
+		if err := c.do(ctx, http.MethodGet, "/services?"+query.Encode(), nil, &out); err != nil {
+			return Service{}, err
+		}
+		// The query matches services by substring.
+		for _, service := range out.Services {
+			if service.Name == name {
+				return service, nil
+			}
+		}
+		if !out.More {
+			return Service{}, ErrNotFound
+		}
+	}
+}
+
+// Updates the service with the given ID.
+func (c *Client) UpdateService(ctx context.Context, id string, req UpdateServiceRequest) (Service, error) {
+	in := map[string]interface{}{
+		"service": map[string]interface{}{
+			"escalation_policy": Reference{
+				ID:   req.EscalationPolicyID,
+				Type: escalationPolicyReferenceType,
+			},
+		},
+	}
+	var out serviceEnvelope
+	err := c.do(ctx, http.MethodPut, "/services/"+url.PathEscape(id), in, &out)
+	return out.Service, err
+}
+
+// Deletes the service with the given ID, including its integrations.
+func (c *Client) DeleteService(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/services/"+url.PathEscape(id), nil, nil)
+}
+
+// Adds an Events API v2 integration to the service with the given ID.
+// The routing key of the integration is returned as IntegrationKey.
+func (c *Client) CreateEventsIntegration(ctx context.Context, serviceID, name string) (Integration, error) {
+	in := integrationEnvelope{Integration: Integration{
+		Type: eventsAPIV2IntegrationType,
+		Name: name,
+	}}
+	var out integrationEnvelope
+	err := c.do(ctx, http.MethodPost, "/services/"+url.PathEscape(serviceID)+"/integrations", in, &out)
+	return out.Integration, err
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		j, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("marshaling json: %w", err)
+		}
+		body = bytes.NewBuffer(j)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.opts.URL+path, body)
+	if err != nil {
+		return fmt.Errorf("creating http request: %w", err)
+	}
+	httpReq.Header.Add("Authorization", "Token token="+c.opts.APIKey)
+	httpReq.Header.Add("Accept", "application/vnd.pagerduty+json;version=2")
+	httpReq.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+	if in != nil {
+		httpReq.Header.Add("Content-Type", "application/json")
+	}
+
+	httpRes, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("executing http request: %w", err)
+	}
+	defer httpRes.Body.Close()
+
+	resBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+
+	if httpRes.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if httpRes.StatusCode < 200 || httpRes.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d: %s", httpRes.StatusCode, string(resBody))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resBody, out); err != nil {
+		return fmt.Errorf("unmarshal json response: %w", err)
+	}
+	return nil
+}
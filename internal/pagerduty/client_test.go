@@ -0,0 +1,111 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateService(t *testing.T) {
+	var (
+		recordedRequest serviceEnvelope
+		recordedAuth    string
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services", r.URL.Path)
+		recordedAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&recordedRequest)
+		fmt.Fprintln(rw, `{"service":{"id":"PSVC123","name":"addon-1","escalation_policy":{"id":"PEP123","type":"escalation_policy_reference"}}}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL+"/"), WithAPIKey("secret"))
+	require.NoError(t, err)
+
+	service, err := c.CreateService(context.Background(), CreateServiceRequest{
+		Name:               "addon-1",
+		EscalationPolicyID: "PEP123",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "PSVC123", service.ID)
+	assert.Equal(t, "Token token=secret", recordedAuth)
+	assert.Equal(t, "addon-1", recordedRequest.Service.Name)
+	assert.Equal(t, Reference{ID: "PEP123", Type: escalationPolicyReferenceType},
+		recordedRequest.Service.EscalationPolicy)
+}
+
+func TestClientCreateEventsIntegration(t *testing.T) {
+	var recordedRequest integrationEnvelope
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services/PSVC123/integrations", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&recordedRequest)
+		fmt.Fprintln(rw, `{"integration":{"id":"PINT123","type":"events_api_v2_inbound_integration","integration_key":"routing-key"}}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL), WithAPIKey("secret"))
+	require.NoError(t, err)
+
+	integration, err := c.CreateEventsIntegration(context.Background(), "PSVC123", "addon-operator")
+	require.NoError(t, err)
+
+	assert.Equal(t, "routing-key", integration.IntegrationKey)
+	assert.Equal(t, eventsAPIV2IntegrationType, recordedRequest.Integration.Type)
+}
+
+func TestClientGetService_NotFound(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL), WithAPIKey("secret"))
+	require.NoError(t, err)
+
+	_, err = c.GetService(context.Background(), "PSVC123")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClientFindService(t *testing.T) {
+	var offsets []string
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/services", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("query"), "addon-1 (cluster-1)")
+		assert.Equal(t, "integrations", r.URL.Query().Get("include[]"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		if r.URL.Query().Get("offset") == "0" {
+			fmt.Fprintln(rw, `{"services":[{"id":"PSVC1","name":"addon-1 (cluster-1) old"}],"more":true}`)
+			return
+		}
+		fmt.Fprintln(rw, `{"services":[{"id":"PSVC2","name":"addon-1 (cluster-1)","integrations":[`+
+			`{"id":"PINT1","type":"events_api_v2_inbound_integration","integration_key":"routing-key"}]}],"more":false}`)
+	}))
+	defer s.Close()
+
+	c, err := NewClient(WithURL(s.URL), WithAPIKey("secret"))
+	require.NoError(t, err)
+
+	service, err := c.FindService(context.Background(), "addon-1 (cluster-1)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "100"}, offsets)
+	assert.Equal(t, "PSVC2", service.ID)
+	require.Len(t, service.Integrations, 1)
+	assert.True(t, service.Integrations[0].IsEventsAPIV2())
+	assert.Equal(t, "routing-key", service.Integrations[0].IntegrationKey)
+
+	_, err = c.FindService(context.Background(), "addon-1 (cluster-1) new")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNewClient_MissingAPIKey(t *testing.T) {
+	_, err := NewClient()
+	require.Error(t, err)
+}
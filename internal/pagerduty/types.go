@@ -0,0 +1,51 @@
+package pagerduty
+
+// Service as returned by the PagerDuty REST API.
+type Service struct {
+	ID               string    `json:"id,omitempty"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	EscalationPolicy Reference `json:"escalation_policy"`
+	// One of "active", "warning", "critical", "maintenance" or "disabled".
+	Status string `json:"status,omitempty"`
+	// Only returned, when requested to be included.
+	Integrations []Integration `json:"integrations,omitempty"`
+}
+
+// Whether the integration receives events via the Events API v2.
+func (i Integration) IsEventsAPIV2() bool {
+	return i.Type == eventsAPIV2IntegrationType
+}
+
+// Integration of a service, receiving events.
+type Integration struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+	// Routing key events are sent with.
+	IntegrationKey string `json:"integration_key,omitempty"`
+}
+
+// Reference to another PagerDuty object.
+type Reference struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Request to create a new service.
+type CreateServiceRequest struct {
+	Name               string
+	Description        string
+	EscalationPolicyID string
+}
+
+// Request to update an existing service.
+type UpdateServiceRequest struct {
+	EscalationPolicyID string
+}
+
+const (
+	escalationPolicyReferenceType = "escalation_policy_reference"
+	// Integration receiving events via the Events API v2.
+	eventsAPIV2IntegrationType = "events_api_v2_inbound_integration"
+)
@@ -0,0 +1,40 @@
+// Package promrules validates prometheus alerting and recording rules
+// with the parser prometheus loads them with.
+package promrules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/prometheus/pkg/rulefmt"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Validate parses the given rule groups like prometheus does when loading
+// a rule file, checking group and rule names, PromQL expressions and templates.
+func Validate(groups []monv1.RuleGroup) error {
+	ruleFile := struct {
+		Groups []monv1.RuleGroup `json:"groups"`
+	}{
+		Groups: make([]monv1.RuleGroup, len(groups)),
+	}
+	for i := range groups {
+		ruleFile.Groups[i] = *groups[i].DeepCopy()
+		// Only understood by Thanos, unknown fields are rejected by prometheus.
+		ruleFile.Groups[i].PartialResponseStrategy = ""
+	}
+
+	content, err := yaml.Marshal(ruleFile)
+	if err != nil {
+		return fmt.Errorf("marshalling rule groups: %w", err)
+	}
+	if _, errs := rulefmt.Parse(content); len(errs) > 0 {
+		msgs := make([]string, len(errs))
+		for i, err := range errs {
+			msgs[i] = err.Error()
+		}
+		return fmt.Errorf("invalid rules: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
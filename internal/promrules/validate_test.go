@@ -0,0 +1,105 @@
+package promrules
+
+import (
+	"testing"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Groups      []monv1.RuleGroup
+		ExpectedErr string
+	}{
+		"alerting and recording rules": {
+			Groups: []monv1.RuleGroup{{
+				Name:                    "addon",
+				Interval:                "1m",
+				PartialResponseStrategy: "warn",
+				Rules: []monv1.Rule{
+					{
+						Record: "addon:http_requests:rate5m",
+						Expr:   intstr.FromString(`sum(rate(http_requests_total{job="addon"}[5m]))`),
+					},
+					{
+						Alert:  "AddonDown",
+						Expr:   intstr.FromString(`up{job="addon"} == 0`),
+						For:    "5m",
+						Labels: map[string]string{"severity": "critical"},
+						Annotations: map[string]string{
+							"summary": "{{ $labels.instance }} is down",
+						},
+					},
+				},
+			}},
+		},
+		"invalid expression": {
+			Groups: []monv1.RuleGroup{{
+				Name: "addon",
+				Rules: []monv1.Rule{{
+					Record: "addon:up:sum",
+					Expr:   intstr.FromString("sum(up"),
+				}},
+			}},
+			ExpectedErr: "invalid rules",
+		},
+		"invalid record name": {
+			Groups: []monv1.RuleGroup{{
+				Name: "addon",
+				Rules: []monv1.Rule{{
+					Record: "addon up",
+					Expr:   intstr.FromString("up"),
+				}},
+			}},
+			ExpectedErr: "invalid recording rule name",
+		},
+		"alert and record": {
+			Groups: []monv1.RuleGroup{{
+				Name: "addon",
+				Rules: []monv1.Rule{{
+					Record: "addon:up",
+					Alert:  "AddonDown",
+					Expr:   intstr.FromString("up == 0"),
+				}},
+			}},
+			ExpectedErr: "only one of 'record' and 'alert' must be set",
+		},
+		"invalid annotation template": {
+			Groups: []monv1.RuleGroup{{
+				Name: "addon",
+				Rules: []monv1.Rule{{
+					Alert:       "AddonDown",
+					Expr:        intstr.FromString("up == 0"),
+					Annotations: map[string]string{"summary": "{{ $labels.instance "},
+				}},
+			}},
+			ExpectedErr: "invalid rules",
+		},
+		"duplicate group": {
+			Groups: []monv1.RuleGroup{
+				{Name: "addon", Rules: []monv1.Rule{{Record: "a", Expr: intstr.FromString("up")}}},
+				{Name: "addon", Rules: []monv1.Rule{{Record: "b", Expr: intstr.FromString("up")}}},
+			},
+			ExpectedErr: "repeated in the same file",
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Validate(tc.Groups)
+			if len(tc.ExpectedErr) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.ExpectedErr)
+			}
+		})
+	}
+}
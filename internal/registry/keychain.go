@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Credentials to authenticate with a registry.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Credentials by registry host.
+type Keychain map[string]Credentials
+
+// Returns the credentials for the given registry host.
+func (k Keychain) credentialsFor(registry string) (Credentials, bool) {
+	if creds, ok := k[registry]; ok {
+		return creds, true
+	}
+	if registry == dockerHubRegistry || registry == dockerHubAPIRegistry {
+		for _, host := range []string{
+			dockerHubRegistry, dockerHubAPIRegistry, "index.docker.io", "index.docker.io/v1",
+		} {
+			if creds, ok := k[host]; ok {
+				return creds, true
+			}
+		}
+	}
+	return Credentials{}, false
+}
+
+// Merges the credentials of the other Keychain into this one,
+// keeping existing entries.
+func (k Keychain) Merge(other Keychain) {
+	for host, creds := range other {
+		if _, ok := k[host]; !ok {
+			k[host] = creds
+		}
+	}
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// Parses the contents of a .dockerconfigjson or legacy .dockercfg file.
+func KeychainFromDockerConfig(data []byte) (Keychain, error) {
+	var config struct {
+		Auths map[string]dockerConfigEntry `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unmarshal docker config: %w", err)
+	}
+	entries := config.Auths
+	if entries == nil {
+		// Legacy .dockercfg files contain the entries at the top level.
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("unmarshal docker config: %w", err)
+		}
+	}
+
+	keychain := Keychain{}
+	for host, entry := range entries {
+		creds := Credentials{Username: entry.Username, Password: entry.Password}
+		if len(entry.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("decoding auth of %s: %w", host, err)
+			}
+			user, pass, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth of %s", host)
+			}
+			creds = Credentials{Username: user, Password: pass}
+		}
+		keychain[strings.TrimPrefix(strings.TrimSuffix(host, "/"), "https://")] = creds
+	}
+	return keychain, nil
+}
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dockerHubRegistry    = "docker.io"
+	dockerHubAPIRegistry = "registry-1.docker.io"
+)
+
+// Reference to an image in a container registry.
+type Reference struct {
+	// Name of the image as given, without tag or digest.
+	Name string
+	// Host of the registry, e.g. quay.io.
+	Registry string
+	// Repository within the registry, e.g. osd-addons/reference-addon-index.
+	Repository string
+	// Tag of the image, defaults to latest.
+	Tag string
+	// Digest of the image, empty when referenced by tag.
+	Digest string
+}
+
+// Parses an image reference like quay.io/osd-addons/index:v1.0.0
+// or quay.io/osd-addons/index@sha256:....
+func ParseReference(image string) (Reference, error) {
+	if len(image) == 0 {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+
+	ref := Reference{Name: image}
+	if i := strings.Index(ref.Name, "@"); i >= 0 {
+		ref.Digest = ref.Name[i+1:]
+		ref.Name = ref.Name[:i]
+		if !strings.Contains(ref.Digest, ":") {
+			return Reference{}, fmt.Errorf("invalid digest in image reference %q", image)
+		}
+	}
+	// A colon after the last slash separates the tag,
+	// other colons belong to the registry port.
+	if i := strings.LastIndex(ref.Name, ":"); i > strings.LastIndex(ref.Name, "/") {
+		ref.Tag = ref.Name[i+1:]
+		ref.Name = ref.Name[:i]
+	}
+	if len(ref.Tag) == 0 && len(ref.Digest) == 0 {
+		ref.Tag = "latest"
+	}
+
+	ref.Registry, ref.Repository = dockerHubRegistry, ref.Name
+	if i := strings.Index(ref.Name, "/"); i >= 0 {
+		host := ref.Name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			ref.Registry, ref.Repository = host, ref.Name[i+1:]
+		}
+	}
+	if len(ref.Repository) == 0 {
+		return Reference{}, fmt.Errorf("missing repository in image reference %q", image)
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+	return ref, nil
+}
+
+// Returns the reference to the image by the given digest,
+// dropping its tag.
+func (r Reference) WithDigest(digest string) string {
+	return r.Name + "@" + digest
+}
+
+// Host serving the registry API.
+func (r Reference) apiHost() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	return r.Registry
+}
+
+// Tag or digest identifying the manifest of the image.
+func (r Reference) manifestReference() string {
+	if len(r.Digest) > 0 {
+		return r.Digest
+	}
+	return r.Tag
+}
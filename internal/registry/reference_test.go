@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	for image, expected := range map[string]Reference{
+		"quay.io/osd-addons/reference-addon-index:v1.0.0": {
+			Name:       "quay.io/osd-addons/reference-addon-index",
+			Registry:   "quay.io",
+			Repository: "osd-addons/reference-addon-index",
+			Tag:        "v1.0.0",
+		},
+		"localhost:5000/index": {
+			Name:       "localhost:5000/index",
+			Registry:   "localhost:5000",
+			Repository: "index",
+			Tag:        "latest",
+		},
+		"busybox": {
+			Name:       "busybox",
+			Registry:   "docker.io",
+			Repository: "library/busybox",
+			Tag:        "latest",
+		},
+		"quay.io/osd-addons/index:v1@sha256:abc": {
+			Name:       "quay.io/osd-addons/index",
+			Registry:   "quay.io",
+			Repository: "osd-addons/index",
+			Tag:        "v1",
+			Digest:     "sha256:abc",
+		},
+	} {
+		ref, err := ParseReference(image)
+		require.NoError(t, err, image)
+		assert.Equal(t, expected, ref, image)
+	}
+
+	for _, image := range []string{"", "quay.io/index@abc"} {
+		_, err := ParseReference(image)
+		assert.Error(t, err, image)
+	}
+}
+
+func TestReferenceWithDigest(t *testing.T) {
+	t.Parallel()
+
+	ref, err := ParseReference("quay.io/osd-addons/index:v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/osd-addons/index@sha256:abc", ref.WithDigest("sha256:abc"))
+}
@@ -0,0 +1,235 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/openshift/addon-operator/internal/version"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Media types of manifests and manifest lists accepted when resolving digests.
+// Manifest lists are preferred, so multi-arch images resolve to the digest
+// of the list instead of the manifest of a single platform.
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// DigestResolver resolves image tags to digests,
+// using HEAD requests against the registry API.
+type DigestResolver struct {
+	httpClient *http.Client
+}
+
+// Creates a new DigestResolver with the given options.
+func NewDigestResolver(opts ...Option) *DigestResolver {
+	o := ResolverOptions{
+		Timeout: defaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	httpClient := o.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: o.Timeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+			},
+		}
+	}
+	return &DigestResolver{httpClient: httpClient}
+}
+
+type ResolverOptions struct {
+	Timeout    time.Duration
+	HTTPClient *http.Client
+}
+
+type Option func(o *ResolverOptions)
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *ResolverOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// Overrides the http.Client used to talk to registries.
+func WithHTTPClient(c *http.Client) Option {
+	return func(o *ResolverOptions) {
+		o.HTTPClient = c
+	}
+}
+
+// Returns the digest the given image reference currently points to.
+// Credentials from the keychain are used when the registry requests authentication.
+func (r *DigestResolver) ResolveDigest(
+	ctx context.Context, image string, keychain Keychain,
+) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+	creds, hasCreds := keychain.credentialsFor(ref.Registry)
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s",
+		ref.apiHost(), ref.Repository, ref.manifestReference())
+	res, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized {
+		challenge := res.Header.Get("WWW-Authenticate")
+		var authorization string
+		switch scheme, params := parseChallenge(challenge); scheme {
+		case "bearer":
+			token, err := r.fetchToken(ctx, params, ref.Repository, creds, hasCreds)
+			if err != nil {
+				return "", err
+			}
+			authorization = "Bearer " + token
+		case "basic":
+			if !hasCreds {
+				return "", fmt.Errorf("registry %s requires credentials", ref.Registry)
+			}
+			authorization = "Basic " + base64.StdEncoding.EncodeToString(
+				[]byte(creds.Username+":"+creds.Password))
+		default:
+			return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+		}
+
+		if res, err = r.headManifest(ctx, manifestURL, authorization); err != nil {
+			return "", err
+		}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d resolving %s", res.StatusCode, image)
+	}
+	digest := res.Header.Get("Docker-Content-Digest")
+	if len(digest) == 0 {
+		return "", fmt.Errorf("registry %s did not return a digest for %s", ref.Registry, image)
+	}
+	return digest, nil
+}
+
+func (r *DigestResolver) headManifest(
+	ctx context.Context, manifestURL, authorization string,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating http request: %w", err)
+	}
+	req.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+	req.Header.Add("Accept", strings.Join(manifestMediaTypes, ", "))
+	if len(authorization) > 0 {
+		req.Header.Add("Authorization", authorization)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing http request: %w", err)
+	}
+	// HEAD responses have no body.
+	res.Body.Close()
+	return res, nil
+}
+
+// Fetches a pull token for the repository from the token service
+// named in a bearer challenge.
+func (r *DigestResolver) fetchToken(
+	ctx context.Context, params map[string]string,
+	repository string, creds Credentials, hasCreds bool,
+) (string, error) {
+	realm := params["realm"]
+	if len(realm) == 0 {
+		return "", errors.New("bearer challenge without realm")
+	}
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("parsing token realm: %w", err)
+	}
+	query := tokenURL.Query()
+	if service := params["service"]; len(service) > 0 {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if len(scope) == 0 {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tokenURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("creating http request: %w", err)
+	}
+	req.Header.Add("User-Agent", fmt.Sprintf("AddonOperator/%s", version.Version))
+	if hasCreds {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+
+	res, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("executing http request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading response body: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d fetching registry token: %s", res.StatusCode, string(body))
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("unmarshal json response: %w", err)
+	}
+	if len(token.Token) > 0 {
+		return token.Token, nil
+	}
+	if len(token.AccessToken) > 0 {
+		return token.AccessToken, nil
+	}
+	return "", errors.New("registry token response without token")
+}
+
+// Parses a WWW-Authenticate header like
+// `Bearer realm="https://quay.io/v2/auth",service="quay.io"`
+// into its lower-cased scheme and parameters.
+func parseChallenge(challenge string) (scheme string, params map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params = map[string]string{}
+	for len(rest) > 0 {
+		var key, value string
+		key, rest, _ = strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if strings.HasPrefix(rest, `"`) {
+			value, rest, _ = strings.Cut(rest[1:], `"`)
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		if len(key) > 0 {
+			params[strings.ToLower(strings.TrimSpace(key))] = value
+		}
+	}
+	return strings.ToLower(scheme), params
+}
@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestResolver(t *testing.T) {
+	t.Parallel()
+
+	const digest = "sha256:04864220677b2ed6244f2e0d421166df908986700647595ffdb6fd9ca4e5098a"
+	var s *httptest.Server
+	s = httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			user, pass, ok := r.BasicAuth()
+			if !ok || user != "robot" || pass != "secret" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Query().Get("scope") != "repository:osd-addons/index:pull" {
+				rw.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprintln(rw, `{"token":"t0ken"}`)
+
+		case "/v2/osd-addons/index/manifests/v1.0.0":
+			if r.Method != http.MethodHead {
+				rw.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			if r.Header.Get("Authorization") != "Bearer t0ken" {
+				rw.Header().Set("WWW-Authenticate", fmt.Sprintf(
+					`Bearer realm="%s/token",service="registry"`, s.URL))
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			rw.Header().Set("Docker-Content-Digest", digest)
+
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	host := strings.TrimPrefix(s.URL, "https://")
+	r := NewDigestResolver(WithHTTPClient(s.Client()))
+
+	t.Run("resolves with credentials", func(t *testing.T) {
+		t.Parallel()
+
+		resolved, err := r.ResolveDigest(context.Background(), host+"/osd-addons/index:v1.0.0",
+			Keychain{host: {Username: "robot", Password: "secret"}})
+		require.NoError(t, err)
+		assert.Equal(t, digest, resolved)
+	})
+
+	t.Run("fails without credentials", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := r.ResolveDigest(context.Background(), host+"/osd-addons/index:v1.0.0", nil)
+		require.Error(t, err)
+	})
+
+	t.Run("fails for unknown tag", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := r.ResolveDigest(context.Background(), host+"/osd-addons/index:v2.0.0", nil)
+		require.EqualError(t, err, fmt.Sprintf("HTTP 404 resolving %s/osd-addons/index:v2.0.0", host))
+	})
+}
+
+func TestKeychainFromDockerConfig(t *testing.T) {
+	t.Parallel()
+
+	// robot:secret
+	keychain, err := KeychainFromDockerConfig([]byte(
+		`{"auths":{"quay.io":{"auth":"cm9ib3Q6c2VjcmV0"},"https://index.docker.io/v1/":{"username":"u","password":"p"}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, Keychain{
+		"quay.io":            {Username: "robot", Password: "secret"},
+		"index.docker.io/v1": {Username: "u", Password: "p"},
+	}, keychain)
+
+	creds, ok := keychain.credentialsFor(dockerHubRegistry)
+	assert.True(t, ok)
+	assert.Equal(t, Credentials{Username: "u", Password: "p"}, creds)
+
+	legacy, err := KeychainFromDockerConfig([]byte(`{"quay.io":{"auth":"cm9ib3Q6c2VjcmV0"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, Keychain{"quay.io": {Username: "robot", Password: "secret"}}, legacy)
+}
+
+func TestParseChallenge(t *testing.T) {
+	t.Parallel()
+
+	scheme, params := parseChallenge(
+		`Bearer realm="https://quay.io/v2/auth",service="quay.io",scope="repository:a/b:pull"`)
+	assert.Equal(t, "bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://quay.io/v2/auth",
+		"service": "quay.io",
+		"scope":   "repository:a/b:pull",
+	}, params)
+}
@@ -0,0 +1,173 @@
+package runtimeconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Name of the optional ConfigMap in the addon-operator namespace
+// holding the runtime configuration of the addon-operator.
+const ConfigMapName = "addon-operator-config"
+
+// Keys supported in the runtime configuration ConfigMap.
+const (
+	// Log level, either a zap level name like "info"
+	// or a logr verbosity like "2".
+	LogLevelKey = "logLevel"
+	// Interval to requeue Addons at while waiting for dependent objects.
+	AddonRetryIntervalKey = "addonRetryInterval"
+	// Interval to requeue the AddonOperator object at.
+	AddonOperatorRequeueIntervalKey = "addonOperatorRequeueInterval"
+	// Interval to re-apply Addons at, reverting manual changes to their objects.
+	AddonDriftDetectionIntervalKey = "addonDriftDetectionInterval"
+	// Comma separated list of feature toggles,
+	// enabled in addition to the ones of the AddonOperator object.
+	FeatureGatesKey = "featureGates"
+	// Maximum number of Addons reconciled concurrently.
+	MaxConcurrentReconcilesKey = "maxConcurrentReconciles"
+)
+
+type Config struct {
+	LogLevel                     zapcore.Level
+	AddonRetryInterval           time.Duration
+	AddonOperatorRequeueInterval time.Duration
+	AddonDriftDetectionInterval  time.Duration
+	FeatureGates                 []string
+	MaxConcurrentReconciles      int
+}
+
+// Configuration used when the ConfigMap does not exist
+// or a key is not set.
+func Default() Config {
+	return Config{
+		// The manager logs in development mode by default.
+		LogLevel:                     zapcore.DebugLevel,
+		AddonRetryInterval:           10 * time.Second,
+		AddonOperatorRequeueInterval: time.Minute,
+		AddonDriftDetectionInterval:  10 * time.Minute,
+		MaxConcurrentReconciles:      1,
+	}
+}
+
+// Parses the data of the runtime configuration ConfigMap,
+// keys that are not set fall back to their default.
+func Parse(data map[string]string) (Config, error) {
+	cfg := Default()
+
+	if v, ok := data[LogLevelKey]; ok {
+		lvl, err := parseLogLevel(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("parsing %s: %w", LogLevelKey, err)
+		}
+		cfg.LogLevel = lvl
+	}
+
+	for key, d := range map[string]*time.Duration{
+		AddonRetryIntervalKey:           &cfg.AddonRetryInterval,
+		AddonOperatorRequeueIntervalKey: &cfg.AddonOperatorRequeueInterval,
+		AddonDriftDetectionIntervalKey:  &cfg.AddonDriftDetectionInterval,
+	} {
+		v, ok := data[key]
+		if !ok {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("parsing %s: %w", key, err)
+		}
+		if parsed <= 0 {
+			return Config{}, fmt.Errorf("parsing %s: must be positive", key)
+		}
+		*d = parsed
+	}
+
+	if v, ok := data[FeatureGatesKey]; ok {
+		cfg.FeatureGates = splitFeatureGates(v)
+	}
+
+	if v, ok := data[MaxConcurrentReconcilesKey]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("parsing %s: %w", MaxConcurrentReconcilesKey, err)
+		}
+		if n < 1 {
+			return Config{}, fmt.Errorf("parsing %s: must be at least 1", MaxConcurrentReconcilesKey)
+		}
+		cfg.MaxConcurrentReconciles = n
+	}
+	return cfg, nil
+}
+
+// Returns true, if switching from this configuration to the other one
+// can't be applied at runtime and requires a restart of the manager.
+// Feature gates and concurrency are only evaluated on startup.
+func (c Config) RequiresRestart(other Config) bool {
+	if c.MaxConcurrentReconciles != other.MaxConcurrentReconciles {
+		return true
+	}
+	if len(c.FeatureGates) != len(other.FeatureGates) {
+		return true
+	}
+	for i := range c.FeatureGates {
+		if c.FeatureGates[i] != other.FeatureGates[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Merges the configured feature gates into the given
+// comma separated list of feature toggles.
+func (c Config) MergeFeatureGates(featureFlags string) string {
+	merged := splitFeatureGates(featureFlags)
+	for _, gate := range c.FeatureGates {
+		if !containsString(merged, gate) {
+			merged = append(merged, gate)
+		}
+	}
+	return strings.Join(merged, ",")
+}
+
+func parseLogLevel(v string) (zapcore.Level, error) {
+	// logr verbosity is the negated zap level.
+	if verbosity, err := strconv.Atoi(v); err == nil {
+		if verbosity < 0 {
+			return 0, fmt.Errorf("verbosity must not be negative")
+		}
+		return zapcore.Level(-verbosity), nil
+	}
+
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(v)); err != nil {
+		return 0, err
+	}
+	return lvl, nil
+}
+
+// Splits, deduplicates and sorts a comma separated list of feature gates.
+func splitFeatureGates(v string) []string {
+	var gates []string
+	for _, gate := range strings.Split(v, ",") {
+		gate = strings.TrimSpace(gate)
+		if len(gate) == 0 || containsString(gates, gate) {
+			continue
+		}
+		gates = append(gates, gate)
+	}
+	sort.Strings(gates)
+	return gates
+}
+
+func containsString(slice []string, s string) bool {
+	for _, v := range slice {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
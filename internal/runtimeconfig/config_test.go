@@ -0,0 +1,102 @@
+package runtimeconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		Data          map[string]string
+		Expected      Config
+		ExpectedError bool
+	}{
+		"empty": {
+			Expected: Default(),
+		},
+		"all keys": {
+			Data: map[string]string{
+				LogLevelKey:                     "info",
+				AddonRetryIntervalKey:           "30s",
+				AddonOperatorRequeueIntervalKey: "5m",
+				AddonDriftDetectionIntervalKey:  "30m",
+				FeatureGatesKey:                 "B, A,,A",
+				MaxConcurrentReconcilesKey:      "4",
+			},
+			Expected: Config{
+				LogLevel:                     zapcore.InfoLevel,
+				AddonRetryInterval:           30 * time.Second,
+				AddonOperatorRequeueInterval: 5 * time.Minute,
+				AddonDriftDetectionInterval:  30 * time.Minute,
+				FeatureGates:                 []string{"A", "B"},
+				MaxConcurrentReconciles:      4,
+			},
+		},
+		"verbosity": {
+			Data: map[string]string{LogLevelKey: "2"},
+			Expected: func() Config {
+				cfg := Default()
+				cfg.LogLevel = zapcore.Level(-2)
+				return cfg
+			}(),
+		},
+		"invalid log level": {
+			Data:          map[string]string{LogLevelKey: "loud"},
+			ExpectedError: true,
+		},
+		"invalid interval": {
+			Data:          map[string]string{AddonRetryIntervalKey: "-1s"},
+			ExpectedError: true,
+		},
+		"invalid concurrency": {
+			Data:          map[string]string{MaxConcurrentReconcilesKey: "0"},
+			ExpectedError: true,
+		},
+	} {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := Parse(tc.Data)
+			if tc.ExpectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, cfg)
+		})
+	}
+}
+
+func TestConfig_RequiresRestart(t *testing.T) {
+	t.Parallel()
+
+	cfg := Default()
+
+	changedLogLevel := cfg
+	changedLogLevel.LogLevel = zapcore.ErrorLevel
+	assert.False(t, cfg.RequiresRestart(changedLogLevel))
+
+	changedGates := cfg
+	changedGates.FeatureGates = []string{"A"}
+	assert.True(t, cfg.RequiresRestart(changedGates))
+
+	changedConcurrency := cfg
+	changedConcurrency.MaxConcurrentReconciles = 2
+	assert.True(t, cfg.RequiresRestart(changedConcurrency))
+}
+
+func TestConfig_MergeFeatureGates(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{FeatureGates: []string{"A", "C"}}
+	assert.Equal(t, "A,B,C", cfg.MergeFeatureGates("B,A"))
+	assert.Equal(t, "A,C", cfg.MergeFeatureGates(""))
+}
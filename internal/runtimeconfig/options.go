@@ -0,0 +1,17 @@
+package runtimeconfig
+
+import (
+	"github.com/go-logr/logr"
+)
+
+type WithLog struct{ Log logr.Logger }
+
+func (w WithLog) ConfigureWatcher(c *WatcherConfig) {
+	c.Log = w.Log
+}
+
+type WithRestart struct{ Restart func() }
+
+func (w WithRestart) ConfigureWatcher(c *WatcherConfig) {
+	c.Restart = w.Restart
+}
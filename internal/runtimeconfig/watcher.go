@@ -0,0 +1,161 @@
+package runtimeconfig
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Loads the runtime configuration from the ConfigMap in the given namespace.
+// Returns the default configuration, if the ConfigMap does not exist.
+func Load(ctx context.Context, clientset kubernetes.Interface, namespace string) (Config, error) {
+	cm, err := clientset.CoreV1().ConfigMaps(namespace).Get(ctx, ConfigMapName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("getting runtime config ConfigMap: %w", err)
+	}
+	return Parse(cm.Data)
+}
+
+// Watcher watches the runtime configuration ConfigMap
+// and applies changes to the running manager.
+type Watcher struct {
+	clientset kubernetes.Interface
+	namespace string
+	apply     func(Config)
+	restart   func()
+	log       logr.Logger
+
+	current    Config
+	currentMux sync.Mutex
+}
+
+// Creates a new Watcher starting from the configuration loaded on startup.
+// apply is called with every valid configuration change.
+func NewWatcher(
+	clientset kubernetes.Interface,
+	namespace string,
+	initial Config,
+	apply func(Config),
+	opts ...WatcherOption,
+) *Watcher {
+	var cfg WatcherConfig
+	cfg.Option(opts...)
+	cfg.Default()
+
+	return &Watcher{
+		clientset: clientset,
+		namespace: namespace,
+		apply:     apply,
+		restart:   cfg.Restart,
+		log:       cfg.Log,
+		current:   initial,
+	}
+}
+
+// The runtime configuration is applied by every replica,
+// not only by the leader.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable and blocks until the context is done.
+func (w *Watcher) Start(ctx context.Context) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(
+		w.clientset, 0,
+		informers.WithNamespace(w.namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", ConfigMapName).String()
+		}),
+	)
+
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.handle(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			w.handle(obj)
+		},
+		DeleteFunc: func(interface{}) {
+			w.update(Default())
+		},
+	}); err != nil {
+		return fmt.Errorf("adding runtime config event handler: %w", err)
+	}
+
+	factory.Start(ctx.Done())
+	<-ctx.Done()
+	factory.Shutdown()
+	return nil
+}
+
+func (w *Watcher) handle(obj interface{}) {
+	cm, ok := obj.(*corev1.ConfigMap)
+	if !ok {
+		return
+	}
+
+	cfg, err := Parse(cm.Data)
+	if err != nil {
+		// Keep running with the last valid configuration.
+		w.log.Error(err, "invalid runtime config, ignoring")
+		return
+	}
+	w.update(cfg)
+}
+
+func (w *Watcher) update(cfg Config) {
+	w.currentMux.Lock()
+	defer w.currentMux.Unlock()
+
+	if w.current.RequiresRestart(cfg) {
+		w.log.Info("runtime config requires a restart, stopping")
+		w.restart()
+		return
+	}
+
+	w.current = cfg
+	w.apply(cfg)
+}
+
+type WatcherConfig struct {
+	Log logr.Logger
+	// Called when a configuration change can't be applied at runtime.
+	// Must stop the manager, so that k8s restarts ADO (pods),
+	// bootstrapping it with the new configuration.
+	// Called from the informer event handler, so it must not block.
+	Restart func()
+}
+
+func (c *WatcherConfig) Option(opts ...WatcherOption) {
+	for _, opt := range opts {
+		opt.ConfigureWatcher(c)
+	}
+}
+
+func (c *WatcherConfig) Default() {
+	if c.Log.GetSink() == nil {
+		c.Log = logr.Discard()
+	}
+	if c.Restart == nil {
+		// Without a way to stop the manager the change
+		// is only applied on the next start.
+		c.Restart = func() {}
+	}
+}
+
+type WatcherOption interface {
+	ConfigureWatcher(c *WatcherConfig)
+}
@@ -0,0 +1,59 @@
+package runtimeconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Load(context.Background(), fake.NewSimpleClientset(), "addon-operator")
+	require.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+
+	clientset := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: "addon-operator",
+		},
+		Data: map[string]string{AddonRetryIntervalKey: "1m"},
+	})
+	cfg, err = Load(context.Background(), clientset, "addon-operator")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, cfg.AddonRetryInterval)
+}
+
+func TestWatcher_Handle(t *testing.T) {
+	t.Parallel()
+
+	var (
+		applied   []Config
+		restarted bool
+	)
+	w := NewWatcher(fake.NewSimpleClientset(), "addon-operator", Default(),
+		func(cfg Config) { applied = append(applied, cfg) },
+		WithRestart{Restart: func() { restarted = true }},
+	)
+
+	// Applied at runtime.
+	w.handle(&corev1.ConfigMap{Data: map[string]string{AddonRetryIntervalKey: "1m"}})
+	require.Len(t, applied, 1)
+	assert.Equal(t, time.Minute, applied[0].AddonRetryInterval)
+
+	// Invalid configurations are ignored.
+	w.handle(&corev1.ConfigMap{Data: map[string]string{AddonRetryIntervalKey: "soon"}})
+	assert.Len(t, applied, 1)
+
+	// Concurrency changes require a restart.
+	w.handle(&corev1.ConfigMap{Data: map[string]string{MaxConcurrentReconcilesKey: "4"}})
+	assert.Len(t, applied, 1)
+	assert.True(t, restarted)
+}
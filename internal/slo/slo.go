@@ -0,0 +1,154 @@
+// Package slo renders the service level objectives of Addons into
+// recording rules of their event rates and computes their error budget.
+package slo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/model"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/promrules"
+)
+
+// Defaults of AddonSLOs, also defaulted by the API server.
+const (
+	DefaultWindow      = 30 * 24 * time.Hour
+	DefaultMaxBurnRate = 14.4
+)
+
+// Window the burn rate of the error budget is measured over.
+const BurnRateWindow = time.Hour
+
+// Window the event rates are recorded over.
+// Error ratios over longer windows are computed from the recorded rates,
+// instead of evaluating the indicator queries over the whole window.
+const RecordWindow = 5 * time.Minute
+
+// Series recording the rates of failed and of all events over the record window.
+const (
+	ErrorsRecord = "addon_slo:errors:rate5m"
+	EventsRecord = "addon_slo:events:rate5m"
+)
+
+// Labels identifying the Addon and SLO on the recorded error ratios.
+const (
+	AddonLabel = "addon"
+	NameLabel  = "slo"
+)
+
+var (
+	ErrTargetInvalid      = errors.New("target must be a percentage greater than 0 and less than 100")
+	ErrMaxBurnRateInvalid = errors.New("maxBurnRate must be a positive number")
+	ErrWindowInvalid      = errors.New("window must be at least 1h")
+)
+
+// Returns the query of the error ratio of the SLO over the given window,
+// computed from the recorded event rates.
+func ErrorRatioQuery(addon, name string, window time.Duration) string {
+	selector := fmt.Sprintf(`{%s=%q,%s=%q}`, AddonLabel, addon, NameLabel, name)
+	return fmt.Sprintf("sum_over_time(%s%s[%s]) / sum_over_time(%s%s[%s])",
+		ErrorsRecord, selector, model.Duration(window),
+		EventsRecord, selector, model.Duration(window))
+}
+
+// Returns the window the error budget of the SLO is computed over.
+func Window(slo addonsv1alpha1.AddonSLO) time.Duration {
+	if slo.Window == nil || slo.Window.Duration <= 0 {
+		return DefaultWindow
+	}
+	return slo.Window.Duration
+}
+
+// Returns the ratio of events, which may fail within the window.
+func ErrorBudget(slo addonsv1alpha1.AddonSLO) (float64, error) {
+	target, err := strconv.ParseFloat(slo.Target, 64)
+	if err != nil || target <= 0 || target >= 100 {
+		return 0, ErrTargetInvalid
+	}
+	return 1 - target/100, nil
+}
+
+// Returns the burn rate, above which the SLO is breached.
+func MaxBurnRate(slo addonsv1alpha1.AddonSLO) (float64, error) {
+	if len(slo.MaxBurnRate) == 0 {
+		return DefaultMaxBurnRate, nil
+	}
+	maxBurnRate, err := strconv.ParseFloat(slo.MaxBurnRate, 64)
+	if err != nil || maxBurnRate <= 0 {
+		return 0, ErrMaxBurnRateInvalid
+	}
+	return maxBurnRate, nil
+}
+
+// Renders the rates of failed and of all events of the SLOs
+// over the record window into recording rules.
+func RuleGroups(addon *addonsv1alpha1.Addon, slos []addonsv1alpha1.AddonSLO) []monv1.RuleGroup {
+	groups := make([]monv1.RuleGroup, 0, len(slos))
+	for _, slo := range slos {
+		labels := map[string]string{
+			AddonLabel: addon.Name,
+			NameLabel:  slo.Name,
+		}
+		groups = append(groups, monv1.RuleGroup{
+			Name: fmt.Sprintf("addon-slo-%s", slo.Name),
+			Rules: []monv1.Rule{
+				{
+					Record: ErrorsRecord,
+					Expr: intstr.FromString(fmt.Sprintf("sum(rate(%s[%s]))",
+						slo.Indicator.ErrorQuery, model.Duration(RecordWindow))),
+					Labels: labels,
+				},
+				{
+					Record: EventsRecord,
+					Expr: intstr.FromString(fmt.Sprintf("sum(rate(%s[%s]))",
+						slo.Indicator.TotalQuery, model.Duration(RecordWindow))),
+					Labels: labels,
+				},
+			},
+		})
+	}
+	return groups
+}
+
+// Validates the SLOs and their recording rules.
+func Validate(addon *addonsv1alpha1.Addon, slos []addonsv1alpha1.AddonSLO) error {
+	for _, slo := range slos {
+		if _, err := ErrorBudget(slo); err != nil {
+			return fmt.Errorf("slo %q: %w", slo.Name, err)
+		}
+		if _, err := MaxBurnRate(slo); err != nil {
+			return fmt.Errorf("slo %q: %w", slo.Name, err)
+		}
+		if Window(slo) < BurnRateWindow {
+			return fmt.Errorf("slo %q: %w", slo.Name, ErrWindowInvalid)
+		}
+	}
+	return promrules.Validate(RuleGroups(addon, slos))
+}
+
+// Budget is the state of the error budget of an SLO.
+type Budget struct {
+	// Ratio of the error budget left within the window, negative once used up.
+	Remaining float64
+	// Rate the error budget is used up at over the burn rate window.
+	BurnRate float64
+}
+
+// Computes the error budget from the error ratios over the window of the SLO
+// and over the burn rate window.
+func Evaluate(slo addonsv1alpha1.AddonSLO, windowErrorRatio, burnRateErrorRatio float64) (Budget, error) {
+	errorBudget, err := ErrorBudget(slo)
+	if err != nil {
+		return Budget{}, err
+	}
+	return Budget{
+		Remaining: 1 - windowErrorRatio/errorBudget,
+		BurnRate:  burnRateErrorRatio / errorBudget,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package slo
+
+import (
+	"testing"
+	"time"
+
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+)
+
+func newTestSLO() addonsv1alpha1.AddonSLO {
+	return addonsv1alpha1.AddonSLO{
+		Name:   "availability",
+		Target: "99.9",
+		Indicator: addonsv1alpha1.AddonSLOIndicator{
+			ErrorQuery: `http_requests_total{job="addon",code=~"5.."}`,
+			TotalQuery: `http_requests_total{job="addon"}`,
+		},
+	}
+}
+
+func TestRuleGroups(t *testing.T) {
+	t.Parallel()
+
+	addon := &addonsv1alpha1.Addon{ObjectMeta: metav1.ObjectMeta{Name: "addon-1"}}
+	groups := RuleGroups(addon, []addonsv1alpha1.AddonSLO{newTestSLO()})
+
+	labels := map[string]string{"addon": "addon-1", "slo": "availability"}
+	assert.Equal(t, []monv1.RuleGroup{{
+		Name: "addon-slo-availability",
+		Rules: []monv1.Rule{
+			{
+				Record: "addon_slo:errors:rate5m",
+				Expr:   intstr.FromString(`sum(rate(http_requests_total{job="addon",code=~"5.."}[5m]))`),
+				Labels: labels,
+			},
+			{
+				Record: "addon_slo:events:rate5m",
+				Expr:   intstr.FromString(`sum(rate(http_requests_total{job="addon"}[5m]))`),
+				Labels: labels,
+			},
+		},
+	}}, groups)
+}
+
+func TestErrorRatioQuery(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		`sum_over_time(addon_slo:errors:rate5m{addon="addon-1",slo="availability"}[30d]) / `+
+			`sum_over_time(addon_slo:events:rate5m{addon="addon-1",slo="availability"}[30d])`,
+		ErrorRatioQuery("addon-1", "availability", DefaultWindow))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Modify      func(slo *addonsv1alpha1.AddonSLO)
+		ExpectedErr error
+	}{
+		"valid": {
+			Modify: func(slo *addonsv1alpha1.AddonSLO) {},
+		},
+		"target of 100": {
+			Modify:      func(slo *addonsv1alpha1.AddonSLO) { slo.Target = "100" },
+			ExpectedErr: ErrTargetInvalid,
+		},
+		"zero max burn rate": {
+			Modify:      func(slo *addonsv1alpha1.AddonSLO) { slo.MaxBurnRate = "0" },
+			ExpectedErr: ErrMaxBurnRateInvalid,
+		},
+		"window below burn rate window": {
+			Modify: func(slo *addonsv1alpha1.AddonSLO) {
+				slo.Window = &metav1.Duration{Duration: 30 * time.Minute}
+			},
+			ExpectedErr: ErrWindowInvalid,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			slo := newTestSLO()
+			tc.Modify(&slo)
+			err := Validate(&addonsv1alpha1.Addon{}, []addonsv1alpha1.AddonSLO{slo})
+			if tc.ExpectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.ExpectedErr)
+			}
+		})
+	}
+}
+
+func TestValidate_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	slo := newTestSLO()
+	slo.Indicator.ErrorQuery = `http_requests_total{`
+	err := Validate(&addonsv1alpha1.Addon{}, []addonsv1alpha1.AddonSLO{slo})
+	require.ErrorContains(t, err, "invalid rules")
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	budget, err := Evaluate(newTestSLO(), 0.00025, 0.002)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.75, budget.Remaining, 1e-9)
+	assert.InDelta(t, 2, budget.BurnRate, 1e-9)
+}
@@ -20,9 +20,12 @@ type Client struct {
 var _ client.Client = &Client{}
 
 func NewClient() *Client {
-	return &Client{
+	c := &Client{
 		StatusMock: &StatusClient{},
 	}
+	// Objects applied server-side are typed via the scheme of the client.
+	c.On("Scheme").Return(NewTestScheme()).Maybe()
+	return c
 }
 
 func (c *Client) SubResource(name string) client.SubResourceClient {
@@ -11,8 +11,13 @@ import (
 	k8sApiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	utilpointer "k8s.io/utils/pointer"
+	pkov1alpha1 "package-operator.run/apis/core/v1alpha1"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
+	monv1alpha1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	obov1alpha1 "github.com/rhobs/observability-operator/pkg/apis/monitoring/v1alpha1"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
@@ -24,10 +29,26 @@ func NewTestSchemeWithAddonsv1alpha1() *runtime.Scheme {
 	return testScheme
 }
 
+// Scheme containing all types managed by the addon-operator.
+func NewTestScheme() *runtime.Scheme {
+	testScheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(testScheme)
+	_ = addonsv1alpha1.AddToScheme(testScheme)
+	_ = operatorsv1.AddToScheme(testScheme)
+	_ = operatorsv1alpha1.AddToScheme(testScheme)
+	_ = monitoringv1.AddToScheme(testScheme)
+	_ = monv1.AddToScheme(testScheme)
+	_ = obov1alpha1.AddToScheme(testScheme)
+	_ = monv1alpha1.AddToScheme(testScheme)
+	_ = pkov1alpha1.AddToScheme(testScheme)
+	return testScheme
+}
+
 func NewTestSchemeWithAddonsv1alpha1AndMsov1alpha1() *runtime.Scheme {
 	testScheme := runtime.NewScheme()
 	_ = addonsv1alpha1.AddToScheme(testScheme)
 	_ = obov1alpha1.AddToScheme(testScheme)
+	_ = monv1alpha1.AddToScheme(testScheme)
 
 	return testScheme
 }
@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	addonsv1beta1 "github.com/openshift/addon-operator/apis/addons/v1beta1"
+)
+
+// AddonConversionHandler converts Addon objects between the served API versions.
+// All conversions go through the v1alpha1 storage version.
+type AddonConversionHandler struct {
+	Log logr.Logger
+}
+
+var _ http.Handler = (*AddonConversionHandler)(nil)
+
+func (h *AddonConversionHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.NewDecoder(req.Body).Decode(review); err != nil {
+		http.Error(w, fmt.Sprintf("decoding ConversionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, "ConversionReview is missing the request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = convertAddons(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.Log.Error(err, "writing ConversionReview response")
+	}
+}
+
+func convertAddons(req *apiextensionsv1.ConversionRequest) *apiextensionsv1.ConversionResponse {
+	resp := &apiextensionsv1.ConversionResponse{UID: req.UID}
+	for _, obj := range req.Objects {
+		converted, err := convertAddon(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			resp.Result = metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: err.Error(),
+			}
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+func convertAddon(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("decoding object: %w", err)
+	}
+	if typeMeta.Kind != "Addon" {
+		return nil, fmt.Errorf("unsupported kind %q", typeMeta.Kind)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	hub := &addonsv1alpha1.Addon{}
+	switch typeMeta.APIVersion {
+	case addonsv1alpha1.GroupVersion.String():
+		if err := json.Unmarshal(raw, hub); err != nil {
+			return nil, fmt.Errorf("decoding v1alpha1 Addon: %w", err)
+		}
+	case addonsv1beta1.GroupVersion.String():
+		src := &addonsv1beta1.Addon{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, fmt.Errorf("decoding v1beta1 Addon: %w", err)
+		}
+		if err := src.ConvertTo(hub); err != nil {
+			return nil, fmt.Errorf("converting v1beta1 Addon: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported apiVersion %q", typeMeta.APIVersion)
+	}
+
+	var dst runtime.Object
+	switch desiredAPIVersion {
+	case addonsv1alpha1.GroupVersion.String():
+		dst = hub
+	case addonsv1beta1.GroupVersion.String():
+		beta := &addonsv1beta1.Addon{}
+		if err := beta.ConvertFrom(hub); err != nil {
+			return nil, fmt.Errorf("converting to v1beta1 Addon: %w", err)
+		}
+		dst = beta
+	default:
+		return nil, fmt.Errorf("unsupported desired apiVersion %q", desiredAPIVersion)
+	}
+
+	gv, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, fmt.Errorf("parsing desired apiVersion: %w", err)
+	}
+	dst.GetObjectKind().SetGroupVersionKind(gv.WithKind(typeMeta.Kind))
+	return json.Marshal(dst)
+}
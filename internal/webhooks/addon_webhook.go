@@ -38,6 +38,13 @@ func (r *AddonWebhookHandler) Handle(ctx context.Context, req admission.Request)
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 		return r.validateUpdate(&obj, &oldObj)
+	case v1.Operation(adminv1beta1.Delete):
+		// The object being deleted is only passed as old object.
+		oldObj := addonsv1alpha1.Addon{}
+		if err := r.decoder.DecodeRaw(req.OldObject, &oldObj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		return r.validateDelete(&oldObj)
 	default:
 		return admission.Allowed("operation allowed")
 	}
@@ -63,6 +70,13 @@ func (r *AddonWebhookHandler) validateCreate(addon *addonsv1alpha1.Addon) admiss
 	if err := validateAddon(addon); err != nil {
 		return admission.Denied(err.Error())
 	}
+	return admission.Allowed("operation allowed").WithWarnings(addonWarnings(addon)...)
+}
+
+func (r *AddonWebhookHandler) validateDelete(addon *addonsv1alpha1.Addon) admission.Response {
+	if err := validateAddonDeletion(addon); err != nil {
+		return admission.Denied(err.Error())
+	}
 	return admission.Allowed("operation allowed")
 }
 
@@ -74,5 +88,5 @@ func (r *AddonWebhookHandler) validateUpdate(addon, oldAddon *addonsv1alpha1.Add
 	if err := validateAddonImmutability(addon, oldAddon); err != nil {
 		return admission.Denied(err.Error())
 	}
-	return admission.Allowed("operation allowed")
+	return admission.Allowed("operation allowed").WithWarnings(addonWarnings(addon)...)
 }
@@ -3,52 +3,408 @@ package webhooks
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/cron"
+	"github.com/openshift/addon-operator/internal/promrules"
+	"github.com/openshift/addon-operator/internal/slo"
 )
 
 var (
-	errSpecInstallTypeInvalid               = errors.New("invalid Addon .spec.install.type")
-	errSpecInstallOwnNamespaceRequired      = errors.New(".spec.install.olmOwnNamespace is required when .spec.install.type = OLMOwnNamespace")
-	errSpecInstallAllNamespacesRequired     = errors.New(".spec.install.olmAllNamespaces is required when .spec.install.type = OLMAllNamespaces")
-	errSpecInstallConfigMutuallyExclusive   = errors.New(".spec.install.olmAllNamespaces is mutually exclusive with .spec.install.olmOwnNamespace")
-	errAdditionalCatalogSourceNameCollision = errors.New("additional catalog source name collides with the main catalog source name")
+	errSpecInstallTypeInvalid                 = errors.New("invalid Addon .spec.install.type")
+	errSpecInstallOwnNamespaceRequired        = errors.New(".spec.install.olmOwnNamespace is required when .spec.install.type = OLMOwnNamespace")
+	errSpecInstallAllNamespacesRequired       = errors.New(".spec.install.olmAllNamespaces is required when .spec.install.type = OLMAllNamespaces")
+	errSpecInstallPackageOperatorRequired     = errors.New(".spec.install.packageOperator is required when .spec.install.type = PackageOperator")
+	errSpecInstallConfigMutuallyExclusive     = errors.New(".spec.install.olmAllNamespaces is mutually exclusive with .spec.install.olmOwnNamespace")
+	errSpecInstallPackageOperatorExclusive    = errors.New(".spec.install.packageOperator is mutually exclusive with .spec.packageOperator")
+	errAdditionalCatalogSourceNameCollision   = errors.New("additional catalog source name collides with the main catalog source name")
+	errAdditionalSubscriptionPackageCollision = errors.New(".spec.install.olm*.additionalCatalogSources[].subscription.packageName must be unique and differ from .packageName")
+	errInstallPlanApprovalVersionRequired     = errors.New(".spec.version is required when .spec.install.olm*.installPlanApproval = Manual")
+	errMaintenanceWindowScheduleInvalid       = errors.New(".spec.upgradePolicy.maintenanceWindows[].schedule must be a valid 5 field cron expression")
+	errMaintenanceWindowDurationInvalid       = errors.New(".spec.upgradePolicy.maintenanceWindows[].duration must be positive")
+	errCanaryHealthCheckWindowInvalid         = errors.New(".spec.upgradePolicy.canaryHealthCheckWindow must be positive")
+	errUpgradePolicyManualVersionRequired     = errors.New(".spec.version is required when .spec.upgradePolicy.mode = Manual")
+	errMonitoringRulesFederationRequired      = errors.New(".spec.monitoring.federation is required when .spec.monitoring.rules are set")
+	errRemoteWriteOAuth2Exclusive             = errors.New(".spec.monitoring.monitoringStack.rhobsRemoteWriteConfig.oauth2 is mutually exclusive with .oauth2ClientCredentials")
+	errRemoteWriteProxyURLRequired            = errors.New(".spec.monitoring.monitoringStack.rhobsRemoteWriteConfig.proxy.url is required when .proxy.mode = Explicit")
+	errRemoteWriteProxyURLInvalid             = errors.New(".spec.monitoring.monitoringStack.rhobsRemoteWriteConfig.proxy.url must be an absolute http or https URL")
+	errUpgradeSilenceDurationInvalid          = errors.New(".spec.monitoring.silenceDuringUpgrade.duration must be positive")
+	errMonitorNamespaceInvalid                = errors.New(".spec.monitoring.serviceMonitors[].namespace and .podMonitors[].namespace must be one of .spec.namespaces")
+	errMonitorNameCollision                   = errors.New(".spec.monitoring.serviceMonitors[] and .podMonitors[] must be unique by namespace and name")
+	errUserWorkloadCardinalityGuardInvalid    = errors.New(".spec.monitoring.monitoringStack.rhobsRemoteWriteConfig.cardinalityGuard is not supported with .spec.monitoring.mode = UserWorkload")
+	errUserWorkloadDeadMansSnitchInvalid      = errors.New(".spec.monitoring.monitoringStack.deadMansSnitch is not supported with .spec.monitoring.mode = UserWorkload")
+	errMonitoringProbesFederationRequired     = errors.New(".spec.monitoring.federation is required when .spec.monitoring.probes are set")
+	errMonitoringProbeNameCollision           = errors.New(".spec.monitoring.probes[].name must be unique")
+	errMonitoringProbeURLInvalid              = errors.New(".spec.monitoring.probes[].url must be an absolute http or https URL")
+	errMonitoringSLOsFederationRequired       = errors.New(".spec.monitoring.federation is required when .spec.monitoring.slos are set")
 )
 
 func validateAddon(addon *addonsv1alpha1.Addon) error {
 	if err := validateInstallSpec(addon.Spec.Install, addon.Name); err != nil {
 		return err
 	}
+	// Both would deploy a ClusterPackage named after the Addon.
+	if addon.Spec.Install.PackageOperator != nil && addon.Spec.AddonPackageOperator != nil {
+		return errSpecInstallPackageOperatorExclusive
+	}
 	if err := validateSecretPropagation(addon); err != nil {
 		return err
 	}
+	// Manual InstallPlans are only approved for the pinned version.
+	if installPlanApproval(addon.Spec.Install) == addonsv1alpha1.InstallPlanApprovalManual &&
+		len(addon.Spec.Version) == 0 {
+		return errInstallPlanApprovalVersionRequired
+	}
+	if err := validateUpgradePolicy(addon.Spec.UpgradePolicy); err != nil {
+		return err
+	}
+	// Manual upgrades are approved for the pinned version.
+	if addon.Spec.UpgradePolicy != nil &&
+		addon.Spec.UpgradePolicy.Mode == addonsv1alpha1.UpgradeModeManual &&
+		len(addon.Spec.Version) == 0 {
+		return errUpgradePolicyManualVersionRequired
+	}
+	if addon.Spec.Monitoring != nil && addon.Spec.Monitoring.Federation != nil {
+		if err := validateMetricRelabelings(addon.Spec.Monitoring.Federation.MetricRelabelings); err != nil {
+			return err
+		}
+	}
+	if err := validateMonitoringRules(addon.Spec.Monitoring); err != nil {
+		return err
+	}
+	if err := validateMonitors(addon); err != nil {
+		return err
+	}
+	if err := validateMonitoringProbes(addon.Spec.Monitoring); err != nil {
+		return err
+	}
+	if err := validateMonitoringSLOs(addon); err != nil {
+		return err
+	}
+	if addon.Spec.Monitoring != nil && addon.Spec.Monitoring.SilenceDuringUpgrade != nil {
+		if d := addon.Spec.Monitoring.SilenceDuringUpgrade.Duration; d != nil && d.Duration <= 0 {
+			return errUpgradeSilenceDurationInvalid
+		}
+	}
+	if addon.Spec.Monitoring != nil && addon.Spec.Monitoring.MonitoringStack != nil {
+		config := addon.Spec.Monitoring.MonitoringStack.RHOBSRemoteWriteConfig
+		if err := validateRHOBSRemoteWriteConfig(config); err != nil {
+			return err
+		}
+		// Series are counted in the MonitoringStack, which is not created then.
+		if addon.Spec.Monitoring.Mode == addonsv1alpha1.AddonMonitoringModeUserWorkload &&
+			config != nil && config.CardinalityGuard != nil {
+			return errUserWorkloadCardinalityGuardInvalid
+		}
+		// Snitches are checked in by the Alertmanager of the MonitoringStack.
+		if addon.Spec.Monitoring.Mode == addonsv1alpha1.AddonMonitoringModeUserWorkload &&
+			addon.Spec.Monitoring.MonitoringStack.DeadMansSnitch != nil {
+			return errUserWorkloadDeadMansSnitchInvalid
+		}
+	}
+	return nil
+}
+
+// Returns warnings about constraints, which are not enforced yet,
+// so Addons admitted before get a chance to migrate.
+func addonWarnings(addon *addonsv1alpha1.Addon) []string {
+	if addon.Spec.Monitoring == nil || addon.Spec.Monitoring.Federation == nil {
+		return nil
+	}
+
+	var warnings []string
+	if err := controllers.ValidateMonitoringFederationTenancy(addon); err != nil {
+		warnings = append(warnings, fmt.Sprintf("%s, this will be rejected in a future release", err))
+	}
+	for i, relabeling := range addon.Spec.Monitoring.Federation.MetricRelabelings {
+		// The enforced relabeling overrides it anyway.
+		if relabeling != nil && relabeling.TargetLabel == controllers.MonitoringFederationAddonLabel {
+			warnings = append(warnings, fmt.Sprintf(
+				".spec.monitoring.federation.metricRelabelings[%d]: the %q label is reserved to identify the Addon and is overridden",
+				i, controllers.MonitoringFederationAddonLabel))
+		}
+	}
+	return warnings
+}
+
+func validateRHOBSRemoteWriteConfig(config *addonsv1alpha1.RHOBSRemoteWriteConfigSpec) error {
+	if config == nil {
+		return nil
+	}
+	if config.OAuth2 != nil && config.OAuth2ClientCredentials != nil {
+		return errRemoteWriteOAuth2Exclusive
+	}
+	for i, entry := range config.Allowlist {
+		if err := validateAllowlistEntry(entry); err != nil {
+			return fmt.Errorf(".spec.monitoring.monitoringStack.rhobsRemoteWriteConfig.allowlist[%d]: %w", i, err)
+		}
+	}
+
+	proxy := config.Proxy
+	if proxy == nil {
+		return nil
+	}
+	if len(proxy.URL) == 0 {
+		if proxy.Mode != addonsv1alpha1.AddonProxyModeInheritCluster {
+			return errRemoteWriteProxyURLRequired
+		}
+		return nil
+	}
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil || (proxyURL.Scheme != "http" && proxyURL.Scheme != "https") || len(proxyURL.Host) == 0 {
+		return errRemoteWriteProxyURLInvalid
+	}
+	return nil
+}
+
+// Monitoring rules are evaluated by cluster-monitoring on the federated metrics.
+func validateMonitoringRules(monitoring *addonsv1alpha1.MonitoringSpec) error {
+	if monitoring == nil || len(monitoring.Rules) == 0 {
+		return nil
+	}
+	// A Thanos Ruler evaluates rules on the metrics of the MonitoringStack instead.
+	thanosRuler := monitoring.MonitoringStack != nil &&
+		monitoring.MonitoringStack.RuleEvaluation == addonsv1alpha1.AddonRuleEvaluationThanosRuler
+	if monitoring.Federation == nil && !thanosRuler {
+		return errMonitoringRulesFederationRequired
+	}
+	if err := promrules.Validate(monitoring.Rules); err != nil {
+		return fmt.Errorf(".spec.monitoring.rules: %w", err)
+	}
+	return nil
+}
+
+// ServiceMonitors and PodMonitors are created in the namespaces of the addon.
+func validateMonitors(addon *addonsv1alpha1.Addon) error {
+	if addon.Spec.Monitoring == nil {
+		return nil
+	}
+
+	namespaces := map[string]struct{}{}
+	for _, ns := range addon.Spec.Namespaces {
+		namespaces[ns.Name] = struct{}{}
+	}
+	validate := func(namespace, name string, seen map[string]struct{}) error {
+		if _, ok := namespaces[namespace]; !ok {
+			return errMonitorNamespaceInvalid
+		}
+		key := namespace + "/" + name
+		if _, ok := seen[key]; ok {
+			return errMonitorNameCollision
+		}
+		seen[key] = struct{}{}
+		return nil
+	}
+
+	seen := map[string]struct{}{}
+	for _, sm := range addon.Spec.Monitoring.ServiceMonitors {
+		if err := validate(sm.Namespace, sm.Name, seen); err != nil {
+			return err
+		}
+	}
+	seen = map[string]struct{}{}
+	for _, pm := range addon.Spec.Monitoring.PodMonitors {
+		if err := validate(pm.Namespace, pm.Name, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Probes are scraped in the monitoring namespace, which only exists with federation.
+func validateMonitoringProbes(monitoring *addonsv1alpha1.MonitoringSpec) error {
+	if monitoring == nil || len(monitoring.Probes) == 0 {
+		return nil
+	}
+	if monitoring.Federation == nil {
+		return errMonitoringProbesFederationRequired
+	}
+
+	seen := map[string]struct{}{}
+	for _, probe := range monitoring.Probes {
+		if _, ok := seen[probe.Name]; ok {
+			return errMonitoringProbeNameCollision
+		}
+		seen[probe.Name] = struct{}{}
+
+		u, err := url.Parse(probe.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return errMonitoringProbeURLInvalid
+		}
+	}
+	return nil
+}
+
+// SLO error ratios are recorded by cluster-monitoring on the federated metrics.
+func validateMonitoringSLOs(addon *addonsv1alpha1.Addon) error {
+	if addon.Spec.Monitoring == nil || len(addon.Spec.Monitoring.SLOs) == 0 {
+		return nil
+	}
+	if addon.Spec.Monitoring.Federation == nil {
+		return errMonitoringSLOsFederationRequired
+	}
+	if err := slo.Validate(addon, addon.Spec.Monitoring.SLOs); err != nil {
+		return fmt.Errorf(".spec.monitoring.slos: %w", err)
+	}
+	return nil
+}
+
+var metricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// Allowlist entries are either metric names or regexes anchored with ^ and $.
+func validateAllowlistEntry(entry string) error {
+	if metricNameRegexp.MatchString(entry) {
+		return nil
+	}
+	if len(entry) < 2 || !strings.HasPrefix(entry, "^") || !strings.HasSuffix(entry, "$") {
+		return fmt.Errorf("%q is neither a metric name nor a regex anchored with ^ and $", entry)
+	}
+	// The allowlist is also embedded into a raw PromQL string.
+	if strings.Contains(entry, "`") {
+		return fmt.Errorf("regex %q must not contain backticks", entry)
+	}
+	if _, err := regexp.Compile(entry); err != nil {
+		return fmt.Errorf("invalid regex %q: %w", entry, err)
+	}
+	return nil
+}
+
+var (
+	relabelLabelNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// Target labels of the replace action may reference regex capture groups.
+	relabelReplaceTargetRegexp = regexp.MustCompile(`^(?:(?:[a-zA-Z_]|\$(?:\{\w+\}|\w+))+\w*)+$`)
+)
+
+// Validates metric relabelings the way prometheus does, when loading its configuration.
+// Otherwise an invalid relabeling would break the configuration of the cluster-monitoring prometheus.
+func validateMetricRelabelings(relabelings []*monv1.RelabelConfig) error {
+	for i, relabeling := range relabelings {
+		if relabeling == nil {
+			continue
+		}
+		if err := validateMetricRelabeling(*relabeling); err != nil {
+			return fmt.Errorf(".spec.monitoring.federation.metricRelabelings[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateMetricRelabeling(relabeling monv1.RelabelConfig) error {
+	action := strings.ToLower(relabeling.Action)
+	if len(action) == 0 {
+		action = "replace"
+	}
+
+	for _, label := range relabeling.SourceLabels {
+		if !relabelLabelNameRegexp.MatchString(string(label)) {
+			return fmt.Errorf("source label %q is not a valid label name", label)
+		}
+	}
+	if len(relabeling.Regex) > 0 {
+		// Prometheus anchors the regex on both ends.
+		if _, err := regexp.Compile("^(?:" + relabeling.Regex + ")$"); err != nil {
+			return fmt.Errorf("invalid regex %q: %w", relabeling.Regex, err)
+		}
+	}
+
+	switch action {
+	case "keep", "drop", "labelmap":
+		return nil
+
+	case "replace":
+		if len(relabeling.TargetLabel) == 0 {
+			return fmt.Errorf("targetLabel is required for action %q", action)
+		}
+		if !relabelReplaceTargetRegexp.MatchString(relabeling.TargetLabel) {
+			return fmt.Errorf("targetLabel %q is not a valid label name", relabeling.TargetLabel)
+		}
+		return nil
+
+	case "hashmod", "lowercase", "uppercase", "keepequal", "dropequal":
+		if len(relabeling.TargetLabel) == 0 {
+			return fmt.Errorf("targetLabel is required for action %q", action)
+		}
+		if !relabelLabelNameRegexp.MatchString(relabeling.TargetLabel) {
+			return fmt.Errorf("targetLabel %q is not a valid label name", relabeling.TargetLabel)
+		}
+		if action == "hashmod" && relabeling.Modulus == 0 {
+			return fmt.Errorf("modulus is required for action %q", action)
+		}
+		return nil
+
+	case "labeldrop", "labelkeep":
+		// Only the regex applies, matching label names.
+		if len(relabeling.SourceLabels) > 0 || len(relabeling.TargetLabel) > 0 ||
+			relabeling.Modulus != 0 || (len(relabeling.Replacement) > 0 && relabeling.Replacement != "$1") {
+			return fmt.Errorf("only regex may be set for action %q", action)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown action %q", relabeling.Action)
+	}
+}
+
+func installPlanApproval(addonSpecInstall addonsv1alpha1.AddonInstallSpec) addonsv1alpha1.AddonInstallPlanApproval {
+	switch {
+	case addonSpecInstall.OLMAllNamespaces != nil:
+		return addonSpecInstall.OLMAllNamespaces.InstallPlanApproval
+	case addonSpecInstall.OLMOwnNamespace != nil:
+		return addonSpecInstall.OLMOwnNamespace.InstallPlanApproval
+	}
+	return ""
+}
+
+func validateUpgradePolicy(upgradePolicy *addonsv1alpha1.AddonUpgradePolicy) error {
+	if upgradePolicy == nil {
+		return nil
+	}
+
+	for _, window := range upgradePolicy.MaintenanceWindows {
+		if _, err := cron.Parse(window.Schedule); err != nil {
+			return errMaintenanceWindowScheduleInvalid
+		}
+		if window.Duration.Duration <= 0 {
+			return errMaintenanceWindowDurationInvalid
+		}
+	}
+	if window := upgradePolicy.CanaryHealthCheckWindow; window != nil && window.Duration <= 0 {
+		return errCanaryHealthCheckWindowInvalid
+	}
 	return nil
 }
 
 func validateSecretPropagation(addon *addonsv1alpha1.Addon) error {
-	var pullSecretName string
+	var pullSecrets []string
 	switch addon.Spec.Install.Type {
 	case addonsv1alpha1.OLMAllNamespaces:
-		pullSecretName = addon.Spec.Install.OLMAllNamespaces.PullSecretName
+		pullSecrets = addon.Spec.Install.OLMAllNamespaces.GetPullSecrets()
 	case addonsv1alpha1.OLMOwnNamespace:
-		pullSecretName = addon.Spec.Install.OLMOwnNamespace.PullSecretName
+		pullSecrets = addon.Spec.Install.OLMOwnNamespace.GetPullSecrets()
 	}
 
-	if len(pullSecretName) == 0 || addon.Spec.SecretPropagation == nil {
+	if len(pullSecrets) == 0 || addon.Spec.SecretPropagation == nil {
 		return nil
 	}
 
+	destinations := map[string]struct{}{}
 	for _, secret := range addon.Spec.SecretPropagation.Secrets {
-		if secret.DestinationSecret.Name == pullSecretName {
-			// pullSecretName is part of the secret list!
-			return nil
+		destinations[secret.DestinationSecret.Name] = struct{}{}
+	}
+	for _, pullSecret := range pullSecrets {
+		if _, ok := destinations[pullSecret]; !ok {
+			// we have not found the pull secret in the secret propagation list.
+			return fmt.Errorf("pullSecretName %q not found as destination in secretPropagation", pullSecret)
 		}
 	}
-
-	// we have not found pullSecretName in the secret propagation list.
-	return fmt.Errorf("pullSecretName %q not found as destination in secretPropagation", pullSecretName)
+	return nil
 }
 
 func validateInstallSpec(addonSpecInstall addonsv1alpha1.AddonInstallSpec, addonName string) error {
@@ -73,7 +429,7 @@ func validateInstallSpec(addonSpecInstall addonsv1alpha1.AddonInstallSpec, addon
 			}
 		}
 
-		return nil
+		return validateAdditionalSubscriptions(addonSpecInstall.OLMOwnNamespace.AddonInstallOLMCommon)
 
 	case addonsv1alpha1.OLMAllNamespaces:
 		if addonSpecInstall.OLMAllNamespaces == nil {
@@ -90,6 +446,13 @@ func validateInstallSpec(addonSpecInstall addonsv1alpha1.AddonInstallSpec, addon
 			}
 		}
 
+		return validateAdditionalSubscriptions(addonSpecInstall.OLMAllNamespaces.AddonInstallOLMCommon)
+
+	case addonsv1alpha1.PackageOperator:
+		if addonSpecInstall.PackageOperator == nil {
+			// missing configuration
+			return errSpecInstallPackageOperatorRequired
+		}
 		return nil
 
 	default:
@@ -100,6 +463,32 @@ func validateInstallSpec(addonSpecInstall addonsv1alpha1.AddonInstallSpec, addon
 	}
 }
 
+// OLM can not resolve more than one Subscription per package in a namespace.
+func validateAdditionalSubscriptions(common addonsv1alpha1.AddonInstallOLMCommon) error {
+	packages := map[string]struct{}{common.PackageName: {}}
+	for _, additionalCtlgSrc := range common.AdditionalCatalogSources {
+		if additionalCtlgSrc.Subscription == nil {
+			continue
+		}
+		packageName := additionalCtlgSrc.Subscription.PackageName
+		if _, ok := packages[packageName]; ok {
+			return errAdditionalSubscriptionPackageCollision
+		}
+		packages[packageName] = struct{}{}
+	}
+	return nil
+}
+
+var errDeletionProtected = errors.New("deletion protection is enabled, set .spec.deletionProtection to false before deleting the Addon")
+
+// Rejects the deletion of Addons with deletion protection enabled.
+func validateAddonDeletion(addon *addonsv1alpha1.Addon) error {
+	if addon.Spec.DeletionProtection {
+		return errDeletionProtected
+	}
+	return nil
+}
+
 var (
 	errInstallTypeImmutable = errors.New(".spec.install.type is immutable")
 	errInstallImmutable     = errors.New(".spec.install is immutable, except for .catalogSourceImage")
@@ -116,15 +505,22 @@ func validateAddonImmutability(addon, oldAddon *addonsv1alpha1.Addon) error {
 		oldSpecInstall.OLMAllNamespaces.CatalogSourceImage = ""
 		oldSpecInstall.OLMAllNamespaces.Config = nil
 		oldSpecInstall.OLMAllNamespaces.PullSecretName = ""
+		oldSpecInstall.OLMAllNamespaces.PullSecrets = nil
 		oldSpecInstall.OLMAllNamespaces.AdditionalCatalogSources = nil
 		oldSpecInstall.OLMAllNamespaces.Channel = ""
+		oldSpecInstall.OLMAllNamespaces.InstallPlanApproval = ""
 	}
 	if oldSpecInstall.OLMOwnNamespace != nil {
 		oldSpecInstall.OLMOwnNamespace.CatalogSourceImage = ""
 		oldSpecInstall.OLMOwnNamespace.Config = nil
 		oldSpecInstall.OLMOwnNamespace.PullSecretName = ""
+		oldSpecInstall.OLMOwnNamespace.PullSecrets = nil
 		oldSpecInstall.OLMOwnNamespace.AdditionalCatalogSources = nil
 		oldSpecInstall.OLMOwnNamespace.Channel = ""
+		oldSpecInstall.OLMOwnNamespace.InstallPlanApproval = ""
+	}
+	if oldSpecInstall.PackageOperator != nil {
+		oldSpecInstall.PackageOperator.Image = ""
 	}
 
 	specInstall := addon.Spec.Install.DeepCopy()
@@ -132,15 +528,22 @@ func validateAddonImmutability(addon, oldAddon *addonsv1alpha1.Addon) error {
 		specInstall.OLMAllNamespaces.CatalogSourceImage = ""
 		specInstall.OLMAllNamespaces.Config = nil
 		specInstall.OLMAllNamespaces.PullSecretName = ""
+		specInstall.OLMAllNamespaces.PullSecrets = nil
 		specInstall.OLMAllNamespaces.AdditionalCatalogSources = nil
 		specInstall.OLMAllNamespaces.Channel = ""
+		specInstall.OLMAllNamespaces.InstallPlanApproval = ""
 	}
 	if specInstall.OLMOwnNamespace != nil {
 		specInstall.OLMOwnNamespace.CatalogSourceImage = ""
 		specInstall.OLMOwnNamespace.Config = nil
 		specInstall.OLMOwnNamespace.PullSecretName = ""
+		specInstall.OLMOwnNamespace.PullSecrets = nil
 		specInstall.OLMOwnNamespace.AdditionalCatalogSources = nil
 		specInstall.OLMOwnNamespace.Channel = ""
+		specInstall.OLMOwnNamespace.InstallPlanApproval = ""
+	}
+	if specInstall.PackageOperator != nil {
+		specInstall.PackageOperator.Image = ""
 	}
 
 	// Do semantic DeepEqual instead of reflect.DeepEqual
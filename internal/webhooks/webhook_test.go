@@ -1,14 +1,24 @@
 package webhooks
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
+	monv1 "github.com/rhobs/obo-prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	addonsv1alpha1 "github.com/openshift/addon-operator/apis/addons/v1alpha1"
+	addonsv1beta1 "github.com/openshift/addon-operator/apis/addons/v1beta1"
+	"github.com/openshift/addon-operator/internal/controllers"
+	"github.com/openshift/addon-operator/internal/slo"
 	"github.com/openshift/addon-operator/internal/testutil"
 )
 
@@ -45,6 +55,23 @@ func Test_validateInstallSpec(t *testing.T) {
 			},
 			expectedErr: errSpecInstallAllNamespacesRequired,
 		},
+		{
+			name: "spec.install.packageOperator required",
+			addonInstallSpec: addonsv1alpha1.AddonInstallSpec{
+				Type: addonsv1alpha1.PackageOperator,
+			},
+			expectedErr: errSpecInstallPackageOperatorRequired,
+		},
+		{
+			name: "spec.install.packageOperator",
+			addonInstallSpec: addonsv1alpha1.AddonInstallSpec{
+				Type: addonsv1alpha1.PackageOperator,
+				PackageOperator: &addonsv1alpha1.AddonInstallPackageOperator{
+					Namespace: "test",
+					Image:     "quay.io/osd-addons/test-package:v1",
+				},
+			},
+		},
 		{
 			name: "spec.install.allNamespaces and *.ownNamespace mutually exclusive",
 			addonInstallSpec: addonsv1alpha1.AddonInstallSpec{
@@ -76,6 +103,29 @@ func Test_validateInstallSpec(t *testing.T) {
 			addonName:   "test-2",
 			expectedErr: errAdditionalCatalogSourceNameCollision,
 		},
+		{
+			name: "additional subscription package collision",
+			addonInstallSpec: addonsv1alpha1.AddonInstallSpec{
+				Type: addonsv1alpha1.OLMAllNamespaces,
+				OLMAllNamespaces: &addonsv1alpha1.AddonInstallOLMAllNamespaces{
+					AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+						PackageName: "test",
+						AdditionalCatalogSources: []addonsv1alpha1.AdditionalCatalogSource{
+							{
+								Name:  "test-1",
+								Image: "image-1",
+								Subscription: &addonsv1alpha1.AdditionalCatalogSourceSubscription{
+									PackageName: "test",
+									Channel:     "stable",
+								},
+							},
+						},
+					},
+				},
+			},
+			addonName:   "test",
+			expectedErr: errAdditionalSubscriptionPackageCollision,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -412,6 +462,35 @@ func TestValidateSecretPropagation(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("pullSecretName %q not found as destination in secretPropagation", "test"),
 		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type: addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{
+							AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+								PullSecrets: []string{"test-1", "test-3"},
+							},
+						},
+					},
+					SecretPropagation: &addonsv1alpha1.AddonSecretPropagation{
+						Secrets: []addonsv1alpha1.AddonSecretPropagationReference{
+							{
+								DestinationSecret: corev1.LocalObjectReference{
+									Name: "test-1",
+								},
+							},
+							{
+								DestinationSecret: corev1.LocalObjectReference{
+									Name: "test-2",
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Errorf("pullSecretName %q not found as destination in secretPropagation", "test-3"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -489,6 +568,161 @@ func TestValidateAddon(t *testing.T) {
 			},
 			expectedErr: errSpecInstallAllNamespacesRequired,
 		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type: addonsv1alpha1.PackageOperator,
+						PackageOperator: &addonsv1alpha1.AddonInstallPackageOperator{
+							Namespace: "test",
+							Image:     "quay.io/osd-addons/test-package:v1",
+						},
+					},
+					AddonPackageOperator: &addonsv1alpha1.AddonPackageOperator{
+						Image: "quay.io/osd-addons/test-package:v1",
+					},
+				},
+			},
+			expectedErr: errSpecInstallPackageOperatorExclusive,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+						ID: "123",
+						MaintenanceWindows: []addonsv1alpha1.AddonMaintenanceWindow{
+							{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: time.Hour}},
+						},
+					},
+				},
+			},
+			expectedErr: errMaintenanceWindowScheduleInvalid,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type: addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{
+							AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+								InstallPlanApproval: addonsv1alpha1.InstallPlanApprovalManual,
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errInstallPlanApprovalVersionRequired,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+						ID: "123",
+						MaintenanceWindows: []addonsv1alpha1.AddonMaintenanceWindow{
+							{Schedule: "0 2 * * 1-5"},
+						},
+					},
+				},
+			},
+			expectedErr: errMaintenanceWindowDurationInvalid,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+						ID:                      "123",
+						Strategy:                addonsv1alpha1.UpgradeStrategyCanary,
+						CanaryHealthCheckWindow: &metav1.Duration{},
+					},
+				},
+			},
+			expectedErr: errCanaryHealthCheckWindowInvalid,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					UpgradePolicy: &addonsv1alpha1.AddonUpgradePolicy{
+						ID:   "123",
+						Mode: addonsv1alpha1.UpgradeModeManual,
+					},
+				},
+			},
+			expectedErr: errUpgradePolicyManualVersionRequired,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					Monitoring: &addonsv1alpha1.MonitoringSpec{
+						SilenceDuringUpgrade: &addonsv1alpha1.SilenceDuringUpgradeSpec{
+							Duration: &metav1.Duration{},
+						},
+					},
+				},
+			},
+			expectedErr: errUpgradeSilenceDurationInvalid,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					Monitoring: &addonsv1alpha1.MonitoringSpec{
+						Mode: addonsv1alpha1.AddonMonitoringModeUserWorkload,
+						MonitoringStack: &addonsv1alpha1.MonitoringStackSpec{
+							RHOBSRemoteWriteConfig: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+								URL: "https://observatorium.example.com/api/v1/receive",
+								CardinalityGuard: &addonsv1alpha1.CardinalityGuardSpec{
+									SeriesLimit: 1000,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErr: errUserWorkloadCardinalityGuardInvalid,
+		},
+		{
+			addon: &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Install: addonsv1alpha1.AddonInstallSpec{
+						Type:            addonsv1alpha1.OLMOwnNamespace,
+						OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{},
+					},
+					Monitoring: &addonsv1alpha1.MonitoringSpec{
+						Mode: addonsv1alpha1.AddonMonitoringModeUserWorkload,
+						MonitoringStack: &addonsv1alpha1.MonitoringStackSpec{
+							DeadMansSnitch: &addonsv1alpha1.DeadMansSnitchSpec{},
+						},
+					},
+				},
+			},
+			expectedErr: errUserWorkloadDeadMansSnitchInvalid,
+		},
 		{
 			addon: &addonsv1alpha1.Addon{
 				ObjectMeta: metav1.ObjectMeta{
@@ -625,3 +859,525 @@ func TestValidateAddon(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMetricRelabelings(t *testing.T) {
+	for name, tc := range map[string]struct {
+		relabeling monv1.RelabelConfig
+		valid      bool
+	}{
+		"drop series": {
+			relabeling: monv1.RelabelConfig{
+				SourceLabels: []monv1.LabelName{"__name__"},
+				Regex:        "apiserver_request_duration_.*",
+				Action:       "drop",
+			},
+			valid: true,
+		},
+		"rename label": {
+			relabeling: monv1.RelabelConfig{
+				SourceLabels: []monv1.LabelName{"pod"},
+				TargetLabel:  "${1}_name",
+				Regex:        "(.+)",
+			},
+			valid: true,
+		},
+		"drop label": {
+			relabeling: monv1.RelabelConfig{
+				Regex:  "pod_template_hash",
+				Action: "LabelDrop",
+			},
+			valid: true,
+		},
+		"invalid regex": {
+			relabeling: monv1.RelabelConfig{
+				Regex:  "(unclosed",
+				Action: "keep",
+			},
+		},
+		"replace without target label": {
+			relabeling: monv1.RelabelConfig{
+				SourceLabels: []monv1.LabelName{"pod"},
+			},
+		},
+		"invalid source label": {
+			relabeling: monv1.RelabelConfig{
+				SourceLabels: []monv1.LabelName{"1pod"},
+				Action:       "keep",
+			},
+		},
+		"hashmod without modulus": {
+			relabeling: monv1.RelabelConfig{
+				SourceLabels: []monv1.LabelName{"instance"},
+				TargetLabel:  "shard",
+				Action:       "hashmod",
+			},
+		},
+		"labeldrop with target label": {
+			relabeling: monv1.RelabelConfig{
+				TargetLabel: "pod",
+				Action:      "labeldrop",
+			},
+		},
+		"unknown action": {
+			relabeling: monv1.RelabelConfig{
+				Action: "rename",
+			},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := validateMetricRelabelings([]*monv1.RelabelConfig{&tc.relabeling})
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, ".spec.monitoring.federation.metricRelabelings[0]")
+			}
+		})
+	}
+}
+
+func TestAddonWarnings(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		federation *addonsv1alpha1.MonitoringFederationSpec
+		expected   []string
+	}{
+		"own namespace": {
+			federation: &addonsv1alpha1.MonitoringFederationSpec{
+				Namespace:   "addon-foo-monitoring",
+				MatchLabels: map[string]string{"app.kubernetes.io/instance": "addon-foo"},
+			},
+		},
+		"shared namespace": {
+			federation: &addonsv1alpha1.MonitoringFederationSpec{
+				Namespace:   "openshift-user-workload-monitoring",
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "prometheus"},
+			},
+			expected: []string{controllers.ErrMonitoringFederationNamespaceShared.Error() +
+				", this will be rejected in a future release"},
+		},
+		"foreign match labels": {
+			federation: &addonsv1alpha1.MonitoringFederationSpec{
+				Namespace:   "addon-foo-monitoring",
+				MatchLabels: map[string]string{"app.kubernetes.io/instance": "addon-bar"},
+			},
+			expected: []string{controllers.ErrMonitoringFederationMatchLabelsForeign.Error() +
+				", this will be rejected in a future release"},
+		},
+		"target addon label": {
+			federation: &addonsv1alpha1.MonitoringFederationSpec{
+				Namespace:   "addon-foo-monitoring",
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "prometheus"},
+				MetricRelabelings: []*monv1.RelabelConfig{{
+					SourceLabels: []monv1.LabelName{"namespace"},
+					TargetLabel:  "addon",
+				}},
+			},
+			expected: []string{".spec.monitoring.federation.metricRelabelings[0]: " +
+				`the "addon" label is reserved to identify the Addon and is overridden`},
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			addon := &addonsv1alpha1.Addon{
+				ObjectMeta: metav1.ObjectMeta{Name: "addon-foo"},
+				Spec: addonsv1alpha1.AddonSpec{
+					Monitoring: &addonsv1alpha1.MonitoringSpec{Federation: tc.federation},
+				},
+			}
+			// Warnings don't deny the Addon.
+			assert.NoError(t, validateMetricRelabelings(tc.federation.MetricRelabelings))
+			assert.Equal(t, tc.expected, addonWarnings(addon))
+		})
+	}
+}
+
+func TestValidateMonitoringRules(t *testing.T) {
+	rules := []monv1.RuleGroup{{
+		Name: "addon",
+		Rules: []monv1.Rule{{
+			Alert: "AddonDown",
+			Expr:  intstr.FromString(`up{job="addon"} == 0`),
+			For:   "5m",
+		}},
+	}}
+	federation := &addonsv1alpha1.MonitoringFederationSpec{
+		Namespace:  "addon-ns",
+		MatchNames: []string{"up"},
+	}
+
+	for name, tc := range map[string]struct {
+		monitoring  *addonsv1alpha1.MonitoringSpec
+		expectedErr string
+	}{
+		"no monitoring": {},
+		"valid rules": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				Rules:      rules,
+			},
+		},
+		"rules without federation": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Rules: rules,
+			},
+			expectedErr: errMonitoringRulesFederationRequired.Error(),
+		},
+		"rules evaluated by a Thanos Ruler without federation": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				MonitoringStack: &addonsv1alpha1.MonitoringStackSpec{
+					RuleEvaluation: addonsv1alpha1.AddonRuleEvaluationThanosRuler,
+				},
+				Rules: rules,
+			},
+		},
+		"invalid expression": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				Rules: []monv1.RuleGroup{{
+					Name: "addon",
+					Rules: []monv1.Rule{{
+						Record: "addon:up:sum",
+						Expr:   intstr.FromString("sum(up"),
+					}},
+				}},
+			},
+			expectedErr: ".spec.monitoring.rules: invalid rules",
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := validateMonitoringRules(tc.monitoring)
+			if len(tc.expectedErr) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestValidateMonitors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		monitoring  *addonsv1alpha1.MonitoringSpec
+		expectedErr error
+	}{
+		"no monitoring": {},
+		"valid monitors": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				ServiceMonitors: []addonsv1alpha1.AddonServiceMonitor{
+					{Name: "metrics", Namespace: "addon-ns"},
+				},
+				PodMonitors: []addonsv1alpha1.AddonPodMonitor{
+					{Name: "metrics", Namespace: "addon-ns"},
+				},
+			},
+		},
+		"namespace not of addon": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				ServiceMonitors: []addonsv1alpha1.AddonServiceMonitor{
+					{Name: "metrics", Namespace: "openshift-monitoring"},
+				},
+			},
+			expectedErr: errMonitorNamespaceInvalid,
+		},
+		"duplicate name": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				PodMonitors: []addonsv1alpha1.AddonPodMonitor{
+					{Name: "metrics", Namespace: "addon-ns"},
+					{Name: "metrics", Namespace: "addon-ns"},
+				},
+			},
+			expectedErr: errMonitorNameCollision,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			addon := &addonsv1alpha1.Addon{
+				Spec: addonsv1alpha1.AddonSpec{
+					Namespaces: []addonsv1alpha1.AddonNamespace{{Name: "addon-ns"}},
+					Monitoring: tc.monitoring,
+				},
+			}
+			assert.ErrorIs(t, validateMonitors(addon), tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateMonitoringProbes(t *testing.T) {
+	federation := &addonsv1alpha1.MonitoringFederationSpec{
+		Namespace:  "addon-ns",
+		MatchNames: []string{"up"},
+	}
+
+	for name, tc := range map[string]struct {
+		monitoring  *addonsv1alpha1.MonitoringSpec
+		expectedErr error
+	}{
+		"no monitoring": {},
+		"valid probes": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				Probes: []addonsv1alpha1.AddonProbe{
+					{Name: "api", URL: "https://api.addon.example.com/healthz"},
+					{Name: "console", URL: "http://console.addon-ns.svc:8080"},
+				},
+			},
+		},
+		"probes without federation": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Probes: []addonsv1alpha1.AddonProbe{
+					{Name: "api", URL: "https://api.addon.example.com/healthz"},
+				},
+			},
+			expectedErr: errMonitoringProbesFederationRequired,
+		},
+		"duplicate name": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				Probes: []addonsv1alpha1.AddonProbe{
+					{Name: "api", URL: "https://api.addon.example.com/healthz"},
+					{Name: "api", URL: "https://api.addon.example.com/readyz"},
+				},
+			},
+			expectedErr: errMonitoringProbeNameCollision,
+		},
+		"url without host": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				Probes: []addonsv1alpha1.AddonProbe{
+					{Name: "api", URL: "https:///healthz"},
+				},
+			},
+			expectedErr: errMonitoringProbeURLInvalid,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, validateMonitoringProbes(tc.monitoring), tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateMonitoringSLOs(t *testing.T) {
+	federation := &addonsv1alpha1.MonitoringFederationSpec{
+		Namespace:  "addon-ns",
+		MatchNames: []string{"http_requests_total"},
+	}
+	availability := addonsv1alpha1.AddonSLO{
+		Name:   "availability",
+		Target: "99.9",
+		Indicator: addonsv1alpha1.AddonSLOIndicator{
+			ErrorQuery: `http_requests_total{code=~"5.."}`,
+			TotalQuery: `http_requests_total`,
+		},
+	}
+	invalidTarget := availability
+	invalidTarget.Target = "100"
+
+	for name, tc := range map[string]struct {
+		monitoring  *addonsv1alpha1.MonitoringSpec
+		expectedErr error
+	}{
+		"no monitoring": {},
+		"valid slos": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				SLOs:       []addonsv1alpha1.AddonSLO{availability},
+			},
+		},
+		"slos without federation": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				SLOs: []addonsv1alpha1.AddonSLO{availability},
+			},
+			expectedErr: errMonitoringSLOsFederationRequired,
+		},
+		"invalid target": {
+			monitoring: &addonsv1alpha1.MonitoringSpec{
+				Federation: federation,
+				SLOs:       []addonsv1alpha1.AddonSLO{invalidTarget},
+			},
+			expectedErr: slo.ErrTargetInvalid,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			addon := &addonsv1alpha1.Addon{
+				ObjectMeta: metav1.ObjectMeta{Name: "addon-1"},
+				Spec:       addonsv1alpha1.AddonSpec{Monitoring: tc.monitoring},
+			}
+			assert.ErrorIs(t, validateMonitoringSLOs(addon), tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateRHOBSRemoteWriteConfig(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config      *addonsv1alpha1.RHOBSRemoteWriteConfigSpec
+		expectedErr error
+	}{
+		"not configured": {},
+		"client credentials and explicit proxy": {
+			config: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				URL: "https://observatorium.example.com/api/v1/receive",
+				OAuth2ClientCredentials: &addonsv1alpha1.RHOBSOAuth2ClientCredentials{
+					SecretName: "rhobs-credentials",
+					TokenURL:   "https://sso.example.com/token",
+				},
+				Proxy: &addonsv1alpha1.RHOBSRemoteWriteProxy{
+					URL: "http://proxy.example.com:3128",
+				},
+			},
+		},
+		"inherited proxy": {
+			config: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				Proxy: &addonsv1alpha1.RHOBSRemoteWriteProxy{
+					Mode: addonsv1alpha1.AddonProxyModeInheritCluster,
+				},
+			},
+		},
+		"oauth2 and client credentials": {
+			config: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				OAuth2: &monv1.OAuth2{TokenURL: "https://sso.example.com/token"},
+				OAuth2ClientCredentials: &addonsv1alpha1.RHOBSOAuth2ClientCredentials{
+					SecretName: "rhobs-credentials",
+					TokenURL:   "https://sso.example.com/token",
+				},
+			},
+			expectedErr: errRemoteWriteOAuth2Exclusive,
+		},
+		"explicit proxy without url": {
+			config: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				Proxy: &addonsv1alpha1.RHOBSRemoteWriteProxy{
+					Mode: addonsv1alpha1.AddonProxyModeExplicit,
+				},
+			},
+			expectedErr: errRemoteWriteProxyURLRequired,
+		},
+		"allowlist of names and regexes": {
+			config: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				Allowlist: []string{"up", "addon:requests:rate5m", `^kube_pod_.+$`},
+			},
+		},
+		"proxy url without scheme": {
+			config: &addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				Proxy: &addonsv1alpha1.RHOBSRemoteWriteProxy{
+					URL: "proxy.example.com:3128",
+				},
+			},
+			expectedErr: errRemoteWriteProxyURLInvalid,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := validateRHOBSRemoteWriteConfig(tc.config)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateRHOBSRemoteWriteConfig_InvalidAllowlist(t *testing.T) {
+	for name, allowlist := range map[string][]string{
+		"unanchored regex": {"kube_pod_.+"},
+		"invalid regex":    {"^kube_(pod$"},
+		"backtick":         {"^kube`pod$"},
+		"lone anchor":      {"^"},
+	} {
+		allowlist := allowlist
+		t.Run(name, func(t *testing.T) {
+			err := validateRHOBSRemoteWriteConfig(&addonsv1alpha1.RHOBSRemoteWriteConfigSpec{
+				Allowlist: append([]string{"up"}, allowlist...),
+			})
+			assert.ErrorContains(t, err, ".rhobsRemoteWriteConfig.allowlist[1]")
+		})
+	}
+}
+
+func TestValidateAddonDeletion(t *testing.T) {
+	for name, tc := range map[string]struct {
+		deletionProtection bool
+		expectedErr        error
+	}{
+		"unprotected": {
+			deletionProtection: false,
+			expectedErr:        nil,
+		},
+		"protected": {
+			deletionProtection: true,
+			expectedErr:        errDeletionProtected,
+		},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			addon := &addonsv1alpha1.Addon{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test",
+				},
+				Spec: addonsv1alpha1.AddonSpec{
+					DeletionProtection: tc.deletionProtection,
+				},
+			}
+			assert.Equal(t, tc.expectedErr, validateAddonDeletion(addon))
+		})
+	}
+}
+
+func TestConvertAddons(t *testing.T) {
+	alpha := testutil.NewAddonWithInstallSpec(addonsv1alpha1.AddonInstallSpec{
+		Type: addonsv1alpha1.OLMOwnNamespace,
+		OLMOwnNamespace: &addonsv1alpha1.AddonInstallOLMOwnNamespace{
+			AddonInstallOLMCommon: addonsv1alpha1.AddonInstallOLMCommon{
+				Namespace:          "reference-addon",
+				PackageName:        "reference-addon",
+				Channel:            "alpha",
+				CatalogSourceImage: "quay.io/osd-addons/reference-addon-index",
+			},
+		},
+	}, "reference-addon")
+	alpha.APIVersion = addonsv1alpha1.GroupVersion.String()
+	alpha.Kind = "Addon"
+	raw, err := json.Marshal(alpha)
+	require.NoError(t, err)
+
+	t.Run("v1alpha1 to v1beta1 and back", func(t *testing.T) {
+		resp := convertAddons(&apiextensionsv1.ConversionRequest{
+			UID:               "1234",
+			DesiredAPIVersion: addonsv1beta1.GroupVersion.String(),
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		})
+		require.Equal(t, metav1.StatusSuccess, resp.Result.Status, resp.Result.Message)
+		require.Len(t, resp.ConvertedObjects, 1)
+
+		beta := &addonsv1beta1.Addon{}
+		require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, beta))
+		assert.Equal(t, addonsv1beta1.GroupVersion.String(), beta.APIVersion)
+		assert.Equal(t, "Addon", beta.Kind)
+		require.NotNil(t, beta.Spec.Install.OLM)
+		assert.Equal(t, alpha.Spec.Install.OLMOwnNamespace.AddonInstallOLMCommon, *beta.Spec.Install.OLM)
+
+		resp = convertAddons(&apiextensionsv1.ConversionRequest{
+			UID:               "1235",
+			DesiredAPIVersion: addonsv1alpha1.GroupVersion.String(),
+			Objects:           resp.ConvertedObjects,
+		})
+		require.Equal(t, metav1.StatusSuccess, resp.Result.Status, resp.Result.Message)
+		require.Len(t, resp.ConvertedObjects, 1)
+
+		roundTripped := &addonsv1alpha1.Addon{}
+		require.NoError(t, json.Unmarshal(resp.ConvertedObjects[0].Raw, roundTripped))
+		assert.Equal(t, alpha, roundTripped)
+	})
+
+	t.Run("unsupported apiVersion", func(t *testing.T) {
+		resp := convertAddons(&apiextensionsv1.ConversionRequest{
+			UID:               "1236",
+			DesiredAPIVersion: "addons.managed.openshift.io/v2",
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		})
+		assert.Equal(t, metav1.StatusFailure, resp.Result.Status)
+		assert.Empty(t, resp.ConvertedObjects)
+	})
+}
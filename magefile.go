@@ -27,8 +27,10 @@ import (
 	olmversion "github.com/operator-framework/api/pkg/lib/version"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -352,9 +354,11 @@ func populateOLMBundleCache(imageCacheDir string) error {
 
 		{"cp", "-a", "config/olm/addon-operator.csv.yaml", manifestsDir},
 		{"cp", "-a", "config/olm/metrics.service.yaml", manifestsDir},
+		{"cp", "-a", "config/olm/alert-receiver.service.yaml", manifestsDir},
 		{"cp", "-a", "config/olm/addon-operator-servicemonitor.yaml", manifestsDir},
 		{"cp", "-a", "config/olm/prometheus-role.yaml", manifestsDir},
 		{"cp", "-a", "config/olm/prometheus-rb.yaml", manifestsDir},
+		{"cp", "-a", "config/olm/readiness-probes-clusterrole.yaml", manifestsDir},
 		{"cp", "-a", "config/olm/annotations.yaml", metadataDir},
 		{"cp", "-a", "config/olm/trusted_ca_bundle_configmap.yaml", manifestsDir},
 		// copy CRDs
@@ -1324,6 +1328,7 @@ func (d Dev) deployAddonOperatorManager(ctx context.Context, cluster *dev.Cluste
 		"config/deploy/addons.managed.openshift.io_addonoperators.yaml",
 		"config/deploy/addons.managed.openshift.io_addons.yaml",
 		"config/deploy/metrics.service.yaml",
+		"config/deploy/alert-receiver.service.yaml",
 		"config/deploy/rbac.yaml",
 		"config/deploy/trusted_ca_bundle_configmap.yaml",
 	}); err != nil {
@@ -1364,6 +1369,47 @@ func (d Dev) deployAddonOperatorWebhook(ctx context.Context, cluster *dev.Cluste
 	if err := cluster.CreateAndWaitForReadiness(ctx, deployment); err != nil {
 		return fmt.Errorf("deploy addon-operator-webhooks: %w", err)
 	}
+	if err := d.wireAddonConversionWebhook(ctx, cluster); err != nil {
+		return fmt.Errorf("wire Addon conversion webhook: %w", err)
+	}
+	return nil
+}
+
+// Wires the conversion webhook into the Addon CRD, as OLM does via the CSV,
+// so v1beta1 Addons are converted from and to the v1alpha1 storage version.
+func (d Dev) wireAddonConversionWebhook(ctx context.Context, cluster *dev.Cluster) error {
+	// The conversion webhook is served with the same certificate as the validating webhook.
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err := loadAndConvertIntoObject(cluster.Scheme, "config/deploy/webhook/validatingwebhookconfig.yaml", webhookConfig)
+	if err != nil {
+		return fmt.Errorf("loading validatingwebhookconfig.yaml: %w", err)
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := cluster.CtrlClient.Get(ctx, ctrlclient.ObjectKey{
+		Name: "addons.addons.managed.openshift.io",
+	}, crd); err != nil {
+		return fmt.Errorf("getting Addon CRD: %w", err)
+	}
+
+	convertPath := "/convert"
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: "addon-operator",
+					Name:      "webhook-service",
+					Path:      &convertPath,
+				},
+				CABundle: webhookConfig.Webhooks[0].ClientConfig.CABundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	if err := cluster.CtrlClient.Update(ctx, crd); err != nil {
+		return fmt.Errorf("updating Addon CRD: %w", err)
+	}
 	return nil
 }
 
@@ -1442,6 +1488,9 @@ func (d Dev) init() error {
 			"config/ocp/config-operator_01_proxy.crd.yaml",
 			"config/ocp/cluster-version.yaml",
 			"config/ocp/monitoring.coreos.com_servicemonitors.yaml",
+			"config/ocp/monitoring.coreos.com_prometheusrules.yaml",
+			"config/ocp/monitoring.coreos.com_podmonitors.yaml",
+			"config/ocp/monitoring.coreos.com_probes.yaml",
 
 			// OpenShift console to interact with OLM.
 			"hack/openshift-console.yaml",